@@ -0,0 +1,21 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"os"
+
+	"github.com/hyperledger/fabric/cmd/common"
+	"github.com/hyperledger/fabric/token/cmd"
+)
+
+func main() {
+	// The BCCSP factories are initialized when the MSP of the token client config is loaded
+	cli := common.NewCLI("token", "Command line client for fabric tokens")
+	token.AddCommands(cli)
+	cli.Run(os.Args[1:])
+}
@@ -32,6 +32,10 @@ const (
 	ApplicationResourcesTreeExperimental = "V1_1_RESOURCETREE_EXPERIMENTAL"
 
 	ApplicationFabTokenExperimental = "V1_4_FABTOKEN_EXPERIMENTAL"
+
+	// ApplicationFabTokenConfidentialExperimental is the capabilities string for fabric tokens
+	// whose quantities are hidden, which are managed by the confidential TMS.
+	ApplicationFabTokenConfidentialExperimental = "V2_0_FABTOKEN_CONFIDENTIAL_EXPERIMENTAL"
 )
 
 // ApplicationProvider provides capabilities information for application level config.
@@ -43,6 +47,7 @@ type ApplicationProvider struct {
 	v20                     bool
 	v11PvtDataExperimental  bool
 	v14FabTokenExperimental bool
+	v20FabTokenConfidential bool
 }
 
 // NewApplicationProvider creates a application capabilities provider.
@@ -55,6 +60,7 @@ func NewApplicationProvider(capabilities map[string]*cb.Capability) *Application
 	_, ap.v20 = capabilities[ApplicationV2_0]
 	_, ap.v11PvtDataExperimental = capabilities[ApplicationPvtDataExperimental]
 	_, ap.v14FabTokenExperimental = capabilities[ApplicationFabTokenExperimental]
+	_, ap.v20FabTokenConfidential = capabilities[ApplicationFabTokenConfidentialExperimental]
 	return ap
 }
 
@@ -128,7 +134,12 @@ func (ap *ApplicationProvider) KeyLevelEndorsement() bool {
 
 // FabToken returns true if support for fabric token functions is enabled.
 func (ap *ApplicationProvider) FabToken() bool {
-	return ap.v14FabTokenExperimental
+	return ap.v14FabTokenExperimental || ap.v20FabTokenConfidential
+}
+
+// ConfidentialFabToken returns true if the fabric tokens are managed by the confidential TMS.
+func (ap *ApplicationProvider) ConfidentialFabToken() bool {
+	return ap.v20FabTokenConfidential
 }
 
 // HasCapability returns true if the capability is supported by this binary.
@@ -149,6 +160,8 @@ func (ap *ApplicationProvider) HasCapability(capability string) bool {
 		return true
 	case ApplicationFabTokenExperimental:
 		return true
+	case ApplicationFabTokenConfidentialExperimental:
+		return true
 	default:
 		return false
 	}
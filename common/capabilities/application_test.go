@@ -83,6 +83,16 @@ func TestFabTokenExperimental(t *testing.T) {
 		ApplicationFabTokenExperimental: {},
 	})
 	assert.True(t, ap.FabToken())
+	assert.False(t, ap.ConfidentialFabToken())
+}
+
+func TestFabTokenConfidentialExperimental(t *testing.T) {
+	ap := NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationFabTokenConfidentialExperimental: {},
+	})
+	assert.True(t, ap.FabToken())
+	assert.True(t, ap.ConfidentialFabToken())
+	assert.True(t, ap.HasCapability(ApplicationFabTokenConfidentialExperimental))
 }
 
 func TestHasCapability(t *testing.T) {
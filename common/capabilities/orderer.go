@@ -18,18 +18,20 @@ const (
 
 	// OrdererV2_0 is the capabilities string that defines new Fabric v2.0 orderer capabilities.
 	//
-	// In particular, it defines whether the orderer supports a Kafka to Raft migration.
-	// A Kafka-based Ordering Service Node requires this in order to receive and process a config update with
-	// consensus-type migration commands. Migration is supported from Kafka to Raft only.
+	// In particular, it defines whether the orderer supports a Kafka or Solo to Raft migration.
+	// A Kafka-based or Solo-based Ordering Service Node requires this in order to receive and process a config update
+	// with consensus-type migration commands. Migration is supported from Kafka or Solo to Raft only.
 	// If not present, these config updates will be rejected.
+	// It also defines whether the orderer cuts batches early for the priority classes of the channel.
 	OrdererV2_0 = "V2_0"
 )
 
 // OrdererProvider provides capabilities information for orderer level config.
 type OrdererProvider struct {
 	*registry
-	v11BugFixes   bool
-	kafka2RaftMig bool
+	v11BugFixes      bool
+	kafka2RaftMig    bool
+	priorityBatching bool
 }
 
 // NewOrdererProvider creates an orderer capabilities provider.
@@ -38,6 +40,7 @@ func NewOrdererProvider(capabilities map[string]*cb.Capability) *OrdererProvider
 	cp.registry = newRegistry(cp, capabilities)
 	_, cp.v11BugFixes = capabilities[OrdererV1_1]
 	_, cp.kafka2RaftMig = capabilities[OrdererV2_0]
+	_, cp.priorityBatching = capabilities[OrdererV2_0]
 	return cp
 }
 
@@ -77,7 +80,12 @@ func (cp *OrdererProvider) ExpirationCheck() bool {
 	return cp.v11BugFixes
 }
 
-// Kafka2RaftMigration checks whether the orderer permits a Kafka to Raft migration.
+// Kafka2RaftMigration checks whether the orderer permits a Kafka or Solo to Raft migration.
 func (cp *OrdererProvider) Kafka2RaftMigration() bool {
 	return cp.kafka2RaftMig
 }
+
+// PriorityBatching checks whether the orderer cuts batches early for the priority classes of the channel.
+func (cp *OrdererProvider) PriorityBatching() bool {
+	return cp.priorityBatching
+}
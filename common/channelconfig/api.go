@@ -44,6 +44,9 @@ type Application interface {
 
 	// Capabilities defines the capabilities for the application portion of a channel
 	Capabilities() ApplicationCapabilities
+
+	// TokenTypes returns a map of token type to the definition of the token type
+	TokenTypes() map[string]*pb.TokenType
 }
 
 // Channel gives read only access to the channel configuration
@@ -98,6 +101,10 @@ type Orderer interface {
 	// BatchTimeout returns the amount of time to wait before creating a batch
 	BatchTimeout() time.Duration
 
+	// BatchTimeoutBounds returns the bounds within which the batch timeout adapts
+	// to the load of the channel, which are zero if the batch timeout does not adapt
+	BatchTimeoutBounds() (min, max time.Duration)
+
 	// MaxChannelsCount returns the maximum count of channels to allow for an ordering network
 	MaxChannelsCount() uint64
 
@@ -175,6 +182,9 @@ type ApplicationCapabilities interface {
 
 	// FabToken returns true if this channel supports FabToken functions
 	FabToken() bool
+
+	// ConfidentialFabToken returns true if the tokens of this channel are managed by the confidential TMS
+	ConfidentialFabToken() bool
 }
 
 // OrdererCapabilities defines the capabilities for the orderer portion of a channel
@@ -194,8 +204,11 @@ type OrdererCapabilities interface {
 	// when validating messages
 	ExpirationCheck() bool
 
-	// Kafka2RaftMigration checks whether the orderer permits a Kafka to Raft migration.
+	// Kafka2RaftMigration checks whether the orderer permits a Kafka or Solo to Raft migration.
 	Kafka2RaftMigration() bool
+
+	// PriorityBatching specifies whether the orderer cuts batches early for the priority classes of the channel.
+	PriorityBatching() bool
 }
 
 // PolicyMapper is an interface for
@@ -19,12 +19,16 @@ const (
 
 	// ACLsKey is the name of the ACLs config
 	ACLsKey = "ACLs"
+
+	// TokenTypesKey is the name of the token types config
+	TokenTypesKey = "TokenTypes"
 )
 
 // ApplicationProtos is used as the source of the ApplicationConfig
 type ApplicationProtos struct {
 	ACLs         *pb.ACLs
 	Capabilities *cb.Capabilities
+	TokenTypes   *pb.TokenTypes
 }
 
 // ApplicationConfig implements the Application interface
@@ -50,6 +54,22 @@ func NewApplicationConfig(appGroup *cb.ConfigGroup, mspConfig *MSPConfigHandler)
 		}
 	}
 
+	if !ac.Capabilities().FabToken() {
+		if _, ok := appGroup.Values[TokenTypesKey]; ok {
+			return nil, errors.New("TokenTypes may not be specified without the required capability")
+		}
+	}
+
+	if ac.Capabilities().ConfidentialFabToken() {
+		if _, ok := appGroup.Values[TokenTypesKey]; ok {
+			return nil, errors.New("TokenTypes may not be specified with the confidential token capability, which hides the quantities of the tokens")
+		}
+	}
+
+	if err := ac.validateTokenTypes(); err != nil {
+		return nil, err
+	}
+
 	var err error
 	for orgName, orgGroup := range appGroup.Groups {
 		ac.applicationOrgs[orgName], err = NewApplicationOrgConfig(orgName, orgGroup, mspConfig)
@@ -77,3 +97,23 @@ func (ac *ApplicationConfig) APIPolicyMapper() PolicyMapper {
 
 	return pm
 }
+
+// TokenTypes returns a map of token type to the definition of the token type
+func (ac *ApplicationConfig) TokenTypes() map[string]*pb.TokenType {
+	return ac.protos.TokenTypes.TokenTypes
+}
+
+func (ac *ApplicationConfig) validateTokenTypes() error {
+	for tokenType, definition := range ac.protos.TokenTypes.TokenTypes {
+		if definition == nil {
+			return errors.Errorf("token type %s has no definition", tokenType)
+		}
+		if definition.Issuer == "" {
+			return errors.Errorf("token type %s has no issuer", tokenType)
+		}
+		if definition.MaxSupply == 0 {
+			return errors.Errorf("token type %s has no maximum supply", tokenType)
+		}
+	}
+	return nil
+}
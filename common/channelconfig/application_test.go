@@ -12,6 +12,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/capabilities"
 	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 	. "github.com/onsi/gomega"
 )
@@ -52,3 +53,76 @@ func TestACL(t *testing.T) {
 		g.Expect(err).To(MatchError("ACLs may not be specified without the required capability"))
 	})
 }
+
+func TestTokenTypes(t *testing.T) {
+	g := NewGomegaWithT(t)
+	tokenTypes := map[string]*pb.TokenType{
+		"USD": {MaxSupply: 1000, Decimals: 2, Issuer: "Org1MSP"},
+	}
+	cgt := &cb.ConfigGroup{
+		Values: map[string]*cb.ConfigValue{
+			TokenTypesKey: {
+				Value: utils.MarshalOrPanic(
+					TokenTypesValue(tokenTypes).Value(),
+				),
+			},
+			CapabilitiesKey: {
+				Value: utils.MarshalOrPanic(
+					CapabilitiesValue(map[string]bool{
+						capabilities.ApplicationFabTokenExperimental: true,
+					}).Value(),
+				),
+			},
+		},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		cg := proto.Clone(cgt).(*cb.ConfigGroup)
+		ac, err := NewApplicationConfig(cg, nil)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(ac.TokenTypes()).To(HaveLen(1))
+		g.Expect(proto.Equal(ac.TokenTypes()["USD"], tokenTypes["USD"])).To(BeTrue())
+	})
+
+	t.Run("NoTokenTypes", func(t *testing.T) {
+		cg := proto.Clone(cgt).(*cb.ConfigGroup)
+		delete(cg.Values, TokenTypesKey)
+		ac, err := NewApplicationConfig(cg, nil)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(ac.TokenTypes()).To(BeEmpty())
+	})
+
+	t.Run("MissingCapability", func(t *testing.T) {
+		cg := proto.Clone(cgt).(*cb.ConfigGroup)
+		delete(cg.Values, CapabilitiesKey)
+		_, err := NewApplicationConfig(cg, nil)
+		g.Expect(err).To(MatchError("TokenTypes may not be specified without the required capability"))
+	})
+
+	t.Run("ConfidentialCapability", func(t *testing.T) {
+		cg := proto.Clone(cgt).(*cb.ConfigGroup)
+		cg.Values[CapabilitiesKey].Value = utils.MarshalOrPanic(CapabilitiesValue(map[string]bool{
+			capabilities.ApplicationFabTokenConfidentialExperimental: true,
+		}).Value())
+		_, err := NewApplicationConfig(cg, nil)
+		g.Expect(err).To(MatchError("TokenTypes may not be specified with the confidential token capability, which hides the quantities of the tokens"))
+	})
+
+	t.Run("MissingIssuer", func(t *testing.T) {
+		cg := proto.Clone(cgt).(*cb.ConfigGroup)
+		cg.Values[TokenTypesKey].Value = utils.MarshalOrPanic(TokenTypesValue(map[string]*pb.TokenType{
+			"USD": {MaxSupply: 1000},
+		}).Value())
+		_, err := NewApplicationConfig(cg, nil)
+		g.Expect(err).To(MatchError("token type USD has no issuer"))
+	})
+
+	t.Run("MissingMaxSupply", func(t *testing.T) {
+		cg := proto.Clone(cgt).(*cb.ConfigGroup)
+		cg.Values[TokenTypesKey].Value = utils.MarshalOrPanic(TokenTypesValue(map[string]*pb.TokenType{
+			"USD": {Issuer: "Org1MSP"},
+		}).Value())
+		_, err := NewApplicationConfig(cg, nil)
+		g.Expect(err).To(MatchError("token type USD has no maximum supply"))
+	})
+}
@@ -130,6 +130,14 @@ func (b *Bundle) ValidateNew(nb Resources) error {
 				return errors.Errorf("application org %s attempted to change MSP ID from %s to %s", orgName, mspID, norg.MSPID())
 			}
 		}
+
+		// The outputs of each TMS can only be spent by the same TMS, hence switching
+		// between the plain and the confidential TMS would strand the existing tokens
+		if ac.Capabilities().FabToken() && nac.Capabilities().FabToken() &&
+			ac.Capabilities().ConfidentialFabToken() != nac.Capabilities().ConfidentialFabToken() {
+			return errors.Errorf("attempted to change the TMS of a channel with tokens, confidential from %t to %t",
+				ac.Capabilities().ConfidentialFabToken(), nac.Capabilities().ConfidentialFabToken())
+		}
 	}
 
 	if cc, ok := b.ConsortiumsConfig(); ok {
@@ -259,15 +267,17 @@ func preValidate(config *cb.Config) error {
 // system vs. standard channels. The migration state machine (for both types of channels) is enforced
 // in the chain implementation, after ordering.
 //
-// Consensus-type changes from Kafka to Raft in the "green" path:
+// Consensus-type changes from Kafka or Solo to Raft in the "green" path:
 // - The system channel starts the migration
-// - A standard channel prepares the context, type change Kafka to Raft
-// - The system channel commits the migration, type change Kafka to Raft
-// Consensus-type changes from Raft to Kafka in the "abort" path:
+// - A standard channel prepares the context, type change Kafka/Solo to Raft
+// - The system channel commits the migration, type change Kafka/Solo to Raft
+// Consensus-type changes from Raft to Kafka or Solo in the "abort" path:
 // - The system channel starts the migration
-// - A standard channel prepares the context, type change Kafka to Raft
+// - A standard channel prepares the context, type change Kafka/Solo to Raft
 // - The system channel aborts the migration
-// - The standard channel reverts the type back, type change Raft to Kafka
+// - The standard channel reverts the type back, type change Raft to Kafka/Solo
+// - The system channel reconfigures after the abort, or starts a new migration attempt
+// A standard channel that had not prepared the context when the migration was aborted needs no revert.
 //
 func (b *Bundle) validateMigrationStep(oc Orderer, noc Orderer) error {
 	oldType := oc.ConsensusType()
@@ -300,8 +310,8 @@ func validateMigrationStepSystem(oldType, newType string, oldState, newState ab.
 			return errors.Errorf(unExpCtx, newState, newContext, "0")
 		}
 	case ab.ConsensusType_MIG_STATE_START:
-		if newType != "kafka" {
-			return errors.Errorf(unExpType, newState, newType, "kafka")
+		if !isMigrationSourceType(newType) {
+			return errors.Errorf(unExpType, newState, newType, "kafka or solo")
 		}
 		if newContext != 0 {
 			return errors.Errorf(unExpCtx, newState, newContext, "0")
@@ -314,8 +324,8 @@ func validateMigrationStepSystem(oldType, newType string, oldState, newState ab.
 			return errors.Errorf(unExpCtx, newState, newContext, ">0")
 		}
 	case ab.ConsensusType_MIG_STATE_ABORT:
-		if newType != "kafka" {
-			return errors.Errorf(unExpType, newState, newType, "kafka")
+		if !isMigrationSourceType(newType) {
+			return errors.Errorf(unExpType, newState, newType, "kafka or solo")
 		}
 		if newContext <= 0 {
 			return errors.Errorf(unExpCtx, newState, newContext, ">0")
@@ -328,14 +338,14 @@ func validateMigrationStepSystem(oldType, newType string, oldState, newState ab.
 	// The following code explicitly checks for permitted transitions; all other transitions return an error.
 	if oldType != newType {
 
-		if oldType == "kafka" && newType == "etcdraft" {
+		if isMigrationSourceType(oldType) && newType == "etcdraft" {
 			// On the system channels, this is permitted, green path commit
 			isSysCommit := (oldState == ab.ConsensusType_MIG_STATE_START) && (newState == ab.ConsensusType_MIG_STATE_COMMIT)
 			if !isSysCommit {
 				return errors.Errorf("Attempted to change consensus type from %s to %s, unexpected state transition: %s to %s",
 					oldType, newType, oldState, newState)
 			}
-		} else if oldType == "etcdraft" && newType == "kafka" {
+		} else if oldType == "etcdraft" && isMigrationSourceType(newType) {
 			return errors.Errorf("Attempted to change consensus type from %s to %s, not permitted on system channel", oldType, newType)
 		} else {
 			return errors.Errorf("Attempted to change consensus type from %s to %s, not supported", oldType, newType)
@@ -351,7 +361,7 @@ func validateMigrationStepSystem(oldType, newType string, oldState, newState ab.
 		}
 
 		// Migration state may change when the type stays the same
-		if oldType == "kafka" {
+		if isMigrationSourceType(oldType) {
 			// In the "green" path: the system channel starts migration
 			isStart := (oldState == ab.ConsensusType_MIG_STATE_NONE) && (newState == ab.ConsensusType_MIG_STATE_START)
 			// In the "abort" path: the system channel aborts a migration
@@ -404,13 +414,13 @@ func validateMigrationStepStandard(oldType, newType string, oldState, newState a
 	// The following code explicitly checks for permitted transitions; all other transitions return an error.
 	if oldType != newType {
 		badAttemptStr := "Attempted to change consensus type from %s to %s, unexpected state transition: %s to %s"
-		if oldType == "kafka" && newType == "etcdraft" {
+		if isMigrationSourceType(oldType) && newType == "etcdraft" {
 			// On the standard channels, this is permitted, green path context
 			isCtx := (oldState == ab.ConsensusType_MIG_STATE_NONE) && (newState == ab.ConsensusType_MIG_STATE_CONTEXT)
 			if !isCtx {
 				return errors.Errorf(badAttemptStr, oldType, newType, oldState, newState)
 			}
-		} else if oldType == "etcdraft" && newType == "kafka" {
+		} else if oldType == "etcdraft" && isMigrationSourceType(newType) {
 			// On the standard channels, this is permitted, abort path
 			isAbort := (oldState == ab.ConsensusType_MIG_STATE_CONTEXT) && (newState == ab.ConsensusType_MIG_STATE_NONE)
 			if !isAbort {
@@ -439,7 +449,7 @@ func validateMigrationStepStandard(oldType, newType string, oldState, newState a
 			if !(isNotMig || isConfigAfterSuccess || isCtxAmend) {
 				return errors.Errorf("Consensus type %s, unexpected migration state transition: %s to %s", oldType, oldState, newState)
 			}
-		} else if oldType == "kafka" {
+		} else if isMigrationSourceType(oldType) {
 			// Not a migration
 			if !isNotMig {
 				return errors.Errorf("Consensus type %s, unexpected migration state transition: %s to %s", oldType, oldState, newState)
@@ -451,3 +461,8 @@ func validateMigrationStepStandard(oldType, newType string, oldState, newState a
 
 	return nil
 }
+
+// isMigrationSourceType tells whether a channel of the given consensus type can be migrated to Raft
+func isMigrationSourceType(consensusType string) bool {
+	return consensusType == "kafka" || consensusType == "solo"
+}
@@ -219,6 +219,37 @@ func TestValidateNew(t *testing.T) {
 		assert.Error(t, err)
 		assert.Regexp(t, "consortium consortium1 org org3 attempted to change MSP ID from", err.Error())
 	})
+
+	t.Run("TMSChange", func(t *testing.T) {
+		appBundle := func(capabilities ...string) *Bundle {
+			caps := map[string]*cb.Capability{}
+			for _, capability := range capabilities {
+				caps[capability] = &cb.Capability{}
+			}
+			return &Bundle{
+				channelConfig: &ChannelConfig{
+					appConfig: &ApplicationConfig{
+						protos: &ApplicationProtos{Capabilities: &cb.Capabilities{Capabilities: caps}},
+					},
+				},
+			}
+		}
+
+		plain := appBundle(cc.ApplicationV1_2, cc.ApplicationFabTokenExperimental)
+		confidential := appBundle(cc.ApplicationV1_2, cc.ApplicationFabTokenExperimental, cc.ApplicationFabTokenConfidentialExperimental)
+
+		err := plain.ValidateNew(confidential)
+		assert.EqualError(t, err, "attempted to change the TMS of a channel with tokens, confidential from false to true")
+
+		err = confidential.ValidateNew(plain)
+		assert.EqualError(t, err, "attempted to change the TMS of a channel with tokens, confidential from true to false")
+
+		// Channels without tokens may enable either TMS
+		err = appBundle(cc.ApplicationV1_2).ValidateNew(confidential)
+		assert.NoError(t, err)
+		err = plain.ValidateNew(plain)
+		assert.NoError(t, err)
+	})
 }
 
 func TestValidateNewWithConsensusMigration(t *testing.T) {
@@ -298,6 +329,36 @@ func TestValidateNewWithConsensusMigration(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("ConsensusTypeMigration Green Path from Solo", func(t *testing.T) {
+		b1 := generateMigrationBundle(true, "solo", ab.ConsensusType_MIG_STATE_NONE, 0)
+		b2 := generateMigrationBundle(true, "solo", ab.ConsensusType_MIG_STATE_START, 0)
+		assert.NoError(t, b1.ValidateNew(b2), "system channel start")
+
+		b3 := generateMigrationBundle(true, "etcdraft", ab.ConsensusType_MIG_STATE_COMMIT, 4)
+		assert.NoError(t, b2.ValidateNew(b3), "system channel commit")
+
+		s1 := generateMigrationBundle(false, "solo", ab.ConsensusType_MIG_STATE_NONE, 0)
+		s2 := generateMigrationBundle(false, "etcdraft", ab.ConsensusType_MIG_STATE_CONTEXT, 4)
+		assert.NoError(t, s1.ValidateNew(s2), "standard channel context")
+	})
+
+	t.Run("ConsensusTypeMigration Abort Path from Solo", func(t *testing.T) {
+		b1 := generateMigrationBundle(true, "solo", ab.ConsensusType_MIG_STATE_START, 0)
+		b2 := generateMigrationBundle(true, "solo", ab.ConsensusType_MIG_STATE_ABORT, 4)
+		assert.NoError(t, b1.ValidateNew(b2), "system channel abort")
+
+		b3 := generateMigrationBundle(true, "solo", ab.ConsensusType_MIG_STATE_NONE, 0)
+		assert.NoError(t, b2.ValidateNew(b3), "system channel config after abort")
+
+		s1 := generateMigrationBundle(false, "etcdraft", ab.ConsensusType_MIG_STATE_CONTEXT, 4)
+		s2 := generateMigrationBundle(false, "solo", ab.ConsensusType_MIG_STATE_NONE, 0)
+		assert.NoError(t, s1.ValidateNew(s2), "standard channel revert")
+
+		b4 := generateMigrationBundle(true, "etcdraft", ab.ConsensusType_MIG_STATE_NONE, 0)
+		assert.EqualError(t, b4.ValidateNew(b3),
+			"Attempted to change consensus type from etcdraft to solo, not permitted on system channel")
+	})
+
 	t.Run("ConsensusTypeMigration Bad Transitions on System Channel, from NONE", func(t *testing.T) {
 		b1 := generateMigrationBundle(true, "kafka", ab.ConsensusType_MIG_STATE_NONE, 0)
 		b2 := generateMigrationBundle(true, "etcdraft", ab.ConsensusType_MIG_STATE_COMMIT, 4)
@@ -313,7 +374,7 @@ func TestValidateNewWithConsensusMigration(t *testing.T) {
 		updateConsensusType(b2, "etcdraft", ab.ConsensusType_MIG_STATE_ABORT, 0)
 		err = b1.ValidateNew(b2)
 		assert.EqualError(t, err,
-			"Consensus-type migration, state=MIG_STATE_ABORT, unexpected type, actual=etcdraft (expected=kafka)")
+			"Consensus-type migration, state=MIG_STATE_ABORT, unexpected type, actual=etcdraft (expected=kafka or solo)")
 
 		updateConsensusType(b2, "kafka", ab.ConsensusType_MIG_STATE_NONE, 7)
 		err = b1.ValidateNew(b2)
@@ -351,7 +412,7 @@ func TestValidateNewWithConsensusMigration(t *testing.T) {
 		updateConsensusType(b2, "etcdraft", ab.ConsensusType_MIG_STATE_ABORT, 0)
 		err = b1.ValidateNew(b2)
 		assert.EqualError(t, err,
-			"Consensus-type migration, state=MIG_STATE_ABORT, unexpected type, actual=etcdraft (expected=kafka)")
+			"Consensus-type migration, state=MIG_STATE_ABORT, unexpected type, actual=etcdraft (expected=kafka or solo)")
 
 		updateConsensusType(b2, "etcdraft", ab.ConsensusType_MIG_STATE_COMMIT, 0)
 		err = b1.ValidateNew(b2)
@@ -374,12 +435,12 @@ func TestValidateNewWithConsensusMigration(t *testing.T) {
 		updateConsensusType(b2, "etcdraft", ab.ConsensusType_MIG_STATE_START, 0)
 		err = b1.ValidateNew(b2)
 		assert.EqualError(t, err,
-			"Consensus-type migration, state=MIG_STATE_START, unexpected type, actual=etcdraft (expected=kafka)")
+			"Consensus-type migration, state=MIG_STATE_START, unexpected type, actual=etcdraft (expected=kafka or solo)")
 
 		updateConsensusType(b2, "etcdraft", ab.ConsensusType_MIG_STATE_ABORT, 0)
 		err = b1.ValidateNew(b2)
 		assert.EqualError(t, err,
-			"Consensus-type migration, state=MIG_STATE_ABORT, unexpected type, actual=etcdraft (expected=kafka)")
+			"Consensus-type migration, state=MIG_STATE_ABORT, unexpected type, actual=etcdraft (expected=kafka or solo)")
 
 		updateConsensusType(b2, "kafka", ab.ConsensusType_MIG_STATE_NONE, 0)
 		err = b1.ValidateNew(b2)
@@ -472,18 +533,19 @@ func TestValidateNewWithConsensusMigration(t *testing.T) {
 		err = b1.ValidateNew(b2)
 		assert.EqualError(t, err, "Consensus-type migration, state=MIG_STATE_ABORT, not permitted on standard channel")
 
-		updateConsensusType(b2, "solo", ab.ConsensusType_MIG_STATE_NONE, 0)
+		updateConsensusType(b2, "foo-bar", ab.ConsensusType_MIG_STATE_NONE, 0)
 		err = b1.ValidateNew(b2)
-		assert.EqualError(t, err, "Attempted to change consensus type from etcdraft to solo, not supported")
+		assert.EqualError(t, err, "Attempted to change consensus type from etcdraft to foo-bar, not supported")
 	})
 
 	t.Run("ConsensusTypeMigration unsupported types", func(t *testing.T) {
-		b1 := generateMigrationBundle(true, "solo", ab.ConsensusType_MIG_STATE_NONE, 0)
-		b2 := generateMigrationBundle(true, "solo", ab.ConsensusType_MIG_STATE_START, 0)
+		b1 := generateMigrationBundle(true, "foo-bar", ab.ConsensusType_MIG_STATE_NONE, 0)
+		b2 := generateMigrationBundle(true, "foo-bar", ab.ConsensusType_MIG_STATE_START, 0)
 		err := b1.ValidateNew(b2)
 		assert.EqualError(t, err,
-			"Consensus-type migration, state=MIG_STATE_START, unexpected type, actual=solo (expected=kafka)")
+			"Consensus-type migration, state=MIG_STATE_START, unexpected type, actual=foo-bar (expected=kafka or solo)")
 
+		updateConsensusType(b1, "solo", ab.ConsensusType_MIG_STATE_NONE, 0)
 		updateConsensusType(b2, "kafka", ab.ConsensusType_MIG_STATE_START, 0)
 		err = b1.ValidateNew(b2)
 		assert.EqualError(t, err,
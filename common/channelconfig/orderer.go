@@ -56,7 +56,9 @@ type OrdererConfig struct {
 	protos *OrdererProtos
 	orgs   map[string]Org
 
-	batchTimeout time.Duration
+	batchTimeout    time.Duration
+	minBatchTimeout time.Duration
+	maxBatchTimeout time.Duration
 }
 
 // NewOrdererConfig creates a new instance of the orderer config.
@@ -113,6 +115,12 @@ func (oc *OrdererConfig) BatchTimeout() time.Duration {
 	return oc.batchTimeout
 }
 
+// BatchTimeoutBounds returns the bounds within which the batch timeout adapts to the load
+// of the channel, which are zero if the batch timeout does not adapt.
+func (oc *OrdererConfig) BatchTimeoutBounds() (min, max time.Duration) {
+	return oc.minBatchTimeout, oc.maxBatchTimeout
+}
+
 // KafkaBrokers returns the addresses (IP:port notation) of a set of "bootstrap"
 // Kafka brokers, i.e. this is not necessarily the entire set of Kafka brokers
 // used for ordering.
@@ -162,6 +170,25 @@ func (oc *OrdererConfig) validateBatchSize() error {
 	if oc.protos.BatchSize.PreferredMaxBytes > oc.protos.BatchSize.AbsoluteMaxBytes {
 		return fmt.Errorf("Attempted to set the batch size preferred max bytes (%v) greater than the absolute max bytes (%v).", oc.protos.BatchSize.PreferredMaxBytes, oc.protos.BatchSize.AbsoluteMaxBytes)
 	}
+	if len(oc.protos.BatchSize.PriorityClasses) > 0 && !oc.Capabilities().PriorityBatching() {
+		return fmt.Errorf("Attempted to set priority classes without the %s orderer capability", capabilities.OrdererV2_0)
+	}
+	names := make(map[string]struct{})
+	for _, class := range oc.protos.BatchSize.PriorityClasses {
+		if class.Name == "" {
+			return fmt.Errorf("Attempted to set a priority class without a name")
+		}
+		if _, exists := names[class.Name]; exists {
+			return fmt.Errorf("Attempted to set the priority class %s more than once", class.Name)
+		}
+		names[class.Name] = struct{}{}
+		if len(class.HeaderTypes) == 0 && class.MinPriority == 0 && class.Policy == "" {
+			return fmt.Errorf("Attempted to set the priority class %s without header types, min priority or policy", class.Name)
+		}
+		if class.MaxMessageCount > oc.protos.BatchSize.MaxMessageCount {
+			return fmt.Errorf("Attempted to set the max message count (%v) of the priority class %s greater than the batch size max message count (%v)", class.MaxMessageCount, class.Name, oc.protos.BatchSize.MaxMessageCount)
+		}
+	}
 	return nil
 }
 
@@ -174,6 +201,24 @@ func (oc *OrdererConfig) validateBatchTimeout() error {
 	if oc.batchTimeout <= 0 {
 		return fmt.Errorf("Attempted to set the batch timeout to a non-positive value: %s", oc.batchTimeout)
 	}
+	if oc.protos.BatchTimeout.MinTimeout == "" && oc.protos.BatchTimeout.MaxTimeout == "" {
+		oc.minBatchTimeout, oc.maxBatchTimeout = 0, 0
+		return nil
+	}
+	oc.minBatchTimeout, err = time.ParseDuration(oc.protos.BatchTimeout.MinTimeout)
+	if err != nil {
+		return fmt.Errorf("Attempted to set the min batch timeout to an invalid value: %s", err)
+	}
+	oc.maxBatchTimeout, err = time.ParseDuration(oc.protos.BatchTimeout.MaxTimeout)
+	if err != nil {
+		return fmt.Errorf("Attempted to set the max batch timeout to an invalid value: %s", err)
+	}
+	if oc.minBatchTimeout <= 0 {
+		return fmt.Errorf("Attempted to set the min batch timeout to a non-positive value: %s", oc.minBatchTimeout)
+	}
+	if oc.minBatchTimeout > oc.batchTimeout || oc.batchTimeout > oc.maxBatchTimeout {
+		return fmt.Errorf("Attempted to set the batch timeout (%s) outside of the min batch timeout (%s) and the max batch timeout (%s)", oc.batchTimeout, oc.minBatchTimeout, oc.maxBatchTimeout)
+	}
 	return nil
 }
 
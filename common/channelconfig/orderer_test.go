@@ -8,7 +8,10 @@ package channelconfig
 
 import (
 	"testing"
+	"time"
 
+	"github.com/hyperledger/fabric/common/capabilities"
+	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 	"github.com/stretchr/testify/assert"
 )
@@ -31,6 +34,45 @@ func TestBatchSize(t *testing.T) {
 	assert.Error(t, oc.validateBatchSize(), "PreferredMaxBytes larger to AbsoluteMaxBytes")
 }
 
+func TestBatchSizePriorityClasses(t *testing.T) {
+	batchSize := func(classes ...*ab.PriorityClass) *ab.BatchSize {
+		return &ab.BatchSize{MaxMessageCount: 10, AbsoluteMaxBytes: 1000, PreferredMaxBytes: 500, PriorityClasses: classes}
+	}
+	v20 := &cb.Capabilities{Capabilities: map[string]*cb.Capability{capabilities.OrdererV2_0: {}}}
+
+	oc := &OrdererConfig{protos: &OrdererProtos{Capabilities: v20, BatchSize: batchSize(
+		&ab.PriorityClass{Name: "config", HeaderTypes: []cb.HeaderType{cb.HeaderType_CONFIG}},
+		&ab.PriorityClass{Name: "urgent", MinPriority: 1, Policy: "/Channel/Application/Admins", MaxMessageCount: 5},
+	)}}
+	assert.NoError(t, oc.validateBatchSize(), "Valid priority classes")
+
+	oc = &OrdererConfig{protos: &OrdererProtos{Capabilities: &cb.Capabilities{}, BatchSize: batchSize(
+		&ab.PriorityClass{Name: "urgent", MinPriority: 1},
+	)}}
+	assert.EqualError(t, oc.validateBatchSize(), "Attempted to set priority classes without the V2_0 orderer capability")
+
+	oc = &OrdererConfig{protos: &OrdererProtos{Capabilities: v20, BatchSize: batchSize(
+		&ab.PriorityClass{MinPriority: 1},
+	)}}
+	assert.Error(t, oc.validateBatchSize(), "Priority class without a name")
+
+	oc = &OrdererConfig{protos: &OrdererProtos{Capabilities: v20, BatchSize: batchSize(
+		&ab.PriorityClass{Name: "urgent", MinPriority: 1},
+		&ab.PriorityClass{Name: "urgent", MinPriority: 2},
+	)}}
+	assert.Error(t, oc.validateBatchSize(), "Duplicate priority class")
+
+	oc = &OrdererConfig{protos: &OrdererProtos{Capabilities: v20, BatchSize: batchSize(
+		&ab.PriorityClass{Name: "urgent"},
+	)}}
+	assert.Error(t, oc.validateBatchSize(), "Priority class without criteria")
+
+	oc = &OrdererConfig{protos: &OrdererProtos{Capabilities: v20, BatchSize: batchSize(
+		&ab.PriorityClass{Name: "urgent", MinPriority: 1, MaxMessageCount: 11},
+	)}}
+	assert.Error(t, oc.validateBatchSize(), "Priority class max message count larger than MaxMessageCount")
+}
+
 func TestBatchTimeout(t *testing.T) {
 	oc := &OrdererConfig{protos: &OrdererProtos{BatchTimeout: &ab.BatchTimeout{Timeout: "1s"}}}
 	assert.NoError(t, oc.validateBatchTimeout(), "Valid batch timeout")
@@ -40,6 +82,21 @@ func TestBatchTimeout(t *testing.T) {
 
 	oc = &OrdererConfig{protos: &OrdererProtos{BatchTimeout: &ab.BatchTimeout{Timeout: "0s"}}}
 	assert.Error(t, oc.validateBatchTimeout(), "Zero batch timeout")
+
+	oc = &OrdererConfig{protos: &OrdererProtos{BatchTimeout: &ab.BatchTimeout{Timeout: "1s", MinTimeout: "100ms", MaxTimeout: "5s"}}}
+	assert.NoError(t, oc.validateBatchTimeout(), "Valid adaptive batch timeout")
+	min, max := oc.BatchTimeoutBounds()
+	assert.Equal(t, 100*time.Millisecond, min)
+	assert.Equal(t, 5*time.Second, max)
+
+	oc = &OrdererConfig{protos: &OrdererProtos{BatchTimeout: &ab.BatchTimeout{Timeout: "1s", MinTimeout: "100ms"}}}
+	assert.Error(t, oc.validateBatchTimeout(), "Missing max batch timeout")
+
+	oc = &OrdererConfig{protos: &OrdererProtos{BatchTimeout: &ab.BatchTimeout{Timeout: "1s", MinTimeout: "0s", MaxTimeout: "5s"}}}
+	assert.Error(t, oc.validateBatchTimeout(), "Zero min batch timeout")
+
+	oc = &OrdererConfig{protos: &OrdererProtos{BatchTimeout: &ab.BatchTimeout{Timeout: "10s", MinTimeout: "100ms", MaxTimeout: "5s"}}}
+	assert.Error(t, oc.validateBatchTimeout(), "Batch timeout larger than max batch timeout")
 }
 
 func TestKafkaBrokers(t *testing.T) {
@@ -128,6 +128,21 @@ func BatchSizeValue(maxMessages, absoluteMaxBytes, preferredMaxBytes uint32) *St
 	}
 }
 
+// PriorityBatchSizeValue returns the config definition for the orderer batch size
+// with the given priority classes, whose transactions force an early cut of a batch.
+// It is a value for the /Channel/Orderer group.
+func PriorityBatchSizeValue(maxMessages, absoluteMaxBytes, preferredMaxBytes uint32, priorityClasses []*ab.PriorityClass) *StandardConfigValue {
+	return &StandardConfigValue{
+		key: BatchSizeKey,
+		value: &ab.BatchSize{
+			MaxMessageCount:   maxMessages,
+			AbsoluteMaxBytes:  absoluteMaxBytes,
+			PreferredMaxBytes: preferredMaxBytes,
+			PriorityClasses:   priorityClasses,
+		},
+	}
+}
+
 // BatchTimeoutValue returns the config definition for the orderer batch timeout.
 // It is a value for the /Channel/Orderer group.
 func BatchTimeoutValue(timeout string) *StandardConfigValue {
@@ -225,6 +240,15 @@ func ACLValues(acls map[string]string) *StandardConfigValue {
 	}
 }
 
+// TokenTypesValue returns the config definition for the token types of an application.
+// It is a value for the /Channel/Application/.
+func TokenTypesValue(tokenTypes map[string]*pb.TokenType) *StandardConfigValue {
+	return &StandardConfigValue{
+		key:   TokenTypesKey,
+		value: &pb.TokenTypes{TokenTypes: tokenTypes},
+	}
+}
+
 // ValidateCapabilities validates whether the peer can meet the capabilities requirement in the given config block
 func ValidateCapabilities(block *cb.Block) error {
 	envelopeConfig, err := utils.ExtractEnvelope(block, 0)
@@ -273,3 +297,17 @@ func ValidateCapabilities(block *cb.Block) error {
 
 	return nil
 }
+
+// AdaptiveBatchTimeoutValue returns the config definition for the orderer batch timeout,
+// which adapts to the load of the channel between the given minimum and maximum.
+// It is a value for the /Channel/Orderer group.
+func AdaptiveBatchTimeoutValue(timeout, minTimeout, maxTimeout string) *StandardConfigValue {
+	return &StandardConfigValue{
+		key: BatchTimeoutKey,
+		value: &ab.BatchTimeout{
+			Timeout:    timeout,
+			MinTimeout: minTimeout,
+			MaxTimeout: maxTimeout,
+		},
+	}
+}
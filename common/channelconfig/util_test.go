@@ -38,6 +38,8 @@ func TestUtilsBasic(t *testing.T) {
 	basicTest(t, ConsensusTypeValue("foo", []byte("bar")))
 	basicTest(t, BatchSizeValue(1, 2, 3))
 	basicTest(t, BatchTimeoutValue("1s"))
+	basicTest(t, PriorityBatchSizeValue(1, 2, 3, []*ab.PriorityClass{{Name: "foo", MinPriority: 1}}))
+	basicTest(t, AdaptiveBatchTimeoutValue("2s", "1s", "5s"))
 	basicTest(t, ChannelRestrictionsValue(7))
 	basicTest(t, KafkaBrokersValue([]string{"foo:1", "bar:2"}))
 	basicTest(t, MSPValue(&mspprotos.MSPConfig{}))
@@ -22,6 +22,7 @@ import (
 	"github.com/hyperledger/fabric/core/comm"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
 )
@@ -109,6 +110,12 @@ type ResponseSender interface {
 	SendBlockResponse(block *cb.Block) error
 }
 
+// FilteredBlockResponseSender is implemented by the response senders which
+// are able to send filtered blocks, when they are requested by the seek info.
+type FilteredBlockResponseSender interface {
+	SendFilteredBlockResponse(filteredBlock *peer.FilteredBlock) error
+}
+
 // Filtered is a marker interface that indicates a response sender
 // is configured to send filtered blocks
 type Filtered interface {
@@ -258,6 +265,11 @@ func (h *Handler) deliverBlocks(ctx context.Context, srv *Server, envelope *cb.E
 		return cb.Status_BAD_REQUEST, nil
 	}
 
+	if err := checkContentType(srv, seekInfo.ContentType); err != nil {
+		logger.Warningf("[channel: %s] Received seekInfo message from %s with unsupported content type: %s", chdr.ChannelId, addr, err)
+		return cb.Status_BAD_REQUEST, nil
+	}
+
 	logger.Debugf("[channel: %s] Received seekInfo (%p) %v from %s", chdr.ChannelId, seekInfo, seekInfo, addr)
 
 	cursor, number := chain.Reader().Iterator(seekInfo.Start)
@@ -326,7 +338,20 @@ func (h *Handler) deliverBlocks(ctx context.Context, srv *Server, envelope *cb.E
 
 		logger.Debugf("[channel: %s] Delivering block [%d] for (%p) for %s", chdr.ChannelId, block.Header.Number, seekInfo, addr)
 
-		if err := srv.SendBlockResponse(block); err != nil {
+		switch {
+		case seekInfo.ContentType == ab.SeekInfo_HEADER_WITH_SIG:
+			err = srv.SendBlockResponse(HeaderWithSig(block))
+		case seekInfo.ContentType == ab.SeekInfo_FILTERED && !isFiltered(srv):
+			filteredBlock, ferr := FilterBlock(block)
+			if ferr != nil {
+				logger.Warningf("[channel: %s] Failed to filter block [%d] for %s: %s", chdr.ChannelId, block.Header.Number, addr, ferr)
+				return cb.Status_INTERNAL_SERVER_ERROR, nil
+			}
+			err = srv.ResponseSender.(FilteredBlockResponseSender).SendFilteredBlockResponse(filteredBlock)
+		default:
+			err = srv.SendBlockResponse(block)
+		}
+		if err != nil {
 			logger.Warningf("[channel: %s] Error sending to %s: %s", chdr.ChannelId, addr, err)
 			return cb.Status_INTERNAL_SERVER_ERROR, err
 		}
@@ -343,6 +368,28 @@ func (h *Handler) deliverBlocks(ctx context.Context, srv *Server, envelope *cb.E
 	return cb.Status_SUCCESS, nil
 }
 
+// checkContentType checks that the given server is able to send the given content type.
+// A server which is configured to send filtered blocks sends them for the FILTERED
+// content type, and does not send the headers of the blocks.
+func checkContentType(srv *Server, contentType ab.SeekInfo_SeekContentType) error {
+	switch contentType {
+	case ab.SeekInfo_BLOCK:
+		return nil
+	case ab.SeekInfo_HEADER_WITH_SIG:
+		if isFiltered(srv) {
+			return errors.New("headers are not delivered by a filtered deliver service")
+		}
+		return nil
+	case ab.SeekInfo_FILTERED:
+		if _, ok := srv.ResponseSender.(FilteredBlockResponseSender); !ok && !isFiltered(srv) {
+			return errors.New("filtered blocks are not delivered by this deliver service")
+		}
+		return nil
+	default:
+		return errors.Errorf("unknown content type %d", contentType)
+	}
+}
+
 func (h *Handler) validateChannelHeader(ctx context.Context, chdr *cb.ChannelHeader) error {
 	if chdr.GetTimestamp() == nil {
 		err := errors.New("channel header in envelope must contain timestamp")
@@ -20,6 +20,12 @@ type filteredResponseSender interface {
 	deliver.Filtered
 }
 
+//go:generate counterfeiter -o mock/filtered_block_response_sender.go -fake-name FilteredBlockResponseSender . filteredBlockResponseSender
+type filteredBlockResponseSender interface {
+	deliver.ResponseSender
+	deliver.FilteredBlockResponseSender
+}
+
 func TestDeliver(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Deliver Suite")
@@ -21,6 +21,7 @@ import (
 	"github.com/hyperledger/fabric/common/util"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -519,6 +520,200 @@ var _ = Describe("Deliver", func() {
 			})
 		})
 
+		Context("when the headers of the blocks are requested", func() {
+			BeforeEach(func() {
+				seekInfo.ContentType = ab.SeekInfo_HEADER_WITH_SIG
+				fakeBlockIterator.NextReturns(&cb.Block{
+					Header:   &cb.BlockHeader{Number: 100},
+					Data:     &cb.BlockData{Data: [][]byte{[]byte("tx")}},
+					Metadata: &cb.BlockMetadata{Metadata: [][]byte{[]byte("signatures")}},
+				}, cb.Status_SUCCESS)
+			})
+
+			It("sends the header and the metadata of the blocks", func() {
+				err := handler.Handle(context.Background(), server)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(1))
+				b := fakeResponseSender.SendBlockResponseArgsForCall(0)
+				Expect(b).To(Equal(&cb.Block{
+					Header:   &cb.BlockHeader{Number: 100},
+					Metadata: &cb.BlockMetadata{Metadata: [][]byte{[]byte("signatures")}},
+				}))
+			})
+
+			Context("when the response sender is filtered", func() {
+				var fakeResponseSender *mock.FilteredResponseSender
+
+				BeforeEach(func() {
+					fakeResponseSender = &mock.FilteredResponseSender{}
+					fakeResponseSender.IsFilteredReturns(true)
+					server.ResponseSender = fakeResponseSender
+				})
+
+				It("sends a bad request message", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(0))
+					Expect(fakeResponseSender.SendStatusResponseCallCount()).To(Equal(1))
+					resp := fakeResponseSender.SendStatusResponseArgsForCall(0)
+					Expect(resp).To(Equal(cb.Status_BAD_REQUEST))
+				})
+			})
+		})
+
+		Context("when the filtered form of the blocks is requested", func() {
+			var fakeResponseSender *mock.FilteredBlockResponseSender
+
+			BeforeEach(func() {
+				fakeResponseSender = &mock.FilteredBlockResponseSender{}
+				server.ResponseSender = fakeResponseSender
+
+				seekInfo.ContentType = ab.SeekInfo_FILTERED
+				fakeBlockIterator.NextReturns(&cb.Block{
+					Header: &cb.BlockHeader{Number: 100},
+					Data: &cb.BlockData{Data: [][]byte{
+						utils.MarshalOrPanic(&cb.Envelope{
+							Payload: utils.MarshalOrPanic(&cb.Payload{
+								Header: &cb.Header{
+									ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{
+										Type:      int32(cb.HeaderType_ENDORSER_TRANSACTION),
+										ChannelId: "chain-id",
+										TxId:      "tx-1",
+									}),
+								},
+							}),
+						}),
+						utils.MarshalOrPanic(&cb.Envelope{
+							Payload: utils.MarshalOrPanic(&cb.Payload{
+								Header: &cb.Header{
+									ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{
+										Type:      int32(cb.HeaderType_CONFIG),
+										ChannelId: "chain-id",
+										TxId:      "tx-2",
+									}),
+								},
+							}),
+						}),
+					}},
+					Metadata: &cb.BlockMetadata{Metadata: [][]byte{{}, {}, {}, {}}},
+				}, cb.Status_SUCCESS)
+			})
+
+			It("sends the IDs and the types of the transactions", func() {
+				err := handler.Handle(context.Background(), server)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(0))
+				Expect(fakeResponseSender.SendFilteredBlockResponseCallCount()).To(Equal(1))
+				fb := fakeResponseSender.SendFilteredBlockResponseArgsForCall(0)
+				Expect(fb.Number).To(Equal(uint64(100)))
+				Expect(fb.ChannelId).To(Equal("chain-id"))
+				Expect(fb.FilteredTransactions).To(HaveLen(2))
+				Expect(fb.FilteredTransactions[0].Txid).To(Equal("tx-1"))
+				Expect(fb.FilteredTransactions[0].Type).To(Equal(cb.HeaderType_ENDORSER_TRANSACTION))
+				Expect(fb.FilteredTransactions[0].TxValidationCode).To(Equal(peer.TxValidationCode_NOT_VALIDATED))
+				Expect(fb.FilteredTransactions[1].Txid).To(Equal("tx-2"))
+				Expect(fb.FilteredTransactions[1].Type).To(Equal(cb.HeaderType_CONFIG))
+				Expect(fb.FilteredTransactions[1].TxValidationCode).To(Equal(peer.TxValidationCode_NOT_VALIDATED))
+			})
+
+			Context("when the blocks carry validation flags", func() {
+				BeforeEach(func() {
+					block, _ := fakeBlockIterator.Next()
+					block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER] = []byte{
+						uint8(peer.TxValidationCode_VALID),
+						uint8(peer.TxValidationCode_MVCC_READ_CONFLICT),
+					}
+				})
+
+				It("sends the validation codes of the transactions", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeResponseSender.SendFilteredBlockResponseCallCount()).To(Equal(1))
+					fb := fakeResponseSender.SendFilteredBlockResponseArgsForCall(0)
+					Expect(fb.FilteredTransactions[0].TxValidationCode).To(Equal(peer.TxValidationCode_VALID))
+					Expect(fb.FilteredTransactions[1].TxValidationCode).To(Equal(peer.TxValidationCode_MVCC_READ_CONFLICT))
+				})
+			})
+
+			Context("when the response sender does not send filtered blocks", func() {
+				BeforeEach(func() {
+					server.ResponseSender = &mock.ResponseSender{}
+				})
+
+				It("sends a bad request message", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					fakeResponseSender := server.ResponseSender.(*mock.ResponseSender)
+					Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(0))
+					Expect(fakeResponseSender.SendStatusResponseCallCount()).To(Equal(1))
+					resp := fakeResponseSender.SendStatusResponseArgsForCall(0)
+					Expect(resp).To(Equal(cb.Status_BAD_REQUEST))
+				})
+			})
+
+			Context("when the response sender is filtered", func() {
+				var fakeResponseSender *mock.FilteredResponseSender
+
+				BeforeEach(func() {
+					fakeResponseSender = &mock.FilteredResponseSender{}
+					fakeResponseSender.IsFilteredReturns(true)
+					server.ResponseSender = fakeResponseSender
+				})
+
+				It("lets the response sender filter the blocks", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(1))
+					Expect(fakeResponseSender.SendStatusResponseCallCount()).To(Equal(1))
+					resp := fakeResponseSender.SendStatusResponseArgsForCall(0)
+					Expect(resp).To(Equal(cb.Status_SUCCESS))
+				})
+			})
+
+			Context("when the block cannot be filtered", func() {
+				BeforeEach(func() {
+					fakeBlockIterator.NextReturns(&cb.Block{
+						Header: &cb.BlockHeader{Number: 100},
+						Data: &cb.BlockData{Data: [][]byte{
+							utils.MarshalOrPanic(&cb.Envelope{Payload: []byte("garbage")}),
+						}},
+					}, cb.Status_SUCCESS)
+				})
+
+				It("sends an internal server error message", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeResponseSender.SendFilteredBlockResponseCallCount()).To(Equal(0))
+					Expect(fakeResponseSender.SendStatusResponseCallCount()).To(Equal(1))
+					resp := fakeResponseSender.SendStatusResponseArgsForCall(0)
+					Expect(resp).To(Equal(cb.Status_INTERNAL_SERVER_ERROR))
+				})
+			})
+		})
+
+		Context("when the content type is unknown", func() {
+			BeforeEach(func() {
+				seekInfo.ContentType = ab.SeekInfo_SeekContentType(42)
+			})
+
+			It("sends a bad request message", func() {
+				err := handler.Handle(context.Background(), server)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(0))
+				Expect(fakeResponseSender.SendStatusResponseCallCount()).To(Equal(1))
+				resp := fakeResponseSender.SendStatusResponseArgsForCall(0)
+				Expect(resp).To(Equal(cb.Status_BAD_REQUEST))
+			})
+		})
+
 		Context("when sending the block fails", func() {
 			BeforeEach(func() {
 				fakeResponseSender.SendBlockResponseReturns(errors.New("send-fails"))
@@ -0,0 +1,144 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package deliver
+
+import (
+	"github.com/hyperledger/fabric/core/ledger/util"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// HeaderWithSig returns a copy of the given block which carries only its
+// header and its metadata, including the signatures on the block.
+func HeaderWithSig(block *cb.Block) *cb.Block {
+	return &cb.Block{
+		Header:   block.Header,
+		Metadata: block.Metadata,
+	}
+}
+
+// FilterBlock returns the filtered form of the given block, which carries the IDs,
+// types and validation codes of its transactions, and the chaincode events they emitted
+// without their payloads.
+func FilterBlock(block *cb.Block) (*peer.FilteredBlock, error) {
+	filteredBlock := &peer.FilteredBlock{
+		Number: block.Header.Number,
+	}
+
+	var txsFltr util.TxValidationFlags
+	if block.Metadata != nil && len(block.Metadata.Metadata) > int(cb.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		txsFltr = util.TxValidationFlags(block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	}
+	for txIndex, ebytes := range block.GetData().GetData() {
+		var env *cb.Envelope
+		var err error
+
+		if ebytes == nil {
+			logger.Debugf("got nil data bytes for tx index %d, "+
+				"block num %d", txIndex, block.Header.Number)
+			continue
+		}
+
+		env, err = utils.GetEnvelopeFromBlock(ebytes)
+		if err != nil {
+			logger.Errorf("error getting tx from block, %s", err)
+			continue
+		}
+
+		// get the payload from the envelope
+		payload, err := utils.GetPayload(env)
+		if err != nil {
+			return nil, errors.WithMessage(err, "could not extract payload from envelope")
+		}
+
+		if payload.Header == nil {
+			logger.Debugf("transaction payload header is nil, %d, block num %d",
+				txIndex, block.Header.Number)
+			continue
+		}
+		chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+		if err != nil {
+			return nil, err
+		}
+
+		filteredBlock.ChannelId = chdr.ChannelId
+
+		filteredTransaction := &peer.FilteredTransaction{
+			Txid: chdr.TxId,
+			Type: cb.HeaderType(chdr.Type),
+		}
+		// blocks which were not committed by a peer, such as the blocks of the
+		// orderer, carry no validation flags, so their transactions are not validated
+		if txIndex < len(txsFltr) {
+			filteredTransaction.TxValidationCode = txsFltr.Flag(txIndex)
+		} else {
+			filteredTransaction.TxValidationCode = peer.TxValidationCode_NOT_VALIDATED
+		}
+
+		if filteredTransaction.Type == cb.HeaderType_ENDORSER_TRANSACTION {
+			tx, err := utils.GetTransaction(payload.Data)
+			if err != nil {
+				return nil, errors.WithMessage(err, "error unmarshal transaction payload for block event")
+			}
+
+			filteredTransaction.Data, err = filterActions(tx.Actions)
+			if err != nil {
+				logger.Errorf(err.Error())
+				return nil, err
+			}
+		}
+
+		filteredBlock.FilteredTransactions = append(filteredBlock.FilteredTransactions, filteredTransaction)
+	}
+
+	return filteredBlock, nil
+}
+
+func filterActions(actions []*peer.TransactionAction) (*peer.FilteredTransaction_TransactionActions, error) {
+	transactionActions := &peer.FilteredTransactionActions{}
+	for _, action := range actions {
+		chaincodeActionPayload, err := utils.GetChaincodeActionPayload(action.Payload)
+		if err != nil {
+			return nil, errors.WithMessage(err, "error unmarshal transaction action payload for block event")
+		}
+
+		if chaincodeActionPayload.Action == nil {
+			logger.Debugf("chaincode action, the payload action is nil, skipping")
+			continue
+		}
+		propRespPayload, err := utils.GetProposalResponsePayload(chaincodeActionPayload.Action.ProposalResponsePayload)
+		if err != nil {
+			return nil, errors.WithMessage(err, "error unmarshal proposal response payload for block event")
+		}
+
+		caPayload, err := utils.GetChaincodeAction(propRespPayload.Extension)
+		if err != nil {
+			return nil, errors.WithMessage(err, "error unmarshal chaincode action for block event")
+		}
+
+		ccEvent, err := utils.GetChaincodeEvents(caPayload.Events)
+		if err != nil {
+			return nil, errors.WithMessage(err, "error unmarshal chaincode event for block event")
+		}
+
+		if ccEvent.GetChaincodeId() != "" {
+			filteredAction := &peer.FilteredChaincodeAction{
+				ChaincodeEvent: &peer.ChaincodeEvent{
+					TxId:        ccEvent.TxId,
+					ChaincodeId: ccEvent.ChaincodeId,
+					EventName:   ccEvent.EventName,
+				},
+			}
+			transactionActions.ChaincodeActions = append(transactionActions.ChaincodeActions, filteredAction)
+		}
+	}
+	return &peer.FilteredTransaction_TransactionActions{
+		TransactionActions: transactionActions,
+	}, nil
+}
@@ -28,8 +28,10 @@ const (
 )
 
 // IndexConfig - a configuration that includes a list of attributes that should be indexed
+// and the secondary indexes that should be maintained in addition
 type IndexConfig struct {
-	AttrsToIndex []IndexableAttr
+	AttrsToIndex     []IndexableAttr
+	SecondaryIndexes []SecondaryIndex
 }
 
 var (
@@ -38,17 +40,33 @@ var (
 
 	// ErrAttrNotIndexed is used to indicate that an attribute is not indexed
 	ErrAttrNotIndexed = errors.New("attribute not indexed")
+
+	// ErrPruned is used to indicate that the requested block or transaction
+	// was stored in a block file that has since been pruned
+	ErrPruned = errors.New("requested block has been pruned")
 )
 
 // BlockStoreProvider provides an handle to a BlockStore
 type BlockStoreProvider interface {
 	CreateBlockStore(ledgerid string) (BlockStore, error)
 	OpenBlockStore(ledgerid string) (BlockStore, error)
+	BootstrapFromSnapshot(ledgerid string, snapshotInfo *BootstrappingSnapshotInfo) (BlockStore, error)
 	Exists(ledgerid string) (bool, error)
 	List() ([]string, error)
+	Remove(ledgerid string) error
 	Close()
 }
 
+// BootstrappingSnapshotInfo contains the blocks that a block store retains when it is bootstrapped
+// from a ledger snapshot instead of the genesis block. The block store starts with the block next to
+// the last block of the snapshot and the blocks before that are not available, except for these blocks
+type BootstrappingSnapshotInfo struct {
+	// LastBlock is the last block committed to the ledger at the time of generating the snapshot
+	LastBlock *common.Block
+	// LastConfigBlock is the most recent config block as of the last block
+	LastConfigBlock *common.Block
+}
+
 // BlockStore - an interface for persisting and retrieving blocks
 // An implementation of this interface is expected to take an argument
 // of type `IndexConfig` which configures the block store on what items should be indexed
@@ -62,5 +80,17 @@ type BlockStore interface {
 	RetrieveTxByBlockNumTranNum(blockNum uint64, tranNum uint64) (*common.Envelope, error)
 	RetrieveBlockByTxID(txID string) (*common.Block, error)
 	RetrieveTxValidationCodeByTxID(txID string) (peer.TxValidationCode, error)
+	// RetrieveTxsByIndexKey returns an iterator over the transactions that have the given key in the
+	// secondary index with the given name. The iterator contains results of type *peer.ProcessedTransaction
+	RetrieveTxsByIndexKey(indexName string, key []byte) (ledger.ResultsIterator, error)
+	// RetrieveTxsByIndexRange returns an iterator over the transactions that have a key in the range
+	// [startKey, endKey) in the secondary index with the given name. A nil endKey refers to the end of the index.
+	// The iterator contains results of type *peer.ProcessedTransaction in the order of their keys
+	RetrieveTxsByIndexRange(indexName string, startKey, endKey []byte) (ledger.ResultsIterator, error)
+	// FirstBlockNumber returns the number of the oldest block that is still available in the store
+	FirstBlockNumber() (uint64, error)
+	// Prune removes the blocks with a number lower than `belowBlockNum`. An implementation may
+	// retain some of these blocks if it can only discard them in bulk. The last block is never removed
+	Prune(belowBlockNum uint64) error
 	Shutdown()
 }
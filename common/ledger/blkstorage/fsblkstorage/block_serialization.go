@@ -25,6 +25,7 @@ import (
 
 type serializedBlockInfo struct {
 	blockHeader *common.BlockHeader
+	data        *common.BlockData
 	txOffsets   []*txindexInfo
 	metadata    *common.BlockMetadata
 }
@@ -41,6 +42,7 @@ func serializeBlock(block *common.Block) ([]byte, *serializedBlockInfo, error) {
 	var err error
 	info := &serializedBlockInfo{}
 	info.blockHeader = block.Header
+	info.data = block.Data
 	info.metadata = block.Metadata
 	if err = addHeaderBytes(block.Header, buf); err != nil {
 		return nil, nil, err
@@ -78,7 +80,7 @@ func extractSerializedBlockInfo(serializedBlockBytes []byte) (*serializedBlockIn
 	if err != nil {
 		return nil, err
 	}
-	_, info.txOffsets, err = extractData(b)
+	info.data, info.txOffsets, err = extractData(b)
 	if err != nil {
 		return nil, err
 	}
@@ -9,6 +9,7 @@ package fsblkstorage
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 
@@ -103,6 +104,103 @@ func retrieveLastFileSuffix(rootDir string) (int, error) {
 	return biggestFileNum, err
 }
 
+// constructPruneInfoFromBlockFiles derives the prune info from the first block file present on the file system
+func constructPruneInfoFromBlockFiles(rootDir string) (*pruneInfo, error) {
+	firstFileNum, err := retrieveFirstFileSuffix(rootDir)
+	if err != nil {
+		return nil, err
+	}
+	if firstFileNum <= 0 {
+		return &pruneInfo{}, nil
+	}
+	firstBlockNum, err := retrieveFirstBlockNumInFile(rootDir, firstFileNum)
+	if err != nil {
+		return nil, err
+	}
+	logger.Infof("Block files before [%d] are not present, first available block is [%d]", firstFileNum, firstBlockNum)
+	return &pruneInfo{firstFileSuffixNum: firstFileNum, firstBlockNumber: firstBlockNum}, nil
+}
+
+func retrieveFirstFileSuffix(rootDir string) (int, error) {
+	logger.Debugf("retrieveFirstFileSuffix()")
+	smallestFileNum := -1
+	filesInfo, err := ioutil.ReadDir(rootDir)
+	if err != nil {
+		return -1, errors.Wrapf(err, "error reading dir %s", rootDir)
+	}
+	for _, fileInfo := range filesInfo {
+		name := fileInfo.Name()
+		if fileInfo.IsDir() || !isBlockFileName(name) {
+			continue
+		}
+		fileNum, err := strconv.Atoi(strings.TrimPrefix(name, blockfilePrefix))
+		if err != nil {
+			return -1, err
+		}
+		if smallestFileNum == -1 || fileNum < smallestFileNum {
+			smallestFileNum = fileNum
+		}
+	}
+	logger.Debugf("retrieveFirstFileSuffix() - smallestFileNum = %d", smallestFileNum)
+	return smallestFileNum, nil
+}
+
+// retrieveFirstBlockNumInFile returns the number of the first block stored in the given block file
+func retrieveFirstBlockNumInFile(rootDir string, fileNum int) (uint64, error) {
+	stream, err := newBlockfileStream(rootDir, fileNum, 0)
+	if err != nil {
+		return 0, err
+	}
+	defer stream.close()
+	blockBytes, err := stream.nextBlockBytes()
+	if err != nil {
+		return 0, err
+	}
+	if blockBytes == nil {
+		return 0, errors.Errorf("block file [%d] does not contain any block", fileNum)
+	}
+	info, err := extractSerializedBlockInfo(blockBytes)
+	if err != nil {
+		return 0, err
+	}
+	return info.blockHeader.Number, nil
+}
+
+// removeBlockfilesBefore removes the block files with a suffix number lower than the given one
+func removeBlockfilesBefore(rootDir string, fileNum int) error {
+	return removeBlockfiles(rootDir, func(suffixNum int) bool { return suffixNum < fileNum })
+}
+
+// removeBlockfilesAfter removes the block files with a suffix number higher than the given one
+func removeBlockfilesAfter(rootDir string, fileNum int) error {
+	return removeBlockfiles(rootDir, func(suffixNum int) bool { return suffixNum > fileNum })
+}
+
+func removeBlockfiles(rootDir string, toRemove func(suffixNum int) bool) error {
+	filesInfo, err := ioutil.ReadDir(rootDir)
+	if err != nil {
+		return errors.Wrapf(err, "error reading dir %s", rootDir)
+	}
+	for _, fileInfo := range filesInfo {
+		name := fileInfo.Name()
+		if fileInfo.IsDir() || !isBlockFileName(name) {
+			continue
+		}
+		suffixNum, err := strconv.Atoi(strings.TrimPrefix(name, blockfilePrefix))
+		if err != nil {
+			return err
+		}
+		if !toRemove(suffixNum) {
+			continue
+		}
+		logger.Debugf("Removing block file [%s]", name)
+		if err := os.Remove(filepath.Join(rootDir, name)); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "error removing block file %s", name)
+		}
+	}
+	return nil
+}
+
 func isBlockFileName(name string) bool {
 	return strings.HasPrefix(name, blockfilePrefix)
 }
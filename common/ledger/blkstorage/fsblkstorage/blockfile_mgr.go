@@ -32,7 +32,9 @@ const (
 )
 
 var (
-	blkMgrInfoKey = []byte("blkMgrInfo")
+	blkMgrInfoKey      = []byte("blkMgrInfo")
+	blkMgrPruneInfoKey = []byte("blkMgrPruneInfo")
+	blkMgrBootstrapKey = []byte("blkMgrBootstrapInfo")
 )
 
 type blockfileMgr struct {
@@ -44,6 +46,9 @@ type blockfileMgr struct {
 	cpInfoCond        *sync.Cond
 	currentFileWriter *blockfileWriter
 	bcInfo            atomic.Value
+	pruneInfo         atomic.Value
+	pruneLock         sync.Mutex
+	bootstrapInfo     *bootstrapInfo
 }
 
 /*
@@ -115,11 +120,41 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 		logger.Debug(`Synching block information from block storage (if needed)`)
 		syncCPInfoFromFS(rootDir, cpInfo)
 	}
+	// Remove the block files that may have been left behind if a crash happened during a rollback
+	if err = removeBlockfilesAfter(rootDir, cpInfo.latestFileChunkSuffixNum); err != nil {
+		panic(fmt.Sprintf("Could not remove rolled back block files: %s", err))
+	}
 	err = mgr.saveCurrentInfo(cpInfo, true)
 	if err != nil {
 		panic(fmt.Sprintf("Could not save next block file info to db: %s", err))
 	}
 
+	// pi = pruneInfo, retrieve from the database the first block file and the first block that
+	// are still available after a previous pruning. If absent, it is derived from the block files
+	pi, err := mgr.loadPruneInfo()
+	if err != nil {
+		panic(fmt.Sprintf("Could not get prune info from db: %s", err))
+	}
+	if pi == nil {
+		if pi, err = constructPruneInfoFromBlockFiles(rootDir); err != nil {
+			panic(fmt.Sprintf("Could not build prune info from block files: %s", err))
+		}
+		if err = mgr.savePruneInfo(pi); err != nil {
+			panic(fmt.Sprintf("Could not save prune info to db: %s", err))
+		}
+	}
+	// Remove the block files that may have been left behind if a crash happened during pruning
+	if err = removeBlockfilesBefore(rootDir, pi.firstFileSuffixNum); err != nil {
+		panic(fmt.Sprintf("Could not remove pruned block files: %s", err))
+	}
+	mgr.pruneInfo.Store(pi)
+
+	// bi = bootstrapInfo, retrieve from the database the blocks retained from the snapshot that
+	// the block storage was bootstrapped from. It is absent if the storage started with the genesis block
+	if mgr.bootstrapInfo, err = mgr.loadBootstrapInfo(); err != nil {
+		panic(fmt.Sprintf("Could not get bootstrap info from db: %s", err))
+	}
+
 	//Open a writer to the file identified by the number and truncate it to only contain the latest block
 	// that was completely saved (file system, index, cpinfo, etc)
 	currentFileWriter, err := newBlockfileWriter(deriveBlockfilePath(rootDir, cpInfo.latestFileChunkSuffixNum))
@@ -153,6 +188,9 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 	if !cpInfo.isChainEmpty {
 		//If start up is a restart of an existing storage, sync the index from block storage and update BlockchainInfo for external API's
 		mgr.syncIndex()
+		if err := mgr.syncSecondaryIndexes(); err != nil {
+			panic(fmt.Sprintf("Could not build the secondary indexes: %s", err))
+		}
 		lastBlockHeader, err := mgr.retrieveBlockHeaderByNumber(cpInfo.lastBlockNumber)
 		if err != nil {
 			panic(fmt.Sprintf("Could not retrieve header of the last block form file: %s", err))
@@ -163,6 +201,9 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 			Height:            cpInfo.lastBlockNumber + 1,
 			CurrentBlockHash:  lastBlockHash,
 			PreviousBlockHash: previousBlockHash}
+	} else if mgr.bootstrapInfo != nil {
+		//If the storage was bootstrapped from a snapshot and no block has been added since, the BlockchainInfo refers to the last block of the snapshot
+		bcInfo = mgr.bootstrapInfo.blockchainInfo()
 	}
 	mgr.bcInfo.Store(bcInfo)
 	return mgr
@@ -189,7 +230,7 @@ func syncCPInfoFromFS(rootDir string, cpInfo *checkpointInfo) {
 		return
 	}
 	//Scan the file system to verify that the checkpoint info stored in db is correct
-	_, endOffsetLastBlock, numBlocks, err := scanForLastCompleteBlock(
+	lastBlockBytes, endOffsetLastBlock, numBlocks, err := scanForLastCompleteBlock(
 		rootDir, cpInfo.latestFileChunkSuffixNum, int64(cpInfo.latestFileChunksize))
 	if err != nil {
 		panic(fmt.Sprintf("Could not open current file for detecting last block in the file: %s", err))
@@ -200,7 +241,12 @@ func syncCPInfoFromFS(rootDir string, cpInfo *checkpointInfo) {
 	}
 	//Updates the checkpoint info for the actual last block number stored and it's end location
 	if cpInfo.isChainEmpty {
-		cpInfo.lastBlockNumber = uint64(numBlocks - 1)
+		//The first block in an empty chain is not necessarily the genesis block if the chain was bootstrapped from a snapshot
+		lastBlockInfo, err := extractSerializedBlockInfo(lastBlockBytes)
+		if err != nil {
+			panic(fmt.Sprintf("Could not extract the header of the last block in the current file: %s", err))
+		}
+		cpInfo.lastBlockNumber = lastBlockInfo.blockHeader.Number
 	} else {
 		cpInfo.lastBlockNumber += uint64(numBlocks)
 	}
@@ -315,7 +361,7 @@ func (mgr *blockfileMgr) addBlock(block *common.Block) error {
 	//save the index in the database
 	if err = mgr.index.indexBlock(&blockIdxInfo{
 		blockNum: block.Header.Number, blockHash: blockHash,
-		flp: blockFLP, txOffsets: txOffsets, metadata: block.Metadata}); err != nil {
+		flp: blockFLP, txOffsets: txOffsets, metadata: block.Metadata, data: block.Data}); err != nil {
 		return err
 	}
 
@@ -337,13 +383,14 @@ func (mgr *blockfileMgr) syncIndex() error {
 		indexEmpty = true
 	}
 
-	//initialize index to file number:zero, offset:zero and blockNum:0
-	startFileNum := 0
+	//initialize index to the first available file number, offset:zero and the first available block number
+	pi := mgr.getPruneInfo()
+	startFileNum := pi.firstFileSuffixNum
 	startOffset := 0
 	skipFirstBlock := false
 	//get the last file that blocks were added to using the checkpoint info
 	endFileNum := mgr.cpInfo.latestFileChunkSuffixNum
-	startingBlockNum := uint64(0)
+	startingBlockNum := pi.firstBlockNumber
 
 	//if the index stored in the db has value, update the index information with those values
 	if !indexEmpty {
@@ -414,6 +461,7 @@ func (mgr *blockfileMgr) syncIndex() error {
 			locPointer: locPointer{offset: int(blockPlacementInfo.blockStartOffset)}}
 		blockIdxInfo.txOffsets = info.txOffsets
 		blockIdxInfo.metadata = info.metadata
+		blockIdxInfo.data = info.data
 
 		logger.Debugf("syncIndex() indexing block [%d]", blockIdxInfo.blockNum)
 		if err = mgr.index.indexBlock(blockIdxInfo); err != nil {
@@ -431,6 +479,144 @@ func (mgr *blockfileMgr) getBlockchainInfo() *common.BlockchainInfo {
 	return mgr.bcInfo.Load().(*common.BlockchainInfo)
 }
 
+func (mgr *blockfileMgr) getPruneInfo() *pruneInfo {
+	return mgr.pruneInfo.Load().(*pruneInfo)
+}
+
+func (mgr *blockfileMgr) firstBlockNumber() uint64 {
+	return mgr.getPruneInfo().firstBlockNumber
+}
+
+// prune removes the block files that contain only blocks with a number lower than `belowBlockNum`.
+// Since the block files are removed as a whole, the blocks that share the file with the block
+// `belowBlockNum` are retained. The file that contains the last block is never removed.
+// The prune info is persisted before the files are removed so that a crash in between
+// leads to the removal of the remaining files on the next start
+func (mgr *blockfileMgr) prune(belowBlockNum uint64) error {
+	mgr.pruneLock.Lock()
+	defer mgr.pruneLock.Unlock()
+
+	bcInfo := mgr.getBlockchainInfo()
+	if bcInfo.Height == 0 {
+		logger.Debug("Nothing to prune as the block storage is empty")
+		return nil
+	}
+	if belowBlockNum > bcInfo.Height-1 {
+		belowBlockNum = bcInfo.Height - 1
+	}
+	currentPI := mgr.getPruneInfo()
+	if belowBlockNum <= currentPI.firstBlockNumber {
+		logger.Debugf("Nothing to prune below block [%d], first available block is [%d]", belowBlockNum, currentPI.firstBlockNumber)
+		return nil
+	}
+	loc, err := mgr.index.getBlockLocByBlockNum(belowBlockNum)
+	if err != nil {
+		return errors.WithMessage(err, "error locating the block file to retain")
+	}
+	if loc.fileSuffixNum <= currentPI.firstFileSuffixNum {
+		logger.Debugf("Nothing to prune below block [%d] as it is stored in the first available block file", belowBlockNum)
+		return nil
+	}
+	firstBlockNum, err := retrieveFirstBlockNumInFile(mgr.rootDir, loc.fileSuffixNum)
+	if err != nil {
+		return err
+	}
+	newPI := &pruneInfo{
+		firstFileSuffixNum: loc.fileSuffixNum,
+		firstBlockNumber:   firstBlockNum,
+	}
+	if err := mgr.savePruneInfo(newPI); err != nil {
+		return errors.WithMessage(err, "error saving prune info to db")
+	}
+	mgr.pruneInfo.Store(newPI)
+
+	if err := mgr.index.removeBlocks(currentPI.firstBlockNumber, newPI.firstBlockNumber); err != nil {
+		return err
+	}
+	if err := removeBlockfilesBefore(mgr.rootDir, newPI.firstFileSuffixNum); err != nil {
+		return err
+	}
+	logger.Infof("Pruned block files [%d] to [%d], first available block is [%d]",
+		currentPI.firstFileSuffixNum, newPI.firstFileSuffixNum-1, newPI.firstBlockNumber)
+	return nil
+}
+
+// checkPruned returns `ErrPruned` if the given location refers to a block file that has been pruned
+func (mgr *blockfileMgr) checkPruned(lp *fileLocPointer) error {
+	if lp.fileSuffixNum < mgr.getPruneInfo().firstFileSuffixNum {
+		return blkstorage.ErrPruned
+	}
+	return nil
+}
+
+// bootstrapFromSnapshot prepares an empty block storage for receiving the block next to the last block
+// of a snapshot. The blocks before that are never added to this storage. However, the last block and
+// the last config block of the snapshot are retained so that the most recent config remains retrievable
+// until the config changes. The checkpoint info, the prune info, and the bootstrap info are persisted
+// in a single batch so that a crash in between leaves the storage empty
+func (mgr *blockfileMgr) bootstrapFromSnapshot(snapshotInfo *blkstorage.BootstrappingSnapshotInfo) error {
+	if !mgr.cpInfo.isChainEmpty || mgr.bootstrapInfo != nil {
+		return errors.New("cannot bootstrap a block storage that is not empty")
+	}
+	if snapshotInfo.LastBlock == nil || snapshotInfo.LastConfigBlock == nil {
+		return errors.New("the last block and the last config block of the snapshot are required for bootstrapping")
+	}
+	lastBlockNum := snapshotInfo.LastBlock.Header.Number
+	if snapshotInfo.LastConfigBlock.Header.Number > lastBlockNum {
+		return errors.Errorf("the last config block [%d] is beyond the last block [%d] of the snapshot",
+			snapshotInfo.LastConfigBlock.Header.Number, lastBlockNum)
+	}
+	bi := &bootstrapInfo{lastBlock: snapshotInfo.LastBlock, lastConfigBlock: snapshotInfo.LastConfigBlock}
+	pi := &pruneInfo{firstFileSuffixNum: mgr.cpInfo.latestFileChunkSuffixNum, firstBlockNumber: lastBlockNum + 1}
+	cpInfo := &checkpointInfo{
+		latestFileChunkSuffixNum: mgr.cpInfo.latestFileChunkSuffixNum,
+		latestFileChunksize:      mgr.cpInfo.latestFileChunksize,
+		isChainEmpty:             true,
+		lastBlockNumber:          lastBlockNum,
+	}
+
+	biBytes, err := bi.marshal()
+	if err != nil {
+		return err
+	}
+	piBytes, err := pi.marshal()
+	if err != nil {
+		return err
+	}
+	cpInfoBytes, err := cpInfo.marshal()
+	if err != nil {
+		return err
+	}
+	batch := leveldbhelper.NewUpdateBatch()
+	batch.Put(blkMgrBootstrapKey, biBytes)
+	batch.Put(blkMgrPruneInfoKey, piBytes)
+	batch.Put(blkMgrInfoKey, cpInfoBytes)
+	if err := mgr.db.WriteBatch(batch, true); err != nil {
+		return errors.WithMessage(err, "error saving bootstrap info to db")
+	}
+
+	mgr.bootstrapInfo = bi
+	mgr.pruneInfo.Store(pi)
+	mgr.updateCheckpoint(cpInfo)
+	mgr.bcInfo.Store(bi.blockchainInfo())
+	logger.Infof("Bootstrapped block storage from snapshot, the next block to be added is [%d]", lastBlockNum+1)
+	return nil
+}
+
+// retrieveBootstrapBlock returns the block retained from the snapshot that the storage
+// was bootstrapped from, if any, with the given block number
+func (mgr *blockfileMgr) retrieveBootstrapBlock(blockNum uint64) *common.Block {
+	if mgr.bootstrapInfo == nil {
+		return nil
+	}
+	for _, block := range []*common.Block{mgr.bootstrapInfo.lastBlock, mgr.bootstrapInfo.lastConfigBlock} {
+		if block.Header.Number == blockNum {
+			return block
+		}
+	}
+	return nil
+}
+
 func (mgr *blockfileMgr) updateCheckpoint(cpInfo *checkpointInfo) {
 	mgr.cpInfoCond.L.Lock()
 	defer mgr.cpInfoCond.L.Unlock()
@@ -455,6 +641,9 @@ func (mgr *blockfileMgr) retrieveBlockByHash(blockHash []byte) (*common.Block, e
 	if err != nil {
 		return nil, err
 	}
+	if err := mgr.checkPruned(loc); err != nil {
+		return nil, err
+	}
 	return mgr.fetchBlock(loc)
 }
 
@@ -465,6 +654,12 @@ func (mgr *blockfileMgr) retrieveBlockByNumber(blockNum uint64) (*common.Block,
 	if blockNum == math.MaxUint64 {
 		blockNum = mgr.getBlockchainInfo().Height - 1
 	}
+	if blockNum < mgr.firstBlockNumber() {
+		if block := mgr.retrieveBootstrapBlock(blockNum); block != nil {
+			return block, nil
+		}
+		return nil, blkstorage.ErrPruned
+	}
 
 	loc, err := mgr.index.getBlockLocByBlockNum(blockNum)
 	if err != nil {
@@ -481,6 +676,9 @@ func (mgr *blockfileMgr) retrieveBlockByTxID(txID string) (*common.Block, error)
 	if err != nil {
 		return nil, err
 	}
+	if err := mgr.checkPruned(loc); err != nil {
+		return nil, err
+	}
 	return mgr.fetchBlock(loc)
 }
 
@@ -516,11 +714,17 @@ func (mgr *blockfileMgr) retrieveTransactionByID(txID string) (*common.Envelope,
 	if err != nil {
 		return nil, err
 	}
+	if err := mgr.checkPruned(loc); err != nil {
+		return nil, err
+	}
 	return mgr.fetchTransactionEnvelope(loc)
 }
 
 func (mgr *blockfileMgr) retrieveTransactionByBlockNumTranNum(blockNum uint64, tranNum uint64) (*common.Envelope, error) {
 	logger.Debugf("retrieveTransactionByBlockNumTranNum() - blockNum = [%d], tranNum = [%d]", blockNum, tranNum)
+	if blockNum < mgr.firstBlockNumber() {
+		return nil, blkstorage.ErrPruned
+	}
 	loc, err := mgr.index.getTXLocByBlockNumTranNum(blockNum, tranNum)
 	if err != nil {
 		return nil, err
@@ -554,6 +758,10 @@ func (mgr *blockfileMgr) fetchTransactionEnvelope(lp *fileLocPointer) (*common.E
 func (mgr *blockfileMgr) fetchBlockBytes(lp *fileLocPointer) ([]byte, error) {
 	stream, err := newBlockfileStream(mgr.rootDir, lp.fileSuffixNum, int64(lp.offset))
 	if err != nil {
+		// the block file may have been pruned after the location was looked up
+		if pruneErr := mgr.checkPruned(lp); pruneErr != nil {
+			return nil, pruneErr
+		}
 		return nil, err
 	}
 	defer stream.close()
@@ -568,6 +776,9 @@ func (mgr *blockfileMgr) fetchRawBytes(lp *fileLocPointer) ([]byte, error) {
 	filePath := deriveBlockfilePath(mgr.rootDir, lp.fileSuffixNum)
 	reader, err := newBlockfileReader(filePath)
 	if err != nil {
+		if pruneErr := mgr.checkPruned(lp); pruneErr != nil {
+			return nil, pruneErr
+		}
 		return nil, err
 	}
 	defer reader.close()
@@ -604,6 +815,44 @@ func (mgr *blockfileMgr) saveCurrentInfo(i *checkpointInfo, sync bool) error {
 	return nil
 }
 
+//Get the prune information that is stored in the database
+func (mgr *blockfileMgr) loadPruneInfo() (*pruneInfo, error) {
+	var b []byte
+	var err error
+	if b, err = mgr.db.Get(blkMgrPruneInfoKey); b == nil || err != nil {
+		return nil, err
+	}
+	i := &pruneInfo{}
+	if err = i.unmarshal(b); err != nil {
+		return nil, err
+	}
+	logger.Debugf("loaded pruneInfo:%s", i)
+	return i, nil
+}
+
+func (mgr *blockfileMgr) savePruneInfo(i *pruneInfo) error {
+	b, err := i.marshal()
+	if err != nil {
+		return err
+	}
+	return mgr.db.Put(blkMgrPruneInfoKey, b, true)
+}
+
+//Get the bootstrap information that is stored in the database
+func (mgr *blockfileMgr) loadBootstrapInfo() (*bootstrapInfo, error) {
+	var b []byte
+	var err error
+	if b, err = mgr.db.Get(blkMgrBootstrapKey); b == nil || err != nil {
+		return nil, err
+	}
+	i := &bootstrapInfo{}
+	if err = i.unmarshal(b); err != nil {
+		return nil, err
+	}
+	logger.Debugf("loaded bootstrapInfo:%s", i)
+	return i, nil
+}
+
 // scanForLastCompleteBlock scan a given block file and detects the last offset in the file
 // after which there may lie a block partially written (towards the end of the file in a crash scenario).
 func scanForLastCompleteBlock(rootDir string, fileNum int, startingOffset int64) ([]byte, int64, int, error) {
@@ -696,3 +945,88 @@ func (i *checkpointInfo) String() string {
 	return fmt.Sprintf("latestFileChunkSuffixNum=[%d], latestFileChunksize=[%d], isChainEmpty=[%t], lastBlockNumber=[%d]",
 		i.latestFileChunkSuffixNum, i.latestFileChunksize, i.isChainEmpty, i.lastBlockNumber)
 }
+
+// pruneInfo tracks the first block file and the first block that are available
+// in the block storage after the older block files have been pruned
+type pruneInfo struct {
+	firstFileSuffixNum int
+	firstBlockNumber   uint64
+}
+
+func (i *pruneInfo) marshal() ([]byte, error) {
+	buffer := proto.NewBuffer([]byte{})
+	if err := buffer.EncodeVarint(uint64(i.firstFileSuffixNum)); err != nil {
+		return nil, err
+	}
+	if err := buffer.EncodeVarint(i.firstBlockNumber); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func (i *pruneInfo) unmarshal(b []byte) error {
+	buffer := proto.NewBuffer(b)
+	val, err := buffer.DecodeVarint()
+	if err != nil {
+		return err
+	}
+	i.firstFileSuffixNum = int(val)
+	if i.firstBlockNumber, err = buffer.DecodeVarint(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (i *pruneInfo) String() string {
+	return fmt.Sprintf("firstFileSuffixNum=[%d], firstBlockNumber=[%d]", i.firstFileSuffixNum, i.firstBlockNumber)
+}
+
+// bootstrapInfo tracks the blocks that are retained from the snapshot
+// that the block storage was bootstrapped from
+type bootstrapInfo struct {
+	lastBlock       *common.Block
+	lastConfigBlock *common.Block
+}
+
+func (i *bootstrapInfo) blockchainInfo() *common.BlockchainInfo {
+	return &common.BlockchainInfo{
+		Height:            i.lastBlock.Header.Number + 1,
+		CurrentBlockHash:  i.lastBlock.Header.Hash(),
+		PreviousBlockHash: i.lastBlock.Header.PreviousHash,
+	}
+}
+
+func (i *bootstrapInfo) marshal() ([]byte, error) {
+	buffer := proto.NewBuffer([]byte{})
+	for _, block := range []*common.Block{i.lastBlock, i.lastConfigBlock} {
+		blockBytes, err := proto.Marshal(block)
+		if err != nil {
+			return nil, errors.Wrap(err, "error marshaling block")
+		}
+		if err := buffer.EncodeRawBytes(blockBytes); err != nil {
+			return nil, err
+		}
+	}
+	return buffer.Bytes(), nil
+}
+
+func (i *bootstrapInfo) unmarshal(b []byte) error {
+	buffer := proto.NewBuffer(b)
+	blocks := make([]*common.Block, 2)
+	for j := range blocks {
+		blockBytes, err := buffer.DecodeRawBytes(false)
+		if err != nil {
+			return err
+		}
+		blocks[j] = &common.Block{}
+		if err := proto.Unmarshal(blockBytes, blocks[j]); err != nil {
+			return errors.Wrap(err, "error unmarshaling block")
+		}
+	}
+	i.lastBlock, i.lastConfigBlock = blocks[0], blocks[1]
+	return nil
+}
+
+func (i *bootstrapInfo) String() string {
+	return fmt.Sprintf("lastBlockNumber=[%d], lastConfigBlockNumber=[%d]", i.lastBlock.Header.Number, i.lastConfigBlock.Header.Number)
+}
@@ -17,9 +17,12 @@ limitations under the License.
 package fsblkstorage
 
 import (
+	"math"
+	"os"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	ledgerutil "github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
@@ -337,3 +340,156 @@ func TestBlockfileMgrGetBlockByTxID(t *testing.T) {
 		}
 	}
 }
+
+func TestBlockfileMgrPrune(t *testing.T) {
+	blocks := testutil.ConstructTestBlocks(t, 110)
+	size := 0
+	for _, block := range blocks[:20] {
+		by, _, err := serializeBlock(block)
+		assert.NoError(t, err, "Error while serializing block")
+		size += len(by) + len(proto.EncodeVarint(uint64(len(by))))
+	}
+
+	// roughly 20 blocks per file
+	env := newTestEnv(t, NewConf(testPath(), size))
+	defer env.Cleanup()
+	ledgerid := "testLedger"
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, ledgerid)
+	blkfileMgrWrapper.addBlocks(blocks[:100])
+	mgr := blkfileMgrWrapper.blockfileMgr
+	assert.True(t, mgr.cpInfo.latestFileChunkSuffixNum > 2)
+
+	// pruning below a block in the first file is a no-op
+	assert.NoError(t, mgr.prune(5))
+	assert.Equal(t, uint64(0), mgr.firstBlockNumber())
+
+	assert.NoError(t, mgr.prune(50))
+	loc, err := mgr.index.getBlockLocByBlockNum(50)
+	assert.NoError(t, err)
+	firstBlockNum := mgr.firstBlockNumber()
+	assert.True(t, firstBlockNum > 0 && firstBlockNum <= 50)
+	for fileNum := 0; fileNum < loc.fileSuffixNum; fileNum++ {
+		_, err := os.Stat(deriveBlockfilePath(mgr.rootDir, fileNum))
+		assert.True(t, os.IsNotExist(err))
+	}
+
+	// the pruned blocks and transactions are reported as pruned
+	_, err = mgr.retrieveBlockByNumber(firstBlockNum - 1)
+	assert.Equal(t, blkstorage.ErrPruned, err)
+	_, err = mgr.retrieveBlockByHash(blocks[0].Header.Hash())
+	assert.Equal(t, blkstorage.ErrPruned, err)
+	txID, err := extractTxID(blocks[0].Data.Data[0])
+	assert.NoError(t, err)
+	_, err = mgr.retrieveTransactionByID(txID)
+	assert.Equal(t, blkstorage.ErrPruned, err)
+	_, err = mgr.retrieveBlockByTxID(txID)
+	assert.Equal(t, blkstorage.ErrPruned, err)
+	_, err = mgr.retrieveTransactionByBlockNumTranNum(0, 0)
+	assert.Equal(t, blkstorage.ErrPruned, err)
+	// validation codes of the pruned transactions are retained for detecting duplicate txids
+	_, err = mgr.retrieveTxValidationCodeByTxID(txID)
+	assert.NoError(t, err)
+
+	itr, err := mgr.retrieveBlocks(0)
+	assert.NoError(t, err)
+	_, err = itr.Next()
+	assert.Equal(t, blkstorage.ErrPruned, err)
+	itr.Close()
+
+	// the retained blocks are still available
+	blkfileMgrWrapper.testGetBlockByNumber(blocks[firstBlockNum:100], firstBlockNum)
+	itr, err = mgr.retrieveBlocks(firstBlockNum)
+	assert.NoError(t, err)
+	blk, err := itr.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, blocks[firstBlockNum], blk)
+	itr.Close()
+	blkfileMgrWrapper.close()
+
+	// prune info survives a restart and the ledger keeps growing
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, ledgerid)
+	defer blkfileMgrWrapper.close()
+	assert.Equal(t, firstBlockNum, blkfileMgrWrapper.blockfileMgr.firstBlockNumber())
+	blkfileMgrWrapper.addBlocks(blocks[100:])
+	blkfileMgrWrapper.testGetBlockByNumber(blocks[firstBlockNum:], firstBlockNum)
+
+	// the last block is always retained
+	assert.NoError(t, blkfileMgrWrapper.blockfileMgr.prune(1000))
+	_, err = blkfileMgrWrapper.blockfileMgr.retrieveBlockByNumber(109)
+	assert.NoError(t, err)
+}
+
+func TestBlockfileMgrPruneInfoFromBlockFiles(t *testing.T) {
+	blocks := testutil.ConstructTestBlocks(t, 30)
+	by, _, err := serializeBlock(blocks[1])
+	assert.NoError(t, err)
+	env := newTestEnv(t, NewConf(testPath(), 5*len(by)))
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgrWrapper.addBlocks(blocks)
+	mgr := blkfileMgrWrapper.blockfileMgr
+	assert.NoError(t, mgr.prune(20))
+	expectedPI := mgr.getPruneInfo()
+	blkfileMgrWrapper.close()
+
+	pi, err := constructPruneInfoFromBlockFiles(mgr.rootDir)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedPI, pi)
+}
+
+func TestBlockfileMgrBootstrapFromSnapshot(t *testing.T) {
+	blocks := testutil.ConstructTestBlocks(t, 20)
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	ledgerid := "testLedger"
+	snapshotInfo := &blkstorage.BootstrappingSnapshotInfo{LastBlock: blocks[9], LastConfigBlock: blocks[0]}
+	blkStore, err := env.provider.BootstrapFromSnapshot(ledgerid, snapshotInfo)
+	assert.NoError(t, err)
+	mgr := blkStore.(*fsBlockStore).fileMgr
+
+	expectedBCInfo := &common.BlockchainInfo{
+		Height:            10,
+		CurrentBlockHash:  blocks[9].Header.Hash(),
+		PreviousBlockHash: blocks[9].Header.PreviousHash,
+	}
+	assert.Equal(t, expectedBCInfo, mgr.getBlockchainInfo())
+	assert.Equal(t, uint64(10), mgr.firstBlockNumber())
+
+	// only the blocks retained from the snapshot are available below the first block
+	blk, err := mgr.retrieveBlockByNumber(math.MaxUint64)
+	assert.NoError(t, err)
+	assert.Equal(t, blocks[9], blk)
+	blk, err = mgr.retrieveBlockByNumber(0)
+	assert.NoError(t, err)
+	assert.Equal(t, blocks[0], blk)
+	_, err = mgr.retrieveBlockByNumber(5)
+	assert.Equal(t, blkstorage.ErrPruned, err)
+
+	// the block storage cannot be bootstrapped again
+	_, err = env.provider.BootstrapFromSnapshot(ledgerid, snapshotInfo)
+	assert.EqualError(t, err, "block store for ledger [testLedger] already exists")
+	assert.EqualError(t, mgr.bootstrapFromSnapshot(snapshotInfo), "cannot bootstrap a block storage that is not empty")
+
+	// the bootstrap info survives a restart
+	mgr.close()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, ledgerid)
+	assert.Equal(t, expectedBCInfo, blkfileMgrWrapper.blockfileMgr.getBlockchainInfo())
+
+	// a block that does not follow the last block of the snapshot is rejected
+	assert.EqualError(t, blkfileMgrWrapper.blockfileMgr.addBlock(blocks[11]), "block number should have been 10 but was 11")
+	blkfileMgrWrapper.addBlocks(blocks[10:15])
+	blkfileMgrWrapper.close()
+
+	// the blocks added after bootstrapping survive a restart
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, ledgerid)
+	defer blkfileMgrWrapper.close()
+	blkfileMgrWrapper.addBlocks(blocks[15:])
+	blkfileMgrWrapper.testGetBlockByNumber(blocks[10:], 10)
+	blkfileMgrWrapper.testGetBlockByHash(blocks[10:])
+	itr, err := blkfileMgrWrapper.blockfileMgr.retrieveBlocks(10)
+	assert.NoError(t, err)
+	defer itr.Close()
+	nextBlk, err := itr.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, blocks[10], nextBlk)
+}
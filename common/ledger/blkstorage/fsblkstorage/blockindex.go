@@ -27,7 +27,9 @@ const (
 	blockNumTranNumIdxKeyPrefix    = 'a'
 	blockTxIDIdxKeyPrefix          = 'b'
 	txValidationResultIdxKeyPrefix = 'v'
+	secondaryIdxKeyPrefix          = 'x'
 	indexCheckpointKeyStr          = "indexCheckpointKey"
+	secondaryIdxCheckpointKeyStr   = "secondaryIndexCheckpointKey"
 )
 
 var indexCheckpointKey = []byte(indexCheckpointKeyStr)
@@ -42,6 +44,13 @@ type index interface {
 	getTXLocByBlockNumTranNum(blockNum uint64, tranNum uint64) (*fileLocPointer, error)
 	getBlockLocByTxID(txID string) (*fileLocPointer, error)
 	getTxValidationCodeByTxID(txID string) (peer.TxValidationCode, error)
+	removeBlocks(startBlockNum, endBlockNum uint64) error
+	removeBlockEntries(blockIdxInfo *blockIdxInfo, blockEndOffset int) error
+	setLastBlockIndexed(blockNum uint64) error
+	getSecondaryIndexEntries(indexName string, startKey, endKey []byte) (*leveldbhelper.Iterator, error)
+	getLaggingSecondaryIndexes(firstBlockNum uint64) ([]*laggingSecondaryIndex, error)
+	indexBlockInSecondaryIndexes(blockIdxInfo *blockIdxInfo, indexes []blkstorage.SecondaryIndex) error
+	setSecondaryIndexesSynced(lastBlockNum uint64) error
 }
 
 type blockIdxInfo struct {
@@ -50,11 +59,16 @@ type blockIdxInfo struct {
 	flp       *fileLocPointer
 	txOffsets []*txindexInfo
 	metadata  *common.BlockMetadata
+	data      *common.BlockData
 }
 
 type blockIndex struct {
-	indexItemsMap map[blkstorage.IndexableAttr]bool
-	db            *leveldbhelper.DBHandle
+	indexItemsMap    map[blkstorage.IndexableAttr]bool
+	secondaryIndexes []blkstorage.SecondaryIndex
+	// laggingIndexes contains the names of the secondary indexes that are behind the block index.
+	// These indexes are skipped while indexing a block until they are synced via `setSecondaryIndexesSynced`
+	laggingIndexes map[string]bool
+	db             *leveldbhelper.DBHandle
 }
 
 func newBlockIndex(indexConfig *blkstorage.IndexConfig, db *leveldbhelper.DBHandle) (*blockIndex, error) {
@@ -72,7 +86,11 @@ func newBlockIndex(indexConfig *blkstorage.IndexConfig, db *leveldbhelper.DBHand
 		return nil, errors.Errorf("dependent index [%s] is not enabled for [%s] or [%s]",
 			blkstorage.IndexableAttrTxID, blkstorage.IndexableAttrTxValidationCode, blkstorage.IndexableAttrBlockTxID)
 	}
-	return &blockIndex{indexItemsMap, db}, nil
+	index := &blockIndex{indexItemsMap: indexItemsMap, db: db}
+	if err := index.initSecondaryIndexes(indexConfig.SecondaryIndexes); err != nil {
+		return nil, err
+	}
+	return index, nil
 }
 
 func (index *blockIndex) getLastBlockIndexed() (uint64, error) {
@@ -167,6 +185,11 @@ func (index *blockIndex) indexBlock(blockIdxInfo *blockIdxInfo) error {
 		}
 	}
 
+	// Secondary indexes that are in sync with the block index
+	if err := index.addSecondaryIndexEntries(batch, blockIdxInfo, index.syncedSecondaryIndexes()); err != nil {
+		return err
+	}
+
 	batch.Put(indexCheckpointKey, encodeBlockNum(blockIdxInfo.blockNum))
 	// Setting snyc to true as a precaution, false may be an ok optimization after further testing.
 	if err := index.db.WriteBatch(batch, true); err != nil {
@@ -175,6 +198,86 @@ func (index *blockIndex) indexBlock(blockIdxInfo *blockIdxInfo) error {
 	return nil
 }
 
+// removeBlocks removes the block number based index entries for the blocks in the range [startBlockNum, endBlockNum).
+// The transaction id based entries are retained so that the duplicate txids can still be detected
+func (index *blockIndex) removeBlocks(startBlockNum, endBlockNum uint64) error {
+	if startBlockNum >= endBlockNum {
+		return nil
+	}
+	batch := leveldbhelper.NewUpdateBatch()
+	for _, r := range [][2][]byte{
+		{constructBlockNumKey(startBlockNum), constructBlockNumKey(endBlockNum)},
+		{constructBlockNumTranNumKey(startBlockNum, 0), constructBlockNumTranNumKey(endBlockNum, 0)},
+	} {
+		itr := index.db.GetIterator(r[0], r[1])
+		for itr.Next() {
+			batch.Delete(itr.Key())
+		}
+		itr.Release()
+		if err := itr.Error(); err != nil {
+			return errors.Wrap(err, "error while iterating over the block index")
+		}
+	}
+	if err := index.removeSecondaryIndexEntries(batch, startBlockNum, endBlockNum); err != nil {
+		return err
+	}
+	logger.Debugf("Removing [%d] index entries for blocks [%d] to [%d]", batch.Len(), startBlockNum, endBlockNum-1)
+	return index.db.WriteBatch(batch, true)
+}
+
+// removeBlockEntries removes all the index entries of the given block, which ends at the offset `blockEndOffset`
+// in its block file. A txid based entry is removed only if it points to a transaction within this block, as the
+// entry for a duplicate txid points to the previous transaction with the same txid
+func (index *blockIndex) removeBlockEntries(blockIdxInfo *blockIdxInfo, blockEndOffset int) error {
+	flp := blockIdxInfo.flp
+	batch := leveldbhelper.NewUpdateBatch()
+	batch.Delete(constructBlockHashKey(blockIdxInfo.blockHash))
+	batch.Delete(constructBlockNumKey(blockIdxInfo.blockNum))
+	for txIterator, txoffset := range blockIdxInfo.txOffsets {
+		batch.Delete(constructBlockNumTranNumKey(blockIdxInfo.blockNum, uint64(txIterator)))
+		txFlp, err := index.getTxLoc(txoffset.txID)
+		if err == blkstorage.ErrAttrNotIndexed || err == blkstorage.ErrNotFoundInIndex {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if txFlp.fileSuffixNum != flp.fileSuffixNum || txFlp.offset < flp.offset || txFlp.offset >= blockEndOffset {
+			continue
+		}
+		batch.Delete(constructTxIDKey(txoffset.txID))
+		batch.Delete(constructBlockTxIDKey(txoffset.txID))
+		batch.Delete(constructTxValidationCodeIDKey(txoffset.txID))
+	}
+	err := forEachSecondaryIndexKey(blockIdxInfo, index.secondaryIndexes,
+		func(indexName string, key []byte, tx *blkstorage.IndexedTx, txFlp *fileLocPointer) error {
+			batch.Delete(constructSecondaryIndexKey(indexName, key, tx.BlockNum, tx.TxNum))
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+	logger.Debugf("Removing index entries for block [%d]", blockIdxInfo.blockNum)
+	return index.db.WriteBatch(batch, true)
+}
+
+// setLastBlockIndexed overwrites the block number recorded as the last block indexed. The last block indexed
+// by a secondary index is overwritten as well if it is beyond the given block number
+func (index *blockIndex) setLastBlockIndexed(blockNum uint64) error {
+	batch := leveldbhelper.NewUpdateBatch()
+	batch.Put(indexCheckpointKey, encodeBlockNum(blockNum))
+	for _, secondaryIndex := range index.secondaryIndexes {
+		lastBlockIndexed, exists, err := index.getSecondaryIndexCheckpoint(secondaryIndex.Name())
+		if err != nil {
+			return err
+		}
+		if exists && lastBlockIndexed > blockNum {
+			batch.Put(constructSecondaryIndexCheckpointKey(secondaryIndex.Name()), encodeBlockNum(blockNum))
+		}
+	}
+	return index.db.WriteBatch(batch, true)
+}
+
 func (index *blockIndex) markDuplicateTxids(blockIdxInfo *blockIdxInfo) error {
 	uniqueTxids := make(map[string]bool)
 	for _, txIdxInfo := range blockIdxInfo.txOffsets {
@@ -22,6 +22,7 @@ import (
 
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/peer"
@@ -59,6 +60,34 @@ func (i *noopIndex) getTxValidationCodeByTxID(txID string) (peer.TxValidationCod
 	return peer.TxValidationCode(-1), nil
 }
 
+func (i *noopIndex) removeBlocks(startBlockNum, endBlockNum uint64) error {
+	return nil
+}
+
+func (i *noopIndex) removeBlockEntries(blockIdxInfo *blockIdxInfo, blockEndOffset int) error {
+	return nil
+}
+
+func (i *noopIndex) setLastBlockIndexed(blockNum uint64) error {
+	return nil
+}
+
+func (i *noopIndex) getSecondaryIndexEntries(indexName string, startKey, endKey []byte) (*leveldbhelper.Iterator, error) {
+	return nil, nil
+}
+
+func (i *noopIndex) getLaggingSecondaryIndexes(firstBlockNum uint64) ([]*laggingSecondaryIndex, error) {
+	return nil, nil
+}
+
+func (i *noopIndex) indexBlockInSecondaryIndexes(blockIdxInfo *blockIdxInfo, indexes []blkstorage.SecondaryIndex) error {
+	return nil
+}
+
+func (i *noopIndex) setSecondaryIndexesSynced(lastBlockNum uint64) error {
+	return nil
+}
+
 func TestBlockIndexSync(t *testing.T) {
 	testBlockIndexSync(t, 10, 5, false)
 	testBlockIndexSync(t, 10, 5, true)
@@ -80,6 +80,28 @@ func (store *fsBlockStore) RetrieveTxValidationCodeByTxID(txID string) (peer.TxV
 	return store.fileMgr.retrieveTxValidationCodeByTxID(txID)
 }
 
+// RetrieveTxsByIndexKey returns an iterator over the transactions that have the given key in a secondary index
+func (store *fsBlockStore) RetrieveTxsByIndexKey(indexName string, key []byte) (ledger.ResultsIterator, error) {
+	// the encoded keys of the secondary indexes are prefix free, hence no other key lies between key and key + 0x00
+	endKey := append(append([]byte{}, key...), 0x00)
+	return store.fileMgr.retrieveTxsBySecondaryIndex(indexName, key, endKey)
+}
+
+// RetrieveTxsByIndexRange returns an iterator over the transactions that have a key in a range of a secondary index
+func (store *fsBlockStore) RetrieveTxsByIndexRange(indexName string, startKey, endKey []byte) (ledger.ResultsIterator, error) {
+	return store.fileMgr.retrieveTxsBySecondaryIndex(indexName, startKey, endKey)
+}
+
+// FirstBlockNumber returns the number of the oldest block that has not been pruned
+func (store *fsBlockStore) FirstBlockNumber() (uint64, error) {
+	return store.fileMgr.firstBlockNumber(), nil
+}
+
+// Prune removes the block files that hold only blocks with a number lower than `belowBlockNum`
+func (store *fsBlockStore) Prune(belowBlockNum uint64) error {
+	return store.fileMgr.prune(belowBlockNum)
+}
+
 // Shutdown shuts down the block store
 func (store *fsBlockStore) Shutdown() {
 	logger.Debugf("closing fs blockStore:%s", store.id)
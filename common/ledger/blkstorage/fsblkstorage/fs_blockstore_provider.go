@@ -17,9 +17,12 @@ limitations under the License.
 package fsblkstorage
 
 import (
+	"os"
+
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/util"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/pkg/errors"
 )
 
 // FsBlockstoreProvider provides handle to block storage - this is not thread-safe
@@ -48,6 +51,25 @@ func (p *FsBlockstoreProvider) OpenBlockStore(ledgerid string) (blkstorage.Block
 	return newFsBlockStore(ledgerid, p.conf, p.indexConfig, indexStoreHandle), nil
 }
 
+// BootstrapFromSnapshot creates a block store for given ledgerid that starts with the block next to the
+// last block of the given snapshot. This method fails if a blockstore already exists for the ledgerid
+func (p *FsBlockstoreProvider) BootstrapFromSnapshot(ledgerid string, snapshotInfo *blkstorage.BootstrappingSnapshotInfo) (blkstorage.BlockStore, error) {
+	exists, err := p.Exists(ledgerid)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, errors.Errorf("block store for ledger [%s] already exists", ledgerid)
+	}
+	indexStoreHandle := p.leveldbProvider.GetDBHandle(ledgerid)
+	store := newFsBlockStore(ledgerid, p.conf, p.indexConfig, indexStoreHandle)
+	if err := store.fileMgr.bootstrapFromSnapshot(snapshotInfo); err != nil {
+		store.Shutdown()
+		return nil, err
+	}
+	return store, nil
+}
+
 // Exists tells whether the BlockStore with given id exists
 func (p *FsBlockstoreProvider) Exists(ledgerid string) (bool, error) {
 	exists, _, err := util.FileExists(p.conf.getLedgerBlockDir(ledgerid))
@@ -59,6 +81,26 @@ func (p *FsBlockstoreProvider) List() ([]string, error) {
 	return util.ListSubdirs(p.conf.getChainsDir())
 }
 
+// Remove removes the block files and the index of the BlockStore with given id.
+// The BlockStore must be shut down before it is removed.
+func (p *FsBlockstoreProvider) Remove(ledgerid string) error {
+	indexStoreHandle := p.leveldbProvider.GetDBHandle(ledgerid)
+	itr := indexStoreHandle.GetIterator(nil, nil)
+	batch := leveldbhelper.NewUpdateBatch()
+	for itr.Next() {
+		batch.Delete(append([]byte{}, itr.Key()...))
+	}
+	itr.Release()
+	if err := itr.Error(); err != nil {
+		return errors.Wrapf(err, "error iterating over the index of ledger [%s]", ledgerid)
+	}
+	if err := indexStoreHandle.WriteBatch(batch, true); err != nil {
+		return errors.Wrapf(err, "error removing the index of ledger [%s]", ledgerid)
+	}
+
+	return os.RemoveAll(p.conf.getLedgerBlockDir(ledgerid))
+}
+
 // Close closes the FsBlockstoreProvider
 func (p *FsBlockstoreProvider) Close() {
 	p.leveldbProvider.Close()
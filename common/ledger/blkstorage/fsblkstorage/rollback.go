@@ -0,0 +1,121 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/pkg/errors"
+)
+
+// Rollback removes the blocks after the block `targetBlockNum` from the block storage of the given ledger.
+// The index entries of the removed blocks are deleted and the block files are truncated accordingly.
+// This function is expected to be invoked while the block storage is not in use (e.g., when the peer is stopped).
+// If the rollback gets interrupted, it should be invoked again before the block storage is used
+func Rollback(conf *Conf, indexConfig *blkstorage.IndexConfig, ledgerID string, targetBlockNum uint64) error {
+	p := NewProvider(conf, indexConfig).(*FsBlockstoreProvider)
+	defer p.Close()
+	exists, err := p.Exists(ledgerID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.Errorf("block store for ledger [%s] does not exist", ledgerID)
+	}
+	store := newFsBlockStore(ledgerID, conf, indexConfig, p.leveldbProvider.GetDBHandle(ledgerID))
+	defer store.Shutdown()
+	return store.fileMgr.rollback(targetBlockNum)
+}
+
+// rollback removes the blocks after the block `targetBlockNum`. The index checkpoint is moved back to the
+// target block before removing the index entries so that a crash in between causes the index to be
+// rebuilt from the block files. The checkpoint info is saved before truncating the block files so that
+// the block files left behind by a crash are either synced back or removed on the next start
+func (mgr *blockfileMgr) rollback(targetBlockNum uint64) error {
+	cpInfo := mgr.cpInfo
+	if cpInfo.isChainEmpty {
+		return errors.New("cannot roll back a block storage that does not contain any block")
+	}
+	if targetBlockNum > cpInfo.lastBlockNumber {
+		return errors.Errorf("target block number [%d] should not be greater than the last block number [%d]",
+			targetBlockNum, cpInfo.lastBlockNumber)
+	}
+	if targetBlockNum == cpInfo.lastBlockNumber {
+		logger.Infof("Block storage is already at the target block [%d], nothing to roll back", targetBlockNum)
+		return nil
+	}
+	if firstBlockNum := mgr.firstBlockNumber(); targetBlockNum < firstBlockNum {
+		return errors.Errorf("cannot roll back to block [%d] as the blocks below block [%d] are not available",
+			targetBlockNum, firstBlockNum)
+	}
+
+	targetFLP, err := mgr.index.getBlockLocByBlockNum(targetBlockNum)
+	if err != nil {
+		return errors.WithMessage(err, "error retrieving the location of the target block")
+	}
+	stream, err := newBlockStream(mgr.rootDir, targetFLP.fileSuffixNum, int64(targetFLP.offset), cpInfo.latestFileChunkSuffixNum)
+	if err != nil {
+		return err
+	}
+	defer stream.close()
+	blockBytes, placementInfo, err := stream.nextBlockBytesAndPlacementInfo()
+	if err != nil {
+		return err
+	}
+	if blockBytes == nil {
+		return errors.Errorf("block [%d] is not found in the block files", targetBlockNum)
+	}
+	targetBlockEndOffset := int(placementInfo.blockBytesOffset) + len(blockBytes)
+
+	logger.Infof("Rolling back block storage from block [%d] to block [%d]", cpInfo.lastBlockNumber, targetBlockNum)
+	if err := mgr.index.setLastBlockIndexed(targetBlockNum); err != nil {
+		return err
+	}
+	for {
+		if blockBytes, placementInfo, err = stream.nextBlockBytesAndPlacementInfo(); err != nil {
+			return err
+		}
+		if blockBytes == nil {
+			break
+		}
+		info, err := extractSerializedBlockInfo(blockBytes)
+		if err != nil {
+			return err
+		}
+		blockIdxInfo := &blockIdxInfo{
+			blockNum:  info.blockHeader.Number,
+			blockHash: info.blockHeader.Hash(),
+			flp: &fileLocPointer{fileSuffixNum: placementInfo.fileNum,
+				locPointer: locPointer{offset: int(placementInfo.blockStartOffset)}},
+			txOffsets: info.txOffsets,
+			metadata:  info.metadata,
+			data:      info.data,
+		}
+		blockEndOffset := int(placementInfo.blockBytesOffset) + len(blockBytes)
+		if err := mgr.index.removeBlockEntries(blockIdxInfo, blockEndOffset); err != nil {
+			return err
+		}
+	}
+
+	newCPInfo := &checkpointInfo{
+		latestFileChunkSuffixNum: targetFLP.fileSuffixNum,
+		latestFileChunksize:      targetBlockEndOffset,
+		isChainEmpty:             false,
+		lastBlockNumber:          targetBlockNum,
+	}
+	if err := mgr.saveCurrentInfo(newCPInfo, true); err != nil {
+		return err
+	}
+	writer, err := newBlockfileWriter(deriveBlockfilePath(mgr.rootDir, newCPInfo.latestFileChunkSuffixNum))
+	if err != nil {
+		return err
+	}
+	defer writer.close()
+	if err := writer.truncateFile(newCPInfo.latestFileChunksize); err != nil {
+		return err
+	}
+	return removeBlockfilesAfter(mgr.rootDir, newCPInfo.latestFileChunkSuffixNum)
+}
@@ -0,0 +1,132 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollback(t *testing.T) {
+	blocks := testutil.ConstructTestBlocks(t, 50)
+	size := 0
+	for _, block := range blocks[:10] {
+		by, _, err := serializeBlock(block)
+		assert.NoError(t, err)
+		size += len(by) + len(proto.EncodeVarint(uint64(len(by))))
+	}
+
+	// roughly 10 blocks per file
+	conf := NewConf(testPath(), size)
+	env := newTestEnv(t, conf)
+	defer env.Cleanup()
+	ledgerid := "testLedger"
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, ledgerid)
+	blkfileMgrWrapper.addBlocks(blocks)
+	rootDir := blkfileMgrWrapper.blockfileMgr.rootDir
+	targetLoc, err := blkfileMgrWrapper.blockfileMgr.index.getBlockLocByBlockNum(23)
+	assert.NoError(t, err)
+	assert.True(t, blkfileMgrWrapper.blockfileMgr.cpInfo.latestFileChunkSuffixNum > targetLoc.fileSuffixNum)
+	blkfileMgrWrapper.close()
+	env.provider.Close()
+
+	assert.NoError(t, Rollback(conf, env.provider.indexConfig, ledgerid, 23))
+	_, err = os.Stat(deriveBlockfilePath(rootDir, targetLoc.fileSuffixNum+1))
+	assert.True(t, os.IsNotExist(err))
+
+	env = newTestEnv(t, conf)
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, ledgerid)
+	defer blkfileMgrWrapper.close()
+	mgr := blkfileMgrWrapper.blockfileMgr
+	bcInfo := mgr.getBlockchainInfo()
+	assert.Equal(t, uint64(24), bcInfo.Height)
+	assert.Equal(t, blocks[23].Header.Hash(), bcInfo.CurrentBlockHash)
+	blkfileMgrWrapper.testGetBlockByNumber(blocks[:24], 0)
+
+	// the index entries of the removed blocks are deleted
+	_, err = mgr.retrieveBlockByNumber(24)
+	assert.Equal(t, blkstorage.ErrNotFoundInIndex, err)
+	_, err = mgr.retrieveBlockByHash(blocks[24].Header.Hash())
+	assert.Equal(t, blkstorage.ErrNotFoundInIndex, err)
+	txID, err := extractTxID(blocks[24].Data.Data[0])
+	assert.NoError(t, err)
+	_, err = mgr.retrieveTransactionByID(txID)
+	assert.Equal(t, blkstorage.ErrNotFoundInIndex, err)
+	_, err = mgr.retrieveTxValidationCodeByTxID(txID)
+	assert.Equal(t, blkstorage.ErrNotFoundInIndex, err)
+	_, err = mgr.retrieveTransactionByBlockNumTranNum(24, 0)
+	assert.Equal(t, blkstorage.ErrNotFoundInIndex, err)
+
+	// the removed blocks can be added again
+	blkfileMgrWrapper.addBlocks(blocks[24:])
+	blkfileMgrWrapper.testGetBlockByNumber(blocks, 0)
+	blkfileMgrWrapper.testGetBlockByHash(blocks)
+	blk, err := mgr.retrieveBlockByTxID(txID)
+	assert.NoError(t, err)
+	assert.Equal(t, blocks[24], blk)
+}
+
+func TestRollbackWithDuplicateTxids(t *testing.T) {
+	conf := NewConf(testPath(), 0)
+	env := newTestEnv(t, conf)
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	block1 := bg.NextBlockWithTxid([][]byte{[]byte("tx with id=txid-1")}, []string{"txid-1"})
+	block2 := bg.NextBlockWithTxid(
+		[][]byte{[]byte("another tx with existing id=txid-1"), []byte("tx with id=txid-2")},
+		[]string{"txid-1", "txid-2"},
+	)
+	blkfileMgrWrapper.addBlocks([]*common.Block{gb, block1, block2})
+	blkfileMgrWrapper.close()
+	env.provider.Close()
+
+	assert.NoError(t, Rollback(conf, env.provider.indexConfig, "testLedger", 1))
+
+	env = newTestEnv(t, conf)
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	mgr := blkfileMgrWrapper.blockfileMgr
+	blk, err := mgr.retrieveBlockByTxID("txid-1")
+	assert.NoError(t, err)
+	assert.Equal(t, block1, blk)
+	_, err = mgr.retrieveBlockByTxID("txid-2")
+	assert.Equal(t, blkstorage.ErrNotFoundInIndex, err)
+}
+
+func TestRollbackErrors(t *testing.T) {
+	blocks := testutil.ConstructTestBlocks(t, 30)
+	by, _, err := serializeBlock(blocks[1])
+	assert.NoError(t, err)
+	conf := NewConf(testPath(), 5*len(by))
+	env := newTestEnv(t, conf)
+	defer env.Cleanup()
+	indexConfig := env.provider.indexConfig
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgrWrapper.addBlocks(blocks)
+	assert.NoError(t, blkfileMgrWrapper.blockfileMgr.prune(20))
+	firstBlockNum := blkfileMgrWrapper.blockfileMgr.firstBlockNumber()
+	blkfileMgrWrapper.close()
+	env.provider.Close()
+
+	err = Rollback(conf, indexConfig, "non-existing-ledger", 5)
+	assert.EqualError(t, err, "block store for ledger [non-existing-ledger] does not exist")
+	err = Rollback(conf, indexConfig, "testLedger", 30)
+	assert.EqualError(t, err, "target block number [30] should not be greater than the last block number [29]")
+	err = Rollback(conf, indexConfig, "testLedger", firstBlockNum-1)
+	assert.EqualError(t, err, fmt.Sprintf("cannot roll back to block [%d] as the blocks below block [%d] are not available",
+		firstBlockNum-1, firstBlockNum))
+	// rolling back to the last block is a no-op
+	assert.NoError(t, Rollback(conf, indexConfig, "testLedger", 29))
+}
@@ -0,0 +1,447 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	ledgerUtil "github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	putil "github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// The entries of the secondary indexes are stored in the block index db under the keys
+//   secondaryIdxKeyPrefix + indexName + 0x00 + encoded key + encoded block number + encoded tran number
+// A key is encoded by escaping the byte 0x00 as 0x00 0xFF and by terminating it with 0x00 0x01. This preserves
+// the order of the keys and makes sure that an encoded key is not a prefix of another encoded key. The value of
+// an entry contains the location and the validation code of the transaction. The last block indexed by a secondary
+// index is stored under the key secondaryIdxCheckpointKeyStr + indexName
+const secondaryIdxNameSep = byte(0x00)
+
+// laggingSecondaryIndex is a secondary index that is behind the block index along with the next block to be indexed
+type laggingSecondaryIndex struct {
+	index        blkstorage.SecondaryIndex
+	nextBlockNum uint64
+}
+
+// secondaryIndexEntry is the value of an entry in a secondary index
+type secondaryIndexEntry struct {
+	blockNum       uint64
+	txNum          uint64
+	validationCode peer.TxValidationCode
+	txFlp          *fileLocPointer
+}
+
+// initSecondaryIndexes validates the given secondary indexes and detects the ones that are behind the block index.
+// If the block index is empty, all the secondary indexes are built along with the block index
+func (index *blockIndex) initSecondaryIndexes(secondaryIndexes []blkstorage.SecondaryIndex) error {
+	if len(secondaryIndexes) == 0 {
+		return nil
+	}
+	if !index.indexItemsMap[blkstorage.IndexableAttrBlockNum] {
+		return errors.Errorf("dependent index [%s] is not enabled for the secondary indexes", blkstorage.IndexableAttrBlockNum)
+	}
+	lastBlockIndexed, err := index.getLastBlockIndexed()
+	indexEmpty := err == errIndexEmpty
+	if err != nil && !indexEmpty {
+		return err
+	}
+	index.laggingIndexes = make(map[string]bool)
+	names := make(map[string]bool)
+	for _, secondaryIndex := range secondaryIndexes {
+		name := secondaryIndex.Name()
+		if name == "" || strings.IndexByte(name, secondaryIdxNameSep) >= 0 {
+			return errors.Errorf("invalid secondary index name [%s]", name)
+		}
+		if names[name] {
+			return errors.Errorf("secondary index [%s] is configured more than once", name)
+		}
+		names[name] = true
+		if indexEmpty {
+			continue
+		}
+		secondaryLastBlockIndexed, exists, err := index.getSecondaryIndexCheckpoint(name)
+		if err != nil {
+			return err
+		}
+		if !exists || secondaryLastBlockIndexed != lastBlockIndexed {
+			logger.Debugf("Secondary index [%s] is behind the block index", name)
+			index.laggingIndexes[name] = true
+		}
+	}
+	index.secondaryIndexes = secondaryIndexes
+	return nil
+}
+
+func (index *blockIndex) getSecondaryIndexCheckpoint(indexName string) (uint64, bool, error) {
+	blockNumBytes, err := index.db.Get(constructSecondaryIndexCheckpointKey(indexName))
+	if err != nil || blockNumBytes == nil {
+		return 0, false, err
+	}
+	return decodeBlockNum(blockNumBytes), true, nil
+}
+
+// syncedSecondaryIndexes returns the secondary indexes that are in sync with the block index
+func (index *blockIndex) syncedSecondaryIndexes() []blkstorage.SecondaryIndex {
+	if len(index.laggingIndexes) == 0 {
+		return index.secondaryIndexes
+	}
+	var synced []blkstorage.SecondaryIndex
+	for _, secondaryIndex := range index.secondaryIndexes {
+		if !index.laggingIndexes[secondaryIndex.Name()] {
+			synced = append(synced, secondaryIndex)
+		}
+	}
+	return synced
+}
+
+// getLaggingSecondaryIndexes returns the secondary indexes that are behind the block index. A secondary index
+// that has never been built starts with the first available block
+func (index *blockIndex) getLaggingSecondaryIndexes(firstBlockNum uint64) ([]*laggingSecondaryIndex, error) {
+	var lagging []*laggingSecondaryIndex
+	for _, secondaryIndex := range index.secondaryIndexes {
+		if !index.laggingIndexes[secondaryIndex.Name()] {
+			continue
+		}
+		lastBlockIndexed, exists, err := index.getSecondaryIndexCheckpoint(secondaryIndex.Name())
+		if err != nil {
+			return nil, err
+		}
+		nextBlockNum := firstBlockNum
+		if exists && lastBlockIndexed+1 > firstBlockNum {
+			nextBlockNum = lastBlockIndexed + 1
+		}
+		lagging = append(lagging, &laggingSecondaryIndex{secondaryIndex, nextBlockNum})
+	}
+	return lagging, nil
+}
+
+// indexBlockInSecondaryIndexes adds the entries of the given block to the given secondary indexes only
+func (index *blockIndex) indexBlockInSecondaryIndexes(blockIdxInfo *blockIdxInfo, indexes []blkstorage.SecondaryIndex) error {
+	batch := leveldbhelper.NewUpdateBatch()
+	if err := index.addSecondaryIndexEntries(batch, blockIdxInfo, indexes); err != nil {
+		return err
+	}
+	return index.db.WriteBatch(batch, true)
+}
+
+// setSecondaryIndexesSynced records that the lagging secondary indexes have indexed the blocks up to the
+// given block, after which these indexes are maintained along with the block index
+func (index *blockIndex) setSecondaryIndexesSynced(lastBlockNum uint64) error {
+	batch := leveldbhelper.NewUpdateBatch()
+	for name := range index.laggingIndexes {
+		batch.Put(constructSecondaryIndexCheckpointKey(name), encodeBlockNum(lastBlockNum))
+	}
+	if err := index.db.WriteBatch(batch, true); err != nil {
+		return err
+	}
+	index.laggingIndexes = make(map[string]bool)
+	return nil
+}
+
+// addSecondaryIndexEntries adds to the batch the entries of the given block for the given secondary indexes
+// and moves the checkpoints of these indexes to the block
+func (index *blockIndex) addSecondaryIndexEntries(batch *leveldbhelper.UpdateBatch, blockIdxInfo *blockIdxInfo,
+	indexes []blkstorage.SecondaryIndex) error {
+	err := forEachSecondaryIndexKey(blockIdxInfo, indexes,
+		func(indexName string, key []byte, tx *blkstorage.IndexedTx, txFlp *fileLocPointer) error {
+			entry := &secondaryIndexEntry{tx.BlockNum, tx.TxNum, tx.ValidationCode, txFlp}
+			entryBytes, err := entry.marshal()
+			if err != nil {
+				return err
+			}
+			batch.Put(constructSecondaryIndexKey(indexName, key, tx.BlockNum, tx.TxNum), entryBytes)
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+	for _, secondaryIndex := range indexes {
+		batch.Put(constructSecondaryIndexCheckpointKey(secondaryIndex.Name()), encodeBlockNum(blockIdxInfo.blockNum))
+	}
+	return nil
+}
+
+// removeSecondaryIndexEntries adds to the batch the removal of the entries of all the secondary indexes
+// that refer to the blocks in the range [startBlockNum, endBlockNum)
+func (index *blockIndex) removeSecondaryIndexEntries(batch *leveldbhelper.UpdateBatch, startBlockNum, endBlockNum uint64) error {
+	itr := index.db.GetIterator([]byte{secondaryIdxKeyPrefix}, []byte{secondaryIdxKeyPrefix + 1})
+	defer itr.Release()
+	for itr.Next() {
+		entry := &secondaryIndexEntry{}
+		if err := entry.unmarshal(itr.Value()); err != nil {
+			return err
+		}
+		if entry.blockNum >= startBlockNum && entry.blockNum < endBlockNum {
+			batch.Delete(itr.Key())
+		}
+	}
+	return errors.Wrap(itr.Error(), "error while iterating over the secondary indexes")
+}
+
+// getSecondaryIndexEntries returns an iterator over the entries of the given secondary index
+// that have a key in the range [startKey, endKey). A nil endKey refers to the end of the index
+func (index *blockIndex) getSecondaryIndexEntries(indexName string, startKey, endKey []byte) (*leveldbhelper.Iterator, error) {
+	indexed := false
+	for _, secondaryIndex := range index.secondaryIndexes {
+		indexed = indexed || secondaryIndex.Name() == indexName
+	}
+	if !indexed {
+		return nil, blkstorage.ErrAttrNotIndexed
+	}
+	start := append(constructSecondaryIndexKeysPrefix(indexName), encodeSecondaryIndexKey(startKey)...)
+	var end []byte
+	if endKey == nil {
+		end = constructSecondaryIndexKeysPrefix(indexName)
+		end[len(end)-1]++
+	} else {
+		end = append(constructSecondaryIndexKeysPrefix(indexName), encodeSecondaryIndexKey(endKey)...)
+	}
+	return index.db.GetIterator(start, end), nil
+}
+
+// forEachSecondaryIndexKey invokes the given function for each key of each transaction of the block in the given indexes
+func forEachSecondaryIndexKey(blockIdxInfo *blockIdxInfo, indexes []blkstorage.SecondaryIndex,
+	f func(indexName string, key []byte, tx *blkstorage.IndexedTx, txFlp *fileLocPointer) error) error {
+	if len(indexes) == 0 {
+		return nil
+	}
+	if blockIdxInfo.data == nil || len(blockIdxInfo.data.Data) != len(blockIdxInfo.txOffsets) {
+		return errors.Errorf("transactions of block [%d] are not available for the secondary indexes", blockIdxInfo.blockNum)
+	}
+	flp := blockIdxInfo.flp
+	txsfltr := ledgerUtil.TxValidationFlags(blockIdxInfo.metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	for txNum, txoffset := range blockIdxInfo.txOffsets {
+		validationCode := peer.TxValidationCode_NOT_VALIDATED
+		if txNum < len(txsfltr) {
+			validationCode = txsfltr.Flag(txNum)
+		}
+		tx := newIndexedTx(blockIdxInfo.blockNum, uint64(txNum), validationCode, blockIdxInfo.data.Data[txNum])
+		txFlp := newFileLocationPointer(flp.fileSuffixNum, flp.offset, txoffset.loc)
+		for _, secondaryIndex := range indexes {
+			keys, err := secondaryIndex.Keys(tx)
+			if err != nil {
+				return errors.WithMessage(err, fmt.Sprintf("error computing the keys of tx [%s] for secondary index [%s]",
+					txoffset.txID, secondaryIndex.Name()))
+			}
+			for _, key := range keys {
+				if err := f(secondaryIndex.Name(), key, tx, txFlp); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// newIndexedTx parses the headers of the given transaction. A header that cannot be parsed is left nil,
+// as a block may contain malformed transactions that are marked invalid
+func newIndexedTx(blockNum, txNum uint64, validationCode peer.TxValidationCode, txEnvelopeBytes []byte) *blkstorage.IndexedTx {
+	tx := &blkstorage.IndexedTx{BlockNum: blockNum, TxNum: txNum, ValidationCode: validationCode}
+	txEnvelope, err := putil.GetEnvelopeFromBlock(txEnvelopeBytes)
+	if err != nil {
+		return tx
+	}
+	tx.Envelope = txEnvelope
+	txPayload, err := putil.GetPayload(txEnvelope)
+	if err != nil || txPayload.Header == nil {
+		return tx
+	}
+	if chdr, err := putil.UnmarshalChannelHeader(txPayload.Header.ChannelHeader); err == nil {
+		tx.ChannelHeader = chdr
+	}
+	if shdr, err := putil.GetSignatureHeader(txPayload.Header.SignatureHeader); err == nil {
+		tx.SignatureHeader = shdr
+	}
+	return tx
+}
+
+// syncSecondaryIndexes builds the entries of the secondary indexes that are behind the block index. A secondary
+// index that is added to the index config of an existing block store is built from the first available block, and
+// a secondary index that was left out of the index config for a while is built from the block next to the last
+// block that it indexed
+func (mgr *blockfileMgr) syncSecondaryIndexes() error {
+	laggingIndexes, err := mgr.index.getLaggingSecondaryIndexes(mgr.firstBlockNumber())
+	if err != nil || len(laggingIndexes) == 0 {
+		return err
+	}
+	lastBlockNum := mgr.cpInfo.lastBlockNumber
+	startBlockNum := lastBlockNum + 1
+	for _, laggingIndex := range laggingIndexes {
+		if laggingIndex.nextBlockNum < startBlockNum {
+			startBlockNum = laggingIndex.nextBlockNum
+		}
+	}
+	if startBlockNum <= lastBlockNum {
+		logger.Infof("Start building secondary indexes from block [%d] to last block [%d]", startBlockNum, lastBlockNum)
+		if err := mgr.buildSecondaryIndexes(startBlockNum, laggingIndexes); err != nil {
+			return err
+		}
+		logger.Infof("Finished building secondary indexes. Last block indexed [%d]", lastBlockNum)
+	}
+	return mgr.index.setSecondaryIndexesSynced(lastBlockNum)
+}
+
+func (mgr *blockfileMgr) buildSecondaryIndexes(startBlockNum uint64, laggingIndexes []*laggingSecondaryIndex) error {
+	flp, err := mgr.index.getBlockLocByBlockNum(startBlockNum)
+	if err != nil {
+		return errors.WithMessage(err, "error retrieving the location of the first block to be indexed")
+	}
+	stream, err := newBlockStream(mgr.rootDir, flp.fileSuffixNum, int64(flp.offset), mgr.cpInfo.latestFileChunkSuffixNum)
+	if err != nil {
+		return err
+	}
+	defer stream.close()
+	for {
+		blockBytes, placementInfo, err := stream.nextBlockBytesAndPlacementInfo()
+		if err != nil {
+			return err
+		}
+		if blockBytes == nil {
+			return nil
+		}
+		info, err := extractSerializedBlockInfo(blockBytes)
+		if err != nil {
+			return err
+		}
+		numBytesToShift := int(placementInfo.blockBytesOffset - placementInfo.blockStartOffset)
+		for _, offset := range info.txOffsets {
+			offset.loc.offset += numBytesToShift
+		}
+		blockIdxInfo := &blockIdxInfo{
+			blockNum:  info.blockHeader.Number,
+			blockHash: info.blockHeader.Hash(),
+			flp: &fileLocPointer{fileSuffixNum: placementInfo.fileNum,
+				locPointer: locPointer{offset: int(placementInfo.blockStartOffset)}},
+			txOffsets: info.txOffsets,
+			metadata:  info.metadata,
+			data:      info.data,
+		}
+		var indexes []blkstorage.SecondaryIndex
+		for _, laggingIndex := range laggingIndexes {
+			if laggingIndex.nextBlockNum <= blockIdxInfo.blockNum {
+				indexes = append(indexes, laggingIndex.index)
+			}
+		}
+		if err := mgr.index.indexBlockInSecondaryIndexes(blockIdxInfo, indexes); err != nil {
+			return err
+		}
+		if blockIdxInfo.blockNum%10000 == 0 {
+			logger.Infof("Indexed block number [%d] in secondary indexes", blockIdxInfo.blockNum)
+		}
+	}
+}
+
+func (mgr *blockfileMgr) retrieveTxsBySecondaryIndex(indexName string, startKey, endKey []byte) (*secondaryIndexItr, error) {
+	logger.Debugf("retrieveTxsBySecondaryIndex() - indexName = [%s], startKey = [%#v], endKey = [%#v]", indexName, startKey, endKey)
+	dbItr, err := mgr.index.getSecondaryIndexEntries(indexName, startKey, endKey)
+	if err != nil {
+		return nil, err
+	}
+	return &secondaryIndexItr{mgr: mgr, dbItr: dbItr}, nil
+}
+
+// secondaryIndexItr is an iterator over the transactions in a range of a secondary index
+type secondaryIndexItr struct {
+	mgr   *blockfileMgr
+	dbItr *leveldbhelper.Iterator
+}
+
+// Next returns the next transaction as *peer.ProcessedTransaction. The transactions in the pruned blocks are skipped
+func (itr *secondaryIndexItr) Next() (ledger.QueryResult, error) {
+	for itr.dbItr.Next() {
+		entry := &secondaryIndexEntry{}
+		if err := entry.unmarshal(itr.dbItr.Value()); err != nil {
+			return nil, err
+		}
+		txEnvelope, err := itr.mgr.fetchTransactionEnvelope(entry.txFlp)
+		if err == blkstorage.ErrPruned {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &peer.ProcessedTransaction{TransactionEnvelope: txEnvelope, ValidationCode: int32(entry.validationCode)}, nil
+	}
+	return nil, errors.Wrap(itr.dbItr.Error(), "error while iterating over the secondary index")
+}
+
+// Close releases the db iterator
+func (itr *secondaryIndexItr) Close() {
+	itr.dbItr.Release()
+}
+
+func (e *secondaryIndexEntry) marshal() ([]byte, error) {
+	flpBytes, err := e.txFlp.marshal()
+	if err != nil {
+		return nil, err
+	}
+	buffer := proto.NewBuffer([]byte{})
+	for _, val := range []uint64{e.blockNum, e.txNum, uint64(e.validationCode)} {
+		if err := buffer.EncodeVarint(val); err != nil {
+			return nil, err
+		}
+	}
+	if err := buffer.EncodeRawBytes(flpBytes); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func (e *secondaryIndexEntry) unmarshal(b []byte) error {
+	buffer := proto.NewBuffer(b)
+	vals := make([]uint64, 3)
+	for i := range vals {
+		val, err := buffer.DecodeVarint()
+		if err != nil {
+			return errors.Wrap(err, "error decoding the secondary index entry")
+		}
+		vals[i] = val
+	}
+	flpBytes, err := buffer.DecodeRawBytes(false)
+	if err != nil {
+		return errors.Wrap(err, "error decoding the secondary index entry")
+	}
+	e.blockNum, e.txNum, e.validationCode = vals[0], vals[1], peer.TxValidationCode(vals[2])
+	e.txFlp = &fileLocPointer{}
+	return e.txFlp.unmarshal(flpBytes)
+}
+
+func constructSecondaryIndexKeysPrefix(indexName string) []byte {
+	prefix := append([]byte{secondaryIdxKeyPrefix}, indexName...)
+	return append(prefix, secondaryIdxNameSep)
+}
+
+func constructSecondaryIndexKey(indexName string, key []byte, blockNum uint64, txNum uint64) []byte {
+	k := append(constructSecondaryIndexKeysPrefix(indexName), encodeSecondaryIndexKey(key)...)
+	k = append(k, util.EncodeOrderPreservingVarUint64(blockNum)...)
+	return append(k, util.EncodeOrderPreservingVarUint64(txNum)...)
+}
+
+func constructSecondaryIndexCheckpointKey(indexName string) []byte {
+	return append([]byte(secondaryIdxCheckpointKeyStr), indexName...)
+}
+
+func encodeSecondaryIndexKey(key []byte) []byte {
+	encoded := make([]byte, 0, len(key)+2)
+	for _, b := range key {
+		if b == 0x00 {
+			encoded = append(encoded, 0x00, 0xFF)
+			continue
+		}
+		encoded = append(encoded, b)
+	}
+	return append(encoded, 0x00, 0x01)
+}
@@ -0,0 +1,322 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	lutils "github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	putil "github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// blockParityIndex indexes the transactions by the parity of their block number
+// and additionally the transactions of the genesis block by the key "genesis"
+type blockParityIndex struct{}
+
+func (blockParityIndex) Name() string {
+	return "BlockParity"
+}
+
+func (blockParityIndex) Keys(tx *blkstorage.IndexedTx) ([][]byte, error) {
+	keys := [][]byte{[]byte("even")}
+	if tx.BlockNum%2 == 1 {
+		keys = [][]byte{[]byte("odd")}
+	}
+	if tx.BlockNum == 0 {
+		keys = append(keys, []byte("genesis"))
+	}
+	return keys, nil
+}
+
+func newTestEnvWithSecondaryIndexes(t testing.TB, conf *Conf) *testEnv {
+	env := newTestEnv(t, conf)
+	env.provider.indexConfig.SecondaryIndexes = []blkstorage.SecondaryIndex{
+		blkstorage.ChaincodeNameIndex{},
+		blkstorage.TxTimestampIndex{},
+		blockParityIndex{},
+	}
+	return env
+}
+
+// constructTestBlocksWithChaincodes constructs a genesis block followed by the given number of blocks, each
+// of which contains a transaction for the chaincode "cc1" and a transaction for the chaincode "cc2"
+func constructTestBlocksWithChaincodes(t *testing.T, numBlocks int) []*common.Block {
+	_, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	blocks := []*common.Block{gb}
+	for i := 1; i <= numBlocks; i++ {
+		blockDetails := &testutil.BlockDetails{
+			BlockNum:     uint64(i),
+			PreviousHash: blocks[i-1].Header.Hash(),
+		}
+		for _, ccName := range []string{"cc1", "cc2"} {
+			blockDetails.Txs = append(blockDetails.Txs, &testutil.TxDetails{
+				TxID:              fmt.Sprintf("txid-%d-%s", i, ccName),
+				ChaincodeName:     ccName,
+				ChaincodeVersion:  "v1",
+				SimulationResults: []byte("results"),
+			})
+		}
+		blocks = append(blocks, testutil.ConstructBlockFromBlockDetails(t, blockDetails, false))
+	}
+	return blocks
+}
+
+func TestSecondaryIndexes(t *testing.T) {
+	env := newTestEnvWithSecondaryIndexes(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	store, err := env.provider.OpenBlockStore("testLedger")
+	assert.NoError(t, err)
+	defer store.Shutdown()
+
+	blocks := constructTestBlocksWithChaincodes(t, 4)
+	blocks[3].Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = lutils.NewTxValidationFlagsSetValue(
+		2, peer.TxValidationCode_MVCC_READ_CONFLICT)
+	for _, block := range blocks {
+		assert.NoError(t, store.AddBlock(block))
+	}
+
+	t.Run("retrieveByKey", func(t *testing.T) {
+		results := retrieveTxsByIndexKey(t, store, blkstorage.SecondaryIndexChaincodeName, "cc1")
+		assert.Equal(t, []string{"txid-1-cc1", "txid-2-cc1", "txid-3-cc1", "txid-4-cc1"}, txIDsOf(t, results))
+		assert.Equal(t, int32(peer.TxValidationCode_VALID), results[1].ValidationCode)
+		assert.Equal(t, int32(peer.TxValidationCode_MVCC_READ_CONFLICT), results[2].ValidationCode)
+
+		results = retrieveTxsByIndexKey(t, store, "BlockParity", "odd")
+		assert.Equal(t, []string{"txid-1-cc1", "txid-1-cc2", "txid-3-cc1", "txid-3-cc2"}, txIDsOf(t, results))
+		results = retrieveTxsByIndexKey(t, store, "BlockParity", "genesis")
+		assert.Len(t, results, 1)
+		results = retrieveTxsByIndexKey(t, store, blkstorage.SecondaryIndexChaincodeName, "cc")
+		assert.Len(t, results, 0)
+	})
+
+	t.Run("retrieveByRange", func(t *testing.T) {
+		itr, err := store.RetrieveTxsByIndexRange(blkstorage.SecondaryIndexChaincodeName, []byte("cc2"), nil)
+		assert.NoError(t, err)
+		results := collectTxs(t, itr)
+		assert.Equal(t, []string{"txid-1-cc2", "txid-2-cc2", "txid-3-cc2", "txid-4-cc2"}, txIDsOf(t, results))
+
+		// the genesis block is indexed by both "even" and "genesis"
+		genesisTxID := txIDOf(t, blocks[0].Data.Data[0])
+		itr, err = store.RetrieveTxsByIndexRange("BlockParity", []byte("even"), []byte("odd"))
+		assert.NoError(t, err)
+		results = collectTxs(t, itr)
+		assert.Equal(t, []string{genesisTxID, "txid-2-cc1", "txid-2-cc2", "txid-4-cc1", "txid-4-cc2", genesisTxID}, txIDsOf(t, results))
+	})
+
+	t.Run("retrieveByTimeRange", func(t *testing.T) {
+		var expectedTxIDs []string
+		startTime := txTimestamp(t, blocks[2].Data.Data[0])
+		endTime := txTimestamp(t, blocks[4].Data.Data[1])
+		for _, block := range blocks[1:] {
+			for _, txBytes := range block.Data.Data {
+				if ts := txTimestamp(t, txBytes); !ts.Before(startTime) && ts.Before(endTime) {
+					expectedTxIDs = append(expectedTxIDs, txIDOf(t, txBytes))
+				}
+			}
+		}
+		itr, err := store.RetrieveTxsByIndexRange(blkstorage.SecondaryIndexTxTimestamp,
+			blkstorage.EncodeTxTimestampKey(startTime), blkstorage.EncodeTxTimestampKey(endTime))
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, expectedTxIDs, txIDsOf(t, collectTxs(t, itr)))
+	})
+
+	t.Run("notIndexed", func(t *testing.T) {
+		_, err := store.RetrieveTxsByIndexKey(blkstorage.SecondaryIndexCreatorMSPID, []byte("Org1MSP"))
+		assert.Equal(t, blkstorage.ErrAttrNotIndexed, err)
+	})
+}
+
+func TestSecondaryIndexesBuiltForExistingBlocks(t *testing.T) {
+	conf := NewConf(testPath(), 0)
+	env := newTestEnv(t, conf)
+	blocks := constructTestBlocksWithChaincodes(t, 6)
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgrWrapper.addBlocks(blocks[:4])
+	blkfileMgrWrapper.close()
+	env.provider.Close()
+
+	// the secondary indexes are built for the existing blocks when the block store is opened
+	env = newTestEnvWithSecondaryIndexes(t, conf)
+	store, err := env.provider.OpenBlockStore("testLedger")
+	assert.NoError(t, err)
+	results := retrieveTxsByIndexKey(t, store, blkstorage.SecondaryIndexChaincodeName, "cc2")
+	assert.Equal(t, []string{"txid-1-cc2", "txid-2-cc2", "txid-3-cc2"}, txIDsOf(t, results))
+	lastBlockIndexed, exists, err := store.(*fsBlockStore).fileMgr.index.(*blockIndex).getSecondaryIndexCheckpoint("BlockParity")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, uint64(3), lastBlockIndexed)
+
+	// the new blocks are indexed as they are added
+	assert.NoError(t, store.AddBlock(blocks[4]))
+	results = retrieveTxsByIndexKey(t, store, blkstorage.SecondaryIndexChaincodeName, "cc2")
+	assert.Equal(t, []string{"txid-1-cc2", "txid-2-cc2", "txid-3-cc2", "txid-4-cc2"}, txIDsOf(t, results))
+	store.Shutdown()
+	env.provider.Close()
+
+	// a secondary index that is left out of the index config for a while catches up with the blocks added meanwhile
+	env = newTestEnv(t, conf)
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgrWrapper.addBlocks(blocks[5:])
+	blkfileMgrWrapper.close()
+	env.provider.Close()
+
+	env = newTestEnvWithSecondaryIndexes(t, conf)
+	defer env.Cleanup()
+	store, err = env.provider.OpenBlockStore("testLedger")
+	assert.NoError(t, err)
+	defer store.Shutdown()
+	results = retrieveTxsByIndexKey(t, store, blkstorage.SecondaryIndexChaincodeName, "cc2")
+	assert.Equal(t, []string{"txid-1-cc2", "txid-2-cc2", "txid-3-cc2", "txid-4-cc2", "txid-5-cc2", "txid-6-cc2"}, txIDsOf(t, results))
+}
+
+func TestSecondaryIndexesRollback(t *testing.T) {
+	conf := NewConf(testPath(), 0)
+	env := newTestEnvWithSecondaryIndexes(t, conf)
+	blocks := constructTestBlocksWithChaincodes(t, 5)
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgrWrapper.addBlocks(blocks)
+	blkfileMgrWrapper.close()
+	env.provider.Close()
+
+	assert.NoError(t, Rollback(conf, env.provider.indexConfig, "testLedger", 2))
+
+	env = newTestEnvWithSecondaryIndexes(t, conf)
+	defer env.Cleanup()
+	store, err := env.provider.OpenBlockStore("testLedger")
+	assert.NoError(t, err)
+	defer store.Shutdown()
+	results := retrieveTxsByIndexKey(t, store, blkstorage.SecondaryIndexChaincodeName, "cc1")
+	assert.Equal(t, []string{"txid-1-cc1", "txid-2-cc1"}, txIDsOf(t, results))
+
+	// the removed blocks are indexed again when they are committed again
+	assert.NoError(t, store.AddBlock(blocks[3]))
+	results = retrieveTxsByIndexKey(t, store, blkstorage.SecondaryIndexChaincodeName, "cc1")
+	assert.Equal(t, []string{"txid-1-cc1", "txid-2-cc1", "txid-3-cc1"}, txIDsOf(t, results))
+}
+
+func TestSecondaryIndexesPrune(t *testing.T) {
+	blocks := constructTestBlocksWithChaincodes(t, 20)
+	by, _, err := serializeBlock(blocks[1])
+	assert.NoError(t, err)
+	// roughly 5 blocks per file
+	env := newTestEnvWithSecondaryIndexes(t, NewConf(testPath(), 5*(len(by)+2)))
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	blkfileMgrWrapper.addBlocks(blocks)
+	mgr := blkfileMgrWrapper.blockfileMgr
+
+	assert.NoError(t, mgr.prune(12))
+	firstBlockNum := mgr.firstBlockNumber()
+	assert.True(t, firstBlockNum > 0)
+	itr, err := mgr.retrieveTxsBySecondaryIndex(blkstorage.SecondaryIndexChaincodeName, []byte("cc1"), []byte("cc1\x00"))
+	assert.NoError(t, err)
+	results := collectTxs(t, itr)
+	assert.Len(t, results, 20-int(firstBlockNum)+1)
+	assert.Equal(t, fmt.Sprintf("txid-%d-cc1", firstBlockNum), txIDsOf(t, results)[0])
+
+	// the entries of the pruned blocks are removed
+	dbItr := mgr.db.GetIterator([]byte{secondaryIdxKeyPrefix}, []byte{secondaryIdxKeyPrefix + 1})
+	defer dbItr.Release()
+	for dbItr.Next() {
+		entry := &secondaryIndexEntry{}
+		assert.NoError(t, entry.unmarshal(dbItr.Value()))
+		assert.True(t, entry.blockNum >= firstBlockNum)
+	}
+}
+
+func TestSecondaryIndexesWrongConfig(t *testing.T) {
+	testCases := []struct {
+		name             string
+		attrsToIndex     []blkstorage.IndexableAttr
+		secondaryIndexes []blkstorage.SecondaryIndex
+	}{
+		{
+			name:             "missingBlockNumIndex",
+			attrsToIndex:     []blkstorage.IndexableAttr{blkstorage.IndexableAttrBlockHash},
+			secondaryIndexes: []blkstorage.SecondaryIndex{blkstorage.ChaincodeNameIndex{}},
+		},
+		{
+			name:             "duplicateIndex",
+			attrsToIndex:     []blkstorage.IndexableAttr{blkstorage.IndexableAttrBlockNum},
+			secondaryIndexes: []blkstorage.SecondaryIndex{blkstorage.ChaincodeNameIndex{}, blkstorage.ChaincodeNameIndex{}},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			env := newTestEnvSelectiveIndexing(t, NewConf(testPath(), 0), testCase.attrsToIndex)
+			defer env.Cleanup()
+			env.provider.indexConfig.SecondaryIndexes = testCase.secondaryIndexes
+			assert.Panics(t, func() {
+				env.provider.OpenBlockStore("test-ledger")
+			})
+		})
+	}
+}
+
+func TestEncodeSecondaryIndexKey(t *testing.T) {
+	keys := [][]byte{{}, {0x00}, {0x00, 0x00}, {0x00, 0x01}, {0x01}, []byte("a"), []byte("a\x00"), []byte("a\x00b"), []byte("ab"), {0xFF}}
+	for i := 1; i < len(keys); i++ {
+		k1 := append(encodeSecondaryIndexKey(keys[i-1]), 0x00)
+		k2 := encodeSecondaryIndexKey(keys[i])
+		assert.True(t, string(k1) < string(k2), "encoded key for %#v should be less than the one for %#v", keys[i-1], keys[i])
+	}
+}
+
+func retrieveTxsByIndexKey(t *testing.T, store blkstorage.BlockStore, indexName, key string) []*peer.ProcessedTransaction {
+	itr, err := store.RetrieveTxsByIndexKey(indexName, []byte(key))
+	assert.NoError(t, err)
+	return collectTxs(t, itr)
+}
+
+func collectTxs(t *testing.T, itr ledger.ResultsIterator) []*peer.ProcessedTransaction {
+	defer itr.Close()
+	var results []*peer.ProcessedTransaction
+	for {
+		result, err := itr.Next()
+		assert.NoError(t, err)
+		if result == nil {
+			return results
+		}
+		results = append(results, result.(*peer.ProcessedTransaction))
+	}
+}
+
+func txIDsOf(t *testing.T, results []*peer.ProcessedTransaction) []string {
+	var txIDs []string
+	for _, result := range results {
+		txBytes, err := proto.Marshal(result.TransactionEnvelope)
+		assert.NoError(t, err)
+		txIDs = append(txIDs, txIDOf(t, txBytes))
+	}
+	return txIDs
+}
+
+func txIDOf(t *testing.T, txBytes []byte) string {
+	txID, err := extractTxID(txBytes)
+	assert.NoError(t, err)
+	return txID
+}
+
+func txTimestamp(t *testing.T, txBytes []byte) time.Time {
+	env, err := putil.GetEnvelopeFromBlock(txBytes)
+	assert.NoError(t, err)
+	payload, err := putil.GetPayload(env)
+	assert.NoError(t, err)
+	chdr, err := putil.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	assert.NoError(t, err)
+	return time.Unix(chdr.Timestamp.Seconds, int64(chdr.Timestamp.Nanos))
+}
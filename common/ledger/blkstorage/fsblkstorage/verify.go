@@ -0,0 +1,350 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	ledgerUtil "github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+// BlockVerifier performs the checks on the blocks that are beyond the scope of the block storage,
+// such as the verification of the block signatures
+type BlockVerifier interface {
+	// Init is invoked before any block is verified with the last config block that the block storage retains
+	// from the snapshot it was bootstrapped from. The block is nil if the storage was not bootstrapped from a snapshot
+	Init(snapshotConfigBlock *common.Block) error
+	// VerifyBlock is invoked for the blocks that pass the checks of the block storage in the order of the block numbers
+	VerifyBlock(block *common.Block) error
+}
+
+// VerificationResult captures the outcome of `VerifyBlockStore`
+type VerificationResult struct {
+	// FirstBlockNum is the number of the first block that is expected in the block files
+	FirstBlockNum uint64
+	// NumVerifiedBlocks is the number of consecutive blocks, starting with the block `FirstBlockNum`, that passed the verification
+	NumVerifiedBlocks uint64
+	// BadBlock is the first block that failed the verification, if any
+	BadBlock *BadBlock
+	// Warnings reports the inconsistencies that are not corruptions, such as an index that is behind the block files.
+	// These are resolved when the block storage is opened next time
+	Warnings []string
+}
+
+// BadBlock describes a block that failed the verification
+type BadBlock struct {
+	BlockNum uint64
+	Reason   string
+}
+
+// VerifyBlockStore verifies the integrity of the block storage of the given ledger without modifying it.
+// It reads all the available blocks from the block files and verifies that each block has the expected number,
+// that the data hash in the header matches the data, that the previous hash matches the hash of the preceding
+// block, and that the index entries of the block match the location of the block in the block files. The blocks
+// that pass these checks are passed to the given verifier, if any. The verification stops at the first bad block.
+// An error is returned only if the verification cannot be performed, for instance if the block storage is in use
+func VerifyBlockStore(conf *Conf, indexConfig *blkstorage.IndexConfig, ledgerID string, verifier BlockVerifier) (*VerificationResult, error) {
+	rootDir := conf.getLedgerBlockDir(ledgerID)
+	exists, _, err := util.FileExists(rootDir)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.Errorf("block store for ledger [%s] does not exist", ledgerID)
+	}
+	p, err := leveldbhelper.NewReadOnlyProvider(&leveldbhelper.Conf{DBPath: conf.getIndexDir()})
+	if err != nil {
+		return nil, errors.WithMessage(err, "error opening the block index")
+	}
+	defer p.Close()
+	db := p.GetDBHandle(ledgerID)
+	index, err := newBlockIndex(&blkstorage.IndexConfig{AttrsToIndex: indexConfig.AttrsToIndex}, db)
+	if err != nil {
+		return nil, err
+	}
+	v := &blockStoreVerifier{
+		mgr:      &blockfileMgr{rootDir: rootDir, conf: conf, db: db},
+		index:    index,
+		verifier: verifier,
+		result:   &VerificationResult{},
+	}
+	if err := v.verify(); err != nil {
+		return nil, err
+	}
+	return v.result, nil
+}
+
+type blockStoreVerifier struct {
+	mgr               *blockfileMgr
+	index             *blockIndex
+	verifier          BlockVerifier
+	pi                *pruneInfo
+	lastBlockIndexed  uint64
+	indexEmpty        bool
+	previousBlockHash []byte
+	result            *VerificationResult
+}
+
+func (v *blockStoreVerifier) verify() error {
+	mgr := v.mgr
+	cpInfo, err := mgr.loadCurrentInfo()
+	if err != nil {
+		return err
+	}
+	if cpInfo == nil {
+		v.warn("checkpoint info is missing from the index")
+		if cpInfo, err = constructCheckpointInfoFromBlockFiles(mgr.rootDir); err != nil {
+			return err
+		}
+	}
+	if v.pi, err = mgr.loadPruneInfo(); err != nil {
+		return err
+	}
+	if v.pi == nil {
+		if v.pi, err = constructPruneInfoFromBlockFiles(mgr.rootDir); err != nil {
+			return err
+		}
+	}
+	mgr.pruneInfo.Store(v.pi)
+	if mgr.bootstrapInfo, err = mgr.loadBootstrapInfo(); err != nil {
+		return err
+	}
+	if v.lastBlockIndexed, err = v.index.getLastBlockIndexed(); err != nil {
+		if err != errIndexEmpty {
+			return err
+		}
+		v.indexEmpty = true
+	}
+
+	var snapshotConfigBlock *common.Block
+	if mgr.bootstrapInfo != nil {
+		snapshotConfigBlock = mgr.bootstrapInfo.lastConfigBlock
+		v.previousBlockHash = mgr.bootstrapInfo.lastBlock.Header.Hash()
+	}
+	if v.verifier != nil {
+		if err := v.verifier.Init(snapshotConfigBlock); err != nil {
+			return err
+		}
+	}
+
+	v.result.FirstBlockNum = v.pi.firstBlockNumber
+	nextBlockNum := v.pi.firstBlockNumber
+	if exists, _, err := util.FileExists(deriveBlockfilePath(mgr.rootDir, v.pi.firstFileSuffixNum)); err != nil || !exists {
+		if err != nil {
+			return err
+		}
+		if !cpInfo.isChainEmpty {
+			v.fail(nextBlockNum, fmt.Sprintf("block file [%d] is missing", v.pi.firstFileSuffixNum))
+		}
+		return nil
+	}
+	stream, err := newBlockStream(mgr.rootDir, v.pi.firstFileSuffixNum, 0, cpInfo.latestFileChunkSuffixNum)
+	if err != nil {
+		return err
+	}
+	defer stream.close()
+
+	for {
+		blockBytes, placementInfo, err := nextBlockBytesAndPlacementInfo(stream)
+		if err == ErrUnexpectedEndOfBlockfile && (cpInfo.isChainEmpty || nextBlockNum > cpInfo.lastBlockNumber) {
+			// a block that was partially written when a crash happened, it is discarded on the next start
+			v.warn(fmt.Sprintf("block file [%d] ends with a partially written block", stream.currentFileNum))
+			break
+		}
+		if err != nil {
+			v.fail(nextBlockNum, fmt.Sprintf("error reading the block from the block files: %s", err))
+			return nil
+		}
+		if blockBytes == nil {
+			break
+		}
+		if reason := v.verifyBlock(nextBlockNum, blockBytes, placementInfo); reason != "" {
+			v.fail(nextBlockNum, reason)
+			return nil
+		}
+		v.result.NumVerifiedBlocks++
+		nextBlockNum++
+	}
+
+	if !cpInfo.isChainEmpty && nextBlockNum <= cpInfo.lastBlockNumber {
+		v.fail(nextBlockNum, fmt.Sprintf("block is missing from the block files, the last block recorded in the checkpoint is [%d]",
+			cpInfo.lastBlockNumber))
+		return nil
+	}
+	if !v.indexEmpty && v.lastBlockIndexed >= nextBlockNum && v.lastBlockIndexed >= v.pi.firstBlockNumber {
+		v.fail(nextBlockNum, fmt.Sprintf("block is missing from the block files, the last block indexed is [%d]", v.lastBlockIndexed))
+		return nil
+	}
+	if v.indexEmpty && v.result.NumVerifiedBlocks > 0 {
+		v.warn("block index is empty")
+	} else if !v.indexEmpty && v.lastBlockIndexed+1 < nextBlockNum {
+		v.warn(fmt.Sprintf("block index is behind the block files, the last block indexed is [%d]", v.lastBlockIndexed))
+	}
+	return nil
+}
+
+// nextBlockBytesAndPlacementInfo reads the next block from the given stream. The panics that
+// the stream raises for a corrupted block length are converted into errors
+func nextBlockBytesAndPlacementInfo(stream *blockStream) (blockBytes []byte, placementInfo *blockPlacementInfo, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("%s", r)
+		}
+	}()
+	return stream.nextBlockBytesAndPlacementInfo()
+}
+
+// verifyBlock returns the reason why the given block fails the verification, or an empty string if the block is fine
+func (v *blockStoreVerifier) verifyBlock(expectedBlockNum uint64, blockBytes []byte, placementInfo *blockPlacementInfo) string {
+	block, err := deserializeBlock(blockBytes)
+	if err != nil {
+		return fmt.Sprintf("error deserializing the block: %s", err)
+	}
+	info, err := extractSerializedBlockInfo(blockBytes)
+	if err != nil {
+		return fmt.Sprintf("error deserializing the block: %s", err)
+	}
+	if block.Header.Number != expectedBlockNum {
+		return fmt.Sprintf("unexpected block number [%d] in the block header", block.Header.Number)
+	}
+	if !bytes.Equal(block.Header.DataHash, block.Data.Hash()) {
+		return fmt.Sprintf("data hash [%x] in the block header does not match the hash of the block data [%x]",
+			block.Header.DataHash, block.Data.Hash())
+	}
+	if v.previousBlockHash != nil && !bytes.Equal(block.Header.PreviousHash, v.previousBlockHash) {
+		return fmt.Sprintf("previous hash [%x] in the block header does not match the hash of the previous block [%x]",
+			block.Header.PreviousHash, v.previousBlockHash)
+	}
+	v.previousBlockHash = block.Header.Hash()
+
+	if !v.indexEmpty && expectedBlockNum <= v.lastBlockIndexed {
+		if reason := v.verifyIndexEntries(info, placementInfo); reason != "" {
+			return reason
+		}
+	}
+	if v.verifier != nil {
+		if err := v.verifier.VerifyBlock(block); err != nil {
+			return err.Error()
+		}
+	}
+	return ""
+}
+
+// verifyIndexEntries returns the reason why the index entries of the given block do not match the
+// location of the block in the block files, or an empty string if the entries match
+func (v *blockStoreVerifier) verifyIndexEntries(info *serializedBlockInfo, placementInfo *blockPlacementInfo) string {
+	index := v.index
+	blockNum := info.blockHeader.Number
+	blockFLP := &fileLocPointer{fileSuffixNum: placementInfo.fileNum,
+		locPointer: locPointer{offset: int(placementInfo.blockStartOffset)}}
+	numBytesToShift := int(placementInfo.blockBytesOffset - placementInfo.blockStartOffset)
+	txsfltr := ledgerUtil.TxValidationFlags(info.metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+
+	if index.indexItemsMap[blkstorage.IndexableAttrBlockNum] {
+		if reason := checkIndexEntry("block number", blockFLP)(index.getBlockLocByBlockNum(blockNum)); reason != "" {
+			return reason
+		}
+	}
+	if index.indexItemsMap[blkstorage.IndexableAttrBlockHash] {
+		if reason := checkIndexEntry("block hash", blockFLP)(index.getBlockLocByHash(info.blockHeader.Hash())); reason != "" {
+			return reason
+		}
+	}
+	for txNum, txOffset := range info.txOffsets {
+		txFLP := newFileLocationPointer(blockFLP.fileSuffixNum, blockFLP.offset,
+			&locPointer{offset: txOffset.loc.offset + numBytesToShift, bytesLength: txOffset.loc.bytesLength})
+		if index.indexItemsMap[blkstorage.IndexableAttrBlockNumTranNum] {
+			entry := fmt.Sprintf("block number and transaction number [%d]", txNum)
+			if reason := checkIndexEntry(entry, txFLP)(index.getTXLocByBlockNumTranNum(blockNum, uint64(txNum))); reason != "" {
+				return reason
+			}
+		}
+		if !index.indexItemsMap[blkstorage.IndexableAttrTxID] {
+			continue
+		}
+		indexedTxFLP, err := index.getTxLoc(txOffset.txID)
+		if err == nil && v.isDuplicateTxID(txOffset.txID, indexedTxFLP, txFLP) {
+			// only the first transaction with a given txid is indexed by the txid based indexes
+			continue
+		}
+		entry := fmt.Sprintf("transaction id [%s]", txOffset.txID)
+		if reason := checkIndexEntry(entry, txFLP)(indexedTxFLP, err); reason != "" {
+			return reason
+		}
+		if index.indexItemsMap[blkstorage.IndexableAttrBlockTxID] {
+			entry := fmt.Sprintf("block of transaction id [%s]", txOffset.txID)
+			if reason := checkIndexEntry(entry, blockFLP)(index.getBlockLocByTxID(txOffset.txID)); reason != "" {
+				return reason
+			}
+		}
+		if index.indexItemsMap[blkstorage.IndexableAttrTxValidationCode] {
+			if txNum >= len(txsfltr) {
+				return fmt.Sprintf("transactions filter in the block metadata does not contain transaction [%d]", txNum)
+			}
+			code, err := index.getTxValidationCodeByTxID(txOffset.txID)
+			if err != nil {
+				return fmt.Sprintf("error retrieving the validation code of transaction id [%s] from the index: %s", txOffset.txID, err)
+			}
+			if code != txsfltr.Flag(txNum) {
+				return fmt.Sprintf("validation code [%s] of transaction id [%s] in the index does not match [%s] in the block metadata",
+					code, txOffset.txID, txsfltr.Flag(txNum))
+			}
+		}
+	}
+	return ""
+}
+
+// isDuplicateTxID tells whether the transaction at the location `txFLP` is a duplicate of the transaction
+// with the given txid that the index refers to. This is the case if the index refers to an earlier location
+// that contains a transaction with the same txid, or to a location in a pruned block file
+func (v *blockStoreVerifier) isDuplicateTxID(txID string, indexedTxFLP, txFLP *fileLocPointer) bool {
+	if indexedTxFLP.fileSuffixNum > txFLP.fileSuffixNum ||
+		(indexedTxFLP.fileSuffixNum == txFLP.fileSuffixNum && indexedTxFLP.offset >= txFLP.offset) {
+		return false
+	}
+	if v.mgr.checkPruned(indexedTxFLP) != nil {
+		return true
+	}
+	txEnvelopeBytes, err := v.mgr.fetchRawBytes(indexedTxFLP)
+	if err != nil {
+		return false
+	}
+	_, n := proto.DecodeVarint(txEnvelopeBytes)
+	indexedTxID, err := extractTxID(txEnvelopeBytes[n:])
+	return err == nil && indexedTxID == txID
+}
+
+// checkIndexEntry returns a function that compares the location retrieved from the index for the given entry
+// with the expected location. The function returns the reason for a mismatch, or an empty string if the locations match
+func checkIndexEntry(entry string, expected *fileLocPointer) func(*fileLocPointer, error) string {
+	return func(actual *fileLocPointer, err error) string {
+		if err != nil {
+			return fmt.Sprintf("error retrieving the %s from the index: %s", entry, err)
+		}
+		if actual.fileSuffixNum != expected.fileSuffixNum || actual.offset != expected.offset ||
+			actual.bytesLength != expected.bytesLength {
+			return fmt.Sprintf("location [%s] of the %s in the index does not match location [%s] in the block files",
+				actual, entry, expected)
+		}
+		return ""
+	}
+}
+
+func (v *blockStoreVerifier) fail(blockNum uint64, reason string) {
+	v.result.BadBlock = &BadBlock{BlockNum: blockNum, Reason: reason}
+}
+
+func (v *blockStoreVerifier) warn(warning string) {
+	v.result.Warnings = append(v.result.Warnings, warning)
+}
@@ -0,0 +1,208 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type testBlockVerifier struct {
+	snapshotConfigBlock *common.Block
+	verifiedBlockNums   []uint64
+	failBlockNum        uint64
+}
+
+func (v *testBlockVerifier) Init(snapshotConfigBlock *common.Block) error {
+	v.snapshotConfigBlock = snapshotConfigBlock
+	return nil
+}
+
+func (v *testBlockVerifier) VerifyBlock(block *common.Block) error {
+	if v.failBlockNum != 0 && block.Header.Number == v.failBlockNum {
+		return errors.New("invalid signature")
+	}
+	v.verifiedBlockNums = append(v.verifiedBlockNums, block.Header.Number)
+	return nil
+}
+
+func TestVerifyBlockStore(t *testing.T) {
+	blocks := testutil.ConstructTestBlocks(t, 30)
+	by, _, err := serializeBlock(blocks[1])
+	assert.NoError(t, err)
+	conf := NewConf(testPath(), 5*len(by))
+	env := newTestEnv(t, conf)
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgrWrapper.addBlocks(blocks)
+	assert.NoError(t, blkfileMgrWrapper.blockfileMgr.prune(12))
+	firstBlockNum := blkfileMgrWrapper.blockfileMgr.firstBlockNumber()
+	assert.True(t, firstBlockNum > 0)
+
+	_, err = VerifyBlockStore(conf, env.provider.indexConfig, "testLedger", nil)
+	assert.Contains(t, err.Error(), "error opening the block index")
+	blkfileMgrWrapper.close()
+	env.provider.Close()
+
+	verifier := &testBlockVerifier{}
+	result, err := VerifyBlockStore(conf, env.provider.indexConfig, "testLedger", verifier)
+	assert.NoError(t, err)
+	assert.Equal(t, &VerificationResult{FirstBlockNum: firstBlockNum, NumVerifiedBlocks: 30 - firstBlockNum}, result)
+	assert.Nil(t, verifier.snapshotConfigBlock)
+	assert.Len(t, verifier.verifiedBlockNums, int(30-firstBlockNum))
+	assert.Equal(t, firstBlockNum, verifier.verifiedBlockNums[0])
+
+	// the block store is not modified by the verification
+	env = newTestEnv(t, conf)
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	blkfileMgrWrapper.testGetBlockByNumber(blocks[firstBlockNum:], firstBlockNum)
+
+	_, err = VerifyBlockStore(conf, env.provider.indexConfig, "non-existing-ledger", nil)
+	assert.EqualError(t, err, "block store for ledger [non-existing-ledger] does not exist")
+}
+
+func TestVerifyBlockStoreWithDuplicateTxids(t *testing.T) {
+	conf := NewConf(testPath(), 0)
+	env := newTestEnv(t, conf)
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	block1 := bg.NextBlockWithTxid([][]byte{[]byte("tx with id=txid-1")}, []string{"txid-1"})
+	block2 := bg.NextBlockWithTxid(
+		[][]byte{[]byte("another tx with existing id=txid-1"), []byte("tx with id=txid-2"), []byte("another tx with existing id=txid-2")},
+		[]string{"txid-1", "txid-2", "txid-2"},
+	)
+	blkfileMgrWrapper.addBlocks([]*common.Block{gb, block1, block2})
+	blkfileMgrWrapper.close()
+	env.provider.Close()
+
+	result, err := VerifyBlockStore(conf, env.provider.indexConfig, "testLedger", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &VerificationResult{NumVerifiedBlocks: 3}, result)
+}
+
+func TestVerifyBlockStoreCorruptions(t *testing.T) {
+	testcases := []struct {
+		name           string
+		corrupt        func(t *testing.T, mgr *blockfileMgr, blocks []*common.Block)
+		expectedResult *VerificationResult
+	}{
+		{
+			name: "data hash",
+			corrupt: func(t *testing.T, mgr *blockfileMgr, blocks []*common.Block) {
+				replaceInBlockfile(t, mgr, blocks[5].Data.Data[0], blocks[6].Data.Data[0])
+			},
+			expectedResult: &VerificationResult{NumVerifiedBlocks: 5, BadBlock: &BadBlock{BlockNum: 5}},
+		},
+		{
+			name: "previous hash",
+			corrupt: func(t *testing.T, mgr *blockfileMgr, blocks []*common.Block) {
+				previousHash := append([]byte{}, blocks[5].Header.PreviousHash...)
+				previousHash[0]++
+				replaceInBlockfile(t, mgr, blocks[5].Header.PreviousHash, previousHash)
+			},
+			expectedResult: &VerificationResult{NumVerifiedBlocks: 5, BadBlock: &BadBlock{BlockNum: 5}},
+		},
+		{
+			name: "index entry",
+			corrupt: func(t *testing.T, mgr *blockfileMgr, blocks []*common.Block) {
+				flp, err := mgr.index.getBlockLocByBlockNum(4)
+				assert.NoError(t, err)
+				flpBytes, err := flp.marshal()
+				assert.NoError(t, err)
+				assert.NoError(t, mgr.db.Put(constructBlockNumKey(3), flpBytes, true))
+			},
+			expectedResult: &VerificationResult{NumVerifiedBlocks: 3, BadBlock: &BadBlock{BlockNum: 3}},
+		},
+		{
+			name: "missing block",
+			corrupt: func(t *testing.T, mgr *blockfileMgr, blocks []*common.Block) {
+				flp, err := mgr.index.getBlockLocByBlockNum(9)
+				assert.NoError(t, err)
+				assert.NoError(t, os.Truncate(deriveBlockfilePath(mgr.rootDir, flp.fileSuffixNum), int64(flp.offset)))
+			},
+			expectedResult: &VerificationResult{NumVerifiedBlocks: 9, BadBlock: &BadBlock{BlockNum: 9}},
+		},
+		{
+			name: "partially written block",
+			corrupt: func(t *testing.T, mgr *blockfileMgr, blocks []*common.Block) {
+				blockBytes, _, err := serializeBlock(testutil.ConstructBlock(t, 10, blocks[9].Header.Hash(), nil, false))
+				assert.NoError(t, err)
+				partialBlockBytes := append(proto.EncodeVarint(uint64(len(blockBytes))), blockBytes[:len(blockBytes)/2]...)
+				f, err := os.OpenFile(deriveBlockfilePath(mgr.rootDir, 0), os.O_APPEND|os.O_WRONLY, 0)
+				assert.NoError(t, err)
+				defer f.Close()
+				_, err = f.Write(partialBlockBytes)
+				assert.NoError(t, err)
+			},
+			expectedResult: &VerificationResult{
+				NumVerifiedBlocks: 10,
+				Warnings:          []string{"block file [0] ends with a partially written block"},
+			},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			blocks := testutil.ConstructTestBlocks(t, 10)
+			conf := NewConf(testPath(), 0)
+			env := newTestEnv(t, conf)
+			defer env.Cleanup()
+			blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+			blkfileMgrWrapper.addBlocks(blocks)
+			testcase.corrupt(t, blkfileMgrWrapper.blockfileMgr, blocks)
+			blkfileMgrWrapper.close()
+			env.provider.Close()
+
+			result, err := VerifyBlockStore(conf, env.provider.indexConfig, "testLedger", nil)
+			assert.NoError(t, err)
+			if result.BadBlock != nil {
+				assert.NotEmpty(t, result.BadBlock.Reason)
+				result.BadBlock.Reason = ""
+			}
+			assert.Equal(t, testcase.expectedResult, result)
+		})
+	}
+}
+
+func TestVerifyBlockStoreWithVerifierFailure(t *testing.T) {
+	blocks := testutil.ConstructTestBlocks(t, 10)
+	conf := NewConf(testPath(), 0)
+	env := newTestEnvSelectiveIndexing(t, conf, []blkstorage.IndexableAttr{blkstorage.IndexableAttrBlockNum})
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgrWrapper.addBlocks(blocks)
+	blkfileMgrWrapper.close()
+	env.provider.Close()
+
+	verifier := &testBlockVerifier{failBlockNum: 7}
+	result, err := VerifyBlockStore(conf, env.provider.indexConfig, "testLedger", verifier)
+	assert.NoError(t, err)
+	assert.Equal(t, &VerificationResult{NumVerifiedBlocks: 7, BadBlock: &BadBlock{BlockNum: 7, Reason: "invalid signature"}}, result)
+	assert.Equal(t, []uint64{0, 1, 2, 3, 4, 5, 6}, verifier.verifiedBlockNums)
+}
+
+// replaceInBlockfile replaces the only occurrence of the given bytes in the first block file with
+// the replacement bytes of the same length
+func replaceInBlockfile(t *testing.T, mgr *blockfileMgr, old, new []byte) {
+	path := deriveBlockfilePath(mgr.rootDir, 0)
+	content, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, bytes.Count(content, old))
+	assert.Equal(t, len(old), len(new))
+	assert.NoError(t, ioutil.WriteFile(path, bytes.Replace(content, old, new, 1), 0644))
+}
@@ -0,0 +1,121 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blkstorage
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/peer"
+)
+
+// names of the secondary indexes that are built in
+const (
+	SecondaryIndexCreatorMSPID  = "CreatorMSPID"
+	SecondaryIndexChaincodeName = "ChaincodeName"
+	SecondaryIndexTxTimestamp   = "TxTimestamp"
+)
+
+// SecondaryIndex is an additional index on the transactions that a block store maintains in its index
+// database along with the indexes of the `IndexableAttr`s. A transaction can be looked up by any of the
+// keys that the index returns for it. When a secondary index is added to the `IndexConfig` of an existing
+// block store, the entries of the index are built for the available blocks when the block store is opened
+type SecondaryIndex interface {
+	// Name returns the name of the index, which is unique among the secondary indexes of a block store
+	Name() string
+	// Keys returns the keys of the given transaction in the index. A transaction without any key is not indexed
+	Keys(tx *IndexedTx) ([][]byte, error)
+}
+
+// IndexedTx contains a transaction that is being indexed along with its headers. The headers are nil
+// if the transaction does not contain them
+type IndexedTx struct {
+	BlockNum        uint64
+	TxNum           uint64
+	ValidationCode  peer.TxValidationCode
+	Envelope        *common.Envelope
+	ChannelHeader   *common.ChannelHeader
+	SignatureHeader *common.SignatureHeader
+}
+
+// CreatorMSPIDIndex indexes the transactions by the MSP ID of their creator
+type CreatorMSPIDIndex struct{}
+
+// Name implements method in interface `SecondaryIndex`
+func (CreatorMSPIDIndex) Name() string {
+	return SecondaryIndexCreatorMSPID
+}
+
+// Keys implements method in interface `SecondaryIndex`
+func (CreatorMSPIDIndex) Keys(tx *IndexedTx) ([][]byte, error) {
+	if tx.SignatureHeader == nil {
+		return nil, nil
+	}
+	creator := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(tx.SignatureHeader.Creator, creator); err != nil || creator.Mspid == "" {
+		return nil, nil
+	}
+	return [][]byte{[]byte(creator.Mspid)}, nil
+}
+
+// ChaincodeNameIndex indexes the endorser transactions by the name of the chaincode that they invoke
+type ChaincodeNameIndex struct{}
+
+// Name implements method in interface `SecondaryIndex`
+func (ChaincodeNameIndex) Name() string {
+	return SecondaryIndexChaincodeName
+}
+
+// Keys implements method in interface `SecondaryIndex`
+func (ChaincodeNameIndex) Keys(tx *IndexedTx) ([][]byte, error) {
+	chdr := tx.ChannelHeader
+	if chdr == nil || common.HeaderType(chdr.Type) != common.HeaderType_ENDORSER_TRANSACTION {
+		return nil, nil
+	}
+	ext := &peer.ChaincodeHeaderExtension{}
+	if err := proto.Unmarshal(chdr.Extension, ext); err != nil || ext.ChaincodeId == nil || ext.ChaincodeId.Name == "" {
+		return nil, nil
+	}
+	return [][]byte{[]byte(ext.ChaincodeId.Name)}, nil
+}
+
+// TxTimestampIndex indexes the transactions by the timestamp in their channel header.
+// The keys of the index are encoded via function `EncodeTxTimestampKey`
+type TxTimestampIndex struct{}
+
+// Name implements method in interface `SecondaryIndex`
+func (TxTimestampIndex) Name() string {
+	return SecondaryIndexTxTimestamp
+}
+
+// Keys implements method in interface `SecondaryIndex`
+func (TxTimestampIndex) Keys(tx *IndexedTx) ([][]byte, error) {
+	if tx.ChannelHeader == nil || tx.ChannelHeader.Timestamp == nil {
+		return nil, nil
+	}
+	t, err := ptypes.Timestamp(tx.ChannelHeader.Timestamp)
+	if err != nil {
+		return nil, nil
+	}
+	return [][]byte{EncodeTxTimestampKey(t)}, nil
+}
+
+// EncodeTxTimestampKey encodes the given time as a key of the `TxTimestampIndex`. The encoding preserves
+// the order of the time, and the time before the Unix epoch is treated as the Unix epoch
+func EncodeTxTimestampKey(t time.Time) []byte {
+	nanos := t.UnixNano()
+	if nanos < 0 {
+		nanos = 0
+	}
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, uint64(nanos))
+	return key
+}
@@ -0,0 +1,77 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blkstorage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreatorMSPIDIndex(t *testing.T) {
+	creator, err := proto.Marshal(&msp.SerializedIdentity{Mspid: "Org1MSP", IdBytes: []byte("cert")})
+	assert.NoError(t, err)
+	keys, err := CreatorMSPIDIndex{}.Keys(&IndexedTx{SignatureHeader: &common.SignatureHeader{Creator: creator}})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("Org1MSP")}, keys)
+
+	for _, tx := range []*IndexedTx{
+		{},
+		{SignatureHeader: &common.SignatureHeader{Creator: []byte("garbage")}},
+		{SignatureHeader: &common.SignatureHeader{}},
+	} {
+		keys, err := CreatorMSPIDIndex{}.Keys(tx)
+		assert.NoError(t, err)
+		assert.Empty(t, keys)
+	}
+}
+
+func TestChaincodeNameIndex(t *testing.T) {
+	ext, err := proto.Marshal(&peer.ChaincodeHeaderExtension{ChaincodeId: &peer.ChaincodeID{Name: "mycc"}})
+	assert.NoError(t, err)
+	keys, err := ChaincodeNameIndex{}.Keys(&IndexedTx{ChannelHeader: &common.ChannelHeader{
+		Type:      int32(common.HeaderType_ENDORSER_TRANSACTION),
+		Extension: ext,
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("mycc")}, keys)
+
+	for _, tx := range []*IndexedTx{
+		{},
+		{ChannelHeader: &common.ChannelHeader{Type: int32(common.HeaderType_CONFIG), Extension: ext}},
+		{ChannelHeader: &common.ChannelHeader{Type: int32(common.HeaderType_ENDORSER_TRANSACTION)}},
+	} {
+		keys, err := ChaincodeNameIndex{}.Keys(tx)
+		assert.NoError(t, err)
+		assert.Empty(t, keys)
+	}
+}
+
+func TestTxTimestampIndex(t *testing.T) {
+	keys, err := TxTimestampIndex{}.Keys(&IndexedTx{ChannelHeader: &common.ChannelHeader{
+		Timestamp: &timestamp.Timestamp{Seconds: 1000, Nanos: 5},
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{EncodeTxTimestampKey(time.Unix(1000, 5))}, keys)
+
+	keys, err = TxTimestampIndex{}.Keys(&IndexedTx{ChannelHeader: &common.ChannelHeader{}})
+	assert.NoError(t, err)
+	assert.Empty(t, keys)
+}
+
+func TestEncodeTxTimestampKey(t *testing.T) {
+	t1 := time.Unix(1000, 999999999)
+	t2 := time.Unix(1001, 0)
+	assert.True(t, string(EncodeTxTimestampKey(t1)) < string(EncodeTxTimestampKey(t2)))
+	assert.Equal(t, EncodeTxTimestampKey(time.Unix(0, 0)), EncodeTxTimestampKey(time.Unix(-10, 0)))
+}
@@ -27,6 +27,7 @@ import (
 type fileLedgerFactory struct {
 	blkstorageProvider blkstorage.BlockStoreProvider
 	ledgers            map[string]blockledger.ReadWriter
+	blockStores        map[string]blkstorage.BlockStore
 	mutex              sync.Mutex
 }
 
@@ -48,6 +49,7 @@ func (flf *fileLedgerFactory) GetOrCreate(chainID string) (blockledger.ReadWrite
 	}
 	ledger = NewFileLedger(blockStore)
 	flf.ledgers[key] = ledger
+	flf.blockStores[key] = blockStore
 	return ledger, nil
 }
 
@@ -60,6 +62,20 @@ func (flf *fileLedgerFactory) ChainIDs() []string {
 	return chainIDs
 }
 
+// Remove closes the ledger of the given chain ID and removes its blocks
+func (flf *fileLedgerFactory) Remove(chainID string) error {
+	flf.mutex.Lock()
+	defer flf.mutex.Unlock()
+
+	if blockStore, ok := flf.blockStores[chainID]; ok {
+		blockStore.Shutdown()
+		delete(flf.blockStores, chainID)
+		delete(flf.ledgers, chainID)
+	}
+
+	return flf.blkstorageProvider.Remove(chainID)
+}
+
 // Close releases all resources acquired by the factory
 func (flf *fileLedgerFactory) Close() {
 	flf.blkstorageProvider.Close()
@@ -73,6 +89,7 @@ func New(directory string) blockledger.Factory {
 			&blkstorage.IndexConfig{
 				AttrsToIndex: []blkstorage.IndexableAttr{blkstorage.IndexableAttrBlockNum}},
 		),
-		ledgers: make(map[string]blockledger.ReadWriter),
+		ledgers:     make(map[string]blockledger.ReadWriter),
+		blockStores: make(map[string]blkstorage.BlockStore),
 	}
 }
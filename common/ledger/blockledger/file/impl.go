@@ -28,6 +28,7 @@ type FileLedgerBlockStore interface {
 	AddBlock(block *cb.Block) error
 	GetBlockchainInfo() (*cb.BlockchainInfo, error)
 	RetrieveBlocks(startBlockNumber uint64) (ledger.ResultsIterator, error)
+	FirstBlockNumber() (uint64, error)
 }
 
 // NewFileLedger creates a new FileLedger for interaction with the ledger
@@ -67,7 +68,12 @@ func (fl *FileLedger) Iterator(startPosition *ab.SeekPosition) (blockledger.Iter
 	var startingBlockNumber uint64
 	switch start := startPosition.Type.(type) {
 	case *ab.SeekPosition_Oldest:
-		startingBlockNumber = 0
+		// the oldest blocks may have been pruned from the block store
+		firstBlockNumber, err := fl.blockStore.FirstBlockNumber()
+		if err != nil {
+			logger.Panic(err)
+		}
+		startingBlockNumber = firstBlockNumber
 	case *ab.SeekPosition_Newest:
 		info, err := fl.blockStore.GetBlockchainInfo()
 		if err != nil {
@@ -81,6 +87,14 @@ func (fl *FileLedger) Iterator(startPosition *ab.SeekPosition) (blockledger.Iter
 		if startingBlockNumber > height {
 			return &blockledger.NotFoundErrorIterator{}, 0
 		}
+		firstBlockNumber, err := fl.blockStore.FirstBlockNumber()
+		if err != nil {
+			logger.Panic(err)
+		}
+		if startingBlockNumber < firstBlockNumber {
+			logger.Warningf("Requested block [%d] has been pruned, first available block is [%d]", startingBlockNumber, firstBlockNumber)
+			return &blockledger.NotFoundErrorIterator{}, 0
+		}
 	default:
 		return &blockledger.NotFoundErrorIterator{}, 0
 	}
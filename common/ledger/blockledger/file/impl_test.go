@@ -69,6 +69,7 @@ type mockBlockStore struct {
 	defaultError               error
 	getBlockchainInfoError     error
 	retrieveBlockByNumberError error
+	firstBlockNumber           uint64
 }
 
 func (mbs *mockBlockStore) AddBlock(block *cb.Block) error {
@@ -107,6 +108,14 @@ func (mbs *mockBlockStore) RetrieveTxValidationCodeByTxID(txID string) (peer.TxV
 	return mbs.txValidationCode, mbs.defaultError
 }
 
+func (mbs *mockBlockStore) FirstBlockNumber() (uint64, error) {
+	return mbs.firstBlockNumber, mbs.defaultError
+}
+
+func (mbs *mockBlockStore) Prune(belowBlockNum uint64) error {
+	return mbs.defaultError
+}
+
 func (*mockBlockStore) Shutdown() {
 }
 
@@ -295,3 +304,24 @@ func TestBlockstoreError(t *testing.T) {
 		assert.Equal(t, cb.Status_SERVICE_UNAVAILABLE, status, "Expected service unavailable error")
 	}
 }
+
+func TestPrunedRetrieval(t *testing.T) {
+	resultsIterator := &mockBlockStoreIterator{}
+	resultsIterator.On("Close").Return()
+	fl := &FileLedger{
+		blockStore: &mockBlockStore{
+			blockchainInfo:   &cb.BlockchainInfo{Height: uint64(20)},
+			resultsIterator:  resultsIterator,
+			firstBlockNumber: 10,
+		},
+		signal: make(chan struct{}),
+	}
+
+	it, num := fl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Oldest{}})
+	it.Close()
+	assert.Equal(t, uint64(10), num, "Expected the iterator to start at the first available block")
+
+	it, _ = fl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: 5}}})
+	defer it.Close()
+	assert.IsType(t, &blockledger.NotFoundErrorIterator{}, it, "Expected Not Found Error if seek number has been pruned")
+}
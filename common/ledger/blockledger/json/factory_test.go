@@ -114,3 +114,18 @@ func TestClose(t *testing.T) {
 	jlf := New(name)
 	assert.NotPanics(t, func() { jlf.Close() }, "Noop should not pannic")
 }
+
+func TestRemove(t *testing.T) {
+	name, err := ioutil.TempDir("", "hyperledger_fabric")
+	assert.Nil(t, err, "Error creating temp dir: %s", err)
+	defer os.RemoveAll(name)
+
+	jlf := New(name)
+	_, err = jlf.GetOrCreate("foo")
+	assert.Nil(t, err, "Error creating chain")
+	assert.Equal(t, 1, len(jlf.ChainIDs()), "Expected 1 chain")
+
+	assert.Nil(t, jlf.Remove("foo"), "Error removing chain")
+	assert.Empty(t, jlf.ChainIDs(), "Expected no chain after removal")
+	assert.Empty(t, New(name).ChainIDs(), "Expected removed chain not to be recovered")
+}
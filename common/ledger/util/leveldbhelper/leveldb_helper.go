@@ -9,6 +9,7 @@ package leveldbhelper
 import (
 	"fmt"
 	"sync"
+	"syscall"
 
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/util"
@@ -81,6 +82,24 @@ func (dbInst *DB) Open() {
 	dbInst.dbState = opened
 }
 
+// OpenReadOnly opens an existing db in read-only mode. Any attempt to modify the db returns an error.
+// Unlike `Open`, it returns an error if the db does not exist or cannot be opened
+func (dbInst *DB) OpenReadOnly() error {
+	dbInst.mutex.Lock()
+	defer dbInst.mutex.Unlock()
+	if dbInst.dbState == opened {
+		return nil
+	}
+	dbOpts := &opt.Options{ReadOnly: true, ErrorIfMissing: true}
+	db, err := leveldb.OpenFile(dbInst.conf.DBPath, dbOpts)
+	if err != nil {
+		return errors.Wrapf(err, "error opening leveldb [%s] in read-only mode", dbInst.conf.DBPath)
+	}
+	dbInst.db = db
+	dbInst.dbState = opened
+	return nil
+}
+
 // Close closes the underlying db
 func (dbInst *DB) Close() {
 	dbInst.mutex.Lock()
@@ -164,3 +183,50 @@ func (dbInst *DB) WriteBatch(batch *leveldb.Batch, sync bool) error {
 	}
 	return nil
 }
+
+// FileLock encapsulates the DB that holds the file lock.
+// A FileLock is expected to be used by a single goroutine
+type FileLock struct {
+	db       *leveldb.DB
+	filePath string
+}
+
+// NewFileLock returns a new file based lock manager
+func NewFileLock(filePath string) *FileLock {
+	return &FileLock{
+		filePath: filePath,
+	}
+}
+
+// Lock acquires the file lock. This is achieved by opening a db for the filePath, as leveldb acquires
+// a file lock while opening a db. If the db is already opened by the same or another process, an error
+// is returned. The lock is released when the db is closed or when the owner process dies
+func (f *FileLock) Lock() error {
+	dbOpts := &opt.Options{}
+	var err error
+	var dirEmpty bool
+	if dirEmpty, err = util.CreateDirIfMissing(f.filePath); err != nil {
+		return errors.WithMessage(err, "error creating dir if missing")
+	}
+	dbOpts.ErrorIfMissing = !dirEmpty
+	f.db, err = leveldb.OpenFile(f.filePath, dbOpts)
+	if err == syscall.EAGAIN {
+		return errors.Errorf("lock is already acquired on file %s", f.filePath)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "error acquiring lock on file %s", f.filePath)
+	}
+	return nil
+}
+
+// Unlock releases a previously acquired lock. Unlock can be invoked multiple times
+func (f *FileLock) Unlock() {
+	if f.db == nil {
+		return
+	}
+	if err := f.db.Close(); err != nil {
+		logger.Warningf("Unable to release the lock on file %s: %s", f.filePath, err)
+		return
+	}
+	f.db = nil
+}
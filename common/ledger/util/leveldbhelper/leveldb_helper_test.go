@@ -137,3 +137,23 @@ func TestCreateDBInNonEmptyDir(t *testing.T) {
 	}()
 	db.Open()
 }
+
+func TestFileLock(t *testing.T) {
+	fileLockPath := testDBPath + "/fileLock"
+	defer os.RemoveAll(fileLockPath)
+
+	fileLock1 := NewFileLock(fileLockPath)
+	assert.NoError(t, fileLock1.Lock())
+
+	// a second lock on the same path fails while the first one is held
+	fileLock2 := NewFileLock(fileLockPath)
+	err := fileLock2.Lock()
+	assert.EqualError(t, err, "lock is already acquired on file "+fileLockPath)
+
+	// the lock can be acquired again after it is released
+	fileLock1.Unlock()
+	assert.NoError(t, fileLock2.Lock())
+	fileLock2.Unlock()
+	// unlocking again is a no-op
+	fileLock2.Unlock()
+}
@@ -41,6 +41,15 @@ func NewProvider(conf *Conf) *Provider {
 	return &Provider{db, make(map[string]*DBHandle), sync.Mutex{}}
 }
 
+// NewReadOnlyProvider constructs a Provider on an existing leveldb that is opened in read-only mode
+func NewReadOnlyProvider(conf *Conf) (*Provider, error) {
+	db := CreateDB(conf)
+	if err := db.OpenReadOnly(); err != nil {
+		return nil, err
+	}
+	return &Provider{db, make(map[string]*DBHandle), sync.Mutex{}}, nil
+}
+
 // GetDBHandle returns a handle to a named db
 func (p *Provider) GetDBHandle(dbName string) *DBHandle {
 	p.mux.Lock()
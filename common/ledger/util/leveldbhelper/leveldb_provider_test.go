@@ -90,6 +90,30 @@ func TestBatchedUpdates(t *testing.T) {
 	}
 }
 
+func TestReadOnlyProvider(t *testing.T) {
+	env := newTestProviderEnv(t, testDBPath)
+	defer env.cleanup()
+	assert.NoError(t, env.provider.GetDBHandle("db1").Put([]byte("key1"), []byte("value1"), true))
+
+	// the db is locked while opened by the read-write provider
+	_, err := NewReadOnlyProvider(&Conf{testDBPath})
+	assert.Error(t, err)
+	env.provider.Close()
+
+	p, err := NewReadOnlyProvider(&Conf{testDBPath})
+	assert.NoError(t, err)
+	defer p.Close()
+	db := p.GetDBHandle("db1")
+	val, err := db.Get([]byte("key1"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value1"), val)
+	assert.Error(t, db.Put([]byte("key2"), []byte("value2"), true))
+	assert.Error(t, db.Delete([]byte("key1"), true))
+
+	_, err = NewReadOnlyProvider(&Conf{testDBPath + "/non-existing"})
+	assert.Error(t, err)
+}
+
 func testDBBasicWriteAndReads(t *testing.T, dbNames ...string) {
 	env := newTestProviderEnv(t, testDBPath)
 	defer env.cleanup()
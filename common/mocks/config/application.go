@@ -8,11 +8,13 @@ package config
 
 import (
 	"github.com/hyperledger/fabric/common/channelconfig"
+	pb "github.com/hyperledger/fabric/protos/peer"
 )
 
 type MockApplication struct {
 	CapabilitiesRv channelconfig.ApplicationCapabilities
 	Acls           map[string]string
+	TokenTypesRv   map[string]*pb.TokenType
 }
 
 func (m *MockApplication) Organizations() map[string]channelconfig.ApplicationOrg {
@@ -35,6 +37,10 @@ func (m *MockApplication) APIPolicyMapper() channelconfig.PolicyMapper {
 	return m
 }
 
+func (m *MockApplication) TokenTypes() map[string]*pb.TokenType {
+	return m.TokenTypesRv
+}
+
 type MockApplicationCapabilities struct {
 	SupportedRv                  error
 	ForbidDuplicateTXIdInBlockRv bool
@@ -48,6 +54,7 @@ type MockApplicationCapabilities struct {
 	V1_3ValidationRv             bool
 	V2_0ValidationRv             bool
 	FabTokenRv                   bool
+	ConfidentialFabTokenRv       bool
 }
 
 func (mac *MockApplicationCapabilities) Supported() error {
@@ -97,3 +104,7 @@ func (mac *MockApplicationCapabilities) V2_0Validation() bool {
 func (mac *MockApplicationCapabilities) FabToken() bool {
 	return mac.FabTokenRv
 }
+
+func (mac *MockApplicationCapabilities) ConfidentialFabToken() bool {
+	return mac.ConfidentialFabTokenRv
+}
@@ -28,6 +28,9 @@ type Orderer struct {
 	BatchSizeVal *ab.BatchSize
 	// BatchTimeoutVal is returned as the result of BatchTimeout()
 	BatchTimeoutVal time.Duration
+	// MinBatchTimeoutVal and MaxBatchTimeoutVal are returned as the result of BatchTimeoutBounds()
+	MinBatchTimeoutVal time.Duration
+	MaxBatchTimeoutVal time.Duration
 	// KafkaBrokersVal is returned as the result of KafkaBrokers()
 	KafkaBrokersVal []string
 	// MaxChannelsCountVal is returns as the result of MaxChannelsCount()
@@ -68,6 +71,11 @@ func (o *Orderer) BatchTimeout() time.Duration {
 	return o.BatchTimeoutVal
 }
 
+// BatchTimeoutBounds returns the MinBatchTimeoutVal and the MaxBatchTimeoutVal
+func (o *Orderer) BatchTimeoutBounds() (time.Duration, time.Duration) {
+	return o.MinBatchTimeoutVal, o.MaxBatchTimeoutVal
+}
+
 // KafkaBrokers returns the KafkaBrokersVal
 func (o *Orderer) KafkaBrokers() []string {
 	return o.KafkaBrokersVal
@@ -103,6 +111,9 @@ type OrdererCapabilities struct {
 	ExpirationVal bool
 
 	Kafka2RaftMigVal bool
+
+	// PriorityBatchingVal is returned by PriorityBatching()
+	PriorityBatchingVal bool
 }
 
 // Supported returns SupportedErr
@@ -130,3 +141,8 @@ func (oc *OrdererCapabilities) ExpirationCheck() bool {
 func (oc *OrdererCapabilities) Kafka2RaftMigration() bool {
 	return oc.Kafka2RaftMigVal
 }
+
+// PriorityBatching returns PriorityBatchingVal
+func (oc *OrdererCapabilities) PriorityBatching() bool {
+	return oc.PriorityBatchingVal
+}
@@ -18,6 +18,8 @@ import (
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/msp"
 	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/orderer/bft"
 	"github.com/hyperledger/fabric/protos/orderer/etcdraft"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
@@ -196,12 +198,33 @@ func NewOrdererGroup(conf *genesisconfig.Orderer) (*cb.ConfigGroup, error) {
 		Policy:    policies.ImplicitMetaAnyPolicy(channelconfig.WritersPolicyKey).Value(),
 		ModPolicy: channelconfig.AdminsPolicyKey,
 	}
-	addValue(ordererGroup, channelconfig.BatchSizeValue(
-		conf.BatchSize.MaxMessageCount,
-		conf.BatchSize.AbsoluteMaxBytes,
-		conf.BatchSize.PreferredMaxBytes,
-	), channelconfig.AdminsPolicyKey)
-	addValue(ordererGroup, channelconfig.BatchTimeoutValue(conf.BatchTimeout.String()), channelconfig.AdminsPolicyKey)
+	if len(conf.BatchSize.PriorityClasses) > 0 {
+		priorityClasses, err := priorityClasses(conf.BatchSize.PriorityClasses)
+		if err != nil {
+			return nil, err
+		}
+		addValue(ordererGroup, channelconfig.PriorityBatchSizeValue(
+			conf.BatchSize.MaxMessageCount,
+			conf.BatchSize.AbsoluteMaxBytes,
+			conf.BatchSize.PreferredMaxBytes,
+			priorityClasses,
+		), channelconfig.AdminsPolicyKey)
+	} else {
+		addValue(ordererGroup, channelconfig.BatchSizeValue(
+			conf.BatchSize.MaxMessageCount,
+			conf.BatchSize.AbsoluteMaxBytes,
+			conf.BatchSize.PreferredMaxBytes,
+		), channelconfig.AdminsPolicyKey)
+	}
+	if conf.MinBatchTimeout != 0 || conf.MaxBatchTimeout != 0 {
+		addValue(ordererGroup, channelconfig.AdaptiveBatchTimeoutValue(
+			conf.BatchTimeout.String(),
+			conf.MinBatchTimeout.String(),
+			conf.MaxBatchTimeout.String(),
+		), channelconfig.AdminsPolicyKey)
+	} else {
+		addValue(ordererGroup, channelconfig.BatchTimeoutValue(conf.BatchTimeout.String()), channelconfig.AdminsPolicyKey)
+	}
 	addValue(ordererGroup, channelconfig.ChannelRestrictionsValue(conf.MaxChannels), channelconfig.AdminsPolicyKey)
 
 	if len(conf.Capabilities) > 0 {
@@ -219,6 +242,12 @@ func NewOrdererGroup(conf *genesisconfig.Orderer) (*cb.ConfigGroup, error) {
 		if consensusMetadata, err = etcdraft.Marshal(conf.EtcdRaft); err != nil {
 			return nil, errors.Errorf("cannot marshal metadata for orderer type %s: %s", etcdraft.TypeKey, err)
 		}
+	case bft.TypeKey:
+		if consensusMetadata, err = bft.Marshal(conf.BFT); err != nil {
+			return nil, errors.Errorf("cannot marshal metadata for orderer type %s: %s", bft.TypeKey, err)
+		}
+		// Blocks of BFT channels must be signed by a quorum of the consenters
+		ordererGroup.Policies[BlockValidationPolicyKey].Policy = bft.BlockValidationPolicy(conf.BFT.Consenters)
 	default:
 		return nil, errors.Errorf("unknown orderer type: %s", conf.OrdererType)
 	}
@@ -237,6 +266,28 @@ func NewOrdererGroup(conf *genesisconfig.Orderer) (*cb.ConfigGroup, error) {
 	return ordererGroup, nil
 }
 
+// priorityClasses converts the priority classes of the batch size, whose header types are given by name.
+func priorityClasses(conf []*genesisconfig.PriorityClass) ([]*ab.PriorityClass, error) {
+	var classes []*ab.PriorityClass
+	for _, c := range conf {
+		class := &ab.PriorityClass{
+			Name:            c.Name,
+			MinPriority:     c.MinPriority,
+			Policy:          c.Policy,
+			MaxMessageCount: c.MaxMessageCount,
+		}
+		for _, name := range c.HeaderTypes {
+			headerType, ok := cb.HeaderType_value[name]
+			if !ok {
+				return nil, errors.Errorf("unknown header type %s in priority class %s", name, c.Name)
+			}
+			class.HeaderTypes = append(class.HeaderTypes, cb.HeaderType(headerType))
+		}
+		classes = append(classes, class)
+	}
+	return classes, nil
+}
+
 // NewOrdererOrgGroup returns an orderer org component of the channel configuration.  It defines the crypto material for the
 // organization (its MSP).  It sets the mod_policy of all elements to "Admins".
 func NewOrdererOrgGroup(conf *genesisconfig.Organization) (*cb.ConfigGroup, error) {
@@ -282,6 +333,18 @@ func NewApplicationGroup(conf *genesisconfig.Application) (*cb.ConfigGroup, erro
 		addValue(applicationGroup, channelconfig.CapabilitiesValue(conf.Capabilities), channelconfig.AdminsPolicyKey)
 	}
 
+	if len(conf.TokenTypes) > 0 {
+		tokenTypes := make(map[string]*pb.TokenType, len(conf.TokenTypes))
+		for name, tokenType := range conf.TokenTypes {
+			tokenTypes[name] = &pb.TokenType{
+				MaxSupply: tokenType.MaxSupply,
+				Decimals:  tokenType.Decimals,
+				Issuer:    tokenType.Issuer,
+			}
+		}
+		addValue(applicationGroup, channelconfig.TokenTypesValue(tokenTypes), channelconfig.AdminsPolicyKey)
+	}
+
 	for _, org := range conf.Organizations {
 		var err error
 		applicationGroup.Groups[org.Name], err = NewApplicationOrgGroup(org)
@@ -8,6 +8,10 @@ package encoder_test
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -19,7 +23,9 @@ import (
 	cb "github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/msp"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/orderer/bft"
 	"github.com/hyperledger/fabric/protos/orderer/etcdraft"
+	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 
 	"github.com/golang/protobuf/proto"
@@ -212,6 +218,64 @@ var _ = Describe("Encoder", func() {
 			})
 		})
 
+		Context("when the batch size has priority classes", func() {
+			BeforeEach(func() {
+				conf.BatchSize.PriorityClasses = []*genesisconfig.PriorityClass{
+					{
+						Name:            "urgent",
+						HeaderTypes:     []string{"CONFIG", "ORDERER_TRANSACTION"},
+						MinPriority:     5,
+						MaxMessageCount: 1,
+					},
+				}
+			})
+
+			It("adds the priority classes to the batch size", func() {
+				cg, err := encoder.NewOrdererGroup(conf)
+				Expect(err).NotTo(HaveOccurred())
+				batchSize := &ab.BatchSize{}
+				err = proto.Unmarshal(cg.Values["BatchSize"].Value, batchSize)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(batchSize.PriorityClasses).To(HaveLen(1))
+				Expect(proto.Equal(batchSize.PriorityClasses[0], &ab.PriorityClass{
+					Name:            "urgent",
+					HeaderTypes:     []cb.HeaderType{cb.HeaderType_CONFIG, cb.HeaderType_ORDERER_TRANSACTION},
+					MinPriority:     5,
+					MaxMessageCount: 1,
+				})).To(BeTrue())
+			})
+
+			Context("when a header type is unknown", func() {
+				BeforeEach(func() {
+					conf.BatchSize.PriorityClasses[0].HeaderTypes = []string{"garbage"}
+				})
+
+				It("returns an error", func() {
+					_, err := encoder.NewOrdererGroup(conf)
+					Expect(err).To(MatchError("unknown header type garbage in priority class urgent"))
+				})
+			})
+		})
+
+		Context("when the batch timeout is adaptive", func() {
+			BeforeEach(func() {
+				conf.BatchTimeout = 2 * time.Second
+				conf.MinBatchTimeout = time.Second
+				conf.MaxBatchTimeout = 5 * time.Second
+			})
+
+			It("adds the bounds to the batch timeout", func() {
+				cg, err := encoder.NewOrdererGroup(conf)
+				Expect(err).NotTo(HaveOccurred())
+				batchTimeout := &ab.BatchTimeout{}
+				err = proto.Unmarshal(cg.Values["BatchTimeout"].Value, batchTimeout)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(batchTimeout.Timeout).To(Equal("2s"))
+				Expect(batchTimeout.MinTimeout).To(Equal("1s"))
+				Expect(batchTimeout.MaxTimeout).To(Equal("5s"))
+			})
+		})
+
 		Context("when the consensus type is Kafka", func() {
 			BeforeEach(func() {
 				conf.OrdererType = "kafka"
@@ -265,6 +329,79 @@ var _ = Describe("Encoder", func() {
 			})
 		})
 
+		Context("when the consensus type is BFT", func() {
+			var tempDir string
+
+			BeforeEach(func() {
+				var err error
+				tempDir, err = ioutil.TempDir("", "encoder")
+				Expect(err).NotTo(HaveOccurred())
+
+				conf.OrdererType = "bft"
+				conf.BFT = &bft.ConfigMetadata{
+					Options: &bft.Options{
+						RequestTimeout:    "10s",
+						ViewChangeTimeout: "20s",
+					},
+				}
+				for i := 1; i <= 4; i++ {
+					consenter := &bft.Consenter{
+						Id:    uint64(i),
+						Host:  fmt.Sprintf("bft%d.example.com", i),
+						Port:  7050,
+						MspId: "SampleOrg",
+					}
+					for _, file := range []struct {
+						name  string
+						field *[]byte
+					}{
+						{name: "identity", field: &consenter.Identity},
+						{name: "client", field: &consenter.ClientTlsCert},
+						{name: "server", field: &consenter.ServerTlsCert},
+					} {
+						path := filepath.Join(tempDir, fmt.Sprintf("%s-%d.pem", file.name, i))
+						err := ioutil.WriteFile(path, []byte(fmt.Sprintf("%s certificate %d", file.name, i)), 0644)
+						Expect(err).NotTo(HaveOccurred())
+						*file.field = []byte(path)
+					}
+					conf.BFT.Consenters = append(conf.BFT.Consenters, consenter)
+				}
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(tempDir)
+			})
+
+			It("adds the BFT metadata and the quorum block validation policy", func() {
+				cg, err := encoder.NewOrdererGroup(conf)
+				Expect(err).NotTo(HaveOccurred())
+				consensusType := &ab.ConsensusType{}
+				err = proto.Unmarshal(cg.Values["ConsensusType"].Value, consensusType)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(consensusType.Type).To(Equal("bft"))
+				metadata := &bft.ConfigMetadata{}
+				err = proto.Unmarshal(consensusType.Metadata, metadata)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(metadata.Consenters).To(HaveLen(4))
+				Expect(metadata.Consenters[0].Identity).To(Equal([]byte("identity certificate 1")))
+				Expect(metadata.Options.RequestTimeout).To(Equal("10s"))
+
+				Expect(proto.Equal(cg.Policies["BlockValidation"].Policy, bft.BlockValidationPolicy(metadata.Consenters))).To(BeTrue())
+				Expect(cg.Policies["BlockValidation"].ModPolicy).To(Equal("Admins"))
+			})
+
+			Context("when the BFT configuration is bad", func() {
+				BeforeEach(func() {
+					conf.BFT.Consenters[0].Identity = []byte(filepath.Join(tempDir, "missing.pem"))
+				})
+
+				It("wraps and returns the error", func() {
+					_, err := encoder.NewOrdererGroup(conf)
+					Expect(err).To(MatchError(fmt.Sprintf("cannot marshal metadata for orderer type bft: cannot load identity for consenter bft1.example.com:7050: open %s: no such file or directory", filepath.Join(tempDir, "missing.pem"))))
+				})
+			})
+		})
+
 		Context("when the consensus type is unknown", func() {
 			BeforeEach(func() {
 				conf.OrdererType = "bad-type"
@@ -366,6 +503,34 @@ var _ = Describe("Encoder", func() {
 				Expect(err).To(MatchError("failed to create application org: 1 - Error loading MSP configuration for org SampleOrg: unknown MSP type 'garbage'"))
 			})
 		})
+
+		Context("when token types are defined", func() {
+			BeforeEach(func() {
+				conf.TokenTypes = map[string]*genesisconfig.TokenType{
+					"USD": {
+						MaxSupply: 1000,
+						Decimals:  2,
+						Issuer:    "SampleMSP",
+					},
+				}
+			})
+
+			It("adds the token types value", func() {
+				cg, err := encoder.NewApplicationGroup(conf)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(cg.Values)).To(Equal(3))
+				Expect(cg.Values["TokenTypes"]).NotTo(BeNil())
+
+				tokenTypes := &pb.TokenTypes{}
+				err = proto.Unmarshal(cg.Values["TokenTypes"].Value, tokenTypes)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(proto.Equal(tokenTypes, &pb.TokenTypes{
+					TokenTypes: map[string]*pb.TokenType{
+						"USD": {MaxSupply: 1000, Decimals: 2, Issuer: "SampleMSP"},
+					},
+				})).To(BeTrue())
+			})
+		})
 	})
 
 	Describe("NewOrdererOrgGroup", func() {
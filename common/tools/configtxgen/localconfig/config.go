@@ -17,6 +17,7 @@ import (
 	"github.com/hyperledger/fabric/common/viperutil"
 	cf "github.com/hyperledger/fabric/core/config"
 	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protos/orderer/bft"
 	"github.com/hyperledger/fabric/protos/orderer/etcdraft"
 	"github.com/spf13/viper"
 )
@@ -113,11 +114,20 @@ type Consortium struct {
 // Application encodes the application-level configuration needed in config
 // transactions.
 type Application struct {
-	Organizations []*Organization    `yaml:"Organizations"`
-	Capabilities  map[string]bool    `yaml:"Capabilities"`
-	Resources     *Resources         `yaml:"Resources"`
-	Policies      map[string]*Policy `yaml:"Policies"`
-	ACLs          map[string]string  `yaml:"ACLs"`
+	Organizations []*Organization       `yaml:"Organizations"`
+	Capabilities  map[string]bool       `yaml:"Capabilities"`
+	Resources     *Resources            `yaml:"Resources"`
+	Policies      map[string]*Policy    `yaml:"Policies"`
+	ACLs          map[string]string     `yaml:"ACLs"`
+	TokenTypes    map[string]*TokenType `yaml:"TokenTypes"`
+}
+
+// TokenType encodes the definition of a token type that may be issued on
+// the channel.
+type TokenType struct {
+	MaxSupply uint64 `yaml:"MaxSupply"`
+	Decimals  uint32 `yaml:"Decimals"`
+	Issuer    string `yaml:"Issuer"`
 }
 
 // Resources encodes the application-level resources configuration needed to
@@ -155,23 +165,37 @@ type AnchorPeer struct {
 // Orderer contains configuration which is used for the
 // bootstrapping of an orderer by the provisional bootstrapper.
 type Orderer struct {
-	OrdererType   string             `yaml:"OrdererType"`
-	Addresses     []string           `yaml:"Addresses"`
-	BatchTimeout  time.Duration      `yaml:"BatchTimeout"`
-	BatchSize     BatchSize          `yaml:"BatchSize"`
-	Kafka         Kafka              `yaml:"Kafka"`
-	EtcdRaft      *etcdraft.Metadata `yaml:"EtcdRaft"`
-	Organizations []*Organization    `yaml:"Organizations"`
-	MaxChannels   uint64             `yaml:"MaxChannels"`
-	Capabilities  map[string]bool    `yaml:"Capabilities"`
-	Policies      map[string]*Policy `yaml:"Policies"`
+	OrdererType     string              `yaml:"OrdererType"`
+	Addresses       []string            `yaml:"Addresses"`
+	BatchTimeout    time.Duration       `yaml:"BatchTimeout"`
+	MinBatchTimeout time.Duration       `yaml:"MinBatchTimeout"`
+	MaxBatchTimeout time.Duration       `yaml:"MaxBatchTimeout"`
+	BatchSize       BatchSize           `yaml:"BatchSize"`
+	Kafka           Kafka               `yaml:"Kafka"`
+	EtcdRaft        *etcdraft.Metadata  `yaml:"EtcdRaft"`
+	BFT             *bft.ConfigMetadata `yaml:"BFT"`
+	Organizations   []*Organization     `yaml:"Organizations"`
+	MaxChannels     uint64              `yaml:"MaxChannels"`
+	Capabilities    map[string]bool     `yaml:"Capabilities"`
+	Policies        map[string]*Policy  `yaml:"Policies"`
 }
 
 // BatchSize contains configuration affecting the size of batches.
 type BatchSize struct {
-	MaxMessageCount   uint32 `yaml:"MaxMessageCount"`
-	AbsoluteMaxBytes  uint32 `yaml:"AbsoluteMaxBytes"`
-	PreferredMaxBytes uint32 `yaml:"PreferredMaxBytes"`
+	MaxMessageCount   uint32           `yaml:"MaxMessageCount"`
+	AbsoluteMaxBytes  uint32           `yaml:"AbsoluteMaxBytes"`
+	PreferredMaxBytes uint32           `yaml:"PreferredMaxBytes"`
+	PriorityClasses   []*PriorityClass `yaml:"PriorityClasses"`
+}
+
+// PriorityClass contains configuration for a class of transactions
+// which force an early cut of a batch.
+type PriorityClass struct {
+	Name            string   `yaml:"Name"`
+	HeaderTypes     []string `yaml:"HeaderTypes"`
+	MinPriority     uint32   `yaml:"MinPriority"`
+	Policy          string   `yaml:"Policy"`
+	MaxMessageCount uint32   `yaml:"MaxMessageCount"`
 }
 
 // Kafka contains configuration for the Kafka-based orderer.
@@ -202,6 +226,12 @@ var genesisDefaults = TopLevel{
 				SnapshotInterval: 100 * 1024 * 1024, // 100MB
 			},
 		},
+		BFT: &bft.ConfigMetadata{
+			Options: &bft.Options{
+				RequestTimeout:    "10s",
+				ViewChangeTimeout: "20s",
+			},
+		},
 	},
 }
 
@@ -461,6 +491,70 @@ loop:
 			cf.TranslatePathInPlace(configDir, &serverCertPath)
 			c.ServerTlsCert = []byte(serverCertPath)
 		}
+	case bft.TypeKey:
+		if ord.BFT == nil {
+			logger.Panicf("%s configuration missing", bft.TypeKey)
+		}
+		if ord.BFT.Options == nil {
+			logger.Infof("Orderer.BFT.Options unset, setting to %v", genesisDefaults.Orderer.BFT.Options)
+			ord.BFT.Options = genesisDefaults.Orderer.BFT.Options
+		}
+	third_loop:
+		for {
+			switch {
+			case ord.BFT.Options.RequestTimeout == "":
+				logger.Infof("Orderer.BFT.Options.RequestTimeout unset, setting to %v", genesisDefaults.Orderer.BFT.Options.RequestTimeout)
+				ord.BFT.Options.RequestTimeout = genesisDefaults.Orderer.BFT.Options.RequestTimeout
+
+			case ord.BFT.Options.ViewChangeTimeout == "":
+				logger.Infof("Orderer.BFT.Options.ViewChangeTimeout unset, setting to %v", genesisDefaults.Orderer.BFT.Options.ViewChangeTimeout)
+				ord.BFT.Options.ViewChangeTimeout = genesisDefaults.Orderer.BFT.Options.ViewChangeTimeout
+
+			case len(ord.BFT.Consenters) == 0:
+				logger.Panicf("%s configuration did not specify any consenter", bft.TypeKey)
+
+			default:
+				break third_loop
+			}
+		}
+
+		ids := make(map[uint64]struct{})
+		for _, c := range ord.BFT.GetConsenters() {
+			if c.Id == 0 {
+				logger.Panicf("consenter info in %s configuration did not specify id", bft.TypeKey)
+			}
+			if _, exists := ids[c.Id]; exists {
+				logger.Panicf("consenter info in %s configuration specified id %d more than once", bft.TypeKey, c.Id)
+			}
+			ids[c.Id] = struct{}{}
+			if c.Host == "" {
+				logger.Panicf("consenter info in %s configuration did not specify host", bft.TypeKey)
+			}
+			if c.Port == 0 {
+				logger.Panicf("consenter info in %s configuration did not specify port", bft.TypeKey)
+			}
+			if c.MspId == "" {
+				logger.Panicf("consenter info in %s configuration did not specify MSP ID", bft.TypeKey)
+			}
+			if c.Identity == nil {
+				logger.Panicf("consenter info in %s configuration did not specify identity", bft.TypeKey)
+			}
+			if c.ClientTlsCert == nil {
+				logger.Panicf("consenter info in %s configuration did not specify client TLS cert", bft.TypeKey)
+			}
+			if c.ServerTlsCert == nil {
+				logger.Panicf("consenter info in %s configuration did not specify server TLS cert", bft.TypeKey)
+			}
+			identityPath := string(c.GetIdentity())
+			cf.TranslatePathInPlace(configDir, &identityPath)
+			c.Identity = []byte(identityPath)
+			clientCertPath := string(c.GetClientTlsCert())
+			cf.TranslatePathInPlace(configDir, &clientCertPath)
+			c.ClientTlsCert = []byte(clientCertPath)
+			serverCertPath := string(c.GetServerTlsCert())
+			cf.TranslatePathInPlace(configDir, &serverCertPath)
+			c.ServerTlsCert = []byte(serverCertPath)
+		}
 	default:
 		logger.Panicf("unknown orderer type: %s", ord.OrdererType)
 	}
@@ -7,9 +7,11 @@ SPDX-License-Identifier: Apache-2.0
 package localconfig
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/hyperledger/fabric/core/config/configtest"
+	"github.com/hyperledger/fabric/protos/orderer/bft"
 	"github.com/hyperledger/fabric/protos/orderer/etcdraft"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -259,4 +261,97 @@ func TestConsensusSpecificInit(t *testing.T) {
 			})
 		})
 	})
+
+	t.Run("bft", func(t *testing.T) {
+		consenter := func() *bft.Consenter {
+			return &bft.Consenter{
+				Id:            1,
+				Host:          "node-1.example.com",
+				Port:          7050,
+				MspId:         "SampleOrg",
+				Identity:      []byte("path/to/identity"),
+				ClientTlsCert: []byte("path/to/client/cert"),
+				ServerTlsCert: []byte("path/to/server/cert"),
+			}
+		}
+		makeProfile := func(consenters []*bft.Consenter, options *bft.Options) *Profile {
+			return &Profile{
+				Orderer: &Orderer{
+					OrdererType: "bft",
+					BFT: &bft.ConfigMetadata{
+						Consenters: consenters,
+						Options:    options,
+					},
+				},
+			}
+		}
+
+		t.Run("BFT section not specified in profile", func(t *testing.T) {
+			profile := &Profile{
+				Orderer: &Orderer{
+					OrdererType: "bft",
+				},
+			}
+
+			assert.Panics(t, func() {
+				profile.completeInitialization(devConfigDir)
+			})
+		})
+
+		t.Run("nil consenter set", func(t *testing.T) {
+			profile := makeProfile(nil, nil)
+
+			assert.Panics(t, func() {
+				profile.completeInitialization(devConfigDir)
+			})
+		})
+
+		t.Run("invalid consenters specification", func(t *testing.T) {
+			for _, unset := range []func(*bft.Consenter){
+				func(c *bft.Consenter) { c.Id = 0 },
+				func(c *bft.Consenter) { c.Host = "" },
+				func(c *bft.Consenter) { c.Port = 0 },
+				func(c *bft.Consenter) { c.MspId = "" },
+				func(c *bft.Consenter) { c.Identity = nil },
+				func(c *bft.Consenter) { c.ClientTlsCert = nil },
+				func(c *bft.Consenter) { c.ServerTlsCert = nil },
+			} {
+				c := consenter()
+				unset(c)
+				profile := makeProfile([]*bft.Consenter{c}, nil)
+
+				assert.Panics(t, func() {
+					profile.completeInitialization(devConfigDir)
+				})
+			}
+		})
+
+		t.Run("duplicate consenter IDs", func(t *testing.T) {
+			profile := makeProfile([]*bft.Consenter{consenter(), consenter()}, nil)
+
+			assert.Panics(t, func() {
+				profile.completeInitialization(devConfigDir)
+			})
+		})
+
+		t.Run("nil Options", func(t *testing.T) {
+			profile := makeProfile([]*bft.Consenter{consenter()}, nil)
+			profile.completeInitialization(devConfigDir)
+
+			assert.Equal(t, genesisDefaults.Orderer.BFT.Options, profile.Orderer.BFT.Options,
+				"Options should be set to the default value")
+			assert.Equal(t, filepath.Join(devConfigDir, "path/to/identity"), string(profile.Orderer.BFT.Consenters[0].Identity),
+				"Identity path should be translated")
+		})
+
+		t.Run("request timeout specified in Options", func(t *testing.T) {
+			profile := makeProfile([]*bft.Consenter{consenter()}, &bft.Options{RequestTimeout: "1m"})
+			profile.completeInitialization(devConfigDir)
+
+			assert.Equal(t, "1m", profile.Orderer.BFT.Options.RequestTimeout,
+				"RequestTimeout should be set to the specified value")
+			assert.Equal(t, genesisDefaults.Orderer.BFT.Options.ViewChangeTimeout, profile.Orderer.BFT.Options.ViewChangeTimeout,
+				"ViewChangeTimeout should be set to the default value")
+		})
+	})
 }
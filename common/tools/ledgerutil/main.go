@@ -0,0 +1,84 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+// ledgerutil is a command line tool that verifies the integrity of the ledgers
+// of a stopped peer or orderer without modifying them
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hyperledger/fabric/common/tools/ledgerutil/metadata"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// exit code when the verification finds a corrupted ledger
+const exitCodeCorrupted = 2
+
+// command line flags
+var (
+	app = kingpin.New("ledgerutil", "Utility for verifying the integrity of the ledgers of a stopped peer or orderer")
+
+	verify        = app.Command("verify", "Verify the ledgers and print a JSON report of the first bad block of each ledger. Exits with code 2 if a ledger is corrupted")
+	blockStoreDir = verify.Flag("block-store", "The directory of the block storage, that is, <peer.fileSystemPath>/ledgersData/chains for a peer or <FileLedger.Location> for an orderer").Required().String()
+	channels      = verify.Flag("channel", "The channel whose ledger is verified, can be repeated. All the ledgers in the block storage are verified by default").Strings()
+	orderer       = verify.Flag("orderer", "The block storage belongs to an orderer").Bool()
+	stateDBDir    = verify.Flag("state-db", "The directory of the LevelDB state database of a peer, that is, <peer.fileSystemPath>/ledgersData/stateLeveldb. The savepoint of the state database is verified if specified").String()
+
+	version = app.Command("version", "Show version information")
+)
+
+func main() {
+	app.HelpFlag.Short('h')
+
+	switch kingpin.MustParse(app.Parse(os.Args[1:])) {
+
+	case verify.FullCommand():
+		if *orderer && *stateDBDir != "" {
+			kingpin.Fatalf("the state database cannot be verified for an orderer")
+		}
+		reports, err := verifyLedgers(&verifyConfig{
+			blockStoreDir: *blockStoreDir,
+			channels:      *channels,
+			orderer:       *orderer,
+			stateDBDir:    *stateDBDir,
+		})
+		kingpin.FatalIfError(err, "verification failed")
+		passed, err := writeReports(os.Stdout, reports)
+		kingpin.FatalIfError(err, "error writing the report")
+		if !passed {
+			os.Exit(exitCodeCorrupted)
+		}
+
+	case version.FullCommand():
+		printVersion()
+	}
+}
+
+// writeReports writes the given reports as JSON and returns whether all the ledgers passed the verification
+func writeReports(w io.Writer, reports []*ledgerReport) (bool, error) {
+	reportBytes, err := json.MarshalIndent(reports, "", "  ")
+	if err != nil {
+		return false, err
+	}
+	if _, err := fmt.Fprintln(w, string(reportBytes)); err != nil {
+		return false, err
+	}
+	for _, report := range reports {
+		if !report.Passed {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func printVersion() {
+	fmt.Println(metadata.GetVersionInfo())
+}
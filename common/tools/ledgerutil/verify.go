@@ -0,0 +1,240 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
+	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("ledgerutil")
+
+// peerIndexConfig is the index configuration of the block storage of the peer ledgers
+var peerIndexConfig = &blkstorage.IndexConfig{AttrsToIndex: []blkstorage.IndexableAttr{
+	blkstorage.IndexableAttrBlockHash,
+	blkstorage.IndexableAttrBlockNum,
+	blkstorage.IndexableAttrTxID,
+	blkstorage.IndexableAttrBlockNumTranNum,
+	blkstorage.IndexableAttrBlockTxID,
+	blkstorage.IndexableAttrTxValidationCode,
+}}
+
+// ordererIndexConfig is the index configuration of the block storage of the orderer ledgers
+var ordererIndexConfig = &blkstorage.IndexConfig{AttrsToIndex: []blkstorage.IndexableAttr{
+	blkstorage.IndexableAttrBlockNum,
+}}
+
+// verifyConfig contains the parameters of the verification
+type verifyConfig struct {
+	blockStoreDir string
+	channels      []string
+	orderer       bool
+	stateDBDir    string
+}
+
+// ledgerReport is the outcome of the verification of a single ledger
+type ledgerReport struct {
+	Channel string `json:"channel"`
+	// FirstBlock is the number of the first block that is available in the block storage
+	FirstBlock uint64 `json:"firstBlock"`
+	// VerifiedBlocks is the number of consecutive blocks, starting with block `FirstBlock`, that passed the verification
+	VerifiedBlocks uint64 `json:"verifiedBlocks"`
+	// SignaturesVerifiedFromBlock is the number of the first block whose signatures were verified. The signatures
+	// of the preceding blocks cannot be verified if the block storage does not contain the channel config for them
+	SignaturesVerifiedFromBlock *uint64 `json:"signaturesVerifiedFromBlock,omitempty"`
+	// StateDBSavepoint is the number of the last block committed to the state database
+	StateDBSavepoint *uint64   `json:"stateDBSavepoint,omitempty"`
+	FirstBadBlock    *badBlock `json:"firstBadBlock,omitempty"`
+	Warnings         []string  `json:"warnings,omitempty"`
+	Passed           bool      `json:"passed"`
+}
+
+type badBlock struct {
+	Number uint64 `json:"number"`
+	Reason string `json:"reason"`
+}
+
+// verifyLedgers verifies the ledgers of the given channels, or all the ledgers in the block storage if
+// no channel is specified. An error is returned only if a verification cannot be performed
+func verifyLedgers(conf *verifyConfig) ([]*ledgerReport, error) {
+	channels := conf.channels
+	if len(channels) == 0 {
+		var err error
+		if channels, err = util.ListSubdirs(filepath.Join(conf.blockStoreDir, fsblkstorage.ChainsDir)); err != nil {
+			return nil, errors.Wrapf(err, "error listing the ledgers in block storage [%s]", conf.blockStoreDir)
+		}
+		if len(channels) == 0 {
+			return nil, errors.Errorf("no ledger found in block storage [%s]", conf.blockStoreDir)
+		}
+	}
+	var reports []*ledgerReport
+	for _, channel := range channels {
+		report, err := verifyLedger(conf, channel)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("error verifying the ledger of channel [%s]", channel))
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+func verifyLedger(conf *verifyConfig, channel string) (*ledgerReport, error) {
+	indexConfig := peerIndexConfig
+	if conf.orderer {
+		indexConfig = ordererIndexConfig
+	}
+	verifier := &signatureVerifier{channel: channel}
+	result, err := fsblkstorage.VerifyBlockStore(fsblkstorage.NewConf(conf.blockStoreDir, 0), indexConfig, channel, verifier)
+	if err != nil {
+		return nil, err
+	}
+	report := &ledgerReport{
+		Channel:                     channel,
+		FirstBlock:                  result.FirstBlockNum,
+		VerifiedBlocks:              result.NumVerifiedBlocks,
+		SignaturesVerifiedFromBlock: verifier.signaturesVerifiedFromBlock,
+		Warnings:                    result.Warnings,
+	}
+	if result.BadBlock != nil {
+		report.FirstBadBlock = &badBlock{Number: result.BadBlock.BlockNum, Reason: result.BadBlock.Reason}
+	}
+
+	if conf.stateDBDir != "" {
+		if err := verifySavepoint(conf.stateDBDir, report); err != nil {
+			return nil, err
+		}
+	}
+	report.Passed = report.FirstBadBlock == nil
+	return report, nil
+}
+
+// verifySavepoint checks the savepoint of the state database against the blocks available in the block storage.
+// The state database is allowed to be behind the block storage because the peer catches it up on start, but
+// the state database must not contain the updates of a block that is missing from the block storage
+func verifySavepoint(stateDBDir string, report *ledgerReport) error {
+	savepoint, err := stateleveldb.RetrieveSavepoint(stateDBDir, report.Channel)
+	if err != nil {
+		return errors.WithMessage(err, "error retrieving the savepoint of the state database")
+	}
+	height := report.FirstBlock + report.VerifiedBlocks
+	if savepoint == nil {
+		if height > 0 {
+			report.Warnings = append(report.Warnings, "state database does not contain a savepoint")
+		}
+		return nil
+	}
+	report.StateDBSavepoint = &savepoint.BlockNum
+	switch {
+	case savepoint.BlockNum >= height && report.FirstBadBlock == nil:
+		report.FirstBadBlock = &badBlock{
+			Number: height,
+			Reason: fmt.Sprintf("block is missing from the block storage, the state database contains the updates up to block [%d]",
+				savepoint.BlockNum),
+		}
+	case savepoint.BlockNum+1 < height:
+		report.Warnings = append(report.Warnings,
+			fmt.Sprintf("state database is behind the block storage, the last block committed to the state database is [%d]",
+				savepoint.BlockNum))
+	}
+	return nil
+}
+
+// signatureVerifier verifies the signatures of the blocks against the block validation policy of the channel
+// config in effect at each block. It implements interface `fsblkstorage.BlockVerifier`
+type signatureVerifier struct {
+	channel                     string
+	verifier                    cluster.BlockVerifier
+	lastConfigBlockNum          uint64
+	signaturesVerifiedFromBlock *uint64
+}
+
+// Init implements method in interface `fsblkstorage.BlockVerifier`
+func (v *signatureVerifier) Init(snapshotConfigBlock *common.Block) error {
+	if snapshotConfigBlock == nil {
+		return nil
+	}
+	return v.updateConfig(snapshotConfigBlock)
+}
+
+// VerifyBlock implements method in interface `fsblkstorage.BlockVerifier`
+func (v *signatureVerifier) VerifyBlock(block *common.Block) error {
+	blockNum := block.Header.Number
+	isConfig := isConfigBlock(block)
+	// the genesis block is not signed
+	if blockNum == 0 {
+		if !isConfig {
+			return errors.New("genesis block does not contain a config transaction")
+		}
+		return v.updateConfig(block)
+	}
+	if v.verifier == nil {
+		// the block storage does not contain the config that the block was signed with
+		if isConfig {
+			return v.updateConfig(block)
+		}
+		return nil
+	}
+
+	if err := cluster.VerifyBlockSignature(block, v.verifier, nil); err != nil {
+		return errors.WithMessage(err, "error verifying the block signatures against the block validation policy")
+	}
+	if v.signaturesVerifiedFromBlock == nil {
+		v.signaturesVerifiedFromBlock = &blockNum
+	}
+	expectedLastConfigBlockNum := v.lastConfigBlockNum
+	if isConfig {
+		expectedLastConfigBlockNum = blockNum
+	}
+	lastConfigBlockNum, err := utils.GetLastConfigIndexFromBlock(block)
+	if err != nil {
+		return errors.WithMessage(err, "error retrieving the last config index from the block metadata")
+	}
+	if lastConfigBlockNum != expectedLastConfigBlockNum {
+		return errors.Errorf("last config index [%d] in the block metadata does not match the last config block [%d]",
+			lastConfigBlockNum, expectedLastConfigBlockNum)
+	}
+	if isConfig {
+		return v.updateConfig(block)
+	}
+	return nil
+}
+
+// updateConfig switches to the channel config contained in the given config block
+func (v *signatureVerifier) updateConfig(configBlock *common.Block) error {
+	configEnv, err := cluster.ConfigFromBlock(configBlock)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("error extracting the channel config from block [%d]", configBlock.Header.Number))
+	}
+	verifier, err := (&cluster.BlockVerifierAssembler{Logger: logger}).VerifierFromConfig(configEnv, v.channel)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("error loading the channel config from block [%d]", configBlock.Header.Number))
+	}
+	v.verifier = verifier
+	v.lastConfigBlockNum = configBlock.Header.Number
+	return nil
+}
+
+// isConfigBlock tells whether the given block contains a config transaction of the channel. Unlike function
+// `utils.IsConfigBlock`, it does not consider the orderer transactions of the system channel as config transactions
+func isConfigBlock(block *common.Block) bool {
+	env, err := utils.ExtractEnvelope(block, 0)
+	if err != nil {
+		return false
+	}
+	chdr, err := utils.ChannelHeader(env)
+	return err == nil && common.HeaderType(chdr.Type) == common.HeaderType_CONFIG
+}
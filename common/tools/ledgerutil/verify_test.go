@@ -0,0 +1,273 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/localmsp"
+	"github.com/hyperledger/fabric/common/tools/configtxgen/configtxgentest"
+	"github.com/hyperledger/fabric/common/tools/configtxgen/encoder"
+	"github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
+	ledgerversion "github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	ledgerUtil "github.com/hyperledger/fabric/core/ledger/util"
+	msptesttools "github.com/hyperledger/fabric/msp/mgmt/testtools"
+	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	if err := msptesttools.LoadMSPSetupForTesting(); err != nil {
+		panic(fmt.Sprintf("error loading the MSP setup: %s", err))
+	}
+	os.Exit(m.Run())
+}
+
+// testChain generates the blocks of a channel signed by the orderer organization of the sample config
+type testChain struct {
+	t          *testing.T
+	channel    string
+	signer     crypto.LocalSigner
+	configEnv  *common.ConfigEnvelope
+	blocks     []*common.Block
+	lastConfig uint64
+}
+
+func newTestChain(t *testing.T, channel string) *testChain {
+	genesisBlock := encoder.New(configtxgentest.Load(localconfig.SampleDevModeSoloProfile)).GenesisBlockForChannel(channel)
+	// the peer sets the transactions filter when it commits the genesis block
+	genesisBlock.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = ledgerUtil.NewTxValidationFlagsSetValue(1, peer.TxValidationCode_VALID)
+	configEnv, err := cluster.ConfigFromBlock(genesisBlock)
+	assert.NoError(t, err)
+	return &testChain{
+		t:         t,
+		channel:   channel,
+		signer:    localmsp.NewSigner(),
+		configEnv: configEnv,
+		blocks:    []*common.Block{genesisBlock},
+	}
+}
+
+func (c *testChain) addTxBlock() *common.Block {
+	txid := fmt.Sprintf("txid-%d", len(c.blocks))
+	env, _, err := testutil.ConstructTransaction(c.t, []byte("simulation results"), txid, false)
+	assert.NoError(c.t, err)
+	return c.addBlock(env, false)
+}
+
+func (c *testChain) addConfigBlock() *common.Block {
+	env, err := utils.CreateSignedEnvelope(common.HeaderType_CONFIG, c.channel, c.signer, c.configEnv, 0, 0)
+	assert.NoError(c.t, err)
+	return c.addBlock(env, true)
+}
+
+func (c *testChain) addBlock(env *common.Envelope, isConfig bool) *common.Block {
+	previousBlock := c.blocks[len(c.blocks)-1]
+	block := common.NewBlock(previousBlock.Header.Number+1, previousBlock.Header.Hash())
+	block.Data.Data = [][]byte{utils.MarshalOrPanic(env)}
+	block.Header.DataHash = block.Data.Hash()
+	if isConfig {
+		c.lastConfig = block.Header.Number
+	}
+	block.Metadata.Metadata[common.BlockMetadataIndex_LAST_CONFIG] = utils.MarshalOrPanic(&common.Metadata{
+		Value: utils.MarshalOrPanic(&common.LastConfig{Index: c.lastConfig}),
+	})
+	block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = ledgerUtil.NewTxValidationFlagsSetValue(1, peer.TxValidationCode_VALID)
+	c.sign(block)
+	c.blocks = append(c.blocks, block)
+	return block
+}
+
+func (c *testChain) sign(block *common.Block) {
+	sigHdr, err := c.signer.NewSignatureHeader()
+	assert.NoError(c.t, err)
+	sigHdrBytes := utils.MarshalOrPanic(sigHdr)
+	signature, err := c.signer.Sign(util.ConcatenateBytes(nil, sigHdrBytes, block.Header.Bytes()))
+	assert.NoError(c.t, err)
+	block.Metadata.Metadata[common.BlockMetadataIndex_SIGNATURES] = utils.MarshalOrPanic(&common.Metadata{
+		Signatures: []*common.MetadataSignature{{SignatureHeader: sigHdrBytes, Signature: signature}},
+	})
+}
+
+func (c *testChain) writeBlockStore(blockStoreDir string) {
+	provider := fsblkstorage.NewProvider(fsblkstorage.NewConf(blockStoreDir, 0), peerIndexConfig)
+	defer provider.Close()
+	store, err := provider.OpenBlockStore(c.channel)
+	assert.NoError(c.t, err)
+	defer store.Shutdown()
+	for _, block := range c.blocks {
+		assert.NoError(c.t, store.AddBlock(block))
+	}
+}
+
+// writeSavepoint commits an empty update batch at the given block to the state database in the given directory
+func writeSavepoint(t *testing.T, stateDBDir, channel string, blockNum uint64) {
+	viper.Set("peer.fileSystemPath", filepath.Dir(filepath.Dir(stateDBDir)))
+	defer viper.Reset()
+	assert.Equal(t, stateDBDir, ledgerconfig.GetStateLevelDBPath())
+	provider := stateleveldb.NewVersionedDBProvider()
+	defer provider.Close()
+	db, err := provider.GetDBHandle(channel)
+	assert.NoError(t, err)
+	assert.NoError(t, db.ApplyUpdates(statedb.NewUpdateBatch(), ledgerversion.NewHeight(blockNum, 0)))
+}
+
+func uint64Ptr(n uint64) *uint64 {
+	return &n
+}
+
+func TestVerifyLedgers(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "ledgerutil")
+	assert.NoError(t, err)
+	defer os.RemoveAll(testDir)
+	blockStoreDir := filepath.Join(testDir, "ledgersData", "chains")
+	stateDBDir := filepath.Join(testDir, "ledgersData", "stateLeveldb")
+
+	chain1 := newTestChain(t, "channel1")
+	chain1.addTxBlock()
+	chain1.addConfigBlock()
+	chain1.addTxBlock()
+	chain1.writeBlockStore(blockStoreDir)
+	writeSavepoint(t, stateDBDir, "channel1", 3)
+
+	// the signature of block 2 is tampered with
+	chain2 := newTestChain(t, "channel2")
+	chain2.addTxBlock()
+	badBlock := chain2.addTxBlock()
+	signatures := utils.GetMetadataFromBlockOrPanic(badBlock, common.BlockMetadataIndex_SIGNATURES)
+	signatures.Signatures[0].Signature = []byte("bad signature")
+	badBlock.Metadata.Metadata[common.BlockMetadataIndex_SIGNATURES] = utils.MarshalOrPanic(signatures)
+	chain2.addTxBlock()
+	chain2.writeBlockStore(blockStoreDir)
+	writeSavepoint(t, stateDBDir, "channel2", 3)
+
+	reports, err := verifyLedgers(&verifyConfig{blockStoreDir: blockStoreDir, stateDBDir: stateDBDir})
+	assert.NoError(t, err)
+	assert.Len(t, reports, 2)
+	assert.Equal(t, &ledgerReport{
+		Channel:                     "channel1",
+		VerifiedBlocks:              4,
+		SignaturesVerifiedFromBlock: uint64Ptr(1),
+		StateDBSavepoint:            uint64Ptr(3),
+		Passed:                      true,
+	}, reports[0])
+	assert.Equal(t, "channel2", reports[1].Channel)
+	assert.Equal(t, uint64(2), reports[1].VerifiedBlocks)
+	assert.Equal(t, uint64(2), reports[1].FirstBadBlock.Number)
+	assert.Contains(t, reports[1].FirstBadBlock.Reason, "error verifying the block signatures against the block validation policy")
+	assert.False(t, reports[1].Passed)
+
+	buf := &bytes.Buffer{}
+	passed, err := writeReports(buf, reports)
+	assert.NoError(t, err)
+	assert.False(t, passed)
+	assert.Contains(t, buf.String(), `"firstBadBlock": {`)
+
+	// the orderer configuration only verifies the block number index and skips the state database
+	reports, err = verifyLedgers(&verifyConfig{blockStoreDir: blockStoreDir, channels: []string{"channel1"}, orderer: true})
+	assert.NoError(t, err)
+	assert.Equal(t, &ledgerReport{
+		Channel:                     "channel1",
+		VerifiedBlocks:              4,
+		SignaturesVerifiedFromBlock: uint64Ptr(1),
+		Passed:                      true,
+	}, reports[0])
+	passed, err = writeReports(ioutil.Discard, reports)
+	assert.NoError(t, err)
+	assert.True(t, passed)
+
+	_, err = verifyLedgers(&verifyConfig{blockStoreDir: blockStoreDir, channels: []string{"channel3"}})
+	assert.EqualError(t, err, "error verifying the ledger of channel [channel3]: block store for ledger [channel3] does not exist")
+	_, err = verifyLedgers(&verifyConfig{blockStoreDir: stateDBDir})
+	assert.Contains(t, err.Error(), fmt.Sprintf("error listing the ledgers in block storage [%s]", stateDBDir))
+	emptyBlockStoreDir := filepath.Join(testDir, "empty")
+	assert.NoError(t, os.MkdirAll(filepath.Join(emptyBlockStoreDir, fsblkstorage.ChainsDir), 0755))
+	_, err = verifyLedgers(&verifyConfig{blockStoreDir: emptyBlockStoreDir})
+	assert.EqualError(t, err, fmt.Sprintf("no ledger found in block storage [%s]", emptyBlockStoreDir))
+}
+
+func TestVerifySavepoint(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "ledgerutil")
+	assert.NoError(t, err)
+	defer os.RemoveAll(testDir)
+
+	stateDBDir := filepath.Join(testDir, "ledgersData", "stateLeveldb")
+	writeSavepoint(t, stateDBDir, "channel2", 2)
+	report := &ledgerReport{Channel: "channel1", VerifiedBlocks: 5}
+	assert.NoError(t, verifySavepoint(stateDBDir, report))
+	assert.Equal(t, []string{"state database does not contain a savepoint"}, report.Warnings)
+
+	writeSavepoint(t, stateDBDir, "channel1", 2)
+	report = &ledgerReport{Channel: "channel1", VerifiedBlocks: 5}
+	assert.NoError(t, verifySavepoint(stateDBDir, report))
+	assert.Equal(t, uint64Ptr(2), report.StateDBSavepoint)
+	assert.Nil(t, report.FirstBadBlock)
+	assert.Equal(t, []string{"state database is behind the block storage, the last block committed to the state database is [2]"},
+		report.Warnings)
+
+	writeSavepoint(t, stateDBDir, "channel1", 7)
+	report = &ledgerReport{Channel: "channel1", VerifiedBlocks: 5}
+	assert.NoError(t, verifySavepoint(stateDBDir, report))
+	assert.Equal(t, &badBlock{
+		Number: 5,
+		Reason: "block is missing from the block storage, the state database contains the updates up to block [7]",
+	}, report.FirstBadBlock)
+	assert.Empty(t, report.Warnings)
+}
+
+func TestSignatureVerifier(t *testing.T) {
+	chain := newTestChain(t, "channel1")
+	txBlock := chain.addTxBlock()
+	configBlock := chain.addConfigBlock()
+	lastBlock := chain.addTxBlock()
+
+	// the config of a block store bootstrapped from a snapshot is not available before the first config block
+	verifier := &signatureVerifier{channel: "channel1"}
+	assert.NoError(t, verifier.Init(nil))
+	assert.NoError(t, verifier.VerifyBlock(txBlock))
+	assert.NoError(t, verifier.VerifyBlock(configBlock))
+	assert.Nil(t, verifier.signaturesVerifiedFromBlock)
+	assert.NoError(t, verifier.VerifyBlock(lastBlock))
+	assert.Equal(t, uint64Ptr(3), verifier.signaturesVerifiedFromBlock)
+
+	verifier = &signatureVerifier{channel: "channel1"}
+	assert.NoError(t, verifier.Init(chain.blocks[0]))
+	assert.NoError(t, verifier.VerifyBlock(txBlock))
+	assert.Equal(t, uint64Ptr(1), verifier.signaturesVerifiedFromBlock)
+
+	// the last config index in the block metadata does not match the last config block
+	verifier = &signatureVerifier{channel: "channel1"}
+	assert.NoError(t, verifier.VerifyBlock(chain.blocks[0]))
+	block := proto.Clone(txBlock).(*common.Block)
+	block.Metadata.Metadata[common.BlockMetadataIndex_LAST_CONFIG] = utils.MarshalOrPanic(&common.Metadata{
+		Value: utils.MarshalOrPanic(&common.LastConfig{Index: 2}),
+	})
+	chain.sign(block)
+	assert.EqualError(t, verifier.VerifyBlock(block),
+		"last config index [2] in the block metadata does not match the last config block [0]")
+	assert.NoError(t, verifier.VerifyBlock(txBlock))
+
+	assert.EqualError(t, verifier.VerifyBlock(&common.Block{Header: &common.BlockHeader{Number: 0}, Data: &common.BlockData{}}),
+		"genesis block does not contain a config transaction")
+}
@@ -80,11 +80,16 @@ func (d *defaultACLProviderImpl) initialize() {
 	d.cResourcePolicyMap[resources.Qscc_GetBlockByHash] = CHANNELREADERS
 	d.cResourcePolicyMap[resources.Qscc_GetTransactionByID] = CHANNELREADERS
 	d.cResourcePolicyMap[resources.Qscc_GetBlockByTxID] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Qscc_GetTransactionsByCreatorMSPID] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Qscc_GetTransactionsByChaincodeName] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Qscc_GetTransactionsByTimeRange] = CHANNELREADERS
 
 	//--------------- CSCC resources -----------
 	//p resources (implemented by the chaincode currently)
 	d.pResourcePolicyMap[resources.Cscc_JoinChain] = mgmt.Admins
 	d.pResourcePolicyMap[resources.Cscc_GetChannels] = mgmt.Members
+	d.pResourcePolicyMap[resources.Cscc_PurgePrivateData] = mgmt.Admins
+	d.pResourcePolicyMap[resources.Cscc_GenerateSnapshot] = mgmt.Admins
 
 	//c resources
 	d.cResourcePolicyMap[resources.Cscc_GetConfigBlock] = CHANNELREADERS
@@ -98,6 +103,7 @@ func (d *defaultACLProviderImpl) initialize() {
 	d.cResourcePolicyMap[resources.Token_Issue] = CHANNELWRITERS
 	d.cResourcePolicyMap[resources.Token_Transfer] = CHANNELWRITERS
 	d.cResourcePolicyMap[resources.Token_List] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Token_Audit] = CHANNELREADERS
 
 	//Event resources
 	d.cResourcePolicyMap[resources.Event_Block] = CHANNELREADERS
@@ -29,12 +29,18 @@ const (
 	Qscc_GetTransactionByID = "qscc/GetTransactionByID"
 	Qscc_GetBlockByTxID     = "qscc/GetBlockByTxID"
 
+	Qscc_GetTransactionsByCreatorMSPID  = "qscc/GetTransactionsByCreatorMSPID"
+	Qscc_GetTransactionsByChaincodeName = "qscc/GetTransactionsByChaincodeName"
+	Qscc_GetTransactionsByTimeRange     = "qscc/GetTransactionsByTimeRange"
+
 	//Cscc resources
 	Cscc_JoinChain                = "cscc/JoinChain"
 	Cscc_GetConfigBlock           = "cscc/GetConfigBlock"
 	Cscc_GetChannels              = "cscc/GetChannels"
 	Cscc_GetConfigTree            = "cscc/GetConfigTree"
 	Cscc_SimulateConfigTreeUpdate = "cscc/SimulateConfigTreeUpdate"
+	Cscc_PurgePrivateData         = "cscc/PurgePrivateData"
+	Cscc_GenerateSnapshot         = "cscc/GenerateSnapshot"
 
 	//Peer resources
 	Peer_Propose              = "peer/Propose"
@@ -48,4 +54,5 @@ const (
 	Token_Issue    = "token/Issue"
 	Token_Transfer = "token/Transfer"
 	Token_List     = "token/List"
+	Token_Audit    = "token/Audit"
 )
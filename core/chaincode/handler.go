@@ -932,15 +932,37 @@ func (h *Handler) HandleGetHistoryForKey(msg *pb.ChaincodeMessage, txContext *Tr
 		return nil, errors.Wrap(err, "unmarshal failed")
 	}
 
-	historyIter, err := txContext.HistoryQueryExecutor.GetHistoryForKey(chaincodeName, getHistoryForKey.Key)
+	metadata, err := getQueryMetadataFromBytes(getHistoryForKey.Metadata)
 	if err != nil {
-		return nil, errors.WithStack(err)
+		return nil, err
 	}
 
-	totalReturnLimit := calculateTotalReturnLimit(nil)
+	totalReturnLimit := calculateTotalReturnLimit(metadata)
+	isPaginated := false
+
+	var historyIter commonledger.ResultsIterator
+	if getHistoryForKey.Options == nil && !isMetadataSetForPagination(metadata) {
+		historyIter, err = txContext.HistoryQueryExecutor.GetHistoryForKey(chaincodeName, getHistoryForKey.Key)
+	} else {
+		queryInfo := createHistoryQueryInfoFromOptions(getHistoryForKey.Options)
+		if isMetadataSetForPagination(metadata) {
+			paginationInfo, err := createPaginationInfoFromMetadata(metadata, totalReturnLimit, pb.ChaincodeMessage_GET_HISTORY_FOR_KEY)
+			if err != nil {
+				return nil, err
+			}
+			for k, v := range paginationInfo {
+				queryInfo[k] = v
+			}
+			isPaginated = true
+		}
+		historyIter, err = txContext.HistoryQueryExecutor.GetHistoryForKeyWithMetadata(chaincodeName, getHistoryForKey.Key, queryInfo)
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 
 	txContext.InitializeQueryContext(iterID, historyIter)
-	payload, err := h.QueryResponseBuilder.BuildQueryResponse(txContext, historyIter, iterID, false, totalReturnLimit)
+	payload, err := h.QueryResponseBuilder.BuildQueryResponse(txContext, historyIter, iterID, isPaginated, totalReturnLimit)
 	if err != nil {
 		txContext.CleanupQueryContext(iterID)
 		return nil, errors.WithStack(err)
@@ -988,18 +1010,43 @@ func createPaginationInfoFromMetadata(metadata *pb.QueryMetadata, totalReturnLim
 	paginationInfoMap := make(map[string]interface{})
 
 	switch queryType {
-	case pb.ChaincodeMessage_GET_QUERY_RESULT:
+	case pb.ChaincodeMessage_GET_QUERY_RESULT, pb.ChaincodeMessage_GET_HISTORY_FOR_KEY:
 		paginationInfoMap["bookmark"] = metadata.Bookmark
 	case pb.ChaincodeMessage_GET_STATE_BY_RANGE:
 		// this is a no-op for range query
 	default:
-		return nil, errors.New("query type must be either GetQueryResult or GetStateByRange")
+		return nil, errors.New("query type must be one of GetQueryResult, GetStateByRange, or GetHistoryForKey")
 	}
 
 	paginationInfoMap["limit"] = totalReturnLimit
 	return paginationInfoMap, nil
 }
 
+// createHistoryQueryInfoFromOptions translates the options of a history query into the metadata that is
+// passed to the history query executor
+func createHistoryQueryInfoFromOptions(options *pb.HistoryQueryOptions) map[string]interface{} {
+	queryInfo := make(map[string]interface{})
+	if options == nil {
+		return queryInfo
+	}
+	if options.StartBlock > 0 {
+		queryInfo["startBlock"] = options.StartBlock
+	}
+	if options.EndBlock > 0 {
+		queryInfo["endBlock"] = options.EndBlock
+	}
+	if options.StartTime != nil {
+		queryInfo["startTime"] = options.StartTime
+	}
+	if options.EndTime != nil {
+		queryInfo["endTime"] = options.EndTime
+	}
+	if options.Descending {
+		queryInfo["descending"] = true
+	}
+	return queryInfo
+}
+
 func calculateTotalReturnLimit(metadata *pb.QueryMetadata) int32 {
 	totalReturnLimit := int32(ledgerconfig.GetTotalQueryLimit())
 	if metadata != nil {
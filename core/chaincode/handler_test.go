@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
 	"github.com/hyperledger/fabric/common/mocks/config"
 	"github.com/hyperledger/fabric/common/util"
@@ -2066,6 +2067,109 @@ var _ = Describe("Handler", func() {
 			Expect(iterID).To(Equal("generated-query-id"))
 		})
 
+		Context("when the query options are specified", func() {
+			BeforeEach(func() {
+				request.Options = &pb.HistoryQueryOptions{
+					StartBlock: 5,
+					EndBlock:   10,
+					StartTime:  &timestamp.Timestamp{Seconds: 100},
+					Descending: true,
+				}
+				payload, err := proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+
+				fakeHistoryQueryExecutor.GetHistoryForKeyWithMetadataReturns(fakeIterator, nil)
+			})
+
+			It("calls GetHistoryForKeyWithMetadata on the history query executor", func() {
+				_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeHistoryQueryExecutor.GetHistoryForKeyCallCount()).To(Equal(0))
+				Expect(fakeHistoryQueryExecutor.GetHistoryForKeyWithMetadataCallCount()).To(Equal(1))
+				ccname, key, metadata := fakeHistoryQueryExecutor.GetHistoryForKeyWithMetadataArgsForCall(0)
+				Expect(ccname).To(Equal("cc-instance-name"))
+				Expect(key).To(Equal("history-key"))
+				Expect(metadata).To(Equal(map[string]interface{}{
+					"startBlock": uint64(5),
+					"endBlock":   uint64(10),
+					"startTime":  &timestamp.Timestamp{Seconds: 100},
+					"descending": true,
+				}))
+			})
+
+			It("builds a query response that is not paginated", func() {
+				_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeQueryResponseBuilder.BuildQueryResponseCallCount()).To(Equal(1))
+				_, iter, _, isPaginated, _ := fakeQueryResponseBuilder.BuildQueryResponseArgsForCall(0)
+				Expect(iter).To(Equal(fakeIterator))
+				Expect(isPaginated).To(BeFalse())
+			})
+
+			Context("when the history query executor fails", func() {
+				BeforeEach(func() {
+					fakeHistoryQueryExecutor.GetHistoryForKeyWithMetadataReturns(nil, errors.New("anchovies"))
+				})
+
+				It("returns an error", func() {
+					_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+					Expect(err).To(MatchError("anchovies"))
+				})
+			})
+		})
+
+		Context("when the pagination metadata is specified", func() {
+			BeforeEach(func() {
+				metadata, err := proto.Marshal(&pb.QueryMetadata{PageSize: 20, Bookmark: "page-bookmark"})
+				Expect(err).NotTo(HaveOccurred())
+				request.Metadata = metadata
+				payload, err := proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+
+				fakeHistoryQueryExecutor.GetHistoryForKeyWithMetadataReturns(fakeIterator, nil)
+			})
+
+			It("calls GetHistoryForKeyWithMetadata with the pagination info", func() {
+				_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeHistoryQueryExecutor.GetHistoryForKeyWithMetadataCallCount()).To(Equal(1))
+				_, _, metadata := fakeHistoryQueryExecutor.GetHistoryForKeyWithMetadataArgsForCall(0)
+				Expect(metadata).To(Equal(map[string]interface{}{
+					"limit":    int32(20),
+					"bookmark": "page-bookmark",
+				}))
+			})
+
+			It("builds a paginated query response", func() {
+				_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeQueryResponseBuilder.BuildQueryResponseCallCount()).To(Equal(1))
+				_, _, _, isPaginated, totalReturnLimit := fakeQueryResponseBuilder.BuildQueryResponseArgsForCall(0)
+				Expect(isPaginated).To(BeTrue())
+				Expect(totalReturnLimit).To(Equal(int32(20)))
+			})
+		})
+
+		Context("when the metadata cannot be unmarshaled", func() {
+			BeforeEach(func() {
+				request.Metadata = []byte("bogus-metadata")
+				payload, err := proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+				Expect(err).To(MatchError(ContainSubstring("unmarshal failed")))
+			})
+		})
+
 		Context("when unmarshalling the request fails", func() {
 			BeforeEach(func() {
 				incomingMessage.Payload = []byte("this-is-a-bogus-payload")
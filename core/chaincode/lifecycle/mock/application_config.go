@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/protos/peer"
 )
 
 type ApplicationConfig struct {
@@ -35,6 +36,15 @@ type ApplicationConfig struct {
 	capabilitiesReturnsOnCall map[int]struct {
 		result1 channelconfig.ApplicationCapabilities
 	}
+	TokenTypesStub        func() map[string]*peer.TokenType
+	tokenTypesMutex       sync.RWMutex
+	tokenTypesArgsForCall []struct{}
+	tokenTypesReturns     struct {
+		result1 map[string]*peer.TokenType
+	}
+	tokenTypesReturnsOnCall map[int]struct {
+		result1 map[string]*peer.TokenType
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -159,6 +169,46 @@ func (fake *ApplicationConfig) CapabilitiesReturnsOnCall(i int, result1 channelc
 	}{result1}
 }
 
+func (fake *ApplicationConfig) TokenTypes() map[string]*peer.TokenType {
+	fake.tokenTypesMutex.Lock()
+	ret, specificReturn := fake.tokenTypesReturnsOnCall[len(fake.tokenTypesArgsForCall)]
+	fake.tokenTypesArgsForCall = append(fake.tokenTypesArgsForCall, struct{}{})
+	fake.recordInvocation("TokenTypes", []interface{}{})
+	fake.tokenTypesMutex.Unlock()
+	if fake.TokenTypesStub != nil {
+		return fake.TokenTypesStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.tokenTypesReturns.result1
+}
+
+func (fake *ApplicationConfig) TokenTypesCallCount() int {
+	fake.tokenTypesMutex.RLock()
+	defer fake.tokenTypesMutex.RUnlock()
+	return len(fake.tokenTypesArgsForCall)
+}
+
+func (fake *ApplicationConfig) TokenTypesReturns(result1 map[string]*peer.TokenType) {
+	fake.TokenTypesStub = nil
+	fake.tokenTypesReturns = struct {
+		result1 map[string]*peer.TokenType
+	}{result1}
+}
+
+func (fake *ApplicationConfig) TokenTypesReturnsOnCall(i int, result1 map[string]*peer.TokenType) {
+	fake.TokenTypesStub = nil
+	if fake.tokenTypesReturnsOnCall == nil {
+		fake.tokenTypesReturnsOnCall = make(map[int]struct {
+			result1 map[string]*peer.TokenType
+		})
+	}
+	fake.tokenTypesReturnsOnCall[i] = struct {
+		result1 map[string]*peer.TokenType
+	}{result1}
+}
+
 func (fake *ApplicationConfig) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -168,6 +218,8 @@ func (fake *ApplicationConfig) Invocations() map[string][][]interface{} {
 	defer fake.aPIPolicyMapperMutex.RUnlock()
 	fake.capabilitiesMutex.RLock()
 	defer fake.capabilitiesMutex.RUnlock()
+	fake.tokenTypesMutex.RLock()
+	defer fake.tokenTypesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -278,6 +278,38 @@ type ChaincodeStub struct {
 		result1 shim.HistoryQueryIteratorInterface
 		result2 error
 	}
+	GetHistoryForKeyWithOptionsStub        func(key string, options *pb.HistoryQueryOptions) (shim.HistoryQueryIteratorInterface, error)
+	getHistoryForKeyWithOptionsMutex       sync.RWMutex
+	getHistoryForKeyWithOptionsArgsForCall []struct {
+		key     string
+		options *pb.HistoryQueryOptions
+	}
+	getHistoryForKeyWithOptionsReturns struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 error
+	}
+	getHistoryForKeyWithOptionsReturnsOnCall map[int]struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 error
+	}
+	GetHistoryForKeyWithPaginationStub        func(key string, options *pb.HistoryQueryOptions, pageSize int32, bookmark string) (shim.HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error)
+	getHistoryForKeyWithPaginationMutex       sync.RWMutex
+	getHistoryForKeyWithPaginationArgsForCall []struct {
+		key      string
+		options  *pb.HistoryQueryOptions
+		pageSize int32
+		bookmark string
+	}
+	getHistoryForKeyWithPaginationReturns struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 *pb.QueryResponseMetadata
+		result3 error
+	}
+	getHistoryForKeyWithPaginationReturnsOnCall map[int]struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 *pb.QueryResponseMetadata
+		result3 error
+	}
 	GetPrivateDataStub        func(collection, key string) ([]byte, error)
 	getPrivateDataMutex       sync.RWMutex
 	getPrivateDataArgsForCall []struct {
@@ -1538,6 +1570,115 @@ func (fake *ChaincodeStub) GetHistoryForKeyReturnsOnCall(i int, result1 shim.His
 	}{result1, result2}
 }
 
+func (fake *ChaincodeStub) GetHistoryForKeyWithOptions(key string, options *pb.HistoryQueryOptions) (shim.HistoryQueryIteratorInterface, error) {
+	fake.getHistoryForKeyWithOptionsMutex.Lock()
+	ret, specificReturn := fake.getHistoryForKeyWithOptionsReturnsOnCall[len(fake.getHistoryForKeyWithOptionsArgsForCall)]
+	fake.getHistoryForKeyWithOptionsArgsForCall = append(fake.getHistoryForKeyWithOptionsArgsForCall, struct {
+		key     string
+		options *pb.HistoryQueryOptions
+	}{key, options})
+	fake.recordInvocation("GetHistoryForKeyWithOptions", []interface{}{key, options})
+	fake.getHistoryForKeyWithOptionsMutex.Unlock()
+	if fake.GetHistoryForKeyWithOptionsStub != nil {
+		return fake.GetHistoryForKeyWithOptionsStub(key, options)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getHistoryForKeyWithOptionsReturns.result1, fake.getHistoryForKeyWithOptionsReturns.result2
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyWithOptionsCallCount() int {
+	fake.getHistoryForKeyWithOptionsMutex.RLock()
+	defer fake.getHistoryForKeyWithOptionsMutex.RUnlock()
+	return len(fake.getHistoryForKeyWithOptionsArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyWithOptionsArgsForCall(i int) (string, *pb.HistoryQueryOptions) {
+	fake.getHistoryForKeyWithOptionsMutex.RLock()
+	defer fake.getHistoryForKeyWithOptionsMutex.RUnlock()
+	return fake.getHistoryForKeyWithOptionsArgsForCall[i].key, fake.getHistoryForKeyWithOptionsArgsForCall[i].options
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyWithOptionsReturns(result1 shim.HistoryQueryIteratorInterface, result2 error) {
+	fake.GetHistoryForKeyWithOptionsStub = nil
+	fake.getHistoryForKeyWithOptionsReturns = struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyWithOptionsReturnsOnCall(i int, result1 shim.HistoryQueryIteratorInterface, result2 error) {
+	fake.GetHistoryForKeyWithOptionsStub = nil
+	if fake.getHistoryForKeyWithOptionsReturnsOnCall == nil {
+		fake.getHistoryForKeyWithOptionsReturnsOnCall = make(map[int]struct {
+			result1 shim.HistoryQueryIteratorInterface
+			result2 error
+		})
+	}
+	fake.getHistoryForKeyWithOptionsReturnsOnCall[i] = struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyWithPagination(key string, options *pb.HistoryQueryOptions, pageSize int32, bookmark string) (shim.HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	fake.getHistoryForKeyWithPaginationMutex.Lock()
+	ret, specificReturn := fake.getHistoryForKeyWithPaginationReturnsOnCall[len(fake.getHistoryForKeyWithPaginationArgsForCall)]
+	fake.getHistoryForKeyWithPaginationArgsForCall = append(fake.getHistoryForKeyWithPaginationArgsForCall, struct {
+		key      string
+		options  *pb.HistoryQueryOptions
+		pageSize int32
+		bookmark string
+	}{key, options, pageSize, bookmark})
+	fake.recordInvocation("GetHistoryForKeyWithPagination", []interface{}{key, options, pageSize, bookmark})
+	fake.getHistoryForKeyWithPaginationMutex.Unlock()
+	if fake.GetHistoryForKeyWithPaginationStub != nil {
+		return fake.GetHistoryForKeyWithPaginationStub(key, options, pageSize, bookmark)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.getHistoryForKeyWithPaginationReturns.result1, fake.getHistoryForKeyWithPaginationReturns.result2, fake.getHistoryForKeyWithPaginationReturns.result3
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyWithPaginationCallCount() int {
+	fake.getHistoryForKeyWithPaginationMutex.RLock()
+	defer fake.getHistoryForKeyWithPaginationMutex.RUnlock()
+	return len(fake.getHistoryForKeyWithPaginationArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyWithPaginationArgsForCall(i int) (string, *pb.HistoryQueryOptions, int32, string) {
+	fake.getHistoryForKeyWithPaginationMutex.RLock()
+	defer fake.getHistoryForKeyWithPaginationMutex.RUnlock()
+	return fake.getHistoryForKeyWithPaginationArgsForCall[i].key, fake.getHistoryForKeyWithPaginationArgsForCall[i].options, fake.getHistoryForKeyWithPaginationArgsForCall[i].pageSize, fake.getHistoryForKeyWithPaginationArgsForCall[i].bookmark
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyWithPaginationReturns(result1 shim.HistoryQueryIteratorInterface, result2 *pb.QueryResponseMetadata, result3 error) {
+	fake.GetHistoryForKeyWithPaginationStub = nil
+	fake.getHistoryForKeyWithPaginationReturns = struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 *pb.QueryResponseMetadata
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyWithPaginationReturnsOnCall(i int, result1 shim.HistoryQueryIteratorInterface, result2 *pb.QueryResponseMetadata, result3 error) {
+	fake.GetHistoryForKeyWithPaginationStub = nil
+	if fake.getHistoryForKeyWithPaginationReturnsOnCall == nil {
+		fake.getHistoryForKeyWithPaginationReturnsOnCall = make(map[int]struct {
+			result1 shim.HistoryQueryIteratorInterface
+			result2 *pb.QueryResponseMetadata
+			result3 error
+		})
+	}
+	fake.getHistoryForKeyWithPaginationReturnsOnCall[i] = struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 *pb.QueryResponseMetadata
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *ChaincodeStub) GetPrivateData(collection string, key string) ([]byte, error) {
 	fake.getPrivateDataMutex.Lock()
 	ret, specificReturn := fake.getPrivateDataReturnsOnCall[len(fake.getPrivateDataArgsForCall)]
@@ -2370,6 +2511,10 @@ func (fake *ChaincodeStub) Invocations() map[string][][]interface{} {
 	defer fake.getQueryResultWithPaginationMutex.RUnlock()
 	fake.getHistoryForKeyMutex.RLock()
 	defer fake.getHistoryForKeyMutex.RUnlock()
+	fake.getHistoryForKeyWithOptionsMutex.RLock()
+	defer fake.getHistoryForKeyWithOptionsMutex.RUnlock()
+	fake.getHistoryForKeyWithPaginationMutex.RLock()
+	defer fake.getHistoryForKeyWithPaginationMutex.RUnlock()
 	fake.getPrivateDataMutex.RLock()
 	defer fake.getPrivateDataMutex.RUnlock()
 	fake.getPrivateDataHashMutex.RLock()
@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/core/ledger"
 )
 
 type HistoryQueryExecutor struct {
@@ -22,6 +23,21 @@ type HistoryQueryExecutor struct {
 		result1 commonledger.ResultsIterator
 		result2 error
 	}
+	GetHistoryForKeyWithMetadataStub        func(namespace string, key string, metadata map[string]interface{}) (ledger.QueryResultsIterator, error)
+	getHistoryForKeyWithMetadataMutex       sync.RWMutex
+	getHistoryForKeyWithMetadataArgsForCall []struct {
+		namespace string
+		key       string
+		metadata  map[string]interface{}
+	}
+	getHistoryForKeyWithMetadataReturns struct {
+		result1 ledger.QueryResultsIterator
+		result2 error
+	}
+	getHistoryForKeyWithMetadataReturnsOnCall map[int]struct {
+		result1 ledger.QueryResultsIterator
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -78,11 +94,66 @@ func (fake *HistoryQueryExecutor) GetHistoryForKeyReturnsOnCall(i int, result1 c
 	}{result1, result2}
 }
 
+func (fake *HistoryQueryExecutor) GetHistoryForKeyWithMetadata(namespace string, key string, metadata map[string]interface{}) (ledger.QueryResultsIterator, error) {
+	fake.getHistoryForKeyWithMetadataMutex.Lock()
+	ret, specificReturn := fake.getHistoryForKeyWithMetadataReturnsOnCall[len(fake.getHistoryForKeyWithMetadataArgsForCall)]
+	fake.getHistoryForKeyWithMetadataArgsForCall = append(fake.getHistoryForKeyWithMetadataArgsForCall, struct {
+		namespace string
+		key       string
+		metadata  map[string]interface{}
+	}{namespace, key, metadata})
+	fake.recordInvocation("GetHistoryForKeyWithMetadata", []interface{}{namespace, key, metadata})
+	fake.getHistoryForKeyWithMetadataMutex.Unlock()
+	if fake.GetHistoryForKeyWithMetadataStub != nil {
+		return fake.GetHistoryForKeyWithMetadataStub(namespace, key, metadata)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getHistoryForKeyWithMetadataReturns.result1, fake.getHistoryForKeyWithMetadataReturns.result2
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForKeyWithMetadataCallCount() int {
+	fake.getHistoryForKeyWithMetadataMutex.RLock()
+	defer fake.getHistoryForKeyWithMetadataMutex.RUnlock()
+	return len(fake.getHistoryForKeyWithMetadataArgsForCall)
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForKeyWithMetadataArgsForCall(i int) (string, string, map[string]interface{}) {
+	fake.getHistoryForKeyWithMetadataMutex.RLock()
+	defer fake.getHistoryForKeyWithMetadataMutex.RUnlock()
+	return fake.getHistoryForKeyWithMetadataArgsForCall[i].namespace, fake.getHistoryForKeyWithMetadataArgsForCall[i].key, fake.getHistoryForKeyWithMetadataArgsForCall[i].metadata
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForKeyWithMetadataReturns(result1 ledger.QueryResultsIterator, result2 error) {
+	fake.GetHistoryForKeyWithMetadataStub = nil
+	fake.getHistoryForKeyWithMetadataReturns = struct {
+		result1 ledger.QueryResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForKeyWithMetadataReturnsOnCall(i int, result1 ledger.QueryResultsIterator, result2 error) {
+	fake.GetHistoryForKeyWithMetadataStub = nil
+	if fake.getHistoryForKeyWithMetadataReturnsOnCall == nil {
+		fake.getHistoryForKeyWithMetadataReturnsOnCall = make(map[int]struct {
+			result1 ledger.QueryResultsIterator
+			result2 error
+		})
+	}
+	fake.getHistoryForKeyWithMetadataReturnsOnCall[i] = struct {
+		result1 ledger.QueryResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *HistoryQueryExecutor) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.getHistoryForKeyMutex.RLock()
 	defer fake.getHistoryForKeyMutex.RUnlock()
+	fake.getHistoryForKeyWithMetadataMutex.RLock()
+	defer fake.getHistoryForKeyWithMetadataMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
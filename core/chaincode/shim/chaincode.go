@@ -707,11 +707,44 @@ func (stub *ChaincodeStub) GetStateByRange(startKey, endKey string) (StateQueryI
 
 // GetHistoryForKey documentation can be found in interfaces.go
 func (stub *ChaincodeStub) GetHistoryForKey(key string) (HistoryQueryIteratorInterface, error) {
-	response, err := stub.handler.handleGetHistoryForKey(key, stub.ChannelId, stub.TxID)
+	// ignore QueryResponseMetadata as it is not applicable for a history query without pagination
+	iterator, _, err := stub.handleGetHistoryForKey(key, nil, nil)
+	return iterator, err
+}
+
+// GetHistoryForKeyWithOptions documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetHistoryForKeyWithOptions(key string, options *pb.HistoryQueryOptions) (HistoryQueryIteratorInterface, error) {
+	// ignore QueryResponseMetadata as it is not applicable for a history query without pagination
+	iterator, _, err := stub.handleGetHistoryForKey(key, options, nil)
+	return iterator, err
+}
+
+// GetHistoryForKeyWithPagination documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetHistoryForKeyWithPagination(key string, options *pb.HistoryQueryOptions, pageSize int32,
+	bookmark string) (HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+
+	metadata, err := createQueryMetadata(pageSize, bookmark)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return &HistoryQueryIterator{CommonIterator: &CommonIterator{stub.handler, stub.ChannelId, stub.TxID, response, 0}}, nil
+	return stub.handleGetHistoryForKey(key, options, metadata)
+}
+
+func (stub *ChaincodeStub) handleGetHistoryForKey(key string, options *pb.HistoryQueryOptions,
+	metadata []byte) (HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+
+	response, err := stub.handler.handleGetHistoryForKey(key, options, metadata, stub.ChannelId, stub.TxID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	iterator := &HistoryQueryIterator{CommonIterator: &CommonIterator{stub.handler, stub.ChannelId, stub.TxID, response, 0}}
+	responseMetadata, err := createQueryResponseMetadata(response.Metadata)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return iterator, responseMetadata, nil
 }
 
 //CreateCompositeKey documentation can be found in interfaces.go
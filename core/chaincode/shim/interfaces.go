@@ -232,6 +232,30 @@ type ChaincodeStubInterface interface {
 	// update ledger, and should limit use to read-only chaincode operations.
 	GetHistoryForKey(key string) (HistoryQueryIteratorInterface, error)
 
+	// GetHistoryForKeyWithOptions returns a history of key values across time
+	// as restricted by the options. The options bound the history by the block
+	// height ([StartBlock, EndBlock)) and/or by the transaction timestamp
+	// ([StartTime, EndTime)), and may request the most recent modification to
+	// be returned first. A nil options returns the full history, as does
+	// GetHistoryForKey. Note that a timestamp bound filters the history records
+	// but does not reduce the range of the history that is scanned on the peer.
+	// The same restrictions as for GetHistoryForKey apply.
+	GetHistoryForKeyWithOptions(key string, options *pb.HistoryQueryOptions) (HistoryQueryIteratorInterface, error)
+
+	// GetHistoryForKeyWithPagination returns a history of key values across time
+	// as restricted by the options, in the same way as GetHistoryForKeyWithOptions.
+	// When an empty string is passed as a value to the bookmark argument, the
+	// returned iterator can be used to fetch the first `pageSize` history records.
+	// When the bookmark is a non-empty string, the iterator can be used to fetch
+	// the next `pageSize` history records after the record that the bookmark refers to.
+	// Note that only the bookmark present in a prior page of query results
+	// (ResponseMetadata) with the same options can be used as a value to the
+	// bookmark argument. An empty bookmark in the ResponseMetadata indicates that
+	// there are no more history records.
+	// The same restrictions as for GetHistoryForKey apply.
+	GetHistoryForKeyWithPagination(key string, options *pb.HistoryQueryOptions, pageSize int32,
+		bookmark string) (HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error)
+
 	// GetPrivateData returns the value of the specified `key` from the specified
 	// `collection`. Note that GetPrivateData doesn't read data from the
 	// private writeset, which has not been committed to the `collection`. In
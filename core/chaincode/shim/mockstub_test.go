@@ -314,6 +314,8 @@ func TestMockMock(t *testing.T) {
 	stub.GetArgsSlice()
 	stub.SetEvent("e", nil)
 	stub.GetHistoryForKey("k")
+	stub.GetHistoryForKeyWithOptions("k", nil)
+	stub.GetHistoryForKeyWithPagination("k", nil, 10, "")
 	iter := &MockStateRangeQueryIterator{}
 	iter.HasNext()
 	iter.Close()
@@ -649,6 +649,11 @@ func (ds *dynamicCapabilities) FabToken() bool {
 	return ds.cr.Capabilities().FabToken()
 }
 
+// ConfidentialFabToken returns true if fabric tokens are managed by the confidential TMS.
+func (ds *dynamicCapabilities) ConfidentialFabToken() bool {
+	return ds.cr.Capabilities().ConfidentialFabToken()
+}
+
 func (ds *dynamicCapabilities) ForbidDuplicateTXIdInBlock() bool {
 	return ds.cr.Capabilities().ForbidDuplicateTXIdInBlock()
 }
@@ -37,6 +37,20 @@ func (_m *Capabilities) CollectionUpgrade() bool {
 	return r0
 }
 
+// ConfidentialFabToken provides a mock function with given fields:
+func (_m *Capabilities) ConfidentialFabToken() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // FabToken provides a mock function with given fields:
 func (_m *Capabilities) FabToken() bool {
 	ret := _m.Called()
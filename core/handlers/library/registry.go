@@ -17,6 +17,7 @@ import (
 	"github.com/hyperledger/fabric/core/handlers/auth"
 	"github.com/hyperledger/fabric/core/handlers/decoration"
 	endorsement2 "github.com/hyperledger/fabric/core/handlers/endorsement/api"
+	"github.com/hyperledger/fabric/core/handlers/statedatabase/api"
 	"github.com/hyperledger/fabric/core/handlers/validation/api"
 )
 
@@ -42,6 +43,8 @@ const (
 	Decoration
 	Endorsement
 	Validation
+	// StateDatabase handler - provide the state databases of the ledgers
+	StateDatabase
 
 	authPluginFactory      = "NewFilter"
 	decoratorPluginFactory = "NewDecorator"
@@ -49,10 +52,11 @@ const (
 )
 
 type registry struct {
-	filters    []auth.Filter
-	decorators []decoration.Decorator
-	endorsers  map[string]endorsement2.PluginFactory
-	validators map[string]validation.PluginFactory
+	filters        []auth.Filter
+	decorators     []decoration.Decorator
+	endorsers      map[string]endorsement2.PluginFactory
+	validators     map[string]validation.PluginFactory
+	stateDatabases map[string]statedatabase.PluginFactory
 }
 
 var once sync.Once
@@ -61,10 +65,11 @@ var reg registry
 // Config configures the factory methods
 // and plugins for the registry
 type Config struct {
-	AuthFilters []*HandlerConfig `mapstructure:"authFilters" yaml:"authFilters"`
-	Decorators  []*HandlerConfig `mapstructure:"decorators" yaml:"decorators"`
-	Endorsers   PluginMapping    `mapstructure:"endorsers" yaml:"endorsers"`
-	Validators  PluginMapping    `mapstructure:"validators" yaml:"validators"`
+	AuthFilters    []*HandlerConfig `mapstructure:"authFilters" yaml:"authFilters"`
+	Decorators     []*HandlerConfig `mapstructure:"decorators" yaml:"decorators"`
+	Endorsers      PluginMapping    `mapstructure:"endorsers" yaml:"endorsers"`
+	Validators     PluginMapping    `mapstructure:"validators" yaml:"validators"`
+	StateDatabases PluginMapping    `mapstructure:"stateDatabases" yaml:"stateDatabases"`
 }
 
 type PluginMapping map[string]*HandlerConfig
@@ -80,8 +85,9 @@ type HandlerConfig struct {
 func InitRegistry(c Config) Registry {
 	once.Do(func() {
 		reg = registry{
-			endorsers:  make(map[string]endorsement2.PluginFactory),
-			validators: make(map[string]validation.PluginFactory),
+			endorsers:      make(map[string]endorsement2.PluginFactory),
+			validators:     make(map[string]validation.PluginFactory),
+			stateDatabases: make(map[string]statedatabase.PluginFactory),
 		}
 		reg.loadHandlers(c)
 	})
@@ -104,6 +110,10 @@ func (r *registry) loadHandlers(c Config) {
 	for chaincodeID, config := range c.Validators {
 		r.evaluateModeAndLoad(config, Validation, chaincodeID)
 	}
+
+	for stateDatabase, config := range c.StateDatabases {
+		r.evaluateModeAndLoad(config, StateDatabase, stateDatabase)
+	}
 }
 
 // evaluateModeAndLoad if a library path is provided, load the shared object
@@ -140,6 +150,11 @@ func (r *registry) loadCompiled(handlerFactory string, handlerType HandlerType,
 			logger.Panicf("expected 1 argument in extraArgs")
 		}
 		r.validators[extraArgs[0]] = inst.(validation.PluginFactory)
+	} else if handlerType == StateDatabase {
+		if len(extraArgs) != 1 {
+			logger.Panicf("expected 1 argument in extraArgs")
+		}
+		r.stateDatabases[extraArgs[0]] = inst.(statedatabase.PluginFactory)
 	}
 }
 
@@ -161,6 +176,8 @@ func (r *registry) loadPlugin(pluginPath string, handlerType HandlerType, extraA
 		r.initEndorsementPlugin(p, extraArgs...)
 	} else if handlerType == Validation {
 		r.initValidationPlugin(p, extraArgs...)
+	} else if handlerType == StateDatabase {
+		r.initStateDatabasePlugin(p, extraArgs...)
 	}
 }
 
@@ -237,6 +254,26 @@ func (r *registry) initValidationPlugin(p *plugin.Plugin, extraArgs ...string) {
 	r.validators[extraArgs[0]] = factory
 }
 
+func (r *registry) initStateDatabasePlugin(p *plugin.Plugin, extraArgs ...string) {
+	if len(extraArgs) != 1 {
+		logger.Panicf("expected 1 argument in extraArgs")
+	}
+	factorySymbol, err := p.Lookup(pluginFactory)
+	if err != nil {
+		panicWithLookupError(pluginFactory, err)
+	}
+
+	constructor, ok := factorySymbol.(func() statedatabase.PluginFactory)
+	if !ok {
+		panicWithDefinitionError(pluginFactory)
+	}
+	factory := constructor()
+	if factory == nil {
+		logger.Panicf("factory instance returned nil")
+	}
+	r.stateDatabases[extraArgs[0]] = factory
+}
+
 // panicWithLookupError panics when a handler constructor lookup fails
 func panicWithLookupError(factory string, err error) {
 	logger.Panicf(fmt.Sprintf("Plugin must contain constructor with name %s. Error from lookup: %s",
@@ -261,6 +298,8 @@ func (r *registry) Lookup(handlerType HandlerType) interface{} {
 		return r.endorsers
 	} else if handlerType == Validation {
 		return r.validators
+	} else if handlerType == StateDatabase {
+		return r.stateDatabases
 	}
 
 	return nil
@@ -19,16 +19,18 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/core/handlers/endorsement/api"
+	"github.com/hyperledger/fabric/core/handlers/statedatabase/api"
 	"github.com/hyperledger/fabric/core/handlers/validation/api"
 	"github.com/hyperledger/fabric/protos/peer"
 	"github.com/stretchr/testify/assert"
 )
 
 const (
-	authPluginPackage      = "github.com/hyperledger/fabric/core/handlers/auth/plugin"
-	decoratorPluginPackage = "github.com/hyperledger/fabric/core/handlers/decoration/plugin"
-	endorsementTestPlugin  = "github.com/hyperledger/fabric/core/handlers/endorsement/testdata/"
-	validationTestPlugin   = "github.com/hyperledger/fabric/core/handlers/validation/testdata/"
+	authPluginPackage       = "github.com/hyperledger/fabric/core/handlers/auth/plugin"
+	decoratorPluginPackage  = "github.com/hyperledger/fabric/core/handlers/decoration/plugin"
+	endorsementTestPlugin   = "github.com/hyperledger/fabric/core/handlers/endorsement/testdata/"
+	validationTestPlugin    = "github.com/hyperledger/fabric/core/handlers/validation/testdata/"
+	stateDatabaseTestPlugin = "github.com/hyperledger/fabric/core/handlers/statedatabase/testdata/"
 )
 
 // raceEnabled is set to true when the race build tag is enabled.
@@ -124,6 +126,26 @@ func TestValidationPlugin(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestStateDatabasePlugin(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "")
+	assert.NoError(t, err, "Could not create temp directory for plugins")
+	defer os.Remove(testDir)
+
+	pluginPath := filepath.Join(testDir, "statedatabaseplugin.so")
+	buildPlugin(t, pluginPath, stateDatabaseTestPlugin)
+
+	testReg := registry{stateDatabases: make(map[string]statedatabase.PluginFactory)}
+	testReg.loadPlugin(pluginPath, StateDatabase, "noopdb")
+	mapping := testReg.Lookup(StateDatabase).(map[string]statedatabase.PluginFactory)
+	factory := mapping["noopdb"]
+	assert.NotNil(t, factory)
+	provider, err := factory.New(&statedatabase.Initializer{DBPath: testDir})
+	assert.NoError(t, err)
+	assert.NotNil(t, provider)
+	_, err = provider.GetDBHandle("testchannel")
+	assert.EqualError(t, err, "not supported")
+}
+
 func TestLoadPluginInvalidPath(t *testing.T) {
 	defer func() {
 		if r := recover(); r == nil {
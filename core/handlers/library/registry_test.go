@@ -11,6 +11,7 @@ import (
 
 	"github.com/hyperledger/fabric/core/handlers/auth"
 	"github.com/hyperledger/fabric/core/handlers/decoration"
+	"github.com/hyperledger/fabric/core/handlers/statedatabase/api"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -31,6 +32,12 @@ func TestInitRegistry(t *testing.T) {
 	decorators, isDecorators := decorationHandlers.([]decoration.Decorator)
 	assert.True(t, isDecorators)
 	assert.Len(t, decorators, 1)
+
+	stateDatabaseHandlers := r.Lookup(StateDatabase)
+	assert.NotNil(t, stateDatabaseHandlers)
+	stateDatabases, isStateDatabases := stateDatabaseHandlers.(map[string]statedatabase.PluginFactory)
+	assert.True(t, isStateDatabases)
+	assert.Empty(t, stateDatabases)
 }
 
 func TestLoadCompiledInvalid(t *testing.T) {
@@ -0,0 +1,30 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statedatabase
+
+import (
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+)
+
+// Initializer contains the dependencies that are passed to a PluginFactory
+type Initializer struct {
+	// DBPath is a directory on the file system of the peer that is reserved for the state database.
+	// A state database that does not keep its data locally can ignore it
+	DBPath string
+	// MetricsProvider is the provider of the metrics of the peer
+	MetricsProvider metrics.Provider
+}
+
+// PluginFactory creates the provider of the state databases of the ledgers, which is used instead of
+// the built-in goleveldb or CouchDB state database. Besides the interface statedb.VersionedDB, the state
+// databases may implement the interface statedb.BulkOptimizable for loading the committed versions of the
+// keys of a block in bulk, and the interface statedb.IndexCapable for creating the indexes that are packaged
+// with the chaincodes
+type PluginFactory interface {
+	New(initializer *Initializer) (statedb.VersionedDBProvider, error)
+}
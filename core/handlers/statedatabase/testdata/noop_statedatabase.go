@@ -0,0 +1,36 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"errors"
+
+	"github.com/hyperledger/fabric/core/handlers/statedatabase/api"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+)
+
+type NoOpVersionedDBProvider struct {
+}
+
+func (*NoOpVersionedDBProvider) GetDBHandle(id string) (statedb.VersionedDB, error) {
+	return nil, errors.New("not supported")
+}
+
+func (*NoOpVersionedDBProvider) Close() {
+}
+
+type NoOpStateDatabaseFactory struct {
+}
+
+func (*NoOpStateDatabaseFactory) New(initializer *statedatabase.Initializer) (statedb.VersionedDBProvider, error) {
+	return &NoOpVersionedDBProvider{}, nil
+}
+
+// NewPluginFactory is the function ran by the plugin infrastructure to create a state database plugin factory.
+func NewPluginFactory() statedatabase.PluginFactory {
+	return &NoOpStateDatabaseFactory{}
+}
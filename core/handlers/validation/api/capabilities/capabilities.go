@@ -60,4 +60,7 @@ type Capabilities interface {
 
 	// FabToken returns true if fabric token function is supported.
 	FabToken() bool
+
+	// ConfidentialFabToken returns true if the tokens are managed by the confidential TMS.
+	ConfidentialFabToken() bool
 }
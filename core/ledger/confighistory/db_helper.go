@@ -95,6 +95,10 @@ func (d *db) entryAt(blockNum uint64, ns, key string) (*compositeKV, error) {
 	return &compositeKV{k, v}, nil
 }
 
+func (d *db) getAllEntries() *leveldbhelper.Iterator {
+	return d.GetIterator(nil, nil)
+}
+
 func encodeCompositeKey(ns, key string, blockNum uint64) []byte {
 	b := []byte(keyPrefix + ns)
 	b = append(b, separatorByte)
@@ -28,9 +28,20 @@ const (
 type Mgr interface {
 	ledger.StateListener
 	GetRetriever(ledgerID string, ledgerInfoRetriever LedgerInfoRetriever) ledger.ConfigHistoryRetriever
+	ExportConfigHistory(ledgerID string, handleEntry func(*Entry) error) error
+	ImportConfigHistory(ledgerID string, entries []*Entry) error
 	Close()
 }
 
+// Entry captures a value persisted in the config history, i.e., the value of the key in the namespace
+// as committed by the block with the number BlockNum
+type Entry struct {
+	Namespace string
+	Key       string
+	BlockNum  uint64
+	Value     []byte
+}
+
 type mgr struct {
 	ccInfoProvider ledger.DeployedChaincodeInfoProvider
 	dbProvider     *dbProvider
@@ -101,6 +112,32 @@ func (m *mgr) GetRetriever(ledgerID string, ledgerInfoRetriever LedgerInfoRetrie
 	}
 }
 
+// ExportConfigHistory implements the function in the interface 'Mgr'. The function handleEntry
+// is invoked for each of the entries in the config history of the ledger
+func (m *mgr) ExportConfigHistory(ledgerID string, handleEntry func(*Entry) error) error {
+	itr := m.dbProvider.getDB(ledgerID).getAllEntries()
+	defer itr.Release()
+	for itr.Next() {
+		k := decodeCompositeKey(itr.Key())
+		v := make([]byte, len(itr.Value()))
+		copy(v, itr.Value())
+		if err := handleEntry(&Entry{Namespace: k.ns, Key: k.key, BlockNum: k.blockNum, Value: v}); err != nil {
+			return err
+		}
+	}
+	return errors.WithStack(itr.Error())
+}
+
+// ImportConfigHistory implements the function in the interface 'Mgr'. The given entries are
+// added to the config history of the ledger, typically, as exported from the config history of another peer
+func (m *mgr) ImportConfigHistory(ledgerID string, entries []*Entry) error {
+	batch := newBatch()
+	for _, e := range entries {
+		batch.add(e.Namespace, e.Key, e.BlockNum, e.Value)
+	}
+	return m.dbProvider.getDB(ledgerID).writeBatch(batch, true)
+}
+
 // Close implements the function in the interface 'Mgr'
 func (m *mgr) Close() {
 	m.dbProvider.Close()
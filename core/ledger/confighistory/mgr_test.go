@@ -17,6 +17,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/mock"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -120,6 +121,29 @@ func TestMgr(t *testing.T) {
 		assert.True(t, ok)
 		assert.Equal(t, maxBlockNumberInLedger, typedErr.MaxBlockNumCommitted)
 	})
+
+	t.Run("test-api-ExportConfigHistory-ImportConfigHistory()", func(t *testing.T) {
+		var entries []*Entry
+		err := mgr.ExportConfigHistory("ledgerid1", func(e *Entry) error {
+			entries = append(entries, e)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Len(t, entries, len(configCommittingBlockNums))
+
+		assert.NoError(t, mgr.ImportConfigHistory("ledgerid3", entries))
+		retriever := mgr.GetRetriever("ledgerid3", dummyLedgerInfoRetriever)
+		for _, commitHeight := range configCommittingBlockNums {
+			retrievedConfig, err := retriever.CollectionConfigAt(commitHeight, chaincodeName)
+			assert.NoError(t, err)
+			assert.Equal(t, sampleCollectionConfigPackage("ledgerid1", commitHeight), retrievedConfig.CollectionConfig)
+		}
+
+		err = mgr.ExportConfigHistory("ledgerid1", func(e *Entry) error {
+			return errors.New("error-handling-entry")
+		})
+		assert.EqualError(t, err, "error-handling-entry")
+	})
 }
 
 func TestWithImplicitColls(t *testing.T) {
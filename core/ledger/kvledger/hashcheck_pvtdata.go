@@ -8,6 +8,7 @@ package kvledger
 import (
 	"bytes"
 
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
@@ -30,6 +31,11 @@ func ConstructValidAndInvalidPvtData(blocksPvtData []*ledger.BlockPvtData, block
 
 	for _, blockPvtData := range blocksPvtData {
 		validData, invalidData, err := findValidAndInvalidBlockPvtData(blockPvtData, blockStore)
+		if err == blkstorage.ErrPruned {
+			// the hashes cannot be verified against a pruned block
+			logger.Warningf("Skipping the pvtData of block [%d] as the block has been pruned", blockPvtData.BlockNum)
+			continue
+		}
 		if err != nil {
 			return nil, nil, err
 		}
@@ -38,4 +38,8 @@ type HistoryDB interface {
 	GetLastSavepoint() (*version.Height, error)
 	ShouldRecover(lastAvailableBlock uint64) (bool, uint64, error)
 	CommitLostBlock(blockAndPvtdata *ledger.BlockAndPvtData) error
+	// SetSavepoint records the given height as the savepoint of an empty history db without
+	// adding any history records. This is used when a ledger is bootstrapped from a snapshot,
+	// in which case the history of the blocks included in the snapshot is not available
+	SetSavepoint(height *version.Height) error
 }
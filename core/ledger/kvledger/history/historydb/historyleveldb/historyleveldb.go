@@ -18,6 +18,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
 	putils "github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
 )
 
 var logger = flogging.MustGetLogger("historyleveldb")
@@ -178,6 +179,20 @@ func (historyDB *historyDB) GetLastSavepoint() (*version.Height, error) {
 	return height, nil
 }
 
+// SetSavepoint implements method in HistoryDB interface
+func (historyDB *historyDB) SetSavepoint(height *version.Height) error {
+	savepoint, err := historyDB.GetLastSavepoint()
+	if err != nil {
+		return err
+	}
+	if savepoint != nil {
+		return errors.Errorf("history database for channel [%s] already has a savepoint at block [%d]", historyDB.dbName, savepoint.BlockNum)
+	}
+	dbBatch := leveldbhelper.NewUpdateBatch()
+	dbBatch.Put(savePointKey, height.ToBytes())
+	return historyDB.db.WriteBatch(dbBatch, true)
+}
+
 // ShouldRecover implements method in interface kvledger.Recoverer
 func (historyDB *historyDB) ShouldRecover(lastAvailableBlock uint64) (bool, uint64, error) {
 	if !ledgerconfig.IsHistoryDBEnabled() {
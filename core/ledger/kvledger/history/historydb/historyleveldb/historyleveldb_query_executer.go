@@ -8,12 +8,16 @@ package historyleveldb
 
 import (
 	"bytes"
+	"encoding/base64"
 
+	"github.com/golang/protobuf/ptypes/timestamp"
 	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/history/historydb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/ledger/queryresult"
@@ -28,40 +32,191 @@ type LevelHistoryDBQueryExecutor struct {
 	blockStore blkstorage.BlockStore
 }
 
+const (
+	optionStartBlock = "startBlock"
+	optionEndBlock   = "endBlock"
+	optionStartTime  = "startTime"
+	optionEndTime    = "endTime"
+	optionDescending = "descending"
+	optionLimit      = "limit"
+	optionBookmark   = "bookmark"
+)
+
 // GetHistoryForKey implements method in interface `ledger.HistoryQueryExecutor`
 func (q *LevelHistoryDBQueryExecutor) GetHistoryForKey(namespace string, key string) (commonledger.ResultsIterator, error) {
+	return q.GetHistoryForKeyWithMetadata(namespace, key, nil)
+}
+
+// GetHistoryForKeyWithMetadata implements method in interface `ledger.HistoryQueryExecutor`
+func (q *LevelHistoryDBQueryExecutor) GetHistoryForKeyWithMetadata(namespace string, key string, metadata map[string]interface{}) (ledger.QueryResultsIterator, error) {
 
 	if ledgerconfig.IsHistoryDBEnabled() == false {
 		return nil, errors.New("history database not enabled")
 	}
 
-	var compositeStartKey []byte
-	var compositeEndKey []byte
-	compositeStartKey = historydb.ConstructPartialCompositeHistoryKey(namespace, key, false)
-	compositeEndKey = historydb.ConstructPartialCompositeHistoryKey(namespace, key, true)
+	options, err := parseHistoryQueryMetadata(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	compositePartialKey := historydb.ConstructPartialCompositeHistoryKey(namespace, key, false)
+	compositeStartKey := historydb.ConstructCompositeHistoryKey(namespace, key, options.startBlock, 0)
+	compositeEndKey := historydb.ConstructPartialCompositeHistoryKey(namespace, key, true)
+	if options.endBlock > 0 {
+		compositeEndKey = historydb.ConstructCompositeHistoryKey(namespace, key, options.endBlock, 0)
+	}
+	// the bookmark is the height of the last record returned in the previous page
+	if options.bookmark != nil {
+		if options.descending {
+			bookmarkKey := historydb.ConstructCompositeHistoryKey(namespace, key, options.bookmark.BlockNum, options.bookmark.TxNum)
+			if bytes.Compare(bookmarkKey, compositeEndKey) < 0 {
+				compositeEndKey = bookmarkKey
+			}
+		} else {
+			bookmarkKey := historydb.ConstructCompositeHistoryKey(namespace, key, options.bookmark.BlockNum, options.bookmark.TxNum+1)
+			if bytes.Compare(bookmarkKey, compositeStartKey) > 0 {
+				compositeStartKey = bookmarkKey
+			}
+		}
+	}
 
-	// range scan to find any history records starting with namespace~key
+	// range scan to find any history records starting with namespace~key within the bounds
 	dbItr := q.historyDB.db.GetIterator(compositeStartKey, compositeEndKey)
-	return newHistoryScanner(compositeStartKey, namespace, key, dbItr, q.blockStore), nil
+	return newHistoryScanner(compositePartialKey, namespace, key, dbItr, q.blockStore, options), nil
+}
+
+// historyQueryOptions holds the parsed metadata of a history query
+type historyQueryOptions struct {
+	startBlock     uint64
+	endBlock       uint64
+	startTime      *timestamp.Timestamp
+	endTime        *timestamp.Timestamp
+	descending     bool
+	limit          int32
+	bookmark       *version.Height
+	bookmarkString string
+}
+
+func parseHistoryQueryMetadata(metadata map[string]interface{}) (*historyQueryOptions, error) {
+	options := &historyQueryOptions{}
+	for key, keyVal := range metadata {
+		var ok bool
+		switch key {
+		case optionStartBlock:
+			options.startBlock, ok = keyVal.(uint64)
+		case optionEndBlock:
+			options.endBlock, ok = keyVal.(uint64)
+		case optionStartTime:
+			options.startTime, ok = keyVal.(*timestamp.Timestamp)
+		case optionEndTime:
+			options.endTime, ok = keyVal.(*timestamp.Timestamp)
+		case optionDescending:
+			options.descending, ok = keyVal.(bool)
+		case optionLimit:
+			options.limit, ok = keyVal.(int32)
+		case optionBookmark:
+			if options.bookmarkString, ok = keyVal.(string); ok && options.bookmarkString != "" {
+				if options.bookmark, ok = decodeHistoryBookmark(options.bookmarkString); !ok {
+					return nil, errors.Errorf("invalid bookmark [%s]", options.bookmarkString)
+				}
+			}
+		default:
+			return nil, errors.Errorf("invalid entry, option %s not recognized", key)
+		}
+		if !ok {
+			return nil, errors.Errorf("invalid entry, option %s has an unexpected type %T", key, keyVal)
+		}
+	}
+	return options, nil
+}
+
+// inTimeRange returns true if the given timestamp is within the bounds [startTime, endTime)
+func (options *historyQueryOptions) inTimeRange(ts *timestamp.Timestamp) bool {
+	if options.startTime != nil && compareTimestamps(ts, options.startTime) < 0 {
+		return false
+	}
+	if options.endTime != nil && compareTimestamps(ts, options.endTime) >= 0 {
+		return false
+	}
+	return true
 }
 
-//historyScanner implements ResultsIterator for iterating through history results
+func compareTimestamps(a, b *timestamp.Timestamp) int {
+	switch {
+	case a.GetSeconds() != b.GetSeconds():
+		if a.GetSeconds() < b.GetSeconds() {
+			return -1
+		}
+		return 1
+	case a.GetNanos() != b.GetNanos():
+		if a.GetNanos() < b.GetNanos() {
+			return -1
+		}
+		return 1
+	default:
+		return 0
+	}
+}
+
+func encodeHistoryBookmark(height *version.Height) string {
+	return base64.RawURLEncoding.EncodeToString(height.ToBytes())
+}
+
+func decodeHistoryBookmark(bookmark string) (*version.Height, bool) {
+	heightBytes, err := base64.RawURLEncoding.DecodeString(bookmark)
+	if err != nil || len(heightBytes) == 0 {
+		return nil, false
+	}
+	height, n := version.NewHeightFromBytes(heightBytes)
+	return height, n == len(heightBytes)
+}
+
+// historyScanner implements ResultsIterator for iterating through history results
 type historyScanner struct {
 	compositePartialKey []byte //compositePartialKey includes namespace~key
 	namespace           string
 	key                 string
 	dbItr               iterator.Iterator
 	blockStore          blkstorage.BlockStore
+	options             *historyQueryOptions
+
+	started       bool
+	exhausted     bool
+	returnedCount int32
+	lastHeight    *version.Height
 }
 
 func newHistoryScanner(compositePartialKey []byte, namespace string, key string,
-	dbItr iterator.Iterator, blockStore blkstorage.BlockStore) *historyScanner {
-	return &historyScanner{compositePartialKey, namespace, key, dbItr, blockStore}
+	dbItr iterator.Iterator, blockStore blkstorage.BlockStore, options *historyQueryOptions) *historyScanner {
+	return &historyScanner{
+		compositePartialKey: compositePartialKey,
+		namespace:           namespace,
+		key:                 key,
+		dbItr:               dbItr,
+		blockStore:          blockStore,
+		options:             options,
+	}
+}
+
+// moveNext moves the underlying iterator to the next history record in the order of the query
+func (scanner *historyScanner) moveNext() bool {
+	if !scanner.options.descending {
+		return scanner.dbItr.Next()
+	}
+	if !scanner.started {
+		scanner.started = true
+		return scanner.dbItr.Last()
+	}
+	return scanner.dbItr.Prev()
 }
 
 func (scanner *historyScanner) Next() (commonledger.QueryResult, error) {
+	if scanner.options.limit > 0 && scanner.returnedCount >= scanner.options.limit {
+		return nil, nil
+	}
 	for {
-		if !scanner.dbItr.Next() {
+		if !scanner.moveNext() {
+			scanner.exhausted = true
 			return nil, nil
 		}
 		historyKey := scanner.dbItr.Key() // history key is in the form namespace~key~blocknum~trannum
@@ -103,6 +258,11 @@ func (scanner *historyScanner) Next() (commonledger.QueryResult, error) {
 		if err != nil {
 			return nil, err
 		}
+		if !scanner.options.inTimeRange(queryResult.(*queryresult.KeyModification).Timestamp) {
+			continue
+		}
+		scanner.returnedCount++
+		scanner.lastHeight = version.NewHeight(blockNum, tranNum)
 		logger.Debugf("Found historic key value for namespace:%s key:%s from transaction %s\n",
 			scanner.namespace, scanner.key, queryResult.(*queryresult.KeyModification).TxId)
 		return queryResult, nil
@@ -113,6 +273,20 @@ func (scanner *historyScanner) Close() {
 	scanner.dbItr.Release()
 }
 
+// GetBookmarkAndClose implements method in interface `ledger.QueryResultsIterator`. The returned bookmark is
+// empty if all the history records in the range have been returned
+func (scanner *historyScanner) GetBookmarkAndClose() string {
+	scanner.Close()
+	switch {
+	case scanner.exhausted:
+		return ""
+	case scanner.lastHeight == nil:
+		return scanner.options.bookmarkString
+	default:
+		return encodeHistoryBookmark(scanner.lastHeight)
+	}
+}
+
 // getTxIDandKeyWriteValueFromTran inspects a transaction for writes to a given key
 func getKeyModificationFromTran(tranEnvelope *common.Envelope, namespace string, key string) (commonledger.QueryResult, error) {
 	logger.Debugf("Entering getKeyModificationFromTran()\n", namespace, key)
@@ -16,6 +16,7 @@ import (
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	util2 "github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/ledger/queryresult"
@@ -249,6 +250,25 @@ func TestHistoryDisabled(t *testing.T) {
 	assert.Error(t, err2, "Error should have been returned for GetHistoryForKey() when history disabled")
 }
 
+//TestSetSavepoint tests that a savepoint can be set on an empty history db only
+func TestSetSavepoint(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+
+	assert.NoError(t, env.testHistoryDB.SetSavepoint(version.NewHeight(10, 0)))
+	savepoint, err := env.testHistoryDB.GetLastSavepoint()
+	assert.NoError(t, err)
+	assert.Equal(t, version.NewHeight(10, 0), savepoint)
+
+	status, blockNum, err := env.testHistoryDB.ShouldRecover(10)
+	assert.NoError(t, err)
+	assert.False(t, status)
+	assert.Equal(t, uint64(11), blockNum)
+
+	err = env.testHistoryDB.SetSavepoint(version.NewHeight(12, 0))
+	assert.EqualError(t, err, "history database for channel [TestHistoryDB] already has a savepoint at block [10]")
+}
+
 //TestGenesisBlockNoError tests that Genesis blocks are ignored by history processing
 // since we only persist history of chaincode key writes
 func TestGenesisBlockNoError(t *testing.T) {
@@ -321,6 +341,149 @@ func TestHistoryWithKeyContainingNilBytes(t *testing.T) {
 	testutilVerifyResults(t, qhistory, "ns1", "\x00key\x00\x01\x01\x15", []string{"dummyVal2"})
 }
 
+func TestHistoryWithMetadata(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+	provider := env.testBlockStorageEnv.provider
+	ledger1id := "ledger1"
+	store1, err := provider.OpenBlockStore(ledger1id)
+	assert.NoError(t, err, "Error upon provider.OpenBlockStore()")
+	defer store1.Shutdown()
+
+	bg, gb := testutil.NewBlockGenerator(t, ledger1id, false)
+	assert.NoError(t, store1.AddBlock(gb))
+	assert.NoError(t, env.testHistoryDB.Commit(gb))
+
+	// blocks 1 to 5 write the values value1 to value6 for the key "key1"; block 3 contains two transactions.
+	// The key "key1\x00\x01" is written in each block as well
+	valuesInBlocks := [][]string{{"value1"}, {"value2"}, {"value3", "value4"}, {"value5"}, {"value6"}}
+	for _, values := range valuesInBlocks {
+		simulationResults := [][]byte{}
+		for _, value := range values {
+			simulator, _ := env.txmgr.NewTxSimulator(util2.GenerateUUID())
+			simulator.SetState("ns1", "key1", []byte(value))
+			simulator.SetState("ns1", "key1\x00\x01", []byte("other-"+value))
+			simulator.Done()
+			simRes, _ := simulator.GetTxSimulationResults()
+			pubSimResBytes, _ := simRes.GetPubSimulationBytes()
+			simulationResults = append(simulationResults, pubSimResBytes)
+		}
+		block := bg.NextBlock(simulationResults)
+		assert.NoError(t, store1.AddBlock(block))
+		assert.NoError(t, env.testHistoryDB.Commit(block))
+	}
+
+	qhistory, err := env.testHistoryDB.NewHistoryQueryExecutor(store1)
+	assert.NoError(t, err, "Error upon NewHistoryQueryExecutor")
+
+	t.Run("block-range", func(t *testing.T) {
+		verifyHistoryWithMetadata(t, qhistory, map[string]interface{}{"startBlock": uint64(2), "endBlock": uint64(4)},
+			[]string{"value2", "value3", "value4"}, "")
+		verifyHistoryWithMetadata(t, qhistory, map[string]interface{}{"startBlock": uint64(4)},
+			[]string{"value5", "value6"}, "")
+		verifyHistoryWithMetadata(t, qhistory, map[string]interface{}{"endBlock": uint64(2)},
+			[]string{"value1"}, "")
+		verifyHistoryWithMetadata(t, qhistory, map[string]interface{}{"startBlock": uint64(4), "endBlock": uint64(2)},
+			[]string{}, "")
+	})
+
+	t.Run("descending", func(t *testing.T) {
+		verifyHistoryWithMetadata(t, qhistory, map[string]interface{}{"descending": true},
+			[]string{"value6", "value5", "value4", "value3", "value2", "value1"}, "")
+		verifyHistoryWithMetadata(t, qhistory, map[string]interface{}{"descending": true, "startBlock": uint64(2), "endBlock": uint64(4)},
+			[]string{"value4", "value3", "value2"}, "")
+	})
+
+	t.Run("time-range", func(t *testing.T) {
+		allMods := retrieveHistoryWithMetadata(t, qhistory, nil)
+		assert.Len(t, allMods, 6)
+		startTime, endTime := allMods[1].Timestamp, allMods[4].Timestamp
+		expectedValues := []string{}
+		for _, kmod := range allMods {
+			if compareTimestamps(kmod.Timestamp, startTime) >= 0 && compareTimestamps(kmod.Timestamp, endTime) < 0 {
+				expectedValues = append(expectedValues, string(kmod.Value))
+			}
+		}
+		verifyHistoryWithMetadata(t, qhistory, map[string]interface{}{"startTime": startTime, "endTime": endTime},
+			expectedValues, "")
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		testHistoryPagination(t, qhistory, map[string]interface{}{},
+			[][]string{{"value1", "value2"}, {"value3", "value4"}, {"value5", "value6"}, {}})
+		testHistoryPagination(t, qhistory, map[string]interface{}{"descending": true},
+			[][]string{{"value6", "value5"}, {"value4", "value3"}, {"value2", "value1"}, {}})
+		testHistoryPagination(t, qhistory, map[string]interface{}{"startBlock": uint64(2), "endBlock": uint64(5)},
+			[][]string{{"value2", "value3"}, {"value4", "value5"}, {}})
+		testHistoryPagination(t, qhistory, map[string]interface{}{"descending": true, "endBlock": uint64(4)},
+			[][]string{{"value4", "value3"}, {"value2", "value1"}, {}})
+	})
+
+	t.Run("invalid-metadata", func(t *testing.T) {
+		_, err := qhistory.GetHistoryForKeyWithMetadata("ns1", "key1", map[string]interface{}{"unknown": 1})
+		assert.EqualError(t, err, "invalid entry, option unknown not recognized")
+		_, err = qhistory.GetHistoryForKeyWithMetadata("ns1", "key1", map[string]interface{}{"startBlock": 1})
+		assert.EqualError(t, err, "invalid entry, option startBlock has an unexpected type int")
+		_, err = qhistory.GetHistoryForKeyWithMetadata("ns1", "key1", map[string]interface{}{"bookmark": "!invalid!"})
+		assert.EqualError(t, err, "invalid bookmark [!invalid!]")
+	})
+}
+
+func testHistoryPagination(t *testing.T, hqe ledger.HistoryQueryExecutor, metadata map[string]interface{}, expectedPages [][]string) {
+	bookmark := ""
+	for i, expectedPage := range expectedPages {
+		pageMetadata := map[string]interface{}{"limit": int32(2), "bookmark": bookmark}
+		for k, v := range metadata {
+			pageMetadata[k] = v
+		}
+		expectedBookmark := "non-empty"
+		if i == len(expectedPages)-1 {
+			expectedBookmark = ""
+		}
+		bookmark = verifyHistoryWithMetadata(t, hqe, pageMetadata, expectedPage, expectedBookmark)
+	}
+}
+
+// verifyHistoryWithMetadata verifies the history of the key "key1" in the namespace "ns1" and returns the bookmark.
+// If `expectedBookmark` is "non-empty", the bookmark is expected to be any non-empty string
+func verifyHistoryWithMetadata(t *testing.T, hqe ledger.HistoryQueryExecutor, metadata map[string]interface{},
+	expectedVals []string, expectedBookmark string) string {
+	itr, err := hqe.GetHistoryForKeyWithMetadata("ns1", "key1", metadata)
+	assert.NoError(t, err, "Error upon GetHistoryForKeyWithMetadata()")
+	retrievedVals := []string{}
+	for {
+		kmod, err := itr.Next()
+		assert.NoError(t, err)
+		if kmod == nil {
+			break
+		}
+		retrievedVals = append(retrievedVals, string(kmod.(*queryresult.KeyModification).Value))
+	}
+	assert.Equal(t, expectedVals, retrievedVals)
+	bookmark := itr.GetBookmarkAndClose()
+	if expectedBookmark == "non-empty" {
+		assert.NotEmpty(t, bookmark)
+	} else {
+		assert.Equal(t, expectedBookmark, bookmark)
+	}
+	return bookmark
+}
+
+func retrieveHistoryWithMetadata(t *testing.T, hqe ledger.HistoryQueryExecutor, metadata map[string]interface{}) []*queryresult.KeyModification {
+	itr, err := hqe.GetHistoryForKeyWithMetadata("ns1", "key1", metadata)
+	assert.NoError(t, err, "Error upon GetHistoryForKeyWithMetadata()")
+	defer itr.Close()
+	kmods := []*queryresult.KeyModification{}
+	for {
+		kmod, err := itr.Next()
+		assert.NoError(t, err)
+		if kmod == nil {
+			return kmods
+		}
+		kmods = append(kmods, kmod.(*queryresult.KeyModification))
+	}
+}
+
 func testutilVerifyResults(t *testing.T, hqe ledger.HistoryQueryExecutor, ns, key string, expectedVals []string) {
 	itr, err := hqe.GetHistoryForKey(ns, key)
 	assert.NoError(t, err, "Error upon GetHistoryForKey()")
@@ -12,6 +12,7 @@ import (
 
 	"github.com/hyperledger/fabric/common/flogging"
 	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
@@ -26,6 +27,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/pvtdatapolicy"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
 )
 
@@ -37,7 +39,9 @@ type kvLedger struct {
 	ledgerID               string
 	blockStore             *ledgerstorage.Store
 	txtmgmt                txmgr.TxMgr
+	versionedDB            privacyenabledstate.DB
 	historyDB              historydb.HistoryDB
+	configHistoryMgr       confighistory.Mgr
 	configHistoryRetriever ledger.ConfigHistoryRetriever
 	blockAPIsRWLock        *sync.RWMutex
 	stats                  *ledgerStats
@@ -58,15 +62,23 @@ func newKVLedger(
 	logger.Debugf("Creating KVLedger ledgerID=%s: ", ledgerID)
 	// Create a kvLedger for this chain/ledger, which encasulates the underlying
 	// id store, blockstore, txmgr (state database), history database
-	l := &kvLedger{ledgerID: ledgerID, blockStore: blockStore, historyDB: historyDB, blockAPIsRWLock: &sync.RWMutex{}}
+	l := &kvLedger{
+		ledgerID:         ledgerID,
+		blockStore:       blockStore,
+		versionedDB:      versionedDB,
+		historyDB:        historyDB,
+		configHistoryMgr: configHistoryMgr,
+		blockAPIsRWLock:  &sync.RWMutex{},
+	}
 
 	// TODO Move the function `GetChaincodeEventListener` to ledger interface and
 	// this functionality of regiserting for events to ledgermgmt package so that this
 	// is reused across other future ledger implementations
 	ccEventListener := versionedDB.GetChaincodeEventListener()
-	logger.Debugf("Register state db for chaincode lifecycle events: %t", ccEventListener != nil)
-	if ccEventListener != nil {
-		cceventmgmt.GetMgr().Register(ledgerID, ccEventListener)
+	ccEventMgr := cceventmgmt.GetMgr()
+	logger.Debugf("Register state db for chaincode lifecycle events: %t", ccEventListener != nil && ccEventMgr != nil)
+	if ccEventListener != nil && ccEventMgr != nil {
+		ccEventMgr.Register(ledgerID, ccEventListener)
 	}
 	btlPolicy := pvtdatapolicy.ConstructBTLPolicy(&collectionInfoRetriever{ledgerID, l, ccInfoProvider})
 	if err := l.initTxMgr(versionedDB, stateListeners, btlPolicy, bookkeeperProvider, ccInfoProvider); err != nil {
@@ -215,6 +227,25 @@ func (l *kvLedger) GetTransactionByID(txID string) (*peer.ProcessedTransaction,
 	return processedTran, nil
 }
 
+// GetTransactionsByCreatorMSPID implements method in interface `ledger.TransactionIndexQuerier`
+func (l *kvLedger) GetTransactionsByCreatorMSPID(mspID string) (commonledger.ResultsIterator, error) {
+	return l.blockStore.RetrieveTxsByIndexKey(blkstorage.SecondaryIndexCreatorMSPID, []byte(mspID))
+}
+
+// GetTransactionsByChaincodeName implements method in interface `ledger.TransactionIndexQuerier`
+func (l *kvLedger) GetTransactionsByChaincodeName(chaincodeName string) (commonledger.ResultsIterator, error) {
+	return l.blockStore.RetrieveTxsByIndexKey(blkstorage.SecondaryIndexChaincodeName, []byte(chaincodeName))
+}
+
+// GetTransactionsByTimeRange implements method in interface `ledger.TransactionIndexQuerier`
+func (l *kvLedger) GetTransactionsByTimeRange(startTime, endTime time.Time) (commonledger.ResultsIterator, error) {
+	if !startTime.Before(endTime) {
+		return nil, errors.Errorf("start time [%s] is not before end time [%s]", startTime, endTime)
+	}
+	return l.blockStore.RetrieveTxsByIndexRange(blkstorage.SecondaryIndexTxTimestamp,
+		blkstorage.EncodeTxTimestampKey(startTime), blkstorage.EncodeTxTimestampKey(endTime))
+}
+
 // GetBlockchainInfo returns basic info about blockchain
 func (l *kvLedger) GetBlockchainInfo() (*common.BlockchainInfo, error) {
 	bcInfo, err := l.blockStore.GetBlockchainInfo()
@@ -268,7 +299,81 @@ func (l *kvLedger) GetTxValidationCodeByTxID(txID string) (peer.TxValidationCode
 
 //Prune prunes the blocks/transactions that satisfy the given policy
 func (l *kvLedger) Prune(policy commonledger.PrunePolicy) error {
-	return errors.New("not yet implemented")
+	retentionPolicy, ok := policy.(*ledger.BlockRetentionPolicy)
+	if !ok {
+		return errors.Errorf("unsupported prune policy type [%T]", policy)
+	}
+	if retentionPolicy.RetainLastNBlocks == 0 && retentionPolicy.RetainFromBlockNum == 0 {
+		return errors.New("prune policy does not specify any block to retain")
+	}
+	info, err := l.blockStore.GetBlockchainInfo()
+	if err != nil {
+		return err
+	}
+	if info.Height == 0 {
+		return nil
+	}
+	lastBlockNum := info.Height - 1
+	belowBlockNum := retentionBoundary(retentionPolicy, info.Height)
+
+	// the latest config block is needed for building the channel config when the peer starts
+	lastBlock, err := l.blockStore.RetrieveBlockByNumber(lastBlockNum)
+	if err != nil {
+		return err
+	}
+	lastConfigBlockNum, err := utils.GetLastConfigIndexFromBlock(lastBlock)
+	if err != nil {
+		return errors.WithMessage(err, "error retrieving the latest config block number")
+	}
+	if lastConfigBlockNum < belowBlockNum {
+		belowBlockNum = lastConfigBlockNum
+	}
+
+	// the blocks that are not yet committed to the state and history databases are needed for their recovery
+	for _, r := range []recoverable{l.txtmgmt, l.historyDB} {
+		recoverFlag, firstBlockNum, err := r.ShouldRecover(lastBlockNum)
+		if err != nil {
+			return err
+		}
+		if recoverFlag && firstBlockNum < belowBlockNum {
+			belowBlockNum = firstBlockNum
+		}
+	}
+
+	logger.Infof("[%s] Pruning blocks below block [%d]", l.ledgerID, belowBlockNum)
+	return l.blockStore.Prune(belowBlockNum)
+}
+
+// retentionBoundary returns the number of the oldest block that is retained by the
+// given policy for a chain of the given height
+func retentionBoundary(policy *ledger.BlockRetentionPolicy, height uint64) uint64 {
+	boundary := height - 1
+	if policy.RetainLastNBlocks > 0 {
+		boundary = 0
+		if policy.RetainLastNBlocks < height {
+			boundary = height - policy.RetainLastNBlocks
+		}
+	}
+	if policy.RetainFromBlockNum > 0 && policy.RetainFromBlockNum < boundary {
+		boundary = policy.RetainFromBlockNum
+	}
+	return boundary
+}
+
+// FirstBlockNumber returns the number of the oldest block that has not been pruned from the ledger
+func (l *kvLedger) FirstBlockNumber() (uint64, error) {
+	return l.blockStore.FirstBlockNumber()
+}
+
+// pruneIfDue applies the block retention policy configured for the peer (if any) at the configured interval
+func (l *kvLedger) pruneIfDue(blockNum uint64) {
+	retainBlocks := ledgerconfig.GetBlockRetentionBlocks()
+	if retainBlocks == 0 || (blockNum+1)%ledgerconfig.GetBlockRetentionPruneInterval() != 0 {
+		return
+	}
+	if err := l.Prune(&ledger.BlockRetentionPolicy{RetainLastNBlocks: retainBlocks}); err != nil {
+		logger.Warningf("[%s] Error while applying the block retention policy after block [%d]: %s", l.ledgerID, blockNum, err)
+	}
 }
 
 // NewTxSimulator returns new `ledger.TxSimulator`
@@ -345,6 +450,7 @@ func (l *kvLedger) CommitWithPvtData(pvtdataAndBlock *ledger.BlockAndPvtData) er
 		elapsedCommitState,
 		txstatsInfo,
 	)
+	l.pruneIfDue(blockNo)
 	return nil
 }
 
@@ -386,16 +492,52 @@ func (l *kvLedger) GetPvtDataByNum(blockNum uint64, filter ledger.PvtNsCollFilte
 	return pvtdata, err
 }
 
-// Purge removes private read-writes set generated by endorsers at block height lesser than
+// PurgePrivateData removes private read-writes set generated by endorsers at block height lesser than
 // a given maxBlockNumToRetain. In other words, Purge only retains private read-write sets
 // that were generated at block height of maxBlockNumToRetain or higher.
+// The private values written by the purged read-write sets are removed from the state database as well,
+// whereas the hashes of these values are retained
 func (l *kvLedger) PurgePrivateData(maxBlockNumToRetain uint64) error {
-	return errors.New("not yet implemented")
+	minBlockNum, err := l.blockStore.PvtDataMinBlockNum()
+	if err != nil {
+		return err
+	}
+	if maxBlockNumToRetain <= minBlockNum {
+		logger.Infof("[%s] Private data below block [%d] has already been purged", l.ledgerID, minBlockNum)
+		return nil
+	}
+	info, err := l.blockStore.GetBlockchainInfo()
+	if err != nil {
+		return err
+	}
+	if maxBlockNumToRetain > info.Height {
+		return errors.Errorf("cannot retain private data from block [%d] as the ledger height is [%d]", maxBlockNumToRetain, info.Height)
+	}
+
+	logger.Infof("[%s] Purging private data of blocks [%d] to [%d]", l.ledgerID, minBlockNum, maxBlockNumToRetain-1)
+	for blockNum := minBlockNum; blockNum < maxBlockNumToRetain; blockNum++ {
+		pvtdata, err := l.blockStore.GetPvtDataByNum(blockNum, nil)
+		if err != nil {
+			return err
+		}
+		if len(pvtdata) == 0 {
+			continue
+		}
+		if err := l.txtmgmt.RemovePvtDataOfOldBlocks(map[uint64][]*ledger.TxPvtData{blockNum: pvtdata}); err != nil {
+			return err
+		}
+	}
+	// the pvtdata store is purged last so that a failure above can be recovered by purging again
+	if err := l.blockStore.PurgePvtDataBelowBlock(maxBlockNumToRetain); err != nil {
+		return err
+	}
+	logger.Infof("[%s] Purged private data below block [%d]", l.ledgerID, maxBlockNumToRetain)
+	return nil
 }
 
 // PrivateDataMinBlockNum returns the lowest retained endorsement block height
 func (l *kvLedger) PrivateDataMinBlockNum() (uint64, error) {
-	return 0, errors.New("not yet implemented")
+	return l.blockStore.PvtDataMinBlockNum()
 }
 
 func (l *kvLedger) GetConfigHistoryRetriever() (ledger.ConfigHistoryRetriever, error) {
@@ -405,6 +547,10 @@ func (l *kvLedger) GetConfigHistoryRetriever() (ledger.ConfigHistoryRetriever, e
 func (l *kvLedger) CommitPvtDataOfOldBlocks(pvtData []*ledger.BlockPvtData) ([]*ledger.PvtdataHashMismatch, error) {
 	logger.Debugf("[%s:] Comparing pvtData of [%d] old blocks against the hashes in transaction's rwset to find valid and invalid data",
 		l.ledgerID, len(pvtData))
+	pvtData, err := l.dropPvtDataOfPurgedBlocks(pvtData)
+	if err != nil {
+		return nil, err
+	}
 	validPvtData, hashMismatches, err := ConstructValidAndInvalidPvtData(pvtData, l.blockStore)
 	if err != nil {
 		return nil, err
@@ -430,6 +576,24 @@ func (l *kvLedger) CommitPvtDataOfOldBlocks(pvtData []*ledger.BlockPvtData) ([]*
 	return hashMismatches, nil
 }
 
+// dropPvtDataOfPurgedBlocks removes the pvtData of the blocks below the lowest block for which the
+// private data is retained, so that the data purged via `PurgePrivateData` does not get reconciled again
+func (l *kvLedger) dropPvtDataOfPurgedBlocks(pvtData []*ledger.BlockPvtData) ([]*ledger.BlockPvtData, error) {
+	minBlockNum, err := l.blockStore.PvtDataMinBlockNum()
+	if err != nil || minBlockNum == 0 {
+		return pvtData, err
+	}
+	var retainedPvtData []*ledger.BlockPvtData
+	for _, blockPvtData := range pvtData {
+		if blockPvtData.BlockNum < minBlockNum {
+			logger.Debugf("[%s:] Skipping the pvtData of block [%d] as it has been purged", l.ledgerID, blockPvtData.BlockNum)
+			continue
+		}
+		retainedPvtData = append(retainedPvtData, blockPvtData)
+	}
+	return retainedPvtData, nil
+}
+
 func (l *kvLedger) GetMissingPvtDataTracker() (ledger.MissingPvtDataTracker, error) {
 	return l, nil
 }
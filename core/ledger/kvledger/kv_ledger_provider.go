@@ -40,6 +40,7 @@ var (
 
 // Provider implements interface ledger.PeerLedgerProvider
 type Provider struct {
+	fileLock            *leveldbhelper.FileLock
 	idStore             *idStore
 	ledgerStoreProvider *ledgerstorage.Provider
 	vdbProvider         privacyenabledstate.DBProvider
@@ -56,13 +57,19 @@ type Provider struct {
 // This is not thread-safe and assumed to be synchronized be the caller
 func NewProvider() (ledger.PeerLedgerProvider, error) {
 	logger.Info("Initializing ledger provider")
+	// Acquire the file lock so that an offline command such as rollback cannot run while the ledger is in use
+	fileLock := leveldbhelper.NewFileLock(ledgerconfig.GetFileLockPath())
+	if err := fileLock.Lock(); err != nil {
+		return nil, errors.WithMessage(err, "as another peer node command is executing,"+
+			" wait for that command to complete its execution or terminate it before retrying")
+	}
 	// Initialize the ID store (inventory of chainIds/ledgerIds)
 	idStore := openIDStore(ledgerconfig.GetLedgerProviderPath())
 	ledgerStoreProvider := ledgerstorage.NewProvider()
 	// Initialize the history database (index for history of values by key)
 	historydbProvider := historyleveldb.NewHistoryDBProvider()
 	logger.Info("ledger provider Initialized")
-	provider := &Provider{idStore, ledgerStoreProvider,
+	provider := &Provider{fileLock, idStore, ledgerStoreProvider,
 		nil, historydbProvider, nil, nil, nil, nil, nil, nil}
 	return provider, nil
 }
@@ -197,12 +204,14 @@ func (provider *Provider) Close() {
 	provider.historydbProvider.Close()
 	provider.bookkeepingProvider.Close()
 	provider.configHistoryMgr.Close()
+	provider.fileLock.Unlock()
 }
 
 // recoverUnderConstructionLedger checks whether the under construction flag is set - this would be the case
 // if a crash had happened during creation of ledger and the ledger creation could have been left in intermediate
-// state. Recovery checks if the ledger was created and the genesis block was committed successfully then it completes
-// the last step of adding the ledger id to the list of created ledgers. Else, it clears the under construction flag
+// state. Recovery checks if the ledger was created and the genesis block was committed successfully (or the block storage
+// was bootstrapped from a snapshot) then it completes the last step of adding the ledger id to the list of created ledgers.
+// Else, it clears the under construction flag
 func (provider *Provider) recoverUnderConstructionLedger() {
 	logger.Debugf("Recovering under construction ledger")
 	ledgerID, err := provider.idStore.getUnderConstructionFlag()
@@ -216,14 +225,21 @@ func (provider *Provider) recoverUnderConstructionLedger() {
 	panicOnErr(err, "Error while opening under construction ledger [%s]", ledgerID)
 	bcInfo, err := ledger.GetBlockchainInfo()
 	panicOnErr(err, "Error while getting blockchain info for the under construction ledger [%s]", ledgerID)
+	firstBlockNum, err := ledger.(*kvLedger).FirstBlockNumber()
+	panicOnErr(err, "Error while getting the first block number for the under construction ledger [%s]", ledgerID)
 	ledger.Close()
 
-	switch bcInfo.Height {
-	case 0:
+	switch {
+	case bcInfo.Height > 0 && firstBlockNum == bcInfo.Height:
+		logger.Infof("Block storage was bootstrapped from a snapshot. Hence, marking the peer ledger as created")
+		_, lastConfigBlock, err := ledger.(*kvLedger).retrieveLastAndLastConfigBlocks(bcInfo.Height - 1)
+		panicOnErr(err, "Error while retrieving the latest config block from blockchain for ledger [%s]", ledgerID)
+		panicOnErr(provider.idStore.createLedgerID(ledgerID, lastConfigBlock), "Error while adding ledgerID [%s] to created list", ledgerID)
+	case bcInfo.Height == 0:
 		logger.Infof("Genesis block was not committed. Hence, the peer ledger not created. unsetting the under construction flag")
 		panicOnErr(provider.runCleanup(ledgerID), "Error while running cleanup for ledger id [%s]", ledgerID)
 		panicOnErr(provider.idStore.unsetUnderConstructionFlag(), "Error while unsetting under construction flag")
-	case 1:
+	case bcInfo.Height == 1:
 		logger.Infof("Genesis block was committed. Hence, marking the peer ledger as created")
 		genesisBlock, err := ledger.GetBlockByNumber(0)
 		panicOnErr(err, "Error while retrieving genesis block from blockchain for ledger [%s]", ledgerID)
@@ -7,8 +7,10 @@ SPDX-License-Identifier: Apache-2.0
 package kvledger
 
 import (
+	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
@@ -435,6 +437,164 @@ func testSyncStateDBWithPvtdatastore(t *testing.T) {
 	assert.Equal(t, value, []byte("pvtValue1.2"))
 }
 
+func TestKVLedgerPrune(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+	defer provider.Close()
+
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, err := provider.Create(gb)
+	assert.NoError(t, err)
+	defer ledger.Close()
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, ledger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: bg.NextTestBlock(1, 10)}))
+	}
+
+	err = ledger.Prune("some-policy")
+	assert.EqualError(t, err, "unsupported prune policy type [string]")
+	err = ledger.Prune(&lgr.BlockRetentionPolicy{})
+	assert.EqualError(t, err, "prune policy does not specify any block to retain")
+
+	// all the blocks share the first block file and the genesis block is the latest config block
+	assert.NoError(t, ledger.Prune(&lgr.BlockRetentionPolicy{RetainLastNBlocks: 1}))
+	firstBlockNum, err := ledger.(*kvLedger).FirstBlockNumber()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), firstBlockNum)
+	b0, err := ledger.GetBlockByNumber(0)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(gb, b0), "proto messages are not equal")
+}
+
+func TestKVLedgerTransactionIndexes(t *testing.T) {
+	viper.Set("ledger.blockchain.secondaryIndexes", []string{"ChaincodeName", "TxTimestamp"})
+	defer viper.Set("ledger.blockchain.secondaryIndexes", []string{})
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+	defer provider.Close()
+
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, err := provider.Create(gb)
+	assert.NoError(t, err)
+	defer ledger.Close()
+	startTime := time.Now().Add(-time.Minute)
+	block1 := bg.NextBlock([][]byte{[]byte("simRes1"), []byte("simRes2")})
+	assert.NoError(t, ledger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: block1}))
+	querier := ledger.(lgr.TransactionIndexQuerier)
+
+	itr, err := querier.GetTransactionsByChaincodeName("foo")
+	assert.NoError(t, err)
+	var txEnvs [][]byte
+	for {
+		res, err := itr.Next()
+		assert.NoError(t, err)
+		if res == nil {
+			break
+		}
+		txEnvs = append(txEnvs, putils.MarshalOrPanic(res.(*peer.ProcessedTransaction).TransactionEnvelope))
+	}
+	itr.Close()
+	assert.Equal(t, block1.Data.Data, txEnvs)
+
+	itr, err = querier.GetTransactionsByTimeRange(startTime, time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+	res, err := itr.Next()
+	assert.NoError(t, err)
+	assert.NotNil(t, res)
+	itr.Close()
+
+	_, err = querier.GetTransactionsByTimeRange(startTime, startTime)
+	assert.EqualError(t, err, fmt.Sprintf("start time [%s] is not before end time [%s]", startTime, startTime))
+
+	_, err = querier.GetTransactionsByCreatorMSPID("Org1MSP")
+	assert.Error(t, err)
+}
+
+func TestKVLedgerPurgePrivateData(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProviderWithCollectionConfig(t,
+		"ns", map[string]uint64{"coll": 0},
+	)
+	defer provider.Close()
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, err := provider.Create(gb)
+	assert.NoError(t, err)
+	defer ledger.Close()
+
+	blockAndPvtdata1 := prepareNextBlockForTest(t, ledger, bg, "SimulateForBlk1",
+		map[string]string{"key1": "value1.1", "key2": "value2.1"},
+		map[string]string{"key1": "pvtValue1.1", "key2": "pvtValue2.1"})
+	assert.NoError(t, ledger.CommitWithPvtData(blockAndPvtdata1))
+	blockAndPvtdata2 := prepareNextBlockForTest(t, ledger, bg, "SimulateForBlk2",
+		map[string]string{"key2": "value2.2"},
+		map[string]string{"key2": "pvtValue2.2"})
+	assert.NoError(t, ledger.CommitWithPvtData(blockAndPvtdata2))
+
+	minBlockNum, err := ledger.PrivateDataMinBlockNum()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), minBlockNum)
+
+	assert.EqualError(t, ledger.PurgePrivateData(4), "cannot retain private data from block [4] as the ledger height is [3]")
+	assert.NoError(t, ledger.PurgePrivateData(2))
+	minBlockNum, err = ledger.PrivateDataMinBlockNum()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), minBlockNum)
+
+	pvtdata, err := ledger.GetPvtDataByNum(1, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, pvtdata)
+	pvtdata, err = ledger.GetPvtDataByNum(2, nil)
+	assert.NoError(t, err)
+	assert.Len(t, pvtdata, 1)
+
+	// the private value written in the purged block is removed from the state but its hash is retained
+	qe, err := ledger.NewQueryExecutor()
+	assert.NoError(t, err)
+	defer qe.Done()
+	_, err = qe.GetPrivateData("ns", "coll", "key1")
+	assert.IsType(t, &txmgr.ErrPvtdataNotAvailable{}, err)
+	hash, err := qe.GetPrivateDataHash("ns", "coll", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, util.ComputeSHA256([]byte("pvtValue1.1")), hash)
+	value, err := qe.GetPrivateData("ns", "coll", "key2")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("pvtValue2.2"), value)
+
+	// purging below an already purged block is a noop
+	assert.NoError(t, ledger.PurgePrivateData(1))
+
+	// the pvtData of the purged blocks is not committed again
+	hashMismatches, err := ledger.CommitPvtDataOfOldBlocks([]*lgr.BlockPvtData{
+		{BlockNum: 1, WriteSets: blockAndPvtdata1.PvtData},
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, hashMismatches)
+	pvtdata, err = ledger.GetPvtDataByNum(1, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, pvtdata)
+}
+
+func TestRetentionBoundary(t *testing.T) {
+	testCases := []struct {
+		policy   *lgr.BlockRetentionPolicy
+		height   uint64
+		boundary uint64
+	}{
+		{&lgr.BlockRetentionPolicy{RetainLastNBlocks: 10}, 100, 90},
+		{&lgr.BlockRetentionPolicy{RetainLastNBlocks: 100}, 100, 0},
+		{&lgr.BlockRetentionPolicy{RetainLastNBlocks: 1000}, 100, 0},
+		{&lgr.BlockRetentionPolicy{RetainFromBlockNum: 50}, 100, 50},
+		{&lgr.BlockRetentionPolicy{RetainFromBlockNum: 500}, 100, 99},
+		{&lgr.BlockRetentionPolicy{RetainLastNBlocks: 10, RetainFromBlockNum: 50}, 100, 50},
+		{&lgr.BlockRetentionPolicy{RetainLastNBlocks: 60, RetainFromBlockNum: 50}, 100, 40},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.boundary, retentionBoundary(tc.policy, tc.height), "policy %+v, height %d", tc.policy, tc.height)
+	}
+}
+
 func TestLedgerWithCouchDbEnabledWithBinaryAndJSONData(t *testing.T) {
 
 	//call a helper method to load the core.yaml
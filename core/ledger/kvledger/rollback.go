@@ -0,0 +1,143 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"os"
+
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/hyperledger/fabric/core/ledger/ledgerstorage"
+	"github.com/pkg/errors"
+)
+
+// RollbackKVLedger rolls back the given ledger to the block `blockNum`. The blocks after this block are removed
+// from the block store and the databases that are derived from the blocks (i.e., the state, history, config
+// history, and bookkeeping databases) are dropped. These databases are rebuilt from the block stores by the
+// recovery of the ledgers on the next start of the peer. As these databases are shared across the ledgers,
+// the rollback is allowed only if every ledger on the peer can be rebuilt from its genesis block.
+// This function is expected to be invoked while the peer is stopped
+func RollbackKVLedger(ledgerID string, blockNum uint64) error {
+	fileLock, err := acquireFileLock()
+	if err != nil {
+		return err
+	}
+	defer fileLock.Unlock()
+
+	ledgerIDs, err := ledgerIDsToRebuild()
+	if err != nil {
+		return err
+	}
+	if !contains(ledgerIDs, ledgerID) {
+		return errors.Errorf("ledger [%s] does not exist", ledgerID)
+	}
+	logger.Infof("Rolling back ledger [%s] to block [%d]", ledgerID, blockNum)
+	if err := ledgerstorage.Rollback(ledgerID, blockNum); err != nil {
+		return err
+	}
+	if err := dropDerivedDBs(); err != nil {
+		return err
+	}
+	logger.Infof("Ledger [%s] rolled back to block [%d]", ledgerID, blockNum)
+	return nil
+}
+
+// ResetAllKVLedgers rolls back all the ledgers to their genesis blocks. As with the function
+// `RollbackKVLedger`, the derived databases are dropped and rebuilt on the next start of the peer.
+// This function is expected to be invoked while the peer is stopped
+func ResetAllKVLedgers() error {
+	fileLock, err := acquireFileLock()
+	if err != nil {
+		return err
+	}
+	defer fileLock.Unlock()
+
+	ledgerIDs, err := ledgerIDsToRebuild()
+	if err != nil {
+		return err
+	}
+	for _, ledgerID := range ledgerIDs {
+		logger.Infof("Resetting ledger [%s] to the genesis block", ledgerID)
+		if err := ledgerstorage.Rollback(ledgerID, 0); err != nil {
+			return err
+		}
+	}
+	if err := dropDerivedDBs(); err != nil {
+		return err
+	}
+	logger.Infof("All ledgers reset to the genesis block")
+	return nil
+}
+
+func acquireFileLock() (*leveldbhelper.FileLock, error) {
+	fileLock := leveldbhelper.NewFileLock(ledgerconfig.GetFileLockPath())
+	if err := fileLock.Lock(); err != nil {
+		return nil, errors.WithMessage(err, "as another peer node command is executing,"+
+			" wait for that command to complete its execution or terminate it before retrying")
+	}
+	return fileLock, nil
+}
+
+// ledgerIDsToRebuild returns the ids of all the ledgers on the peer after verifying that the
+// derived databases can be dropped and rebuilt for each of these ledgers
+func ledgerIDsToRebuild() ([]string, error) {
+	if ledgerconfig.IsCouchDBEnabled() {
+		return nil, errors.New("rollback is not supported when CouchDB is used as the state database")
+	}
+	if stateDatabase := ledgerconfig.GetStateDatabase(); stateDatabase != ledgerconfig.StateDatabaseGoLevelDB {
+		return nil, errors.Errorf("rollback is not supported when the registered state database [%s] is used", stateDatabase)
+	}
+	idStore := openIDStore(ledgerconfig.GetLedgerProviderPath())
+	ledgerIDs, err := idStore.getAllLedgerIds()
+	idStore.close()
+	if err != nil {
+		return nil, err
+	}
+
+	ledgerStoreProvider := ledgerstorage.NewProvider()
+	defer ledgerStoreProvider.Close()
+	for _, ledgerID := range ledgerIDs {
+		store, err := ledgerStoreProvider.Open(ledgerID)
+		if err != nil {
+			return nil, err
+		}
+		firstBlockNum, err := store.FirstBlockNumber()
+		store.Shutdown()
+		if err != nil {
+			return nil, err
+		}
+		if firstBlockNum > 0 {
+			return nil, errors.Errorf("the databases of ledger [%s] cannot be rebuilt as the blocks below block [%d] are not available",
+				ledgerID, firstBlockNum)
+		}
+	}
+	return ledgerIDs, nil
+}
+
+func dropDerivedDBs() error {
+	for _, dbPath := range []string{
+		ledgerconfig.GetStateLevelDBPath(),
+		ledgerconfig.GetHistoryLevelDBPath(),
+		ledgerconfig.GetConfigHistoryPath(),
+		ledgerconfig.GetInternalBookkeeperPath(),
+	} {
+		logger.Infof("Dropping database [%s]", dbPath)
+		if err := os.RemoveAll(dbPath); err != nil {
+			return errors.Wrapf(err, "error dropping database [%s]", dbPath)
+		}
+	}
+	return nil
+}
+
+func contains(ledgerIDs []string, ledgerID string) bool {
+	for _, id := range ledgerIDs {
+		if id == ledgerID {
+			return true
+		}
+	}
+	return false
+}
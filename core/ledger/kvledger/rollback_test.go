@@ -0,0 +1,151 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	lgr "github.com/hyperledger/fabric/core/ledger"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollbackKVLedger(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProviderWithCollectionConfig(t, "ns", map[string]uint64{"coll": 0})
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	l, err := provider.Create(gb)
+	assert.NoError(t, err)
+	var blocksAndPvtData []*lgr.BlockAndPvtData
+	for i := 1; i <= 4; i++ {
+		blockAndPvtData := prepareNextBlockForTest(t, l, bg, fmt.Sprintf("SimulateForBlk%d", i),
+			map[string]string{"key1": fmt.Sprintf("value1.%d", i)},
+			map[string]string{"key1": fmt.Sprintf("pvtValue1.%d", i)})
+		assert.NoError(t, l.CommitWithPvtData(blockAndPvtData))
+		blocksAndPvtData = append(blocksAndPvtData, blockAndPvtData)
+	}
+
+	// rollback is not allowed while the ledger is in use
+	err = RollbackKVLedger("testLedger", 2)
+	assert.Contains(t, err.Error(), "as another peer node command is executing")
+	l.Close()
+	provider.Close()
+
+	assert.EqualError(t, RollbackKVLedger("non-existing-ledger", 2), "ledger [non-existing-ledger] does not exist")
+	assert.NoError(t, RollbackKVLedger("testLedger", 2))
+
+	// the state is rebuilt from the remaining blocks when the ledger is opened
+	provider = testutilNewProviderWithCollectionConfig(t, "ns", map[string]uint64{"coll": 0})
+	defer provider.Close()
+	l, err = provider.Open("testLedger")
+	assert.NoError(t, err)
+	defer l.Close()
+	bcInfo, err := l.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), bcInfo.Height)
+	checkStateForRollbackTest(t, l, "value1.2", "pvtValue1.2")
+
+	// the removed blocks can be committed again
+	for _, blockAndPvtData := range blocksAndPvtData[2:] {
+		assert.NoError(t, l.CommitWithPvtData(blockAndPvtData))
+	}
+	bcInfo, err = l.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), bcInfo.Height)
+	checkStateForRollbackTest(t, l, "value1.4", "pvtValue1.4")
+}
+
+func TestResetAllKVLedgers(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProviderWithCollectionConfig(t, "ns", map[string]uint64{"coll": 0})
+	for _, ledgerID := range []string{"ledger1", "ledger2"} {
+		bg, gb := testutil.NewBlockGenerator(t, ledgerID, false)
+		l, err := provider.Create(gb)
+		assert.NoError(t, err)
+		for i := 1; i <= 3; i++ {
+			assert.NoError(t, l.CommitWithPvtData(prepareNextBlockForTest(t, l, bg, fmt.Sprintf("SimulateForBlk%d", i),
+				map[string]string{"key1": fmt.Sprintf("value1.%d", i)},
+				map[string]string{"key1": fmt.Sprintf("pvtValue1.%d", i)})))
+		}
+		l.Close()
+	}
+	provider.Close()
+
+	// a registered state database cannot be dropped and rebuilt
+	viper.Set("ledger.state.stateDatabase", "sqldb")
+	assert.EqualError(t, ResetAllKVLedgers(), "rollback is not supported when the registered state database [sqldb] is used")
+	viper.Set("ledger.state.stateDatabase", "goleveldb")
+	assert.NoError(t, ResetAllKVLedgers())
+
+	provider = testutilNewProviderWithCollectionConfig(t, "ns", map[string]uint64{"coll": 0})
+	defer provider.Close()
+	for _, ledgerID := range []string{"ledger1", "ledger2"} {
+		l, err := provider.Open(ledgerID)
+		assert.NoError(t, err)
+		bcInfo, err := l.GetBlockchainInfo()
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(1), bcInfo.Height)
+		qe, err := l.NewQueryExecutor()
+		assert.NoError(t, err)
+		value, err := qe.GetState("ns", "key1")
+		assert.NoError(t, err)
+		assert.Nil(t, value)
+		qe.Done()
+		l.Close()
+	}
+}
+
+func TestRollbackKVLedgerCreatedFromSnapshot(t *testing.T) {
+	snapshotDir, err := ioutil.TempDir("", "kvledger-rollback")
+	assert.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+
+	env := newTestEnv(t)
+	provider := testutilNewProvider(t)
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	l, err := provider.Create(gb)
+	assert.NoError(t, err)
+	assert.NoError(t, l.CommitWithPvtData(&lgr.BlockAndPvtData{Block: bg.NextTestBlock(1, 10)}))
+	assert.NoError(t, l.(*kvLedger).GenerateSnapshot(snapshotDir))
+	l.Close()
+	provider.Close()
+	env.cleanup()
+
+	env = newTestEnv(t)
+	defer env.cleanup()
+	provider = testutilNewProvider(t)
+	l, _, err = provider.CreateFromSnapshot(snapshotDir)
+	assert.NoError(t, err)
+	assert.NoError(t, l.CommitWithPvtData(&lgr.BlockAndPvtData{Block: bg.NextTestBlock(1, 10)}))
+	firstBlockNum, err := l.(*kvLedger).blockStore.FirstBlockNumber()
+	assert.NoError(t, err)
+	l.Close()
+	provider.Close()
+
+	err = RollbackKVLedger("testLedger", 2)
+	assert.EqualError(t, err, fmt.Sprintf("the databases of ledger [testLedger] cannot be rebuilt as the blocks below block [%d] are not available", firstBlockNum))
+	err = ResetAllKVLedgers()
+	assert.EqualError(t, err, fmt.Sprintf("the databases of ledger [testLedger] cannot be rebuilt as the blocks below block [%d] are not available", firstBlockNum))
+}
+
+func checkStateForRollbackTest(t *testing.T, l lgr.PeerLedger, expectedValue, expectedPvtValue string) {
+	qe, err := l.NewQueryExecutor()
+	assert.NoError(t, err)
+	defer qe.Done()
+	value, err := qe.GetState("ns", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(expectedValue), value)
+	pvtValue, err := qe.GetPrivateData("ns", "coll", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(expectedPvtValue), pvtValue)
+}
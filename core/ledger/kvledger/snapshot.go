@@ -0,0 +1,652 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"hash"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/confighistory"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// A snapshot is a directory that contains the following files. The metadata file is written last
+// so that its presence indicates that the snapshot was generated completely
+const (
+	snapshotMetadataFileName       = "_snapshot_metadata.json"
+	snapshotBlocksFileName         = "blocks.data"
+	snapshotPublicStateFileName    = "public_state.data"
+	snapshotPvtStateHashesFileName = "private_state_hashes.data"
+	snapshotConfigHistoryFileName  = "confighistory.data"
+)
+
+var snapshotDataFileNames = []string{
+	snapshotBlocksFileName,
+	snapshotPublicStateFileName,
+	snapshotPvtStateHashesFileName,
+	snapshotConfigHistoryFileName,
+}
+
+// snapshotMetadata is persisted in the snapshot metadata file. The hashes are hex encoded
+type snapshotMetadata struct {
+	ChannelName       string            `json:"channel_name"`
+	LastBlockNumber   uint64            `json:"last_block_number"`
+	LastBlockHash     string            `json:"last_block_hash"`
+	PreviousBlockHash string            `json:"previous_block_hash"`
+	FileHashes        map[string]string `json:"file_hashes"`
+}
+
+// GenerateSnapshot generates a snapshot of the ledger in the given directory. The directory is created if it does
+// not exist and is expected to be empty otherwise. The snapshot is taken at the boundary of the last committed block
+// and captures the public state, the hashes of the private state, the config history (which includes the history of
+// the collection configurations that drives the eligibility of this peer for the private data), the last block,
+// and the latest config block. The block commits are paused while the snapshot is being generated.
+// The history database and the private data are not included in the snapshot
+func (l *kvLedger) GenerateSnapshot(snapshotDir string) error {
+	l.blockAPIsRWLock.Lock()
+	defer l.blockAPIsRWLock.Unlock()
+
+	info, err := l.blockStore.GetBlockchainInfo()
+	if err != nil {
+		return err
+	}
+	if info.Height == 0 {
+		return errors.Errorf("cannot generate a snapshot for ledger [%s] that has no blocks", l.ledgerID)
+	}
+	lastBlockNum := info.Height - 1
+	savepoint, err := l.versionedDB.GetLatestSavePoint()
+	if err != nil {
+		return err
+	}
+	if savepoint == nil || savepoint.BlockNum != lastBlockNum {
+		return errors.Errorf("cannot generate a snapshot for ledger [%s] while the state database is not in sync with the block [%d]",
+			l.ledgerID, lastBlockNum)
+	}
+	lastBlock, lastConfigBlock, err := l.retrieveLastAndLastConfigBlocks(lastBlockNum)
+	if err != nil {
+		return err
+	}
+
+	if err := createEmptyDir(snapshotDir); err != nil {
+		return err
+	}
+	metadata := &snapshotMetadata{
+		ChannelName:       l.ledgerID,
+		LastBlockNumber:   lastBlockNum,
+		LastBlockHash:     hex.EncodeToString(info.CurrentBlockHash),
+		PreviousBlockHash: hex.EncodeToString(info.PreviousBlockHash),
+		FileHashes:        map[string]string{},
+	}
+	if err := l.exportSnapshotData(snapshotDir, metadata, lastBlock, lastConfigBlock); err != nil {
+		os.RemoveAll(snapshotDir)
+		return err
+	}
+	metadataBytes, err := json.Marshal(metadata)
+	if err != nil {
+		os.RemoveAll(snapshotDir)
+		return errors.Wrap(err, "error marshaling snapshot metadata")
+	}
+	if err := writeAndSyncFile(filepath.Join(snapshotDir, snapshotMetadataFileName), metadataBytes); err != nil {
+		os.RemoveAll(snapshotDir)
+		return err
+	}
+	logger.Infof("[%s] Generated snapshot at block [%d] in directory [%s]", l.ledgerID, lastBlockNum, snapshotDir)
+	return nil
+}
+
+func (l *kvLedger) exportSnapshotData(snapshotDir string, metadata *snapshotMetadata, lastBlock, lastConfigBlock *common.Block) error {
+	if err := exportSnapshotFile(snapshotDir, snapshotBlocksFileName, metadata, func(w *snapshotFileWriter) error {
+		return exportBlocks(w, lastBlock, lastConfigBlock)
+	}); err != nil {
+		return err
+	}
+
+	pubStateWriter, err := newSnapshotFileWriter(filepath.Join(snapshotDir, snapshotPublicStateFileName))
+	if err != nil {
+		return err
+	}
+	defer pubStateWriter.close()
+	pvtStateHashesWriter, err := newSnapshotFileWriter(filepath.Join(snapshotDir, snapshotPvtStateHashesFileName))
+	if err != nil {
+		return err
+	}
+	defer pvtStateHashesWriter.close()
+	if err := exportState(l.versionedDB, pubStateWriter, pvtStateHashesWriter); err != nil {
+		return err
+	}
+	for _, w := range []*snapshotFileWriter{pubStateWriter, pvtStateHashesWriter} {
+		fileHash, err := w.done()
+		if err != nil {
+			return err
+		}
+		metadata.FileHashes[filepath.Base(w.file.Name())] = hex.EncodeToString(fileHash)
+	}
+
+	return exportSnapshotFile(snapshotDir, snapshotConfigHistoryFileName, metadata, func(w *snapshotFileWriter) error {
+		return l.configHistoryMgr.ExportConfigHistory(l.ledgerID, func(e *confighistory.Entry) error {
+			return w.addRecord(encodeConfigHistoryEntry(e))
+		})
+	})
+}
+
+func (l *kvLedger) retrieveLastAndLastConfigBlocks(lastBlockNum uint64) (*common.Block, *common.Block, error) {
+	lastBlock, err := l.blockStore.RetrieveBlockByNumber(lastBlockNum)
+	if err != nil {
+		return nil, nil, err
+	}
+	lastConfigBlockNum, err := utils.GetLastConfigIndexFromBlock(lastBlock)
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "error retrieving the latest config block number")
+	}
+	lastConfigBlock, err := l.blockStore.RetrieveBlockByNumber(lastConfigBlockNum)
+	if err != nil {
+		return nil, nil, err
+	}
+	return lastBlock, lastConfigBlock, nil
+}
+
+// CreateFromSnapshot implements the corresponding method from interface ledger.PeerLedgerProvider. The ledger is created
+// with the channel name recorded in the snapshot and starts with the block next to the last block in the snapshot.
+// Similar to the function `Create`, the under construction flag is set for the duration of the ledger creation. The block
+// storage is bootstrapped as the last step so that the ledger is considered created only after all the data is imported
+func (provider *Provider) CreateFromSnapshot(snapshotDir string) (ledger.PeerLedger, string, error) {
+	metadata, err := loadSnapshotMetadata(snapshotDir)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := verifySnapshotFileHashes(snapshotDir, metadata); err != nil {
+		return nil, "", err
+	}
+	snapshotInfo, err := loadSnapshotBlocks(snapshotDir, metadata)
+	if err != nil {
+		return nil, "", err
+	}
+	ledgerID := metadata.ChannelName
+	exists, err := provider.idStore.ledgerIDExists(ledgerID)
+	if err != nil {
+		return nil, "", err
+	}
+	if exists {
+		return nil, "", ErrLedgerIDExists
+	}
+	if err = provider.idStore.setUnderConstructionFlag(ledgerID); err != nil {
+		return nil, "", err
+	}
+	if err := provider.importSnapshot(ledgerID, snapshotDir, snapshotInfo); err != nil {
+		logger.Errorf("Error importing the snapshot for ledger [%s]. Unsetting under construction flag. Error: %+v", ledgerID, err)
+		panicOnErr(provider.runCleanup(ledgerID), "Error running cleanup for ledger id [%s]", ledgerID)
+		panicOnErr(provider.idStore.unsetUnderConstructionFlag(), "Error while unsetting under construction flag")
+		return nil, "", err
+	}
+	lgr, err := provider.openInternal(ledgerID)
+	if err != nil {
+		return nil, "", err
+	}
+	panicOnErr(provider.idStore.createLedgerID(ledgerID, snapshotInfo.LastConfigBlock), "Error while marking ledger as created")
+	logger.Infof("Created ledger [%s] from the snapshot at block [%d]", ledgerID, metadata.LastBlockNumber)
+	return lgr, ledgerID, nil
+}
+
+// importSnapshot imports the snapshot data into the databases of the ledger. A database that already has a savepoint
+// at the last block of the snapshot is skipped, which would be the case if an earlier attempt to import the snapshot
+// was interrupted after importing the data into the database
+func (provider *Provider) importSnapshot(ledgerID string, snapshotDir string, snapshotInfo *blkstorage.BootstrappingSnapshotInfo) error {
+	lastBlockNum := snapshotInfo.LastBlock.Header.Number
+	savepoint := version.NewHeight(lastBlockNum, 0)
+
+	vDB, err := provider.vdbProvider.GetDBHandle(ledgerID)
+	if err != nil {
+		return err
+	}
+	vdbSavepoint, err := vDB.GetLatestSavePoint()
+	if err != nil {
+		return err
+	}
+	if vdbSavepoint == nil {
+		itr, err := newSnapshotStateIterator(
+			filepath.Join(snapshotDir, snapshotPublicStateFileName),
+			filepath.Join(snapshotDir, snapshotPvtStateHashesFileName),
+		)
+		if err != nil {
+			return err
+		}
+		defer itr.Close()
+		if err := vDB.ImportState(itr, savepoint); err != nil {
+			return err
+		}
+	} else if vdbSavepoint.BlockNum != lastBlockNum {
+		return errors.Errorf("state database for ledger [%s] has a savepoint at block [%d]", ledgerID, vdbSavepoint.BlockNum)
+	}
+
+	entries, err := loadConfigHistoryEntries(filepath.Join(snapshotDir, snapshotConfigHistoryFileName))
+	if err != nil {
+		return err
+	}
+	if err := provider.configHistoryMgr.ImportConfigHistory(ledgerID, entries); err != nil {
+		return err
+	}
+
+	historyDB, err := provider.historydbProvider.GetDBHandle(ledgerID)
+	if err != nil {
+		return err
+	}
+	historyDBSavepoint, err := historyDB.GetLastSavepoint()
+	if err != nil {
+		return err
+	}
+	if historyDBSavepoint == nil {
+		if err := historyDB.SetSavepoint(savepoint); err != nil {
+			return err
+		}
+	} else if historyDBSavepoint.BlockNum != lastBlockNum {
+		return errors.Errorf("history database for ledger [%s] has a savepoint at block [%d]", ledgerID, historyDBSavepoint.BlockNum)
+	}
+
+	return provider.ledgerStoreProvider.BootstrapFromSnapshot(ledgerID, snapshotInfo)
+}
+
+func exportBlocks(w *snapshotFileWriter, lastBlock, lastConfigBlock *common.Block) error {
+	for _, block := range []*common.Block{lastBlock, lastConfigBlock} {
+		blockBytes, err := proto.Marshal(block)
+		if err != nil {
+			return errors.Wrapf(err, "error marshaling block [%d]", block.Header.Number)
+		}
+		if err := w.addRecord(blockBytes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func exportState(vDB privacyenabledstate.DB, pubStateWriter, pvtStateHashesWriter *snapshotFileWriter) error {
+	itr, err := vDB.GetFullScanIterator()
+	if err != nil {
+		return err
+	}
+	defer itr.Close()
+	for {
+		kv, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if kv == nil {
+			return nil
+		}
+		w := pubStateWriter
+		if kv.CollectionName != "" {
+			w = pvtStateHashesWriter
+		}
+		if err := w.addRecord(encodeStateRecord(kv)); err != nil {
+			return err
+		}
+	}
+}
+
+func loadSnapshotMetadata(snapshotDir string) (*snapshotMetadata, error) {
+	metadataBytes, err := ioutil.ReadFile(filepath.Join(snapshotDir, snapshotMetadataFileName))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading the snapshot metadata from directory [%s]", snapshotDir)
+	}
+	metadata := &snapshotMetadata{}
+	if err := json.Unmarshal(metadataBytes, metadata); err != nil {
+		return nil, errors.Wrap(err, "error unmarshaling snapshot metadata")
+	}
+	if metadata.ChannelName == "" {
+		return nil, errors.New("channel name is missing in the snapshot metadata")
+	}
+	return metadata, nil
+}
+
+func verifySnapshotFileHashes(snapshotDir string, metadata *snapshotMetadata) error {
+	for _, fileName := range snapshotDataFileNames {
+		expectedHash, ok := metadata.FileHashes[fileName]
+		if !ok {
+			return errors.Errorf("hash of the snapshot file [%s] is missing in the snapshot metadata", fileName)
+		}
+		f, err := os.Open(filepath.Join(snapshotDir, fileName))
+		if err != nil {
+			return errors.Wrapf(err, "error opening the snapshot file [%s]", fileName)
+		}
+		h := sha256.New()
+		_, err = io.Copy(h, f)
+		f.Close()
+		if err != nil {
+			return errors.Wrapf(err, "error reading the snapshot file [%s]", fileName)
+		}
+		if hex.EncodeToString(h.Sum(nil)) != expectedHash {
+			return errors.Errorf("hash of the snapshot file [%s] does not match the hash in the snapshot metadata", fileName)
+		}
+	}
+	return nil
+}
+
+func loadSnapshotBlocks(snapshotDir string, metadata *snapshotMetadata) (*blkstorage.BootstrappingSnapshotInfo, error) {
+	r, err := newSnapshotFileReader(filepath.Join(snapshotDir, snapshotBlocksFileName))
+	if err != nil {
+		return nil, err
+	}
+	defer r.close()
+	var blocks []*common.Block
+	for i := 0; i < 2; i++ {
+		blockBytes, err := r.nextRecord()
+		if err != nil {
+			return nil, err
+		}
+		if blockBytes == nil {
+			return nil, errors.New("snapshot blocks file does not contain the last block and the latest config block")
+		}
+		block, err := utils.GetBlockFromBlockBytes(blockBytes)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, block)
+	}
+	lastBlock, lastConfigBlock := blocks[0], blocks[1]
+
+	if lastBlock.Header.Number != metadata.LastBlockNumber {
+		return nil, errors.Errorf("number of the last block in the snapshot [%d] does not match the snapshot metadata [%d]",
+			lastBlock.Header.Number, metadata.LastBlockNumber)
+	}
+	if hex.EncodeToString(lastBlock.Header.Hash()) != metadata.LastBlockHash {
+		return nil, errors.New("hash of the last block in the snapshot does not match the snapshot metadata")
+	}
+	if hex.EncodeToString(lastBlock.Header.PreviousHash) != metadata.PreviousBlockHash {
+		return nil, errors.New("previous hash of the last block in the snapshot does not match the snapshot metadata")
+	}
+	lastConfigBlockNum, err := utils.GetLastConfigIndexFromBlock(lastBlock)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error retrieving the latest config block number")
+	}
+	if lastConfigBlock.Header.Number != lastConfigBlockNum {
+		return nil, errors.Errorf("number of the latest config block in the snapshot [%d] does not match the last block [%d]",
+			lastConfigBlock.Header.Number, lastConfigBlockNum)
+	}
+	channelName, err := utils.GetChainIDFromBlock(lastConfigBlock)
+	if err != nil {
+		return nil, err
+	}
+	if channelName != metadata.ChannelName {
+		return nil, errors.Errorf("channel of the latest config block in the snapshot [%s] does not match the snapshot metadata [%s]",
+			channelName, metadata.ChannelName)
+	}
+	return &blkstorage.BootstrappingSnapshotInfo{LastBlock: lastBlock, LastConfigBlock: lastConfigBlock}, nil
+}
+
+func loadConfigHistoryEntries(filePath string) ([]*confighistory.Entry, error) {
+	r, err := newSnapshotFileReader(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer r.close()
+	var entries []*confighistory.Entry
+	for {
+		record, err := r.nextRecord()
+		if err != nil {
+			return nil, err
+		}
+		if record == nil {
+			return entries, nil
+		}
+		entry, err := decodeConfigHistoryEntry(record)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+}
+
+// snapshotStateIterator implements interface privacyenabledstate.FullScanIterator
+// over the state records in one or more snapshot files
+type snapshotStateIterator struct {
+	readers []*snapshotFileReader
+}
+
+func newSnapshotStateIterator(filePaths ...string) (*snapshotStateIterator, error) {
+	itr := &snapshotStateIterator{}
+	for _, filePath := range filePaths {
+		r, err := newSnapshotFileReader(filePath)
+		if err != nil {
+			itr.Close()
+			return nil, err
+		}
+		itr.readers = append(itr.readers, r)
+	}
+	return itr, nil
+}
+
+func (itr *snapshotStateIterator) Next() (*privacyenabledstate.FullScanKV, error) {
+	for len(itr.readers) > 0 {
+		record, err := itr.readers[0].nextRecord()
+		if err != nil {
+			return nil, err
+		}
+		if record != nil {
+			return decodeStateRecord(record)
+		}
+		itr.readers[0].close()
+		itr.readers = itr.readers[1:]
+	}
+	return nil, nil
+}
+
+func (itr *snapshotStateIterator) Close() {
+	for _, r := range itr.readers {
+		r.close()
+	}
+	itr.readers = nil
+}
+
+func encodeStateRecord(kv *privacyenabledstate.FullScanKV) []byte {
+	buf := proto.NewBuffer(nil)
+	buf.EncodeStringBytes(kv.Namespace)
+	buf.EncodeStringBytes(kv.CollectionName)
+	buf.EncodeStringBytes(kv.Key)
+	buf.EncodeRawBytes(kv.Value)
+	buf.EncodeRawBytes(kv.Metadata)
+	buf.EncodeRawBytes(kv.Version.ToBytes())
+	return buf.Bytes()
+}
+
+func decodeStateRecord(b []byte) (*privacyenabledstate.FullScanKV, error) {
+	buf := proto.NewBuffer(b)
+	var fields [6][]byte
+	for i := range fields {
+		field, err := buf.DecodeRawBytes(true)
+		if err != nil {
+			return nil, errors.Wrap(err, "error decoding state record")
+		}
+		fields[i] = field
+	}
+	ver, _ := version.NewHeightFromBytes(fields[5])
+	kv := &privacyenabledstate.FullScanKV{
+		Namespace:      string(fields[0]),
+		CollectionName: string(fields[1]),
+		Key:            string(fields[2]),
+		VersionedValue: &statedb.VersionedValue{Value: fields[3], Version: ver},
+	}
+	if len(fields[4]) > 0 {
+		kv.Metadata = fields[4]
+	}
+	return kv, nil
+}
+
+func encodeConfigHistoryEntry(e *confighistory.Entry) []byte {
+	buf := proto.NewBuffer(nil)
+	buf.EncodeStringBytes(e.Namespace)
+	buf.EncodeStringBytes(e.Key)
+	buf.EncodeVarint(e.BlockNum)
+	buf.EncodeRawBytes(e.Value)
+	return buf.Bytes()
+}
+
+func decodeConfigHistoryEntry(b []byte) (*confighistory.Entry, error) {
+	buf := proto.NewBuffer(b)
+	ns, err := buf.DecodeStringBytes()
+	if err != nil {
+		return nil, errors.Wrap(err, "error decoding config history entry")
+	}
+	key, err := buf.DecodeStringBytes()
+	if err != nil {
+		return nil, errors.Wrap(err, "error decoding config history entry")
+	}
+	blockNum, err := buf.DecodeVarint()
+	if err != nil {
+		return nil, errors.Wrap(err, "error decoding config history entry")
+	}
+	value, err := buf.DecodeRawBytes(true)
+	if err != nil {
+		return nil, errors.Wrap(err, "error decoding config history entry")
+	}
+	return &confighistory.Entry{Namespace: ns, Key: key, BlockNum: blockNum, Value: value}, nil
+}
+
+// snapshotFileWriter writes length prefixed records to a snapshot file and computes the hash of the file content
+type snapshotFileWriter struct {
+	file      *os.File
+	bufWriter *bufio.Writer
+	hasher    hash.Hash
+	closed    bool
+}
+
+func newSnapshotFileWriter(filePath string) (*snapshotFileWriter, error) {
+	file, err := os.OpenFile(filePath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error creating the snapshot file [%s]", filePath)
+	}
+	hasher := sha256.New()
+	return &snapshotFileWriter{
+		file:      file,
+		bufWriter: bufio.NewWriter(io.MultiWriter(file, hasher)),
+		hasher:    hasher,
+	}, nil
+}
+
+func (w *snapshotFileWriter) addRecord(record []byte) error {
+	lenBytes := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(lenBytes, uint64(len(record)))
+	if _, err := w.bufWriter.Write(lenBytes[:n]); err != nil {
+		return errors.Wrapf(err, "error writing to the snapshot file [%s]", w.file.Name())
+	}
+	if _, err := w.bufWriter.Write(record); err != nil {
+		return errors.Wrapf(err, "error writing to the snapshot file [%s]", w.file.Name())
+	}
+	return nil
+}
+
+// done flushes and closes the file and returns the hash of the file content
+func (w *snapshotFileWriter) done() ([]byte, error) {
+	if err := w.bufWriter.Flush(); err != nil {
+		return nil, errors.Wrapf(err, "error writing to the snapshot file [%s]", w.file.Name())
+	}
+	if err := w.file.Sync(); err != nil {
+		return nil, errors.Wrapf(err, "error syncing the snapshot file [%s]", w.file.Name())
+	}
+	w.close()
+	return w.hasher.Sum(nil), nil
+}
+
+func (w *snapshotFileWriter) close() {
+	if w.closed {
+		return
+	}
+	w.file.Close()
+	w.closed = true
+}
+
+// exportSnapshotFile writes a snapshot file by invoking the given function and records the hash of the file in the metadata
+func exportSnapshotFile(snapshotDir, fileName string, metadata *snapshotMetadata, writeRecords func(*snapshotFileWriter) error) error {
+	w, err := newSnapshotFileWriter(filepath.Join(snapshotDir, fileName))
+	if err != nil {
+		return err
+	}
+	defer w.close()
+	if err := writeRecords(w); err != nil {
+		return err
+	}
+	fileHash, err := w.done()
+	if err != nil {
+		return err
+	}
+	metadata.FileHashes[fileName] = hex.EncodeToString(fileHash)
+	return nil
+}
+
+// snapshotFileReader reads the records written by a snapshotFileWriter
+type snapshotFileReader struct {
+	file      *os.File
+	bufReader *bufio.Reader
+}
+
+func newSnapshotFileReader(filePath string) (*snapshotFileReader, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error opening the snapshot file [%s]", filePath)
+	}
+	return &snapshotFileReader{file: file, bufReader: bufio.NewReader(file)}, nil
+}
+
+// nextRecord returns the next record in the file. A nil is returned once the file is exhausted
+func (r *snapshotFileReader) nextRecord() ([]byte, error) {
+	recordLen, err := binary.ReadUvarint(r.bufReader)
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading the snapshot file [%s]", r.file.Name())
+	}
+	record := make([]byte, recordLen)
+	if _, err := io.ReadFull(r.bufReader, record); err != nil {
+		return nil, errors.Wrapf(err, "error reading the snapshot file [%s]", r.file.Name())
+	}
+	return record, nil
+}
+
+func (r *snapshotFileReader) close() {
+	r.file.Close()
+}
+
+func createEmptyDir(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.Wrapf(err, "error creating the snapshot directory [%s]", dir)
+	}
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return errors.Wrapf(err, "error reading the snapshot directory [%s]", dir)
+	}
+	if len(files) != 0 {
+		return errors.Errorf("snapshot directory [%s] is not empty", dir)
+	}
+	return nil
+}
+
+func writeAndSyncFile(filePath string, content []byte) error {
+	file, err := os.OpenFile(filePath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return errors.Wrapf(err, "error creating the file [%s]", filePath)
+	}
+	defer file.Close()
+	if _, err := file.Write(content); err != nil {
+		return errors.Wrapf(err, "error writing to the file [%s]", filePath)
+	}
+	return errors.Wrapf(file.Sync(), "error syncing the file [%s]", filePath)
+}
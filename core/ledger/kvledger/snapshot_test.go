@@ -0,0 +1,219 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/util"
+	lgr "github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/confighistory"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateSnapshotAndCreateFromSnapshot(t *testing.T) {
+	snapshotDir, err := ioutil.TempDir("", "kvledger-snapshot")
+	assert.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+	snapshotDir = filepath.Join(snapshotDir, "snapshot")
+
+	// create and populate a ledger in the source environment
+	env := newTestEnv(t)
+	provider := testutilNewProviderWithCollectionConfig(t, "ns", map[string]uint64{"coll": 0})
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	sourceLedger, err := provider.Create(gb)
+	assert.NoError(t, err)
+	assert.NoError(t, sourceLedger.CommitWithPvtData(prepareNextBlockForTest(t, sourceLedger, bg, "SimulateForBlk1",
+		map[string]string{"key1": "value1.1", "key2": "value2.1"},
+		map[string]string{"key1": "pvtValue1.1"})))
+	assert.NoError(t, sourceLedger.CommitWithPvtData(prepareNextBlockForTest(t, sourceLedger, bg, "SimulateForBlk2",
+		map[string]string{"key2": "value2.2"},
+		map[string]string{"key2": "pvtValue2.2"})))
+	configHistoryEntries := []*confighistory.Entry{
+		{Namespace: "lscc", Key: "ns~collection", BlockNum: 1, Value: []byte("collection-config")},
+	}
+	assert.NoError(t, provider.(*Provider).configHistoryMgr.ImportConfigHistory("testLedger", configHistoryEntries))
+
+	assert.NoError(t, sourceLedger.(*kvLedger).GenerateSnapshot(snapshotDir))
+	err = sourceLedger.(*kvLedger).GenerateSnapshot(snapshotDir)
+	assert.EqualError(t, err, "snapshot directory ["+snapshotDir+"] is not empty")
+	sourceBCInfo, err := sourceLedger.GetBlockchainInfo()
+	assert.NoError(t, err)
+	lastBlock, err := sourceLedger.GetBlockByNumber(2)
+	assert.NoError(t, err)
+	sourceLedger.Close()
+	provider.Close()
+	env.cleanup()
+
+	// create the ledger from the snapshot in the destination environment
+	env = newTestEnv(t)
+	defer env.cleanup()
+	provider = testutilNewProviderWithCollectionConfig(t, "ns", map[string]uint64{"coll": 0})
+	l, ledgerID, err := provider.CreateFromSnapshot(snapshotDir)
+	assert.NoError(t, err)
+	assert.Equal(t, "testLedger", ledgerID)
+
+	bcInfo, err := l.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, sourceBCInfo, bcInfo)
+	b2, err := l.GetBlockByNumber(2)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(lastBlock, b2), "proto messages are not equal")
+	b0, err := l.GetBlockByNumber(0)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(gb, b0), "proto messages are not equal")
+	_, err = l.GetBlockByNumber(1)
+	assert.Equal(t, blkstorage.ErrPruned, err)
+
+	qe, err := l.NewQueryExecutor()
+	assert.NoError(t, err)
+	value, err := qe.GetState("ns", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value1.1"), value)
+	value, err = qe.GetState("ns", "key2")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value2.2"), value)
+	hash, err := qe.GetPrivateDataHash("ns", "coll", "key2")
+	assert.NoError(t, err)
+	assert.Equal(t, util.ComputeSHA256([]byte("pvtValue2.2")), hash)
+	_, err = qe.GetPrivateData("ns", "coll", "key2")
+	assert.IsType(t, &txmgr.ErrPvtdataNotAvailable{}, err)
+	qe.Done()
+
+	var importedEntries []*confighistory.Entry
+	assert.NoError(t, provider.(*Provider).configHistoryMgr.ExportConfigHistory(ledgerID, func(e *confighistory.Entry) error {
+		importedEntries = append(importedEntries, e)
+		return nil
+	}))
+	assert.Equal(t, configHistoryEntries, importedEntries)
+
+	// the ledger continues with the block next to the last block in the snapshot
+	assert.NoError(t, l.CommitWithPvtData(prepareNextBlockForTest(t, l, bg, "SimulateForBlk3",
+		map[string]string{"key1": "value1.3"},
+		map[string]string{"key1": "pvtValue1.3"})))
+	bcInfo, err = l.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4), bcInfo.Height)
+	l.Close()
+
+	_, _, err = provider.CreateFromSnapshot(snapshotDir)
+	assert.Equal(t, ErrLedgerIDExists, err)
+	provider.Close()
+
+	provider = testutilNewProviderWithCollectionConfig(t, "ns", map[string]uint64{"coll": 0})
+	defer provider.Close()
+	l, err = provider.Open(ledgerID)
+	assert.NoError(t, err)
+	defer l.Close()
+	bcInfo, err = l.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4), bcInfo.Height)
+	qe, err = l.NewQueryExecutor()
+	assert.NoError(t, err)
+	defer qe.Done()
+	value, err = qe.GetPrivateData("ns", "coll", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("pvtValue1.3"), value)
+}
+
+func TestCreateFromSnapshotErrors(t *testing.T) {
+	snapshotDir, err := ioutil.TempDir("", "kvledger-snapshot")
+	assert.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+
+	env := newTestEnv(t)
+	provider := testutilNewProvider(t)
+	_, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	l, err := provider.Create(gb)
+	assert.NoError(t, err)
+	assert.NoError(t, l.(*kvLedger).GenerateSnapshot(snapshotDir))
+	l.Close()
+	provider.Close()
+	env.cleanup()
+
+	env = newTestEnv(t)
+	defer env.cleanup()
+	provider = testutilNewProvider(t)
+	defer provider.Close()
+
+	t.Run("missing-metadata", func(t *testing.T) {
+		_, _, err := provider.CreateFromSnapshot(filepath.Join(snapshotDir, "non-existing"))
+		assert.Contains(t, err.Error(), "error reading the snapshot metadata from directory")
+	})
+
+	t.Run("tampered-file", func(t *testing.T) {
+		filePath := filepath.Join(snapshotDir, snapshotPublicStateFileName)
+		content, err := ioutil.ReadFile(filePath)
+		assert.NoError(t, err)
+		assert.NoError(t, ioutil.WriteFile(filePath, append(content, 0x01), 0644))
+		defer ioutil.WriteFile(filePath, content, 0644)
+
+		_, _, err = provider.CreateFromSnapshot(snapshotDir)
+		assert.EqualError(t, err, "hash of the snapshot file [public_state.data] does not match the hash in the snapshot metadata")
+		exists, err := provider.Exists("testLedger")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
+func TestRecoveryOfLedgerCreatedFromSnapshot(t *testing.T) {
+	snapshotDir, err := ioutil.TempDir("", "kvledger-snapshot")
+	assert.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+
+	env := newTestEnv(t)
+	provider := testutilNewProvider(t)
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	l, err := provider.Create(gb)
+	assert.NoError(t, err)
+	assert.NoError(t, l.CommitWithPvtData(&lgr.BlockAndPvtData{Block: bg.NextTestBlock(1, 10)}))
+	assert.NoError(t, l.(*kvLedger).GenerateSnapshot(snapshotDir))
+	l.Close()
+	provider.Close()
+	env.cleanup()
+
+	env = newTestEnv(t)
+	defer env.cleanup()
+	provider = testutilNewProvider(t)
+
+	// assume a crash happens after the snapshot is imported but before the ledger is marked as created
+	metadata, err := loadSnapshotMetadata(snapshotDir)
+	assert.NoError(t, err)
+	snapshotInfo, err := loadSnapshotBlocks(snapshotDir, metadata)
+	assert.NoError(t, err)
+	assert.NoError(t, provider.(*Provider).idStore.setUnderConstructionFlag("testLedger"))
+	assert.NoError(t, provider.(*Provider).importSnapshot("testLedger", snapshotDir, snapshotInfo))
+	provider.Close()
+
+	// construct a new provider to invoke recovery
+	provider = testutilNewProvider(t)
+	defer provider.Close()
+	flag, err := provider.(*Provider).idStore.getUnderConstructionFlag()
+	assert.NoError(t, err)
+	assert.Equal(t, "", flag)
+	l, err = provider.Open("testLedger")
+	assert.NoError(t, err)
+	defer l.Close()
+	bcInfo, err := l.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), bcInfo.Height)
+
+	s := provider.(*Provider).idStore
+	configBlockBytes, err := s.db.Get(s.encodeLedgerKey("testLedger"))
+	assert.NoError(t, err)
+	configBlock := &common.Block{}
+	assert.NoError(t, proto.Unmarshal(configBlockBytes, configBlock))
+	assert.True(t, proto.Equal(gb, configBlock), "proto messages are not equal")
+}
@@ -8,12 +8,14 @@ package privacyenabledstate
 
 import (
 	"encoding/base64"
+	"fmt"
 	"strings"
 
 	"github.com/hyperledger/fabric-lib-go/healthz"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/handlers/statedatabase/api"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
@@ -31,8 +33,25 @@ const (
 	nsJoiner       = "$$"
 	pvtDataPrefix  = "p"
 	hashDataPrefix = "h"
+
+	// importBatchSize is the maximum number of key-values that are written to the db in a single batch while importing state
+	importBatchSize = 10000
 )
 
+// stateDBProviderFactories contains the factories of the state databases registered via the
+// peer.handlers.stateDatabases config, keyed by the name of the state database
+var stateDBProviderFactories = map[string]statedatabase.PluginFactory{}
+
+// RegisterStateDBProviderFactories registers the factories of the state databases that can be selected
+// via the ledger.state.stateDatabase config in addition to the built-in goleveldb and CouchDB.
+// This function is expected to be invoked only during ledgermgmt.Initialize() function.
+func RegisterStateDBProviderFactories(factories map[string]statedatabase.PluginFactory) {
+	stateDBProviderFactories = map[string]statedatabase.PluginFactory{}
+	for name, factory := range factories {
+		stateDBProviderFactories[name] = factory
+	}
+}
+
 // CommonStorageDBProvider implements interface DBProvider
 type CommonStorageDBProvider struct {
 	statedb.VersionedDBProvider
@@ -42,14 +61,9 @@ type CommonStorageDBProvider struct {
 
 // NewCommonStorageDBProvider constructs an instance of DBProvider
 func NewCommonStorageDBProvider(bookkeeperProvider bookkeeping.Provider, metricsProvider metrics.Provider, healthCheckRegistry ledger.HealthCheckRegistry) (DBProvider, error) {
-	var vdbProvider statedb.VersionedDBProvider
-	var err error
-	if ledgerconfig.IsCouchDBEnabled() {
-		if vdbProvider, err = statecouchdb.NewVersionedDBProvider(metricsProvider); err != nil {
-			return nil, err
-		}
-	} else {
-		vdbProvider = stateleveldb.NewVersionedDBProvider()
+	vdbProvider, err := newVersionedDBProvider(ledgerconfig.GetStateDatabase(), metricsProvider)
+	if err != nil {
+		return nil, err
 	}
 
 	dbProvider := &CommonStorageDBProvider{vdbProvider, healthCheckRegistry, bookkeeperProvider}
@@ -62,9 +76,35 @@ func NewCommonStorageDBProvider(bookkeeperProvider bookkeeping.Provider, metrics
 	return dbProvider, nil
 }
 
+// newVersionedDBProvider constructs the provider of the given built-in or registered state database
+func newVersionedDBProvider(stateDatabase string, metricsProvider metrics.Provider) (statedb.VersionedDBProvider, error) {
+	switch stateDatabase {
+	case ledgerconfig.StateDatabaseGoLevelDB:
+		return stateleveldb.NewVersionedDBProvider(), nil
+	case ledgerconfig.StateDatabaseCouchDB:
+		return statecouchdb.NewVersionedDBProvider(metricsProvider)
+	}
+	factory, ok := stateDBProviderFactories[stateDatabase]
+	if !ok {
+		return nil, errors.Errorf("state database [%s] is not registered", stateDatabase)
+	}
+	vdbProvider, err := factory.New(&statedatabase.Initializer{
+		DBPath:          ledgerconfig.GetStateDatabasePath(stateDatabase),
+		MetricsProvider: metricsProvider,
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("error creating the provider of state database [%s]", stateDatabase))
+	}
+	return vdbProvider, nil
+}
+
 func (p *CommonStorageDBProvider) RegisterHealthChecker() error {
 	if healthChecker, ok := p.VersionedDBProvider.(healthz.HealthChecker); ok {
-		return p.HealthCheckRegistry.RegisterChecker("couchdb", healthChecker)
+		name := ledgerconfig.GetStateDatabase()
+		if _, ok := p.VersionedDBProvider.(*statecouchdb.VersionedDBProvider); ok {
+			name = "couchdb"
+		}
+		return p.HealthCheckRegistry.RegisterChecker(name, healthChecker)
 	}
 	return nil
 }
@@ -221,6 +261,58 @@ func (s *CommonStorageDB) ApplyPrivacyAwareUpdates(updates *UpdateBatch, height
 	return s.VersionedDB.ApplyUpdates(combinedUpdates.UpdateBatch, height)
 }
 
+// GetFullScanIterator implements corresponding function in interface DB. The returned iterator skips the private data
+func (s *CommonStorageDB) GetFullScanIterator() (FullScanIterator, error) {
+	fullScannable, ok := s.VersionedDB.(statedb.FullScannable)
+	if !ok {
+		return nil, errors.New("full scan is not supported by the state database")
+	}
+	dbItr, err := fullScannable.GetFullScanIterator(isPvtDataNs)
+	if err != nil {
+		return nil, err
+	}
+	return &fullScanIterator{dbItr, !s.BytesKeySupported()}, nil
+}
+
+// ImportState implements corresponding function in interface DB. The data is expected to be imported into an empty db.
+// The data is written in multiple batches and the savepoint is recorded only after writing all the data so that
+// an interrupted import does not make the db appear consistent
+func (s *CommonStorageDB) ImportState(itr FullScanIterator, savepoint *version.Height) error {
+	existingSavepoint, err := s.GetLatestSavePoint()
+	if err != nil {
+		return err
+	}
+	if existingSavepoint != nil {
+		return errors.Errorf("cannot import state into a db that has a savepoint at block [%d]", existingSavepoint.BlockNum)
+	}
+	batch := NewUpdateBatch()
+	batchSize := 0
+	for {
+		kv, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if kv == nil {
+			break
+		}
+		if kv.CollectionName == "" {
+			batch.PubUpdates.PutValAndMetadata(kv.Namespace, kv.Key, kv.Value, kv.Metadata, kv.Version)
+		} else {
+			batch.HashUpdates.PutValHashAndMetadata(kv.Namespace, kv.CollectionName, []byte(kv.Key), kv.Value, kv.Metadata, kv.Version)
+		}
+		batchSize++
+		if batchSize < importBatchSize {
+			continue
+		}
+		if err := s.ApplyPrivacyAwareUpdates(batch, nil); err != nil {
+			return err
+		}
+		batch = NewUpdateBatch()
+		batchSize = 0
+	}
+	return s.ApplyPrivacyAwareUpdates(batch, savepoint)
+}
+
 // GetStateMetadata implements corresponding function in interface DB. This implementation provides
 // an optimization such that it keeps track if a namespaces has never stored metadata for any of
 // its items, the value 'nil' is returned without going to the db. This is intented to be invoked
@@ -265,7 +357,7 @@ func (s *CommonStorageDB) HandleChaincodeDeploy(chaincodeDefinition *cceventmgmt
 	if chaincodeDefinition == nil {
 		return errors.New("chaincode definition not found while creating couchdb index")
 	}
-	dbArtifacts, err := ccprovider.ExtractFileEntries(dbArtifactsTar, indexCapable.GetDBType())
+	dbArtifacts, err := ccprovider.ExtractFileEntries(dbArtifactsTar, statedb.IndexArtifactsDBType)
 	if err != nil {
 		logger.Errorf("Index creation: error extracting db artifacts from tar for chaincode [%s]: %s", chaincodeDefinition.Name, err)
 		return nil
@@ -320,6 +412,20 @@ func deriveHashedDataNs(namespace, collection string) string {
 	return namespace + nsJoiner + hashDataPrefix + collection
 }
 
+func isPvtDataNs(ns string) bool {
+	return strings.Contains(ns, nsJoiner+pvtDataPrefix)
+}
+
+// splitHashedDataNs returns the namespace and the collection name encoded in the given namespace
+// of the hashed data. The last return value is false if the given namespace is not of the hashed data
+func splitHashedDataNs(ns string) (string, string, bool) {
+	split := strings.SplitN(ns, nsJoiner+hashDataPrefix, 2)
+	if len(split) != 2 {
+		return "", "", false
+	}
+	return split[0], split[1], true
+}
+
 func addPvtUpdates(pubUpdateBatch *PubUpdateBatch, pvtUpdateBatch *PvtUpdateBatch) {
 	for ns, nsBatch := range pvtUpdateBatch.UpdateMap {
 		for _, coll := range nsBatch.GetCollectionNames() {
@@ -357,3 +463,32 @@ func extractCollectionNames(chaincodeDefinition *cceventmgmt.ChaincodeDefinition
 	}
 	return collectionConfigsMap, nil
 }
+
+type fullScanIterator struct {
+	dbItr     statedb.FullScanIterator
+	base64Key bool
+}
+
+func (itr *fullScanIterator) Next() (*FullScanKV, error) {
+	dbKV, err := itr.dbItr.Next()
+	if err != nil || dbKV == nil {
+		return nil, err
+	}
+	ns, coll, isHashedData := splitHashedDataNs(dbKV.Namespace)
+	if !isHashedData {
+		return &FullScanKV{Namespace: dbKV.Namespace, Key: dbKV.Key, VersionedValue: &dbKV.VersionedValue}, nil
+	}
+	keyHash := dbKV.Key
+	if itr.base64Key {
+		keyHashBytes, err := base64.StdEncoding.DecodeString(keyHash)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error decoding the key hash [%s]", keyHash)
+		}
+		keyHash = string(keyHashBytes)
+	}
+	return &FullScanKV{Namespace: ns, CollectionName: coll, Key: keyHash, VersionedValue: &dbKV.VersionedValue}, nil
+}
+
+func (itr *fullScanIterator) Close() {
+	itr.dbItr.Close()
+}
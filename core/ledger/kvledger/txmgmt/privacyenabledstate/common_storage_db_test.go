@@ -7,13 +7,23 @@ SPDX-License-Identifier: Apache-2.0
 package privacyenabledstate_test
 
 import (
+	"context"
+	"os"
 	"testing"
 
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/core/handlers/statedatabase/api"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/commontests"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statecouchdb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/hyperledger/fabric/core/ledger/mock"
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
 )
 
 func TestHealthCheckRegister(t *testing.T) {
@@ -38,3 +48,71 @@ func TestHealthCheckRegister(t *testing.T) {
 	gt.Expect(arg1).To(Equal("couchdb"))
 	gt.Expect(arg2).NotTo(Equal(nil))
 }
+
+// testStateDBFactory creates a state database that is backed by goleveldb, as an alternative store would be
+type testStateDBFactory struct {
+	initializer *statedatabase.Initializer
+	err         error
+}
+
+func (f *testStateDBFactory) New(initializer *statedatabase.Initializer) (statedb.VersionedDBProvider, error) {
+	f.initializer = initializer
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &testVersionedDBProvider{stateleveldb.NewVersionedDBProvider()}, nil
+}
+
+type testVersionedDBProvider struct {
+	*stateleveldb.VersionedDBProvider
+}
+
+func (p *testVersionedDBProvider) HealthCheck(ctx context.Context) error {
+	return nil
+}
+
+func TestRegisteredStateDatabase(t *testing.T) {
+	gt := NewGomegaWithT(t)
+	defer viper.Set("ledger.state.stateDatabase", "")
+	defer privacyenabledstate.RegisterStateDBProviderFactories(nil)
+	gt.Expect(os.RemoveAll(ledgerconfig.GetStateLevelDBPath())).To(Succeed())
+	defer os.RemoveAll(ledgerconfig.GetStateLevelDBPath())
+	bookkeeperTestEnv := bookkeeping.NewTestEnv(t)
+	defer bookkeeperTestEnv.Cleanup()
+
+	factory := &testStateDBFactory{}
+	privacyenabledstate.RegisterStateDBProviderFactories(map[string]statedatabase.PluginFactory{"teststatedb": factory})
+	viper.Set("ledger.state.stateDatabase", "teststatedb")
+	fakeHealthCheckRegistry := &mock.HealthCheckRegistry{}
+	dbProvider, err := privacyenabledstate.NewCommonStorageDBProvider(bookkeeperTestEnv.TestProvider, &disabled.Provider{}, fakeHealthCheckRegistry)
+	gt.Expect(err).NotTo(HaveOccurred())
+	defer dbProvider.Close()
+
+	gt.Expect(factory.initializer.DBPath).To(Equal(ledgerconfig.GetStateDatabasePath("teststatedb")))
+	gt.Expect(factory.initializer.MetricsProvider).To(Equal(&disabled.Provider{}))
+	gt.Expect(fakeHealthCheckRegistry.RegisterCheckerCallCount()).To(Equal(1))
+	name, _ := fakeHealthCheckRegistry.RegisterCheckerArgsForCall(0)
+	gt.Expect(name).To(Equal("teststatedb"))
+
+	vdbProvider := dbProvider.(*privacyenabledstate.CommonStorageDBProvider).VersionedDBProvider
+	gt.Expect(vdbProvider).To(BeAssignableToTypeOf(&testVersionedDBProvider{}))
+	commontests.TestVersionedDBProvider(t, vdbProvider)
+}
+
+func TestRegisteredStateDatabaseErrors(t *testing.T) {
+	gt := NewGomegaWithT(t)
+	defer viper.Set("ledger.state.stateDatabase", "")
+	defer privacyenabledstate.RegisterStateDBProviderFactories(nil)
+	bookkeeperTestEnv := bookkeeping.NewTestEnv(t)
+	defer bookkeeperTestEnv.Cleanup()
+
+	viper.Set("ledger.state.stateDatabase", "teststatedb")
+	_, err := privacyenabledstate.NewCommonStorageDBProvider(bookkeeperTestEnv.TestProvider, &disabled.Provider{}, &mock.HealthCheckRegistry{})
+	gt.Expect(err).To(MatchError("state database [teststatedb] is not registered"))
+
+	privacyenabledstate.RegisterStateDBProviderFactories(map[string]statedatabase.PluginFactory{
+		"teststatedb": &testStateDBFactory{err: errors.New("connection refused")},
+	})
+	_, err = privacyenabledstate.NewCommonStorageDBProvider(bookkeeperTestEnv.TestProvider, &disabled.Provider{}, &mock.HealthCheckRegistry{})
+	gt.Expect(err).To(MatchError("error creating the provider of state database [teststatedb]: connection refused"))
+}
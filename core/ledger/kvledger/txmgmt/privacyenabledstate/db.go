@@ -39,6 +39,25 @@ type DB interface {
 	GetPrivateDataMetadataByHash(namespace, collection string, keyHash []byte) ([]byte, error)
 	ExecuteQueryOnPrivateData(namespace, collection, query string) (statedb.ResultsIterator, error)
 	ApplyPrivacyAwareUpdates(updates *UpdateBatch, height *version.Height) error
+	GetFullScanIterator() (FullScanIterator, error)
+	ImportState(itr FullScanIterator, savepoint *version.Height) error
+}
+
+// FullScanIterator iterates over the public data and the hashes of the private data of all the namespaces
+type FullScanIterator interface {
+	// Next returns the next key-value. A nil is returned once the iterator is exhausted
+	Next() (*FullScanKV, error)
+	// Close releases any resources held by the iterator
+	Close()
+}
+
+// FullScanKV encloses a key-value of either the public data or the hashes of the private data.
+// For the hashes of the private data, CollectionName is non-empty and Key contains the key hash
+type FullScanKV struct {
+	Namespace      string
+	CollectionName string
+	Key            string
+	*statedb.VersionedValue
 }
 
 // PvtdataCompositeKey encloses Namespace, CollectionName and Key components
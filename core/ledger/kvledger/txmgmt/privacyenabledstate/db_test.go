@@ -572,6 +572,81 @@ func testMetadataRetrieval(t *testing.T, env TestEnv) {
 	assert.Nil(t, vm)
 }
 
+func TestFullScanAndImportState(t *testing.T) {
+	env := &LevelDBCommonStorageTestEnv{}
+	env.Init(t)
+	defer env.Cleanup()
+	sourceDB := env.GetDBHandle("source-ledger-id")
+
+	updates := NewUpdateBatch()
+	updates.PubUpdates.PutValAndMetadata("ns1", "key1", []byte("value1"), []byte("metadata1"), version.NewHeight(1, 1))
+	updates.PubUpdates.Put("ns2", "key2", []byte("value2"), version.NewHeight(1, 2))
+	putPvtUpdatesWithMetadata(t, updates, "ns1", "coll1", "key1", []byte("pvt_value1"), []byte("metadata1"), version.NewHeight(1, 3))
+	putPvtUpdates(t, updates, "ns2", "coll2", "key2", []byte("pvt_value2"), version.NewHeight(1, 4))
+	assert.NoError(t, sourceDB.ApplyPrivacyAwareUpdates(updates, version.NewHeight(1, 4)))
+
+	itr, err := sourceDB.GetFullScanIterator()
+	assert.NoError(t, err)
+	var kvs []*FullScanKV
+	for {
+		kv, err := itr.Next()
+		assert.NoError(t, err)
+		if kv == nil {
+			break
+		}
+		kvs = append(kvs, kv)
+	}
+	itr.Close()
+	// the private data is not included in the scan and the key hashes are returned for the hashed data
+	var scannedKeys []HashedCompositeKey
+	for _, kv := range kvs {
+		scannedKeys = append(scannedKeys, HashedCompositeKey{kv.Namespace, kv.CollectionName, kv.Key})
+	}
+	assert.ElementsMatch(t, []HashedCompositeKey{
+		{"ns1", "", "key1"},
+		{"ns1", "coll1", string(util.ComputeStringHash("key1"))},
+		{"ns2", "", "key2"},
+		{"ns2", "coll2", string(util.ComputeStringHash("key2"))},
+	}, scannedKeys)
+
+	destDB := env.GetDBHandle("dest-ledger-id")
+	assert.NoError(t, destDB.ImportState(&sliceFullScanIterator{kvs: kvs}, version.NewHeight(1, 4)))
+	savepoint, err := destDB.GetLatestSavePoint()
+	assert.NoError(t, err)
+	assert.Equal(t, version.NewHeight(1, 4), savepoint)
+
+	vv, err := destDB.GetState("ns1", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, &statedb.VersionedValue{Value: []byte("value1"), Metadata: []byte("metadata1"), Version: version.NewHeight(1, 1)}, vv)
+	vv, err = destDB.GetValueHash("ns2", "coll2", util.ComputeStringHash("key2"))
+	assert.NoError(t, err)
+	assert.Equal(t, util.ComputeHash([]byte("pvt_value2")), vv.Value)
+	metadata, err := destDB.GetPrivateDataMetadataByHash("ns1", "coll1", util.ComputeStringHash("key1"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("metadata1"), metadata)
+	vv, err = destDB.GetPrivateData("ns1", "coll1", "key1")
+	assert.NoError(t, err)
+	assert.Nil(t, vv)
+
+	err = destDB.ImportState(&sliceFullScanIterator{kvs: kvs}, version.NewHeight(1, 4))
+	assert.EqualError(t, err, "cannot import state into a db that has a savepoint at block [1]")
+}
+
+type sliceFullScanIterator struct {
+	kvs []*FullScanKV
+}
+
+func (itr *sliceFullScanIterator) Next() (*FullScanKV, error) {
+	if len(itr.kvs) == 0 {
+		return nil, nil
+	}
+	kv := itr.kvs[0]
+	itr.kvs = itr.kvs[1:]
+	return kv, nil
+}
+
+func (itr *sliceFullScanIterator) Close() {}
+
 func putPvtUpdates(t *testing.T, updates *UpdateBatch, ns, coll, key string, value []byte, ver *version.Height) {
 	updates.PvtUpdates.Put(ns, coll, key, value, ver)
 	updates.HashUpdates.Put(ns, coll, util.ComputeStringHash(key), util.ComputeHash(value), ver)
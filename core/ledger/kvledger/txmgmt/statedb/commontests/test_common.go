@@ -966,3 +966,28 @@ func TestApplyUpdatesWithNilHeight(t *testing.T, dbProvider statedb.VersionedDBP
 	assert.Equal(t, savePoint, ht) // savepoint should still be what was set with batch1
 	// (because batch2 calls ApplyUpdates with savepoint as nil)
 }
+
+// TestVersionedDBProvider runs the tests that are applicable to any state database against the given provider,
+// which allows a state database registered via the peer.handlers.stateDatabases config to be verified. State
+// databases that support rich queries are expected to be verified with TestQuery in addition
+func TestVersionedDBProvider(t *testing.T, dbProvider statedb.VersionedDBProvider) {
+	tests := []struct {
+		name string
+		test func(*testing.T, statedb.VersionedDBProvider)
+	}{
+		{"GetStateMultipleKeys", TestGetStateMultipleKeys},
+		{"BasicRW", TestBasicRW},
+		{"MultiDBBasicRW", TestMultiDBBasicRW},
+		{"Deletes", TestDeletes},
+		{"Iterator", TestIterator},
+		{"GetVersion", TestGetVersion},
+		{"ValueAndMetadataWrites", TestValueAndMetadataWrites},
+		{"PaginatedRangeQuery", TestPaginatedRangeQuery},
+		{"ApplyUpdatesWithNilHeight", TestApplyUpdatesWithNilHeight},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.test(t, dbProvider)
+		})
+	}
+}
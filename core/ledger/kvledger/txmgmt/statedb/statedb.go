@@ -81,6 +81,10 @@ type BulkOptimizable interface {
 	ClearCachedVersions()
 }
 
+// IndexArtifactsDBType is the database type under which the index definitions are packaged in a chaincode,
+// i.e. META-INF/statedb/couchdb. All the index capable databases process the index definitions of this directory
+const IndexArtifactsDBType = "couchdb"
+
 //IndexCapable interface provides additional functions for
 //databases capable of index operations
 type IndexCapable interface {
@@ -88,6 +92,22 @@ type IndexCapable interface {
 	ProcessIndexesForChaincodeDeploy(namespace string, fileEntries []*ccprovider.TarFileEntry) error
 }
 
+//FullScannable interface provides additional functions for
+//databases capable of iterating over the keys of all the namespaces
+type FullScannable interface {
+	// GetFullScanIterator returns an iterator over all the keys in the db, sorted by namespace and key.
+	// The namespaces for which the function skipNamespace returns true are excluded
+	GetFullScanIterator(skipNamespace func(namespace string) bool) (FullScanIterator, error)
+}
+
+// FullScanIterator iterates over the key-values of all the namespaces
+type FullScanIterator interface {
+	// Next returns the next key-value. A nil is returned once the iterator is exhausted
+	Next() (*VersionedKV, error)
+	// Close releases any resources held by the iterator
+	Close()
+}
+
 // CompositeKey encloses Namespace and Key components
 type CompositeKey struct {
 	Namespace string
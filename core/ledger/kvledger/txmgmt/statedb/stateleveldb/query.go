@@ -0,0 +1,584 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateleveldb
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+)
+
+const optionBookmark = "bookmark"
+
+// jsonQuery is a parsed query in the CouchDB Mango query syntax. The supported query fields are `selector`,
+// `fields`, `sort`, `use_index`, and `skip`. As with CouchDB, a `limit` or a `bookmark` in the query is
+// ignored and the pagination is controlled by the query metadata instead
+type jsonQuery struct {
+	selector map[string]interface{}
+	matcher  matcher
+	fields   [][]string
+	sort     []*sortField
+	useIndex []string
+	skip     int
+}
+
+type sortField struct {
+	name string
+	path []string
+	desc bool
+}
+
+func parseQuery(query string) (*jsonQuery, error) {
+	queryFields := &struct {
+		Selector json.RawMessage `json:"selector"`
+		Fields   []string        `json:"fields"`
+		Sort     []interface{}   `json:"sort"`
+		UseIndex interface{}     `json:"use_index"`
+		Skip     int             `json:"skip"`
+	}{}
+	if err := json.Unmarshal([]byte(query), queryFields); err != nil {
+		return nil, errors.Wrap(err, "error parsing the query")
+	}
+	if len(queryFields.Selector) == 0 {
+		return nil, errors.New("selector is missing in the query")
+	}
+	q := &jsonQuery{skip: queryFields.Skip}
+	decoder := json.NewDecoder(bytes.NewReader(queryFields.Selector))
+	decoder.UseNumber()
+	if err := decoder.Decode(&q.selector); err != nil || q.selector == nil {
+		return nil, errors.New("selector in the query should be a JSON object")
+	}
+	var err error
+	if q.matcher, err = parseSelector(q.selector); err != nil {
+		return nil, err
+	}
+	for _, field := range queryFields.Fields {
+		path, err := splitFieldPath(field)
+		if err != nil {
+			return nil, err
+		}
+		q.fields = append(q.fields, path)
+	}
+	for _, s := range queryFields.Sort {
+		field, err := parseSortField(s)
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid sort field in the query")
+		}
+		q.sort = append(q.sort, field)
+	}
+	switch useIndex := queryFields.UseIndex.(type) {
+	case nil:
+	case string:
+		q.useIndex = []string{useIndex}
+	case []interface{}:
+		for _, u := range useIndex {
+			s, ok := u.(string)
+			if !ok {
+				return nil, errors.New("use_index in the query should be a string or an array of strings")
+			}
+			q.useIndex = append(q.useIndex, s)
+		}
+	default:
+		return nil, errors.New("use_index in the query should be a string or an array of strings")
+	}
+	if len(q.useIndex) > 2 {
+		return nil, errors.New("use_index in the query should contain a design document name and optionally an index name")
+	}
+	return q, nil
+}
+
+// parseSortField parses a field in the `sort` of a query or in the `fields` of an index definition, which is
+// either a field name or a JSON object with a field name and a direction, e.g., {"size":"desc"}
+func parseSortField(field interface{}) (*sortField, error) {
+	f := &sortField{}
+	switch v := field.(type) {
+	case string:
+		f.name = v
+	case map[string]interface{}:
+		if len(v) != 1 {
+			return nil, errors.Errorf("invalid sort field %v", v)
+		}
+		for name, direction := range v {
+			f.name = name
+			switch direction {
+			case "asc":
+			case "desc":
+				f.desc = true
+			default:
+				return nil, errors.Errorf("invalid sort direction %v for the field [%s]", direction, name)
+			}
+		}
+	default:
+		return nil, errors.Errorf("invalid sort field %v", field)
+	}
+	var err error
+	f.path, err = splitFieldPath(f.name)
+	return f, err
+}
+
+// selectIndex returns the index to be used for the query and whether the entries of the index are in the
+// requested sort order. An index is used only if all the indexed fields are to be sorted on or are
+// required to be present by the selector, as the index does not contain the values that lack any of the indexed fields
+func (q *jsonQuery) selectIndex(indexes []*queryIndex) (*queryIndex, bool) {
+	if len(q.useIndex) > 0 {
+		var specifiedIndexes []*queryIndex
+		for _, idx := range indexes {
+			if idx.matches(q.useIndex) {
+				specifiedIndexes = append(specifiedIndexes, idx)
+			}
+		}
+		if len(specifiedIndexes) == 0 {
+			logger.Warningf("Index %s specified in the query is not found, ignoring use_index", q.useIndex)
+		} else {
+			indexes = specifiedIndexes
+		}
+	}
+	var usableIndexes []*queryIndex
+	for _, idx := range indexes {
+		if q.canUseIndex(idx) {
+			usableIndexes = append(usableIndexes, idx)
+		}
+	}
+	if len(q.sort) > 0 {
+		for _, idx := range usableIndexes {
+			if q.isSortedByIndex(idx) {
+				return idx, true
+			}
+		}
+	}
+	if len(usableIndexes) == 0 {
+		return nil, false
+	}
+	return usableIndexes[0], false
+}
+
+func (q *jsonQuery) canUseIndex(idx *queryIndex) bool {
+	for _, field := range idx.fields {
+		if !q.isSortField(field) && !q.requiresField(field) {
+			return false
+		}
+	}
+	return true
+}
+
+func (q *jsonQuery) isSortedByIndex(idx *queryIndex) bool {
+	if len(q.sort) > len(idx.fields) {
+		return false
+	}
+	for i, s := range q.sort {
+		if s.desc || s.name != idx.fields[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (q *jsonQuery) isSortField(field string) bool {
+	for _, s := range q.sort {
+		if s.name == field {
+			return true
+		}
+	}
+	return false
+}
+
+// requiresField returns true if a top level condition of the selector on the given field matches only
+// the values that contain the field
+func (q *jsonQuery) requiresField(field string) bool {
+	cond, ok := q.selector[field]
+	if !ok {
+		return false
+	}
+	condMap, ok := cond.(map[string]interface{})
+	if !ok || len(condMap) == 0 {
+		return true
+	}
+	for op, arg := range condMap {
+		switch op {
+		case "$not", "$and", "$or", "$nor":
+		case "$exists":
+			if arg == true {
+				return true
+			}
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// indexRange returns the range of the index entries that may match the top level
+// conditions of the selector on the first indexed field
+func (q *jsonQuery) indexRange(idx *queryIndex) ([]byte, []byte) {
+	entriesPrefix := idx.entriesPrefix()
+	start, end := entriesPrefix, prefixEnd(entriesPrefix)
+	cond, ok := q.selector[idx.fields[0]]
+	if !ok {
+		return start, end
+	}
+	condMap, ok := cond.(map[string]interface{})
+	if !ok || len(condMap) == 0 {
+		condMap = map[string]interface{}{"$eq": cond}
+	}
+	for op, arg := range condMap {
+		bound := appendOrderedJSONValue(append([]byte{}, entriesPrefix...), arg)
+		switch op {
+		case "$eq":
+			start, end = maxKey(start, bound), minKey(end, prefixEnd(bound))
+		case "$gt":
+			start = maxKey(start, prefixEnd(bound))
+		case "$gte":
+			start = maxKey(start, bound)
+		case "$lt":
+			end = minKey(end, bound)
+		case "$lte":
+			end = minKey(end, prefixEnd(bound))
+		}
+	}
+	return start, end
+}
+
+// sortKey returns the key that orders the results as per the `sort` of the query. The second
+// return value is false if the document does not contain all the fields to be sorted on
+func (q *jsonQuery) sortKey(key string, doc interface{}) ([]byte, bool) {
+	var sortKey []byte
+	for _, s := range q.sort {
+		value, exists := lookupField(doc, s.path)
+		if !exists {
+			return nil, false
+		}
+		encodedValue := encodeOrderedJSONValue(value)
+		if s.desc {
+			for i := range encodedValue {
+				encodedValue[i] = ^encodedValue[i]
+			}
+		}
+		sortKey = append(sortKey, encodedValue...)
+	}
+	return appendOrderedString(sortKey, key), true
+}
+
+// project returns the value with only the `fields` of the query
+func (q *jsonQuery) project(doc map[string]interface{}, value []byte) ([]byte, error) {
+	if len(q.fields) == 0 {
+		return value, nil
+	}
+	projection := map[string]interface{}{}
+	for _, path := range q.fields {
+		fieldValue, exists := lookupField(doc, path)
+		if !exists {
+			continue
+		}
+		m := projection
+		for _, field := range path[:len(path)-1] {
+			nested, ok := m[field].(map[string]interface{})
+			if !ok {
+				nested = map[string]interface{}{}
+				m[field] = nested
+			}
+			m = nested
+		}
+		m[path[len(path)-1]] = fieldValue
+	}
+	return json.Marshal(projection)
+}
+
+// ExecuteQuery implements method in VersionedDB interface
+func (vdb *versionedDB) ExecuteQuery(namespace, query string) (statedb.ResultsIterator, error) {
+	return vdb.ExecuteQueryWithMetadata(namespace, query, nil)
+}
+
+// ExecuteQueryWithMetadata implements method in VersionedDB interface. The values that are JSON objects are
+// matched against the selector of the query. The results are returned in the order of the keys unless the
+// query specifies a sort order. The results are retrieved from an index if a suitable index exists for the
+// query; otherwise, all the values in the namespace are scanned. A query with a sort order that no index
+// provides is sorted in memory
+func (vdb *versionedDB) ExecuteQueryWithMetadata(namespace, query string, metadata map[string]interface{}) (statedb.QueryResultsIterator, error) {
+	logger.Debugf("Entering ExecuteQueryWithMetadata namespace: %s, query: %s, metadata: %v", namespace, query, metadata)
+	requestedLimit := int32(0)
+	bookmark := ""
+	if metadata != nil {
+		if err := validateQueryMetadata(metadata); err != nil {
+			return nil, err
+		}
+		if limitOption, ok := metadata[optionLimit]; ok {
+			requestedLimit = limitOption.(int32)
+		}
+		if bookmarkOption, ok := metadata[optionBookmark]; ok {
+			bookmark = bookmarkOption.(string)
+		}
+	}
+	q, err := parseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	var startAfter []byte
+	if bookmark != "" {
+		if startAfter, err = base64.RawURLEncoding.DecodeString(bookmark); err != nil || len(startAfter) == 0 {
+			return nil, errors.Errorf("invalid bookmark [%s]", bookmark)
+		}
+	}
+
+	var candidates candidateIterator
+	idx, sortedByIndex := q.selectIndex(vdb.getIndexes(namespace))
+	switch {
+	case len(q.sort) > 0 && !sortedByIndex:
+		var unsortedCandidates candidateIterator
+		if idx != nil {
+			unsortedCandidates = vdb.newIndexScanner(namespace, idx, q, nil)
+		} else {
+			unsortedCandidates = vdb.newNamespaceScanner(namespace, nil)
+		}
+		if candidates, err = newSortedCandidates(unsortedCandidates, q, startAfter); err != nil {
+			return nil, err
+		}
+	case idx != nil:
+		candidates = vdb.newIndexScanner(namespace, idx, q, startAfter)
+	default:
+		candidates = vdb.newNamespaceScanner(namespace, startAfter)
+	}
+	return &queryScanner{
+		namespace:      namespace,
+		query:          q,
+		candidates:     candidates,
+		requestedLimit: requestedLimit,
+		skip:           q.skip,
+		bookmark:       bookmark,
+	}, nil
+}
+
+func validateQueryMetadata(metadata map[string]interface{}) error {
+	for key, keyVal := range metadata {
+		switch key {
+		case optionBookmark:
+			if _, ok := keyVal.(string); ok {
+				continue
+			}
+			return fmt.Errorf("Invalid entry, \"bookmark\" must be a string")
+
+		case optionLimit:
+			if _, ok := keyVal.(int32); ok {
+				continue
+			}
+			return fmt.Errorf("Invalid entry, \"limit\" must be an int32")
+
+		default:
+			return fmt.Errorf("Invalid entry, option %s not recognized", key)
+		}
+	}
+	return nil
+}
+
+// queryCandidate is a value that may match a query. The `orderKey` determines the position of the candidate
+// in the results and is used as the bookmark for resuming the query after this candidate. The `doc` is set
+// only if the candidate is already known to match the selector
+type queryCandidate struct {
+	orderKey []byte
+	key      string
+	vv       *statedb.VersionedValue
+	doc      map[string]interface{}
+}
+
+// candidateIterator returns the candidates in the ascending order of their `orderKey`
+type candidateIterator interface {
+	next() (*queryCandidate, error)
+	close()
+}
+
+// namespaceScanner returns all the values in a namespace and uses the keys as the order keys
+type namespaceScanner struct {
+	dbItr iterator.Iterator
+}
+
+func (vdb *versionedDB) newNamespaceScanner(namespace string, startAfter []byte) *namespaceScanner {
+	startKey := constructCompositeKey(namespace, "")
+	if startAfter != nil {
+		startKey = append(constructCompositeKey(namespace, string(startAfter)), 0x00)
+	}
+	endKey := constructCompositeKey(namespace, "")
+	endKey[len(endKey)-1] = lastKeyIndicator
+	return &namespaceScanner{vdb.db.GetIterator(startKey, endKey)}
+}
+
+func (s *namespaceScanner) next() (*queryCandidate, error) {
+	if !s.dbItr.Next() {
+		return nil, errors.Wrap(s.dbItr.Error(), "error scanning the namespace")
+	}
+	_, key := splitCompositeKey(s.dbItr.Key())
+	vv, err := decodeValue(append([]byte{}, s.dbItr.Value()...))
+	if err != nil {
+		return nil, err
+	}
+	return &queryCandidate{orderKey: []byte(key), key: key, vv: vv}, nil
+}
+
+func (s *namespaceScanner) close() {
+	s.dbItr.Release()
+}
+
+// indexScanner returns the values from the entries of an index and uses the index entries
+// (without the prefix of the index) as the order keys
+type indexScanner struct {
+	vdb           *versionedDB
+	namespace     string
+	entriesPrefix []byte
+	dbItr         iterator.Iterator
+}
+
+func (vdb *versionedDB) newIndexScanner(namespace string, idx *queryIndex, q *jsonQuery, startAfter []byte) *indexScanner {
+	entriesPrefix := idx.entriesPrefix()
+	startKey, endKey := q.indexRange(idx)
+	if startAfter != nil {
+		startKey = maxKey(startKey, append(append(append([]byte{}, entriesPrefix...), startAfter...), 0x00))
+	}
+	logger.Debugf("Channel [%s]: using index [%s] for a query on namespace [%s]", vdb.dbName, idx.name, namespace)
+	return &indexScanner{vdb, namespace, entriesPrefix, vdb.db.GetIterator(startKey, endKey)}
+}
+
+func (s *indexScanner) next() (*queryCandidate, error) {
+	for s.dbItr.Next() {
+		orderKey := append([]byte{}, s.dbItr.Key()[len(s.entriesPrefix):]...)
+		key := string(s.dbItr.Value())
+		vv, err := s.vdb.GetState(s.namespace, key)
+		if err != nil {
+			return nil, err
+		}
+		if vv == nil {
+			logger.Warningf("Channel [%s]: index entry found for the non-existing key [%s] in namespace [%s]", s.vdb.dbName, key, s.namespace)
+			continue
+		}
+		return &queryCandidate{orderKey: orderKey, key: key, vv: vv}, nil
+	}
+	return nil, errors.Wrap(s.dbItr.Error(), "error scanning the index")
+}
+
+func (s *indexScanner) close() {
+	s.dbItr.Release()
+}
+
+// sortedCandidates holds the matching candidates sorted in memory as per the `sort` of a query
+type sortedCandidates struct {
+	candidates []*queryCandidate
+}
+
+func newSortedCandidates(unsortedCandidates candidateIterator, q *jsonQuery, startAfter []byte) (*sortedCandidates, error) {
+	defer unsortedCandidates.close()
+	s := &sortedCandidates{}
+	for {
+		c, err := unsortedCandidates.next()
+		if err != nil {
+			return nil, err
+		}
+		if c == nil {
+			break
+		}
+		doc, ok := decodeJSONDocument(c.vv.Value)
+		if !ok || !q.matcher(doc) {
+			continue
+		}
+		sortKey, ok := q.sortKey(c.key, doc)
+		if !ok || (startAfter != nil && bytes.Compare(sortKey, startAfter) <= 0) {
+			continue
+		}
+		c.orderKey, c.doc = sortKey, doc
+		s.candidates = append(s.candidates, c)
+	}
+	sort.Slice(s.candidates, func(i, j int) bool {
+		return bytes.Compare(s.candidates[i].orderKey, s.candidates[j].orderKey) < 0
+	})
+	return s, nil
+}
+
+func (s *sortedCandidates) next() (*queryCandidate, error) {
+	if len(s.candidates) == 0 {
+		return nil, nil
+	}
+	c := s.candidates[0]
+	s.candidates = s.candidates[1:]
+	return c, nil
+}
+
+func (s *sortedCandidates) close() {
+	s.candidates = nil
+}
+
+type queryScanner struct {
+	namespace            string
+	query                *jsonQuery
+	candidates           candidateIterator
+	requestedLimit       int32
+	skip                 int
+	totalRecordsReturned int32
+	bookmark             string
+}
+
+func (scanner *queryScanner) Next() (statedb.QueryResult, error) {
+	if scanner.requestedLimit > 0 && scanner.totalRecordsReturned >= scanner.requestedLimit {
+		return nil, nil
+	}
+	for {
+		c, err := scanner.candidates.next()
+		if err != nil || c == nil {
+			return nil, err
+		}
+		doc := c.doc
+		if doc == nil {
+			var ok bool
+			if doc, ok = decodeJSONDocument(c.vv.Value); !ok || !scanner.query.matcher(doc) {
+				continue
+			}
+		}
+		scanner.bookmark = base64.RawURLEncoding.EncodeToString(c.orderKey)
+		if scanner.skip > 0 {
+			scanner.skip--
+			continue
+		}
+		value, err := scanner.query.project(doc, c.vv.Value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error projecting the fields of the value of the key [%s]", c.key)
+		}
+		scanner.totalRecordsReturned++
+		return &statedb.VersionedKV{
+			CompositeKey:   statedb.CompositeKey{Namespace: scanner.namespace, Key: c.key},
+			VersionedValue: statedb.VersionedValue{Value: value, Metadata: c.vv.Metadata, Version: c.vv.Version},
+		}, nil
+	}
+}
+
+func (scanner *queryScanner) Close() {
+	scanner.candidates.close()
+}
+
+// GetBookmarkAndClose returns a bookmark that resumes the query after the last returned result
+func (scanner *queryScanner) GetBookmarkAndClose() string {
+	scanner.Close()
+	return scanner.bookmark
+}
+
+func maxKey(a, b []byte) []byte {
+	if bytes.Compare(a, b) >= 0 {
+		return a
+	}
+	return b
+}
+
+// minKey treats a nil key as the key after the last key
+func minKey(a, b []byte) []byte {
+	if a == nil {
+		return b
+	}
+	if b == nil || bytes.Compare(a, b) <= 0 {
+		return a
+	}
+	return b
+}
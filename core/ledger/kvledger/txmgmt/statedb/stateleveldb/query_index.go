@@ -0,0 +1,297 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateleveldb
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/pkg/errors"
+)
+
+// The index definitions and the index entries are stored in the state database under the keys that begin
+// with `queryIndexKeyPrefix`. As the savepoint key is {0x00}, these keys do not overlap with the keys of the state
+//   index definition: queryIndexKeyPrefix + 'd' + namespace + 0x00 + indexName
+//   index entry:      queryIndexKeyPrefix + 'e' + namespace + 0x00 + indexName + 0x00 + encoded field values + encoded key
+// The value of an index entry is the key of the state
+var (
+	queryIndexKeyPrefix  = []byte{0x00, 0x01}
+	indexDefKeyMarker    = byte('d')
+	indexEntryKeyMarker  = byte('e')
+	indexKeyComponentSep = byte(0x00)
+)
+
+// queryIndex is a JSON index on one or more fields of the values in a namespace. The definition of an index
+// is in the same format as a CouchDB index definition, for instance,
+//   {"index":{"fields":["docType","owner"]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}
+// An index contains only the keys whose values contain all the indexed fields. The entries of an index are
+// sorted in the ascending order of the indexed fields
+type queryIndex struct {
+	namespace string
+	name      string
+	ddoc      string
+	fields    []string
+	paths     [][]string
+	defBytes  []byte
+}
+
+type indexDefinition struct {
+	Index struct {
+		Fields []interface{} `json:"fields"`
+	} `json:"index"`
+	DDoc string `json:"ddoc"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+func parseIndexDefinition(namespace string, defBytes []byte) (*queryIndex, error) {
+	def := &indexDefinition{}
+	if err := json.Unmarshal(defBytes, def); err != nil {
+		return nil, errors.Wrap(err, "error parsing the index definition")
+	}
+	if def.Type != "" && def.Type != "json" {
+		return nil, errors.Errorf("index type [%s] is not supported", def.Type)
+	}
+	if def.Name == "" {
+		return nil, errors.New("index name is missing in the index definition")
+	}
+	if strings.IndexByte(def.Name, indexKeyComponentSep) >= 0 {
+		return nil, errors.Errorf("invalid index name [%s]", def.Name)
+	}
+	if len(def.Index.Fields) == 0 {
+		return nil, errors.New("index fields are missing in the index definition")
+	}
+	idx := &queryIndex{
+		namespace: namespace,
+		name:      def.Name,
+		ddoc:      strings.TrimPrefix(def.DDoc, "_design/"),
+		defBytes:  defBytes,
+	}
+	for _, f := range def.Index.Fields {
+		field, err := parseSortField(f)
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid field in the index definition")
+		}
+		idx.fields = append(idx.fields, field.name)
+		idx.paths = append(idx.paths, field.path)
+	}
+	return idx, nil
+}
+
+// matches returns true if the index is referred by the `use_index` option of a query, which is either
+// a design document name or an array of a design document name and an index name
+func (idx *queryIndex) matches(useIndex []string) bool {
+	if strings.TrimPrefix(useIndex[0], "_design/") != idx.ddoc {
+		return false
+	}
+	return len(useIndex) == 1 || useIndex[1] == idx.name
+}
+
+func (idx *queryIndex) defKey() []byte {
+	return append(indexDefKeysPrefix(idx.namespace), idx.name...)
+}
+
+func (idx *queryIndex) entriesPrefix() []byte {
+	prefix := indexKeysPrefix(indexEntryKeyMarker)
+	prefix = append(append(prefix, idx.namespace...), indexKeyComponentSep)
+	return append(append(prefix, idx.name...), indexKeyComponentSep)
+}
+
+// entryKey returns the key of the index entry for the given key and value. The second return value
+// is false if the value does not contain all the indexed fields
+func (idx *queryIndex) entryKey(key string, doc interface{}) ([]byte, bool) {
+	entryKey := idx.entriesPrefix()
+	for _, path := range idx.paths {
+		value, exists := lookupField(doc, path)
+		if !exists {
+			return nil, false
+		}
+		entryKey = appendOrderedJSONValue(entryKey, value)
+	}
+	return appendOrderedString(entryKey, key), true
+}
+
+func indexKeysPrefix(marker byte) []byte {
+	return append(append([]byte{}, queryIndexKeyPrefix...), marker)
+}
+
+func indexDefKeysPrefix(namespace string) []byte {
+	prefix := indexKeysPrefix(indexDefKeyMarker)
+	return append(append(prefix, namespace...), indexKeyComponentSep)
+}
+
+// loadIndexes loads the definitions of the indexes of all the namespaces
+func (vdb *versionedDB) loadIndexes() error {
+	defKeysPrefix := indexKeysPrefix(indexDefKeyMarker)
+	itr := vdb.db.GetIterator(defKeysPrefix, prefixEnd(defKeysPrefix))
+	defer itr.Release()
+	for itr.Next() {
+		nsAndName := bytes.SplitN(itr.Key()[len(defKeysPrefix):], []byte{indexKeyComponentSep}, 2)
+		namespace := string(nsAndName[0])
+		defBytes := append([]byte{}, itr.Value()...)
+		idx, err := parseIndexDefinition(namespace, defBytes)
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("error loading the index [%s] for namespace [%s]", nsAndName[1], namespace))
+		}
+		vdb.indexes[namespace] = append(vdb.indexes[namespace], idx)
+	}
+	return errors.Wrap(itr.Error(), "error loading the indexes")
+}
+
+// getIndexes returns the indexes of the given namespace
+func (vdb *versionedDB) getIndexes(namespace string) []*queryIndex {
+	vdb.indexesLock.RLock()
+	defer vdb.indexesLock.RUnlock()
+	return vdb.indexes[namespace]
+}
+
+// ProcessIndexesForChaincodeDeploy creates the indexes for the given namespace. An existing index with the same
+// name is replaced if its definition is different. The index entries for the existing values are created
+// along with the index
+func (vdb *versionedDB) ProcessIndexesForChaincodeDeploy(namespace string, fileEntries []*ccprovider.TarFileEntry) error {
+	vdb.indexesLock.Lock()
+	defer vdb.indexesLock.Unlock()
+	for _, fileEntry := range fileEntries {
+		filename := fileEntry.FileHeader.Name
+		idx, err := parseIndexDefinition(namespace, fileEntry.FileContent)
+		if err == nil {
+			err = vdb.createIndex(idx)
+		}
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf(
+				"error creating index from file [%s] for namespace [%s]", filename, namespace))
+		}
+	}
+	return nil
+}
+
+// createIndex is expected to be invoked with the `indexesLock` held
+func (vdb *versionedDB) createIndex(idx *queryIndex) error {
+	var existingIndexes []*queryIndex
+	for _, existingIdx := range vdb.indexes[idx.namespace] {
+		if existingIdx.name != idx.name {
+			existingIndexes = append(existingIndexes, existingIdx)
+			continue
+		}
+		if bytes.Equal(existingIdx.defBytes, idx.defBytes) {
+			logger.Debugf("Channel [%s]: index [%s] already exists for namespace [%s]", vdb.dbName, idx.name, idx.namespace)
+			return nil
+		}
+	}
+
+	logger.Infof("Channel [%s]: creating index [%s] on fields %s for namespace [%s]", vdb.dbName, idx.name, idx.fields, idx.namespace)
+	dbBatch := leveldbhelper.NewUpdateBatch()
+	entriesPrefix := idx.entriesPrefix()
+	itr := vdb.db.GetIterator(entriesPrefix, prefixEnd(entriesPrefix))
+	for itr.Next() {
+		dbBatch.Delete(append([]byte{}, itr.Key()...))
+	}
+	err := itr.Error()
+	itr.Release()
+	if err != nil {
+		return errors.Wrap(err, "error removing the existing entries of the index")
+	}
+
+	dbBatch.Put(idx.defKey(), idx.defBytes)
+	scanner, err := vdb.GetStateRangeScanIterator(idx.namespace, "", "")
+	if err != nil {
+		return err
+	}
+	defer scanner.Close()
+	for {
+		queryResult, err := scanner.Next()
+		if err != nil {
+			return err
+		}
+		if queryResult == nil {
+			break
+		}
+		kv := queryResult.(*statedb.VersionedKV)
+		doc, ok := decodeJSONDocument(kv.Value)
+		if !ok {
+			continue
+		}
+		if entryKey, ok := idx.entryKey(kv.Key, doc); ok {
+			dbBatch.Put(entryKey, []byte(kv.Key))
+		}
+	}
+	if err := vdb.db.WriteBatch(dbBatch, true); err != nil {
+		return err
+	}
+	vdb.indexes[idx.namespace] = append(existingIndexes, idx)
+	return nil
+}
+
+// addIndexUpdates adds to the batch the changes in the index entries that are caused by the update
+// of the given key. This function is expected to be invoked with the `indexesLock` held
+func (vdb *versionedDB) addIndexUpdates(dbBatch *leveldbhelper.UpdateBatch, namespace, key string, newValue []byte) error {
+	indexes := vdb.indexes[namespace]
+	if len(indexes) == 0 {
+		return nil
+	}
+	var oldDoc interface{}
+	oldVV, err := vdb.GetState(namespace, key)
+	if err != nil {
+		return err
+	}
+	oldDocExists := false
+	if oldVV != nil {
+		oldDoc, oldDocExists = decodeJSONDocument(oldVV.Value)
+	}
+	newDoc, newDocExists := decodeJSONDocument(newValue)
+	for _, idx := range indexes {
+		if oldDocExists {
+			if entryKey, ok := idx.entryKey(key, oldDoc); ok {
+				dbBatch.Delete(entryKey)
+			}
+		}
+		if newDocExists {
+			if entryKey, ok := idx.entryKey(key, newDoc); ok {
+				dbBatch.Put(entryKey, []byte(key))
+			}
+		}
+	}
+	return nil
+}
+
+// GetDBType implements method in IndexCapable interface
+func (vdb *versionedDB) GetDBType() string {
+	return ledgerconfig.StateDatabaseGoLevelDB
+}
+
+// decodeJSONDocument decodes the value if it is a JSON object. The numbers are decoded as json.Number
+// so that their representation is retained
+func decodeJSONDocument(value []byte) (map[string]interface{}, bool) {
+	if trimmed := bytes.TrimLeft(value, " \t\r\n"); len(trimmed) == 0 || trimmed[0] != '{' {
+		return nil, false
+	}
+	doc := map[string]interface{}{}
+	decoder := json.NewDecoder(bytes.NewReader(value))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil || decoder.More() {
+		return nil, false
+	}
+	return doc, true
+}
+
+// prefixEnd returns the smallest key that is greater than all the keys that begin with the given prefix
+func prefixEnd(prefix []byte) []byte {
+	end := append([]byte{}, prefix...)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xFF {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,441 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateleveldb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// matcher evaluates a condition of a Mango selector against a JSON document
+type matcher func(doc interface{}) bool
+
+// parseSelector translates a Mango selector (as supported by CouchDB) into a matcher. The supported
+// combination operators are $and, $or, $nor, and $not and the supported condition operators are
+// $eq, $ne, $gt, $gte, $lt, $lte, $exists, $type, $in, $nin, $size, $mod, $regex, $all, $elemMatch,
+// and $allMatch. The values are compared as per the CouchDB collation order
+// (null < false < true < numbers < strings < arrays < objects) except that the strings are compared bytewise
+func parseSelector(selector map[string]interface{}) (matcher, error) {
+	return parseCondition(nil, selector)
+}
+
+// parseCondition parses the condition `cond` that applies to the field at `path` (the document itself for an empty path)
+func parseCondition(path []string, cond interface{}) (matcher, error) {
+	condMap, ok := cond.(map[string]interface{})
+	if !ok || len(condMap) == 0 {
+		// implicit equality
+		return fieldMatcher(path, eqOp(cond)), nil
+	}
+	var matchers []matcher
+	for _, k := range sortedKeys(condMap) {
+		arg := condMap[k]
+		var m matcher
+		var err error
+		switch {
+		case !strings.HasPrefix(k, "$"):
+			var subPath []string
+			if subPath, err = splitFieldPath(k); err == nil {
+				m, err = parseCondition(append(append([]string{}, path...), subPath...), arg)
+			}
+		case k == "$and" || k == "$or" || k == "$nor":
+			m, err = parseCombination(path, k, arg)
+		case k == "$not":
+			var sub matcher
+			if sub, err = parseCondition(path, arg); err == nil {
+				m = func(doc interface{}) bool { return !sub(doc) }
+			}
+		default:
+			var op valueOp
+			if op, err = parseOperator(k, arg); err == nil {
+				m = fieldMatcher(path, op)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, m)
+	}
+	return andMatcher(matchers), nil
+}
+
+func parseCombination(path []string, operator string, arg interface{}) (matcher, error) {
+	conds, ok := arg.([]interface{})
+	if !ok {
+		return nil, errors.Errorf("operator [%s] expects an array of selectors", operator)
+	}
+	var matchers []matcher
+	for _, cond := range conds {
+		if _, ok := cond.(map[string]interface{}); !ok {
+			return nil, errors.Errorf("operator [%s] expects an array of selectors", operator)
+		}
+		m, err := parseCondition(path, cond)
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, m)
+	}
+	switch operator {
+	case "$and":
+		return andMatcher(matchers), nil
+	case "$or":
+		return func(doc interface{}) bool {
+			for _, m := range matchers {
+				if m(doc) {
+					return true
+				}
+			}
+			return false
+		}, nil
+	default:
+		return func(doc interface{}) bool {
+			for _, m := range matchers {
+				if m(doc) {
+					return false
+				}
+			}
+			return true
+		}, nil
+	}
+}
+
+func andMatcher(matchers []matcher) matcher {
+	return func(doc interface{}) bool {
+		for _, m := range matchers {
+			if !m(doc) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// valueOp evaluates an operator against the value of a field. The parameter `exists` is false if the
+// field is not present in the document
+type valueOp func(value interface{}, exists bool) bool
+
+func fieldMatcher(path []string, op valueOp) matcher {
+	return func(doc interface{}) bool {
+		value, exists := lookupField(doc, path)
+		return op(value, exists)
+	}
+}
+
+func eqOp(arg interface{}) valueOp {
+	return func(value interface{}, exists bool) bool {
+		return exists && compareJSONValues(value, arg) == 0
+	}
+}
+
+func parseOperator(operator string, arg interface{}) (valueOp, error) {
+	switch operator {
+	case "$eq":
+		return eqOp(arg), nil
+	case "$ne":
+		return func(value interface{}, exists bool) bool {
+			return exists && compareJSONValues(value, arg) != 0
+		}, nil
+	case "$gt", "$gte", "$lt", "$lte":
+		return func(value interface{}, exists bool) bool {
+			if !exists {
+				return false
+			}
+			c := compareJSONValues(value, arg)
+			switch operator {
+			case "$gt":
+				return c > 0
+			case "$gte":
+				return c >= 0
+			case "$lt":
+				return c < 0
+			default:
+				return c <= 0
+			}
+		}, nil
+	case "$exists":
+		shouldExist, ok := arg.(bool)
+		if !ok {
+			return nil, errors.New("operator [$exists] expects a boolean")
+		}
+		return func(value interface{}, exists bool) bool { return exists == shouldExist }, nil
+	case "$type":
+		typeName, ok := arg.(string)
+		if !ok {
+			return nil, errors.New("operator [$type] expects a string")
+		}
+		return func(value interface{}, exists bool) bool {
+			return exists && jsonTypeName(value) == typeName
+		}, nil
+	case "$in", "$nin":
+		values, ok := arg.([]interface{})
+		if !ok {
+			return nil, errors.Errorf("operator [%s] expects an array", operator)
+		}
+		return func(value interface{}, exists bool) bool {
+			if !exists {
+				return false
+			}
+			for _, v := range values {
+				if compareJSONValues(value, v) == 0 {
+					return operator == "$in"
+				}
+			}
+			return operator == "$nin"
+		}, nil
+	case "$size":
+		size, ok := toInteger(arg)
+		if !ok {
+			return nil, errors.New("operator [$size] expects an integer")
+		}
+		return func(value interface{}, exists bool) bool {
+			array, ok := value.([]interface{})
+			return exists && ok && int64(len(array)) == size
+		}, nil
+	case "$mod":
+		args, ok := arg.([]interface{})
+		if !ok || len(args) != 2 {
+			return nil, errors.New("operator [$mod] expects an array of a divisor and a remainder")
+		}
+		divisor, ok1 := toInteger(args[0])
+		remainder, ok2 := toInteger(args[1])
+		if !ok1 || !ok2 || divisor == 0 {
+			return nil, errors.New("operator [$mod] expects a non-zero integer divisor and an integer remainder")
+		}
+		return func(value interface{}, exists bool) bool {
+			v, ok := toInteger(value)
+			return exists && ok && v%divisor == remainder
+		}, nil
+	case "$regex":
+		pattern, ok := arg.(string)
+		if !ok {
+			return nil, errors.New("operator [$regex] expects a string")
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid regular expression [%s]", pattern)
+		}
+		return func(value interface{}, exists bool) bool {
+			s, ok := value.(string)
+			return exists && ok && re.MatchString(s)
+		}, nil
+	case "$all":
+		values, ok := arg.([]interface{})
+		if !ok {
+			return nil, errors.New("operator [$all] expects an array")
+		}
+		return func(value interface{}, exists bool) bool {
+			array, ok := value.([]interface{})
+			if !exists || !ok {
+				return false
+			}
+			for _, v := range values {
+				if !containsJSONValue(array, v) {
+					return false
+				}
+			}
+			return true
+		}, nil
+	case "$elemMatch", "$allMatch":
+		if _, ok := arg.(map[string]interface{}); !ok {
+			return nil, errors.Errorf("operator [%s] expects a selector", operator)
+		}
+		sub, err := parseCondition(nil, arg)
+		if err != nil {
+			return nil, err
+		}
+		return func(value interface{}, exists bool) bool {
+			array, ok := value.([]interface{})
+			if !exists || !ok || len(array) == 0 {
+				return false
+			}
+			matchCount := 0
+			for _, elem := range array {
+				if sub(elem) {
+					matchCount++
+				}
+			}
+			if operator == "$elemMatch" {
+				return matchCount > 0
+			}
+			return matchCount == len(array)
+		}, nil
+	default:
+		return nil, errors.Errorf("unsupported operator [%s] in the query selector", operator)
+	}
+}
+
+// splitFieldPath splits a field path in dot notation. A dot that is part of a field name is escaped with a backslash
+func splitFieldPath(path string) ([]string, error) {
+	var fields []string
+	var field []byte
+	for i := 0; i < len(path); i++ {
+		switch {
+		case path[i] == '\\' && i+1 < len(path) && path[i+1] == '.':
+			field = append(field, '.')
+			i++
+		case path[i] == '.':
+			fields = append(fields, string(field))
+			field = nil
+		default:
+			field = append(field, path[i])
+		}
+	}
+	fields = append(fields, string(field))
+	for _, f := range fields {
+		if f == "" {
+			return nil, errors.Errorf("invalid field path [%s]", path)
+		}
+	}
+	return fields, nil
+}
+
+func lookupField(doc interface{}, path []string) (interface{}, bool) {
+	value := doc
+	for _, field := range path {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = m[field]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+func jsonTypeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+func toInteger(value interface{}) (int64, bool) {
+	n, ok := value.(json.Number)
+	if !ok {
+		return 0, false
+	}
+	i, err := n.Int64()
+	return i, err == nil
+}
+
+func containsJSONValue(array []interface{}, value interface{}) bool {
+	for _, elem := range array {
+		if compareJSONValues(elem, value) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func compareJSONValues(a, b interface{}) int {
+	return bytes.Compare(encodeOrderedJSONValue(a), encodeOrderedJSONValue(b))
+}
+
+const (
+	typeTagNull byte = iota + 1
+	typeTagFalse
+	typeTagTrue
+	typeTagNumber
+	typeTagString
+	typeTagArray
+	typeTagObject
+)
+
+// encodeOrderedJSONValue encodes a JSON value such that the bytewise order of the encoded values follows the
+// collation order of the values. None of the encoded values is a prefix of another encoded value, which
+// makes the encoding of a sequence of values order preserving as well
+func encodeOrderedJSONValue(value interface{}) []byte {
+	return appendOrderedJSONValue(nil, value)
+}
+
+func appendOrderedJSONValue(buf []byte, value interface{}) []byte {
+	switch v := value.(type) {
+	case nil:
+		return append(buf, typeTagNull)
+	case bool:
+		if v {
+			return append(buf, typeTagTrue)
+		}
+		return append(buf, typeTagFalse)
+	case json.Number:
+		f, _ := v.Float64()
+		return appendOrderedFloat(append(buf, typeTagNumber), f)
+	case float64:
+		return appendOrderedFloat(append(buf, typeTagNumber), v)
+	case string:
+		return appendOrderedString(append(buf, typeTagString), v)
+	case []interface{}:
+		buf = append(buf, typeTagArray)
+		for _, elem := range v {
+			buf = appendOrderedJSONValue(buf, elem)
+		}
+		return append(buf, 0x00)
+	case map[string]interface{}:
+		buf = append(buf, typeTagObject)
+		for _, k := range sortedKeys(v) {
+			buf = appendOrderedString(buf, k)
+			buf = appendOrderedJSONValue(buf, v[k])
+		}
+		return append(buf, 0x00)
+	default:
+		panic(fmt.Sprintf("unexpected type %T in a JSON value", value))
+	}
+}
+
+func appendOrderedFloat(buf []byte, f float64) []byte {
+	if f == 0 {
+		// -0 and 0 are equal
+		f = 0
+	}
+	bits := math.Float64bits(f)
+	if f < 0 {
+		bits = ^bits
+	} else {
+		bits |= 1 << 63
+	}
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, bits)
+	return append(buf, b...)
+}
+
+// appendOrderedString escapes the 0x00 bytes in the string as 0x00 0xFF and terminates the string with 0x00 0x01
+func appendOrderedString(buf []byte, s string) []byte {
+	for i := 0; i < len(s); i++ {
+		buf = append(buf, s[i])
+		if s[i] == 0x00 {
+			buf = append(buf, 0xFF)
+		}
+	}
+	return append(buf, 0x00, 0x01)
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
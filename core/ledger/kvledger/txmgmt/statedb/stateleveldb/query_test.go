@@ -0,0 +1,309 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateleveldb
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/commontests"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectorMatching(t *testing.T) {
+	doc := `{"owner":"tom","size":5,"price":10.5,"color":null,"sold":false,
+		"tags":["blue","big"],"dims":{"h":10,"w":20},"parts":[{"id":1},{"id":2}],"a.b":"dotted"}`
+	testCases := []struct {
+		selector string
+		expected bool
+	}{
+		{`{"owner":"tom"}`, true},
+		{`{"owner":"jerry"}`, false},
+		{`{"size":5.0}`, true},
+		{`{"owner":{"$eq":"tom"},"size":{"$gt":4,"$lte":5}}`, true},
+		{`{"size":{"$lt":5}}`, false},
+		{`{"size":{"$gt":"a"}}`, false},
+		{`{"owner":{"$gt":100}}`, true},
+		{`{"size":{"$ne":5}}`, false},
+		{`{"missing":{"$ne":5}}`, false},
+		{`{"missing":{"$exists":false}}`, true},
+		{`{"color":{"$exists":true}}`, true},
+		{`{"color":null}`, true},
+		{`{"sold":{"$type":"boolean"}}`, true},
+		{`{"dims":{"$type":"object"}}`, true},
+		{`{"owner":{"$in":["jerry","tom"]}}`, true},
+		{`{"owner":{"$nin":["jerry","tom"]}}`, false},
+		{`{"tags":{"$size":2}}`, true},
+		{`{"size":{"$mod":[2,1]}}`, true},
+		{`{"price":{"$mod":[2,1]}}`, false},
+		{`{"owner":{"$regex":"^t.m$"}}`, true},
+		{`{"tags":{"$all":["big","blue"]}}`, true},
+		{`{"tags":{"$all":["big","red"]}}`, false},
+		{`{"tags":["blue","big"]}`, true},
+		{`{"tags":"blue"}`, false},
+		{`{"tags":{"$elemMatch":{"$eq":"big"}}}`, true},
+		{`{"parts":{"$elemMatch":{"id":2}}}`, true},
+		{`{"parts":{"$allMatch":{"id":{"$gt":0}}}}`, true},
+		{`{"parts":{"$allMatch":{"id":{"$gt":1}}}}`, false},
+		{`{"dims.h":10}`, true},
+		{`{"dims":{"w":{"$gte":20}}}`, true},
+		{`{"dims":{"h":10,"w":20}}`, true},
+		{`{"a\\.b":"dotted"}`, true},
+		{`{"$or":[{"owner":"jerry"},{"size":5}]}`, true},
+		{`{"$nor":[{"owner":"jerry"},{"size":5}]}`, false},
+		{`{"$and":[{"owner":"tom"},{"$not":{"size":5}}]}`, false},
+		{`{"size":{"$not":{"$gt":10}}}`, true},
+		{`{"missing":{"$not":{"$eq":1}}}`, true},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.selector, func(t *testing.T) {
+			q, err := parseQuery(fmt.Sprintf(`{"selector":%s}`, testCase.selector))
+			assert.NoError(t, err)
+			d, ok := decodeJSONDocument([]byte(doc))
+			assert.True(t, ok)
+			assert.Equal(t, testCase.expected, q.matcher(d))
+		})
+	}
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	testCases := []struct {
+		query         string
+		expectedError string
+	}{
+		{`not a json`, "error parsing the query"},
+		{`{"fields":["a"]}`, "selector is missing in the query"},
+		{`{"selector":["a"]}`, "selector in the query should be a JSON object"},
+		{`{"selector":{"a":{"$unknown":1}}}`, "unsupported operator [$unknown] in the query selector"},
+		{`{"selector":{"$or":{"a":1}}}`, "operator [$or] expects an array of selectors"},
+		{`{"selector":{"a":{"$exists":1}}}`, "operator [$exists] expects a boolean"},
+		{`{"selector":{"a":{"$mod":[0,1]}}}`, "operator [$mod] expects a non-zero integer divisor and an integer remainder"},
+		{`{"selector":{"a":{"$regex":"("}}}`, "invalid regular expression [(]"},
+		{`{"selector":{"a..b":1}}`, "invalid field path [a..b]"},
+		{`{"selector":{},"sort":[{"a":"up"}]}`, "invalid sort field in the query: invalid sort direction up for the field [a]"},
+		{`{"selector":{},"use_index":1}`, "use_index in the query should be a string or an array of strings"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.query, func(t *testing.T) {
+			_, err := parseQuery(testCase.query)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), testCase.expectedError)
+		})
+	}
+}
+
+func TestOrderedJSONValueEncoding(t *testing.T) {
+	// values in the ascending order
+	values := []string{`null`, `false`, `true`, `-1e10`, `-2.5`, `0`, `1`, `1.5`, `100`, `""`, `"A"`, `"a"`,
+		`"a\u0000"`, `"ab"`, `[]`, `[1]`, `[1,"a"]`, `[2]`, `{}`, `{"a":1}`, `{"a":2}`, `{"b":0}`}
+	for i := 0; i < len(values)-1; i++ {
+		var v1, v2 interface{}
+		assert.NoError(t, json.Unmarshal([]byte(values[i]), &v1))
+		assert.NoError(t, json.Unmarshal([]byte(values[i+1]), &v2))
+		assert.Equal(t, -1, compareJSONValues(v1, v2), "%s should be less than %s", values[i], values[i+1])
+	}
+	assert.Equal(t, 0, compareJSONValues(json.Number("-0"), json.Number("0.0")))
+}
+
+func TestQueryWithIndexes(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testquerywithindexes")
+	assert.NoError(t, err)
+
+	batch := statedb.NewUpdateBatch()
+	colors := []string{"red", "blue", "green"}
+	for i := 1; i <= 20; i++ {
+		value := fmt.Sprintf(`{"asset_name":"marble%d","color":"%s","size":%d,"owner":"owner%d"}`, i, colors[i%3], 21-i, i%4)
+		batch.Put("ns1", fmt.Sprintf("key%02d", i), []byte(value), version.NewHeight(1, uint64(i)))
+	}
+	batch.Put("ns1", "key21", []byte(`{"asset_name":"marble21","color":"red"}`), version.NewHeight(1, 21))
+	batch.Put("ns1", "key22", []byte("not a json"), version.NewHeight(1, 22))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 22)))
+
+	redKeysBySize := []string{"key18", "key15", "key12", "key09", "key06", "key03"}
+	redKeys := []string{"key03", "key06", "key09", "key12", "key15", "key18", "key21"}
+
+	// queries without indexes
+	testPaginatedQuery(t, db, `{"selector":{"color":"red"}}`, 3, redKeys)
+	testPaginatedQuery(t, db, `{"selector":{"color":"red"},"sort":[{"size":"asc"}]}`, 4, redKeysBySize)
+	testPaginatedQuery(t, db, `{"selector":{"color":"red"},"sort":[{"size":"desc"}]}`, 4, reverse(redKeysBySize))
+
+	indexCapable, ok := db.(statedb.IndexCapable)
+	assert.True(t, ok)
+	assert.Equal(t, "goleveldb", indexCapable.GetDBType())
+	dbArtifactsTarBytes := testutil.CreateTarBytesForTest(
+		[]*testutil.TarFileEntry{
+			{Name: "META-INF/statedb/couchdb/indexes/indexSize.json", Body: `{"index":{"fields":[{"size":"desc"}]},"ddoc":"indexSizeDoc","name":"indexSize","type":"json"}`},
+			{Name: "META-INF/statedb/couchdb/indexes/indexColorSize.json", Body: `{"index":{"fields":["color","size"]},"ddoc":"indexColorSizeDoc","name":"indexColorSize","type":"json"}`},
+		},
+	)
+	fileEntries, err := ccprovider.ExtractFileEntries(dbArtifactsTarBytes, statedb.IndexArtifactsDBType)
+	assert.NoError(t, err)
+	assert.NoError(t, indexCapable.ProcessIndexesForChaincodeDeploy("ns1", fileEntries["META-INF/statedb/couchdb/indexes"]))
+	vdb := db.(*versionedDB)
+
+	// queries with indexes
+	q, err := parseQuery(`{"selector":{"color":"red","size":{"$gt":0}},"sort":["color","size"]}`)
+	assert.NoError(t, err)
+	idx, sortedByIndex := q.selectIndex(vdb.getIndexes("ns1"))
+	assert.Equal(t, "indexColorSize", idx.name)
+	assert.True(t, sortedByIndex)
+	testPaginatedQuery(t, db, `{"selector":{"color":"red","size":{"$gt":0}},"sort":["color","size"]}`, 4, redKeysBySize)
+	testPaginatedQuery(t, db, `{"selector":{"color":"red","size":{"$gt":5,"$lte":15}},"sort":["color","size"]}`, 2,
+		[]string{"key15", "key12", "key09", "key06"})
+	testPaginatedQuery(t, db, `{"selector":{"size":{"$gt":18}},"sort":["size"],"use_index":["indexSizeDoc","indexSize"]}`, 2,
+		[]string{"key02", "key01"})
+	testPaginatedQuery(t, db, `{"selector":{"size":{"$lt":3}},"sort":[{"size":"desc"}]}`, 1, []string{"key19", "key20"})
+
+	// an index is not used if the selector does not require all the indexed fields
+	q, err = parseQuery(`{"selector":{"color":"red"}}`)
+	assert.NoError(t, err)
+	idx, _ = q.selectIndex(vdb.getIndexes("ns1"))
+	assert.Nil(t, idx)
+	testPaginatedQuery(t, db, `{"selector":{"color":"red"}}`, 3, redKeys)
+
+	// the indexes are updated along with the state
+	batch = statedb.NewUpdateBatch()
+	batch.Put("ns1", "key03", []byte(`{"asset_name":"marble3","color":"blue","size":18}`), version.NewHeight(2, 1))
+	batch.Delete("ns1", "key06", version.NewHeight(2, 2))
+	batch.Put("ns1", "key23", []byte(`{"asset_name":"marble23","color":"red","size":100}`), version.NewHeight(2, 3))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(2, 3)))
+	testPaginatedQuery(t, db, `{"selector":{"color":"red","size":{"$gt":0}},"sort":["color","size"]}`, 10,
+		[]string{"key18", "key15", "key12", "key09", "key23"})
+
+	// the indexes are retained across the restarts and are not included in the full scan
+	env.DBProvider.Close()
+	env.DBProvider = NewVersionedDBProvider()
+	db, err = env.DBProvider.GetDBHandle("testquerywithindexes")
+	assert.NoError(t, err)
+	assert.Len(t, db.(*versionedDB).getIndexes("ns1"), 2)
+	testPaginatedQuery(t, db, `{"selector":{"color":"red","size":{"$gt":0}},"sort":["color","size"]}`, 10,
+		[]string{"key18", "key15", "key12", "key09", "key23"})
+	itr, err := db.(statedb.FullScannable).GetFullScanIterator(nil)
+	assert.NoError(t, err)
+	defer itr.Close()
+	numKVs := 0
+	for {
+		kv, err := itr.Next()
+		assert.NoError(t, err)
+		if kv == nil {
+			break
+		}
+		assert.Equal(t, "ns1", kv.Namespace)
+		numKVs++
+	}
+	assert.Equal(t, 22, numKVs)
+}
+
+func TestQueryProjectionAndSkip(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testqueryprojection")
+	assert.NoError(t, err)
+	batch := statedb.NewUpdateBatch()
+	batch.PutValAndMetadata("ns1", "key1", []byte(`{"owner":"tom","size":1,"dims":{"h":1,"w":2}}`), []byte("metadata1"), version.NewHeight(1, 1))
+	batch.Put("ns1", "key2", []byte(`{"owner":"tom","size":2}`), version.NewHeight(1, 2))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 2)))
+
+	itr, err := db.ExecuteQuery("ns1", `{"selector":{"owner":"tom"},"fields":["size","dims.w"]}`)
+	assert.NoError(t, err)
+	result, err := itr.Next()
+	assert.NoError(t, err)
+	kv := result.(*statedb.VersionedKV)
+	assert.Equal(t, "key1", kv.Key)
+	assert.JSONEq(t, `{"size":1,"dims":{"w":2}}`, string(kv.Value))
+	assert.Equal(t, []byte("metadata1"), kv.Metadata)
+	assert.Equal(t, version.NewHeight(1, 1), kv.Version)
+	itr.Close()
+
+	itr, err = db.ExecuteQuery("ns1", `{"selector":{"owner":"tom"},"skip":1}`)
+	assert.NoError(t, err)
+	commontests.TestItrWithoutClose(t, itr, []string{"key2"})
+	itr.Close()
+}
+
+func TestQueryErrors(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testqueryerrors")
+	assert.NoError(t, err)
+
+	_, err = db.ExecuteQueryWithMetadata("ns1", `{"selector":{}}`, map[string]interface{}{"limit": 10})
+	assert.EqualError(t, err, `Invalid entry, "limit" must be an int32`)
+	_, err = db.ExecuteQueryWithMetadata("ns1", `{"selector":{}}`, map[string]interface{}{"bookmark": 10})
+	assert.EqualError(t, err, `Invalid entry, "bookmark" must be a string`)
+	_, err = db.ExecuteQueryWithMetadata("ns1", `{"selector":{}}`, map[string]interface{}{"pagesize": int32(10)})
+	assert.EqualError(t, err, "Invalid entry, option pagesize not recognized")
+	_, err = db.ExecuteQueryWithMetadata("ns1", `{"selector":{}}`, map[string]interface{}{"bookmark": "!!!"})
+	assert.EqualError(t, err, "invalid bookmark [!!!]")
+
+	indexCapable := db.(statedb.IndexCapable)
+	for _, testCase := range []struct {
+		indexDef      string
+		expectedError string
+	}{
+		{`{"index":{"fields": This is a bad json}`, "error parsing the index definition"},
+		{`{"index":{"fields":["a"]},"name":"index1","type":"text"}`, "index type [text] is not supported"},
+		{`{"index":{"fields":["a"]}}`, "index name is missing in the index definition"},
+		{`{"index":{"fields":[]},"name":"index1"}`, "index fields are missing in the index definition"},
+		{`{"index":{"fields":[{"a":"up"}]},"name":"index1"}`, "invalid field in the index definition"},
+	} {
+		fileEntries := []*ccprovider.TarFileEntry{{
+			FileHeader:  &tar.Header{Name: "META-INF/statedb/couchdb/indexes/index1.json"},
+			FileContent: []byte(testCase.indexDef),
+		}}
+		err := indexCapable.ProcessIndexesForChaincodeDeploy("ns1", fileEntries)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "error creating index from file [META-INF/statedb/couchdb/indexes/index1.json] for namespace [ns1]")
+		assert.Contains(t, err.Error(), testCase.expectedError)
+	}
+}
+
+// testPaginatedQuery verifies that the query returns the expected keys, both without pagination and with the given page size
+func testPaginatedQuery(t *testing.T, db statedb.VersionedDB, query string, pageSize int32, expectedKeys []string) {
+	itr, err := db.ExecuteQuery("ns1", query)
+	assert.NoError(t, err)
+	commontests.TestItrWithoutClose(t, itr, expectedKeys)
+	itr.Close()
+
+	bookmark := ""
+	for i := 0; i < len(expectedKeys); i += int(pageSize) {
+		metadata := map[string]interface{}{"limit": pageSize}
+		if bookmark != "" {
+			metadata["bookmark"] = bookmark
+		}
+		itr, err := db.ExecuteQueryWithMetadata("ns1", query, metadata)
+		assert.NoError(t, err)
+		end := i + int(pageSize)
+		if end > len(expectedKeys) {
+			end = len(expectedKeys)
+		}
+		commontests.TestItrWithoutClose(t, itr, expectedKeys[i:end])
+		bookmark = itr.GetBookmarkAndClose()
+	}
+	// the query with the last bookmark returns no more results
+	lastPageItr, err := db.ExecuteQueryWithMetadata("ns1", query, map[string]interface{}{"limit": pageSize, "bookmark": bookmark})
+	assert.NoError(t, err)
+	commontests.TestItrWithoutClose(t, lastPageItr, nil)
+	assert.Equal(t, bookmark, lastPageItr.GetBookmarkAndClose(), strings.Join(expectedKeys, ","))
+}
+
+func reverse(keys []string) []string {
+	reversed := make([]string, len(keys))
+	for i, key := range keys {
+		reversed[len(keys)-1-i] = key
+	}
+	return reversed
+}
@@ -7,13 +7,13 @@ package stateleveldb
 
 import (
 	"bytes"
+	"sync"
 
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
-	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb/iterator"
 )
 
@@ -26,6 +26,8 @@ var savePointKey = []byte{0x00}
 // VersionedDBProvider implements interface VersionedDBProvider
 type VersionedDBProvider struct {
 	dbProvider *leveldbhelper.Provider
+	databases  map[string]*versionedDB
+	mux        sync.Mutex
 }
 
 // NewVersionedDBProvider instantiates VersionedDBProvider
@@ -33,12 +35,22 @@ func NewVersionedDBProvider() *VersionedDBProvider {
 	dbPath := ledgerconfig.GetStateLevelDBPath()
 	logger.Debugf("constructing VersionedDBProvider dbPath=%s", dbPath)
 	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: dbPath})
-	return &VersionedDBProvider{dbProvider}
+	return &VersionedDBProvider{dbProvider: dbProvider, databases: make(map[string]*versionedDB)}
 }
 
 // GetDBHandle gets the handle to a named database
 func (provider *VersionedDBProvider) GetDBHandle(dbName string) (statedb.VersionedDB, error) {
-	return newVersionedDB(provider.dbProvider.GetDBHandle(dbName), dbName), nil
+	provider.mux.Lock()
+	defer provider.mux.Unlock()
+	vdb := provider.databases[dbName]
+	if vdb == nil {
+		var err error
+		if vdb, err = newVersionedDB(provider.dbProvider.GetDBHandle(dbName), dbName); err != nil {
+			return nil, err
+		}
+		provider.databases[dbName] = vdb
+	}
+	return vdb, nil
 }
 
 // Close closes the underlying db
@@ -46,15 +58,34 @@ func (provider *VersionedDBProvider) Close() {
 	provider.dbProvider.Close()
 }
 
+// RetrieveSavepoint opens the state database at the given path in read-only mode and returns the savepoint
+// of the given ledger, that is, the height of the last block committed to the state database of the ledger.
+// The returned height is nil if the ledger has no savepoint. This function is meant for the offline tools and
+// returns an error if the state database is in use
+func RetrieveSavepoint(dbPath, ledgerID string) (*version.Height, error) {
+	dbProvider, err := leveldbhelper.NewReadOnlyProvider(&leveldbhelper.Conf{DBPath: dbPath})
+	if err != nil {
+		return nil, err
+	}
+	defer dbProvider.Close()
+	return (&versionedDB{db: dbProvider.GetDBHandle(ledgerID), dbName: ledgerID}).GetLatestSavePoint()
+}
+
 // VersionedDB implements VersionedDB interface
 type versionedDB struct {
-	db     *leveldbhelper.DBHandle
-	dbName string
+	db          *leveldbhelper.DBHandle
+	dbName      string
+	indexes     map[string][]*queryIndex
+	indexesLock sync.RWMutex
 }
 
 // newVersionedDB constructs an instance of VersionedDB
-func newVersionedDB(db *leveldbhelper.DBHandle, dbName string) *versionedDB {
-	return &versionedDB{db, dbName}
+func newVersionedDB(db *leveldbhelper.DBHandle, dbName string) (*versionedDB, error) {
+	vdb := &versionedDB{db: db, dbName: dbName, indexes: make(map[string][]*queryIndex)}
+	if err := vdb.loadIndexes(); err != nil {
+		return nil, err
+	}
+	return vdb, nil
 }
 
 // Open implements method in VersionedDB interface
@@ -154,18 +185,10 @@ func (vdb *versionedDB) GetStateRangeScanIteratorWithMetadata(namespace string,
 
 }
 
-// ExecuteQuery implements method in VersionedDB interface
-func (vdb *versionedDB) ExecuteQuery(namespace, query string) (statedb.ResultsIterator, error) {
-	return nil, errors.New("ExecuteQuery not supported for leveldb")
-}
-
-// ExecuteQueryWithMetadata implements method in VersionedDB interface
-func (vdb *versionedDB) ExecuteQueryWithMetadata(namespace, query string, metadata map[string]interface{}) (statedb.QueryResultsIterator, error) {
-	return nil, errors.New("ExecuteQueryWithMetadata not supported for leveldb")
-}
-
 // ApplyUpdates implements method in VersionedDB interface
 func (vdb *versionedDB) ApplyUpdates(batch *statedb.UpdateBatch, height *version.Height) error {
+	vdb.indexesLock.Lock()
+	defer vdb.indexesLock.Unlock()
 	dbBatch := leveldbhelper.NewUpdateBatch()
 	namespaces := batch.GetUpdatedNamespaces()
 	for _, ns := range namespaces {
@@ -173,6 +196,9 @@ func (vdb *versionedDB) ApplyUpdates(batch *statedb.UpdateBatch, height *version
 		for k, vv := range updates {
 			compositeKey := constructCompositeKey(ns, k)
 			logger.Debugf("Channel [%s]: Applying key(string)=[%s] key(bytes)=[%#v]", vdb.dbName, string(compositeKey), compositeKey)
+			if err := vdb.addIndexUpdates(dbBatch, ns, k, vv.Value); err != nil {
+				return err
+			}
 
 			if vv.Value == nil {
 				dbBatch.Delete(compositeKey)
@@ -212,6 +238,11 @@ func (vdb *versionedDB) GetLatestSavePoint() (*version.Height, error) {
 	return version, nil
 }
 
+// GetFullScanIterator implements method in FullScannable interface
+func (vdb *versionedDB) GetFullScanIterator(skipNamespace func(string) bool) (statedb.FullScanIterator, error) {
+	return &fullDBScanner{vdb.db.GetIterator(nil, nil), skipNamespace}, nil
+}
+
 func constructCompositeKey(ns string, key string) []byte {
 	return append(append([]byte(ns), compositeKeySep...), []byte(key)...)
 }
@@ -275,3 +306,37 @@ func (scanner *kvScanner) GetBookmarkAndClose() string {
 	scanner.Close()
 	return retval
 }
+
+type fullDBScanner struct {
+	dbItr         iterator.Iterator
+	skipNamespace func(string) bool
+}
+
+func (s *fullDBScanner) Next() (*statedb.VersionedKV, error) {
+	for s.dbItr.Next() {
+		dbKey := s.dbItr.Key()
+		if bytes.Equal(dbKey, savePointKey) || bytes.HasPrefix(dbKey, queryIndexKeyPrefix) {
+			continue
+		}
+		ns, key := splitCompositeKey(dbKey)
+		if s.skipNamespace != nil && s.skipNamespace(ns) {
+			continue
+		}
+		dbVal := s.dbItr.Value()
+		dbValCopy := make([]byte, len(dbVal))
+		copy(dbValCopy, dbVal)
+		vv, err := decodeValue(dbValCopy)
+		if err != nil {
+			return nil, err
+		}
+		return &statedb.VersionedKV{
+			CompositeKey:   statedb.CompositeKey{Namespace: ns, Key: key},
+			VersionedValue: *vv,
+		}, nil
+	}
+	return nil, nil
+}
+
+func (s *fullDBScanner) Close() {
+	s.dbItr.Release()
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/commontests"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
@@ -69,27 +70,10 @@ func testCompositeKey(t *testing.T, dbName string, ns string, key string) {
 	assert.Equal(t, key, key1)
 }
 
-// TestQueryOnLevelDB tests queries on levelDB.
-func TestQueryOnLevelDB(t *testing.T) {
+func TestQuery(t *testing.T) {
 	env := NewTestVDBEnv(t)
 	defer env.Cleanup()
-	db, err := env.DBProvider.GetDBHandle("testquery")
-	assert.NoError(t, err)
-	db.Open()
-	defer db.Close()
-	batch := statedb.NewUpdateBatch()
-	jsonValue1 := `{"asset_name": "marble1","color": "blue","size": 1,"owner": "tom"}`
-	batch.Put("ns1", "key1", []byte(jsonValue1), version.NewHeight(1, 1))
-
-	savePoint := version.NewHeight(2, 22)
-	db.ApplyUpdates(batch, savePoint)
-
-	// query for owner=jerry, use namespace "ns1"
-	// As queries are not supported in levelDB, call to ExecuteQuery()
-	// should return a error message
-	itr, err := db.ExecuteQuery("ns1", `{"selector":{"owner":"jerry"}}`)
-	assert.Error(t, err, "ExecuteQuery not supported for leveldb")
-	assert.Nil(t, itr)
+	commontests.TestQuery(t, env.DBProvider)
 }
 
 func TestGetStateMultipleKeys(t *testing.T) {
@@ -136,3 +120,78 @@ func TestApplyUpdatesWithNilHeight(t *testing.T) {
 	defer env.Cleanup()
 	commontests.TestApplyUpdatesWithNilHeight(t, env.DBProvider)
 }
+
+func TestFullScanIterator(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+
+	db, err := env.DBProvider.GetDBHandle("testfullscaniterator")
+	assert.NoError(t, err)
+	// another db sharing the same leveldb should not be included in the scan
+	otherDB, err := env.DBProvider.GetDBHandle("testfullscaniterator1")
+	assert.NoError(t, err)
+
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	batch.PutValAndMetadata("ns1", "key2", []byte("value2"), []byte("metadata2"), version.NewHeight(1, 2))
+	batch.Put("ns2", "key1", []byte("value3"), version.NewHeight(1, 3))
+	batch.Put("ns3", "key1", []byte("value4"), version.NewHeight(1, 4))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 4)))
+	otherBatch := statedb.NewUpdateBatch()
+	otherBatch.Put("ns1", "key3", []byte("value5"), version.NewHeight(1, 1))
+	assert.NoError(t, otherDB.ApplyUpdates(otherBatch, version.NewHeight(1, 1)))
+
+	itr, err := db.(statedb.FullScannable).GetFullScanIterator(
+		func(ns string) bool { return ns == "ns2" },
+	)
+	assert.NoError(t, err)
+	defer itr.Close()
+
+	var results []*statedb.VersionedKV
+	for {
+		kv, err := itr.Next()
+		assert.NoError(t, err)
+		if kv == nil {
+			break
+		}
+		results = append(results, kv)
+	}
+	assert.Equal(t, []*statedb.VersionedKV{
+		{
+			CompositeKey:   statedb.CompositeKey{Namespace: "ns1", Key: "key1"},
+			VersionedValue: statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)},
+		},
+		{
+			CompositeKey:   statedb.CompositeKey{Namespace: "ns1", Key: "key2"},
+			VersionedValue: statedb.VersionedValue{Value: []byte("value2"), Metadata: []byte("metadata2"), Version: version.NewHeight(1, 2)},
+		},
+		{
+			CompositeKey:   statedb.CompositeKey{Namespace: "ns3", Key: "key1"},
+			VersionedValue: statedb.VersionedValue{Value: []byte("value4"), Version: version.NewHeight(1, 4)},
+		},
+	}, results)
+}
+
+func TestRetrieveSavepoint(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	dbPath := ledgerconfig.GetStateLevelDBPath()
+
+	db, err := env.DBProvider.GetDBHandle("testretrievesavepoint")
+	assert.NoError(t, err)
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte("value1"), version.NewHeight(5, 1))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(5, 1)))
+
+	// the state database is in use
+	_, err = RetrieveSavepoint(dbPath, "testretrievesavepoint")
+	assert.Error(t, err)
+	env.DBProvider.Close()
+
+	savepoint, err := RetrieveSavepoint(dbPath, "testretrievesavepoint")
+	assert.NoError(t, err)
+	assert.Equal(t, version.NewHeight(5, 1), savepoint)
+	savepoint, err = RetrieveSavepoint(dbPath, "non-existing-ledger")
+	assert.NoError(t, err)
+	assert.Nil(t, savepoint)
+}
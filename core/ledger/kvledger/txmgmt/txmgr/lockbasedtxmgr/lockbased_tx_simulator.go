@@ -12,6 +12,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
 	"github.com/pkg/errors"
 )
 
@@ -161,9 +162,80 @@ func (s *lockBasedTxSimulator) GetTxSimulationResults() (*ledger.TxSimulationRes
 	return s.rwsetBuilder.GetTxSimulationResults()
 }
 
-// ExecuteUpdate implements method in interface `ledger.TxSimulator`
+// ExecuteUpdate implements method in interface `ledger.TxSimulator`.
+// The query is expected in the format of `updateSpec`. The documents that match the selector are updated and
+// added to the write set. As the results of a rich query cannot be re-evaluated during validation, the complete
+// namespace is scanned and added to the range query set for protecting against phantom reads. Hence, the
+// transaction gets invalidated if any key in the namespace is modified after the simulation
 func (s *lockBasedTxSimulator) ExecuteUpdate(query string) error {
-	return errors.New("not supported")
+	if err := s.helper.checkDone(); err != nil {
+		return err
+	}
+	if err := s.checkPvtdataQueryPerformed(); err != nil {
+		return err
+	}
+	if err := s.checkPaginatedQueryPerformed(); err != nil {
+		return err
+	}
+	spec, err := parseUpdateSpec(query)
+	if err != nil {
+		return err
+	}
+	mangoQuery, err := spec.mangoQuery()
+	if err != nil {
+		return err
+	}
+
+	itr, err := s.helper.executeQuery(spec.Namespace, mangoQuery)
+	if err != nil {
+		return err
+	}
+	defer itr.Close()
+	var updates []*queryresult.KV
+	for {
+		result, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if result == nil {
+			break
+		}
+		kv := result.(*queryresult.KV)
+		value, err := spec.apply(kv.Key, kv.Value)
+		if err != nil {
+			return err
+		}
+		updates = append(updates, &queryresult.KV{Namespace: kv.Namespace, Key: kv.Key, Value: value})
+	}
+
+	if err := s.scanNamespace(spec.Namespace); err != nil {
+		return err
+	}
+	for _, kv := range updates {
+		if err := s.SetState(kv.Namespace, kv.Key, kv.Value); err != nil {
+			return err
+		}
+	}
+	logger.Debugf("txid [%s]: ExecuteUpdate updated [%d] keys in namespace [%s]", s.txid, len(updates), spec.Namespace)
+	return nil
+}
+
+// scanNamespace iterates over all the keys in the namespace so that the range query info gets recorded
+func (s *lockBasedTxSimulator) scanNamespace(namespace string) error {
+	itr, err := s.helper.getStateRangeScanIterator(namespace, "", "")
+	if err != nil {
+		return err
+	}
+	defer itr.Close()
+	for {
+		result, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if result == nil {
+			return nil
+		}
+	}
 }
 
 func (s *lockBasedTxSimulator) checkWritePrecondition(key string, value []byte) error {
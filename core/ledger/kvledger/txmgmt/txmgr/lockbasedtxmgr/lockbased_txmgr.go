@@ -397,6 +397,58 @@ func (uniquePvtData uniquePvtDataMap) transformToUpdateBatch() *privacyenabledst
 	return batch
 }
 
+// RemovePvtDataOfOldBlocks implements method in interface `txmgmt.TxMgr`
+// It deletes from the stateDB the pvt keys whose committed value was written by the passed
+// blocksPvtData. The corresponding hashed keys are retained in the stateDB. A pvt key that has
+// been overwritten by a transaction outside of the passed blocksPvtData is left as is.
+func (txmgr *LockBasedTxMgr) RemovePvtDataOfOldBlocks(blocksPvtData map[uint64][]*ledger.TxPvtData) error {
+	logger.Debug("Waiting for purge mgr to finish the background job of computing expirying keys for the block")
+	txmgr.pvtdataPurgeMgr.WaitForPrepareToFinish()
+	txmgr.oldBlockCommit.Lock()
+	defer txmgr.oldBlockCommit.Unlock()
+	logger.Debug("lock acquired on oldBlockCommit for removing pvtData of old blocks from state database")
+
+	batch := privacyenabledstate.NewUpdateBatch()
+	for blkNum, blockPvtData := range blocksPvtData {
+		for _, txPvtData := range blockPvtData {
+			ver := version.NewHeight(blkNum, txPvtData.SeqInBlock)
+			for _, nsPvtData := range txPvtData.WriteSet.NsPvtRwset {
+				for _, collPvtData := range nsPvtData.CollectionPvtRwset {
+					if err := txmgr.addDeletesForCommittedPvtWrites(batch.PvtUpdates, nsPvtData.Namespace, collPvtData, ver); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	if batch.PvtUpdates.IsEmpty() {
+		return nil
+	}
+	logger.Debug("Committing deletes of pvtData of old blocks to state database")
+	return txmgr.db.ApplyPrivacyAwareUpdates(batch, nil)
+}
+
+func (txmgr *LockBasedTxMgr) addDeletesForCommittedPvtWrites(pvtUpdates *privacyenabledstate.PvtUpdateBatch,
+	ns string, collPvtData *rwset.CollectionPvtReadWriteSet, ver *version.Height) error {
+
+	kvRWSet := &kvrwset.KVRWSet{}
+	if err := proto.Unmarshal(collPvtData.Rwset, kvRWSet); err != nil {
+		return err
+	}
+	coll := collPvtData.CollectionName
+	for _, kvWrite := range kvRWSet.Writes {
+		committedPvtVerVal, err := txmgr.db.GetPrivateData(ns, coll, kvWrite.Key)
+		if err != nil {
+			return err
+		}
+		if committedPvtVerVal == nil || !version.AreSame(committedPvtVerVal.Version, ver) {
+			continue
+		}
+		pvtUpdates.Delete(ns, coll, kvWrite.Key, ver)
+	}
+	return nil
+}
+
 func (txmgr *LockBasedTxMgr) invokeNamespaceListeners() error {
 	for _, listener := range txmgr.stateListeners {
 		stateUpdatesForListener := extractStateUpdates(txmgr.current.batch, listener.InterestedInNamespaces())
@@ -659,18 +659,14 @@ func createTestValue(i int) []byte {
 	return []byte(fmt.Sprintf("value_%03d", i))
 }
 
-//TestExecuteQueryQuery is only tested on the CouchDB testEnv
 func TestExecuteQuery(t *testing.T) {
 
 	for _, testEnv := range testEnvs {
-		// Query is only supported and tested on the CouchDB testEnv
-		if testEnv.getName() == couchDBtestEnvName {
-			t.Logf("Running test for TestEnv = %s", testEnv.getName())
-			testLedgerID := "testexecutequery"
-			testEnv.init(t, testLedgerID, nil)
-			testExecuteQuery(t, testEnv)
-			testEnv.cleanup()
-		}
+		t.Logf("Running test for TestEnv = %s", testEnv.getName())
+		testLedgerID := "testexecutequery"
+		testEnv.init(t, testLedgerID, nil)
+		testExecuteQuery(t, testEnv)
+		testEnv.cleanup()
 	}
 }
 
@@ -734,18 +730,14 @@ func testExecuteQuery(t *testing.T, env testEnv) {
 	assert.Equal(t, 3, counter)
 }
 
-// TestExecutePaginatedQuery is only tested on the CouchDB testEnv
 func TestExecutePaginatedQuery(t *testing.T) {
 
 	for _, testEnv := range testEnvs {
-		// Query is only supported and tested on the CouchDB testEnv
-		if testEnv.getName() == couchDBtestEnvName {
-			t.Logf("Running test for TestEnv = %s", testEnv.getName())
-			testLedgerID := "testexecutepaginatedquery"
-			testEnv.init(t, testLedgerID, nil)
-			testExecutePaginatedQuery(t, testEnv)
-			testEnv.cleanup()
-		}
+		t.Logf("Running test for TestEnv = %s", testEnv.getName())
+		testLedgerID := "testexecutepaginatedquery"
+		testEnv.init(t, testLedgerID, nil)
+		testExecutePaginatedQuery(t, testEnv)
+		testEnv.cleanup()
 	}
 }
 
@@ -899,18 +891,14 @@ func TestTxSimulatorUnsupportedTx(t *testing.T) {
 
 }
 
-// TestTxSimulatorQueryUnsupportedTx is only tested on the CouchDB testEnv
 func TestTxSimulatorQueryUnsupportedTx(t *testing.T) {
 
 	for _, testEnv := range testEnvs {
-		// Query is only supported and tested on the CouchDB testEnv
-		if testEnv.getName() == couchDBtestEnvName {
-			t.Logf("Running test for TestEnv = %s", testEnv.getName())
-			testLedgerID := "testtxsimulatorunsupportedtxqueries"
-			testEnv.init(t, testLedgerID, nil)
-			testTxSimulatorQueryUnsupportedTx(t, testEnv)
-			testEnv.cleanup()
-		}
+		t.Logf("Running test for TestEnv = %s", testEnv.getName())
+		testLedgerID := "testtxsimulatorunsupportedtxqueries"
+		testEnv.init(t, testLedgerID, nil)
+		testTxSimulatorQueryUnsupportedTx(t, testEnv)
+		testEnv.cleanup()
 	}
 }
 
@@ -950,6 +938,73 @@ func testTxSimulatorQueryUnsupportedTx(t *testing.T, env testEnv) {
 
 }
 
+func TestExecuteUpdate(t *testing.T) {
+	for _, testEnv := range testEnvs {
+		t.Logf("Running test for TestEnv = %s", testEnv.getName())
+		testLedgerID := "testexecuteupdate"
+		testEnv.init(t, testLedgerID, nil)
+		testExecuteUpdate(t, testEnv)
+		testEnv.cleanup()
+	}
+}
+
+func testExecuteUpdate(t *testing.T, env testEnv) {
+	txMgr := env.getTxMgr()
+	txMgrHelper := newTxMgrTestHelper(t, txMgr)
+
+	s1, _ := txMgr.NewTxSimulator("test_tx1")
+	s1.SetState("ns1", "key1", []byte(`{"asset_name":"marble1","color":"red","owner":"tom"}`))
+	s1.SetState("ns1", "key2", []byte(`{"asset_name":"marble2","color":"blue","owner":"bob"}`))
+	s1.SetState("ns1", "key3", []byte(`{"asset_name":"marble3","color":"green","owner":"tom","tmp":"x"}`))
+	s1.Done()
+	txRWSet, _ := s1.GetTxSimulationResults()
+	txMgrHelper.validateAndCommitRWSet(txRWSet.PubSimulationResults)
+
+	updateQuery := `{"namespace":"ns1","selector":{"owner":"tom"},"set":{"owner":"jerry"},"unset":["tmp"]}`
+	s2, _ := txMgr.NewTxSimulator("test_tx2")
+	assert.NoError(t, s2.ExecuteUpdate(updateQuery))
+	s2.Done()
+	txRWSet2, _ := s2.GetTxSimulationResults()
+	txMgrHelper.validateAndCommitRWSet(txRWSet2.PubSimulationResults)
+	checkState := func(key, expectedValue string) {
+		qe, _ := txMgr.NewQueryExecutor("test_tx")
+		defer qe.Done()
+		value, err := qe.GetState("ns1", key)
+		assert.NoError(t, err)
+		assert.JSONEq(t, expectedValue, string(value))
+	}
+	checkState("key1", `{"asset_name":"marble1","color":"red","owner":"jerry"}`)
+	checkState("key2", `{"asset_name":"marble2","color":"blue","owner":"bob"}`)
+	checkState("key3", `{"asset_name":"marble3","color":"green","owner":"jerry"}`)
+
+	// a key added to the namespace after the simulation invalidates the update
+	updateQuery = `{"namespace":"ns1","selector":{"owner":"jerry"},"set":{"color":"black"}}`
+	s3, _ := txMgr.NewTxSimulator("test_tx3")
+	assert.NoError(t, s3.ExecuteUpdate(updateQuery))
+	s3.Done()
+	txRWSet3, _ := s3.GetTxSimulationResults()
+	s4, _ := txMgr.NewTxSimulator("test_tx4")
+	s4.SetState("ns1", "key4", []byte(`{"asset_name":"marble4","color":"red","owner":"jerry"}`))
+	s4.Done()
+	txRWSet4, _ := s4.GetTxSimulationResults()
+	txMgrHelper.validateAndCommitRWSet(txRWSet4.PubSimulationResults)
+	txMgrHelper.checkRWsetInvalid(txRWSet3.PubSimulationResults)
+
+	// an update is a write and is not allowed after a paginated query
+	s5, _ := txMgr.NewTxSimulator("test_tx5")
+	_, err := s5.ExecuteQueryWithMetadata("ns1", `{"selector":{"owner":"bob"}}`, map[string]interface{}{"limit": int32(2)})
+	assert.NoError(t, err)
+	err = s5.ExecuteUpdate(updateQuery)
+	_, ok := err.(*txmgr.ErrUnsupportedTransaction)
+	assert.True(t, ok)
+	s5.Done()
+
+	s6, _ := txMgr.NewTxSimulator("test_tx6")
+	err = s6.ExecuteUpdate(`{"selector":{"owner":"tom"},"set":{"owner":"jerry"}}`)
+	assert.EqualError(t, err, "namespace is missing in the update query")
+	s6.Done()
+}
+
 func TestConstructUniquePvtData(t *testing.T) {
 	v1 := []byte{1}
 	// ns1-coll1-key1 should be rejected as it is updated in the future by Blk2Tx1
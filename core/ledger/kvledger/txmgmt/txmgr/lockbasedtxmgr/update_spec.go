@@ -0,0 +1,146 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lockbasedtxmgr
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// updateSpec is the JSON representation of the query that is passed to the function `ExecuteUpdate`. For instance,
+//   {"namespace":"mycc","selector":{"owner":"tom"},"set":{"owner":"jerry","audit.migrated":true},"unset":["tmp"]}
+// updates all the documents in the namespace "mycc" that match the Mango selector. The fields in `set` and `unset`
+// are the paths of the fields in dot notation. The missing intermediate objects of a path in `set` are created
+type updateSpec struct {
+	Namespace string                     `json:"namespace"`
+	Selector  json.RawMessage            `json:"selector"`
+	UseIndex  json.RawMessage            `json:"use_index,omitempty"`
+	Set       map[string]json.RawMessage `json:"set,omitempty"`
+	Unset     []string                   `json:"unset,omitempty"`
+
+	setPaths []string
+}
+
+func parseUpdateSpec(query string) (*updateSpec, error) {
+	spec := &updateSpec{}
+	decoder := json.NewDecoder(strings.NewReader(query))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(spec); err != nil {
+		return nil, errors.Wrap(err, "error parsing the update query")
+	}
+	if spec.Namespace == "" {
+		return nil, errors.New("namespace is missing in the update query")
+	}
+	if len(spec.Selector) == 0 {
+		return nil, errors.New("selector is missing in the update query")
+	}
+	if len(spec.Set) == 0 && len(spec.Unset) == 0 {
+		return nil, errors.New("no field to set or unset is specified in the update query")
+	}
+	// the fields are set in the order of their paths so that applying the update is deterministic
+	for path := range spec.Set {
+		spec.setPaths = append(spec.setPaths, path)
+	}
+	sort.Strings(spec.setPaths)
+	allPaths := append(append([]string{}, spec.setPaths...), spec.Unset...)
+	for i, path := range allPaths {
+		for _, field := range strings.Split(path, ".") {
+			if field == "" {
+				return nil, errors.Errorf("invalid field path [%s] in the update query", path)
+			}
+		}
+		for _, otherPath := range allPaths[i+1:] {
+			if path == otherPath || strings.HasPrefix(path, otherPath+".") || strings.HasPrefix(otherPath, path+".") {
+				return nil, errors.Errorf("conflicting field paths [%s] and [%s] in the update query", path, otherPath)
+			}
+		}
+	}
+	return spec, nil
+}
+
+// mangoQuery returns the Mango query that selects the documents to be updated
+func (spec *updateSpec) mangoQuery() (string, error) {
+	query := map[string]json.RawMessage{"selector": spec.Selector}
+	if len(spec.UseIndex) > 0 {
+		query["use_index"] = spec.UseIndex
+	}
+	queryBytes, err := json.Marshal(query)
+	if err != nil {
+		return "", errors.Wrap(err, "error constructing the query for the update")
+	}
+	return string(queryBytes), nil
+}
+
+// apply returns the value that results from applying the update to the given value of the key
+func (spec *updateSpec) apply(key string, value []byte) ([]byte, error) {
+	doc := map[string]interface{}{}
+	if err := unmarshalJSON(value, &doc); err != nil || doc == nil {
+		return nil, errors.Errorf("value of the key [%s] is not a JSON object", key)
+	}
+	for _, path := range spec.setPaths {
+		var fieldValue interface{}
+		if err := unmarshalJSON(spec.Set[path], &fieldValue); err != nil {
+			return nil, errors.Wrapf(err, "error parsing the value of the field [%s] in the update query", path)
+		}
+		if err := setField(doc, strings.Split(path, "."), fieldValue); err != nil {
+			return nil, errors.WithMessage(err, "error updating the value of the key ["+key+"]")
+		}
+	}
+	for _, path := range spec.Unset {
+		unsetField(doc, strings.Split(path, "."))
+	}
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(doc); err != nil {
+		return nil, errors.Wrapf(err, "error marshaling the updated value of the key [%s]", key)
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+func setField(doc map[string]interface{}, fields []string, value interface{}) error {
+	for _, field := range fields[:len(fields)-1] {
+		nested, ok := doc[field]
+		if !ok {
+			nested = map[string]interface{}{}
+			doc[field] = nested
+		}
+		if doc, ok = nested.(map[string]interface{}); !ok {
+			return errors.Errorf("field [%s] is not a JSON object", field)
+		}
+	}
+	doc[fields[len(fields)-1]] = value
+	return nil
+}
+
+func unsetField(doc map[string]interface{}, fields []string) {
+	for _, field := range fields[:len(fields)-1] {
+		nested, ok := doc[field].(map[string]interface{})
+		if !ok {
+			return
+		}
+		doc = nested
+	}
+	delete(doc, fields[len(fields)-1])
+}
+
+// unmarshalJSON decodes the numbers as json.Number so that their representation is retained
+func unmarshalJSON(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+	if decoder.More() {
+		return errors.New("unexpected data after the JSON value")
+	}
+	return nil
+}
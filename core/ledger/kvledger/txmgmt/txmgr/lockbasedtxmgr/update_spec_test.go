@@ -0,0 +1,67 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lockbasedtxmgr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseUpdateSpec(t *testing.T) {
+	spec, err := parseUpdateSpec(`{"namespace":"ns1","selector":{"owner":"tom"},"use_index":"indexOwner",` +
+		`"set":{"owner":"jerry","audit.migrated":true},"unset":["tmp"]}`)
+	assert.NoError(t, err)
+	assert.Equal(t, "ns1", spec.Namespace)
+	assert.Equal(t, []string{"audit.migrated", "owner"}, spec.setPaths)
+	mangoQuery, err := spec.mangoQuery()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"selector":{"owner":"tom"},"use_index":"indexOwner"}`, mangoQuery)
+
+	testCases := []struct {
+		name        string
+		query       string
+		expectedErr string
+	}{
+		{"invalid-json", `{"namespace":`, "error parsing the update query: unexpected EOF"},
+		{"unknown-field", `{"namespace":"ns1","selector":{},"set":{"a":1},"limit":10}`,
+			`error parsing the update query: json: unknown field "limit"`},
+		{"missing-namespace", `{"selector":{},"set":{"a":1}}`, "namespace is missing in the update query"},
+		{"missing-selector", `{"namespace":"ns1","set":{"a":1}}`, "selector is missing in the update query"},
+		{"missing-fields", `{"namespace":"ns1","selector":{}}`, "no field to set or unset is specified in the update query"},
+		{"empty-field", `{"namespace":"ns1","selector":{},"set":{"a..b":1}}`, "invalid field path [a..b] in the update query"},
+		{"conflicting-fields", `{"namespace":"ns1","selector":{},"set":{"a.b":1},"unset":["a"]}`,
+			"conflicting field paths [a.b] and [a] in the update query"},
+		{"duplicate-fields", `{"namespace":"ns1","selector":{},"set":{"a":1},"unset":["a"]}`,
+			"conflicting field paths [a] and [a] in the update query"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := parseUpdateSpec(testCase.query)
+			assert.EqualError(t, err, testCase.expectedErr)
+		})
+	}
+}
+
+func TestApplyUpdateSpec(t *testing.T) {
+	spec, err := parseUpdateSpec(`{"namespace":"ns1","selector":{},` +
+		`"set":{"owner":"jerry","audit.migrated":true,"audit.note":"a<b"},"unset":["tmp","missing.field"]}`)
+	assert.NoError(t, err)
+
+	value, err := spec.apply("key1", []byte(`{"owner":"tom","size":12345678901234567890,"tmp":"x","audit":{"by":"admin"}}`))
+	assert.NoError(t, err)
+	assert.Equal(t,
+		`{"audit":{"by":"admin","migrated":true,"note":"a<b"},"owner":"jerry","size":12345678901234567890}`,
+		string(value))
+
+	_, err = spec.apply("key2", []byte("not-json"))
+	assert.EqualError(t, err, "value of the key [key2] is not a JSON object")
+	_, err = spec.apply("key3", []byte(`["an","array"]`))
+	assert.EqualError(t, err, "value of the key [key3] is not a JSON object")
+	_, err = spec.apply("key4", []byte(`{"audit":"not-an-object"}`))
+	assert.EqualError(t, err, "error updating the value of the key [key4]: field [audit] is not a JSON object")
+}
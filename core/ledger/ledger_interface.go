@@ -8,6 +8,7 @@ package ledger
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-lib-go/healthz"
@@ -35,6 +36,10 @@ type PeerLedgerProvider interface {
 	// This function guarantees that the creation of ledger and committing the genesis block would an atomic action
 	// The chain id retrieved from the genesis block is treated as a ledger id
 	Create(genesisBlock *common.Block) (PeerLedger, error)
+	// CreateFromSnapshot creates a new ledger from the snapshot in the given directory, as generated
+	// by a `SnapshotGenerator`. The ledger starts with the block next to the last block in the snapshot.
+	// The channel name recorded in the snapshot is treated as a ledger id and is returned along with the ledger
+	CreateFromSnapshot(snapshotDir string) (PeerLedger, string, error)
 	// Open opens an already created ledger
 	Open(ledgerID string) (PeerLedger, error)
 	// Exists tells whether the ledger with given id exists
@@ -98,6 +103,36 @@ type PeerLedger interface {
 	GetMissingPvtDataTracker() (MissingPvtDataTracker, error)
 }
 
+// SnapshotGenerator is implemented by the ledgers that can generate a snapshot which
+// can be used by other peers for creating the ledger via `PeerLedgerProvider.CreateFromSnapshot`
+type SnapshotGenerator interface {
+	// GenerateSnapshot generates a snapshot at the last committed block in the given directory
+	GenerateSnapshot(snapshotDir string) error
+}
+
+// TransactionIndexQuerier is implemented by the ledgers that can look up the transactions via the
+// secondary transaction indexes of their block store. A query fails if the corresponding index is not
+// enabled. The results returned by the iterators are of type `*peer.ProcessedTransaction`
+type TransactionIndexQuerier interface {
+	// GetTransactionsByCreatorMSPID returns the transactions that are created by the members of the given MSP
+	GetTransactionsByCreatorMSPID(mspID string) (commonledger.ResultsIterator, error)
+	// GetTransactionsByChaincodeName returns the endorser transactions that invoke the given chaincode
+	GetTransactionsByChaincodeName(chaincodeName string) (commonledger.ResultsIterator, error)
+	// GetTransactionsByTimeRange returns the transactions with a timestamp in the range [startTime, endTime)
+	GetTransactionsByTimeRange(startTime, endTime time.Time) (commonledger.ResultsIterator, error)
+}
+
+// BlockRetentionPolicy is a `commonledger.PrunePolicy` that specifies the blocks to be retained when
+// the ledger is pruned. A block is retained if it satisfies any of the specified criteria and a zero value
+// disables the corresponding criterion. Irrespective of the policy, a ledger retains the last block, the latest
+// config block, and the blocks that are yet to be committed to the state and the history databases
+type BlockRetentionPolicy struct {
+	// RetainLastNBlocks retains the given number of most recent blocks
+	RetainLastNBlocks uint64
+	// RetainFromBlockNum retains the blocks with a number greater than or equal to the given one
+	RetainFromBlockNum uint64
+}
+
 // ValidatedLedger represents the 'final ledger' after filtering out invalid transactions from PeerLedger.
 // Post-v1
 type ValidatedLedger interface {
@@ -178,6 +213,13 @@ type HistoryQueryExecutor interface {
 	// GetHistoryForKey retrieves the history of values for a key.
 	// The returned ResultsIterator contains results of type *KeyModification which is defined in protos/ledger/queryresult.
 	GetHistoryForKey(namespace string, key string) (commonledger.ResultsIterator, error)
+	// GetHistoryForKeyWithMetadata retrieves the history of values for a key as restricted by the metadata.
+	// metadata is a map of additional query parameters. The supported parameters are "startBlock" and "endBlock"
+	// (uint64) that bound the block heights as [startBlock, endBlock), "startTime" and "endTime" (*timestamp.Timestamp)
+	// that bound the transaction timestamps as [startTime, endTime), "descending" (bool) that reverses the order
+	// of the results, and "limit" (int32) and "bookmark" (string) for the pagination
+	// The returned ResultsIterator contains results of type *KeyModification which is defined in protos/ledger/queryresult.
+	GetHistoryForKeyWithMetadata(namespace string, key string, metadata map[string]interface{}) (QueryResultsIterator, error)
 }
 
 // TxSimulator simulates a transaction on a consistent snapshot of the 'as recent state as possible'
@@ -194,7 +236,10 @@ type TxSimulator interface {
 	SetStateMetadata(namespace, key string, metadata map[string][]byte) error
 	// DeleteStateMetadata deletes the metadata (if any) associated with an existing key-tuple <namespace, key>
 	DeleteStateMetadata(namespace, key string) error
-	// ExecuteUpdate for supporting rich data model (see comments on QueryExecutor above)
+	// ExecuteUpdate for supporting rich data model (see comments on QueryExecutor above).
+	// The query is a JSON object that contains the namespace, a Mango selector, and the fields to set and unset
+	// in the matching documents - e.g., {"namespace":"mycc","selector":{"owner":"tom"},"set":{"owner":"jerry"},"unset":["tmp"]}.
+	// Only used for state databases that support query
 	ExecuteUpdate(query string) error
 	// SetPrivateData sets the given value to a key in the private data state represented by the tuple <namespace, collection, key>
 	SetPrivateData(namespace, collection, key string, value []byte) error
@@ -22,15 +22,33 @@ func IsCouchDBEnabled() bool {
 	return false
 }
 
+// names of the built-in state databases
+const (
+	StateDatabaseGoLevelDB = "goleveldb"
+	StateDatabaseCouchDB   = "CouchDB"
+)
+
+// GetStateDatabase returns the name of the state database, which is either one of the built-in
+// state databases or a state database registered via the peer.handlers.stateDatabases config
+func GetStateDatabase() string {
+	stateDatabase := viper.GetString("ledger.state.stateDatabase")
+	if stateDatabase == "" {
+		return StateDatabaseGoLevelDB
+	}
+	return stateDatabase
+}
+
 const confPeerFileSystemPath = "peer.fileSystemPath"
 const confLedgersData = "ledgersData"
 const confLedgerProvider = "ledgerProvider"
 const confStateleveldb = "stateLeveldb"
+const confStateDatabases = "stateDatabases"
 const confHistoryLeveldb = "historyLeveldb"
 const confBookkeeper = "bookkeeper"
 const confConfigHistory = "configHistory"
 const confChains = "chains"
 const confPvtdataStore = "pvtdataStore"
+const confFileLock = "fileLock"
 const confTotalQueryLimit = "ledger.state.totalQueryLimit"
 const confInternalQueryLimit = "ledger.state.couchDBConfig.internalQueryLimit"
 const confEnableHistoryDatabase = "ledger.history.enableHistoryDatabase"
@@ -38,6 +56,8 @@ const confMaxBatchSize = "ledger.state.couchDBConfig.maxBatchUpdateSize"
 const confAutoWarmIndexes = "ledger.state.couchDBConfig.autoWarmIndexes"
 const confWarmIndexesAfterNBlocks = "ledger.state.couchDBConfig.warmIndexesAfterNBlocks"
 
+var confBlockRetentionBlocks = &conf{"ledger.blockchain.retention.retainBlocks", 0}
+var confBlockRetentionPruneInterval = &conf{"ledger.blockchain.retention.pruneInterval", 1000}
 var confCollElgProcMaxDbBatchSize = &conf{"ledger.pvtdataStore.collElgProcMaxDbBatchSize", 5000}
 var confCollElgProcDbBatchesInterval = &conf{"ledger.pvtdataStore.collElgProcDbBatchesInterval", 1000}
 
@@ -58,6 +78,12 @@ func GetStateLevelDBPath() string {
 	return filepath.Join(GetRootPath(), confStateleveldb)
 }
 
+// GetStateDatabasePath returns the filesystem path that is reserved for the given state database
+// registered via the peer.handlers.stateDatabases config
+func GetStateDatabasePath(stateDatabase string) string {
+	return filepath.Join(GetRootPath(), confStateDatabases, stateDatabase)
+}
+
 // GetHistoryLevelDBPath returns the filesystem path that is used to maintain the history level db
 func GetHistoryLevelDBPath() string {
 	return filepath.Join(GetRootPath(), confHistoryLeveldb)
@@ -83,11 +109,43 @@ func GetConfigHistoryPath() string {
 	return filepath.Join(GetRootPath(), confConfigHistory)
 }
 
+// GetFileLockPath returns the filesystem path that is used to create a file lock, which
+// ensures that only one process at a time operates on the ledger data
+func GetFileLockPath() string {
+	return filepath.Join(GetRootPath(), confFileLock)
+}
+
 // GetMaxBlockfileSize returns maximum size of the block file
 func GetMaxBlockfileSize() int {
 	return 64 * 1024 * 1024
 }
 
+// GetBlockRetentionBlocks returns the number of most recent blocks that the peer retains
+// when pruning the block storage. A value of zero disables the pruning
+func GetBlockRetentionBlocks() uint64 {
+	retainBlocks := viper.GetInt(confBlockRetentionBlocks.Name)
+	if retainBlocks <= 0 {
+		retainBlocks = confBlockRetentionBlocks.DefaultVal
+	}
+	return uint64(retainBlocks)
+}
+
+// GetBlockRetentionPruneInterval returns the interval in the terms of number of blocks
+// when the block retention policy is applied
+func GetBlockRetentionPruneInterval() uint64 {
+	pruneInterval := viper.GetInt(confBlockRetentionPruneInterval.Name)
+	if pruneInterval <= 0 {
+		pruneInterval = confBlockRetentionPruneInterval.DefaultVal
+	}
+	return uint64(pruneInterval)
+}
+
+// GetBlockStoreSecondaryIndexes returns the names of the secondary transaction indexes
+// that are maintained by the block storage in addition to the default indexes
+func GetBlockStoreSecondaryIndexes() []string {
+	return viper.GetStringSlice("ledger.blockchain.secondaryIndexes")
+}
+
 // GetTotalQueryLimit exposes the totalLimit variable
 func GetTotalQueryLimit() int {
 	totalQueryLimit := viper.GetInt(confTotalQueryLimit)
@@ -46,6 +46,18 @@ func TestIsCouchDBEnabled(t *testing.T) {
 	assert.True(t, updatedValue) //test config returns true
 }
 
+func TestGetStateDatabase(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	viper.Set("ledger.state.stateDatabase", "")
+	assert.Equal(t, StateDatabaseGoLevelDB, GetStateDatabase())
+	viper.Set("ledger.state.stateDatabase", "CouchDB")
+	assert.Equal(t, StateDatabaseCouchDB, GetStateDatabase())
+	viper.Set("ledger.state.stateDatabase", "sqldb")
+	assert.Equal(t, "sqldb", GetStateDatabase())
+	assert.Equal(t, "/var/hyperledger/production/ledgersData/stateDatabases/sqldb", GetStateDatabasePath("sqldb"))
+}
+
 func TestLedgerConfigPathDefault(t *testing.T) {
 	setUpCoreYAMLConfig()
 	assert.Equal(t, "/var/hyperledger/production/ledgersData", GetRootPath())
@@ -174,6 +186,25 @@ func TestCollElgProcDbBatchesInterval(t *testing.T) {
 	assert.Equal(t, testVal, GetPvtdataStoreCollElgProcDbBatchesInterval())
 }
 
+func TestBlockRetention(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	assert.Equal(t, uint64(0), GetBlockRetentionBlocks())
+	assert.Equal(t, uint64(1000), GetBlockRetentionPruneInterval())
+	viper.Set("ledger.blockchain.retention.retainBlocks", 5000)
+	viper.Set("ledger.blockchain.retention.pruneInterval", 100)
+	assert.Equal(t, uint64(5000), GetBlockRetentionBlocks())
+	assert.Equal(t, uint64(100), GetBlockRetentionPruneInterval())
+}
+
+func TestBlockStoreSecondaryIndexes(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	assert.Empty(t, GetBlockStoreSecondaryIndexes())
+	viper.Set("ledger.blockchain.secondaryIndexes", []string{"CreatorMSPID", "TxTimestamp"})
+	assert.Equal(t, []string{"CreatorMSPID", "TxTimestamp"}, GetBlockStoreSecondaryIndexes())
+}
+
 func TestIsHistoryDBEnabledDefault(t *testing.T) {
 	setUpCoreYAMLConfig()
 	defaultValue := IsHistoryDBEnabled()
@@ -9,15 +9,19 @@ package ledgermgmt
 import (
 	"bytes"
 	"sync"
+	"time"
 
 	"github.com/hyperledger/fabric/common/flogging"
+	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/handlers/statedatabase/api"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
 	"github.com/hyperledger/fabric/core/ledger/customtx"
 	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
@@ -46,6 +50,7 @@ type Initializer struct {
 	MembershipInfoProvider        ledger.MembershipInfoProvider
 	MetricsProvider               metrics.Provider
 	HealthCheckRegistry           ledger.HealthCheckRegistry
+	StateDBProviderFactories      map[string]statedatabase.PluginFactory
 }
 
 // Initialize initializes ledgermgmt
@@ -62,6 +67,7 @@ func initialize(initializer *Initializer) {
 	initialized = true
 	openedLedgers = make(map[string]ledger.PeerLedger)
 	customtx.Initialize(initializer.CustomTxProcessors)
+	privacyenabledstate.RegisterStateDBProviderFactories(initializer.StateDBProviderFactories)
 	cceventmgmt.Initialize(&chaincodeInfoProviderImpl{
 		initializer.PlatformRegistry,
 		initializer.DeployedChaincodeInfoProvider,
@@ -107,6 +113,25 @@ func CreateLedger(genesisBlock *common.Block) (ledger.PeerLedger, error) {
 	return l, nil
 }
 
+// CreateLedgerFromSnapshot creates a new ledger from the snapshot in the given directory.
+// The channel name recorded in the snapshot is treated as a ledger id
+func CreateLedgerFromSnapshot(snapshotDir string) (ledger.PeerLedger, error) {
+	lock.Lock()
+	defer lock.Unlock()
+	if !initialized {
+		return nil, ErrLedgerMgmtNotInitialized
+	}
+	logger.Infof("Creating ledger from snapshot in directory [%s]", snapshotDir)
+	l, id, err := ledgerProvider.CreateFromSnapshot(snapshotDir)
+	if err != nil {
+		return nil, err
+	}
+	l = wrapLedger(id, l)
+	openedLedgers[id] = l
+	logger.Infof("Created ledger [%s] from snapshot", id)
+	return l, nil
+}
+
 // OpenLedger returns a ledger for the given id
 func OpenLedger(id string) (ledger.PeerLedger, error) {
 	logger.Infof("Opening ledger with id = %s", id)
@@ -172,6 +197,50 @@ func (l *closableLedger) Close() {
 	l.closeWithoutLock()
 }
 
+// GenerateSnapshot implements the interface ledger.SnapshotGenerator if supported by the actual ledger
+func (l *closableLedger) GenerateSnapshot(snapshotDir string) error {
+	snapshotGenerator, ok := l.PeerLedger.(ledger.SnapshotGenerator)
+	if !ok {
+		return errors.Errorf("ledger [%s] does not support generating snapshots", l.id)
+	}
+	return snapshotGenerator.GenerateSnapshot(snapshotDir)
+}
+
+// GetTransactionsByCreatorMSPID implements the interface ledger.TransactionIndexQuerier if supported by the actual ledger
+func (l *closableLedger) GetTransactionsByCreatorMSPID(mspID string) (commonledger.ResultsIterator, error) {
+	querier, err := l.transactionIndexQuerier()
+	if err != nil {
+		return nil, err
+	}
+	return querier.GetTransactionsByCreatorMSPID(mspID)
+}
+
+// GetTransactionsByChaincodeName implements the interface ledger.TransactionIndexQuerier if supported by the actual ledger
+func (l *closableLedger) GetTransactionsByChaincodeName(chaincodeName string) (commonledger.ResultsIterator, error) {
+	querier, err := l.transactionIndexQuerier()
+	if err != nil {
+		return nil, err
+	}
+	return querier.GetTransactionsByChaincodeName(chaincodeName)
+}
+
+// GetTransactionsByTimeRange implements the interface ledger.TransactionIndexQuerier if supported by the actual ledger
+func (l *closableLedger) GetTransactionsByTimeRange(startTime, endTime time.Time) (commonledger.ResultsIterator, error) {
+	querier, err := l.transactionIndexQuerier()
+	if err != nil {
+		return nil, err
+	}
+	return querier.GetTransactionsByTimeRange(startTime, endTime)
+}
+
+func (l *closableLedger) transactionIndexQuerier() (ledger.TransactionIndexQuerier, error) {
+	querier, ok := l.PeerLedger.(ledger.TransactionIndexQuerier)
+	if !ok {
+		return nil, errors.Errorf("ledger [%s] does not support querying the transaction indexes", l.id)
+	}
+	return querier, nil
+}
+
 func (l *closableLedger) closeWithoutLock() {
 	l.PeerLedger.Close()
 	delete(openedLedgers, l.id)
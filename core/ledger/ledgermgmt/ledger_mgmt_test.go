@@ -18,6 +18,7 @@ package ledgermgmt
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"testing"
 
@@ -98,6 +99,33 @@ func TestLedgerMgmt(t *testing.T) {
 	Close()
 }
 
+func TestCreateLedgerFromSnapshot(t *testing.T) {
+	snapshotDir, err := ioutil.TempDir("", "ledgermgmt-snapshot")
+	assert.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+
+	InitializeTestEnv()
+	ledgerID := constructTestLedgerID(0)
+	gb, _ := test.MakeGenesisBlock(ledgerID)
+	l, err := CreateLedger(gb)
+	assert.NoError(t, err)
+	assert.NoError(t, l.(ledger.SnapshotGenerator).GenerateSnapshot(snapshotDir))
+	CleanupTestEnv()
+
+	InitializeTestEnv()
+	defer CleanupTestEnv()
+	l, err = CreateLedgerFromSnapshot(snapshotDir)
+	assert.NoError(t, err)
+	bcInfo, err := l.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), bcInfo.Height)
+	ids, err := GetLedgerIDs()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{ledgerID}, ids)
+	_, err = OpenLedger(ledgerID)
+	assert.Equal(t, ErrLedgerAlreadyOpened, err)
+}
+
 func TestChaincodeInfoProvider(t *testing.T) {
 	InitializeTestEnv()
 	defer CleanupTestEnv()
@@ -39,6 +39,24 @@ type Store struct {
 // NewProvider returns the handle to the provider
 func NewProvider() *Provider {
 	// Initialize the block storage
+	blockStoreProvider := fsblkstorage.NewProvider(blockStoreConf(), blockStoreIndexConfig())
+	pvtStoreProvider := pvtdatastorage.NewProvider()
+	return &Provider{blockStoreProvider, pvtStoreProvider}
+}
+
+// Rollback removes the blocks after the block `targetBlockNum` from the block store of the given ledger.
+// The pvt data store is left as is - while the removed blocks are committed again, the pvt data store
+// skips the pvt data of the blocks that it already contains. This function is expected to be invoked
+// while the stores are not in use (e.g., when the peer is stopped)
+func Rollback(ledgerid string, targetBlockNum uint64) error {
+	return fsblkstorage.Rollback(blockStoreConf(), blockStoreIndexConfig(), ledgerid, targetBlockNum)
+}
+
+func blockStoreConf() *fsblkstorage.Conf {
+	return fsblkstorage.NewConf(ledgerconfig.GetBlockStorePath(), ledgerconfig.GetMaxBlockfileSize())
+}
+
+func blockStoreIndexConfig() *blkstorage.IndexConfig {
 	attrsToIndex := []blkstorage.IndexableAttr{
 		blkstorage.IndexableAttrBlockHash,
 		blkstorage.IndexableAttrBlockNum,
@@ -47,13 +65,27 @@ func NewProvider() *Provider {
 		blkstorage.IndexableAttrBlockTxID,
 		blkstorage.IndexableAttrTxValidationCode,
 	}
-	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex}
-	blockStoreProvider := fsblkstorage.NewProvider(
-		fsblkstorage.NewConf(ledgerconfig.GetBlockStorePath(), ledgerconfig.GetMaxBlockfileSize()),
-		indexConfig)
+	var secondaryIndexes []blkstorage.SecondaryIndex
+	added := map[string]bool{}
+	for _, name := range ledgerconfig.GetBlockStoreSecondaryIndexes() {
+		index, ok := supportedSecondaryIndexes[name]
+		if !ok {
+			logger.Warningf("Ignoring unknown secondary index [%s] of the block storage", name)
+			continue
+		}
+		if added[name] {
+			continue
+		}
+		added[name] = true
+		secondaryIndexes = append(secondaryIndexes, index)
+	}
+	return &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex, SecondaryIndexes: secondaryIndexes}
+}
 
-	pvtStoreProvider := pvtdatastorage.NewProvider()
-	return &Provider{blockStoreProvider, pvtStoreProvider}
+var supportedSecondaryIndexes = map[string]blkstorage.SecondaryIndex{
+	blkstorage.SecondaryIndexCreatorMSPID:  blkstorage.CreatorMSPIDIndex{},
+	blkstorage.SecondaryIndexChaincodeName: blkstorage.ChaincodeNameIndex{},
+	blkstorage.SecondaryIndexTxTimestamp:   blkstorage.TxTimestampIndex{},
 }
 
 // Open opens the store
@@ -75,6 +107,28 @@ func (p *Provider) Open(ledgerid string) (*Store, error) {
 	return store, nil
 }
 
+// BootstrapFromSnapshot initializes the stores for a ledger that starts with the block next to the last block
+// of the given snapshot. The pvt data store records that the pvt data of the blocks up to the last block
+// of the snapshot is not available. The stores are expected to be opened via function `Open` afterwards
+func (p *Provider) BootstrapFromSnapshot(ledgerid string, snapshotInfo *blkstorage.BootstrappingSnapshotInfo) error {
+	blockStore, err := p.blkStoreProvider.BootstrapFromSnapshot(ledgerid, snapshotInfo)
+	if err != nil {
+		return err
+	}
+	blockStore.Shutdown()
+
+	pvtdataStore, err := p.pvtdataStoreProvider.OpenStore(ledgerid)
+	if err != nil {
+		return err
+	}
+	defer pvtdataStore.Shutdown()
+	lastBlockNum := snapshotInfo.LastBlock.Header.Number
+	if err := pvtdataStore.InitLastCommittedBlock(lastBlockNum); err != nil {
+		return err
+	}
+	return pvtdataStore.PurgeBelowBlock(lastBlockNum + 1)
+}
+
 // Close closes the provider
 func (p *Provider) Close() {
 	p.blkStoreProvider.Close()
@@ -241,6 +295,18 @@ func (s *Store) ResetLastUpdatedOldBlocksList() error {
 	return s.pvtdataStore.ResetLastUpdatedOldBlocksList()
 }
 
+// PurgePvtDataBelowBlock purges the pvt data of the blocks below the given block number from the underlying pvtdata store
+func (s *Store) PurgePvtDataBelowBlock(blockNum uint64) error {
+	s.rwlock.Lock()
+	defer s.rwlock.Unlock()
+	return s.pvtdataStore.PurgeBelowBlock(blockNum)
+}
+
+// PvtDataMinBlockNum invokes the function `MinBlockNum` on underlying pvtdata store
+func (s *Store) PvtDataMinBlockNum() (uint64, error) {
+	return s.pvtdataStore.MinBlockNum()
+}
+
 // init first invokes function `initFromExistingBlockchain`
 // in order to check whether the pvtdata store is present because of an upgrade
 // of peer from 1.0 and need to be updated with the existing blockchain. If, this is
@@ -324,6 +324,17 @@ func TestConstructPvtdataMap(t *testing.T) {
 	assert.Nil(t, constructPvtdataMap(nil))
 }
 
+func TestBlockStoreIndexConfig(t *testing.T) {
+	defer viper.Set("ledger.blockchain.secondaryIndexes", []string{})
+	assert.Empty(t, blockStoreIndexConfig().SecondaryIndexes)
+
+	viper.Set("ledger.blockchain.secondaryIndexes", []string{"TxTimestamp", "UnknownIndex", "CreatorMSPID", "TxTimestamp"})
+	assert.Equal(t,
+		[]blkstorage.SecondaryIndex{blkstorage.TxTimestampIndex{}, blkstorage.CreatorMSPIDIndex{}},
+		blockStoreIndexConfig().SecondaryIndexes,
+	)
+}
+
 func sampleDataWithPvtdataForSelectiveTx(t *testing.T) []*ledger.BlockAndPvtData {
 	var blockAndpvtdata []*ledger.BlockAndPvtData
 	blocks := testutil.ConstructTestBlocks(t, 10)
@@ -27,6 +27,7 @@ var (
 	ineligibleMissingDataKeyPrefix = []byte{5}
 	collElgKeyPrefix               = []byte{6}
 	lastUpdatedOldBlocksKey        = []byte{7}
+	pvtDataMinBlkKey               = []byte{8}
 
 	nilByte    = byte(0)
 	emptyValue = []byte{}
@@ -53,6 +54,15 @@ func decodeLastCommittedBlockVal(blockNumBytes []byte) uint64 {
 	return s
 }
 
+func encodePvtDataMinBlockVal(blockNum uint64) []byte {
+	return proto.EncodeVarint(blockNum)
+}
+
+func decodePvtDataMinBlockVal(blockNumBytes []byte) uint64 {
+	s, _ := proto.DecodeVarint(blockNumBytes)
+	return s
+}
+
 func encodeDataKey(key *dataKey) []byte {
 	dataKeyBytes := append(pvtDataKeyPrefix, version.NewHeight(key.blkNum, key.txNum).ToBytes()...)
 	dataKeyBytes = append(dataKeyBytes, []byte(key.ns)...)
@@ -204,6 +214,26 @@ func datakeyRange(blockNum uint64) (startKey, endKey []byte) {
 	return
 }
 
+func datakeyRangeBelowBlock(blockNum uint64) (startKey, endKey []byte) {
+	startKey = append(pvtDataKeyPrefix, version.NewHeight(0, 0).ToBytes()...)
+	endKey = append(pvtDataKeyPrefix, version.NewHeight(blockNum, 0).ToBytes()...)
+	return
+}
+
+func expirykeyRange() (startKey, endKey []byte) {
+	return expiryKeyPrefix, eligibleMissingDataKeyPrefix
+}
+
+func eligibleMissingdatakeyRangeBelowBlock(blockNum uint64) (startKey, endKey []byte) {
+	startKey = append(eligibleMissingDataKeyPrefix, util.EncodeReverseOrderVarUint64(blockNum-1)...)
+	endKey = ineligibleMissingDataKeyPrefix
+	return
+}
+
+func ineligibleMissingdatakeyRange() (startKey, endKey []byte) {
+	return ineligibleMissingDataKeyPrefix, collElgKeyPrefix
+}
+
 func eligibleMissingdatakeyRange(blkNum uint64) (startKey, endKey []byte) {
 	startKey = append(eligibleMissingDataKeyPrefix, util.EncodeReverseOrderVarUint64(blkNum)...)
 	endKey = append(eligibleMissingDataKeyPrefix, util.EncodeReverseOrderVarUint64(blkNum-1)...)
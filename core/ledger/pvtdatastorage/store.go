@@ -76,6 +76,13 @@ type Store interface {
 	GetLastUpdatedOldBlocksPvtData() (map[uint64][]*ledger.TxPvtData, error)
 	// ResetLastUpdatedOldBlocksList removes the `lastUpdatedOldBlocksList` entry from the store
 	ResetLastUpdatedOldBlocksList() error
+	// PurgeBelowBlock removes the pvt data of all the blocks with a number lesser than the given
+	// `blockNum`, along with the expiry and missing data entries of these blocks, and records `blockNum`
+	// as the lowest block number for which the pvt data is retained. The call is a noop if the pvt data
+	// of the blocks below `blockNum` has already been purged
+	PurgeBelowBlock(blockNum uint64) error
+	// MinBlockNum returns the lowest block number for which the pvt data is retained in the store
+	MinBlockNum() (uint64, error)
 	// IsEmpty returns true if the store does not have any block committed yet
 	IsEmpty() (bool, error)
 	// LastCommittedBlockHeight returns the height of the last committed block
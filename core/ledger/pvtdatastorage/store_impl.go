@@ -36,6 +36,7 @@ type store struct {
 
 	isEmpty            bool
 	lastCommittedBlock uint64
+	minBlockNum        uint64
 	batchPending       bool
 	purgerLock         sync.Mutex
 	collElgProcSync    *collElgProcSync
@@ -147,6 +148,9 @@ func (s *store) initState() error {
 	if s.batchPending, err = s.hasPendingCommit(); err != nil {
 		return err
 	}
+	if s.minBlockNum, err = s.getMinBlockNum(); err != nil {
+		return err
+	}
 	if blist, err = s.getLastUpdatedOldBlocksList(); err != nil {
 		return err
 	}
@@ -904,6 +908,63 @@ func (s *store) processCollElgEvents(maxBatchSize, batchesInterval int) {
 	logger.Debugf("Converted [%d] inelligible mising data entries to elligible", totalEntriesConverted)
 }
 
+// PurgeBelowBlock implements the function in the interface `Store`
+func (s *store) PurgeBelowBlock(blockNum uint64) error {
+	if blockNum > s.nextBlockNum() {
+		return &ErrIllegalArgs{fmt.Sprintf("Next block number=%d, purge requested below block number=%d", s.nextBlockNum(), blockNum)}
+	}
+	s.purgerLock.Lock()
+	defer s.purgerLock.Unlock()
+	if blockNum <= s.minBlockNum {
+		logger.Debugf("[%s] - Private data below block number [%d] has already been purged", s.ledgerid, s.minBlockNum)
+		return nil
+	}
+
+	batch := leveldbhelper.NewUpdateBatch()
+	numDataEntries := 0
+	itr := s.db.GetIterator(datakeyRangeBelowBlock(blockNum))
+	for itr.Next() {
+		batch.Delete(itr.Key())
+		numDataEntries++
+	}
+	itr.Release()
+
+	itr = s.db.GetIterator(expirykeyRange())
+	for itr.Next() {
+		if decodeExpiryKey(itr.Key()).committingBlk < blockNum {
+			batch.Delete(itr.Key())
+		}
+	}
+	itr.Release()
+
+	itr = s.db.GetIterator(eligibleMissingdatakeyRangeBelowBlock(blockNum))
+	for itr.Next() {
+		batch.Delete(itr.Key())
+	}
+	itr.Release()
+
+	itr = s.db.GetIterator(ineligibleMissingdatakeyRange())
+	for itr.Next() {
+		if decodeMissingDataKey(itr.Key()).blkNum < blockNum {
+			batch.Delete(itr.Key())
+		}
+	}
+	itr.Release()
+
+	batch.Put(pvtDataMinBlkKey, encodePvtDataMinBlockVal(blockNum))
+	if err := s.db.WriteBatch(batch, true); err != nil {
+		return err
+	}
+	atomic.StoreUint64(&s.minBlockNum, blockNum)
+	logger.Infof("[%s] - [%d] Entries purged from private data storage below block number [%d]", s.ledgerid, numDataEntries, blockNum)
+	return nil
+}
+
+// MinBlockNum implements the function in the interface `Store`
+func (s *store) MinBlockNum() (uint64, error) {
+	return atomic.LoadUint64(&s.minBlockNum), nil
+}
+
 // LastCommittedBlockHeight implements the function in the interface `Store`
 func (s *store) LastCommittedBlockHeight() (uint64, error) {
 	if s.isEmpty {
@@ -952,6 +1013,14 @@ func (s *store) getLastCommittedBlockNum() (bool, uint64, error) {
 	return false, decodeLastCommittedBlockVal(v), nil
 }
 
+func (s *store) getMinBlockNum() (uint64, error) {
+	v, err := s.db.Get(pvtDataMinBlkKey)
+	if v == nil || err != nil {
+		return 0, err
+	}
+	return decodePvtDataMinBlockVal(v), nil
+}
+
 type collElgProcSync struct {
 	notification, procComplete chan bool
 }
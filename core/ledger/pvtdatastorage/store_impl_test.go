@@ -8,6 +8,7 @@ package pvtdatastorage
 
 import (
 	"fmt"
+	"math"
 	"os"
 	"strings"
 	"testing"
@@ -627,6 +628,76 @@ func TestStorePurge(t *testing.T) {
 	assert.True(testDataKeyExists(t, s, &dataKey{nsCollBlk: nsCollBlk{ns: "ns-1", coll: "coll-2", blkNum: 1}, txNum: 2}))
 }
 
+func TestPurgeBelowBlock(t *testing.T) {
+	btlPolicy := btltestutil.SampleBTLPolicy(
+		map[[2]string]uint64{
+			{"ns-1", "coll-1"}: 0,
+			{"ns-1", "coll-2"}: 10,
+			{"ns-2", "coll-1"}: 0,
+			{"ns-2", "coll-2"}: 0,
+		},
+	)
+	env := NewTestStoreEnv(t, "TestPurgeBelowBlock", btlPolicy)
+	defer env.Cleanup()
+	assert := assert.New(t)
+	s := env.TestStore
+
+	minBlkNum, err := s.MinBlockNum()
+	assert.NoError(err)
+	assert.Equal(uint64(0), minBlkNum)
+
+	// write pvt data and missing data for blocks 0, 1, and 2
+	for blkNum := uint64(0); blkNum <= 2; blkNum++ {
+		missingData := make(ledger.TxMissingPvtDataMap)
+		missingData.Add(1, "ns-1", "coll-1", true)
+		missingData.Add(3, "ns-2", "coll-1", false)
+		testData := []*ledger.TxPvtData{
+			produceSamplePvtdata(t, 2, []string{"ns-1:coll-1", "ns-1:coll-2", "ns-2:coll-2"}),
+		}
+		assert.NoError(s.Prepare(blkNum, testData, missingData))
+		assert.NoError(s.Commit())
+	}
+	testWaitForPurgerRoutineToFinish(s)
+
+	// purging beyond the next block should not be allowed
+	_, ok := s.PurgeBelowBlock(4).(*ErrIllegalArgs)
+	assert.True(ok)
+
+	assert.NoError(s.PurgeBelowBlock(2))
+	minBlkNum, err = s.MinBlockNum()
+	assert.NoError(err)
+	assert.Equal(uint64(2), minBlkNum)
+
+	for blkNum := uint64(0); blkNum <= 2; blkNum++ {
+		retained := blkNum >= 2
+		pvtdata, err := s.GetPvtDataByBlockNum(blkNum, nil)
+		assert.NoError(err)
+		assert.Equal(retained, len(pvtdata) == 1)
+		assert.Equal(retained, testDataKeyExists(t, s, &dataKey{nsCollBlk{"ns-1", "coll-1", blkNum}, 2}))
+		assert.Equal(retained, testDataKeyExists(t, s, &dataKey{nsCollBlk{"ns-1", "coll-2", blkNum}, 2}))
+		assert.Equal(retained, testMissingDataKeyExists(t, s, &missingDataKey{nsCollBlk{"ns-1", "coll-1", blkNum}, true}))
+		assert.Equal(retained, testMissingDataKeyExists(t, s, &missingDataKey{nsCollBlk{"ns-2", "coll-1", blkNum}, false}))
+	}
+
+	expiryEntries, err := s.(*store).retrieveExpiryEntries(0, math.MaxUint64-1)
+	assert.NoError(err)
+	assert.Len(expiryEntries, 1)
+	assert.Equal(uint64(2), expiryEntries[0].key.committingBlk)
+
+	missingDataInfo, err := s.GetMissingPvtDataInfoForMostRecentBlocks(10)
+	assert.NoError(err)
+	assert.Equal(ledger.MissingPvtDataInfo{2: ledger.MissingBlockPvtdataInfo{1: {{Namespace: "ns-1", Collection: "coll-1"}}}}, missingDataInfo)
+
+	// purging below an already purged block is a noop and the minimum block number survives a restart
+	assert.NoError(s.PurgeBelowBlock(1))
+	env.CloseAndReopen()
+	s = env.TestStore
+	minBlkNum, err = s.MinBlockNum()
+	assert.NoError(err)
+	assert.Equal(uint64(2), minBlkNum)
+	assert.True(testDataKeyExists(t, s, &dataKey{nsCollBlk{"ns-1", "coll-1", 2}, 2}))
+}
+
 func TestStoreState(t *testing.T) {
 	btlPolicy := btltestutil.SampleBTLPolicy(
 		map[[2]string]uint64{
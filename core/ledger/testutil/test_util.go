@@ -80,6 +80,7 @@ func ResetConfigToDefaultValues() {
 	viper.Set("ledger.history.enableHistoryDatabase", false)
 	viper.Set("ledger.state.couchDBConfig.autoWarmIndexes", true)
 	viper.Set("ledger.state.couchDBConfig.warmIndexesAfterNBlocks", 1)
+	viper.Set("ledger.blockchain.secondaryIndexes", []string{})
 	viper.Set("peer.fileSystemPath", "/var/hyperledger/production")
 }
 
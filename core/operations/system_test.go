@@ -93,6 +93,24 @@ var _ = Describe("System", func() {
 		Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
 	})
 
+	It("hosts a secure endpoint for registered handlers", func() {
+		system.RegisterHandler("/admin", http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+			resp.WriteHeader(http.StatusNoContent)
+		}))
+		err := system.Start()
+		Expect(err).NotTo(HaveOccurred())
+
+		adminURL := fmt.Sprintf("https://%s/admin", system.Addr())
+		resp, err := client.Get(adminURL)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusNoContent))
+		resp.Body.Close()
+
+		resp, err = unauthClient.Get(adminURL)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+	})
+
 	Context("when TLS is disabled", func() {
 		BeforeEach(func() {
 			options.TLS.Enabled = false
@@ -15,10 +15,8 @@ import (
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/core/aclmgmt/resources"
-	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/peer"
-	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 )
@@ -56,6 +54,14 @@ func (brs *blockResponseSender) SendBlockResponse(block *common.Block) error {
 	return brs.Send(response)
 }
 
+// SendFilteredBlockResponse generates deliver response with filtered block message
+func (brs *blockResponseSender) SendFilteredBlockResponse(filteredBlock *peer.FilteredBlock) error {
+	response := &peer.DeliverResponse{
+		Type: &peer.DeliverResponse_FilteredBlock{FilteredBlock: filteredBlock},
+	}
+	return brs.Send(response)
+}
+
 // filteredBlockResponseSender structure used to send filtered block responses
 type filteredBlockResponseSender struct {
 	peer.Deliver_DeliverFilteredServer
@@ -78,8 +84,7 @@ func (fbrs *filteredBlockResponseSender) IsFiltered() bool {
 // SendBlockResponse generates deliver response with block message
 func (fbrs *filteredBlockResponseSender) SendBlockResponse(block *common.Block) error {
 	// Generates filtered block response
-	b := blockEvent(*block)
-	filteredBlock, err := b.toFilteredBlock()
+	filteredBlock, err := deliver.FilterBlock(block)
 	if err != nil {
 		logger.Warningf("Failed to generate filtered block due to: %s", err)
 		return fbrs.SendStatusResponse(common.Status_BAD_REQUEST)
@@ -90,13 +95,6 @@ func (fbrs *filteredBlockResponseSender) SendBlockResponse(block *common.Block)
 	return fbrs.Send(response)
 }
 
-// transactionActions aliasing for peer.TransactionAction pointers slice
-type transactionActions []*peer.TransactionAction
-
-// blockEvent an alias for common.Block structure, used to
-// extend with auxiliary functionality
-type blockEvent common.Block
-
 // Deliver sends a stream of blocks to a client after commitment
 func (s *server) DeliverFiltered(srv peer.Deliver_DeliverFilteredServer) error {
 	logger.Debugf("Starting new DeliverFiltered handler")
@@ -154,114 +152,6 @@ func (s *server) sendProducer(srv peer.Deliver_DeliverFilteredServer) func(msg p
 	}
 }
 
-func (block *blockEvent) toFilteredBlock() (*peer.FilteredBlock, error) {
-	filteredBlock := &peer.FilteredBlock{
-		Number: block.Header.Number,
-	}
-
-	txsFltr := util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
-	for txIndex, ebytes := range block.Data.Data {
-		var env *common.Envelope
-		var err error
-
-		if ebytes == nil {
-			logger.Debugf("got nil data bytes for tx index %d, "+
-				"block num %d", txIndex, block.Header.Number)
-			continue
-		}
-
-		env, err = utils.GetEnvelopeFromBlock(ebytes)
-		if err != nil {
-			logger.Errorf("error getting tx from block, %s", err)
-			continue
-		}
-
-		// get the payload from the envelope
-		payload, err := utils.GetPayload(env)
-		if err != nil {
-			return nil, errors.WithMessage(err, "could not extract payload from envelope")
-		}
-
-		if payload.Header == nil {
-			logger.Debugf("transaction payload header is nil, %d, block num %d",
-				txIndex, block.Header.Number)
-			continue
-		}
-		chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
-		if err != nil {
-			return nil, err
-		}
-
-		filteredBlock.ChannelId = chdr.ChannelId
-
-		filteredTransaction := &peer.FilteredTransaction{
-			Txid:             chdr.TxId,
-			Type:             common.HeaderType(chdr.Type),
-			TxValidationCode: txsFltr.Flag(txIndex),
-		}
-
-		if filteredTransaction.Type == common.HeaderType_ENDORSER_TRANSACTION {
-			tx, err := utils.GetTransaction(payload.Data)
-			if err != nil {
-				return nil, errors.WithMessage(err, "error unmarshal transaction payload for block event")
-			}
-
-			filteredTransaction.Data, err = transactionActions(tx.Actions).toFilteredActions()
-			if err != nil {
-				logger.Errorf(err.Error())
-				return nil, err
-			}
-		}
-
-		filteredBlock.FilteredTransactions = append(filteredBlock.FilteredTransactions, filteredTransaction)
-	}
-
-	return filteredBlock, nil
-}
-
-func (ta transactionActions) toFilteredActions() (*peer.FilteredTransaction_TransactionActions, error) {
-	transactionActions := &peer.FilteredTransactionActions{}
-	for _, action := range ta {
-		chaincodeActionPayload, err := utils.GetChaincodeActionPayload(action.Payload)
-		if err != nil {
-			return nil, errors.WithMessage(err, "error unmarshal transaction action payload for block event")
-		}
-
-		if chaincodeActionPayload.Action == nil {
-			logger.Debugf("chaincode action, the payload action is nil, skipping")
-			continue
-		}
-		propRespPayload, err := utils.GetProposalResponsePayload(chaincodeActionPayload.Action.ProposalResponsePayload)
-		if err != nil {
-			return nil, errors.WithMessage(err, "error unmarshal proposal response payload for block event")
-		}
-
-		caPayload, err := utils.GetChaincodeAction(propRespPayload.Extension)
-		if err != nil {
-			return nil, errors.WithMessage(err, "error unmarshal chaincode action for block event")
-		}
-
-		ccEvent, err := utils.GetChaincodeEvents(caPayload.Events)
-		if err != nil {
-			return nil, errors.WithMessage(err, "error unmarshal chaincode event for block event")
-		}
-
-		if ccEvent.GetChaincodeId() != "" {
-			filteredAction := &peer.FilteredChaincodeAction{
-				ChaincodeEvent: &peer.ChaincodeEvent{
-					TxId:        ccEvent.TxId,
-					ChaincodeId: ccEvent.ChaincodeId,
-					EventName:   ccEvent.EventName,
-				},
-			}
-			transactionActions.ChaincodeActions = append(transactionActions.ChaincodeActions, filteredAction)
-		}
-	}
-	return &peer.FilteredTransaction_TransactionActions{
-		TransactionActions: transactionActions,
-	}, nil
-}
-
 func dumpStacktraceOnPanic() {
 	func() {
 		if r := recover(); r != nil {
@@ -58,6 +58,8 @@ var configTxProcessor = newConfigTxProcessor()
 var tokenTxProcessor = &transaction.Processor{
 	TMSManager: &manager.Manager{
 		IdentityDeserializerManager: &manager.FabricIdentityDeserializerManager{},
+		CapabilityChecker:           &manager.ChannelConfigCapabilityChecker{GetChannelConfig: GetChannelConfig},
+		TokenTypesProvider:          &manager.ChannelConfigTokenTypesProvider{GetChannelConfig: GetChannelConfig},
 	},
 }
 var ConfigTxProcessors = customtx.Processors{
@@ -452,6 +454,27 @@ func CreateChainFromBlock(cb *common.Block, ccp ccprovider.ChaincodeProvider, sc
 	return createChain(cid, l, cb, ccp, sccp, pluginMapper, deployedCCInfoProvider, legacyLifecycleValidation, newLifecycleValidation)
 }
 
+// CreateChainFromSnapshot creates a new chain from the ledger snapshot in the given directory. The chain
+// starts with the block next to the last block in the snapshot and the config block is the latest config
+// block as of the last block in the snapshot
+func CreateChainFromSnapshot(snapshotDir string, ccp ccprovider.ChaincodeProvider, sccp sysccprovider.SystemChaincodeProvider, deployedCCInfoProvider ledger.DeployedChaincodeInfoProvider, legacyLifecycleValidation, newLifecycleValidation plugindispatcher.LifecycleResources) (string, error) {
+	l, err := ledgermgmt.CreateLedgerFromSnapshot(snapshotDir)
+	if err != nil {
+		return "", errors.WithMessage(err, "cannot create ledger from snapshot")
+	}
+	cb, err := getCurrConfigBlockFromLedger(l)
+	if err != nil {
+		l.Close()
+		return "", errors.WithMessage(err, "cannot retrieve the config block of the ledger created from snapshot")
+	}
+	cid, err := utils.GetChainIDFromBlock(cb)
+	if err != nil {
+		l.Close()
+		return "", err
+	}
+	return cid, createChain(cid, l, cb, ccp, sccp, pluginMapper, deployedCCInfoProvider, legacyLifecycleValidation, newLifecycleValidation)
+}
+
 // GetLedger returns the ledger of the chain with chain ID. Note that this
 // call returns nil if chain cid has not been created.
 func GetLedger(cid string) ledger.PeerLedger {
@@ -771,6 +794,17 @@ func (flbs fileLedgerBlockStore) RetrieveBlocks(startBlockNumber uint64) (common
 	return flbs.GetBlocksIterator(startBlockNumber)
 }
 
+// FirstBlockNumber returns the number of the oldest block available in the ledger.
+// Ledgers that do not support pruning serve the blocks from the genesis block onwards
+func (flbs fileLedgerBlockStore) FirstBlockNumber() (uint64, error) {
+	if prunableLedger, ok := flbs.PeerLedger.(interface {
+		FirstBlockNumber() (uint64, error)
+	}); ok {
+		return prunableLedger.FirstBlockNumber()
+	}
+	return 0, nil
+}
+
 // NewConfigSupport returns
 func NewConfigSupport() cc.Manager {
 	return &configSupport{}
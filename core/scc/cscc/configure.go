@@ -13,6 +13,7 @@ package cscc
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/channelconfig"
@@ -87,6 +88,9 @@ const (
 	GetChannels              string = "GetChannels"
 	GetConfigTree            string = "GetConfigTree"
 	SimulateConfigTreeUpdate string = "SimulateConfigTreeUpdate"
+	PurgePrivateData         string = "PurgePrivateData"
+	JoinChainBySnapshot      string = "JoinChainBySnapshot"
+	GenerateSnapshot         string = "GenerateSnapshot"
 )
 
 // Init is mostly useless from an SCC perspective
@@ -192,6 +196,30 @@ func (e *PeerConfiger) InvokeNoShim(args [][]byte, sp *pb.SignedProposal) pb.Res
 			return shim.Error(fmt.Sprintf("access denied for [%s][%s]: %s", fname, args[1], err))
 		}
 		return e.simulateConfigTreeUpdate(args[1], args[2])
+	case PurgePrivateData:
+		if len(args) < 3 {
+			return shim.Error(fmt.Sprintf("Incorrect number of arguments, %d", len(args)))
+		}
+		// 2. check purge policy
+		if err = e.aclProvider.CheckACL(resources.Cscc_PurgePrivateData, string(args[1]), sp); err != nil {
+			return shim.Error(fmt.Sprintf("access denied for [%s][%s]: %s", fname, args[1], err))
+		}
+		return purgePrivateData(args[1], args[2])
+	case JoinChainBySnapshot:
+		// 1. check join policy.
+		if err = e.aclProvider.CheckACL(resources.Cscc_JoinChain, "", sp); err != nil {
+			return shim.Error(fmt.Sprintf("access denied for [%s]: [%s]", fname, err))
+		}
+		return joinChainBySnapshot(string(args[1]), e.ccp, e.sccp, e.deployedCCInfoProvider, e.legacyLifecycle, e.newLifecycle)
+	case GenerateSnapshot:
+		if len(args) < 3 {
+			return shim.Error(fmt.Sprintf("Incorrect number of arguments, %d", len(args)))
+		}
+		// 2. check snapshot policy
+		if err = e.aclProvider.CheckACL(resources.Cscc_GenerateSnapshot, string(args[1]), sp); err != nil {
+			return shim.Error(fmt.Sprintf("access denied for [%s][%s]: %s", fname, args[1], err))
+		}
+		return generateSnapshot(args[1], string(args[2]))
 	case GetChannels:
 		// 2. check get channels policy
 		if err = e.aclProvider.CheckACL(resources.Cscc_GetChannels, "", sp); err != nil {
@@ -256,6 +284,22 @@ func joinChain(chainID string, block *common.Block, ccp ccprovider.ChaincodeProv
 	return shim.Success(nil)
 }
 
+// joinChainBySnapshot joins the chain recorded in the ledger snapshot in the given directory.
+// The directory is expected to be accessible on the file system of the peer
+func joinChainBySnapshot(snapshotDir string, ccp ccprovider.ChaincodeProvider, sccp sysccprovider.SystemChaincodeProvider, deployedCCInfoProvider ledger.DeployedChaincodeInfoProvider, lr, nr plugindispatcher.LifecycleResources) pb.Response {
+	if snapshotDir == "" {
+		return shim.Error("Snapshot directory must not be empty")
+	}
+	chainID, err := peer.CreateChainFromSnapshot(snapshotDir, ccp, sccp, deployedCCInfoProvider, lr, nr)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	peer.InitChain(chainID)
+
+	return shim.Success([]byte(chainID))
+}
+
 // Return the current configuration block for the specified chainID. If the
 // peer doesn't belong to the chain, return error
 func getConfigBlock(chainID []byte) pb.Response {
@@ -334,6 +378,53 @@ func supportByType(pc *PeerConfiger, chainID []byte, env *common.Envelope) (conf
 	return nil, errors.Errorf("invalid payload header type: %d", channelHdr.Type)
 }
 
+// purgePrivateData removes the private data of all collections generated below the given block
+// number on the specified chainID and returns the lowest block number with retained private data
+func purgePrivateData(chainID []byte, blockNumBytes []byte) pb.Response {
+	if chainID == nil {
+		return shim.Error("Chain ID must not be nil")
+	}
+	blockNum, err := strconv.ParseUint(string(blockNumBytes), 10, 64)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Invalid block number [%s]: %s", blockNumBytes, err))
+	}
+	l := peer.GetLedger(string(chainID))
+	if l == nil {
+		return shim.Error(fmt.Sprintf("Unknown chain ID, %s", string(chainID)))
+	}
+	if err := l.PurgePrivateData(blockNum); err != nil {
+		return shim.Error(err.Error())
+	}
+	minBlockNum, err := l.PrivateDataMinBlockNum()
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	return shim.Success([]byte(strconv.FormatUint(minBlockNum, 10)))
+}
+
+// generateSnapshot generates a snapshot of the ledger of the specified chainID in the given
+// directory on the file system of the peer
+func generateSnapshot(chainID []byte, snapshotDir string) pb.Response {
+	if chainID == nil {
+		return shim.Error("Chain ID must not be nil")
+	}
+	if snapshotDir == "" {
+		return shim.Error("Snapshot directory must not be empty")
+	}
+	l := peer.GetLedger(string(chainID))
+	if l == nil {
+		return shim.Error(fmt.Sprintf("Unknown chain ID, %s", string(chainID)))
+	}
+	snapshotGenerator, ok := l.(ledger.SnapshotGenerator)
+	if !ok {
+		return shim.Error(fmt.Sprintf("Ledger of chain ID %s does not support generating snapshots", string(chainID)))
+	}
+	if err := snapshotGenerator.GenerateSnapshot(snapshotDir); err != nil {
+		return shim.Error(err.Error())
+	}
+	return shim.Success(nil)
+}
+
 // getChannels returns information about all channels for this peer
 func getChannels() pb.Response {
 	channelInfoArray := peer.GetChannelsInfo()
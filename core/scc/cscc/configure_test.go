@@ -370,6 +370,98 @@ func TestConfigerInvokeJoinChainCorrectParams(t *testing.T) {
 	if len(cqr.GetChannels()) != 1 {
 		t.FailNow()
 	}
+
+	// purge the private data below the current height of the channel
+	mockAclProvider.Reset()
+	mockAclProvider.On("CheckACL", resources.Cscc_PurgePrivateData, "mytestchainid", sProp).Return(nil)
+	args = [][]byte{[]byte(PurgePrivateData), []byte(chainID), []byte("1")}
+	res = stub.MockInvokeWithSignedProposal("2", args, sProp)
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+	assert.Equal(t, []byte("1"), res.Payload)
+}
+
+func TestPurgePrivateData(t *testing.T) {
+	aclProvider := &mock.ACLProvider{}
+	pc := &PeerConfiger{
+		aclProvider: aclProvider,
+	}
+
+	t.Run("MissingBlockNumber", func(t *testing.T) {
+		res := pc.InvokeNoShim([][]byte{[]byte("PurgePrivateData"), []byte("testchan")}, nil)
+		assert.NotEqual(t, int32(shim.OK), res.Status)
+		assert.Equal(t, "Incorrect number of arguments, 2", res.Message)
+	})
+
+	t.Run("InvalidBlockNumber", func(t *testing.T) {
+		res := pc.InvokeNoShim([][]byte{[]byte("PurgePrivateData"), []byte("testchan"), []byte("-1")}, nil)
+		assert.NotEqual(t, int32(shim.OK), res.Status)
+		assert.Contains(t, res.Message, "Invalid block number [-1]")
+	})
+
+	t.Run("UnknownChannel", func(t *testing.T) {
+		res := pc.InvokeNoShim([][]byte{[]byte("PurgePrivateData"), []byte("testchan"), []byte("10")}, nil)
+		assert.NotEqual(t, int32(shim.OK), res.Status)
+		assert.Equal(t, "Unknown chain ID, testchan", res.Message)
+	})
+
+	t.Run("BadACL", func(t *testing.T) {
+		aclProvider.CheckACLReturns(fmt.Errorf("fake-error"))
+		res := pc.InvokeNoShim([][]byte{[]byte("PurgePrivateData"), []byte("testchan"), []byte("10")}, nil)
+		assert.NotEqual(t, int32(shim.OK), res.Status)
+		assert.Equal(t, "access denied for [PurgePrivateData][testchan]: fake-error", res.Message)
+	})
+}
+
+func TestGenerateSnapshot(t *testing.T) {
+	aclProvider := &mock.ACLProvider{}
+	pc := &PeerConfiger{
+		aclProvider: aclProvider,
+	}
+
+	t.Run("MissingSnapshotDir", func(t *testing.T) {
+		res := pc.InvokeNoShim([][]byte{[]byte("GenerateSnapshot"), []byte("testchan")}, nil)
+		assert.NotEqual(t, int32(shim.OK), res.Status)
+		assert.Equal(t, "Incorrect number of arguments, 2", res.Message)
+	})
+
+	t.Run("EmptySnapshotDir", func(t *testing.T) {
+		res := pc.InvokeNoShim([][]byte{[]byte("GenerateSnapshot"), []byte("testchan"), []byte("")}, nil)
+		assert.NotEqual(t, int32(shim.OK), res.Status)
+		assert.Equal(t, "Snapshot directory must not be empty", res.Message)
+	})
+
+	t.Run("UnknownChannel", func(t *testing.T) {
+		res := pc.InvokeNoShim([][]byte{[]byte("GenerateSnapshot"), []byte("testchan"), []byte("/snapshots/testchan")}, nil)
+		assert.NotEqual(t, int32(shim.OK), res.Status)
+		assert.Equal(t, "Unknown chain ID, testchan", res.Message)
+	})
+
+	t.Run("BadACL", func(t *testing.T) {
+		aclProvider.CheckACLReturns(fmt.Errorf("fake-error"))
+		res := pc.InvokeNoShim([][]byte{[]byte("GenerateSnapshot"), []byte("testchan"), []byte("/snapshots/testchan")}, nil)
+		assert.NotEqual(t, int32(shim.OK), res.Status)
+		assert.Equal(t, "access denied for [GenerateSnapshot][testchan]: fake-error", res.Message)
+	})
+}
+
+func TestJoinChainBySnapshot(t *testing.T) {
+	aclProvider := &mock.ACLProvider{}
+	pc := &PeerConfiger{
+		aclProvider: aclProvider,
+	}
+
+	t.Run("EmptySnapshotDir", func(t *testing.T) {
+		res := pc.InvokeNoShim([][]byte{[]byte("JoinChainBySnapshot"), []byte("")}, nil)
+		assert.NotEqual(t, int32(shim.OK), res.Status)
+		assert.Equal(t, "Snapshot directory must not be empty", res.Message)
+	})
+
+	t.Run("BadACL", func(t *testing.T) {
+		aclProvider.CheckACLReturns(fmt.Errorf("fake-error"))
+		res := pc.InvokeNoShim([][]byte{[]byte("JoinChainBySnapshot"), []byte("/snapshots/testchan")}, nil)
+		assert.NotEqual(t, int32(shim.OK), res.Status)
+		assert.Equal(t, "access denied for [JoinChainBySnapshot]: [fake-error]", res.Message)
+	})
 }
 
 func TestGetConfigTree(t *testing.T) {
@@ -9,8 +9,10 @@ package qscc
 import (
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/hyperledger/fabric/common/flogging"
+	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/core/aclmgmt"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	"github.com/hyperledger/fabric/core/ledger"
@@ -40,6 +42,9 @@ func (e *LedgerQuerier) Enabled() bool             { return true }
 // - GetBlockByNumber returns a block
 // - GetBlockByHash returns a block
 // - GetTransactionByID returns a transaction
+// - GetTransactionsByCreatorMSPID returns the transactions of an MSP
+// - GetTransactionsByChaincodeName returns the transactions of a chaincode
+// - GetTransactionsByTimeRange returns the transactions of a time window
 type LedgerQuerier struct {
 	aclProvider aclmgmt.ACLProvider
 }
@@ -53,6 +58,10 @@ const (
 	GetBlockByHash     string = "GetBlockByHash"
 	GetTransactionByID string = "GetTransactionByID"
 	GetBlockByTxID     string = "GetBlockByTxID"
+
+	GetTransactionsByCreatorMSPID  string = "GetTransactionsByCreatorMSPID"
+	GetTransactionsByChaincodeName string = "GetTransactionsByChaincodeName"
+	GetTransactionsByTimeRange     string = "GetTransactionsByTimeRange"
 )
 
 // Init is called once per chain when the chain is created.
@@ -71,6 +80,9 @@ func (e *LedgerQuerier) Init(stub shim.ChaincodeStubInterface) pb.Response {
 // # GetBlockByNumber: Return the block specified by block number in args[2]
 // # GetBlockByHash: Return the block specified by block hash in args[2]
 // # GetTransactionByID: Return the transaction specified by ID in args[2]
+// # GetTransactionsByCreatorMSPID: Return the transactions created by the MSP ID in args[2]
+// # GetTransactionsByChaincodeName: Return the transactions invoking the chaincode in args[2]
+// # GetTransactionsByTimeRange: Return the transactions with a timestamp in [args[2], args[3]) in RFC3339 format
 func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 	args := stub.GetArgs()
 
@@ -83,6 +95,9 @@ func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 	if fname != GetChainInfo && len(args) < 3 {
 		return shim.Error(fmt.Sprintf("missing 3rd argument for %s", fname))
 	}
+	if fname == GetTransactionsByTimeRange && len(args) < 4 {
+		return shim.Error(fmt.Sprintf("missing 4th argument for %s", fname))
+	}
 
 	targetLedger := peer.GetLedger(cid)
 	if targetLedger == nil {
@@ -115,6 +130,12 @@ func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 		return getChainInfo(targetLedger)
 	case GetBlockByTxID:
 		return getBlockByTxID(targetLedger, args[2])
+	case GetTransactionsByCreatorMSPID:
+		return getTransactionsByCreatorMSPID(targetLedger, args[2])
+	case GetTransactionsByChaincodeName:
+		return getTransactionsByChaincodeName(targetLedger, args[2])
+	case GetTransactionsByTimeRange:
+		return getTransactionsByTimeRange(targetLedger, args[2], args[3])
 	}
 
 	return shim.Error(fmt.Sprintf("Requested function %s not found.", fname))
@@ -214,6 +235,72 @@ func getBlockByTxID(vledger ledger.PeerLedger, rawTxID []byte) pb.Response {
 	return shim.Success(bytes)
 }
 
+func getTransactionsByCreatorMSPID(vledger ledger.PeerLedger, mspID []byte) pb.Response {
+	querier, ok := vledger.(ledger.TransactionIndexQuerier)
+	if !ok {
+		return shim.Error("Ledger does not support querying the transaction indexes")
+	}
+	itr, err := querier.GetTransactionsByCreatorMSPID(string(mspID))
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get transactions for creator MSP ID %s, error %s", string(mspID), err))
+	}
+	return processedTransactionsResponse(itr)
+}
+
+func getTransactionsByChaincodeName(vledger ledger.PeerLedger, ccName []byte) pb.Response {
+	querier, ok := vledger.(ledger.TransactionIndexQuerier)
+	if !ok {
+		return shim.Error("Ledger does not support querying the transaction indexes")
+	}
+	itr, err := querier.GetTransactionsByChaincodeName(string(ccName))
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get transactions for chaincode %s, error %s", string(ccName), err))
+	}
+	return processedTransactionsResponse(itr)
+}
+
+func getTransactionsByTimeRange(vledger ledger.PeerLedger, rawStartTime, rawEndTime []byte) pb.Response {
+	startTime, err := time.Parse(time.RFC3339, string(rawStartTime))
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to parse start time with error %s", err))
+	}
+	endTime, err := time.Parse(time.RFC3339, string(rawEndTime))
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to parse end time with error %s", err))
+	}
+	querier, ok := vledger.(ledger.TransactionIndexQuerier)
+	if !ok {
+		return shim.Error("Ledger does not support querying the transaction indexes")
+	}
+	itr, err := querier.GetTransactionsByTimeRange(startTime, endTime)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get transactions for time range [%s, %s), error %s",
+			string(rawStartTime), string(rawEndTime), err))
+	}
+	return processedTransactionsResponse(itr)
+}
+
+func processedTransactionsResponse(itr commonledger.ResultsIterator) pb.Response {
+	defer itr.Close()
+	processedTrans := &pb.ProcessedTransactions{}
+	for {
+		res, err := itr.Next()
+		if err != nil {
+			return shim.Error(fmt.Sprintf("Failed to iterate over transactions with error %s", err))
+		}
+		if res == nil {
+			break
+		}
+		processedTrans.Transactions = append(processedTrans.Transactions, res.(*pb.ProcessedTransaction))
+	}
+	bytes, err := utils.Marshal(processedTrans)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return shim.Success(bytes)
+}
+
 func getACLResource(fname string) string {
 	return "qscc/" + fname
 }
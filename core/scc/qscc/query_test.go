@@ -20,7 +20,9 @@ import (
 	"io/ioutil"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/aclmgmt/mocks"
@@ -328,6 +330,58 @@ func TestQueryGeneratedBlock(t *testing.T) {
 	}
 }
 
+func TestQueryTransactionIndexes(t *testing.T) {
+	chainid := "mytestchainid9"
+	path := tempDir(t, "test9")
+	defer os.RemoveAll(path)
+
+	viper.Set("ledger.blockchain.secondaryIndexes", []string{"ChaincodeName", "TxTimestamp"})
+	defer viper.Set("ledger.blockchain.secondaryIndexes", []string{})
+	stub, err := setupTestLedger(chainid, path)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	startTime := time.Now().Add(-time.Minute).Format(time.RFC3339)
+	block1 := addBlockForTesting(t, chainid)
+	endTime := time.Now().Add(time.Minute).Format(time.RFC3339)
+
+	args := [][]byte{[]byte(GetTransactionsByChaincodeName), []byte(chainid), []byte("foo")}
+	prop := resetProvider(resources.Qscc_GetTransactionsByChaincodeName, chainid, &peer2.SignedProposal{}, nil)
+	res := stub.MockInvokeWithSignedProposal("1", args, prop)
+	assert.Equal(t, int32(shim.OK), res.Status, "GetTransactionsByChaincodeName should have succeeded: %s", res.Message)
+	processedTrans := &peer2.ProcessedTransactions{}
+	assert.NoError(t, proto.Unmarshal(res.Payload, processedTrans))
+	assert.Len(t, processedTrans.Transactions, 2)
+	for i, processedTran := range processedTrans.Transactions {
+		assert.Equal(t, block1.Data.Data[i], utils.MarshalOrPanic(processedTran.TransactionEnvelope))
+		assert.Equal(t, int32(peer2.TxValidationCode_VALID), processedTran.ValidationCode)
+	}
+
+	args = [][]byte{[]byte(GetTransactionsByTimeRange), []byte(chainid), []byte(startTime), []byte(endTime)}
+	prop = resetProvider(resources.Qscc_GetTransactionsByTimeRange, chainid, &peer2.SignedProposal{}, nil)
+	res = stub.MockInvokeWithSignedProposal("2", args, prop)
+	assert.Equal(t, int32(shim.OK), res.Status, "GetTransactionsByTimeRange should have succeeded: %s", res.Message)
+	processedTrans = &peer2.ProcessedTransactions{}
+	assert.NoError(t, proto.Unmarshal(res.Payload, processedTrans))
+	assert.True(t, len(processedTrans.Transactions) >= 2)
+
+	args = [][]byte{[]byte(GetTransactionsByTimeRange), []byte(chainid), []byte(startTime)}
+	prop = resetProvider(resources.Qscc_GetTransactionsByTimeRange, chainid, &peer2.SignedProposal{}, nil)
+	res = stub.MockInvokeWithSignedProposal("3", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetTransactionsByTimeRange should have failed with missing end time")
+
+	args = [][]byte{[]byte(GetTransactionsByTimeRange), []byte(chainid), []byte("yesterday"), []byte(endTime)}
+	prop = resetProvider(resources.Qscc_GetTransactionsByTimeRange, chainid, &peer2.SignedProposal{}, nil)
+	res = stub.MockInvokeWithSignedProposal("4", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetTransactionsByTimeRange should have failed with invalid start time")
+
+	// the index of creator MSP IDs is not enabled
+	args = [][]byte{[]byte(GetTransactionsByCreatorMSPID), []byte(chainid), []byte("Org1MSP")}
+	prop = resetProvider(resources.Qscc_GetTransactionsByCreatorMSPID, chainid, &peer2.SignedProposal{}, nil)
+	res = stub.MockInvokeWithSignedProposal("5", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetTransactionsByCreatorMSPID should have failed as the index is not enabled")
+}
+
 func addBlockForTesting(t *testing.T, chainid string) *common.Block {
 	ledger := peer.GetLedger(chainid)
 	defer ledger.Close()
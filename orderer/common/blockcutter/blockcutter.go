@@ -7,17 +7,27 @@ SPDX-License-Identifier: Apache-2.0
 package blockcutter
 
 import (
+	"math"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/policies"
 	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
 )
 
 var logger = flogging.MustGetLogger("orderer.common.blockcutter")
 
+// loadSmoothing is the weight of the fill of the last batch in the load of a channel.
+const loadSmoothing = 0.25
+
 type OrdererConfigFetcher interface {
 	OrdererConfig() (channelconfig.Orderer, bool)
+	PolicyManager() policies.Manager
 }
 
 // Receiver defines a sink for the ordered broadcast messages
@@ -31,10 +41,32 @@ type Receiver interface {
 	Cut() []*cb.Envelope
 }
 
+// TimeoutAdapter is implemented by the receivers which adapt the batch timeout to the load of the channel.
+type TimeoutAdapter interface {
+	// BatchTimeout returns the amount of time to wait before cutting the pending batch
+	BatchTimeout() time.Duration
+}
+
+// BatchTimeout returns the amount of time to wait before cutting the pending batch of the given receiver,
+// which is adapted to the load of the channel by the receiver if it is a TimeoutAdapter, and the batch
+// timeout of the given orderer config otherwise.
+func BatchTimeout(r Receiver, ordererConfig channelconfig.Orderer) time.Duration {
+	if adapter, ok := r.(TimeoutAdapter); ok {
+		return adapter.BatchTimeout()
+	}
+	return ordererConfig.BatchTimeout()
+}
+
 type receiver struct {
 	sharedConfigFetcher   OrdererConfigFetcher
 	pendingBatch          []*cb.Envelope
 	pendingBatchSizeBytes uint32
+	pendingPriorities     map[string]uint32
+
+	// load is the moving average of the fill of the batches, between 0 and 1,
+	// which is valid once loadObserved is set.
+	load         float64
+	loadObserved bool
 
 	PendingBatchStartTime time.Time
 	ChannelID             string
@@ -58,6 +90,7 @@ func NewReceiverImpl(channelID string, sharedConfigFetcher OrdererConfigFetcher,
 //   - no batch is cut and there are messages pending
 // messageBatches length: 1, pending: false
 //   - the message count reaches BatchSize.MaxMessageCount
+//   - the count of the messages of a priority class reaches the max message count of the class
 // messageBatches length: 1, pending: true
 //   - the current message will cause the pending batch size in bytes to exceed BatchSize.PreferredMaxBytes.
 // messageBatches length: 2, pending: false
@@ -94,6 +127,7 @@ func (r *receiver) Ordered(msg *cb.Envelope) (messageBatches [][]*cb.Envelope, p
 
 		// Record that this batch took no time to fill
 		r.Metrics.BlockFillDuration.With("channel", r.ChannelID).Observe(0)
+		r.observeLoad(ordererConfig, 1)
 
 		return
 	}
@@ -118,20 +152,141 @@ func (r *receiver) Ordered(msg *cb.Envelope) (messageBatches [][]*cb.Envelope, p
 		messageBatch := r.Cut()
 		messageBatches = append(messageBatches, messageBatch)
 		pending = false
+		return
+	}
+
+	if len(batchSize.PriorityClasses) == 0 {
+		return
+	}
+
+	class := r.priorityClass(msg, batchSize.PriorityClasses)
+	if class == nil {
+		return
+	}
+	if r.pendingPriorities == nil {
+		r.pendingPriorities = make(map[string]uint32)
+	}
+	r.pendingPriorities[class.Name]++
+	if r.pendingPriorities[class.Name] >= class.MaxMessageCount {
+		logger.Debugf("Priority class %s met, cutting batch", class.Name)
+		r.Metrics.PriorityBatchesCut.With("channel", r.ChannelID, "class", class.Name).Add(1)
+		messageBatch := r.Cut()
+		messageBatches = append(messageBatches, messageBatch)
+		pending = false
 	}
 
 	return
 }
 
+// priorityClass returns the first of the given priority classes which the given message belongs to,
+// or nil if it belongs to none of them.
+func (r *receiver) priorityClass(msg *cb.Envelope, classes []*ab.PriorityClass) *ab.PriorityClass {
+	payload, err := utils.UnmarshalPayload(msg.Payload)
+	if err != nil || payload.Header == nil {
+		logger.Debugf("Message without a payload header belongs to no priority class")
+		return nil
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		logger.Debugf("Message without a channel header belongs to no priority class: %s", err)
+		return nil
+	}
+
+	var priority uint32
+	if cb.HeaderType(chdr.Type) == cb.HeaderType_ENDORSER_TRANSACTION {
+		ext := &peer.ChaincodeHeaderExtension{}
+		if err := proto.Unmarshal(chdr.Extension, ext); err == nil {
+			priority = ext.Priority
+		}
+	}
+
+	for _, class := range classes {
+		if len(class.HeaderTypes) > 0 && !containsHeaderType(class.HeaderTypes, cb.HeaderType(chdr.Type)) {
+			continue
+		}
+		if priority < class.MinPriority {
+			continue
+		}
+		if class.Policy != "" && !r.satisfiesPolicy(msg, class.Policy) {
+			continue
+		}
+		return class
+	}
+	return nil
+}
+
+// satisfiesPolicy returns whether the creator of the given message satisfies the policy of the given name.
+func (r *receiver) satisfiesPolicy(msg *cb.Envelope, policyName string) bool {
+	policy, ok := r.sharedConfigFetcher.PolicyManager().GetPolicy(policyName)
+	if !ok {
+		logger.Warningf("[channel: %s] Could not find policy %s of a priority class", r.ChannelID, policyName)
+		return false
+	}
+	signedData, err := msg.AsSignedData()
+	if err != nil {
+		return false
+	}
+	return policy.Evaluate(signedData) == nil
+}
+
+func containsHeaderType(headerTypes []cb.HeaderType, headerType cb.HeaderType) bool {
+	for _, t := range headerTypes {
+		if t == headerType {
+			return true
+		}
+	}
+	return false
+}
+
+// BatchTimeout returns the amount of time to wait before cutting the pending batch. If the orderer
+// config sets the bounds of the batch timeout, it shrinks towards the lower bound as the batches are
+// cut with few messages, and grows towards the upper bound as the batches are cut full.
+func (r *receiver) BatchTimeout() time.Duration {
+	ordererConfig, ok := r.sharedConfigFetcher.OrdererConfig()
+	if !ok {
+		logger.Panicf("Could not retrieve orderer config to query batch parameters, block cutting is not possible")
+	}
+
+	min, max := ordererConfig.BatchTimeoutBounds()
+	if max == 0 || !r.loadObserved {
+		return ordererConfig.BatchTimeout()
+	}
+	return min + time.Duration(r.load*float64(max-min))
+}
+
+// observeLoad records the fill of a batch that was cut in the load of the channel.
+func (r *receiver) observeLoad(ordererConfig channelconfig.Orderer, fill float64) {
+	min, max := ordererConfig.BatchTimeoutBounds()
+	if max == 0 {
+		return
+	}
+	if !r.loadObserved {
+		// start from the load at which the batch timeout is the configured one
+		r.load = float64(ordererConfig.BatchTimeout()-min) / float64(max-min)
+		r.loadObserved = true
+	}
+	r.load += loadSmoothing * (math.Min(fill, 1) - r.load)
+	r.Metrics.BatchTimeout.With("channel", r.ChannelID).Set(r.BatchTimeout().Seconds())
+}
+
 // Cut returns the current batch and starts a new one
 func (r *receiver) Cut() []*cb.Envelope {
 	if r.pendingBatch != nil {
 		r.Metrics.BlockFillDuration.With("channel", r.ChannelID).Observe(time.Since(r.PendingBatchStartTime).Seconds())
+		if ordererConfig, ok := r.sharedConfigFetcher.OrdererConfig(); ok {
+			batchSize := ordererConfig.BatchSize()
+			fill := math.Max(
+				float64(len(r.pendingBatch))/float64(batchSize.MaxMessageCount),
+				float64(r.pendingBatchSizeBytes)/float64(batchSize.PreferredMaxBytes),
+			)
+			r.observeLoad(ordererConfig, fill)
+		}
 	}
 	r.PendingBatchStartTime = time.Time{}
 	batch := r.pendingBatch
 	r.pendingBatch = nil
 	r.pendingBatchSizeBytes = 0
+	r.pendingPriorities = nil
 	return batch
 }
 
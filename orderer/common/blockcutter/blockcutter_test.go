@@ -7,23 +7,47 @@ SPDX-License-Identifier: Apache-2.0
 package blockcutter_test
 
 import (
+	"errors"
+	"time"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	mockpolicies "github.com/hyperledger/fabric/common/mocks/policies"
+	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	"github.com/hyperledger/fabric/orderer/common/blockcutter/mock"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
 )
 
+func priorityMessage(headerType cb.HeaderType, priority uint32) *cb.Envelope {
+	chdr := &cb.ChannelHeader{Type: int32(headerType), ChannelId: "mychannel"}
+	if priority > 0 {
+		chdr.Extension = utils.MarshalOrPanic(&peer.ChaincodeHeaderExtension{Priority: priority})
+	}
+	payload := &cb.Payload{
+		Header: &cb.Header{
+			ChannelHeader:   utils.MarshalOrPanic(chdr),
+			SignatureHeader: utils.MarshalOrPanic(&cb.SignatureHeader{Creator: []byte("creator")}),
+		},
+	}
+	return &cb.Envelope{Payload: utils.MarshalOrPanic(payload), Signature: []byte("signature")}
+}
+
 var _ = Describe("Blockcutter", func() {
 	var (
 		bc                blockcutter.Receiver
 		fakeConfig        *mock.OrdererConfig
 		fakeConfigFetcher *mock.OrdererConfigFetcher
 
-		metrics               *blockcutter.Metrics
-		fakeBlockFillDuration *mock.MetricsHistogram
+		metrics                *blockcutter.Metrics
+		fakeBlockFillDuration  *mock.MetricsHistogram
+		fakePriorityBatchesCut *metricsfakes.Counter
+		fakeBatchTimeout       *metricsfakes.Gauge
 	)
 
 	BeforeEach(func() {
@@ -33,8 +57,14 @@ var _ = Describe("Blockcutter", func() {
 
 		fakeBlockFillDuration = &mock.MetricsHistogram{}
 		fakeBlockFillDuration.WithReturns(fakeBlockFillDuration)
+		fakePriorityBatchesCut = &metricsfakes.Counter{}
+		fakePriorityBatchesCut.WithReturns(fakePriorityBatchesCut)
+		fakeBatchTimeout = &metricsfakes.Gauge{}
+		fakeBatchTimeout.WithReturns(fakeBatchTimeout)
 		metrics = &blockcutter.Metrics{
-			BlockFillDuration: fakeBlockFillDuration,
+			BlockFillDuration:  fakeBlockFillDuration,
+			PriorityBatchesCut: fakePriorityBatchesCut,
+			BatchTimeout:       fakeBatchTimeout,
 		}
 
 		bc = blockcutter.NewReceiverImpl("mychannel", fakeConfigFetcher, metrics)
@@ -173,6 +203,91 @@ var _ = Describe("Blockcutter", func() {
 			})
 		})
 
+		Context("when the channel has priority classes", func() {
+			var fakePolicyManager *mockpolicies.Manager
+
+			BeforeEach(func() {
+				fakeConfig.BatchSizeReturns(&ab.BatchSize{
+					MaxMessageCount:   10,
+					PreferredMaxBytes: 1000,
+					PriorityClasses: []*ab.PriorityClass{
+						{Name: "token", HeaderTypes: []cb.HeaderType{cb.HeaderType_TOKEN_TRANSACTION}},
+						{Name: "urgent", MinPriority: 5, MaxMessageCount: 2},
+						{Name: "admins", Policy: "Admins"},
+					},
+				})
+				fakePolicyManager = &mockpolicies.Manager{
+					PolicyMap: map[string]policies.Policy{
+						"Admins": &mockpolicies.Policy{Err: errors.New("not an admin")},
+					},
+				}
+				fakeConfigFetcher.PolicyManagerReturns(fakePolicyManager)
+			})
+
+			It("enqueues the messages which belong to no priority class", func() {
+				batches, pending := bc.Ordered(priorityMessage(cb.HeaderType_ENDORSER_TRANSACTION, 4))
+				Expect(batches).To(BeEmpty())
+				Expect(pending).To(BeTrue())
+				Expect(fakePriorityBatchesCut.AddCallCount()).To(Equal(0))
+			})
+
+			It("cuts the batch once a message of a priority class by header type is enqueued", func() {
+				batches, pending := bc.Ordered(priorityMessage(cb.HeaderType_ENDORSER_TRANSACTION, 0))
+				Expect(batches).To(BeEmpty())
+				Expect(pending).To(BeTrue())
+
+				batches, pending = bc.Ordered(priorityMessage(cb.HeaderType_TOKEN_TRANSACTION, 0))
+				Expect(len(batches)).To(Equal(1))
+				Expect(len(batches[0])).To(Equal(2))
+				Expect(pending).To(BeFalse())
+
+				Expect(fakePriorityBatchesCut.AddCallCount()).To(Equal(1))
+				Expect(fakePriorityBatchesCut.WithArgsForCall(0)).To(Equal([]string{"channel", "mychannel", "class", "token"}))
+			})
+
+			It("cuts the batch once enough messages of a priority class by header extension are enqueued", func() {
+				batches, pending := bc.Ordered(priorityMessage(cb.HeaderType_ENDORSER_TRANSACTION, 5))
+				Expect(batches).To(BeEmpty())
+				Expect(pending).To(BeTrue())
+
+				batches, pending = bc.Ordered(priorityMessage(cb.HeaderType_ENDORSER_TRANSACTION, 0))
+				Expect(batches).To(BeEmpty())
+				Expect(pending).To(BeTrue())
+
+				batches, pending = bc.Ordered(priorityMessage(cb.HeaderType_ENDORSER_TRANSACTION, 7))
+				Expect(len(batches)).To(Equal(1))
+				Expect(len(batches[0])).To(Equal(3))
+				Expect(pending).To(BeFalse())
+
+				Expect(fakePriorityBatchesCut.AddCallCount()).To(Equal(1))
+				Expect(fakePriorityBatchesCut.WithArgsForCall(0)).To(Equal([]string{"channel", "mychannel", "class", "urgent"}))
+			})
+
+			It("counts the messages of a priority class from the start of the batch", func() {
+				bc.Ordered(priorityMessage(cb.HeaderType_ENDORSER_TRANSACTION, 5))
+				Expect(bc.Cut()).To(HaveLen(1))
+
+				batches, pending := bc.Ordered(priorityMessage(cb.HeaderType_ENDORSER_TRANSACTION, 5))
+				Expect(batches).To(BeEmpty())
+				Expect(pending).To(BeTrue())
+			})
+
+			Context("when the creator satisfies the policy of a priority class", func() {
+				BeforeEach(func() {
+					fakePolicyManager.PolicyMap["Admins"] = &mockpolicies.Policy{}
+				})
+
+				It("cuts the batch once a message of the creator is enqueued", func() {
+					batches, pending := bc.Ordered(priorityMessage(cb.HeaderType_ENDORSER_TRANSACTION, 0))
+					Expect(len(batches)).To(Equal(1))
+					Expect(len(batches[0])).To(Equal(1))
+					Expect(pending).To(BeFalse())
+
+					Expect(fakePriorityBatchesCut.WithArgsForCall(0)).To(Equal([]string{"channel", "mychannel", "class", "admins"}))
+				})
+			})
+		})
+
 		Context("when the orderer config cannot be retrieved", func() {
 			BeforeEach(func() {
 				fakeConfigFetcher.OrdererConfigReturns(nil, false)
@@ -184,6 +299,63 @@ var _ = Describe("Blockcutter", func() {
 		})
 	})
 
+	Describe("BatchTimeout", func() {
+		var message *cb.Envelope
+
+		BeforeEach(func() {
+			fakeConfig.BatchSizeReturns(&ab.BatchSize{
+				MaxMessageCount:   10,
+				PreferredMaxBytes: 1000,
+			})
+			message = &cb.Envelope{Payload: []byte("Twenty Bytes of Data"), Signature: []byte("Twenty Bytes of Data")}
+			fakeConfig.BatchTimeoutReturns(2 * time.Second)
+			fakeConfig.BatchTimeoutBoundsReturns(time.Second, 5*time.Second)
+		})
+
+		It("is the batch timeout of the config before a batch is cut", func() {
+			Expect(blockcutter.BatchTimeout(bc, fakeConfig)).To(Equal(2 * time.Second))
+		})
+
+		It("shrinks as the batches are cut with few messages", func() {
+			timeout := blockcutter.BatchTimeout(bc, fakeConfig)
+			for i := 0; i < 20; i++ {
+				bc.Ordered(message)
+				bc.Cut()
+				Expect(blockcutter.BatchTimeout(bc, fakeConfig)).To(BeNumerically("<", timeout))
+				timeout = blockcutter.BatchTimeout(bc, fakeConfig)
+			}
+			Expect(timeout).To(BeNumerically("~", 1400*time.Millisecond, 10*time.Millisecond))
+			Expect(fakeBatchTimeout.SetCallCount()).To(Equal(20))
+			Expect(fakeBatchTimeout.WithArgsForCall(0)).To(Equal([]string{"channel", "mychannel"}))
+		})
+
+		It("grows as the batches are cut full", func() {
+			timeout := blockcutter.BatchTimeout(bc, fakeConfig)
+			for i := 0; i < 20; i++ {
+				for j := 0; j < 10; j++ {
+					bc.Ordered(message)
+				}
+				Expect(blockcutter.BatchTimeout(bc, fakeConfig)).To(BeNumerically(">", timeout))
+				timeout = blockcutter.BatchTimeout(bc, fakeConfig)
+			}
+			Expect(timeout).To(BeNumerically("~", 5*time.Second, 20*time.Millisecond))
+		})
+
+		Context("when the batch timeout has no bounds", func() {
+			BeforeEach(func() {
+				fakeConfig.BatchTimeoutBoundsReturns(0, 0)
+			})
+
+			It("is the batch timeout of the config", func() {
+				for j := 0; j < 10; j++ {
+					bc.Ordered(message)
+				}
+				Expect(blockcutter.BatchTimeout(bc, fakeConfig)).To(Equal(2 * time.Second))
+				Expect(fakeBatchTimeout.SetCallCount()).To(Equal(0))
+			})
+		})
+	})
+
 	Describe("Cut", func() {
 		It("cuts an empty batch", func() {
 			batch := bc.Cut()
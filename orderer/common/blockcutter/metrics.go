@@ -16,14 +16,32 @@ var (
 		LabelNames:   []string{"channel"},
 		StatsdFormat: "%{#fqname}.%{channel}",
 	}
+	priorityBatchesCut = metrics.CounterOpts{
+		Namespace:    "blockcutter",
+		Name:         "priority_batches_cut",
+		Help:         "The number of batches cut early for a priority class.",
+		LabelNames:   []string{"channel", "class"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{class}",
+	}
+	batchTimeout = metrics.GaugeOpts{
+		Namespace:    "blockcutter",
+		Name:         "batch_timeout",
+		Help:         "The batch timeout in seconds, as adapted to the load of the channel.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
 )
 
 type Metrics struct {
-	BlockFillDuration metrics.Histogram
+	BlockFillDuration  metrics.Histogram
+	PriorityBatchesCut metrics.Counter
+	BatchTimeout       metrics.Gauge
 }
 
 func NewMetrics(p metrics.Provider) *Metrics {
 	return &Metrics{
-		BlockFillDuration: p.NewHistogram(blockFillDuration),
+		BlockFillDuration:  p.NewHistogram(blockFillDuration),
+		PriorityBatchesCut: p.NewCounter(priorityBatchesCut),
+		BatchTimeout:       p.NewGauge(batchTimeout),
 	}
 }
@@ -5,6 +5,7 @@ import (
 	sync "sync"
 
 	channelconfig "github.com/hyperledger/fabric/common/channelconfig"
+	policies "github.com/hyperledger/fabric/common/policies"
 )
 
 type OrdererConfigFetcher struct {
@@ -20,6 +21,16 @@ type OrdererConfigFetcher struct {
 		result1 channelconfig.Orderer
 		result2 bool
 	}
+	PolicyManagerStub        func() policies.Manager
+	policyManagerMutex       sync.RWMutex
+	policyManagerArgsForCall []struct {
+	}
+	policyManagerReturns struct {
+		result1 policies.Manager
+	}
+	policyManagerReturnsOnCall map[int]struct {
+		result1 policies.Manager
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -79,11 +90,65 @@ func (fake *OrdererConfigFetcher) OrdererConfigReturnsOnCall(i int, result1 chan
 	}{result1, result2}
 }
 
+func (fake *OrdererConfigFetcher) PolicyManager() policies.Manager {
+	fake.policyManagerMutex.Lock()
+	ret, specificReturn := fake.policyManagerReturnsOnCall[len(fake.policyManagerArgsForCall)]
+	fake.policyManagerArgsForCall = append(fake.policyManagerArgsForCall, struct {
+	}{})
+	fake.recordInvocation("PolicyManager", []interface{}{})
+	fake.policyManagerMutex.Unlock()
+	if fake.PolicyManagerStub != nil {
+		return fake.PolicyManagerStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.policyManagerReturns
+	return fakeReturns.result1
+}
+
+func (fake *OrdererConfigFetcher) PolicyManagerCallCount() int {
+	fake.policyManagerMutex.RLock()
+	defer fake.policyManagerMutex.RUnlock()
+	return len(fake.policyManagerArgsForCall)
+}
+
+func (fake *OrdererConfigFetcher) PolicyManagerCalls(stub func() policies.Manager) {
+	fake.policyManagerMutex.Lock()
+	defer fake.policyManagerMutex.Unlock()
+	fake.PolicyManagerStub = stub
+}
+
+func (fake *OrdererConfigFetcher) PolicyManagerReturns(result1 policies.Manager) {
+	fake.policyManagerMutex.Lock()
+	defer fake.policyManagerMutex.Unlock()
+	fake.PolicyManagerStub = nil
+	fake.policyManagerReturns = struct {
+		result1 policies.Manager
+	}{result1}
+}
+
+func (fake *OrdererConfigFetcher) PolicyManagerReturnsOnCall(i int, result1 policies.Manager) {
+	fake.policyManagerMutex.Lock()
+	defer fake.policyManagerMutex.Unlock()
+	fake.PolicyManagerStub = nil
+	if fake.policyManagerReturnsOnCall == nil {
+		fake.policyManagerReturnsOnCall = make(map[int]struct {
+			result1 policies.Manager
+		})
+	}
+	fake.policyManagerReturnsOnCall[i] = struct {
+		result1 policies.Manager
+	}{result1}
+}
+
 func (fake *OrdererConfigFetcher) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.ordererConfigMutex.RLock()
 	defer fake.ordererConfigMutex.RUnlock()
+	fake.policyManagerMutex.RLock()
+	defer fake.policyManagerMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
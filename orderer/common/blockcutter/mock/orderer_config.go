@@ -30,6 +30,18 @@ type OrdererConfig struct {
 	batchTimeoutReturnsOnCall map[int]struct {
 		result1 time.Duration
 	}
+	BatchTimeoutBoundsStub        func() (time.Duration, time.Duration)
+	batchTimeoutBoundsMutex       sync.RWMutex
+	batchTimeoutBoundsArgsForCall []struct {
+	}
+	batchTimeoutBoundsReturns struct {
+		result1 time.Duration
+		result2 time.Duration
+	}
+	batchTimeoutBoundsReturnsOnCall map[int]struct {
+		result1 time.Duration
+		result2 time.Duration
+	}
 	CapabilitiesStub        func() channelconfig.OrdererCapabilities
 	capabilitiesMutex       sync.RWMutex
 	capabilitiesArgsForCall []struct {
@@ -218,6 +230,61 @@ func (fake *OrdererConfig) BatchTimeoutReturnsOnCall(i int, result1 time.Duratio
 	}{result1}
 }
 
+func (fake *OrdererConfig) BatchTimeoutBounds() (time.Duration, time.Duration) {
+	fake.batchTimeoutBoundsMutex.Lock()
+	ret, specificReturn := fake.batchTimeoutBoundsReturnsOnCall[len(fake.batchTimeoutBoundsArgsForCall)]
+	fake.batchTimeoutBoundsArgsForCall = append(fake.batchTimeoutBoundsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("BatchTimeoutBounds", []interface{}{})
+	fake.batchTimeoutBoundsMutex.Unlock()
+	if fake.BatchTimeoutBoundsStub != nil {
+		return fake.BatchTimeoutBoundsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.batchTimeoutBoundsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *OrdererConfig) BatchTimeoutBoundsCallCount() int {
+	fake.batchTimeoutBoundsMutex.RLock()
+	defer fake.batchTimeoutBoundsMutex.RUnlock()
+	return len(fake.batchTimeoutBoundsArgsForCall)
+}
+
+func (fake *OrdererConfig) BatchTimeoutBoundsCalls(stub func() (time.Duration, time.Duration)) {
+	fake.batchTimeoutBoundsMutex.Lock()
+	defer fake.batchTimeoutBoundsMutex.Unlock()
+	fake.BatchTimeoutBoundsStub = stub
+}
+
+func (fake *OrdererConfig) BatchTimeoutBoundsReturns(result1 time.Duration, result2 time.Duration) {
+	fake.batchTimeoutBoundsMutex.Lock()
+	defer fake.batchTimeoutBoundsMutex.Unlock()
+	fake.BatchTimeoutBoundsStub = nil
+	fake.batchTimeoutBoundsReturns = struct {
+		result1 time.Duration
+		result2 time.Duration
+	}{result1, result2}
+}
+
+func (fake *OrdererConfig) BatchTimeoutBoundsReturnsOnCall(i int, result1 time.Duration, result2 time.Duration) {
+	fake.batchTimeoutBoundsMutex.Lock()
+	defer fake.batchTimeoutBoundsMutex.Unlock()
+	fake.BatchTimeoutBoundsStub = nil
+	if fake.batchTimeoutBoundsReturnsOnCall == nil {
+		fake.batchTimeoutBoundsReturnsOnCall = make(map[int]struct {
+			result1 time.Duration
+			result2 time.Duration
+		})
+	}
+	fake.batchTimeoutBoundsReturnsOnCall[i] = struct {
+		result1 time.Duration
+		result2 time.Duration
+	}{result1, result2}
+}
+
 func (fake *OrdererConfig) Capabilities() channelconfig.OrdererCapabilities {
 	fake.capabilitiesMutex.Lock()
 	ret, specificReturn := fake.capabilitiesReturnsOnCall[len(fake.capabilitiesArgsForCall)]
@@ -641,6 +708,8 @@ func (fake *OrdererConfig) Invocations() map[string][][]interface{} {
 	defer fake.batchSizeMutex.RUnlock()
 	fake.batchTimeoutMutex.RLock()
 	defer fake.batchTimeoutMutex.RUnlock()
+	fake.batchTimeoutBoundsMutex.RLock()
+	defer fake.batchTimeoutBoundsMutex.RUnlock()
 	fake.capabilitiesMutex.RLock()
 	defer fake.capabilitiesMutex.RUnlock()
 	fake.consensusMetadataMutex.RLock()
@@ -0,0 +1,335 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package broadcast
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// AdmissionConfig configures the admission of transactions into the consenters.
+type AdmissionConfig struct {
+	// Rate is the number of transactions per second that are admitted on a channel
+	// for an organization of weight 1. Zero means that the rate is not limited.
+	Rate float64
+	// Burst is the number of transactions that an organization of weight 1
+	// may submit at once on a channel, when the rate is limited.
+	Burst int
+	// MaxInFlight is the number of transactions that are enqueued into the consenter
+	// of a channel concurrently. Zero means that the transactions do not wait.
+	MaxInFlight int
+	// QueueSize is the number of transactions that may wait to be enqueued on a channel.
+	// It is divided among the organizations with waiting transactions by their weights.
+	QueueSize int
+	// RetryAfter is the time after which clients are told to retry, when their
+	// organization exceeds its share of the queue.
+	RetryAfter time.Duration
+	// Weights are the weights of the organizations, by MSP ID.
+	// Organizations without a weight have a weight of 1.
+	Weights map[string]uint32
+}
+
+// AdmissionError is returned when an organization exceeds its share of a channel.
+type AdmissionError struct {
+	Org        string
+	Channel    string
+	Reason     string
+	RetryAfter time.Duration
+}
+
+func (e *AdmissionError) Error() string {
+	return fmt.Sprintf("organization %s %s on channel %s, retry after %s", e.Org, e.Reason, e.Channel, e.RetryAfter)
+}
+
+// Admission admits transactions into the consenters with weighted fair queuing among
+// the organizations that submit them, so that an organization which floods a channel
+// with transactions does not starve the other organizations of the channel.
+type Admission struct {
+	config AdmissionConfig
+	now    func() time.Time
+
+	mutex     sync.Mutex
+	channels  map[string]*channelAdmission
+	lastPrune time.Time
+}
+
+// admissionPruneInterval is the interval at which the state of the idle channels
+// and organizations is dropped.
+const admissionPruneInterval = time.Minute
+
+// NewAdmission creates an Admission with the given config.
+func NewAdmission(config AdmissionConfig) *Admission {
+	return &Admission{
+		config:   config,
+		now:      time.Now,
+		channels: make(map[string]*channelAdmission),
+	}
+}
+
+func newChannelAdmission() *channelAdmission {
+	return &channelAdmission{
+		pending:    make(map[string]int),
+		lastFinish: make(map[string]float64),
+		buckets:    make(map[string]*tokenBucket),
+	}
+}
+
+// channelAdmission is the admission state of a channel.
+type channelAdmission struct {
+	inFlight int
+	waiting  ticketQueue
+	pending  map[string]int // number of waiting transactions by organization
+
+	// virtualTime is the finish time of the last admitted ticket, and lastFinish
+	// is the finish time of the last ticket of each organization.
+	virtualTime float64
+	lastFinish  map[string]float64
+	sequence    uint64
+
+	buckets map[string]*tokenBucket
+}
+
+// ticket is a transaction that waits to be admitted.
+type ticket struct {
+	org    string
+	finish float64
+	seq    uint64
+	index  int
+	ready  chan struct{}
+}
+
+// ticketQueue is a heap of tickets ordered by their finish time.
+type ticketQueue []*ticket
+
+func (q ticketQueue) Len() int { return len(q) }
+func (q ticketQueue) Less(i, j int) bool {
+	if q[i].finish != q[j].finish {
+		return q[i].finish < q[j].finish
+	}
+	return q[i].seq < q[j].seq
+}
+func (q ticketQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+func (q *ticketQueue) Push(x interface{}) {
+	t := x.(*ticket)
+	t.index = len(*q)
+	*q = append(*q, t)
+}
+func (q *ticketQueue) Pop() interface{} {
+	old := *q
+	t := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return t
+}
+
+// tokenBucket limits the rate of the transactions of an organization.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// take takes a token from the bucket, or returns the time until a token is available.
+func (b *tokenBucket) take(now time.Time, rate, burst float64) (bool, time.Duration) {
+	b.tokens = b.available(now, rate, burst)
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1 - b.tokens) / rate * float64(time.Second))
+}
+
+// available returns the number of tokens in the bucket at the given time.
+func (b *tokenBucket) available(now time.Time, rate, burst float64) float64 {
+	return math.Min(burst, b.tokens+now.Sub(b.last).Seconds()*rate)
+}
+
+func (a *Admission) weight(org string) uint32 {
+	if w, exists := a.config.Weights[org]; exists && w > 0 {
+		return w
+	}
+	return 1
+}
+
+// limits returns the rate and the burst of the given organization.
+func (a *Admission) limits(org string) (rate, burst float64) {
+	weight := float64(a.weight(org))
+	return a.config.Rate * weight, math.Max(1, float64(a.config.Burst)*weight)
+}
+
+// Admit waits until a transaction of the given organization may be enqueued on the given channel,
+// and returns a function which must be called once the transaction was enqueued. If the organization
+// exceeds its rate or its share of the queue, an *AdmissionError is returned. If the context is done
+// while the transaction waits, the transaction leaves the queue and the error of the context is returned.
+func (a *Admission) Admit(ctx context.Context, channel, org string) (func(), error) {
+	a.mutex.Lock()
+	now := a.now()
+	a.prune(now)
+	ca, exists := a.channels[channel]
+	if !exists {
+		ca = newChannelAdmission()
+		a.channels[channel] = ca
+	}
+
+	weight := float64(a.weight(org))
+	if a.config.Rate > 0 {
+		rate, burst := a.limits(org)
+		bucket, exists := ca.buckets[org]
+		if !exists {
+			bucket = &tokenBucket{tokens: burst, last: now}
+			ca.buckets[org] = bucket
+		}
+		if ok, wait := bucket.take(now, rate, burst); !ok {
+			a.mutex.Unlock()
+			return nil, &AdmissionError{Org: org, Channel: channel, Reason: "exceeds its rate", RetryAfter: wait}
+		}
+	}
+
+	release := func() { a.release(ca) }
+	if a.config.MaxInFlight <= 0 || (ca.inFlight < a.config.MaxInFlight && ca.waiting.Len() == 0) {
+		ca.inFlight++
+		a.mutex.Unlock()
+		return release, nil
+	}
+
+	if ca.pending[org] >= a.share(ca, org) {
+		a.mutex.Unlock()
+		return nil, &AdmissionError{Org: org, Channel: channel, Reason: "exceeds its share of the queue", RetryAfter: a.config.RetryAfter}
+	}
+
+	finish := math.Max(ca.virtualTime, ca.lastFinish[org]) + 1/weight
+	ca.lastFinish[org] = finish
+	ca.sequence++
+	t := &ticket{org: org, finish: finish, seq: ca.sequence, ready: make(chan struct{})}
+	heap.Push(&ca.waiting, t)
+	ca.pending[org]++
+	a.mutex.Unlock()
+
+	select {
+	case <-t.ready:
+		return release, nil
+	case <-ctx.Done():
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	select {
+	case <-t.ready:
+		// the transaction was admitted meanwhile, its slot goes to the next one
+		a.admitNext(ca)
+	default:
+		heap.Remove(&ca.waiting, t.index)
+		a.leaveQueue(ca, t.org)
+	}
+	return nil, errors.Wrapf(ctx.Err(), "organization %s stopped waiting on channel %s", org, channel)
+}
+
+// share returns the number of transactions of the given organization that may wait,
+// which is its weighted share of the queue among the organizations with waiting transactions.
+func (a *Admission) share(ca *channelAdmission, org string) int {
+	total := a.weight(org)
+	for other := range ca.pending {
+		if other != org {
+			total += a.weight(other)
+		}
+	}
+	share := a.config.QueueSize * int(a.weight(org)) / int(total)
+	if share < 1 {
+		return 1
+	}
+	return share
+}
+
+// release admits the waiting transaction with the earliest finish time, once a transaction was enqueued.
+func (a *Admission) release(ca *channelAdmission) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.admitNext(ca)
+}
+
+// admitNext hands the slot of a transaction that leaves the channel to the waiting transaction
+// with the earliest finish time. It must be called with the mutex locked.
+func (a *Admission) admitNext(ca *channelAdmission) {
+	ca.inFlight--
+	if ca.waiting.Len() == 0 {
+		return
+	}
+
+	t := heap.Pop(&ca.waiting).(*ticket)
+	ca.virtualTime = t.finish
+	a.leaveQueue(ca, t.org)
+	ca.inFlight++
+	close(t.ready)
+}
+
+// leaveQueue accounts for a transaction of the given organization which leaves the queue. Once
+// the queue is empty, the finish times of the organizations do not matter anymore, as they are
+// not later than the virtual time. It must be called with the mutex locked.
+func (a *Admission) leaveQueue(ca *channelAdmission, org string) {
+	ca.pending[org]--
+	if ca.pending[org] == 0 {
+		delete(ca.pending, org)
+	}
+	if ca.waiting.Len() == 0 {
+		ca.lastFinish = make(map[string]float64)
+	}
+}
+
+// prune drops, at most once per admissionPruneInterval, the token buckets that are full, which
+// are the same as new ones, and the channels that have neither transactions nor buckets left,
+// e.g. because they were removed. It must be called with the mutex locked.
+func (a *Admission) prune(now time.Time) {
+	if now.Sub(a.lastPrune) < admissionPruneInterval {
+		return
+	}
+	a.lastPrune = now
+
+	for channel, ca := range a.channels {
+		for org, bucket := range ca.buckets {
+			rate, burst := a.limits(org)
+			if bucket.available(now, rate, burst) >= burst {
+				delete(ca.buckets, org)
+			}
+		}
+		if ca.inFlight == 0 && ca.waiting.Len() == 0 && len(ca.buckets) == 0 {
+			delete(a.channels, channel)
+		}
+	}
+}
+
+// creatorOrg returns the MSP ID of the creator of the given envelope.
+func creatorOrg(msg *cb.Envelope) (string, error) {
+	payload, err := utils.UnmarshalPayload(msg.Payload)
+	if err != nil {
+		return "", err
+	}
+	if payload.Header == nil {
+		return "", errors.New("missing header in payload")
+	}
+	shdr, err := utils.GetSignatureHeader(payload.Header.SignatureHeader)
+	if err != nil {
+		return "", err
+	}
+	creator := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(shdr.Creator, creator); err != nil {
+		return "", errors.Wrap(err, "failed unmarshaling creator")
+	}
+	return creator.Mspid, nil
+}
@@ -0,0 +1,198 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package broadcast
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// waitPending waits until the given number of transactions of the given organization wait on the channel.
+func waitPending(t *testing.T, a *Admission, channel, org string, n int) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		a.mutex.Lock()
+		pending := a.channels[channel].pending[org]
+		a.mutex.Unlock()
+		if pending == n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("%d transactions of %s did not wait", n, org)
+}
+
+func TestAdmissionFairQueuing(t *testing.T) {
+	a := NewAdmission(AdmissionConfig{MaxInFlight: 1, QueueSize: 10, Weights: map[string]uint32{"org3": 2}})
+
+	// The in flight transaction holds back the others
+	release, err := a.Admit(context.Background(), "ch", "org1")
+	assert.NoError(t, err)
+
+	var lock sync.Mutex
+	var admitted []string
+	var wg sync.WaitGroup
+	admit := func(org string, n int) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, err := a.Admit(context.Background(), "ch", org)
+			assert.NoError(t, err)
+			lock.Lock()
+			admitted = append(admitted, org)
+			lock.Unlock()
+			release()
+		}()
+		waitPending(t, a, "ch", org, n)
+	}
+
+	// org1 floods the channel before the others submit
+	admit("org1", 1)
+	admit("org1", 2)
+	admit("org1", 3)
+	admit("org2", 1)
+	admit("org3", 1)
+	admit("org3", 2)
+
+	release()
+	wg.Wait()
+
+	// org3 has twice the weight of the others
+	assert.Equal(t, []string{"org3", "org1", "org2", "org3", "org1", "org1"}, admitted)
+}
+
+func TestAdmissionQueueShare(t *testing.T) {
+	a := NewAdmission(AdmissionConfig{MaxInFlight: 1, QueueSize: 2, RetryAfter: time.Second})
+
+	release, err := a.Admit(context.Background(), "ch", "org1")
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	wait := func(org string, n int) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, err := a.Admit(context.Background(), "ch", org)
+			assert.NoError(t, err)
+			release()
+		}()
+		waitPending(t, a, "ch", org, n)
+	}
+
+	// org1 alone may fill the queue
+	wait("org1", 1)
+	wait("org1", 2)
+	_, err = a.Admit(context.Background(), "ch", "org1")
+	assert.EqualError(t, err, "organization org1 exceeds its share of the queue on channel ch, retry after 1s")
+	assert.Equal(t, time.Second, err.(*AdmissionError).RetryAfter)
+
+	// org2 gets its share of the queue
+	wait("org2", 1)
+	_, err = a.Admit(context.Background(), "ch", "org2")
+	assert.EqualError(t, err, "organization org2 exceeds its share of the queue on channel ch, retry after 1s")
+
+	// Other channels are not affected
+	releaseOther, err := a.Admit(context.Background(), "other", "org1")
+	assert.NoError(t, err)
+	releaseOther()
+
+	release()
+	wg.Wait()
+}
+
+func TestAdmissionRate(t *testing.T) {
+	now := time.Now()
+	a := NewAdmission(AdmissionConfig{Rate: 2, Burst: 1, Weights: map[string]uint32{"org2": 2}})
+	a.now = func() time.Time { return now }
+
+	admit := func(org string) error {
+		release, err := a.Admit(context.Background(), "ch", org)
+		if err == nil {
+			release()
+		}
+		return err
+	}
+
+	assert.NoError(t, admit("org1"))
+	err := admit("org1")
+	assert.EqualError(t, err, "organization org1 exceeds its rate on channel ch, retry after 500ms")
+
+	// org2 has twice the burst
+	assert.NoError(t, admit("org2"))
+	assert.NoError(t, admit("org2"))
+	assert.Error(t, admit("org2"))
+
+	now = now.Add(500 * time.Millisecond)
+	assert.NoError(t, admit("org1"))
+	assert.Error(t, admit("org1"))
+}
+
+func TestAdmissionCancel(t *testing.T) {
+	a := NewAdmission(AdmissionConfig{MaxInFlight: 1, QueueSize: 10})
+
+	release, err := a.Admit(context.Background(), "ch", "org1")
+	assert.NoError(t, err)
+
+	// The transaction of a client that goes away leaves the queue
+	ctx, cancel := context.WithCancel(context.Background())
+	errC := make(chan error, 1)
+	go func() {
+		_, err := a.Admit(ctx, "ch", "org2")
+		errC <- err
+	}()
+	waitPending(t, a, "ch", "org2", 1)
+	cancel()
+	assert.EqualError(t, <-errC, "organization org2 stopped waiting on channel ch: context canceled")
+	waitPending(t, a, "ch", "org2", 0)
+	assert.Equal(t, 0, a.channels["ch"].waiting.Len())
+
+	// and does not hold back the next one
+	admitted := make(chan struct{})
+	go func() {
+		release, err := a.Admit(context.Background(), "ch", "org3")
+		assert.NoError(t, err)
+		release()
+		close(admitted)
+	}()
+	waitPending(t, a, "ch", "org3", 1)
+	release()
+	<-admitted
+	assert.Equal(t, 0, a.channels["ch"].inFlight)
+}
+
+func TestAdmissionPrune(t *testing.T) {
+	now := time.Now()
+	a := NewAdmission(AdmissionConfig{Rate: 2, Burst: 1})
+	a.now = func() time.Time { return now }
+
+	release, err := a.Admit(context.Background(), "idle", "org1")
+	assert.NoError(t, err)
+	release()
+	_, err = a.Admit(context.Background(), "busy", "org1")
+	assert.NoError(t, err)
+
+	// Nothing is dropped before the prune interval elapsed
+	now = now.Add(admissionPruneInterval / 2)
+	_, err = a.Admit(context.Background(), "busy", "org2")
+	assert.NoError(t, err)
+	assert.Len(t, a.channels, 2)
+	assert.Len(t, a.channels["busy"].buckets, 2)
+
+	// The idle channel is dropped, and the busy one keeps only the buckets that are not full
+	now = now.Add(admissionPruneInterval / 2)
+	_, err = a.Admit(context.Background(), "busy", "org3")
+	assert.NoError(t, err)
+	assert.Len(t, a.channels, 1)
+	assert.Contains(t, a.channels, "busy")
+	assert.Len(t, a.channels["busy"].buckets, 1)
+	assert.Contains(t, a.channels["busy"].buckets, "org3")
+	assert.Equal(t, 3, a.channels["busy"].inFlight)
+}
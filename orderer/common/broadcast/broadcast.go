@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package broadcast
 
 import (
+	"context"
 	"io"
 	"time"
 
@@ -60,6 +61,9 @@ type Consenter interface {
 type Handler struct {
 	SupportRegistrar ChannelSupportRegistrar
 	Metrics          *Metrics
+	// Admission admits normal messages into the consenters fairly among organizations.
+	// When nil, messages are enqueued in arrival order.
+	Admission *Admission
 }
 
 // Handle reads requests from a Broadcast stream, processes them, and returns the responses to the stream
@@ -77,7 +81,7 @@ func (bh *Handler) Handle(srv ab.AtomicBroadcast_BroadcastServer) error {
 			return err
 		}
 
-		resp := bh.ProcessMessage(msg, addr)
+		resp := bh.ProcessMessage(srv.Context(), msg, addr)
 		err = srv.Send(resp)
 		if resp.Status != cb.Status_SUCCESS {
 			return err
@@ -93,10 +97,13 @@ func (bh *Handler) Handle(srv ab.AtomicBroadcast_BroadcastServer) error {
 
 type MetricsTracker struct {
 	ValidateStartTime time.Time
+	AdmitStartTime    time.Time
 	EnqueueStartTime  time.Time
 	ValidateDuration  time.Duration
+	AdmitDuration     time.Duration
 	ChannelID         string
 	TxType            string
+	Org               string
 	Metrics           *Metrics
 }
 
@@ -118,6 +125,21 @@ func (mt *MetricsTracker) Record(resp *ab.BroadcastResponse) {
 	}
 
 	mt.Metrics.ProcessedCount.With(labels...).Add(1)
+
+	orgLabels := []string{
+		"status", resp.Status.String(),
+		"channel", mt.ChannelID,
+		"org", mt.Org,
+	}
+
+	if mt.AdmitStartTime != (time.Time{}) {
+		if mt.AdmitDuration == 0 {
+			mt.EndAdmit()
+		}
+		mt.Metrics.AdmissionDuration.With(orgLabels...).Observe(mt.AdmitDuration.Seconds())
+	}
+
+	mt.Metrics.OrgProcessedCount.With(orgLabels...).Add(1)
 }
 
 func (mt *MetricsTracker) BeginValidate() {
@@ -128,15 +150,33 @@ func (mt *MetricsTracker) EndValidate() {
 	mt.ValidateDuration = time.Since(mt.ValidateStartTime)
 }
 
+func (mt *MetricsTracker) BeginAdmit() {
+	mt.AdmitStartTime = time.Now()
+}
+
+func (mt *MetricsTracker) EndAdmit() {
+	mt.AdmitDuration = time.Since(mt.AdmitStartTime)
+}
+
+// SetOrg sets the organization label to the MSP ID of the creator of the given message. It must only
+// be called once the message is validated, so that clients cannot make up label values
+func (mt *MetricsTracker) SetOrg(msg *cb.Envelope) {
+	if org, err := creatorOrg(msg); err == nil && org != "" {
+		mt.Org = org
+	}
+}
+
 func (mt *MetricsTracker) BeginEnqueue() {
 	mt.EnqueueStartTime = time.Now()
 }
 
-// ProcessMessage validates and enqueues a single message
-func (bh *Handler) ProcessMessage(msg *cb.Envelope, addr string) (resp *ab.BroadcastResponse) {
+// ProcessMessage validates and enqueues a single message. The context is the one of the
+// stream that carries the message, the admission of the message is canceled along with it
+func (bh *Handler) ProcessMessage(ctx context.Context, msg *cb.Envelope, addr string) (resp *ab.BroadcastResponse) {
 	tracker := &MetricsTracker{
 		ChannelID: "unknown",
 		TxType:    "unknown",
+		Org:       "unknown",
 		Metrics:   bh.Metrics,
 	}
 	defer func() {
@@ -156,7 +196,6 @@ func (bh *Handler) ProcessMessage(msg *cb.Envelope, addr string) (resp *ab.Broad
 		logger.Warningf("[channel: %s] Could not get message processor for serving %s: %s", tracker.ChannelID, addr, err)
 		return &ab.BroadcastResponse{Status: cb.Status_BAD_REQUEST, Info: err.Error()}
 	}
-
 	if !isConfig {
 		logger.Debugf("[channel: %s] Broadcast is processing normal message from %s with txid '%s' of type %s", chdr.ChannelId, addr, chdr.TxId, cb.HeaderType_name[chdr.Type])
 
@@ -166,6 +205,18 @@ func (bh *Handler) ProcessMessage(msg *cb.Envelope, addr string) (resp *ab.Broad
 			return &ab.BroadcastResponse{Status: ClassifyError(err), Info: err.Error()}
 		}
 		tracker.EndValidate()
+		tracker.SetOrg(msg)
+
+		if bh.Admission != nil {
+			tracker.BeginAdmit()
+			release, err := bh.Admission.Admit(ctx, chdr.ChannelId, tracker.Org)
+			tracker.EndAdmit()
+			if err != nil {
+				logger.Warningf("[channel: %s] Rejecting broadcast of normal message from %s with SERVICE_UNAVAILABLE: rejected by admission: %s", chdr.ChannelId, addr, err)
+				return &ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE, Info: err.Error()}
+			}
+			defer release()
+		}
 
 		tracker.BeginEnqueue()
 		if err = processor.WaitReady(); err != nil {
@@ -187,6 +238,7 @@ func (bh *Handler) ProcessMessage(msg *cb.Envelope, addr string) (resp *ab.Broad
 			return &ab.BroadcastResponse{Status: ClassifyError(err), Info: err.Error()}
 		}
 		tracker.EndValidate()
+		tracker.SetOrg(msg)
 
 		tracker.BeginEnqueue()
 		if err = processor.WaitReady(); err != nil {
@@ -19,7 +19,9 @@ import (
 	"github.com/hyperledger/fabric/orderer/common/broadcast/mock"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
 	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
 )
 
 var _ = Describe("Broadcast", func() {
@@ -29,6 +31,8 @@ var _ = Describe("Broadcast", func() {
 		fakeValidateHistogram *mock.MetricsHistogram
 		fakeEnqueueHistogram  *mock.MetricsHistogram
 		fakeProcessedCounter  *mock.MetricsCounter
+		fakeAdmitHistogram    *mock.MetricsHistogram
+		fakeOrgCounter        *mock.MetricsCounter
 	)
 
 	BeforeEach(func() {
@@ -43,12 +47,20 @@ var _ = Describe("Broadcast", func() {
 		fakeProcessedCounter = &mock.MetricsCounter{}
 		fakeProcessedCounter.WithReturns(fakeProcessedCounter)
 
+		fakeAdmitHistogram = &mock.MetricsHistogram{}
+		fakeAdmitHistogram.WithReturns(fakeAdmitHistogram)
+
+		fakeOrgCounter = &mock.MetricsCounter{}
+		fakeOrgCounter.WithReturns(fakeOrgCounter)
+
 		handler = &broadcast.Handler{
 			SupportRegistrar: fakeSupportRegistrar,
 			Metrics: &broadcast.Metrics{
-				ValidateDuration: fakeValidateHistogram,
-				EnqueueDuration:  fakeEnqueueHistogram,
-				ProcessedCount:   fakeProcessedCounter,
+				ValidateDuration:  fakeValidateHistogram,
+				EnqueueDuration:   fakeEnqueueHistogram,
+				ProcessedCount:    fakeProcessedCounter,
+				AdmissionDuration: fakeAdmitHistogram,
+				OrgProcessedCount: fakeOrgCounter,
 			},
 		}
 	})
@@ -124,10 +136,63 @@ var _ = Describe("Broadcast", func() {
 			Expect(fakeProcessedCounter.AddCallCount()).To(Equal(1))
 			Expect(fakeProcessedCounter.AddArgsForCall(0)).To(Equal(float64(1)))
 
+			Expect(fakeAdmitHistogram.ObserveCallCount()).To(Equal(0))
+			Expect(fakeOrgCounter.WithCallCount()).To(Equal(1))
+			Expect(fakeOrgCounter.WithArgsForCall(0)).To(Equal([]string{
+				"status", "SUCCESS",
+				"channel", "fake-channel",
+				"org", "unknown",
+			}))
+			Expect(fakeOrgCounter.AddCallCount()).To(Equal(1))
+
 			Expect(fakeABServer.SendCallCount()).To(Equal(1))
 			Expect(proto.Equal(fakeABServer.SendArgsForCall(0), &ab.BroadcastResponse{Status: cb.Status_SUCCESS})).To(BeTrue())
 		})
 
+		Context("when admission is enabled", func() {
+			BeforeEach(func() {
+				fakeMsg.Payload = utils.MarshalOrPanic(&cb.Payload{
+					Header: &cb.Header{
+						SignatureHeader: utils.MarshalOrPanic(&cb.SignatureHeader{
+							Creator: utils.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "Org1MSP"}),
+						}),
+					},
+				})
+				fakeABServer.RecvReturnsOnCall(1, fakeMsg, nil)
+				fakeABServer.RecvReturnsOnCall(2, nil, io.EOF)
+
+				handler.Admission = broadcast.NewAdmission(broadcast.AdmissionConfig{
+					Rate:  0.001,
+					Burst: 1,
+				})
+			})
+
+			It("rejects the messages of an organization that exceeds its rate", func() {
+				err := handler.Handle(fakeABServer)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeSupport.OrderCallCount()).To(Equal(1))
+				Expect(fakeABServer.SendCallCount()).To(Equal(2))
+				Expect(fakeABServer.SendArgsForCall(0).Status).To(Equal(cb.Status_SUCCESS))
+				resp := fakeABServer.SendArgsForCall(1)
+				Expect(resp.Status).To(Equal(cb.Status_SERVICE_UNAVAILABLE))
+				Expect(resp.Info).To(MatchRegexp("organization Org1MSP exceeds its rate on channel fake-channel, retry after .*s"))
+
+				Expect(fakeAdmitHistogram.ObserveCallCount()).To(Equal(2))
+				Expect(fakeOrgCounter.WithCallCount()).To(Equal(2))
+				Expect(fakeOrgCounter.WithArgsForCall(0)).To(Equal([]string{
+					"status", "SUCCESS",
+					"channel", "fake-channel",
+					"org", "Org1MSP",
+				}))
+				Expect(fakeOrgCounter.WithArgsForCall(1)).To(Equal([]string{
+					"status", "SERVICE_UNAVAILABLE",
+					"channel", "fake-channel",
+					"org", "Org1MSP",
+				}))
+			})
+		})
+
 		Context("when the channel support cannot be retrieved", func() {
 			BeforeEach(func() {
 				fakeSupportRegistrar.BroadcastChannelSupportReturns(&cb.ChannelHeader{
@@ -241,6 +306,26 @@ var _ = Describe("Broadcast", func() {
 				)).To(BeTrue())
 			})
 
+			It("does not label the metrics with the unverified creator", func() {
+				fakeMsg.Payload = utils.MarshalOrPanic(&cb.Payload{
+					Header: &cb.Header{
+						SignatureHeader: utils.MarshalOrPanic(&cb.SignatureHeader{
+							Creator: utils.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "MadeUpMSP"}),
+						}),
+					},
+				})
+
+				err := handler.Handle(fakeABServer)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeOrgCounter.WithCallCount()).To(Equal(1))
+				Expect(fakeOrgCounter.WithArgsForCall(0)).To(Equal([]string{
+					"status", "BAD_REQUEST",
+					"channel", "fake-channel",
+					"org", "unknown",
+				}))
+			})
+
 			Context("when the error cause is msgprocessor.ErrChannelDoesNotExist", func() {
 				BeforeEach(func() {
 					fakeSupport.ProcessNormalMsgReturns(0, msgprocessor.ErrChannelDoesNotExist)
@@ -30,18 +30,36 @@ var (
 		LabelNames:   []string{"channel", "type", "status"},
 		StatsdFormat: "%{#fqname}.%{channel}.%{type}.%{status}",
 	}
+	admissionDuration = metrics.HistogramOpts{
+		Namespace:    "broadcast",
+		Name:         "admission_duration",
+		Help:         "The time a transaction waits to be admitted in seconds.",
+		LabelNames:   []string{"channel", "org", "status"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{org}.%{status}",
+	}
+	orgProcessedCount = metrics.CounterOpts{
+		Namespace:    "broadcast",
+		Name:         "org_processed_count",
+		Help:         "The number of transactions processed per organization.",
+		LabelNames:   []string{"channel", "org", "status"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{org}.%{status}",
+	}
 )
 
 type Metrics struct {
-	ValidateDuration metrics.Histogram
-	EnqueueDuration  metrics.Histogram
-	ProcessedCount   metrics.Counter
+	ValidateDuration  metrics.Histogram
+	EnqueueDuration   metrics.Histogram
+	ProcessedCount    metrics.Counter
+	AdmissionDuration metrics.Histogram
+	OrgProcessedCount metrics.Counter
 }
 
 func NewMetrics(p metrics.Provider) *Metrics {
 	return &Metrics{
-		ValidateDuration: p.NewHistogram(validateDuration),
-		EnqueueDuration:  p.NewHistogram(enqueueDuration),
-		ProcessedCount:   p.NewCounter(processedCount),
+		ValidateDuration:  p.NewHistogram(validateDuration),
+		EnqueueDuration:   p.NewHistogram(enqueueDuration),
+		ProcessedCount:    p.NewCounter(processedCount),
+		AdmissionDuration: p.NewHistogram(admissionDuration),
+		OrgProcessedCount: p.NewCounter(orgProcessedCount),
 	}
 }
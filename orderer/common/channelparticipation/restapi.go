@@ -0,0 +1,195 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channelparticipation
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"path"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/gorilla/mux"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/orderer/common/multichannel"
+	"github.com/hyperledger/fabric/orderer/common/types"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+const (
+	// URLBaseV1 is the path under which the channel participation API is served.
+	URLBaseV1 = "/participation/v1/"
+	// URLBaseV1Channels is the path of the channels resource.
+	URLBaseV1Channels = URLBaseV1 + "channels"
+
+	channelIDKey = "channelID"
+)
+
+//go:generate mockery -dir . -name ChannelManagement -case underscore -output mocks
+
+// ChannelManagement lists, joins and removes the channels of the orderer.
+type ChannelManagement interface {
+	// ChannelList returns the channels of the orderer.
+	ChannelList() types.ChannelList
+
+	// ChannelInfo returns the info of the given channel.
+	ChannelInfo(channelID string) (types.ChannelInfo, error)
+
+	// JoinChannel makes the orderer join the given channel with the given config block.
+	JoinChannel(channelID string, configBlock *cb.Block) (types.ChannelInfo, error)
+
+	// RemoveChannel makes the orderer leave the given channel, and removes its ledger.
+	RemoveChannel(channelID string) error
+}
+
+// HTTPHandler serves the channel participation API.
+type HTTPHandler struct {
+	logger    *flogging.FabricLogger
+	config    localconfig.ChannelParticipation
+	registrar ChannelManagement
+	router    *mux.Router
+}
+
+// NewHTTPHandler creates an HTTPHandler that manages the channels of the given ChannelManagement.
+func NewHTTPHandler(config localconfig.ChannelParticipation, registrar ChannelManagement) *HTTPHandler {
+	handler := &HTTPHandler{
+		logger:    flogging.MustGetLogger("orderer.common.channelparticipation"),
+		config:    config,
+		registrar: registrar,
+		router:    mux.NewRouter(),
+	}
+
+	handler.router.HandleFunc(URLBaseV1Channels, handler.serveListAll).Methods(http.MethodGet)
+	handler.router.HandleFunc(URLBaseV1Channels, handler.serveJoin).Methods(http.MethodPost)
+	handler.router.HandleFunc(URLBaseV1Channels+"/{"+channelIDKey+"}", handler.serveListOne).Methods(http.MethodGet)
+	handler.router.HandleFunc(URLBaseV1Channels+"/{"+channelIDKey+"}", handler.serveRemove).Methods(http.MethodDelete)
+
+	handler.router.HandleFunc(URLBaseV1Channels, handler.serveNotAllowed("GET, POST"))
+	handler.router.HandleFunc(URLBaseV1Channels+"/{"+channelIDKey+"}", handler.serveNotAllowed("GET, DELETE"))
+	handler.router.NotFoundHandler = http.HandlerFunc(handler.serveNotFound)
+
+	return handler
+}
+
+func (h *HTTPHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	h.router.ServeHTTP(resp, req)
+}
+
+// serveListAll lists all the channels of the orderer.
+func (h *HTTPHandler) serveListAll(resp http.ResponseWriter, req *http.Request) {
+	list := h.registrar.ChannelList()
+	if list.SystemChannel != nil {
+		list.SystemChannel.URL = channelURL(list.SystemChannel.Name)
+	}
+	for i := range list.Channels {
+		list.Channels[i].URL = channelURL(list.Channels[i].Name)
+	}
+	h.sendResponse(resp, http.StatusOK, list)
+}
+
+// serveListOne lists the given channel.
+func (h *HTTPHandler) serveListOne(resp http.ResponseWriter, req *http.Request) {
+	channelID := mux.Vars(req)[channelIDKey]
+	info, err := h.registrar.ChannelInfo(channelID)
+	if err != nil {
+		h.sendResponse(resp, statusCode(err, http.StatusInternalServerError), err)
+		return
+	}
+	info.URL = channelURL(channelID)
+	h.sendResponse(resp, http.StatusOK, info)
+}
+
+// serveJoin joins the channel of the config block carried by the request body.
+func (h *HTTPHandler) serveJoin(resp http.ResponseWriter, req *http.Request) {
+	body, err := ioutil.ReadAll(http.MaxBytesReader(resp, req.Body, int64(h.config.MaxRequestBodySize)))
+	if err != nil {
+		h.sendResponse(resp, http.StatusBadRequest, errors.Wrap(err, "cannot read request body"))
+		return
+	}
+
+	block := &cb.Block{}
+	if err := proto.Unmarshal(body, block); err != nil {
+		h.sendResponse(resp, http.StatusBadRequest, errors.Wrap(err, "cannot unmarshal config block"))
+		return
+	}
+	channelID, err := utils.GetChainIDFromBlock(block)
+	if err != nil {
+		h.sendResponse(resp, http.StatusBadRequest, errors.Wrap(err, "cannot extract channel ID from config block"))
+		return
+	}
+
+	h.logger.Infof("Joining channel %s with config block %d", channelID, block.Header.Number)
+	info, err := h.registrar.JoinChannel(channelID, block)
+	if err != nil {
+		h.logger.Warningf("Failed joining channel %s: %s", channelID, err)
+		h.sendResponse(resp, statusCode(err, http.StatusBadRequest), err)
+		return
+	}
+
+	info.URL = channelURL(channelID)
+	resp.Header().Set("Location", info.URL)
+	h.sendResponse(resp, http.StatusCreated, info)
+}
+
+// serveRemove removes the given channel.
+func (h *HTTPHandler) serveRemove(resp http.ResponseWriter, req *http.Request) {
+	channelID := mux.Vars(req)[channelIDKey]
+	h.logger.Infof("Removing channel %s", channelID)
+	if err := h.registrar.RemoveChannel(channelID); err != nil {
+		h.logger.Warningf("Failed removing channel %s: %s", channelID, err)
+		h.sendResponse(resp, statusCode(err, http.StatusInternalServerError), err)
+		return
+	}
+	resp.WriteHeader(http.StatusNoContent)
+}
+
+func (h *HTTPHandler) serveNotAllowed(allow string) http.HandlerFunc {
+	return func(resp http.ResponseWriter, req *http.Request) {
+		resp.Header().Set("Allow", allow)
+		h.sendResponse(resp, http.StatusMethodNotAllowed, errors.Errorf("invalid request method: %s", req.Method))
+	}
+}
+
+func (h *HTTPHandler) serveNotFound(resp http.ResponseWriter, req *http.Request) {
+	h.sendResponse(resp, http.StatusNotFound, errors.Errorf("invalid path: %s", req.URL.Path))
+}
+
+func (h *HTTPHandler) sendResponse(resp http.ResponseWriter, code int, payload interface{}) {
+	encoder := json.NewEncoder(resp)
+	if err, ok := payload.(error); ok {
+		payload = &types.ErrorResponse{Error: err.Error()}
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(code)
+
+	if err := encoder.Encode(payload); err != nil {
+		h.logger.Errorw("failed to encode payload", "error", err)
+	}
+}
+
+// statusCode maps the errors of the registrar to HTTP status codes,
+// and any other error to the given default status code.
+func statusCode(err error, defaultCode int) int {
+	switch errors.Cause(err) {
+	case multichannel.ErrChannelNotExist:
+		return http.StatusNotFound
+	case multichannel.ErrSystemChannelExists:
+		return http.StatusMethodNotAllowed
+	case multichannel.ErrChannelAlreadyExists, multichannel.ErrChannelOnboarding:
+		return http.StatusConflict
+	default:
+		return defaultCode
+	}
+}
+
+func channelURL(channelID string) string {
+	return path.Join(URLBaseV1Channels, channelID)
+}
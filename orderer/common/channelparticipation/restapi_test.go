@@ -0,0 +1,208 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channelparticipation_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/orderer/common/channelparticipation"
+	"github.com/hyperledger/fabric/orderer/common/channelparticipation/mocks"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/orderer/common/multichannel"
+	"github.com/hyperledger/fabric/orderer/common/types"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var config = localconfig.ChannelParticipation{Enabled: true, MaxRequestBodySize: 1024 * 1024}
+
+func configBlock(channelID string, number uint64) *cb.Block {
+	env, err := utils.CreateSignedEnvelope(cb.HeaderType_CONFIG, channelID, nil, &cb.ConfigEnvelope{}, 0, 0)
+	if err != nil {
+		panic(err)
+	}
+	block := cb.NewBlock(number, nil)
+	block.Data.Data = [][]byte{utils.MarshalOrPanic(env)}
+	return block
+}
+
+func serve(handler http.Handler, method, url string, body []byte) *httptest.ResponseRecorder {
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest(method, url, bytes.NewReader(body))
+	handler.ServeHTTP(resp, req)
+	return resp
+}
+
+func assertError(t *testing.T, resp *httptest.ResponseRecorder, code int, message string) {
+	assert.Equal(t, code, resp.Code)
+	assert.Equal(t, "application/json", resp.Header().Get("Content-Type"))
+	errResp := &types.ErrorResponse{}
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), errResp))
+	assert.Equal(t, message, errResp.Error)
+}
+
+func TestListAllChannels(t *testing.T) {
+	registrar := &mocks.ChannelManagement{}
+	registrar.On("ChannelList").Return(types.ChannelList{
+		SystemChannel: &types.ChannelInfoShort{Name: "system-channel"},
+		Channels:      []types.ChannelInfoShort{{Name: "app1"}, {Name: "app2"}},
+	})
+	handler := channelparticipation.NewHTTPHandler(config, registrar)
+
+	resp := serve(handler, http.MethodGet, "/participation/v1/channels", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "application/json", resp.Header().Get("Content-Type"))
+	list := types.ChannelList{}
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &list))
+	assert.Equal(t, types.ChannelList{
+		SystemChannel: &types.ChannelInfoShort{Name: "system-channel", URL: "/participation/v1/channels/system-channel"},
+		Channels: []types.ChannelInfoShort{
+			{Name: "app1", URL: "/participation/v1/channels/app1"},
+			{Name: "app2", URL: "/participation/v1/channels/app2"},
+		},
+	}, list)
+}
+
+func TestListOneChannel(t *testing.T) {
+	registrar := &mocks.ChannelManagement{}
+	registrar.On("ChannelInfo", "app1").Return(types.ChannelInfo{
+		Name:              "app1",
+		ConsensusRelation: types.ConsensusRelationFollower,
+		Status:            types.StatusActive,
+		Height:            10,
+	}, nil)
+	registrar.On("ChannelInfo", "app2").Return(types.ChannelInfo{}, multichannel.ErrChannelNotExist)
+	handler := channelparticipation.NewHTTPHandler(config, registrar)
+
+	resp := serve(handler, http.MethodGet, "/participation/v1/channels/app1", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	info := types.ChannelInfo{}
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &info))
+	assert.Equal(t, types.ChannelInfo{
+		Name:              "app1",
+		URL:               "/participation/v1/channels/app1",
+		ConsensusRelation: types.ConsensusRelationFollower,
+		Status:            types.StatusActive,
+		Height:            10,
+	}, info)
+
+	resp = serve(handler, http.MethodGet, "/participation/v1/channels/app2", nil)
+	assertError(t, resp, http.StatusNotFound, "channel does not exist")
+}
+
+func TestJoinChannel(t *testing.T) {
+	block := configBlock("app1", 5)
+
+	t.Run("Success", func(t *testing.T) {
+		registrar := &mocks.ChannelManagement{}
+		registrar.On("JoinChannel", "app1", mock.Anything).Return(types.ChannelInfo{
+			Name:   "app1",
+			Status: types.StatusOnBoarding,
+		}, nil)
+		handler := channelparticipation.NewHTTPHandler(config, registrar)
+
+		resp := serve(handler, http.MethodPost, "/participation/v1/channels", utils.MarshalOrPanic(block))
+		assert.Equal(t, http.StatusCreated, resp.Code)
+		assert.Equal(t, "/participation/v1/channels/app1", resp.Header().Get("Location"))
+		info := types.ChannelInfo{}
+		assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &info))
+		assert.Equal(t, types.ChannelInfo{
+			Name:   "app1",
+			URL:    "/participation/v1/channels/app1",
+			Status: types.StatusOnBoarding,
+		}, info)
+		joinedBlock := registrar.Calls[0].Arguments.Get(1).(*cb.Block)
+		assert.True(t, proto.Equal(block, joinedBlock))
+	})
+
+	for _, tc := range []struct {
+		name    string
+		err     error
+		code    int
+		message string
+	}{
+		{name: "AlreadyExists", err: multichannel.ErrChannelAlreadyExists, code: http.StatusConflict, message: "channel already exists"},
+		{name: "SystemChannel", err: multichannel.ErrSystemChannelExists, code: http.StatusMethodNotAllowed, message: "system channel exists"},
+		{name: "InvalidBlock", err: errors.New("invalid join block: block is empty"), code: http.StatusBadRequest, message: "invalid join block: block is empty"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			registrar := &mocks.ChannelManagement{}
+			registrar.On("JoinChannel", "app1", mock.Anything).Return(types.ChannelInfo{}, tc.err)
+			handler := channelparticipation.NewHTTPHandler(config, registrar)
+
+			resp := serve(handler, http.MethodPost, "/participation/v1/channels", utils.MarshalOrPanic(block))
+			assertError(t, resp, tc.code, tc.message)
+		})
+	}
+
+	t.Run("BadBody", func(t *testing.T) {
+		registrar := &mocks.ChannelManagement{}
+		handler := channelparticipation.NewHTTPHandler(config, registrar)
+
+		resp := serve(handler, http.MethodPost, "/participation/v1/channels", []byte("not a block"))
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.Contains(t, resp.Body.String(), "cannot unmarshal config block")
+
+		resp = serve(handler, http.MethodPost, "/participation/v1/channels", utils.MarshalOrPanic(cb.NewBlock(0, nil)))
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.Contains(t, resp.Body.String(), "cannot extract channel ID from config block")
+		registrar.AssertNotCalled(t, "JoinChannel", mock.Anything, mock.Anything)
+	})
+
+	t.Run("BodyTooLarge", func(t *testing.T) {
+		registrar := &mocks.ChannelManagement{}
+		handler := channelparticipation.NewHTTPHandler(localconfig.ChannelParticipation{Enabled: true, MaxRequestBodySize: 10}, registrar)
+
+		resp := serve(handler, http.MethodPost, "/participation/v1/channels", utils.MarshalOrPanic(block))
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.Contains(t, resp.Body.String(), "cannot read request body")
+		registrar.AssertNotCalled(t, "JoinChannel", mock.Anything, mock.Anything)
+	})
+}
+
+func TestRemoveChannel(t *testing.T) {
+	registrar := &mocks.ChannelManagement{}
+	registrar.On("RemoveChannel", "app1").Return(nil)
+	registrar.On("RemoveChannel", "app2").Return(multichannel.ErrChannelNotExist)
+	registrar.On("RemoveChannel", "app3").Return(multichannel.ErrChannelOnboarding)
+	registrar.On("RemoveChannel", "app4").Return(errors.New("failed removing the ledger of channel app4"))
+	handler := channelparticipation.NewHTTPHandler(config, registrar)
+
+	resp := serve(handler, http.MethodDelete, "/participation/v1/channels/app1", nil)
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+	assert.Empty(t, resp.Body.String())
+
+	resp = serve(handler, http.MethodDelete, "/participation/v1/channels/app2", nil)
+	assertError(t, resp, http.StatusNotFound, "channel does not exist")
+	resp = serve(handler, http.MethodDelete, "/participation/v1/channels/app3", nil)
+	assertError(t, resp, http.StatusConflict, "channel is being onboarded")
+	resp = serve(handler, http.MethodDelete, "/participation/v1/channels/app4", nil)
+	assertError(t, resp, http.StatusInternalServerError, "failed removing the ledger of channel app4")
+}
+
+func TestInvalidRequests(t *testing.T) {
+	handler := channelparticipation.NewHTTPHandler(config, &mocks.ChannelManagement{})
+
+	resp := serve(handler, http.MethodPut, "/participation/v1/channels", nil)
+	assertError(t, resp, http.StatusMethodNotAllowed, "invalid request method: PUT")
+	assert.Equal(t, "GET, POST", resp.Header().Get("Allow"))
+
+	resp = serve(handler, http.MethodPost, "/participation/v1/channels/app1", nil)
+	assertError(t, resp, http.StatusMethodNotAllowed, "invalid request method: POST")
+	assert.Equal(t, "GET, DELETE", resp.Header().Get("Allow"))
+
+	resp = serve(handler, http.MethodGet, "/participation/v1/peers", nil)
+	assertError(t, resp, http.StatusNotFound, "invalid path: /participation/v1/peers")
+}
@@ -28,14 +28,16 @@ var logger = flogging.MustGetLogger("localconfig")
 // modify the default mapping, see the "Unmarshal"
 // section of https://github.com/spf13/viper for more info.
 type TopLevel struct {
-	General    General
-	FileLedger FileLedger
-	RAMLedger  RAMLedger
-	Kafka      Kafka
-	Debug      Debug
-	Consensus  interface{}
-	Operations Operations
-	Metrics    Metrics
+	General              General
+	FileLedger           FileLedger
+	RAMLedger            RAMLedger
+	Kafka                Kafka
+	Debug                Debug
+	Consensus            interface{}
+	Operations           Operations
+	Metrics              Metrics
+	ChannelParticipation ChannelParticipation
+	Broadcast            Broadcast
 }
 
 // General contains config which should be common among all orderer types.
@@ -206,6 +208,31 @@ type Statsd struct {
 	Prefix        string
 }
 
+// ChannelParticipation provides the channel participation API configuration for the orderer.
+// Channel participation uses the same ListenAddress and TLS settings of the Operations service.
+type ChannelParticipation struct {
+	Enabled            bool
+	MaxRequestBodySize uint32
+}
+
+// Broadcast contains configuration for the Broadcast service.
+type Broadcast struct {
+	Admission Admission
+}
+
+// Admission contains configuration for the admission of transactions into the consenters,
+// which rate limits and fairly queues the transactions of a channel by the organization
+// of their creator.
+type Admission struct {
+	Enabled     bool
+	Rate        float64
+	Burst       int
+	MaxInFlight int
+	QueueSize   int
+	RetryAfter  time.Duration
+	Weights     map[string]uint32
+}
+
 // Defaults carries the default orderer configuration values.
 var Defaults = TopLevel{
 	General: General{
@@ -279,6 +306,19 @@ var Defaults = TopLevel{
 	Metrics: Metrics{
 		Provider: "disabled",
 	},
+	ChannelParticipation: ChannelParticipation{
+		Enabled:            false,
+		MaxRequestBodySize: 1024 * 1024,
+	},
+	Broadcast: Broadcast{
+		Admission: Admission{
+			Enabled:     false,
+			Burst:       100,
+			MaxInFlight: 100,
+			QueueSize:   1000,
+			RetryAfter:  time.Second,
+		},
+	},
 }
 
 // Load parses the orderer YAML file and environment, producing
@@ -349,6 +389,23 @@ func (c *TopLevel) completeInitialization(configDir string) {
 		case c.General.Cluster.SendBufferSize == 0:
 			c.General.Cluster.SendBufferSize = 10
 
+		case c.ChannelParticipation.MaxRequestBodySize == 0:
+			logger.Infof("ChannelParticipation.MaxRequestBodySize unset, setting to %v", Defaults.ChannelParticipation.MaxRequestBodySize)
+			c.ChannelParticipation.MaxRequestBodySize = Defaults.ChannelParticipation.MaxRequestBodySize
+		case c.Broadcast.Admission.Enabled && c.Broadcast.Admission.Rate > 0 && c.Broadcast.Admission.Burst == 0:
+			logger.Infof("Broadcast.Admission.Burst unset, setting to %v", Defaults.Broadcast.Admission.Burst)
+			c.Broadcast.Admission.Burst = Defaults.Broadcast.Admission.Burst
+		case c.Broadcast.Admission.Enabled && c.Broadcast.Admission.MaxInFlight > 0 && c.Broadcast.Admission.QueueSize == 0:
+			logger.Infof("Broadcast.Admission.QueueSize unset, setting to %v", Defaults.Broadcast.Admission.QueueSize)
+			c.Broadcast.Admission.QueueSize = Defaults.Broadcast.Admission.QueueSize
+		case c.Broadcast.Admission.Enabled && c.Broadcast.Admission.RetryAfter == 0:
+			logger.Infof("Broadcast.Admission.RetryAfter unset, setting to %v", Defaults.Broadcast.Admission.RetryAfter)
+			c.Broadcast.Admission.RetryAfter = Defaults.Broadcast.Admission.RetryAfter
+		case c.Broadcast.Admission.Rate < 0:
+			logger.Panicf("Broadcast.Admission.Rate must not be negative, but is %v", c.Broadcast.Admission.Rate)
+		case c.General.GenesisMethod == "none" && !c.ChannelParticipation.Enabled:
+			logger.Panic("General.GenesisMethod can be set to none only if ChannelParticipation.Enabled is set to true.")
+
 		case c.Kafka.TLS.Enabled && c.Kafka.TLS.Certificate == "":
 			logger.Panicf("General.Kafka.TLS.Certificate must be set if General.Kafka.TLS.Enabled is set to true.")
 		case c.Kafka.TLS.Enabled && c.Kafka.TLS.PrivateKey == "":
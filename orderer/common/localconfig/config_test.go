@@ -192,3 +192,34 @@ Consensus:
 	assert.Equal(t, foo.Foo, "bar")
 	assert.Equal(t, foo.Hello.World, 42)
 }
+
+func TestAdmissionConfig(t *testing.T) {
+	name, err := ioutil.TempDir("", "hyperledger_fabric")
+	assert.Nil(t, err, "Error creating temp dir: %s", err)
+	defer os.RemoveAll(name)
+
+	content := `---
+Broadcast:
+  Admission:
+    Enabled: true
+    Rate: 50
+    MaxInFlight: 10
+    Weights:
+      Org1MSP: 3
+`
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(name, "orderer.yaml"), []byte(content), 0600))
+	os.Setenv("FABRIC_CFG_PATH", name)
+	defer os.Unsetenv("FABRIC_CFG_PATH")
+
+	conf, err := Load()
+	assert.NoError(t, err)
+	admission := conf.Broadcast.Admission
+	assert.True(t, admission.Enabled)
+	assert.Equal(t, 50.0, admission.Rate)
+	assert.Equal(t, 10, admission.MaxInFlight)
+	assert.Equal(t, Defaults.Broadcast.Admission.Burst, admission.Burst)
+	assert.Equal(t, Defaults.Broadcast.Admission.QueueSize, admission.QueueSize)
+	assert.Equal(t, Defaults.Broadcast.Admission.RetryAfter, admission.RetryAfter)
+	assert.Equal(t, map[string]uint32{"Org1MSP": 3}, admission.Weights)
+}
@@ -173,6 +173,13 @@ func (bw *BlockWriter) commitBlock(encodedMetadataValue []byte) {
 }
 
 func (bw *BlockWriter) addBlockSignature(block *cb.Block) {
+	// Blocks that were already signed, either by a quorum of consenters or by the orderer
+	// the block was pulled from, keep their signatures, which peers verify against the
+	// block validation policy of the channel.
+	if existing, err := utils.GetMetadataFromBlock(block, cb.BlockMetadataIndex_SIGNATURES); err == nil && len(existing.Signatures) > 0 {
+		return
+	}
+
 	blockSignature := &cb.MetadataSignature{
 		SignatureHeader: utils.MarshalOrPanic(utils.NewSignatureHeaderOrPanic(bw.support)),
 	}
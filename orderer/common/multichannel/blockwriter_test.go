@@ -70,6 +70,30 @@ func TestBlockSignature(t *testing.T) {
 	assert.NotNil(t, md.Signatures, "Should have signature")
 }
 
+func TestBlockSignatureKeepsExistingSignatures(t *testing.T) {
+	bw := &BlockWriter{
+		support: &mockBlockWriterSupport{
+			LocalSigner: mockCrypto(),
+		},
+	}
+
+	signatures := []*cb.MetadataSignature{
+		{SignatureHeader: []byte("header-1"), Signature: []byte("signature-1")},
+		{SignatureHeader: []byte("header-2"), Signature: []byte("signature-2")},
+	}
+	block := cb.NewBlock(7, []byte("foo"))
+	block.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES] = utils.MarshalOrPanic(&cb.Metadata{
+		Signatures: signatures,
+	})
+	bw.addBlockSignature(block)
+
+	md := utils.GetMetadataFromBlockOrPanic(block, cb.BlockMetadataIndex_SIGNATURES)
+	assert.Nil(t, md.Value, "Value is empty in this case")
+	assert.Len(t, md.Signatures, 2, "Should keep the existing signatures")
+	assert.Equal(t, signatures[0].Signature, md.Signatures[0].Signature)
+	assert.Equal(t, signatures[1].Signature, md.Signatures[1].Signature)
+}
+
 func TestBlockLastConfig(t *testing.T) {
 	lastConfigSeq := uint64(6)
 	newConfigSeq := lastConfigSeq + 1
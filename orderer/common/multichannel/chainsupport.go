@@ -13,7 +13,9 @@ import (
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
+	"github.com/hyperledger/fabric/orderer/common/types"
 	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/orderer/consensus/migration"
 	cb "github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
@@ -136,6 +138,30 @@ func (cs *ChainSupport) ChainID() string {
 	return cs.ConfigtxValidator().ChainID()
 }
 
+// StatusReport returns the relation of the orderer to the consensus protocol of the channel,
+// and its status in it. Chains that do not report their status are considered active consenters.
+func (cs *ChainSupport) StatusReport() (types.ConsensusRelation, types.Status) {
+	if reporter, ok := cs.Chain.(consensus.StatusReporter); ok {
+		return reporter.StatusReport()
+	}
+	return types.ConsensusRelationConsenter, types.StatusActive
+}
+
+// MigrationStatus returns the consensus-type migration status of the chain, or nil if the
+// consensus type of the chain cannot be migrated.
+func (cs *ChainSupport) MigrationStatus() migration.Status {
+	if reporter, ok := cs.Chain.(consensus.MigrationStatusReporter); ok {
+		return reporter.MigrationStatus()
+	}
+	return nil
+}
+
+// IsSystemChannel returns true if this is the system channel, which defines the consortiums.
+func (cs *ChainSupport) IsSystemChannel() bool {
+	_, ok := cs.ConsortiumsConfig()
+	return ok
+}
+
 // ConfigProto passes through to the underlying configtx.Validator
 func (cs *ChainSupport) ConfigProto() *cb.Config {
 	return cs.ConfigtxValidator().ConfigProto()
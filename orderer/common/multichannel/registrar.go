@@ -11,6 +11,7 @@ package multichannel
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/hyperledger/fabric/common/channelconfig"
@@ -21,7 +22,9 @@ import (
 	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
+	"github.com/hyperledger/fabric/orderer/common/types"
 	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/orderer/consensus/migration"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 	"github.com/hyperledger/fabric/protos/utils"
@@ -35,6 +38,17 @@ const (
 
 var logger = flogging.MustGetLogger("orderer.commmon.multichannel")
 
+var (
+	// ErrSystemChannelExists is returned when channels are joined or removed while a system channel exists.
+	ErrSystemChannelExists = errors.New("system channel exists")
+	// ErrChannelAlreadyExists is returned when joining a channel the orderer is already a member of.
+	ErrChannelAlreadyExists = errors.New("channel already exists")
+	// ErrChannelNotExist is returned when the requested channel does not exist.
+	ErrChannelNotExist = errors.New("channel does not exist")
+	// ErrChannelOnboarding is returned when removing a channel that is still being onboarded.
+	ErrChannelOnboarding = errors.New("channel is being onboarded")
+)
+
 // checkResources makes sure that the channel config is compatible with this binary and logs sanity checks
 func checkResources(res channelconfig.Resources) error {
 	channelconfig.LogSanityChecks(res)
@@ -89,10 +103,22 @@ type ledgerResources struct {
 	blockledger.ReadWriter
 }
 
+// ChannelReplicator replicates the blocks of a channel that precede a join block.
+type ChannelReplicator interface {
+	// ReplicateChannel pulls the blocks of the channel of the given join block from the orderers
+	// listed in it, and appends them to the given ledger, up to and including the join block.
+	ReplicateChannel(joinBlock *cb.Block, ledger blockledger.ReadWriter) error
+}
+
 // Registrar serves as a point of access and control for the individual channel resources.
 type Registrar struct {
 	lock   sync.RWMutex
 	chains map[string]*ChainSupport
+	// onboarding holds the channels that were joined, and whose blocks are being replicated
+	onboarding map[string]struct{}
+
+	channelParticipation bool
+	replicator           ChannelReplicator
 
 	consenters         map[string]consensus.Consenter
 	ledgerFactory      blockledger.Factory
@@ -129,6 +155,7 @@ func NewRegistrar(ledgerFactory blockledger.Factory,
 	signer crypto.LocalSigner, metricsProvider metrics.Provider, callbacks ...channelconfig.BundleActor) *Registrar {
 	r := &Registrar{
 		chains:             make(map[string]*ChainSupport),
+		onboarding:         make(map[string]struct{}),
 		ledgerFactory:      ledgerFactory,
 		signer:             signer,
 		blockcutterMetrics: blockcutter.NewMetrics(metricsProvider),
@@ -138,6 +165,15 @@ func NewRegistrar(ledgerFactory blockledger.Factory,
 	return r
 }
 
+// EnableChannelParticipation allows the Registrar to be initialized without a system channel,
+// in which case channels are joined and removed through the Registrar. The given ChannelReplicator
+// onboards the channels that are joined with a config block which is not a genesis block.
+// It must be called before Initialize.
+func (r *Registrar) EnableChannelParticipation(replicator ChannelReplicator) {
+	r.channelParticipation = true
+	r.replicator = replicator
+}
+
 func (r *Registrar) Initialize(consenters map[string]consensus.Consenter) {
 	r.consenters = consenters
 	existingChains := r.ledgerFactory.ChainIDs()
@@ -198,8 +234,150 @@ func (r *Registrar) Initialize(consenters map[string]consensus.Consenter) {
 	}
 
 	if r.systemChannelID == "" {
-		logger.Panicf("No system chain found.  If bootstrapping, does your system channel contain a consortiums group definition?")
+		if !r.channelParticipation {
+			logger.Panicf("No system chain found.  If bootstrapping, does your system channel contain a consortiums group definition?")
+		}
+		logger.Infof("Starting without a system channel, with %d existing channels", len(r.chains))
+		return
+	}
+
+	r.restoreConsensusMigration()
+}
+
+// restoreConsensusMigration marks the standard channels as "START" when the orderer restarts while consensus-type
+// migration is pending on the system channel, since only the system channel records the start in its config.
+func (r *Registrar) restoreConsensusMigration() {
+	sysStatus := r.systemChannel.MigrationStatus()
+	if sysStatus == nil || !sysStatus.IsPending() {
+		return
+	}
+
+	_, sysContext := sysStatus.StateContext()
+	for chainID, status := range r.standardMigrationStatuses() {
+		if state, _ := status.StateContext(); state == ab.ConsensusType_MIG_STATE_NONE {
+			status.SetStateContext(ab.ConsensusType_MIG_STATE_START, sysContext)
+			logger.Infof("Consensus-type migration: restored start on channel %s; Status: %s", chainID, status)
+		}
+	}
+}
+
+// ConsensusMigrationPending checks whether consensus-type migration is started on the system channel.
+func (r *Registrar) ConsensusMigrationPending() bool {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	sysStatus := r.systemMigrationStatus()
+	return sysStatus != nil && sysStatus.IsPending()
+}
+
+// ConsensusMigrationStart marks the system channel and every standard channel as "START" with the given context,
+// unless consensus-type migration is pending on, or was not completely aborted on, one of the standard channels.
+func (r *Registrar) ConsensusMigrationStart(context uint64) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	sysStatus := r.systemMigrationStatus()
+	if sysStatus == nil {
+		return errors.New("cannot start consensus-type migration without a system channel that can be migrated")
+	}
+
+	standardStatuses := r.standardMigrationStatuses()
+	for chainID, status := range standardStatuses {
+		if state, _ := status.StateContext(); state != ab.ConsensusType_MIG_STATE_NONE {
+			return errors.Errorf("cannot start consensus-type migration, channel %s is not ready: %s", chainID, status)
+		}
+	}
+
+	sysStatus.SetStateContext(ab.ConsensusType_MIG_STATE_START, context)
+	for _, status := range standardStatuses {
+		status.SetStateContext(ab.ConsensusType_MIG_STATE_START, context)
+	}
+
+	logger.Infof("Consensus-type migration: started on %d standard channels; System channel status: %s", len(standardStatuses), sysStatus)
+	return nil
+}
+
+// ConsensusMigrationCommit marks the system channel as "COMMIT", once the system channel is at "START" and every
+// standard channel is at "CONTEXT" with the same context.
+func (r *Registrar) ConsensusMigrationCommit() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	sysStatus := r.systemMigrationStatus()
+	if sysStatus == nil {
+		return errors.New("cannot commit consensus-type migration without a system channel that can be migrated")
+	}
+	sysState, sysContext := sysStatus.StateContext()
+	if sysState != ab.ConsensusType_MIG_STATE_START || sysContext == 0 {
+		return errors.Errorf("cannot commit consensus-type migration, system channel is not started: %s", sysStatus)
+	}
+
+	for chainID, status := range r.standardMigrationStatuses() {
+		if state, context := status.StateContext(); state != ab.ConsensusType_MIG_STATE_CONTEXT || context != sysContext {
+			return errors.Errorf("cannot commit consensus-type migration, channel %s is not ready: %s", chainID, status)
+		}
+	}
+
+	sysStatus.SetStateContext(ab.ConsensusType_MIG_STATE_COMMIT, sysContext)
+	logger.Infof("Consensus-type migration: committed; System channel status: %s", sysStatus)
+	return nil
+}
+
+// ConsensusMigrationAbort marks the system channel and every standard channel as "ABORT", once the system channel
+// is at "START" and every standard channel is pending. This releases the channels from the pending state, so that
+// they order transactions again.
+func (r *Registrar) ConsensusMigrationAbort() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	sysStatus := r.systemMigrationStatus()
+	if sysStatus == nil {
+		return errors.New("cannot abort consensus-type migration without a system channel that can be migrated")
+	}
+	sysState, sysContext := sysStatus.StateContext()
+	if sysState != ab.ConsensusType_MIG_STATE_START {
+		return errors.Errorf("cannot abort consensus-type migration, system channel is not started: %s", sysStatus)
+	}
+
+	standardStatuses := r.standardMigrationStatuses()
+	for chainID, status := range standardStatuses {
+		if !status.IsPending() {
+			return errors.Errorf("cannot abort consensus-type migration, channel %s is not pending: %s", chainID, status)
+		}
+	}
+
+	sysStatus.SetStateContext(ab.ConsensusType_MIG_STATE_ABORT, sysContext)
+	for _, status := range standardStatuses {
+		_, context := status.StateContext()
+		status.SetStateContext(ab.ConsensusType_MIG_STATE_ABORT, context)
 	}
+
+	logger.Infof("Consensus-type migration: aborted on %d standard channels; System channel status: %s", len(standardStatuses), sysStatus)
+	return nil
+}
+
+// systemMigrationStatus returns the consensus-type migration status of the system channel, or nil if there is no
+// system channel or its consensus type cannot be migrated. It must be called with the lock taken.
+func (r *Registrar) systemMigrationStatus() migration.Status {
+	if r.systemChannel == nil {
+		return nil
+	}
+	return r.systemChannel.MigrationStatus()
+}
+
+// standardMigrationStatuses returns the consensus-type migration statuses of the standard channels whose consensus
+// type can be migrated. It must be called with the lock taken.
+func (r *Registrar) standardMigrationStatuses() map[string]migration.Status {
+	statuses := make(map[string]migration.Status)
+	for chainID, cs := range r.chains {
+		if chainID == r.systemChannelID {
+			continue
+		}
+		if status := cs.MigrationStatus(); status != nil {
+			statuses[chainID] = status
+		}
+	}
+	return statuses
 }
 
 // SystemChannelID returns the ChannelID for the system channel.
@@ -218,6 +396,9 @@ func (r *Registrar) BroadcastChannelSupport(msg *cb.Envelope) (*cb.ChannelHeader
 
 	cs := r.GetChain(chdr.ChannelId)
 	if cs == nil {
+		if r.systemChannel == nil {
+			return nil, false, nil, errors.Errorf("channel %s does not exist", chdr.ChannelId)
+		}
 		cs = r.systemChannel
 	}
 
@@ -292,6 +473,9 @@ func (r *Registrar) CreateChain(chainName string) {
 		logger.Infof("A chain of type %T for channel %s already exists. "+
 			"Halting it.", chain.Chain, chainName)
 		chain.Halt()
+		// Wait for the block that is being committed, if any
+		chain.BlockWriter.committingBlock.Lock()
+		chain.BlockWriter.committingBlock.Unlock()
 	}
 	r.newChain(configTx(lf))
 }
@@ -321,6 +505,7 @@ func (r *Registrar) newChain(configtx *cb.Envelope) {
 	cs.start()
 
 	r.chains = newChains
+	delete(r.onboarding, chainID)
 }
 
 // ChannelsCount returns the count of the current total number of channels.
@@ -340,3 +525,233 @@ func (r *Registrar) NewChannelConfig(envConfigUpdate *cb.Envelope) (channelconfi
 func (r *Registrar) CreateBundle(channelID string, config *cb.Config) (channelconfig.Resources, error) {
 	return channelconfig.NewBundle(channelID, config)
 }
+
+// ChannelList returns the names of the system channel, if it exists, and of the other channels,
+// including those that are being onboarded.
+func (r *Registrar) ChannelList() types.ChannelList {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	list := types.ChannelList{}
+	if r.systemChannelID != "" {
+		list.SystemChannel = &types.ChannelInfoShort{Name: r.systemChannelID}
+	}
+	for name := range r.chains {
+		if name == r.systemChannelID {
+			continue
+		}
+		list.Channels = append(list.Channels, types.ChannelInfoShort{Name: name})
+	}
+	for name := range r.onboarding {
+		list.Channels = append(list.Channels, types.ChannelInfoShort{Name: name})
+	}
+	sort.Slice(list.Channels, func(i, j int) bool {
+		return list.Channels[i].Name < list.Channels[j].Name
+	})
+
+	return list
+}
+
+// ChannelInfo returns the height of the given channel, and the relation and status
+// of the orderer in it.
+func (r *Registrar) ChannelInfo(channelID string) (types.ChannelInfo, error) {
+	r.lock.RLock()
+	cs, exists := r.chains[channelID]
+	_, onboarding := r.onboarding[channelID]
+	r.lock.RUnlock()
+
+	info := types.ChannelInfo{Name: channelID}
+	switch {
+	case exists:
+		info.ConsensusRelation, info.Status = cs.StatusReport()
+		info.Height = cs.Height()
+	case onboarding:
+		ledger, err := r.ledgerFactory.GetOrCreate(channelID)
+		if err != nil {
+			return types.ChannelInfo{}, errors.Wrapf(err, "failed obtaining the ledger of channel %s", channelID)
+		}
+		info.Status = types.StatusOnBoarding
+		info.Height = ledger.Height()
+	default:
+		return types.ChannelInfo{}, ErrChannelNotExist
+	}
+
+	return info, nil
+}
+
+// JoinChannel makes the orderer join the given channel, using the given config block of the channel.
+// A genesis block starts the channel right away. Any other config block makes the orderer onboard the
+// channel first, by replicating the blocks that precede the config block from the orderers of the channel.
+// Channels can be joined only when the orderer has no system channel.
+func (r *Registrar) JoinChannel(channelID string, configBlock *cb.Block) (types.ChannelInfo, error) {
+	r.lock.Lock()
+	if r.systemChannelID != "" {
+		r.lock.Unlock()
+		return types.ChannelInfo{}, ErrSystemChannelExists
+	}
+	_, exists := r.chains[channelID]
+	_, onboarding := r.onboarding[channelID]
+	if exists || onboarding {
+		r.lock.Unlock()
+		return types.ChannelInfo{}, ErrChannelAlreadyExists
+	}
+	// Reserve the channel name while the channel is being joined
+	r.onboarding[channelID] = struct{}{}
+	r.lock.Unlock()
+
+	ledger, err := r.joinLedger(channelID, configBlock)
+	if err != nil {
+		r.lock.Lock()
+		delete(r.onboarding, channelID)
+		r.lock.Unlock()
+		return types.ChannelInfo{}, err
+	}
+
+	if configBlock.Header.Number == 0 {
+		logger.Infof("Joining channel %s with its genesis block", channelID)
+		r.newChain(configTx(ledger))
+		return r.ChannelInfo(channelID)
+	}
+
+	logger.Infof("Joining channel %s with config block %d, onboarding it", channelID, configBlock.Header.Number)
+	go r.onboard(channelID, configBlock, ledger)
+
+	return types.ChannelInfo{
+		Name:   channelID,
+		Status: types.StatusOnBoarding,
+		Height: ledger.Height(),
+	}, nil
+}
+
+// joinLedger validates the given join block, and creates the ledger of the given channel.
+// When the join block is a genesis block, it is appended to the ledger.
+func (r *Registrar) joinLedger(channelID string, configBlock *cb.Block) (blockledger.ReadWriter, error) {
+	if err := r.validateJoinBlock(channelID, configBlock); err != nil {
+		return nil, err
+	}
+
+	if configBlock.Header.Number > 0 && r.replicator == nil {
+		return nil, errors.Errorf("cannot onboard channel %s from config block %d", channelID, configBlock.Header.Number)
+	}
+
+	ledger, err := r.ledgerFactory.GetOrCreate(channelID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed creating the ledger of channel %s", channelID)
+	}
+	if ledger.Height() > 0 {
+		return nil, errors.Errorf("ledger of channel %s already has %d blocks", channelID, ledger.Height())
+	}
+
+	if configBlock.Header.Number == 0 {
+		if err := ledger.Append(configBlock); err != nil {
+			r.removeLedger(channelID)
+			return nil, errors.Wrapf(err, "failed appending the genesis block of channel %s", channelID)
+		}
+	}
+
+	return ledger, nil
+}
+
+// validateJoinBlock checks that the given block is a config block of the given
+// application channel, with a configuration this orderer supports.
+func (r *Registrar) validateJoinBlock(channelID string, configBlock *cb.Block) error {
+	if configBlock == nil || configBlock.Header == nil || configBlock.Data == nil {
+		return errors.New("invalid join block: block is empty")
+	}
+	if !utils.IsConfigBlock(configBlock) {
+		return errors.New("invalid join block: block is not a config block")
+	}
+
+	env, err := utils.ExtractEnvelope(configBlock, 0)
+	if err != nil {
+		return errors.Wrap(err, "invalid join block")
+	}
+	bundle, err := channelconfig.NewBundleFromEnvelope(env)
+	if err != nil {
+		return errors.Wrap(err, "invalid join block")
+	}
+	if bundle.ConfigtxValidator().ChainID() != channelID {
+		return errors.Errorf("invalid join block: block is of channel %s, not %s",
+			bundle.ConfigtxValidator().ChainID(), channelID)
+	}
+	if _, isSystemChannel := bundle.ConsortiumsConfig(); isSystemChannel {
+		return errors.Errorf("invalid join block: channel %s is a system channel", channelID)
+	}
+	if err := checkResources(bundle); err != nil {
+		return errors.Wrap(err, "invalid join block")
+	}
+
+	oc, _ := bundle.OrdererConfig()
+	if _, exists := r.consenters[oc.ConsensusType()]; !exists {
+		return errors.Errorf("invalid join block: unknown consensus type %s", oc.ConsensusType())
+	}
+
+	return nil
+}
+
+// onboard replicates the blocks of the given channel up to the given join block,
+// and starts the channel. If the replication fails, the ledger is removed.
+func (r *Registrar) onboard(channelID string, joinBlock *cb.Block, ledger blockledger.ReadWriter) {
+	if err := r.replicator.ReplicateChannel(joinBlock, ledger); err != nil {
+		logger.Errorf("Failed onboarding channel %s: %s", channelID, err)
+		r.removeLedger(channelID)
+		r.lock.Lock()
+		delete(r.onboarding, channelID)
+		r.lock.Unlock()
+		return
+	}
+
+	logger.Infof("Onboarded channel %s up to block %d", channelID, joinBlock.Header.Number)
+	r.newChain(configTx(ledger))
+}
+
+// RemoveChannel halts the given channel, and removes its ledger.
+// Channels can be removed only when the orderer has no system channel.
+func (r *Registrar) RemoveChannel(channelID string) error {
+	r.lock.Lock()
+	if r.systemChannelID != "" {
+		r.lock.Unlock()
+		return ErrSystemChannelExists
+	}
+	if _, onboarding := r.onboarding[channelID]; onboarding {
+		r.lock.Unlock()
+		return ErrChannelOnboarding
+	}
+	cs, exists := r.chains[channelID]
+	if !exists {
+		r.lock.Unlock()
+		return ErrChannelNotExist
+	}
+
+	// Copy the map to allow concurrent reads from broadcast/deliver while the chain is removed
+	newChains := make(map[string]*ChainSupport)
+	for key, value := range r.chains {
+		if key != channelID {
+			newChains[key] = value
+		}
+	}
+	r.chains = newChains
+	r.lock.Unlock()
+
+	logger.Infof("Removing channel %s", channelID)
+	cs.Halt()
+	// Wait for the block that is being committed, if any
+	cs.BlockWriter.committingBlock.Lock()
+	defer cs.BlockWriter.committingBlock.Unlock()
+
+	if remover, ok := cs.Chain.(consensus.StorageRemover); ok {
+		if err := remover.RemoveStorage(); err != nil {
+			return errors.Wrapf(err, "failed removing the consensus storage of channel %s", channelID)
+		}
+	}
+
+	return r.removeLedger(channelID)
+}
+
+func (r *Registrar) removeLedger(channelID string) error {
+	if err := r.ledgerFactory.Remove(channelID); err != nil {
+		logger.Errorf("Failed removing the ledger of channel %s: %s", channelID, err)
+		return errors.Wrapf(err, "failed removing the ledger of channel %s", channelID)
+	}
+	return nil
+}
@@ -8,6 +8,7 @@ package multichannel
 
 import (
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/crypto"
@@ -24,7 +25,9 @@ import (
 	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/orderer/common/blockcutter"
+	"github.com/hyperledger/fabric/orderer/common/types"
 	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/orderer/consensus/migration"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 	"github.com/hyperledger/fabric/protos/utils"
@@ -376,3 +379,274 @@ func TestBroadcastChannelSupportRejection(t *testing.T) {
 	_, _, _, err := registrar.BroadcastChannelSupport(configTx)
 	assert.Error(t, err, "Messages of type HeaderType_CONFIG should return an error.")
 }
+
+// Aborting consensus-type migration on the system channel releases the standard channels, which then revert
+// the migration context with a config update back to the original consensus type.
+func TestConsensusMigrationAbort(t *testing.T) {
+	lf, _ := NewRAMLedgerAndFactory(10)
+	consenters := map[string]consensus.Consenter{conf.Orderer.OrdererType: &mockConsenter{}}
+
+	registrar := NewRegistrar(lf, mockCrypto(), &disabled.Provider{})
+	registrar.Initialize(consenters)
+
+	appConf := configtxgentest.Load(genesisconfig.SampleInsecureSoloProfile)
+	appConf.Consortiums = nil
+	ledger, err := lf.GetOrCreate("mychannel")
+	assert.NoError(t, err)
+	ledger.Append(encoder.New(appConf).GenesisBlockForChannel("mychannel"))
+	registrar.CreateChain("mychannel")
+
+	sysStepper := registrar.GetChain(registrar.SystemChannelID()).Chain.(*mockChain).migrationStatusStepper
+	stdStepper := registrar.GetChain("mychannel").Chain.(*mockChain).migrationStatusStepper
+
+	assertStateContext := func(status migration.Status, expectedState ab.ConsensusType_MigrationState, expectedContext uint64) {
+		state, context := status.StateContext()
+		assert.Equal(t, expectedState, state)
+		assert.Equal(t, expectedContext, context)
+	}
+
+	assert.EqualError(t, registrar.ConsensusMigrationAbort(), "cannot abort consensus-type migration, system channel is not started: "+
+		"State=MIG_STATE_NONE, Context=0, Sys=true, Type=solo")
+
+	// START on the system channel, at block 5
+	commitBlock, commitMigration := sysStepper.Step(registrar.SystemChannelID(), "solo", ab.ConsensusType_MIG_STATE_START, 0, 4, registrar)
+	assert.True(t, commitBlock)
+	assert.False(t, commitMigration)
+	assert.True(t, registrar.ConsensusMigrationPending())
+	assertStateContext(sysStepper, ab.ConsensusType_MIG_STATE_START, 5)
+	assertStateContext(stdStepper, ab.ConsensusType_MIG_STATE_START, 5)
+
+	// CONTEXT on the standard channel
+	commitBlock, _ = stdStepper.Step("mychannel", "etcdraft", ab.ConsensusType_MIG_STATE_CONTEXT, 5, 0, registrar)
+	assert.True(t, commitBlock)
+	assertStateContext(stdStepper, ab.ConsensusType_MIG_STATE_CONTEXT, 5)
+
+	// ABORT on the system channel releases the standard channel
+	commitBlock, commitMigration = sysStepper.Step(registrar.SystemChannelID(), "solo", ab.ConsensusType_MIG_STATE_ABORT, 5, 5, registrar)
+	assert.True(t, commitBlock)
+	assert.False(t, commitMigration)
+	assert.False(t, registrar.ConsensusMigrationPending())
+	assertStateContext(sysStepper, ab.ConsensusType_MIG_STATE_ABORT, 5)
+	assertStateContext(stdStepper, ab.ConsensusType_MIG_STATE_ABORT, 5)
+	assert.False(t, stdStepper.IsPending())
+	assert.False(t, stdStepper.IsCommitted())
+
+	// Migration cannot restart until the standard channel reverts its context
+	commitBlock, _ = sysStepper.Step(registrar.SystemChannelID(), "solo", ab.ConsensusType_MIG_STATE_START, 0, 6, registrar)
+	assert.False(t, commitBlock)
+	assertStateContext(sysStepper, ab.ConsensusType_MIG_STATE_ABORT, 5)
+
+	// NONE on the standard channel, with a type other than the original one, is rejected
+	commitBlock, _ = stdStepper.Step("mychannel", "etcdraft", ab.ConsensusType_MIG_STATE_NONE, 0, 0, registrar)
+	assert.False(t, commitBlock)
+	assertStateContext(stdStepper, ab.ConsensusType_MIG_STATE_ABORT, 5)
+
+	// NONE on the standard channel, with the original type, completes the abort
+	commitBlock, _ = stdStepper.Step("mychannel", "solo", ab.ConsensusType_MIG_STATE_NONE, 0, 0, registrar)
+	assert.True(t, commitBlock)
+	assertStateContext(stdStepper, ab.ConsensusType_MIG_STATE_NONE, 0)
+
+	// NONE on the system channel completes the abort
+	commitBlock, _ = sysStepper.Step(registrar.SystemChannelID(), "solo", ab.ConsensusType_MIG_STATE_NONE, 0, 6, registrar)
+	assert.True(t, commitBlock)
+	assertStateContext(sysStepper, ab.ConsensusType_MIG_STATE_NONE, 0)
+
+	// Migration can start again
+	commitBlock, _ = sysStepper.Step(registrar.SystemChannelID(), "solo", ab.ConsensusType_MIG_STATE_START, 0, 7, registrar)
+	assert.True(t, commitBlock)
+	assertStateContext(sysStepper, ab.ConsensusType_MIG_STATE_START, 8)
+	assertStateContext(stdStepper, ab.ConsensusType_MIG_STATE_START, 8)
+}
+
+type replicatorFunc func(joinBlock *cb.Block, ledger blockledger.ReadWriter) error
+
+func (rf replicatorFunc) ReplicateChannel(joinBlock *cb.Block, ledger blockledger.ReadWriter) error {
+	return rf(joinBlock, ledger)
+}
+
+// appChannelBlocks returns the blocks of an application channel: a genesis block,
+// a block with normal transactions and a config block.
+func appChannelBlocks(t *testing.T, channelID string) []*cb.Block {
+	appConf := configtxgentest.Load(genesisconfig.SampleInsecureSoloProfile)
+	appConf.Consortiums = nil
+	genesis := encoder.New(appConf).GenesisBlockForChannel(channelID)
+
+	ledger, err := ramledger.New(10).GetOrCreate(channelID)
+	assert.NoError(t, err)
+	assert.NoError(t, ledger.Append(genesis))
+	assert.NoError(t, ledger.Append(blockledger.CreateNextBlock(ledger, []*cb.Envelope{makeNormalTx(channelID, 1)})))
+	configBlock := blockledger.CreateNextBlock(ledger, []*cb.Envelope{utils.ExtractEnvelopeOrPanic(genesis, 0)})
+	configBlock.Metadata.Metadata[cb.BlockMetadataIndex_LAST_CONFIG] = utils.MarshalOrPanic(&cb.Metadata{Value: utils.MarshalOrPanic(&cb.LastConfig{Index: 2})})
+	assert.NoError(t, ledger.Append(configBlock))
+
+	return []*cb.Block{genesis, blockledger.GetBlock(ledger, 1), configBlock}
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	deadline := time.Now().Add(time.Minute)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("Condition was not met in time")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestNoSystemChannelWithChannelParticipation(t *testing.T) {
+	lf := ramledger.New(10)
+	consenters := map[string]consensus.Consenter{conf.Orderer.OrdererType: &mockConsenter{}}
+
+	registrar := NewRegistrar(lf, mockCrypto(), &disabled.Provider{})
+	registrar.EnableChannelParticipation(nil)
+	assert.NotPanics(t, func() { registrar.Initialize(consenters) })
+
+	assert.Empty(t, registrar.SystemChannelID())
+	assert.Equal(t, types.ChannelList{}, registrar.ChannelList())
+	_, _, _, err := registrar.BroadcastChannelSupport(makeNormalTx("mychannel", 1))
+	assert.EqualError(t, err, "channel mychannel does not exist")
+}
+
+func TestJoinAndRemoveChannel(t *testing.T) {
+	blocks := appChannelBlocks(t, "mychannel")
+	lf := ramledger.New(10)
+	consenters := map[string]consensus.Consenter{conf.Orderer.OrdererType: &mockConsenter{}}
+
+	registrar := NewRegistrar(lf, mockCrypto(), &disabled.Provider{})
+	registrar.EnableChannelParticipation(nil)
+	registrar.Initialize(consenters)
+
+	t.Run("InvalidBlock", func(t *testing.T) {
+		_, err := registrar.JoinChannel("mychannel", &cb.Block{})
+		assert.EqualError(t, err, "invalid join block: block is empty")
+		_, err = registrar.JoinChannel("mychannel", blocks[1])
+		assert.EqualError(t, err, "invalid join block: block is not a config block")
+		_, err = registrar.JoinChannel("otherchannel", blocks[0])
+		assert.EqualError(t, err, "invalid join block: block is of channel mychannel, not otherchannel")
+		_, err = registrar.JoinChannel(genesisconfig.TestChainID, genesisBlock)
+		assert.EqualError(t, err, "invalid join block: channel testchainid is a system channel")
+		assert.Equal(t, types.ChannelList{}, registrar.ChannelList())
+		assert.Empty(t, lf.ChainIDs())
+	})
+
+	t.Run("NoReplicator", func(t *testing.T) {
+		_, err := registrar.JoinChannel("mychannel", blocks[2])
+		assert.EqualError(t, err, "cannot onboard channel mychannel from config block 2")
+		assert.Empty(t, lf.ChainIDs())
+	})
+
+	t.Run("GenesisBlock", func(t *testing.T) {
+		info, err := registrar.JoinChannel("mychannel", blocks[0])
+		assert.NoError(t, err)
+		assert.Equal(t, types.ChannelInfo{
+			Name:              "mychannel",
+			ConsensusRelation: types.ConsensusRelationConsenter,
+			Status:            types.StatusActive,
+			Height:            1,
+		}, info)
+		assert.NotNil(t, registrar.GetChain("mychannel"))
+		assert.Equal(t, types.ChannelList{
+			Channels: []types.ChannelInfoShort{{Name: "mychannel"}},
+		}, registrar.ChannelList())
+
+		_, err = registrar.JoinChannel("mychannel", blocks[0])
+		assert.Equal(t, ErrChannelAlreadyExists, err)
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		chain := registrar.GetChain("mychannel")
+		assert.NoError(t, registrar.RemoveChannel("mychannel"))
+		assert.Nil(t, registrar.GetChain("mychannel"))
+		assert.Empty(t, lf.ChainIDs())
+		// The removed chain is halted
+		<-chain.Chain.(*mockChain).done
+
+		_, err := registrar.ChannelInfo("mychannel")
+		assert.Equal(t, ErrChannelNotExist, err)
+		assert.Equal(t, ErrChannelNotExist, registrar.RemoveChannel("mychannel"))
+	})
+}
+
+func TestJoinChannelOnboarding(t *testing.T) {
+	blocks := appChannelBlocks(t, "mychannel")
+	consenters := map[string]consensus.Consenter{conf.Orderer.OrdererType: &mockConsenter{}}
+
+	t.Run("Success", func(t *testing.T) {
+		lf := ramledger.New(10)
+		release := make(chan struct{})
+		registrar := NewRegistrar(lf, mockCrypto(), &disabled.Provider{})
+		registrar.EnableChannelParticipation(replicatorFunc(func(joinBlock *cb.Block, ledger blockledger.ReadWriter) error {
+			assert.Equal(t, blocks[2], joinBlock)
+			<-release
+			for _, block := range blocks {
+				if err := ledger.Append(block); err != nil {
+					return err
+				}
+			}
+			return nil
+		}))
+		registrar.Initialize(consenters)
+
+		info, err := registrar.JoinChannel("mychannel", blocks[2])
+		assert.NoError(t, err)
+		assert.Equal(t, types.ChannelInfo{Name: "mychannel", Status: types.StatusOnBoarding}, info)
+		info, err = registrar.ChannelInfo("mychannel")
+		assert.NoError(t, err)
+		assert.Equal(t, types.StatusOnBoarding, info.Status)
+		assert.Equal(t, types.ChannelList{
+			Channels: []types.ChannelInfoShort{{Name: "mychannel"}},
+		}, registrar.ChannelList())
+		_, err = registrar.JoinChannel("mychannel", blocks[2])
+		assert.Equal(t, ErrChannelAlreadyExists, err)
+		assert.Equal(t, ErrChannelOnboarding, registrar.RemoveChannel("mychannel"))
+
+		close(release)
+		waitFor(t, func() bool { return registrar.GetChain("mychannel") != nil })
+		info, err = registrar.ChannelInfo("mychannel")
+		assert.NoError(t, err)
+		assert.Equal(t, types.ChannelInfo{
+			Name:              "mychannel",
+			ConsensusRelation: types.ConsensusRelationConsenter,
+			Status:            types.StatusActive,
+			Height:            3,
+		}, info)
+		assert.Equal(t, types.ChannelList{
+			Channels: []types.ChannelInfoShort{{Name: "mychannel"}},
+		}, registrar.ChannelList())
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		lf := ramledger.New(10)
+		registrar := NewRegistrar(lf, mockCrypto(), &disabled.Provider{})
+		registrar.EnableChannelParticipation(replicatorFunc(func(joinBlock *cb.Block, ledger blockledger.ReadWriter) error {
+			return errors.New("no orderer is reachable")
+		}))
+		registrar.Initialize(consenters)
+
+		_, err := registrar.JoinChannel("mychannel", blocks[2])
+		assert.NoError(t, err)
+		waitFor(t, func() bool { return len(registrar.ChannelList().Channels) == 0 })
+		assert.Empty(t, lf.ChainIDs())
+		assert.Nil(t, registrar.GetChain("mychannel"))
+	})
+}
+
+func TestJoinAndRemoveChannelWithSystemChannel(t *testing.T) {
+	blocks := appChannelBlocks(t, "mychannel")
+	lf, _ := NewRAMLedgerAndFactory(10)
+	consenters := map[string]consensus.Consenter{conf.Orderer.OrdererType: &mockConsenter{}}
+
+	registrar := NewRegistrar(lf, mockCrypto(), &disabled.Provider{})
+	registrar.EnableChannelParticipation(nil)
+	registrar.Initialize(consenters)
+
+	assert.Equal(t, types.ChannelList{
+		SystemChannel: &types.ChannelInfoShort{Name: genesisconfig.TestChainID},
+	}, registrar.ChannelList())
+	info, err := registrar.ChannelInfo(genesisconfig.TestChainID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), info.Height)
+
+	_, err = registrar.JoinChannel("mychannel", blocks[0])
+	assert.Equal(t, ErrSystemChannelExists, err)
+	assert.Equal(t, ErrSystemChannelExists, registrar.RemoveChannel(genesisconfig.TestChainID))
+}
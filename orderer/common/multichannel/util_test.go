@@ -15,6 +15,7 @@ import (
 	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
 	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/orderer/consensus/migration"
 	cb "github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/utils"
 )
@@ -29,6 +30,8 @@ func (mc *mockConsenter) HandleChain(support consensus.ConsenterSupport, metadat
 		support:  support,
 		metadata: metadata,
 		done:     make(chan struct{}),
+
+		migrationStatusStepper: migration.NewStatusStepper(support.IsSystemChannel(), support.ChainID(), "solo"),
 	}, nil
 }
 
@@ -38,6 +41,12 @@ type mockChain struct {
 	support  consensus.ConsenterSupport
 	metadata *cb.Metadata
 	done     chan struct{}
+
+	migrationStatusStepper migration.StatusStepper
+}
+
+func (mch *mockChain) MigrationStatus() migration.Status {
+	return mch.migrationStatusStepper
 }
 
 func (mch *mockChain) Errored() <-chan struct{} {
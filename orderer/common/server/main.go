@@ -40,11 +40,14 @@ import (
 	"github.com/hyperledger/fabric/msp"
 	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
 	"github.com/hyperledger/fabric/orderer/common/bootstrap/file"
+	"github.com/hyperledger/fabric/orderer/common/broadcast"
+	"github.com/hyperledger/fabric/orderer/common/channelparticipation"
 	"github.com/hyperledger/fabric/orderer/common/cluster"
 	"github.com/hyperledger/fabric/orderer/common/localconfig"
 	"github.com/hyperledger/fabric/orderer/common/metadata"
 	"github.com/hyperledger/fabric/orderer/common/multichannel"
 	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/orderer/consensus/bft"
 	"github.com/hyperledger/fabric/orderer/consensus/etcdraft"
 	"github.com/hyperledger/fabric/orderer/consensus/kafka"
 	"github.com/hyperledger/fabric/orderer/consensus/solo"
@@ -66,7 +69,7 @@ var (
 	_       = app.Command("benchmark", "Run orderer in benchmark mode")
 	version = app.Command("version", "Show version information")
 
-	clusterTypes = map[string]struct{}{"etcdraft": {}}
+	clusterTypes = map[string]struct{}{"etcdraft": {}, "bft": {}}
 )
 
 // Main is the entry point of orderer process
@@ -94,11 +97,15 @@ func Main() {
 // Start provides a layer of abstraction for benchmark test
 func Start(cmd string, conf *localconfig.TopLevel) {
 	bootstrapBlock := extractBootstrapBlock(conf)
-	if err := ValidateBootstrapBlock(bootstrapBlock); err != nil {
-		logger.Panicf("Failed validating bootstrap block: %v", err)
+	// Orderers without a system channel join the channels of a cluster
+	// through the channel participation API.
+	clusterType := true
+	if bootstrapBlock != nil {
+		if err := ValidateBootstrapBlock(bootstrapBlock); err != nil {
+			logger.Panicf("Failed validating bootstrap block: %v", err)
+		}
+		clusterType = isClusterType(bootstrapBlock)
 	}
-
-	clusterType := isClusterType(bootstrapBlock)
 	signer := localmsp.NewSigner()
 
 	lf, _ := createLedgerFactory(conf)
@@ -109,7 +116,7 @@ func Start(cmd string, conf *localconfig.TopLevel) {
 
 	r := createReplicator(lf, bootstrapBlock, conf, clusterClientConfig.SecOpts, signer)
 	// Only clusters that are equipped with a recent config block can replicate.
-	if clusterType && conf.General.GenesisMethod == "file" {
+	if bootstrapBlock != nil && clusterType && conf.General.GenesisMethod == "file" {
 		r.replicateIfNeeded(bootstrapBlock)
 	}
 
@@ -156,8 +163,14 @@ func Start(cmd string, conf *localconfig.TopLevel) {
 	}
 
 	manager := initializeMultichannelRegistrar(bootstrapBlock, r, clusterDialer, clusterServerConfig, clusterGRPCServer, conf, signer, metricsProvider, opsSystem, lf, tlsCallback)
+	if clusterType {
+		opsSystem.RegisterHandler(etcdraft.LeadershipPath, etcdraft.NewLeadershipHandler(manager))
+	}
+	if conf.ChannelParticipation.Enabled {
+		opsSystem.RegisterHandler(channelparticipation.URLBaseV1, channelparticipation.NewHTTPHandler(conf.ChannelParticipation, manager))
+	}
 	mutualTLS := serverConfig.SecOpts.UseTLS && serverConfig.SecOpts.RequireClientCert
-	server := NewServer(manager, metricsProvider, &conf.Debug, conf.General.Authentication.TimeWindow, mutualTLS)
+	server := NewServer(manager, metricsProvider, &conf.Debug, conf.General.Authentication.TimeWindow, mutualTLS, newAdmission(conf.Broadcast.Admission))
 
 	logger.Infof("Starting %s", metadata.GetVersionInfo())
 	go handleSignals(addPlatformSignals(map[os.Signal]func(){
@@ -198,15 +211,16 @@ func createReplicator(
 		logger:        logger,
 	}
 
-	systemChannelName, err := utils.GetChainIDFromBlock(bootstrapBlock)
-	if err != nil {
-		logger.Panicf("Failed extracting system channel name from bootstrap block: %v", err)
-	}
-
-	// System channel is not verified because we trust the bootstrap block
-	// and use backward hash chain verification.
 	verifiersByChannel := vl.loadVerifiers()
-	verifiersByChannel[systemChannelName] = &cluster.NoopBlockVerifier{}
+	if bootstrapBlock != nil {
+		systemChannelName, err := utils.GetChainIDFromBlock(bootstrapBlock)
+		if err != nil {
+			logger.Panicf("Failed extracting system channel name from bootstrap block: %v", err)
+		}
+		// System channel is not verified because we trust the bootstrap block
+		// and use backward hash chain verification.
+		verifiersByChannel[systemChannelName] = &cluster.NoopBlockVerifier{}
+	}
 
 	vr := &cluster.VerificationRegistry{
 		Logger:             logger,
@@ -474,6 +488,8 @@ func extractBootstrapBlock(conf *localconfig.TopLevel) *cb.Block {
 		bootstrapBlock = encoder.New(genesisconfig.Load(conf.General.GenesisProfile)).GenesisBlockForChannel(conf.General.SystemChannel)
 	case "file":
 		bootstrapBlock = file.New(conf.General.GenesisFile).GenesisBlock()
+	case "none":
+		logger.Info("Starting without a system channel")
 	default:
 		logger.Panic("Unknown genesis method:", conf.General.GenesisMethod)
 	}
@@ -561,7 +577,9 @@ func initializeMultichannelRegistrar(
 ) *multichannel.Registrar {
 	genesisBlock := extractBootstrapBlock(conf)
 	// Are we bootstrapping?
-	if len(lf.ChainIDs()) == 0 {
+	if genesisBlock == nil {
+		logger.Info("Not bootstrapping because there is no system channel")
+	} else if len(lf.ChainIDs()) == 0 {
 		initializeBootstrapChannel(genesisBlock, lf)
 	} else {
 		logger.Info("Not bootstrapping because of existing chains")
@@ -571,14 +589,17 @@ func initializeMultichannelRegistrar(
 
 	registrar := multichannel.NewRegistrar(lf, signer, metricsProvider, callbacks...)
 
-	consenters["solo"] = solo.New()
+	consenters["solo"] = solo.New(registrar)
 	var kafkaMetrics *kafka.Metrics
-	consenters["kafka"], kafkaMetrics = kafka.New(conf.Kafka, metricsProvider, healthChecker)
+	consenters["kafka"], kafkaMetrics = kafka.New(conf.Kafka, metricsProvider, healthChecker, registrar)
 	// Note, we pass a 'nil' channel here, we could pass a channel that
 	// closes if we wished to cleanup this routine on exit.
 	go kafkaMetrics.PollGoMetricsUntilStop(time.Minute, nil)
-	if isClusterType(bootstrapBlock) {
-		initializeEtcdraftConsenter(consenters, conf, lf, clusterDialer, bootstrapBlock, ri, srvConf, srv, registrar)
+	if bootstrapBlock == nil || isClusterType(bootstrapBlock) {
+		initializeEtcdraftConsenter(consenters, conf, lf, clusterDialer, bootstrapBlock, ri, srvConf, srv, registrar, metricsProvider)
+	}
+	if conf.ChannelParticipation.Enabled {
+		registrar.EnableChannelParticipation(ri)
 	}
 	registrar.Initialize(consenters)
 	return registrar
@@ -594,7 +615,17 @@ func initializeEtcdraftConsenter(
 	srvConf comm.ServerConfig,
 	srv *comm.GRPCServer,
 	registrar *multichannel.Registrar,
+	metricsProvider metrics.Provider,
 ) {
+	if bootstrapBlock == nil {
+		// Without a system channel, there are no inactive chains to track,
+		// and the chains this orderer is not a consenter of are followed.
+		raftConsenter := etcdraft.New(clusterDialer, conf, srvConf, srv, registrar, nil, metricsProvider)
+		consenters["etcdraft"] = raftConsenter
+		consenters["bft"] = bft.New(clusterDialer, conf, srvConf.SecOpts.Certificate, raftConsenter.Communication)
+		return
+	}
+
 	replicationRefreshInterval := conf.General.Cluster.ReplicationBackgroundRefreshInterval
 	if replicationRefreshInterval == 0 {
 		replicationRefreshInterval = defaultReplicationBackgroundRefreshInterval
@@ -631,8 +662,26 @@ func initializeEtcdraftConsenter(
 	ri.channelLister = icr
 
 	go icr.run()
-	raftConsenter := etcdraft.New(clusterDialer, conf, srvConf, srv, registrar, icr)
+	raftConsenter := etcdraft.New(clusterDialer, conf, srvConf, srv, registrar, icr, metricsProvider)
 	consenters["etcdraft"] = raftConsenter
+	// The BFT consenter shares the cluster communication of the etcdraft consenter
+	consenters["bft"] = bft.New(clusterDialer, conf, srvConf.SecOpts.Certificate, raftConsenter.Communication)
+}
+
+// newAdmission creates the admission of transactions into the consenters, or returns nil if it is disabled.
+func newAdmission(conf localconfig.Admission) *broadcast.Admission {
+	if !conf.Enabled {
+		return nil
+	}
+	logger.Infof("Admission of transactions is enabled, rate: %v, max in flight: %d, queue size: %d", conf.Rate, conf.MaxInFlight, conf.QueueSize)
+	return broadcast.NewAdmission(broadcast.AdmissionConfig{
+		Rate:        conf.Rate,
+		Burst:       conf.Burst,
+		MaxInFlight: conf.MaxInFlight,
+		QueueSize:   conf.QueueSize,
+		RetryAfter:  conf.RetryAfter,
+		Weights:     conf.Weights,
+	})
 }
 
 func newOperationsSystem(ops localconfig.Operations, metrics localconfig.Metrics) *operations.System {
@@ -605,7 +605,7 @@ func TestInitializeEtcdraftConsenter(t *testing.T) {
 				Key:         crt.Key,
 				UseTLS:      true,
 			},
-		}, srv, &multichannel.Registrar{})
+		}, srv, &multichannel.Registrar{}, &disabled.Provider{})
 	assert.NotNil(t, consenters["etcdraft"])
 }
 
@@ -674,3 +674,16 @@ func TestCreateReplicator(t *testing.T) {
 	err = r.verifierRetriever.RetrieveVerifier("system").VerifyBlockSignature(nil, nil)
 	assert.NoError(t, err)
 }
+
+func TestNoSystemChannel(t *testing.T) {
+	conf := &localconfig.TopLevel{
+		General: localconfig.General{GenesisMethod: "none"},
+	}
+	assert.Nil(t, extractBootstrapBlock(conf))
+
+	ledgerFactory := &server_mocks.Factory{}
+	ledgerFactory.On("ChainIDs").Return(nil)
+
+	r := createReplicator(ledgerFactory, nil, conf, &comm.SecureOptions{}, &crypto.LocalSigner{})
+	assert.NotNil(t, r)
+}
@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package server
 
 import (
+	"bytes"
+	"fmt"
 	"sync"
 	"time"
 
@@ -119,6 +121,58 @@ func (ri *replicationInitiator) ReplicateChains(lastConfigBlock *common.Block, c
 	return replicator.ReplicateChains()
 }
 
+// ReplicateChannel pulls the blocks that precede the given join block from the orderers
+// of its channel, and appends them to the given ledger followed by the join block.
+// The pulled blocks are verified against the hash chain that ends with the join block.
+func (ri *replicationInitiator) ReplicateChannel(joinBlock *common.Block, ledger blockledger.ReadWriter) error {
+	channel, err := utils.GetChainIDFromBlock(joinBlock)
+	if err != nil {
+		return errors.WithMessage(err, "failed extracting channel name from join block")
+	}
+	pullerConfig := cluster.PullerConfigFromTopLevelConfig(channel, ri.conf, ri.secOpts.Key, ri.secOpts.Certificate, ri.signer)
+	puller, err := cluster.BlockPullerFromConfigBlock(pullerConfig, joinBlock, &joinBlockVerifierRetriever{})
+	if err != nil {
+		return errors.WithMessage(err, "failed creating puller from join block")
+	}
+	puller.MaxPullBlockRetries = uint64(ri.conf.General.Cluster.ReplicationMaxRetries)
+	puller.RetryTimeout = ri.conf.General.Cluster.ReplicationRetryTimeout
+	defer puller.Close()
+
+	ri.logger.Infof("Will now replicate channel %s up to block %d", channel, joinBlock.Header.Number)
+	var prevHash []byte
+	if height := ledger.Height(); height > 0 {
+		prevHash = blockledger.GetBlock(ledger, height-1).Header.Hash()
+	}
+	for seq := ledger.Height(); seq < joinBlock.Header.Number; seq++ {
+		block := puller.PullBlock(seq)
+		if block == nil {
+			return errors.Errorf("failed pulling block %d of channel %s", seq, channel)
+		}
+		if prevHash != nil && !bytes.Equal(block.Header.PreviousHash, prevHash) {
+			return errors.Errorf("block header mismatch on sequence %d, expected %x, got %x",
+				block.Header.Number, prevHash, block.Header.PreviousHash)
+		}
+		prevHash = block.Header.Hash()
+		if err := ledger.Append(block); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("failed appending block %d", seq))
+		}
+	}
+
+	if prevHash != nil && !bytes.Equal(joinBlock.Header.PreviousHash, prevHash) {
+		return errors.Errorf("join block %d does not chain to the pulled blocks, expected previous hash %x, got %x",
+			joinBlock.Header.Number, prevHash, joinBlock.Header.PreviousHash)
+	}
+	return ledger.Append(joinBlock)
+}
+
+// joinBlockVerifierRetriever does not verify the signatures of the blocks that
+// precede a join block, as they are verified by the hash chain of the join block.
+type joinBlockVerifierRetriever struct{}
+
+func (*joinBlockVerifierRetriever) RetrieveVerifier(channel string) cluster.BlockVerifier {
+	return &cluster.NoopBlockVerifier{}
+}
+
 type ledgerFactory struct {
 	blockledger.Factory
 	onBlockCommit cluster.BlockCommitFunc
@@ -22,6 +22,7 @@ import (
 	"github.com/hyperledger/fabric/common/crypto"
 	deliver_mocks "github.com/hyperledger/fabric/common/deliver/mock"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	ledger_mocks "github.com/hyperledger/fabric/common/ledger/blockledger/mocks"
 	ramledger "github.com/hyperledger/fabric/common/ledger/blockledger/ram"
 	"github.com/hyperledger/fabric/core/comm"
@@ -723,6 +724,113 @@ func TestReplicate(t *testing.T) {
 	}
 }
 
+func TestReplicateChannel(t *testing.T) {
+	t.Parallel()
+
+	blockBytes, err := ioutil.ReadFile(filepath.Join("testdata", "genesis.block"))
+	assert.NoError(t, err)
+
+	key := loadPEM("server.key", t)
+	cert := loadPEM("server.crt", t)
+	caCert := loadPEM("ca.crt", t)
+
+	copyBlock := func(seq uint64, endpoint string) *common.Block {
+		block := &common.Block{}
+		assert.NoError(t, proto.Unmarshal(blockBytes, block))
+		block.Header.Number = seq
+		injectOrdererEndpoint(t, block, endpoint)
+		return block
+	}
+
+	newReplicationInitiator := func() *replicationInitiator {
+		return &replicationInitiator{
+			logger: flogging.MustGetLogger("testReplicateChannel"),
+			conf: &localconfig.TopLevel{
+				General: localconfig.General{
+					Cluster: localconfig.Cluster{
+						ReplicationPullTimeout:  time.Hour,
+						DialTimeout:             time.Hour,
+						RPCTimeout:              time.Hour,
+						ReplicationRetryTimeout: time.Millisecond,
+						ReplicationBufferSize:   1,
+						ReplicationMaxRetries:   5,
+					},
+				},
+			},
+			secOpts: &comm.SecureOptions{
+				Certificate:   cert,
+				Key:           key,
+				UseTLS:        true,
+				ServerRootCAs: [][]byte{caCert},
+			},
+		}
+	}
+
+	for _, testCase := range []struct {
+		name          string
+		corruptJoin   bool
+		expectedError string
+	}{
+		{
+			name: "pulls the blocks that precede the join block",
+		},
+		{
+			name:          "join block does not chain to the pulled blocks",
+			corruptJoin:   true,
+			expectedError: "join block 3 does not chain to the pulled blocks",
+		},
+	} {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			deliverServer := newServerNode(t, key, cert)
+			defer deliverServer.srv.Stop()
+			endpoint := deliverServer.srv.Address()
+
+			blocks := make([]*common.Block, 3)
+			for seq := range blocks {
+				blocks[seq] = copyBlock(uint64(seq), endpoint)
+				if seq > 0 {
+					blocks[seq].Header.PreviousHash = blocks[seq-1].Header.Hash()
+				}
+			}
+			joinBlock := copyBlock(3, endpoint)
+			joinBlock.Header.PreviousHash = blocks[2].Header.Hash()
+			if testCase.corruptJoin {
+				joinBlock.Header.PreviousHash = []byte{1, 2, 3}
+			}
+
+			// The height of the channel is probed first, and then the blocks are pulled
+			deliverServer.blockResponses <- &orderer.DeliverResponse{
+				Type: &orderer.DeliverResponse_Block{Block: blocks[2]},
+			}
+			for _, block := range blocks {
+				deliverServer.blockResponses <- &orderer.DeliverResponse{
+					Type: &orderer.DeliverResponse_Block{Block: block},
+				}
+			}
+
+			ledger, err := ramledger.New(10).GetOrCreate("testchainid")
+			assert.NoError(t, err)
+
+			err = newReplicationInitiator().ReplicateChannel(joinBlock, ledger)
+			if testCase.expectedError != "" {
+				assert.Contains(t, err.Error(), testCase.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, uint64(4), ledger.Height())
+			assert.Equal(t, joinBlock.Header, blockledger.GetBlock(ledger, 3).Header)
+		})
+	}
+
+	t.Run("invalid join block", func(t *testing.T) {
+		ledger, err := ramledger.New(10).GetOrCreate("testchainid")
+		assert.NoError(t, err)
+		err = newReplicationInitiator().ReplicateChannel(&common.Block{}, ledger)
+		assert.EqualError(t, err, "failed extracting channel name from join block: failed to retrieve channel id - block is empty")
+	})
+}
+
 func TestInactiveChainReplicator(t *testing.T) {
 	for _, testCase := range []struct {
 		description                          string
@@ -23,6 +23,7 @@ import (
 	"github.com/hyperledger/fabric/orderer/common/multichannel"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/peer"
 	"github.com/pkg/errors"
 )
 
@@ -71,13 +72,21 @@ func (rs *responseSender) SendBlockResponse(block *cb.Block) error {
 	return rs.Send(response)
 }
 
+func (rs *responseSender) SendFilteredBlockResponse(filteredBlock *peer.FilteredBlock) error {
+	response := &ab.DeliverResponse{
+		Type: &ab.DeliverResponse_FilteredBlock{FilteredBlock: filteredBlock},
+	}
+	return rs.Send(response)
+}
+
 // NewServer creates an ab.AtomicBroadcastServer based on the broadcast target and ledger Reader
-func NewServer(r *multichannel.Registrar, metricsProvider metrics.Provider, debug *localconfig.Debug, timeWindow time.Duration, mutualTLS bool) ab.AtomicBroadcastServer {
+func NewServer(r *multichannel.Registrar, metricsProvider metrics.Provider, debug *localconfig.Debug, timeWindow time.Duration, mutualTLS bool, admission *broadcast.Admission) ab.AtomicBroadcastServer {
 	s := &server{
 		dh: deliver.NewHandler(deliverSupport{Registrar: r}, timeWindow, mutualTLS, deliver.NewMetrics(metricsProvider)),
 		bh: &broadcast.Handler{
 			SupportRegistrar: broadcastSupport{Registrar: r},
 			Metrics:          broadcast.NewMetrics(metricsProvider),
+			Admission:        admission,
 		},
 		debug:     debug,
 		Registrar: r,
@@ -0,0 +1,74 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package types
+
+// ErrorResponse carries the error message of a failed request.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// ChannelList carries the response to an HTTP request to List all the channels.
+type ChannelList struct {
+	// The system channel info, nil if it doesn't exist.
+	SystemChannel *ChannelInfoShort `json:"systemChannel"`
+	// Application channels only, nil or empty if no channels defined.
+	Channels []ChannelInfoShort `json:"channels"`
+}
+
+// ChannelInfoShort carries a short info of a single channel.
+type ChannelInfoShort struct {
+	// The channel name.
+	Name string `json:"name"`
+	// The channel relative URL (no Host:Port, only path), e.g.: "/participation/v1/channels/my-channel".
+	URL string `json:"url"`
+}
+
+// ConsensusRelation represents the relationship between the orderer and the channel's consensus cluster.
+type ConsensusRelation string
+
+const (
+	// ConsensusRelationConsenter means the orderer is a cluster consenter of a cluster consensus protocol (e.g. etcdraft),
+	// or is the single orderer of a non-cluster consensus protocol (e.g. solo, kafka).
+	ConsensusRelationConsenter ConsensusRelation = "consenter"
+	// ConsensusRelationFollower means the orderer is following a cluster consensus protocol by pulling blocks from
+	// other orderers.
+	ConsensusRelationFollower ConsensusRelation = "follower"
+	// ConsensusRelationConfigTracker means the orderer is not a cluster consenter of the channel, and only tracks the
+	// channel's configuration in order to detect when it is added to it.
+	ConsensusRelationConfigTracker ConsensusRelation = "config-tracker"
+)
+
+// Status represents the degree by which the orderer had caught up with the rest of the cluster after joining the
+// channel (either as a consenter or a follower).
+type Status string
+
+const (
+	// StatusActive means the orderer is active in the channel's consensus protocol, or following the cluster,
+	// with its height approximately the same as the rest of the cluster.
+	StatusActive Status = "active"
+	// StatusOnBoarding means the orderer is catching up with the rest of the cluster by pulling blocks from them,
+	// and its height is significantly behind the rest of the cluster.
+	StatusOnBoarding Status = "onboarding"
+	// StatusInactive means the orderer is not storing any meaningful state.
+	StatusInactive Status = "inactive"
+)
+
+// ChannelInfo carries the response to an HTTP request to List a single channel.
+// This is marshaled into the body of the HTTP response.
+type ChannelInfo struct {
+	// The channel name.
+	Name string `json:"name"`
+	// The channel relative URL (no Host:Port, only path), e.g.: "/participation/v1/channels/my-channel".
+	URL string `json:"url"`
+	// Whether the orderer is a "consenter", "follower", or "config-tracker" of
+	// the cluster for this channel. Empty while the channel is onboarding.
+	ConsensusRelation ConsensusRelation `json:"consensusRelation"`
+	// Whether the orderer is "onboarding", "active", or "inactive", for this channel.
+	Status Status `json:"status"`
+	// Current block height.
+	Height uint64 `json:"height"`
+}
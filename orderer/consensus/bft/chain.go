@@ -0,0 +1,1012 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"bytes"
+	"sort"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
+	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/orderer/bft"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultTickInterval is the interval at which the timeouts of the chain are checked.
+	DefaultTickInterval = 100 * time.Millisecond
+
+	// DefaultRequestPoolSize is the number of requests that may wait to be ordered.
+	DefaultRequestPoolSize = 10000
+
+	// futureMessagesLimit is the number of messages of the next decision that
+	// are kept until the current decision is made.
+	futureMessagesLimit = 1000
+)
+
+// Configurator is used to configure the communication layer
+// when the chain starts.
+type Configurator interface {
+	Configure(channel string, newNodes []cluster.RemoteNode)
+}
+
+// RPC is used to mock the transport layer in tests.
+type RPC interface {
+	SendConsensus(dest uint64, msg *orderer.ConsensusRequest) error
+	SendSubmit(dest uint64, request *orderer.SubmitRequest) error
+}
+
+// BlockPuller is used to pull blocks from other OSN
+type BlockPuller interface {
+	PullBlock(seq uint64) *common.Block
+	HeightsByEndpoints() (map[string]uint64, error)
+	Close()
+}
+
+// CreateBlockPuller is a function to create BlockPuller on demand.
+// It is passed into chain initializer so that tests could mock this.
+type CreateBlockPuller func() (BlockPuller, error)
+
+// Options contains all the configurations relevant to the chain.
+type Options struct {
+	ID uint64
+
+	Clock  clock.Clock
+	Logger *flogging.FabricLogger
+
+	TickInterval      time.Duration
+	RequestTimeout    time.Duration
+	ViewChangeTimeout time.Duration
+	RequestPoolSize   int
+
+	// View is the view in which the last block of the channel was committed.
+	View       uint64
+	Consenters []*bft.Consenter
+}
+
+type submit struct {
+	req    *orderer.SubmitRequest
+	sender uint64
+}
+
+type message struct {
+	sender uint64
+	msg    *bft.Message
+}
+
+// preparedCertificate proves that a quorum of the consenters accepted a proposal.
+type preparedCertificate struct {
+	prePrepare *bft.PrePrepare
+	prepares   []*bft.SignedMessage
+}
+
+// Chain implements consensus.Chain interface with a Byzantine fault tolerant protocol.
+//
+// Blocks are proposed by the leader of the current view, and are committed once
+// a quorum of the consenters signed them. If a request is not ordered in time,
+// the consenters suspect the leader of censoring it, and change the view.
+type Chain struct {
+	configurator Configurator
+	rpc          RPC
+	egress       *egress
+	createPuller CreateBlockPuller
+	support      consensus.ConsenterSupport
+
+	channelID string
+	id        uint64
+	opts      Options
+	logger    *flogging.FabricLogger
+	clock     clock.Clock
+
+	submitC chan *submit
+	msgC    chan *message
+	haltC   chan struct{} // Signals to goroutines that the chain is halting
+	doneC   chan struct{} // Closes when the chain halts
+	startC  chan struct{} // Closes when the node is started
+
+	// The state below is only accessed by the serving goroutine.
+	consenters *consenterSet
+	pool       *requestPool
+
+	// The last committed block, and the signatures of the consenters over it
+	lastHeader     *common.BlockHeader
+	lastSignatures []*common.MetadataSignature
+
+	view uint64 // The current view
+	seq  uint64 // The number of the next block
+
+	// The state of the current decision
+	proposal *bft.PrePrepare
+	digest   []byte
+	prepares map[uint64]*bft.SignedMessage
+	commits  map[uint64]*bft.Commit
+	prepared bool
+
+	// The latest proposal that a quorum of the consenters accepted
+	preparedCert *preparedCertificate
+	// The proposal that must be proposed in the current view, as it may have been committed in an earlier view
+	mandated *common.Block
+
+	// Messages of the next decision, which are handled once the current decision is made
+	future      []*message
+	futureTicks int
+
+	// The state of view changes
+	changing        bool
+	nextView        uint64
+	viewChangeStart time.Time
+	voted           uint64
+	votes           map[uint64]uint64
+	viewData        map[uint64]map[uint64]*bft.SignedMessage
+	newViewSent     uint64
+}
+
+// NewChain constructs a chain object.
+func NewChain(
+	support consensus.ConsenterSupport,
+	opts Options,
+	conf Configurator,
+	rpc RPC,
+	f CreateBlockPuller,
+) (*Chain, error) {
+	lg := opts.Logger.With("channel", support.ChainID(), "node", opts.ID)
+
+	consenters, err := newConsenterSet(opts.Consenters)
+	if err != nil {
+		return nil, err
+	}
+	if !consenters.contains(opts.ID) {
+		return nil, errors.Errorf("%d is not a consenter of channel %s", opts.ID, support.ChainID())
+	}
+
+	lastBlock := support.Block(support.Height() - 1)
+	if lastBlock == nil {
+		return nil, errors.Errorf("failed to retrieve block %d", support.Height()-1)
+	}
+	var lastSignatures []*common.MetadataSignature
+	if lastBlock.Header.Number > 0 {
+		md, err := utils.GetMetadataFromBlock(lastBlock, common.BlockMetadataIndex_SIGNATURES)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to read signatures of the last block")
+		}
+		lastSignatures = md.Signatures
+	}
+
+	c := &Chain{
+		configurator:   conf,
+		rpc:            rpc,
+		egress:         newEgress(rpc, lg),
+		createPuller:   f,
+		support:        support,
+		channelID:      support.ChainID(),
+		id:             opts.ID,
+		opts:           opts,
+		logger:         lg,
+		clock:          opts.Clock,
+		submitC:        make(chan *submit),
+		msgC:           make(chan *message),
+		haltC:          make(chan struct{}),
+		doneC:          make(chan struct{}),
+		startC:         make(chan struct{}),
+		consenters:     consenters,
+		pool:           newRequestPool(opts.RequestPoolSize),
+		lastHeader:     lastBlock.Header,
+		lastSignatures: lastSignatures,
+		view:           opts.View,
+		voted:          opts.View,
+		seq:            lastBlock.Header.Number + 1,
+		votes:          make(map[uint64]uint64),
+		viewData:       make(map[uint64]map[uint64]*bft.SignedMessage),
+	}
+	c.resetDecision()
+
+	return c, nil
+}
+
+// Start instructs the orderer to begin serving the chain and keep it current.
+func (c *Chain) Start() {
+	c.logger.Infof("Starting BFT node in view %d", c.view)
+
+	if err := c.configureComm(); err != nil {
+		c.logger.Errorf("Failed to start chain, aborting: +%v", err)
+		close(c.doneC)
+		return
+	}
+
+	close(c.startC)
+	go c.serve()
+}
+
+// Order submits normal type transactions for ordering.
+func (c *Chain) Order(env *common.Envelope, configSeq uint64) error {
+	return c.Submit(&orderer.SubmitRequest{LastValidationSeq: configSeq, Payload: env, Channel: c.channelID}, 0)
+}
+
+// Configure submits config type transactions for ordering.
+func (c *Chain) Configure(env *common.Envelope, configSeq uint64) error {
+	if err := c.checkConfig(env); err != nil {
+		return err
+	}
+	return c.Submit(&orderer.SubmitRequest{LastValidationSeq: configSeq, Payload: env, Channel: c.channelID}, 0)
+}
+
+// WaitReady blocks while the chain is catching up with the other consenters.
+// In any other case, it returns right away.
+func (c *Chain) WaitReady() error {
+	if err := c.isRunning(); err != nil {
+		return err
+	}
+
+	select {
+	case c.submitC <- nil:
+	case <-c.doneC:
+		return errors.Errorf("chain is stopped")
+	}
+
+	return nil
+}
+
+// Errored returns a channel that closes when the chain stops.
+func (c *Chain) Errored() <-chan struct{} {
+	return c.doneC
+}
+
+// Halt stops the chain.
+func (c *Chain) Halt() {
+	select {
+	case <-c.startC:
+	default:
+		c.logger.Warnf("Attempted to halt a chain that has not started")
+		return
+	}
+
+	select {
+	case c.haltC <- struct{}{}:
+	case <-c.doneC:
+		return
+	}
+	<-c.doneC
+}
+
+func (c *Chain) isRunning() error {
+	select {
+	case <-c.startC:
+	default:
+		return errors.Errorf("chain is not started")
+	}
+
+	select {
+	case <-c.doneC:
+		return errors.Errorf("chain is stopped")
+	default:
+	}
+
+	return nil
+}
+
+// Consensus passes the given ConsensusRequest message to the chain.
+func (c *Chain) Consensus(req *orderer.ConsensusRequest, sender uint64) error {
+	if err := c.isRunning(); err != nil {
+		return err
+	}
+
+	msg := &bft.Message{}
+	if err := proto.Unmarshal(req.Payload, msg); err != nil {
+		return errors.Errorf("failed to unmarshal ConsensusRequest payload to BFT Message: %s", err)
+	}
+
+	select {
+	case c.msgC <- &message{sender: sender, msg: msg}:
+		return nil
+	case <-c.doneC:
+		return errors.Errorf("chain is stopped")
+	}
+}
+
+// Submit passes the given request to the chain. Requests that were relayed by
+// other consenters are validated, as the consenters do not trust each other.
+func (c *Chain) Submit(req *orderer.SubmitRequest, sender uint64) error {
+	if err := c.isRunning(); err != nil {
+		return err
+	}
+
+	if sender != 0 || req.LastValidationSeq < c.support.Sequence() {
+		env, configSeq, _, err := c.processRequest(req.Payload)
+		if err != nil {
+			return errors.WithMessage(err, "invalid request")
+		}
+		req = &orderer.SubmitRequest{LastValidationSeq: configSeq, Payload: env, Channel: req.Channel}
+	}
+
+	select {
+	case c.submitC <- &submit{req: req, sender: sender}:
+		return nil
+	case <-c.doneC:
+		return errors.Errorf("chain is stopped")
+	}
+}
+
+func (c *Chain) serve() {
+	ticker := c.clock.NewTicker(c.opts.TickInterval)
+
+	defer func() {
+		ticker.Stop()
+		c.egress.stop()
+		close(c.doneC)
+	}()
+
+	for {
+		select {
+		case s := <-c.submitC:
+			if s == nil {
+				// polled by WaitReady
+				continue
+			}
+			c.onRequest(s.req, s.sender)
+		case m := <-c.msgC:
+			c.onMessage(m.sender, m.msg)
+		case <-ticker.C():
+			c.onTick()
+		case <-c.haltC:
+			c.logger.Infof("Stop serving requests")
+			return
+		}
+	}
+}
+
+// onRequest adds a request to the pool, and relays requests that were submitted
+// locally to the other consenters, so that they all keep track of it.
+func (c *Chain) onRequest(req *orderer.SubmitRequest, sender uint64) {
+	isConfig, err := c.isConfig(req.Payload)
+	if err != nil {
+		c.logger.Warningf("Dropping request: %v", err)
+		return
+	}
+
+	added, err := c.pool.add(req.Payload, isConfig, req.LastValidationSeq, c.clock.Now())
+	if err != nil {
+		c.logger.Warningf("Dropping request: %v", err)
+		return
+	}
+	if !added {
+		return
+	}
+
+	if sender == 0 {
+		for _, id := range c.consenters.ids {
+			if id != c.id {
+				c.egress.send(id, req)
+			}
+		}
+	}
+
+	c.propose()
+}
+
+// onTick checks whether the leader of the current view or a view change
+// takes too long, and cuts a batch if the batch timeout expired.
+func (c *Chain) onTick() {
+	now := c.clock.Now()
+
+	if c.changing {
+		if now.Sub(c.viewChangeStart) >= c.opts.ViewChangeTimeout {
+			c.logger.Warningf("View change to view %d did not complete within %v", c.nextView, c.opts.ViewChangeTimeout)
+			c.vote(c.nextView + 1)
+		}
+	} else if oldest := c.pool.oldest(); oldest != nil && now.Sub(oldest.arrival) >= c.opts.RequestTimeout && c.voted <= c.view {
+		// The request may have become invalid, in which case it is not expected to be ordered
+		if _, _, _, err := c.processRequest(oldest.env); err != nil {
+			c.logger.Debugf("Dropping request that is no longer valid: %v", err)
+			c.pool.remove(oldest.digest)
+		} else {
+			c.logger.Warningf("A request was not ordered within %v, suspecting the leader %d of view %d",
+				c.opts.RequestTimeout, c.consenters.leaderOf(c.view), c.view)
+			c.vote(c.view + 1)
+		}
+	}
+
+	// Messages of the next decision that wait for longer than a tick
+	// indicate that this node missed the current decision.
+	if len(c.future) > 0 {
+		c.futureTicks++
+		if c.futureTicks > 1 {
+			c.catchUp(c.seq + 1)
+		}
+	}
+
+	c.propose()
+}
+
+func (c *Chain) onMessage(sender uint64, msg *bft.Message) {
+	if !c.consenters.contains(sender) {
+		c.logger.Warningf("Ignoring message from %d, as it is not a consenter", sender)
+		return
+	}
+
+	switch content := msg.Content.(type) {
+	case *bft.Message_PrePrepare:
+		c.onPrePrepare(sender, msg, content.PrePrepare)
+	case *bft.Message_Prepare:
+		c.onPrepare(sender, msg, content.Prepare)
+	case *bft.Message_Commit:
+		c.onCommit(sender, msg, content.Commit)
+	case *bft.Message_ViewChange:
+		c.onViewChange(sender, content.ViewChange)
+	case *bft.Message_ViewData:
+		c.onViewData(sender, content.ViewData)
+	case *bft.Message_NewView:
+		c.onNewView(sender, content.NewView)
+	default:
+		c.logger.Warningf("Ignoring message of unknown type from %d", sender)
+	}
+}
+
+// isCurrent returns whether a message of the given view and sequence belongs to the current decision.
+// Messages of the next decision are kept until the current decision is made.
+func (c *Chain) isCurrent(sender uint64, msg *bft.Message, view, seq uint64) bool {
+	if c.changing || view != c.view || seq < c.seq {
+		return false
+	}
+	if seq == c.seq+1 && len(c.future) < futureMessagesLimit {
+		c.future = append(c.future, &message{sender: sender, msg: msg})
+	}
+	return seq == c.seq
+}
+
+func (c *Chain) onPrePrepare(sender uint64, msg *bft.Message, pp *bft.PrePrepare) {
+	if sender != c.consenters.leaderOf(pp.View) {
+		c.logger.Warningf("Ignoring proposal of %d for view %d, as it is not the leader of that view", sender, pp.View)
+		return
+	}
+
+	// A proposal of a later decision by the leader of the current view, or of a later
+	// view, indicates that this node missed decisions, which are pulled from the other consenters.
+	if (pp.View == c.view && pp.Seq > c.seq+1) || (pp.View > c.view && pp.Seq > c.seq) {
+		c.catchUp(pp.Seq)
+		if pp.View != c.view || pp.Seq != c.seq {
+			return
+		}
+	}
+
+	if !c.isCurrent(sender, msg, pp.View, pp.Seq) {
+		return
+	}
+
+	if c.proposal != nil {
+		c.logger.Warningf("Ignoring proposal of %d for block %d, as a proposal was already accepted", sender, pp.Seq)
+		return
+	}
+
+	if err := c.verifyProposal(pp.Proposal); err != nil {
+		c.logger.Warningf("Rejecting proposal of %d for block %d: %v", sender, pp.Seq, err)
+		c.vote(c.view + 1)
+		return
+	}
+
+	c.accept(pp)
+}
+
+func (c *Chain) onPrepare(sender uint64, msg *bft.Message, sm *bft.SignedMessage) {
+	prepare := &bft.Prepare{}
+	if sm.Signer != sender {
+		c.logger.Warningf("Ignoring prepare of %d sent by %d", sm.Signer, sender)
+		return
+	}
+	if err := c.consenters.verifySigned(sm, prepare); err != nil {
+		c.logger.Warningf("Ignoring prepare of %d: %v", sender, err)
+		return
+	}
+
+	if !c.isCurrent(sender, msg, prepare.View, prepare.Seq) {
+		return
+	}
+
+	c.prepares[sender] = sm
+	c.checkPrepared()
+}
+
+func (c *Chain) onCommit(sender uint64, msg *bft.Message, commit *bft.Commit) {
+	if !c.isCurrent(sender, msg, commit.View, commit.Seq) {
+		return
+	}
+
+	// The signature over the block is verified once the proposal is known
+	c.commits[sender] = commit
+	c.checkCommitted()
+}
+
+// propose proposes the next block if this node is the leader of the current view,
+// and either the block that was prepared in an earlier view must be proposed again,
+// or a batch of requests is ready.
+func (c *Chain) propose() {
+	if c.changing || c.proposal != nil || c.consenters.leaderOf(c.view) != c.id {
+		return
+	}
+
+	block := c.mandated
+	if block == nil {
+		batch := c.cutBatch()
+		if len(batch) == 0 {
+			return
+		}
+		block = c.support.CreateNextBlock(batch)
+	}
+
+	pp := &bft.PrePrepare{View: c.view, Seq: c.seq, Proposal: block}
+	c.logger.Debugf("Proposing block %d with %d transactions in view %d", c.seq, len(block.Data.Data), c.view)
+	c.broadcast(&bft.Message{Content: &bft.Message_PrePrepare{PrePrepare: pp}})
+	c.accept(pp)
+}
+
+// cutBatch returns the next batch of requests, once the batch is full, the batch timeout expired,
+// or a config request is next. Requests that became invalid since they were added are dropped.
+func (c *Chain) cutBatch() []*common.Envelope {
+	oldest := c.pool.oldest()
+	if oldest == nil {
+		return nil
+	}
+
+	batchSize := c.support.SharedConfig().BatchSize()
+	if !oldest.config && c.pool.size() < int(batchSize.MaxMessageCount) &&
+		c.clock.Since(oldest.arrival) < c.support.SharedConfig().BatchTimeout() {
+		return nil
+	}
+
+	var batch []*common.Envelope
+	var batchBytes uint32
+	for _, r := range c.pool.pending() {
+		if len(batch) > 0 && (r.config || batchBytes+r.size > batchSize.PreferredMaxBytes) {
+			break
+		}
+
+		env := r.env
+		if r.configSeq < c.support.Sequence() {
+			var err error
+			if env, _, _, err = c.processRequest(r.env); err != nil {
+				c.logger.Debugf("Dropping request that is no longer valid: %v", err)
+				c.pool.remove(r.digest)
+				continue
+			}
+		}
+
+		batch = append(batch, env)
+		batchBytes += r.size
+		if r.config || len(batch) == int(batchSize.MaxMessageCount) {
+			break
+		}
+	}
+	return batch
+}
+
+// verifyProposal verifies that the proposed block extends the chain,
+// and that its requests are valid.
+func (c *Chain) verifyProposal(block *common.Block) error {
+	if block == nil || block.Header == nil || block.Data == nil {
+		return errors.New("proposal is not a block")
+	}
+	if block.Header.Number != c.seq {
+		return errors.Errorf("expected block %d but got block %d", c.seq, block.Header.Number)
+	}
+	if !bytes.Equal(block.Header.PreviousHash, c.lastHeader.Hash()) {
+		return errors.New("previous hash of the block does not match the hash of the last block")
+	}
+	if !bytes.Equal(block.Header.DataHash, block.Data.Hash()) {
+		return errors.New("data hash of the block does not match its data")
+	}
+
+	if c.mandated != nil {
+		if !bytes.Equal(block.Header.Hash(), c.mandated.Header.Hash()) {
+			return errors.New("block does not match the block that was prepared in an earlier view")
+		}
+		return nil
+	}
+
+	if len(block.Data.Data) == 0 {
+		return errors.New("block is empty")
+	}
+	if maxCount := c.support.SharedConfig().BatchSize().MaxMessageCount; len(block.Data.Data) > int(maxCount) {
+		return errors.Errorf("block has %d transactions, but blocks have at most %d transactions", len(block.Data.Data), maxCount)
+	}
+
+	for i, data := range block.Data.Data {
+		env, err := utils.UnmarshalEnvelope(data)
+		if err != nil {
+			return errors.WithMessage(err, "invalid transaction")
+		}
+		isConfig, err := c.isConfig(env)
+		if err != nil {
+			return err
+		}
+		if !isConfig {
+			if _, err := c.support.ProcessNormalMsg(env); err != nil {
+				return errors.WithMessage(err, "invalid transaction")
+			}
+			continue
+		}
+
+		if len(block.Data.Data) != 1 {
+			return errors.Errorf("config transaction %d is not alone in its block", i)
+		}
+		if err := c.verifyConfig(env); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verifyConfig verifies that the given config transaction carries the config
+// that its config update produces.
+func (c *Chain) verifyConfig(env *common.Envelope) error {
+	expectedEnv, _, err := c.support.ProcessConfigMsg(env)
+	if err != nil {
+		return errors.WithMessage(err, "invalid config transaction")
+	}
+	expected, err := configFromEnvelope(expectedEnv)
+	if err != nil {
+		return err
+	}
+	config, err := configFromEnvelope(env)
+	if err != nil {
+		return err
+	}
+	if !proto.Equal(expected, config) {
+		return errors.New("config does not match the config produced by its config update")
+	}
+	return ValidateConfig(config)
+}
+
+// accept accepts the given proposal, and notifies the other consenters.
+func (c *Chain) accept(pp *bft.PrePrepare) {
+	c.proposal = pp
+	c.digest = pp.Proposal.Header.Hash()
+
+	prepare := c.sign(&bft.Prepare{View: pp.View, Seq: pp.Seq, Digest: c.digest})
+	c.broadcast(&bft.Message{Content: &bft.Message_Prepare{Prepare: prepare}})
+	c.prepares[c.id] = prepare
+
+	c.checkPrepared()
+	c.checkCommitted()
+}
+
+// checkPrepared checks whether a quorum of the consenters accepted the proposal,
+// in which case this node signs the block.
+func (c *Chain) checkPrepared() {
+	if c.proposal == nil || c.prepared {
+		return
+	}
+
+	var prepares []*bft.SignedMessage
+	for _, id := range c.consenters.ids {
+		sm, exists := c.prepares[id]
+		if !exists {
+			continue
+		}
+		prepare := &bft.Prepare{}
+		if err := proto.Unmarshal(sm.Payload, prepare); err == nil && bytes.Equal(prepare.Digest, c.digest) {
+			prepares = append(prepares, sm)
+		}
+	}
+	if len(prepares) < c.consenters.quorum() {
+		return
+	}
+
+	c.prepared = true
+	c.preparedCert = &preparedCertificate{prePrepare: c.proposal, prepares: prepares}
+
+	commit := &bft.Commit{
+		View:      c.view,
+		Seq:       c.seq,
+		Digest:    c.digest,
+		Signature: c.signBlock(c.proposal.Proposal.Header),
+	}
+	c.broadcast(&bft.Message{Content: &bft.Message_Commit{Commit: commit}})
+	c.commits[c.id] = commit
+}
+
+// checkCommitted checks whether a quorum of the consenters signed the proposed block,
+// in which case the block is committed.
+func (c *Chain) checkCommitted() {
+	if c.proposal == nil {
+		return
+	}
+
+	var signatures []*common.MetadataSignature
+	for _, id := range c.consenters.ids {
+		commit, exists := c.commits[id]
+		if !exists || !bytes.Equal(commit.Digest, c.digest) {
+			continue
+		}
+		signer, err := c.consenters.verifyBlockSignature(commit.Signature, c.proposal.Proposal.Header)
+		if err != nil || signer != id {
+			c.logger.Warningf("Ignoring commit of %d with an invalid signature: %v", id, err)
+			delete(c.commits, id)
+			continue
+		}
+		signatures = append(signatures, commit.Signature)
+	}
+	if len(signatures) < c.consenters.quorum() {
+		return
+	}
+
+	c.commit(c.proposal.Proposal, signatures)
+}
+
+// commit writes the given block, which is signed by a quorum of the consenters, to the ledger.
+func (c *Chain) commit(proposal *common.Block, signatures []*common.MetadataSignature) {
+	block := &common.Block{
+		Header:   proposal.Header,
+		Data:     proposal.Data,
+		Metadata: &common.BlockMetadata{Metadata: make([][]byte, len(common.BlockMetadataIndex_name))},
+	}
+	block.Metadata.Metadata[common.BlockMetadataIndex_SIGNATURES] = utils.MarshalOrPanic(&common.Metadata{
+		Signatures: signatures,
+	})
+	metadata := utils.MarshalOrPanic(&bft.BlockMetadata{View: c.view})
+
+	c.logger.Debugf("Committing block %d with %d signatures in view %d", block.Header.Number, len(signatures), c.view)
+	isConfig := utils.IsConfigBlock(block)
+	if isConfig {
+		c.support.WriteConfigBlock(block, metadata)
+	} else {
+		c.support.WriteBlock(block, metadata)
+	}
+
+	c.advance(block.Header, signatures, block.Data, isConfig)
+}
+
+// advance moves on to the next decision once the given block was written.
+func (c *Chain) advance(header *common.BlockHeader, signatures []*common.MetadataSignature, data *common.BlockData, isConfig bool) {
+	c.lastHeader = header
+	c.lastSignatures = signatures
+	c.seq = header.Number + 1
+	c.pool.removeOrdered(data)
+	c.preparedCert = nil
+	c.mandated = nil
+	c.resetDecision()
+
+	if isConfig {
+		c.reconfigure()
+	}
+
+	future := c.future
+	c.future = nil
+	c.futureTicks = 0
+	for _, m := range future {
+		c.onMessage(m.sender, m.msg)
+	}
+
+	c.propose()
+}
+
+func (c *Chain) resetDecision() {
+	c.proposal = nil
+	c.digest = nil
+	c.prepared = false
+	c.prepares = make(map[uint64]*bft.SignedMessage)
+	c.commits = make(map[uint64]*bft.Commit)
+}
+
+// reconfigure applies the config of the channel once a config block was written.
+func (c *Chain) reconfigure() {
+	md := &bft.ConfigMetadata{}
+	if err := proto.Unmarshal(c.support.SharedConfig().ConsensusMetadata(), md); err != nil {
+		c.logger.Panicf("Failed to unmarshal BFT metadata of the config: %v", err)
+	}
+	consenters, err := newConsenterSet(md.Consenters)
+	if err != nil {
+		c.logger.Panicf("Invalid consenters in the config: %v", err)
+	}
+	requestTimeout, viewChangeTimeout, err := timeouts(md.Options)
+	if err != nil {
+		c.logger.Panicf("Invalid options in the config: %v", err)
+	}
+
+	c.consenters = consenters
+	c.opts.Consenters = md.Consenters
+	c.opts.RequestTimeout = requestTimeout
+	c.opts.ViewChangeTimeout = viewChangeTimeout
+
+	if !consenters.contains(c.id) {
+		c.logger.Infof("This node was removed from the consenters of the channel, halting")
+		go c.Halt()
+		return
+	}
+
+	if err := c.configureComm(); err != nil {
+		c.logger.Panicf("Failed to configure communication: %v", err)
+	}
+
+	// Requests that are no longer valid under the new config are not expected to be ordered
+	for _, r := range c.pool.pending() {
+		if _, _, _, err := c.processRequest(r.env); err != nil {
+			c.pool.remove(r.digest)
+		}
+	}
+}
+
+func (c *Chain) configureComm() error {
+	nodes, err := c.consenters.remoteNodes(c.id)
+	if err != nil {
+		return err
+	}
+	c.configurator.Configure(c.channelID, nodes)
+
+	var ids []uint64
+	for _, n := range nodes {
+		ids = append(ids, n.ID)
+	}
+	c.egress.configure(ids)
+	return nil
+}
+
+// catchUp pulls the blocks up to the given height from the other consenters,
+// or as many blocks as they have if more.
+func (c *Chain) catchUp(height uint64) {
+	if c.seq >= height {
+		return
+	}
+
+	puller, err := c.createPuller()
+	if err != nil {
+		c.logger.Errorf("Failed to create block puller: %v", err)
+		return
+	}
+	defer puller.Close()
+
+	heights, err := puller.HeightsByEndpoints()
+	if err != nil {
+		c.logger.Errorf("Failed to obtain the heights of the channel: %v", err)
+		return
+	}
+	for _, h := range heights {
+		if h > height {
+			height = h
+		}
+	}
+
+	c.logger.Infof("Catching up from block %d to block %d", c.seq, height-1)
+	for c.seq < height {
+		block := puller.PullBlock(c.seq)
+		if block == nil {
+			c.logger.Warningf("Failed to pull block %d", c.seq)
+			return
+		}
+		if err := c.commitPulled(block); err != nil {
+			c.logger.Warningf("Failed to commit pulled block %d: %v", c.seq, err)
+			return
+		}
+	}
+}
+
+// commitPulled writes a block that was pulled, and which carries the signatures of the consenters.
+func (c *Chain) commitPulled(block *common.Block) error {
+	if block.Header.Number != c.seq || !bytes.Equal(block.Header.PreviousHash, c.lastHeader.Hash()) {
+		return errors.Errorf("block %d does not extend the chain", block.Header.Number)
+	}
+	md, err := utils.GetMetadataFromBlock(block, common.BlockMetadataIndex_SIGNATURES)
+	if err != nil {
+		return err
+	}
+	if err := c.consenters.verifyDecision(block.Header, md.Signatures); err != nil {
+		return err
+	}
+
+	var view uint64
+	if ordererMetadata, err := utils.GetMetadataFromBlock(block, common.BlockMetadataIndex_ORDERER); err == nil {
+		blockMetadata := &bft.BlockMetadata{}
+		if err := proto.Unmarshal(ordererMetadata.Value, blockMetadata); err == nil {
+			view = blockMetadata.View
+		}
+	}
+
+	isConfig := utils.IsConfigBlock(block)
+	if isConfig {
+		c.support.WriteConfigBlock(block, nil)
+	} else {
+		c.support.WriteBlock(block, nil)
+	}
+
+	// The block was committed in a later view, which the consenters moved on to
+	if view > c.view && (!c.changing || view >= c.nextView) {
+		c.installView(view)
+	}
+
+	c.advance(block.Header, md.Signatures, block.Data, isConfig)
+	return nil
+}
+
+// processRequest validates the given request against the current config, and
+// reproduces config requests. It returns the request along with the config sequence
+// it was validated against.
+func (c *Chain) processRequest(env *common.Envelope) (*common.Envelope, uint64, bool, error) {
+	isConfig, err := c.isConfig(env)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	if !isConfig {
+		configSeq, err := c.support.ProcessNormalMsg(env)
+		return env, configSeq, false, err
+	}
+
+	config, configSeq, err := c.support.ProcessConfigMsg(env)
+	if err != nil {
+		return nil, 0, true, err
+	}
+	if err := c.checkConfig(config); err != nil {
+		return nil, 0, true, err
+	}
+	return config, configSeq, true, nil
+}
+
+func (c *Chain) isConfig(env *common.Envelope) (bool, error) {
+	chdr, err := utils.ChannelHeader(env)
+	if err != nil {
+		return false, errors.WithMessage(err, "failed to extract channel header")
+	}
+	switch c.support.ClassifyMsg(chdr) {
+	case msgprocessor.NormalMsg:
+		return false, nil
+	case msgprocessor.ConfigMsg:
+		return true, nil
+	default:
+		return false, errors.Errorf("unexpected message of type %d", chdr.Type)
+	}
+}
+
+// checkConfig validates the config carried by the given config transaction.
+func (c *Chain) checkConfig(env *common.Envelope) error {
+	config, err := configFromEnvelope(env)
+	if err != nil {
+		return err
+	}
+	return ValidateConfig(config)
+}
+
+// sign signs the given message with the identity of this node.
+func (c *Chain) sign(msg proto.Message) *bft.SignedMessage {
+	payload := utils.MarshalOrPanic(msg)
+	signature, err := c.support.Sign(payload)
+	if err != nil {
+		c.logger.Panicf("Failed to sign message: %v", err)
+	}
+	return &bft.SignedMessage{Payload: payload, Signer: c.id, Signature: signature}
+}
+
+// signBlock signs the given block header the same way orderers sign the blocks they write.
+func (c *Chain) signBlock(header *common.BlockHeader) *common.MetadataSignature {
+	sig := &common.MetadataSignature{
+		SignatureHeader: utils.MarshalOrPanic(utils.NewSignatureHeaderOrPanic(c.support)),
+	}
+	sig.Signature = utils.SignOrPanic(c.support, util.ConcatenateBytes(nil, sig.SignatureHeader, header.Bytes()))
+	return sig
+}
+
+func (c *Chain) broadcast(msg *bft.Message) {
+	req := &orderer.ConsensusRequest{Channel: c.channelID, Payload: utils.MarshalOrPanic(msg)}
+	for _, id := range c.consenters.ids {
+		if id != c.id {
+			c.egress.send(id, req)
+		}
+	}
+}
+
+func (c *Chain) send(dest uint64, msg *bft.Message) {
+	c.egress.send(dest, &orderer.ConsensusRequest{Channel: c.channelID, Payload: utils.MarshalOrPanic(msg)})
+}
+
+func sortSignedMessages(messages []*bft.SignedMessage) {
+	sort.Slice(messages, func(i, j int) bool { return messages[i].Signer < messages[j].Signer })
+}
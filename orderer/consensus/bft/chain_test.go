@@ -0,0 +1,464 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/bccsp/factory"
+	bccsputils "github.com/hyperledger/fabric/bccsp/utils"
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/hyperledger/fabric/common/flogging"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
+	"github.com/hyperledger/fabric/orderer/consensus/bft"
+	consensusmocks "github.com/hyperledger/fabric/orderer/consensus/mocks"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/orderer"
+	bftprotos "github.com/hyperledger/fabric/protos/orderer/bft"
+	"github.com/hyperledger/fabric/protos/utils"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	channelID           = "test-channel"
+	mspID               = "SampleOrg"
+	tickInterval        = time.Second
+	LongEventualTimeout = 10 * time.Second
+)
+
+func init() {
+	factory.InitFactories(nil)
+}
+
+var _ = Describe("Chain", func() {
+	var net *network
+
+	BeforeEach(func() {
+		net = newNetwork(4)
+		net.start()
+	})
+
+	AfterEach(func() {
+		net.stop()
+	})
+
+	It("orders transactions in blocks signed by a quorum of the consenters", func() {
+		Expect(net.nodes[2].chain.Order(newEnvelope(1), 0)).To(Succeed())
+		Eventually(net.minHeight, LongEventualTimeout).Should(Equal(uint64(2)))
+
+		expected := net.nodes[1].block(1)
+		Expect(expected.Data.Data).To(HaveLen(1))
+		for _, n := range net.nodes {
+			block := n.block(1)
+			Expect(block.Header.Hash()).To(Equal(expected.Header.Hash()))
+			Expect(validSigners(net, block)).To(BeNumerically(">=", 3))
+			Expect(viewOf(block)).To(Equal(uint64(0)))
+		}
+	})
+
+	It("changes the view when the leader censors requests", func() {
+		// The leader of view 0 does not send its proposals
+		net.setDrop(func(from, to uint64, msg *bftprotos.Message) bool {
+			return from == 1 && msg.GetPrePrepare() != nil
+		})
+
+		Expect(net.nodes[2].chain.Order(newEnvelope(1), 0)).To(Succeed())
+		Eventually(func() uint64 {
+			net.tick()
+			return net.minHeight()
+		}, LongEventualTimeout).Should(Equal(uint64(2)))
+
+		for _, n := range net.nodes {
+			block := n.block(1)
+			Expect(block.Data.Data).To(HaveLen(1))
+			Expect(validSigners(net, block)).To(BeNumerically(">=", 3))
+			Expect(viewOf(block)).To(Equal(uint64(1)))
+		}
+
+		// The leader of view 1 keeps ordering transactions
+		Expect(net.nodes[3].chain.Order(newEnvelope(2), 0)).To(Succeed())
+		Eventually(net.minHeight, LongEventualTimeout).Should(Equal(uint64(3)))
+		Expect(viewOf(net.nodes[4].block(2))).To(Equal(uint64(1)))
+	})
+
+	It("catches up a consenter that missed blocks", func() {
+		net.disconnect(4)
+
+		Expect(net.nodes[1].chain.Order(newEnvelope(1), 0)).To(Succeed())
+		Eventually(net.nodes[3].height, LongEventualTimeout).Should(Equal(uint64(2)))
+		Expect(net.nodes[1].chain.Order(newEnvelope(2), 0)).To(Succeed())
+		Eventually(net.nodes[3].height, LongEventualTimeout).Should(Equal(uint64(3)))
+		Expect(net.nodes[4].height()).To(Equal(uint64(1)))
+
+		net.connect(4)
+		Expect(net.nodes[1].chain.Order(newEnvelope(3), 0)).To(Succeed())
+		Eventually(net.minHeight, LongEventualTimeout).Should(Equal(uint64(4)))
+
+		for number := uint64(1); number < 4; number++ {
+			Expect(net.nodes[4].block(number).Header.Hash()).To(Equal(net.nodes[1].block(number).Header.Hash()))
+			Expect(validSigners(net, net.nodes[4].block(number))).To(BeNumerically(">=", 3))
+		}
+	})
+
+	It("stops when halted", func() {
+		chain := net.nodes[1].chain
+		chain.Halt()
+		Eventually(chain.Errored()).Should(BeClosed())
+		Expect(chain.Order(newEnvelope(1), 0)).To(MatchError("chain is stopped"))
+		Expect(chain.WaitReady()).To(MatchError("chain is stopped"))
+	})
+})
+
+type node struct {
+	id      uint64
+	keyPair *tlsgen.CertKeyPair
+	clock   *fakeclock.FakeClock
+	support *consensusmocks.FakeConsenterSupport
+	chain   *bft.Chain
+
+	lock   sync.Mutex
+	ledger []*common.Block
+}
+
+func (n *node) height() uint64 {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return uint64(len(n.ledger))
+}
+
+func (n *node) block(number uint64) *common.Block {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if number >= uint64(len(n.ledger)) {
+		return nil
+	}
+	return proto.Clone(n.ledger[number]).(*common.Block)
+}
+
+func (n *node) write(block *common.Block, metadata []byte) {
+	block = proto.Clone(block).(*common.Block)
+	if metadata != nil {
+		block.Metadata.Metadata[common.BlockMetadataIndex_ORDERER] = utils.MarshalOrPanic(&common.Metadata{Value: metadata})
+	}
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.ledger = append(n.ledger, block)
+}
+
+func (n *node) createNextBlock(envs []*common.Envelope) *common.Block {
+	last := n.block(n.height() - 1)
+	data := &common.BlockData{}
+	for _, env := range envs {
+		data.Data = append(data.Data, utils.MarshalOrPanic(env))
+	}
+	block := common.NewBlock(last.Header.Number+1, last.Header.Hash())
+	block.Header.DataHash = data.Hash()
+	block.Data = data
+	return block
+}
+
+func (n *node) sign(msg []byte) ([]byte, error) {
+	digest := sha256.Sum256(msg)
+	signature, err := n.keyPair.Signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		return nil, err
+	}
+	return bccsputils.SignatureToLowS(n.keyPair.Signer.Public().(*ecdsa.PublicKey), signature)
+}
+
+func (n *node) newSignatureHeader() (*common.SignatureHeader, error) {
+	return &common.SignatureHeader{
+		Creator: utils.MarshalOrPanic(&msp.SerializedIdentity{Mspid: mspID, IdBytes: n.keyPair.Cert}),
+		Nonce:   []byte{byte(n.id)},
+	}, nil
+}
+
+func (n *node) newSupport(metadata []byte) *consensusmocks.FakeConsenterSupport {
+	support := &consensusmocks.FakeConsenterSupport{}
+	support.ChainIDReturns(channelID)
+	support.HeightStub = n.height
+	support.BlockStub = n.block
+	support.SharedConfigReturns(&mockconfig.Orderer{
+		ConsensusTypeVal:     bftprotos.TypeKey,
+		ConsensusMetadataVal: metadata,
+		BatchSizeVal: &orderer.BatchSize{
+			MaxMessageCount:   1,
+			AbsoluteMaxBytes:  10 * 1024 * 1024,
+			PreferredMaxBytes: 1024 * 1024,
+		},
+		BatchTimeoutVal: time.Second,
+	})
+	support.ClassifyMsgStub = func(chdr *common.ChannelHeader) msgprocessor.Classification {
+		if chdr.Type == int32(common.HeaderType_CONFIG) {
+			return msgprocessor.ConfigMsg
+		}
+		return msgprocessor.NormalMsg
+	}
+	support.CreateNextBlockStub = n.createNextBlock
+	support.WriteBlockStub = n.write
+	support.WriteConfigBlockStub = n.write
+	support.SignStub = n.sign
+	support.NewSignatureHeaderStub = n.newSignatureHeader
+	return support
+}
+
+// network connects the chains of its nodes with each other.
+type network struct {
+	lock         sync.RWMutex
+	nodes        map[uint64]*node
+	disconnected map[uint64]bool
+	drop         func(from, to uint64, msg *bftprotos.Message) bool
+}
+
+func newNetwork(size int) *network {
+	ca, err := tlsgen.NewCA()
+	Expect(err).NotTo(HaveOccurred())
+
+	net := &network{
+		nodes:        make(map[uint64]*node),
+		disconnected: make(map[uint64]bool),
+	}
+
+	var consenters []*bftprotos.Consenter
+	for id := uint64(1); id <= uint64(size); id++ {
+		keyPair, err := ca.NewServerCertKeyPair("127.0.0.1")
+		Expect(err).NotTo(HaveOccurred())
+		net.nodes[id] = &node{
+			id:      id,
+			keyPair: keyPair,
+			clock:   fakeclock.NewFakeClock(time.Now()),
+		}
+		consenters = append(consenters, &bftprotos.Consenter{
+			Id:            id,
+			Host:          "127.0.0.1",
+			Port:          uint32(7050 + id),
+			MspId:         mspID,
+			Identity:      keyPair.Cert,
+			ClientTlsCert: keyPair.Cert,
+			ServerTlsCert: keyPair.Cert,
+		})
+	}
+	metadata := utils.MarshalOrPanic(&bftprotos.ConfigMetadata{
+		Consenters: consenters,
+		Options:    &bftprotos.Options{RequestTimeout: "10s", ViewChangeTimeout: "20s"},
+	})
+
+	genesis := common.NewBlock(0, nil)
+	genesis.Header.DataHash = genesis.Data.Hash()
+
+	for id, n := range net.nodes {
+		n.ledger = []*common.Block{genesis}
+		n.support = n.newSupport(metadata)
+		opts := bft.Options{
+			ID:                id,
+			Clock:             n.clock,
+			Logger:            flogging.NewFabricLogger(zap.NewNop()),
+			TickInterval:      tickInterval,
+			RequestTimeout:    10 * time.Second,
+			ViewChangeTimeout: 20 * time.Second,
+			RequestPoolSize:   100,
+			Consenters:        consenters,
+		}
+		puller := &puller{self: id, net: net}
+		n.chain, err = bft.NewChain(n.support, opts, &configurator{}, &rpc{from: id, net: net}, func() (bft.BlockPuller, error) {
+			return puller, nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	return net
+}
+
+func (net *network) start() {
+	for _, n := range net.nodes {
+		n.chain.Start()
+	}
+}
+
+func (net *network) stop() {
+	for _, n := range net.nodes {
+		n.chain.Halt()
+	}
+}
+
+// tick advances the clocks of all nodes by a tick.
+func (net *network) tick() {
+	for _, n := range net.nodes {
+		n.clock.Increment(tickInterval)
+	}
+}
+
+func (net *network) minHeight() uint64 {
+	var min uint64
+	for _, n := range net.nodes {
+		if h := n.height(); min == 0 || h < min {
+			min = h
+		}
+	}
+	return min
+}
+
+func (net *network) setDrop(drop func(from, to uint64, msg *bftprotos.Message) bool) {
+	net.lock.Lock()
+	defer net.lock.Unlock()
+	net.drop = drop
+}
+
+func (net *network) disconnect(id uint64) {
+	net.lock.Lock()
+	defer net.lock.Unlock()
+	net.disconnected[id] = true
+}
+
+func (net *network) connect(id uint64) {
+	net.lock.Lock()
+	defer net.lock.Unlock()
+	delete(net.disconnected, id)
+}
+
+func (net *network) route(from, to uint64) (*node, error) {
+	net.lock.RLock()
+	defer net.lock.RUnlock()
+	if net.disconnected[from] || net.disconnected[to] {
+		return nil, errors.Errorf("%d is disconnected from %d", from, to)
+	}
+	return net.nodes[to], nil
+}
+
+func (net *network) dropped(from, to uint64, msg *bftprotos.Message) bool {
+	net.lock.RLock()
+	defer net.lock.RUnlock()
+	return net.drop != nil && net.drop(from, to, msg)
+}
+
+type rpc struct {
+	from uint64
+	net  *network
+}
+
+func (r *rpc) SendConsensus(dest uint64, req *orderer.ConsensusRequest) error {
+	to, err := r.net.route(r.from, dest)
+	if err != nil {
+		return err
+	}
+	msg := &bftprotos.Message{}
+	if err := proto.Unmarshal(req.Payload, msg); err != nil {
+		return err
+	}
+	if r.net.dropped(r.from, dest, msg) {
+		return nil
+	}
+	return to.chain.Consensus(req, r.from)
+}
+
+func (r *rpc) SendSubmit(dest uint64, req *orderer.SubmitRequest) error {
+	to, err := r.net.route(r.from, dest)
+	if err != nil {
+		return err
+	}
+	return to.chain.Submit(req, r.from)
+}
+
+type configurator struct{}
+
+func (*configurator) Configure(channel string, newNodes []cluster.RemoteNode) {}
+
+// puller pulls blocks from the ledgers of the nodes that are connected to the given node.
+type puller struct {
+	self uint64
+	net  *network
+}
+
+func (p *puller) PullBlock(seq uint64) *common.Block {
+	for id := range p.net.nodes {
+		n, err := p.net.route(p.self, id)
+		if id == p.self || err != nil {
+			continue
+		}
+		if block := n.block(seq); block != nil {
+			return block
+		}
+	}
+	return nil
+}
+
+func (p *puller) HeightsByEndpoints() (map[string]uint64, error) {
+	heights := make(map[string]uint64)
+	for id := range p.net.nodes {
+		n, err := p.net.route(p.self, id)
+		if id == p.self || err != nil {
+			continue
+		}
+		heights[fmt.Sprintf("127.0.0.1:%d", 7050+id)] = n.height()
+	}
+	return heights, nil
+}
+
+func (p *puller) Close() {}
+
+func newEnvelope(i int) *common.Envelope {
+	return &common.Envelope{
+		Payload: utils.MarshalOrPanic(&common.Payload{
+			Header: &common.Header{ChannelHeader: utils.MarshalOrPanic(&common.ChannelHeader{
+				Type:      int32(common.HeaderType_MESSAGE),
+				ChannelId: channelID,
+			})},
+			Data: []byte(fmt.Sprintf("tx-%d", i)),
+		}),
+	}
+}
+
+// validSigners returns the number of consenters whose signature over the block is valid.
+func validSigners(net *network, block *common.Block) int {
+	md, err := utils.GetMetadataFromBlock(block, common.BlockMetadataIndex_SIGNATURES)
+	Expect(err).NotTo(HaveOccurred())
+
+	signers := make(map[string]struct{})
+	for _, sig := range md.Signatures {
+		shdr, err := utils.GetSignatureHeader(sig.SignatureHeader)
+		Expect(err).NotTo(HaveOccurred())
+		creator := &msp.SerializedIdentity{}
+		Expect(proto.Unmarshal(shdr.Creator, creator)).To(Succeed())
+		bl, _ := pem.Decode(creator.IdBytes)
+		Expect(bl).NotTo(BeNil())
+		cert, err := x509.ParseCertificate(bl.Bytes)
+		Expect(err).NotTo(HaveOccurred())
+
+		r, s, err := bccsputils.UnmarshalECDSASignature(sig.Signature)
+		Expect(err).NotTo(HaveOccurred())
+		digest := sha256.Sum256(util.ConcatenateBytes(nil, sig.SignatureHeader, block.Header.Bytes()))
+		if ecdsa.Verify(cert.PublicKey.(*ecdsa.PublicKey), digest[:], r, s) {
+			signers[string(creator.IdBytes)] = struct{}{}
+		}
+	}
+	return len(signers)
+}
+
+func viewOf(block *common.Block) uint64 {
+	md, err := utils.GetMetadataFromBlock(block, common.BlockMetadataIndex_ORDERER)
+	Expect(err).NotTo(HaveOccurred())
+	blockMetadata := &bftprotos.BlockMetadata{}
+	Expect(proto.Unmarshal(md.Value, blockMetadata)).To(Succeed())
+	return blockMetadata.View
+}
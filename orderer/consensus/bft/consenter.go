@@ -0,0 +1,129 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"bytes"
+
+	"code.cloudfoundry.org/clock"
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/orderer/consensus/inactive"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/orderer/bft"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// Consenter implements the BFT consenter
+type Consenter struct {
+	Dialer        *cluster.PredicateDialer
+	Communication cluster.Communicator
+	Logger        *flogging.FabricLogger
+	OrdererConfig localconfig.TopLevel
+	Cert          []byte
+}
+
+// New creates a BFT Consenter, which shares the cluster communication
+// of the etcdraft consenter with the given dialer.
+func New(clusterDialer *cluster.PredicateDialer, conf *localconfig.TopLevel, cert []byte, comm cluster.Communicator) *Consenter {
+	return &Consenter{
+		Dialer:        clusterDialer,
+		Communication: comm,
+		Logger:        flogging.MustGetLogger("orderer.consensus.bft"),
+		OrdererConfig: *conf,
+		Cert:          cert,
+	}
+}
+
+func (c *Consenter) detectSelfID(consenters []*bft.Consenter) (uint64, error) {
+	var serverCertificates []string
+	for _, cst := range consenters {
+		serverCertificates = append(serverCertificates, string(cst.ServerTlsCert))
+		if bytes.Equal(c.Cert, cst.ServerTlsCert) {
+			return cst.Id, nil
+		}
+	}
+
+	c.Logger.Warning("Could not find", string(c.Cert), "among", serverCertificates)
+	return 0, cluster.ErrNotInChannel
+}
+
+// HandleChain returns a new Chain instance or an error upon failure
+func (c *Consenter) HandleChain(support consensus.ConsenterSupport, metadata *common.Metadata) (consensus.Chain, error) {
+	m := &bft.ConfigMetadata{}
+	if err := proto.Unmarshal(support.SharedConfig().ConsensusMetadata(), m); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal consensus metadata")
+	}
+	if err := CheckConfigMetadata(m); err != nil {
+		return nil, err
+	}
+	requestTimeout, viewChangeTimeout, err := timeouts(m.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	lastConfigBlock, err := lastConfigBlockFromSupport(support)
+	if err != nil {
+		return nil, err
+	}
+	env, err := utils.ExtractEnvelope(lastConfigBlock, 0)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to extract the envelope of the last config block")
+	}
+	config, err := configFromEnvelope(env)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to extract the config of the last config block")
+	}
+	if err := ValidateConfig(config); err != nil {
+		return nil, errors.WithMessage(err, "invalid config of BFT channel")
+	}
+
+	id, err := c.detectSelfID(m.Consenters)
+	if err != nil {
+		return &inactive.Chain{Err: errors.Errorf("channel %s is not serviced by me", support.ChainID())}, nil
+	}
+
+	blockMetadata := &bft.BlockMetadata{}
+	if metadata != nil && len(metadata.Value) != 0 {
+		if err := proto.Unmarshal(metadata.Value, blockMetadata); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal block's metadata")
+		}
+	}
+
+	opts := Options{
+		ID:     id,
+		Clock:  clock.NewClock(),
+		Logger: c.Logger,
+
+		TickInterval:      DefaultTickInterval,
+		RequestTimeout:    requestTimeout,
+		ViewChangeTimeout: viewChangeTimeout,
+		RequestPoolSize:   DefaultRequestPoolSize,
+
+		View:       blockMetadata.View,
+		Consenters: m.Consenters,
+	}
+
+	rpc := &cluster.RPC{
+		Timeout:       c.OrdererConfig.General.Cluster.RPCTimeout,
+		Logger:        c.Logger,
+		Channel:       support.ChainID(),
+		Comm:          c.Communication,
+		StreamsByType: cluster.NewStreamsByType(),
+	}
+	return NewChain(
+		support,
+		opts,
+		c.Communication,
+		rpc,
+		func() (BlockPuller, error) { return newBlockPuller(support, c.Dialer, c.OrdererConfig.General.Cluster) },
+	)
+}
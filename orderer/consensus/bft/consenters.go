@@ -0,0 +1,203 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/orderer/bft"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// consenter is a consenter of the channel, along with the key
+// its signatures are verified with.
+type consenter struct {
+	*bft.Consenter
+	identity []byte // DER encoded identity certificate
+	key      bccsp.Key
+}
+
+// consenterSet is the set of consenters of the channel, sorted by their IDs.
+type consenterSet struct {
+	ids  []uint64
+	byID map[uint64]*consenter
+}
+
+func newConsenterSet(consenters []*bft.Consenter) (*consenterSet, error) {
+	csp := factory.GetDefault()
+	set := &consenterSet{byID: make(map[uint64]*consenter)}
+	for _, c := range consenters {
+		cert, err := certFromPEM(c.Identity)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("invalid identity of consenter %d", c.Id))
+		}
+		key, err := csp.KeyImport(cert, &bccsp.X509PublicKeyImportOpts{Temporary: true})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed importing the public key of consenter %d", c.Id)
+		}
+		set.ids = append(set.ids, c.Id)
+		set.byID[c.Id] = &consenter{Consenter: c, identity: cert.Raw, key: key}
+	}
+	sort.Slice(set.ids, func(i, j int) bool { return set.ids[i] < set.ids[j] })
+	return set, nil
+}
+
+// quorum returns the number of consenters that must agree on a decision.
+func (s *consenterSet) quorum() int {
+	return bft.QuorumSize(len(s.ids))
+}
+
+// faulty returns the number of faulty consenters that are tolerated.
+func (s *consenterSet) faulty() int {
+	return bft.MaxFaulty(len(s.ids))
+}
+
+// leaderOf returns the ID of the leader of the given view.
+func (s *consenterSet) leaderOf(view uint64) uint64 {
+	return s.ids[view%uint64(len(s.ids))]
+}
+
+// contains returns whether the given ID is the ID of a consenter.
+func (s *consenterSet) contains(id uint64) bool {
+	_, exists := s.byID[id]
+	return exists
+}
+
+// verify verifies the signature of the consenter with the given ID over the given message.
+func (s *consenterSet) verify(id uint64, msg, signature []byte) error {
+	c, exists := s.byID[id]
+	if !exists {
+		return errors.Errorf("%d is not a consenter", id)
+	}
+	csp := factory.GetDefault()
+	digest, err := csp.Hash(msg, &bccsp.SHAOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed hashing message")
+	}
+	valid, err := csp.Verify(c.key, signature, digest, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed verifying signature of consenter %d", id)
+	}
+	if !valid {
+		return errors.Errorf("invalid signature of consenter %d", id)
+	}
+	return nil
+}
+
+// verifySigned verifies the given signed message, and unmarshals its payload into the given message.
+func (s *consenterSet) verifySigned(sm *bft.SignedMessage, msg proto.Message) error {
+	if sm == nil {
+		return errors.New("nil signed message")
+	}
+	if err := s.verify(sm.Signer, sm.Payload, sm.Signature); err != nil {
+		return err
+	}
+	return proto.Unmarshal(sm.Payload, msg)
+}
+
+// verifyBlockSignature verifies that the given signature over the given block header
+// was made by a consenter, and returns its ID.
+func (s *consenterSet) verifyBlockSignature(sig *common.MetadataSignature, header *common.BlockHeader) (uint64, error) {
+	if sig == nil || header == nil {
+		return 0, errors.New("missing signature or block header")
+	}
+	shdr, err := utils.GetSignatureHeader(sig.SignatureHeader)
+	if err != nil {
+		return 0, err
+	}
+	creator := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(shdr.Creator, creator); err != nil {
+		return 0, errors.Wrap(err, "failed unmarshaling creator of signature")
+	}
+	cert, err := certFromPEM(creator.IdBytes)
+	if err != nil {
+		return 0, errors.WithMessage(err, "invalid creator of signature")
+	}
+	for _, id := range s.ids {
+		c := s.byID[id]
+		if c.MspId != creator.Mspid || !bytes.Equal(c.identity, cert.Raw) {
+			continue
+		}
+		if err := s.verify(id, util.ConcatenateBytes(nil, sig.SignatureHeader, header.Bytes()), sig.Signature); err != nil {
+			return 0, err
+		}
+		return id, nil
+	}
+	return 0, errors.New("creator of signature is not a consenter")
+}
+
+// verifyDecision verifies that the given block header was signed by a quorum of the consenters.
+func (s *consenterSet) verifyDecision(header *common.BlockHeader, signatures []*common.MetadataSignature) error {
+	if header == nil {
+		return errors.New("missing block header")
+	}
+	// The genesis block is not signed
+	if header.Number == 0 {
+		return nil
+	}
+	signers := make(map[uint64]struct{})
+	for _, sig := range signatures {
+		if id, err := s.verifyBlockSignature(sig, header); err == nil {
+			signers[id] = struct{}{}
+		}
+	}
+	if len(signers) < s.quorum() {
+		return errors.Errorf("block %d is signed by %d consenters, but %d signatures are needed", header.Number, len(signers), s.quorum())
+	}
+	return nil
+}
+
+// remoteNodes returns the consenters other than the given one, as cluster members.
+func (s *consenterSet) remoteNodes(self uint64) ([]cluster.RemoteNode, error) {
+	var nodes []cluster.RemoteNode
+	for _, id := range s.ids {
+		// No need to know yourself
+		if id == self {
+			continue
+		}
+		c := s.byID[id]
+		serverCert, _ := pem.Decode(c.ServerTlsCert)
+		if serverCert == nil {
+			return nil, errors.Errorf("invalid PEM block of server TLS cert of consenter %d", id)
+		}
+		clientCert, _ := pem.Decode(c.ClientTlsCert)
+		if clientCert == nil {
+			return nil, errors.Errorf("invalid PEM block of client TLS cert of consenter %d", id)
+		}
+		nodes = append(nodes, cluster.RemoteNode{
+			ID:            id,
+			Endpoint:      fmt.Sprintf("%s:%d", c.Host, c.Port),
+			ServerTLSCert: serverCert.Bytes,
+			ClientTLSCert: clientCert.Bytes,
+		})
+	}
+	return nodes, nil
+}
+
+func certFromPEM(pemBytes []byte) (*x509.Certificate, error) {
+	bl, _ := pem.Decode(pemBytes)
+	if bl == nil {
+		return nil, errors.New("certificate is not PEM encoded")
+	}
+	cert, err := x509.ParseCertificate(bl.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed parsing certificate")
+	}
+	return cert, nil
+}
@@ -0,0 +1,86 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/protos/orderer"
+)
+
+// egressQueueSize is the number of messages that may wait to be sent to a consenter.
+const egressQueueSize = 1000
+
+// egress sends messages to the other consenters in the background, each through
+// its own queue, so that slow or faulty consenters do not hold back the chain.
+type egress struct {
+	rpc    RPC
+	logger *flogging.FabricLogger
+	queues map[uint64]chan proto.Message
+}
+
+func newEgress(rpc RPC, logger *flogging.FabricLogger) *egress {
+	return &egress{
+		rpc:    rpc,
+		logger: logger,
+		queues: make(map[uint64]chan proto.Message),
+	}
+}
+
+// configure starts sending to the given consenters, and stops sending to the others.
+func (e *egress) configure(ids []uint64) {
+	members := make(map[uint64]struct{})
+	for _, id := range ids {
+		members[id] = struct{}{}
+		if _, exists := e.queues[id]; !exists {
+			queue := make(chan proto.Message, egressQueueSize)
+			e.queues[id] = queue
+			go e.serve(id, queue)
+		}
+	}
+	for id, queue := range e.queues {
+		if _, exists := members[id]; !exists {
+			close(queue)
+			delete(e.queues, id)
+		}
+	}
+}
+
+// send enqueues the given ConsensusRequest or SubmitRequest to be sent to the given consenter.
+// The message is dropped if the queue of the consenter is full.
+func (e *egress) send(dest uint64, msg proto.Message) {
+	queue, exists := e.queues[dest]
+	if !exists {
+		e.logger.Warningf("Not sending message to %d, as it is not a consenter", dest)
+		return
+	}
+	select {
+	case queue <- msg:
+	default:
+		e.logger.Warningf("Dropping message to %d, as %d messages wait to be sent to it", dest, len(queue))
+	}
+}
+
+// stop stops sending messages.
+func (e *egress) stop() {
+	e.configure(nil)
+}
+
+func (e *egress) serve(dest uint64, queue chan proto.Message) {
+	for msg := range queue {
+		var err error
+		switch msg := msg.(type) {
+		case *orderer.ConsensusRequest:
+			err = e.rpc.SendConsensus(dest, msg)
+		case *orderer.SubmitRequest:
+			err = e.rpc.SendSubmit(dest, msg)
+		}
+		if err != nil {
+			e.logger.Debugf("Failed to send message to %d: %v", dest, err)
+		}
+	}
+}
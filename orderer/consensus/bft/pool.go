@@ -0,0 +1,136 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"time"
+
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// request is a request that waits to be ordered.
+type request struct {
+	digest    string
+	env       *common.Envelope
+	size      uint32
+	config    bool
+	configSeq uint64
+	arrival   time.Time
+}
+
+// requestPool holds the requests that wait to be ordered, in the order of their arrival.
+// The consenters keep track of the time each request waits, so that they detect
+// a leader that censors requests.
+type requestPool struct {
+	maxSize  int
+	requests *list.List
+	index    map[string]*list.Element
+
+	// The digests of recently ordered requests are kept, so that a request
+	// which was relayed by a slow consenter after it was ordered is not added again.
+	ordered      map[string]struct{}
+	orderedOrder []string
+}
+
+func newRequestPool(maxSize int) *requestPool {
+	return &requestPool{
+		maxSize:  maxSize,
+		requests: list.New(),
+		index:    make(map[string]*list.Element),
+		ordered:  make(map[string]struct{}),
+	}
+}
+
+func digest(data []byte) string {
+	hash := sha256.Sum256(data)
+	return string(hash[:])
+}
+
+// add adds the given request to the pool, unless it is already in the pool
+// or was recently ordered, in which case false is returned.
+func (p *requestPool) add(env *common.Envelope, config bool, configSeq uint64, now time.Time) (bool, error) {
+	data := utils.MarshalOrPanic(env)
+	d := digest(data)
+	if _, exists := p.index[d]; exists {
+		return false, nil
+	}
+	if _, exists := p.ordered[d]; exists {
+		return false, nil
+	}
+	if p.requests.Len() >= p.maxSize {
+		return false, errors.Errorf("request pool is full (%d requests)", p.maxSize)
+	}
+	p.index[d] = p.requests.PushBack(&request{
+		digest:    d,
+		env:       env,
+		size:      uint32(len(data)),
+		config:    config,
+		configSeq: configSeq,
+		arrival:   now,
+	})
+	return true, nil
+}
+
+// remove removes the request with the given digest from the pool.
+func (p *requestPool) remove(d string) {
+	if e, exists := p.index[d]; exists {
+		p.requests.Remove(e)
+		delete(p.index, d)
+	}
+}
+
+// removeOrdered removes the requests in the given block data from the pool,
+// and remembers them as ordered.
+func (p *requestPool) removeOrdered(data *common.BlockData) {
+	for _, tx := range data.GetData() {
+		d := digest(tx)
+		p.remove(d)
+		if _, exists := p.ordered[d]; exists {
+			continue
+		}
+		p.ordered[d] = struct{}{}
+		p.orderedOrder = append(p.orderedOrder, d)
+	}
+	for len(p.orderedOrder) > p.maxSize {
+		delete(p.ordered, p.orderedOrder[0])
+		p.orderedOrder = p.orderedOrder[1:]
+	}
+}
+
+// oldest returns the request that waits the longest, or nil if the pool is empty.
+func (p *requestPool) oldest() *request {
+	if e := p.requests.Front(); e != nil {
+		return e.Value.(*request)
+	}
+	return nil
+}
+
+// size returns the number of requests in the pool.
+func (p *requestPool) size() int {
+	return p.requests.Len()
+}
+
+// pending returns the requests in the order of their arrival.
+func (p *requestPool) pending() []*request {
+	requests := make([]*request, 0, p.requests.Len())
+	for e := p.requests.Front(); e != nil; e = e.Next() {
+		requests = append(requests, e.Value.(*request))
+	}
+	return requests
+}
+
+// restartTimers restarts the time the requests wait, which is done when
+// a new view is installed, so that its leader has the time to order them.
+func (p *requestPool) restartTimers(now time.Time) {
+	for e := p.requests.Front(); e != nil; e = e.Next() {
+		e.Value.(*request).arrival = now
+	}
+}
@@ -0,0 +1,61 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestPool(t *testing.T) {
+	envelope := func(i int) *common.Envelope {
+		return &common.Envelope{Payload: []byte(fmt.Sprintf("tx-%d", i))}
+	}
+	now := time.Now()
+
+	pool := newRequestPool(2)
+	added, err := pool.add(envelope(1), false, 0, now)
+	assert.NoError(t, err)
+	assert.True(t, added)
+
+	// Duplicate requests are not added
+	added, err = pool.add(envelope(1), false, 0, now)
+	assert.NoError(t, err)
+	assert.False(t, added)
+
+	added, err = pool.add(envelope(2), true, 1, now.Add(time.Second))
+	assert.NoError(t, err)
+	assert.True(t, added)
+
+	_, err = pool.add(envelope(3), false, 0, now)
+	assert.EqualError(t, err, "request pool is full (2 requests)")
+
+	assert.Equal(t, 2, pool.size())
+	assert.Equal(t, envelope(1).Payload, pool.oldest().env.Payload)
+	assert.True(t, pool.pending()[1].config)
+	assert.Equal(t, uint64(1), pool.pending()[1].configSeq)
+
+	// Ordered requests are removed, and are not added again
+	pool.removeOrdered(&common.BlockData{Data: [][]byte{utils.MarshalOrPanic(envelope(1))}})
+	assert.Equal(t, 1, pool.size())
+	assert.Equal(t, envelope(2).Payload, pool.oldest().env.Payload)
+	added, err = pool.add(envelope(1), false, 0, now)
+	assert.NoError(t, err)
+	assert.False(t, added)
+
+	later := now.Add(time.Minute)
+	pool.restartTimers(later)
+	assert.Equal(t, later, pool.oldest().arrival)
+
+	pool.remove(pool.oldest().digest)
+	assert.Nil(t, pool.oldest())
+}
@@ -0,0 +1,229 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/configtx"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/orderer/bft"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// blockValidationPolicyKey is the key of the block validation policy in the orderer group.
+const blockValidationPolicyKey = "BlockValidation"
+
+// CheckConfigMetadata validates the BFT configuration of a channel.
+func CheckConfigMetadata(md *bft.ConfigMetadata) error {
+	if md == nil || md.Options == nil {
+		return errors.New("BFT options have not been provided")
+	}
+	if _, _, err := timeouts(md.Options); err != nil {
+		return err
+	}
+	if len(md.Consenters) == 0 {
+		return errors.New("BFT consenters have not been provided")
+	}
+
+	ids := make(map[uint64]struct{})
+	for _, c := range md.Consenters {
+		if c.Id == 0 {
+			return errors.New("consenter ID must not be 0")
+		}
+		if _, exists := ids[c.Id]; exists {
+			return errors.Errorf("consenter ID %d is not unique", c.Id)
+		}
+		ids[c.Id] = struct{}{}
+		if c.MspId == "" {
+			return errors.Errorf("MSP ID of consenter %d has not been provided", c.Id)
+		}
+		if _, err := certFromPEM(c.Identity); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("invalid identity of consenter %d", c.Id))
+		}
+		if bl, _ := pem.Decode(c.ClientTlsCert); bl == nil {
+			return errors.Errorf("client TLS cert of consenter %d is not PEM encoded", c.Id)
+		}
+		if bl, _ := pem.Decode(c.ServerTlsCert); bl == nil {
+			return errors.Errorf("server TLS cert of consenter %d is not PEM encoded", c.Id)
+		}
+	}
+	return nil
+}
+
+// timeouts parses the request and view change timeouts of the given options.
+func timeouts(options *bft.Options) (time.Duration, time.Duration, error) {
+	requestTimeout, err := time.ParseDuration(options.RequestTimeout)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "invalid request timeout")
+	}
+	if requestTimeout <= 0 {
+		return 0, 0, errors.Errorf("request timeout must be positive, but is %s", options.RequestTimeout)
+	}
+	viewChangeTimeout, err := time.ParseDuration(options.ViewChangeTimeout)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "invalid view change timeout")
+	}
+	if viewChangeTimeout <= 0 {
+		return 0, 0, errors.Errorf("view change timeout must be positive, but is %s", options.ViewChangeTimeout)
+	}
+	return requestTimeout, viewChangeTimeout, nil
+}
+
+// ValidateConfig validates that the given channel config is a valid config of a BFT channel,
+// and that its block validation policy requires the blocks to be signed by a quorum of its consenters.
+func ValidateConfig(config *common.Config) error {
+	ordererGroup, exists := config.GetChannelGroup().GetGroups()[channelconfig.OrdererGroupKey]
+	if !exists {
+		return errors.New("config has no orderer group")
+	}
+
+	consensusTypeValue, exists := ordererGroup.Values[channelconfig.ConsensusTypeKey]
+	if !exists {
+		return errors.New("config has no consensus type")
+	}
+	consensusType := &orderer.ConsensusType{}
+	if err := proto.Unmarshal(consensusTypeValue.Value, consensusType); err != nil {
+		return errors.Wrap(err, "failed to unmarshal consensus type")
+	}
+	if consensusType.Type != bft.TypeKey {
+		return errors.Errorf("consensus type of BFT channels cannot be changed to %s", consensusType.Type)
+	}
+
+	md := &bft.ConfigMetadata{}
+	if err := proto.Unmarshal(consensusType.Metadata, md); err != nil {
+		return errors.Wrap(err, "failed to unmarshal BFT metadata")
+	}
+	if err := CheckConfigMetadata(md); err != nil {
+		return err
+	}
+
+	policy, exists := ordererGroup.Policies[blockValidationPolicyKey]
+	if !exists || !sameSignaturePolicy(policy.Policy, bft.BlockValidationPolicy(md.Consenters)) {
+		return errors.New("block validation policy must require the signatures of a quorum of the consenters")
+	}
+	return nil
+}
+
+func sameSignaturePolicy(policy, expected *common.Policy) bool {
+	if policy == nil || policy.Type != expected.Type {
+		return false
+	}
+	envelope := &common.SignaturePolicyEnvelope{}
+	if err := proto.Unmarshal(policy.Value, envelope); err != nil {
+		return false
+	}
+	expectedEnvelope := &common.SignaturePolicyEnvelope{}
+	if err := proto.Unmarshal(expected.Value, expectedEnvelope); err != nil {
+		return false
+	}
+	return proto.Equal(envelope, expectedEnvelope)
+}
+
+// configFromEnvelope extracts the channel config from the given config envelope,
+// i.e. HeaderType_ORDERER_TRANSACTION or HeaderType_CONFIG
+func configFromEnvelope(env *common.Envelope) (*common.Config, error) {
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return nil, err
+	}
+	if payload.Header == nil {
+		return nil, errors.New("missing header in payload")
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	switch chdr.Type {
+	case int32(common.HeaderType_ORDERER_TRANSACTION):
+		configEnv, err := utils.UnmarshalEnvelope(payload.Data)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal config envelope for orderer type transaction")
+		}
+		return configFromEnvelope(configEnv)
+	case int32(common.HeaderType_CONFIG):
+		configEnv, err := configtx.UnmarshalConfigEnvelope(payload.Data)
+		if err != nil {
+			return nil, err
+		}
+		return configEnv.Config, nil
+	default:
+		return nil, errors.Errorf("unexpected header type: %v", chdr.Type)
+	}
+}
+
+func lastConfigBlockFromSupport(support consensus.ConsenterSupport) (*common.Block, error) {
+	lastBlockSeq := support.Height() - 1
+	lastBlock := support.Block(lastBlockSeq)
+	if lastBlock == nil {
+		return nil, errors.Errorf("unable to retrieve block %d", lastBlockSeq)
+	}
+	return cluster.LastConfigBlock(lastBlock, support)
+}
+
+// newBlockPuller creates a new block puller
+func newBlockPuller(support consensus.ConsenterSupport,
+	baseDialer *cluster.PredicateDialer,
+	clusterConfig localconfig.Cluster) (*cluster.BlockPuller, error) {
+
+	verifyBlockSequence := func(blocks []*common.Block, _ string) error {
+		return cluster.VerifyBlocks(blocks, support)
+	}
+
+	secureConfig, err := baseDialer.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	secureConfig.AsyncConnect = false
+	stdDialer := &cluster.StandardDialer{
+		Dialer: cluster.NewTLSPinningDialer(secureConfig),
+	}
+
+	// Extract the TLS CA certs and endpoints from the configuration,
+	lastConfigBlock, err := lastConfigBlockFromSupport(support)
+	if err != nil {
+		return nil, err
+	}
+	endpointConfig, err := cluster.EndpointconfigFromConfigBlock(lastConfigBlock)
+	if err != nil {
+		return nil, err
+	}
+	// and overwrite them.
+	secureConfig.SecOpts.ServerRootCAs = endpointConfig.TLSRootCAs
+	stdDialer.Dialer.SetConfig(secureConfig)
+
+	der, _ := pem.Decode(secureConfig.SecOpts.Certificate)
+	if der == nil {
+		return nil, errors.Errorf("client certificate isn't in PEM format: %v",
+			string(secureConfig.SecOpts.Certificate))
+	}
+
+	return &cluster.BlockPuller{
+		VerifyBlockSequence: verifyBlockSequence,
+		Logger:              flogging.MustGetLogger("orderer.common.cluster.puller"),
+		RetryTimeout:        clusterConfig.ReplicationRetryTimeout,
+		MaxTotalBufferBytes: clusterConfig.ReplicationBufferSize,
+		FetchTimeout:        clusterConfig.ReplicationPullTimeout,
+		MaxPullBlockRetries: uint64(clusterConfig.ReplicationMaxRetries),
+		Endpoints:           endpointConfig.Endpoints,
+		Signer:              support,
+		TLSCert:             der.Bytes,
+		Channel:             support.ChainID(),
+		Dialer:              stdDialer,
+	}, nil
+}
@@ -0,0 +1,128 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/orderer/bft"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckConfigMetadata(t *testing.T) {
+	ca, err := tlsgen.NewCA()
+	assert.NoError(t, err)
+	kp, err := ca.NewServerCertKeyPair("127.0.0.1")
+	assert.NoError(t, err)
+
+	validMetadata := func() *bft.ConfigMetadata {
+		return &bft.ConfigMetadata{
+			Consenters: []*bft.Consenter{
+				{Id: 1, MspId: "SampleOrg", Identity: kp.Cert, ClientTlsCert: kp.Cert, ServerTlsCert: kp.Cert},
+				{Id: 2, MspId: "SampleOrg", Identity: kp.Cert, ClientTlsCert: kp.Cert, ServerTlsCert: kp.Cert},
+			},
+			Options: &bft.Options{RequestTimeout: "10s", ViewChangeTimeout: "20s"},
+		}
+	}
+
+	for _, testCase := range []struct {
+		name          string
+		mutate        func(*bft.ConfigMetadata)
+		expectedError string
+	}{
+		{
+			name:   "valid",
+			mutate: func(*bft.ConfigMetadata) {},
+		},
+		{
+			name:          "missing options",
+			mutate:        func(md *bft.ConfigMetadata) { md.Options = nil },
+			expectedError: "BFT options have not been provided",
+		},
+		{
+			name:          "invalid request timeout",
+			mutate:        func(md *bft.ConfigMetadata) { md.Options.RequestTimeout = "-1s" },
+			expectedError: "request timeout must be positive, but is -1s",
+		},
+		{
+			name:          "no consenters",
+			mutate:        func(md *bft.ConfigMetadata) { md.Consenters = nil },
+			expectedError: "BFT consenters have not been provided",
+		},
+		{
+			name:          "duplicate ID",
+			mutate:        func(md *bft.ConfigMetadata) { md.Consenters[1].Id = 1 },
+			expectedError: "consenter ID 1 is not unique",
+		},
+		{
+			name:          "invalid identity",
+			mutate:        func(md *bft.ConfigMetadata) { md.Consenters[1].Identity = []byte("identity") },
+			expectedError: "invalid identity of consenter 2: certificate is not PEM encoded",
+		},
+		{
+			name:          "invalid server TLS cert",
+			mutate:        func(md *bft.ConfigMetadata) { md.Consenters[0].ServerTlsCert = nil },
+			expectedError: "server TLS cert of consenter 1 is not PEM encoded",
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			md := validMetadata()
+			testCase.mutate(md)
+			err := CheckConfigMetadata(md)
+			if testCase.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, testCase.expectedError)
+		})
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	ca, err := tlsgen.NewCA()
+	assert.NoError(t, err)
+	kp, err := ca.NewServerCertKeyPair("127.0.0.1")
+	assert.NoError(t, err)
+
+	md := &bft.ConfigMetadata{
+		Consenters: []*bft.Consenter{
+			{Id: 1, MspId: "SampleOrg", Identity: kp.Cert, ClientTlsCert: kp.Cert, ServerTlsCert: kp.Cert},
+		},
+		Options: &bft.Options{RequestTimeout: "10s", ViewChangeTimeout: "20s"},
+	}
+	config := func(consensusType string, policy *common.Policy) *common.Config {
+		ordererGroup := &common.ConfigGroup{
+			Values: map[string]*common.ConfigValue{
+				channelconfig.ConsensusTypeKey: {
+					Value: utils.MarshalOrPanic(&orderer.ConsensusType{Type: consensusType, Metadata: utils.MarshalOrPanic(md)}),
+				},
+			},
+			Policies: map[string]*common.ConfigPolicy{},
+		}
+		if policy != nil {
+			ordererGroup.Policies[blockValidationPolicyKey] = &common.ConfigPolicy{Policy: policy}
+		}
+		return &common.Config{
+			ChannelGroup: &common.ConfigGroup{
+				Groups: map[string]*common.ConfigGroup{channelconfig.OrdererGroupKey: ordererGroup},
+			},
+		}
+	}
+
+	assert.NoError(t, ValidateConfig(config(bft.TypeKey, bft.BlockValidationPolicy(md.Consenters))))
+	assert.EqualError(t, ValidateConfig(config("etcdraft", bft.BlockValidationPolicy(md.Consenters))),
+		"consensus type of BFT channels cannot be changed to etcdraft")
+	assert.EqualError(t, ValidateConfig(config(bft.TypeKey, nil)),
+		"block validation policy must require the signatures of a quorum of the consenters")
+	assert.EqualError(t, ValidateConfig(&common.Config{ChannelGroup: &common.ConfigGroup{}}),
+		"config has no orderer group")
+}
@@ -0,0 +1,258 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"bytes"
+
+	"github.com/hyperledger/fabric/protos/orderer/bft"
+	"github.com/pkg/errors"
+)
+
+// vote votes to change the view to the given view.
+func (c *Chain) vote(view uint64) {
+	if view <= c.voted {
+		return
+	}
+	c.logger.Infof("Voting to change the view from %d to %d", c.view, view)
+	c.voted = view
+	c.votes[c.id] = view
+	c.broadcast(&bft.Message{Content: &bft.Message_ViewChange{ViewChange: &bft.ViewChange{NextView: view}}})
+	c.checkVotes()
+}
+
+func (c *Chain) onViewChange(sender uint64, vc *bft.ViewChange) {
+	if vc.NextView <= c.view || vc.NextView <= c.votes[sender] {
+		return
+	}
+	c.votes[sender] = vc.NextView
+	c.checkVotes()
+}
+
+// checkVotes joins a view change once f+1 consenters voted for it, as at least one
+// of them is correct, and starts the view change once a quorum of the consenters voted for it.
+func (c *Chain) checkVotes() {
+	var join, start uint64
+	for _, v := range c.votes {
+		if v <= c.view {
+			continue
+		}
+		count := 0
+		for _, other := range c.votes {
+			if other >= v {
+				count++
+			}
+		}
+		if count > c.consenters.faulty() && v > join {
+			join = v
+		}
+		if count >= c.consenters.quorum() && v > start {
+			start = v
+		}
+	}
+
+	if join > c.voted {
+		c.vote(join)
+		return
+	}
+	if start > c.view && (!c.changing || start > c.nextView) {
+		c.startViewChange(start)
+	}
+}
+
+// startViewChange stops taking part in the current view, and sends the state of this node
+// to the leader of the next view.
+func (c *Chain) startViewChange(view uint64) {
+	c.logger.Infof("Changing the view from %d to %d", c.view, view)
+	c.changing = true
+	c.nextView = view
+	c.viewChangeStart = c.clock.Now()
+	if c.voted < view {
+		c.voted = view
+	}
+	c.resetDecision()
+	c.future = nil
+	c.futureTicks = 0
+
+	vd := &bft.ViewData{
+		NextView:               view,
+		LastDecision:           c.lastHeader,
+		LastDecisionSignatures: c.lastSignatures,
+	}
+	if c.preparedCert != nil && c.preparedCert.prePrepare.Seq == c.seq {
+		vd.Prepared = c.preparedCert.prePrepare
+		vd.Prepares = c.preparedCert.prepares
+	}
+	sm := c.sign(vd)
+
+	leader := c.consenters.leaderOf(view)
+	if leader == c.id {
+		c.onViewData(c.id, sm)
+		return
+	}
+	c.send(leader, &bft.Message{Content: &bft.Message_ViewData{ViewData: sm}})
+}
+
+// onViewData collects the state of the consenters, and once a quorum of them
+// sent it, the new view is announced by this node, the leader of the new view.
+func (c *Chain) onViewData(sender uint64, sm *bft.SignedMessage) {
+	vd := &bft.ViewData{}
+	if sm.Signer != sender {
+		c.logger.Warningf("Ignoring view data of %d sent by %d", sm.Signer, sender)
+		return
+	}
+	if err := c.consenters.verifySigned(sm, vd); err != nil {
+		c.logger.Warningf("Ignoring view data of %d: %v", sender, err)
+		return
+	}
+	if vd.NextView <= c.view || vd.NextView <= c.newViewSent || c.consenters.leaderOf(vd.NextView) != c.id {
+		return
+	}
+
+	viewData, exists := c.viewData[vd.NextView]
+	if !exists {
+		viewData = make(map[uint64]*bft.SignedMessage)
+		c.viewData[vd.NextView] = viewData
+	}
+	viewData[sender] = sm
+	if len(viewData) < c.consenters.quorum() {
+		return
+	}
+
+	nv := &bft.NewView{View: vd.NextView}
+	for _, sm := range viewData {
+		nv.ViewData = append(nv.ViewData, sm)
+	}
+	sortSignedMessages(nv.ViewData)
+
+	c.logger.Infof("Announcing view %d with the view data of %d consenters", nv.View, len(nv.ViewData))
+	c.newViewSent = nv.View
+	c.broadcast(&bft.Message{Content: &bft.Message_NewView{NewView: nv}})
+	c.onNewView(c.id, nv)
+}
+
+// onNewView installs the view announced by its leader, catches up with the latest decision
+// the consenters made, and mandates the proposal that may have been committed in an earlier view.
+func (c *Chain) onNewView(sender uint64, nv *bft.NewView) {
+	if nv.View <= c.view {
+		return
+	}
+	if sender != c.consenters.leaderOf(nv.View) {
+		c.logger.Warningf("Ignoring new view %d announced by %d, as it is not its leader", nv.View, sender)
+		return
+	}
+
+	viewData, err := c.verifyNewView(nv)
+	if err != nil {
+		c.logger.Warningf("Ignoring new view %d announced by %d: %v", nv.View, sender, err)
+		return
+	}
+
+	var maxDecision uint64
+	var mandated *bft.PrePrepare
+	for _, vd := range viewData {
+		if vd.LastDecision == nil || c.consenters.verifyDecision(vd.LastDecision, vd.LastDecisionSignatures) != nil {
+			continue
+		}
+		if vd.LastDecision.Number > maxDecision {
+			maxDecision = vd.LastDecision.Number
+		}
+		if vd.Prepared == nil {
+			continue
+		}
+		if err := c.verifyPrepared(vd); err != nil {
+			c.logger.Warningf("Ignoring prepared proposal in view data for view %d: %v", nv.View, err)
+			continue
+		}
+		if mandated == nil || vd.Prepared.Seq > mandated.Seq || (vd.Prepared.Seq == mandated.Seq && vd.Prepared.View > mandated.View) {
+			mandated = vd.Prepared
+		}
+	}
+
+	c.installView(nv.View)
+	c.catchUp(maxDecision + 1)
+	if mandated != nil && mandated.Seq == c.seq {
+		c.mandated = mandated.Proposal
+	}
+	c.propose()
+}
+
+// verifyNewView verifies that the given new view carries the view data of a quorum of the consenters.
+func (c *Chain) verifyNewView(nv *bft.NewView) ([]*bft.ViewData, error) {
+	signers := make(map[uint64]struct{})
+	var viewData []*bft.ViewData
+	for _, sm := range nv.ViewData {
+		vd := &bft.ViewData{}
+		if err := c.consenters.verifySigned(sm, vd); err != nil {
+			return nil, err
+		}
+		if vd.NextView != nv.View {
+			return nil, errors.Errorf("view data of %d is for view %d", sm.Signer, vd.NextView)
+		}
+		if _, exists := signers[sm.Signer]; exists {
+			return nil, errors.Errorf("duplicate view data of %d", sm.Signer)
+		}
+		signers[sm.Signer] = struct{}{}
+		viewData = append(viewData, vd)
+	}
+	if len(signers) < c.consenters.quorum() {
+		return nil, errors.Errorf("view data of %d consenters, but %d are needed", len(signers), c.consenters.quorum())
+	}
+	return viewData, nil
+}
+
+// verifyPrepared verifies that the prepared proposal in the given view data
+// was accepted by a quorum of the consenters.
+func (c *Chain) verifyPrepared(vd *bft.ViewData) error {
+	pp := vd.Prepared
+	if pp.Proposal == nil || pp.Proposal.Header == nil || pp.Proposal.Data == nil {
+		return errors.New("prepared proposal is not a block")
+	}
+	if pp.Seq != vd.LastDecision.Number+1 || pp.Proposal.Header.Number != pp.Seq {
+		return errors.Errorf("prepared proposal of block %d does not follow the last decision %d", pp.Seq, vd.LastDecision.Number)
+	}
+	if !bytes.Equal(pp.Proposal.Header.DataHash, pp.Proposal.Data.Hash()) {
+		return errors.New("data hash of the prepared proposal does not match its data")
+	}
+
+	digest := pp.Proposal.Header.Hash()
+	signers := make(map[uint64]struct{})
+	for _, sm := range vd.Prepares {
+		prepare := &bft.Prepare{}
+		if err := c.consenters.verifySigned(sm, prepare); err != nil {
+			return err
+		}
+		if prepare.View != pp.View || prepare.Seq != pp.Seq || !bytes.Equal(prepare.Digest, digest) {
+			return errors.Errorf("prepare of %d does not match the prepared proposal", sm.Signer)
+		}
+		signers[sm.Signer] = struct{}{}
+	}
+	if len(signers) < c.consenters.quorum() {
+		return errors.Errorf("prepared proposal is accepted by %d consenters, but %d are needed", len(signers), c.consenters.quorum())
+	}
+	return nil
+}
+
+// installView moves on to the given view.
+func (c *Chain) installView(view uint64) {
+	c.logger.Infof("Installing view %d, led by %d", view, c.consenters.leaderOf(view))
+	c.view = view
+	c.changing = false
+	if c.voted < view {
+		c.voted = view
+	}
+	c.pool.restartTimers(c.clock.Now())
+	c.resetDecision()
+	c.mandated = nil
+	c.future = nil
+	c.futureTicks = 0
+	for v := range c.viewData {
+		if v <= view {
+			delete(c.viewData, v)
+		}
+	}
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
+	"github.com/hyperledger/fabric/orderer/common/types"
+	"github.com/hyperledger/fabric/orderer/consensus/migration"
 	cb "github.com/hyperledger/fabric/protos/common"
 )
 
@@ -67,6 +69,28 @@ type Chain interface {
 	Halt()
 }
 
+// StatusReporter is implemented by chains that report their relation to the
+// consensus protocol of the channel, and the status of the orderer in it.
+// Chains that do not implement it are considered active consenters.
+type StatusReporter interface {
+	// StatusReport provides the cluster relation and status.
+	StatusReport() (types.ConsensusRelation, types.Status)
+}
+
+// MigrationStatusReporter is implemented by chains whose consensus type may be migrated to Raft,
+// i.e. Kafka and Solo chains, which track the consensus-type migration status of their channel.
+type MigrationStatusReporter interface {
+	// MigrationStatus provides the consensus-type migration status of the chain.
+	MigrationStatus() migration.Status
+}
+
+// StorageRemover is implemented by chains that persist state of their own besides the ledger.
+// When the orderer leaves a channel, its chain is halted, and then its state is removed.
+type StorageRemover interface {
+	// RemoveStorage removes the state persisted by the halted chain.
+	RemoveStorage() error
+}
+
 //go:generate counterfeiter -o mocks/mock_consenter_support.go . ConsenterSupport
 
 // ConsenterSupport provides the resources available to a Consenter implementation.
@@ -106,4 +130,7 @@ type ConsenterSupport interface {
 
 	// Height returns the number of blocks in the chain this channel is associated with.
 	Height() uint64
+
+	// IsSystemChannel returns true if this is the system channel.
+	IsSystemChannel() bool
 }
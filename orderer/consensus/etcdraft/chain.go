@@ -10,6 +10,7 @@ import (
 	"context"
 	"encoding/pem"
 	"fmt"
+	"os"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -21,6 +22,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/configtx"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	"github.com/hyperledger/fabric/orderer/common/cluster"
 	"github.com/hyperledger/fabric/orderer/consensus"
 	"github.com/hyperledger/fabric/protos/common"
@@ -102,6 +104,13 @@ type Options struct {
 	MaxInflightMsgs int
 
 	RaftMetadata *etcdraft.RaftMetadata
+
+	Metrics *Metrics
+
+	// MigrationInit is set when the chain starts right after its consensus-type migration to Raft.
+	// The fresh Raft node then starts the cluster with the consenters of the Raft metadata,
+	// rather than joining an existing one, although the chain has blocks.
+	MigrationInit bool
 }
 
 type submit struct {
@@ -138,9 +147,11 @@ type Chain struct {
 
 	raftMetadataLock     sync.RWMutex
 	confChangeInProgress *raftpb.ConfChange
-	justElected          bool // this is true when node has just been elected
-	configInflight       bool // this is true when there is config block or ConfChange in flight
-	blockInflight        int  // number of in flight blocks
+	catchUpNode          uint64 // node added by the last ConfChange that must catch up before the next ConfChange is proposed
+	catchUpIndex         uint64 // raft index of the ConfChange that added catchUpNode
+	justElected          bool   // this is true when node has just been elected
+	configInflight       bool   // this is true when there is config block or ConfChange in flight
+	blockInflight        int    // number of in flight blocks
 
 	clock clock.Clock // Tests can inject a fake clock
 
@@ -161,7 +172,8 @@ type Chain struct {
 	node *node
 	opts Options
 
-	logger *flogging.FabricLogger
+	metrics *Metrics
+	logger  *flogging.FabricLogger
 }
 
 // NewChain constructs a chain object.
@@ -220,10 +232,19 @@ func NewChain(
 		lastSnapBlockNum: snapBlkNum,
 		createPuller:     f,
 		clock:            opts.Clock,
+		metrics:          opts.Metrics.forChannel(support.ChainID()),
 		logger:           lg,
 		opts:             opts,
 	}
 
+	// Sets initial values for metrics
+	c.metrics.ClusterSize.Set(float64(len(c.opts.RaftMetadata.Consenters)))
+	c.metrics.IsLeader.Set(float64(0)) // all nodes start out as followers
+	c.metrics.LeaderID.Set(float64(raft.None))
+	c.metrics.CommittedBlockNumber.Set(float64(c.support.Height() - 1))
+	c.metrics.SnapshotBlockNumber.Set(float64(c.lastSnapBlockNum))
+	c.metrics.PendingConfChanges.Set(float64(0))
+
 	// DO NOT use Applied option in config, see https://github.com/etcd-io/etcd/issues/10217
 	// We guard against replay of written blocks in `entriesToApply` instead.
 	config := &raft.Config{
@@ -251,6 +272,8 @@ func NewChain(
 		tickInterval: c.opts.TickInterval,
 		clock:        c.clock,
 		metadata:     c.opts.RaftMetadata,
+		metrics:      c.metrics,
+		subscriberC:  make(chan chan uint64),
 	}
 
 	return c, nil
@@ -266,7 +289,12 @@ func (c *Chain) Start() {
 		return
 	}
 
-	c.node.start(c.fresh, c.support.Height() > 1)
+	isJoin := c.support.Height() > 1
+	if isJoin && c.opts.MigrationInit {
+		c.logger.Infof("Consensus-type migration detected, starting a new Raft cluster on an existing channel; height=%d", c.support.Height())
+		isJoin = false
+	}
+	c.node.start(c.fresh, isJoin)
 	close(c.startC)
 	close(c.errorC)
 
@@ -346,7 +374,8 @@ func (c *Chain) Errored() <-chan struct{} {
 	return c.errorC
 }
 
-// Halt stops the chain.
+// Halt stops the chain. If this node is the Raft leader, it first transfers
+// the leadership to the most up-to-date follower.
 func (c *Chain) Halt() {
 	select {
 	case <-c.startC:
@@ -355,6 +384,8 @@ func (c *Chain) Halt() {
 		return
 	}
 
+	c.node.abdicateLeader()
+
 	select {
 	case c.haltC <- struct{}{}:
 	case <-c.doneC:
@@ -363,6 +394,60 @@ func (c *Chain) Halt() {
 	<-c.doneC
 }
 
+// RemoveStorage removes the WAL and the snapshots of the halted chain,
+// so that this node can join the channel again as a fresh Raft node.
+func (c *Chain) RemoveStorage() error {
+	if err := os.RemoveAll(c.opts.WALDir); err != nil {
+		return errors.Wrapf(err, "failed removing WAL dir %s", c.opts.WALDir)
+	}
+	if err := os.RemoveAll(c.opts.SnapDir); err != nil {
+		return errors.Wrapf(err, "failed removing snapshot dir %s", c.opts.SnapDir)
+	}
+	return nil
+}
+
+// TransferLeadership transfers the Raft leadership of the chain to the given consenter,
+// and waits until the transfer completes or an election timeout expires. It can be
+// invoked on the current leader, or on the transferee itself since followers can only
+// forward requests to transfer the leadership to themselves.
+func (c *Chain) TransferLeadership(transferee uint64) error {
+	if err := c.isRunning(); err != nil {
+		return err
+	}
+
+	c.raftMetadataLock.RLock()
+	_, exists := c.opts.RaftMetadata.Consenters[transferee]
+	c.raftMetadataLock.RUnlock()
+	if !exists {
+		return errors.Errorf("node %d is not a consenter of channel %s", transferee, c.channelID)
+	}
+
+	status := c.node.Status()
+	switch {
+	case status.Lead == raft.None:
+		return errors.Errorf("no Raft leader")
+	case status.Lead == transferee:
+		return nil
+	case status.Lead != c.raftID && transferee != c.raftID:
+		return errors.Errorf("node %d is neither the leader (%d) nor the transferee (%d)", c.raftID, status.Lead, transferee)
+	}
+
+	c.logger.Infof("Transferring leadership from %d to %d", status.Lead, transferee)
+	newLead := c.node.transferLeadership(status.Lead, transferee)
+	if err := c.isRunning(); err != nil {
+		return err
+	}
+
+	switch newLead {
+	case raft.None:
+		return errors.Errorf("timed out transferring leadership to %d", transferee)
+	case transferee:
+		return nil
+	default:
+		return errors.Errorf("leadership was transferred to %d instead of %d", newLead, transferee)
+	}
+}
+
 func (c *Chain) isRunning() error {
 	select {
 	case <-c.startC:
@@ -447,7 +532,7 @@ func (c *Chain) serveRequest() {
 	start := func() {
 		if !ticking {
 			ticking = true
-			timer.Reset(c.support.SharedConfig().BatchTimeout())
+			timer.Reset(blockcutter.BatchTimeout(c.support.BlockCutter(), c.support.SharedConfig()))
 		}
 	}
 
@@ -466,6 +551,7 @@ func (c *Chain) serveRequest() {
 	becomeLeader := func() {
 		c.blockInflight = 0
 		c.justElected = true
+		c.catchUpNode = raft.None
 		submitC = nil
 
 		// if there is unfinished ConfChange, we should resume the effort to propose it as
@@ -482,6 +568,7 @@ func (c *Chain) serveRequest() {
 
 	becomeFollower := func() {
 		c.blockInflight = 0
+		c.catchUpNode = raft.None
 		_ = c.support.BlockCutter().Cut()
 		stop()
 		submitC = c.submitC
@@ -532,12 +619,16 @@ func (c *Chain) serveRequest() {
 				newLeader := atomic.LoadUint64(&app.soft.Lead) // etcdraft requires atomic access
 				if newLeader != soft.Lead {
 					c.logger.Infof("Raft leader changed: %d -> %d", soft.Lead, newLeader)
+					c.metrics.LeaderChanges.Add(1)
+					c.metrics.LeaderID.Set(float64(newLeader))
 
 					if newLeader == c.raftID {
+						c.metrics.IsLeader.Set(1)
 						becomeLeader()
 					}
 
 					if soft.Lead == c.raftID {
+						c.metrics.IsLeader.Set(0)
 						becomeFollower()
 					}
 				}
@@ -570,6 +661,12 @@ func (c *Chain) serveRequest() {
 
 			c.apply(app.entries)
 
+			if c.catchUpNode != raft.None && c.caughtUp(c.catchUpNode, c.catchUpIndex) {
+				c.logger.Infof("Node %d caught up with the leader, proposing next config change", c.catchUpNode)
+				c.catchUpNode = raft.None
+				c.proposeConfChange(c.confChangeInProgress)
+			}
+
 			if c.justElected {
 				msgInflight := c.node.lastIndex() > c.appliedIndex
 				if msgInflight || c.configInflight {
@@ -613,6 +710,7 @@ func (c *Chain) serveRequest() {
 
 			b := utils.UnmarshalBlockOrPanic(sn.Data)
 			c.lastSnapBlockNum = b.Header.Number
+			c.metrics.SnapshotBlockNumber.Set(float64(c.lastSnapBlockNum))
 			c.confState = sn.Metadata.ConfState
 			c.appliedIndex = sn.Metadata.Index
 
@@ -640,6 +738,7 @@ func (c *Chain) writeBlock(block *common.Block, index uint64) {
 	}
 
 	c.logger.Debugf("Writing block %d to ledger, there are %d blocks in flight", block.Header.Number, c.blockInflight)
+	c.metrics.CommittedBlockNumber.Set(float64(block.Header.Number))
 
 	if utils.IsConfigBlock(block) {
 		c.writeConfigBlock(block, index)
@@ -665,6 +764,7 @@ func (c *Chain) ordered(msg *orderer.SubmitRequest) (batches [][]*common.Envelop
 
 	if c.isConfig(msg.Payload) {
 		// ConfigMsg
+		c.metrics.ConfigProposalsReceived.Add(1)
 		if msg.LastValidationSeq < seq {
 			msg.Payload, _, err = c.support.ProcessConfigMsg(msg.Payload)
 			if err != nil {
@@ -680,6 +780,7 @@ func (c *Chain) ordered(msg *orderer.SubmitRequest) (batches [][]*common.Envelop
 		return batches, false, nil
 	}
 	// it is a normal message
+	c.metrics.NormalProposalsReceived.Add(1)
 	if msg.LastValidationSeq < seq {
 		if _, err := c.support.ProcessNormalMsg(msg.Payload); err != nil {
 			return nil, true, errors.Errorf("bad normal message: %s", err)
@@ -695,6 +796,7 @@ func (c *Chain) propose(bc *blockCreator, batches ...[]*common.Envelope) {
 		b := bc.createNextBlock(batch)
 		data := utils.MarshalOrPanic(b)
 		if err := c.node.Propose(context.TODO(), data); err != nil {
+			c.metrics.ProposalFailures.Add(1)
 			c.logger.Errorf("Failed to propose block to raft: %s", err)
 			return // don't bother continue proposing next batch
 		}
@@ -741,6 +843,7 @@ func (c *Chain) catchUp(snap *raftpb.Snapshot) error {
 		} else {
 			c.support.WriteBlock(block, nil)
 		}
+		c.metrics.CommittedBlockNumber.Set(float64(block.Header.Number))
 
 		next++
 	}
@@ -800,17 +903,14 @@ func (c *Chain) apply(ents []raftpb.Entry) {
 			}
 
 			// This ConfChange was introduced by a previously committed config block,
-			// we can now unblock submitC to accept envelopes.
-			if c.confChangeInProgress != nil &&
-				c.confChangeInProgress.NodeID == cc.NodeID &&
-				c.confChangeInProgress.Type == cc.Type {
-
+			// once all the ConfChanges it introduced are applied we can unblock submitC
+			// to accept envelopes.
+			if c.confChangeInProgress != nil {
 				if err := c.configureComm(); err != nil {
 					c.logger.Panicf("Failed to configure communication: %s", err)
 				}
 
-				c.confChangeInProgress = nil
-				c.configInflight = false
+				c.confChangeApplied(cc, ents[i].Index)
 			}
 
 			if cc.Type == raftpb.ConfChangeRemoveNode && cc.NodeID == c.raftID {
@@ -839,6 +939,7 @@ func (c *Chain) apply(ents []raftpb.Entry) {
 				c.accDataSize, c.sizeLimit, appliedb, c.lastSnapBlockNum)
 			c.accDataSize = 0
 			c.lastSnapBlockNum = appliedb
+			c.metrics.SnapshotBlockNumber.Set(float64(appliedb))
 		default:
 			c.logger.Warnf("Snapshotting is in progress, it is very likely that SnapshotInterval is too small")
 		}
@@ -925,12 +1026,23 @@ func (c *Chain) checkConsentersSet(configValue *common.ConfigValue) error {
 		return err
 	}
 
+	consenters := make(map[string]struct{})
+	for _, consenter := range updatedMetadata.Consenters {
+		if _, exists := consenters[string(consenter.ClientTlsCert)]; exists {
+			return errors.Errorf("consenter %s:%d appears more than once in the consenters set", consenter.Host, consenter.Port)
+		}
+		consenters[string(consenter.ClientTlsCert)] = struct{}{}
+	}
+
 	c.raftMetadataLock.RLock()
 	changes := ComputeMembershipChanges(c.opts.RaftMetadata.Consenters, updatedMetadata.Consenters)
 	c.raftMetadataLock.RUnlock()
 
 	if changes.TotalChanges > 1 {
-		return errors.New("update of more than one consenters at a time is not supported")
+		c.logger.Infof("Config update changes %d consenters, they will be applied one at a time", changes.TotalChanges)
+	}
+	if changes.Rotated() {
+		c.logger.Infof("Config update rotates the TLS certificates of %d consenters", len(changes.RotatedNodes))
 	}
 
 	return nil
@@ -955,6 +1067,19 @@ func (c *Chain) writeConfigBlock(block *common.Block, index uint64) {
 	c.support.WriteConfigBlock(block, raftMetadataBytes)
 	c.configInflight = false
 
+	if changes.Rotated() {
+		c.raftMetadataLock.Lock()
+		c.opts.RaftMetadata = raftMetadata
+		c.raftMetadataLock.Unlock()
+
+		for nodeID := range changes.RotatedNodes {
+			c.logger.Infof("Config block just committed rotates the TLS certificates of node %d", nodeID)
+		}
+		if err := c.configureComm(); err != nil {
+			c.logger.Panicf("Failed to configure communication: %s", err)
+		}
+	}
+
 	// update membership
 	if confChange != nil {
 		// ProposeConfChange returns error only if node being stopped.
@@ -968,6 +1093,13 @@ func (c *Chain) writeConfigBlock(block *common.Block, index uint64) {
 		c.raftMetadataLock.Lock()
 		c.opts.RaftMetadata = raftMetadata
 		c.raftMetadataLock.Unlock()
+		c.metrics.ClusterSize.Set(float64(len(raftMetadata.Consenters)))
+		c.metrics.PendingConfChanges.Set(float64(changes.TotalChanges))
+
+		if changes.TotalChanges > 1 {
+			c.logger.Infof("Config block just committed adds %d and removes %d nodes, applying them one at a time",
+				len(changes.AddedNodes), len(changes.RemovedNodes))
+		}
 
 		switch confChange.Type {
 		case raftpb.ConfChangeAddNode:
@@ -1016,16 +1148,71 @@ func (c *Chain) getInFlightConfChange() *raftpb.ConfChange {
 	// extracting current Raft configuration state
 	confState := c.node.ApplyConfChange(raftpb.ConfChange{})
 
-	if len(confState.Nodes) == len(raftMetadata.Consenters) {
-		// since configuration change could only add one node or
-		// remove one node at a time, if raft nodes state size
-		// equal to membership stored in block metadata field,
-		// that means everything is in sync and no need to propose
-		// update
-		return nil
+	// if raft nodes are the same as the membership stored in
+	// block metadata field, that means everything is in sync
+	// and no need to propose update, in which case nil is returned
+	return ConfChange(raftMetadata, confState)
+}
+
+// confChangeApplied tracks the progress of the ConfChanges introduced by the last
+// config block after the given ConfChange, committed at the given raft index, is
+// applied, and proposes the next one if any.
+func (c *Chain) confChangeApplied(cc raftpb.ConfChange, index uint64) {
+	pending := PendingConfChanges(c.opts.RaftMetadata, &c.confState)
+	c.metrics.PendingConfChanges.Set(float64(pending))
+
+	next := ConfChange(c.opts.RaftMetadata, &c.confState)
+	if next == nil {
+		c.logger.Infof("All config changes are applied, current nodes in channel: %+v", c.confState.Nodes)
+		c.confChangeInProgress = nil
+		c.catchUpNode = raft.None
+		c.configInflight = false
+		return
 	}
 
-	return ConfChange(raftMetadata, confState)
+	c.confChangeInProgress = next
+	if cc.Type == raftpb.ConfChangeRemoveNode && cc.NodeID == c.raftID {
+		// this node is halting, the next leader proposes the remaining ConfChanges
+		return
+	}
+
+	if cc.Type == raftpb.ConfChangeAddNode {
+		// The quorum of the cluster must not depend on more than one node that is
+		// still catching up, therefore the next ConfChange is only proposed once
+		// the added node has replicated the ConfChange that added it.
+		c.logger.Infof("%d config changes pending, waiting for node %d to catch up before proposing the next one", pending, cc.NodeID)
+		c.catchUpNode = cc.NodeID
+		c.catchUpIndex = index
+		return
+	}
+
+	c.logger.Infof("%d config changes pending, proposing the next one", pending)
+	c.proposeConfChange(next)
+}
+
+// caughtUp returns true if the given node has replicated the raft log up to the given
+// index, as tracked by the leader. It always returns false on followers.
+func (c *Chain) caughtUp(nodeID uint64, index uint64) bool {
+	status := c.node.Status()
+	if status.RaftState != raft.StateLeader {
+		return false
+	}
+
+	progress, exists := status.Progress[nodeID]
+	return exists && progress.Match >= index
+}
+
+// proposeConfChange proposes the given ConfChange if the node is the leader, since the
+// proposals of followers are dropped because DisableProposalForwarding is enabled.
+func (c *Chain) proposeConfChange(cc *raftpb.ConfChange) {
+	if c.node.Status().RaftState != raft.StateLeader {
+		return
+	}
+
+	// ProposeConfChange returns error only if node being stopped.
+	if err := c.node.ProposeConfChange(context.TODO(), *cc); err != nil {
+		c.logger.Warnf("Failed to propose configuration update to Raft node: %s", err)
+	}
 }
 
 // newRaftMetadata extract raft metadata from the configuration block
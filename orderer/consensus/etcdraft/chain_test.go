@@ -23,6 +23,7 @@ import (
 	"github.com/hyperledger/fabric/bccsp/factory"
 	"github.com/hyperledger/fabric/common/crypto/tlsgen"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
 	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
 	"github.com/hyperledger/fabric/orderer/common/cluster"
 	"github.com/hyperledger/fabric/orderer/consensus/etcdraft"
@@ -97,6 +98,7 @@ var _ = Describe("Chain", func() {
 			walDir            string
 			snapDir           string
 			err               error
+			fakeFields        *fakeMetricsFields
 		)
 
 		BeforeEach(func() {
@@ -136,6 +138,8 @@ var _ = Describe("Chain", func() {
 				meta.NextConsenterId++
 			}
 
+			fakeFields = newFakeMetricsFields()
+
 			opts = etcdraft.Options{
 				RaftID:          1,
 				Clock:           clock,
@@ -149,6 +153,7 @@ var _ = Describe("Chain", func() {
 				MemoryStorage:   storage,
 				WALDir:          walDir,
 				SnapDir:         snapDir,
+				Metrics:         newFakeMetrics(fakeFields),
 			}
 		})
 
@@ -199,6 +204,29 @@ var _ = Describe("Chain", func() {
 				expectedNodeConfig := nodeConfigFromMetadata(consenterMetadata)
 				configurator.AssertCalled(testingInstance, "Configure", channelID, expectedNodeConfig)
 			})
+
+			It("sets the initial values of the metrics", func() {
+				Expect(fakeFields.fakeClusterSize.WithCallCount()).To(Equal(1))
+				Expect(fakeFields.fakeClusterSize.WithArgsForCall(0)).To(Equal([]string{"channel", channelID}))
+				Expect(fakeFields.fakeClusterSize.SetArgsForCall(0)).To(Equal(float64(1)))
+				Expect(fakeFields.fakeIsLeader.SetArgsForCall(0)).To(Equal(float64(0)))
+				Expect(fakeFields.fakeLeaderID.SetArgsForCall(0)).To(Equal(float64(0)))
+				Expect(fakeFields.fakeCommittedBlockNumber.SetArgsForCall(0)).To(Equal(float64(0)))
+				Expect(fakeFields.fakeSnapshotBlockNumber.SetArgsForCall(0)).To(Equal(float64(0)))
+				Expect(fakeFields.fakeDataPersistDuration.ObserveCallCount()).To(BeNumerically(">", 0))
+			})
+		})
+
+		Context("when a node starts up after consensus-type migration", func() {
+			BeforeEach(func() {
+				// the migrated chain has blocks, but no Raft data
+				support.HeightReturns(5)
+				opts.MigrationInit = true
+			})
+
+			It("starts a new Raft cluster rather than joining one", func() {
+				campaign(clock, observeC)
+			})
 		})
 
 		Context("when no Raft leader is elected", func() {
@@ -219,6 +247,15 @@ var _ = Describe("Chain", func() {
 				Expect(err).To(MatchError("chain is stopped"))
 			})
 
+			It("updates the leadership metrics", func() {
+				Expect(fakeFields.fakeLeaderChanges.AddCallCount()).To(Equal(1))
+				Expect(fakeFields.fakeLeaderChanges.AddArgsForCall(0)).To(Equal(float64(1)))
+				Expect(fakeFields.fakeLeaderID.SetCallCount()).To(Equal(2))
+				Expect(fakeFields.fakeLeaderID.SetArgsForCall(1)).To(Equal(float64(1)))
+				Expect(fakeFields.fakeIsLeader.SetCallCount()).To(Equal(2))
+				Expect(fakeFields.fakeIsLeader.SetArgsForCall(1)).To(Equal(float64(1)))
+			})
+
 			It("produces blocks following batch rules", func() {
 				close(cutter.Block)
 
@@ -227,6 +264,10 @@ var _ = Describe("Chain", func() {
 				err := chain.Order(env, 0)
 				Expect(err).NotTo(HaveOccurred())
 				Eventually(support.WriteBlockCallCount, LongEventualTimeout).Should(Equal(1))
+				Expect(fakeFields.fakeNormalProposalsReceived.AddCallCount()).To(Equal(1))
+				Expect(fakeFields.fakeNormalProposalsReceived.AddArgsForCall(0)).To(Equal(float64(1)))
+				Expect(fakeFields.fakeCommittedBlockNumber.SetCallCount()).To(Equal(2))
+				Expect(fakeFields.fakeCommittedBlockNumber.SetArgsForCall(1)).To(Equal(float64(1)))
 
 				By("respecting batch timeout")
 				cutter.CutNext = false
@@ -415,6 +456,8 @@ var _ = Describe("Chain", func() {
 									Expect(err).NotTo(HaveOccurred())
 									Eventually(support.WriteConfigBlockCallCount, LongEventualTimeout).Should(Equal(1))
 									Eventually(support.WriteBlockCallCount, LongEventualTimeout).Should(Equal(0))
+									Expect(fakeFields.fakeConfigProposalsReceived.AddCallCount()).To(Equal(1))
+									Expect(fakeFields.fakeConfigProposalsReceived.AddArgsForCall(0)).To(Equal(float64(1)))
 								})
 							})
 
@@ -528,9 +571,32 @@ var _ = Describe("Chain", func() {
 
 						}) // BeforeEach block
 
-						It("should fail, since consenters set change is not supported", func() {
+						It("should be able to process config update changing several consenters", func() {
 							err := chain.Configure(configEnv, configSeq)
-							Expect(err).To(MatchError("update of more than one consenters at a time is not supported"))
+							Expect(err).NotTo(HaveOccurred())
+						})
+					})
+
+					Context("updating consenters set with a duplicated consenter", func() {
+						configEnvWith := func(metadata *raftprotos.Metadata) *common.Envelope {
+							values := map[string]*common.ConfigValue{
+								"ConsensusType": {
+									Version: 1,
+									Value: marshalOrPanic(&orderer.ConsensusType{
+										Metadata: marshalOrPanic(metadata),
+									}),
+								},
+							}
+							return newConfigEnv(channelID,
+								common.HeaderType_CONFIG,
+								newConfigUpdateEnv(channelID, values))
+						}
+
+						It("should fail if a consenter appears more than once", func() {
+							metadata := createMetadata(1, tlsCA)
+							metadata.Consenters = append(metadata.Consenters, metadata.Consenters[0])
+							err := chain.Configure(configEnvWith(metadata), 0)
+							Expect(err).To(MatchError("consenter localhost:7050 appears more than once in the consenters set"))
 						})
 					})
 
@@ -582,7 +648,7 @@ var _ = Describe("Chain", func() {
 							Expect(err).NotTo(HaveOccurred())
 						})
 
-						It("fail since not allowed to add and remove node at same change", func() {
+						It("should be able to process config update adding and removing nodes at same change", func() {
 							metadata := proto.Clone(consenterMetadata).(*raftprotos.Metadata)
 							// Remove one of the consenters
 							metadata.Consenters = metadata.Consenters[1:]
@@ -606,7 +672,7 @@ var _ = Describe("Chain", func() {
 							configSeq = 0
 
 							err := chain.Configure(configEnv, configSeq)
-							Expect(err).To(MatchError("update of more than one consenters at a time is not supported"))
+							Expect(err).NotTo(HaveOccurred())
 						})
 					})
 				})
@@ -816,6 +882,9 @@ var _ = Describe("Chain", func() {
 							Eventually(support.WriteBlockCallCount, LongEventualTimeout).Should(Equal(2))
 							Eventually(countFiles, LongEventualTimeout).Should(Equal(2))
 							Eventually(opts.MemoryStorage.FirstIndex, LongEventualTimeout).Should(BeNumerically(">", i))
+
+							Eventually(fakeFields.fakeSnapshotBlockNumber.SetCallCount, LongEventualTimeout).Should(Equal(3))
+							Expect(fakeFields.fakeSnapshotBlockNumber.SetArgsForCall(2)).To(Equal(float64(2)))
 						})
 
 						It("pauses chain if sync is in progress", func() {
@@ -1106,6 +1175,7 @@ var _ = Describe("Chain", func() {
 								Logger:        logger,
 								MemoryStorage: storage,
 								RaftMetadata:  &raftprotos.RaftMetadata{},
+								Metrics:       newFakeMetrics(newFakeMetricsFields()),
 							},
 							configurator,
 							nil,
@@ -1137,6 +1207,7 @@ var _ = Describe("Chain", func() {
 								Logger:        logger,
 								MemoryStorage: storage,
 								RaftMetadata:  &raftprotos.RaftMetadata{},
+								Metrics:       newFakeMetrics(newFakeMetricsFields()),
 							},
 							nil,
 							nil,
@@ -1165,6 +1236,7 @@ var _ = Describe("Chain", func() {
 								SnapDir:      snapDir,
 								Logger:       logger,
 								RaftMetadata: &raftprotos.RaftMetadata{},
+								Metrics:      newFakeMetrics(newFakeMetricsFields()),
 							},
 							nil,
 							nil,
@@ -1356,6 +1428,68 @@ var _ = Describe("Chain", func() {
 						},
 					}
 				}
+				rotateConsenterConfigValue = func(ids ...uint64) map[string]*common.ConfigValue {
+					newRaftMetadata := proto.Clone(raftMetadata).(*raftprotos.RaftMetadata)
+					for _, id := range ids {
+						newRaftMetadata.Consenters[id].ClientTlsCert = clientTLSCert(tlsCA)
+						newRaftMetadata.Consenters[id].ServerTlsCert = serverTLSCert(tlsCA)
+					}
+
+					metadata := &raftprotos.Metadata{}
+					for _, consenter := range newRaftMetadata.Consenters {
+						metadata.Consenters = append(metadata.Consenters, consenter)
+					}
+
+					return map[string]*common.ConfigValue{
+						"ConsensusType": {
+							Version: 1,
+							Value: marshalOrPanic(&orderer.ConsensusType{
+								Metadata: marshalOrPanic(metadata),
+							}),
+						},
+					}
+				}
+				rotateCertificates = func(ids ...uint64) {
+					configEnv := newConfigEnv(channelID, common.HeaderType_CONFIG, newConfigUpdateEnv(channelID, rotateConsenterConfigValue(ids...)))
+					c1.cutter.CutNext = true
+
+					By("sending config transaction")
+					err := c1.Configure(configEnv, 0)
+					Expect(err).ToNot(HaveOccurred())
+
+					network.exec(func(c *chain) {
+						Eventually(c.support.WriteConfigBlockCallCount, defaultTimeout).Should(Equal(1))
+					})
+
+					By("keeping the Raft IDs of the nodes and updating their certificates")
+					_, raftmetabytes := c1.support.WriteConfigBlockArgsForCall(0)
+					meta := &common.Metadata{Value: raftmetabytes}
+					raftmeta, err := etcdraft.ReadRaftMetadata(meta, nil)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(raftmeta.Consenters).To(HaveLen(3))
+					Expect(raftmeta.NextConsenterId).To(Equal(uint64(4)))
+					for id, consenter := range raftMetadata.Consenters {
+						Expect(raftmeta.Consenters).To(HaveKey(id))
+						rotated := false
+						for _, rotatedID := range ids {
+							rotated = rotated || rotatedID == id
+						}
+						if rotated {
+							Expect(raftmeta.Consenters[id].ClientTlsCert).NotTo(Equal(consenter.ClientTlsCert))
+						} else {
+							Expect(raftmeta.Consenters[id].ClientTlsCert).To(Equal(consenter.ClientTlsCert))
+						}
+					}
+
+					By("submitting new transaction once the certificates are rotated")
+					c1.cutter.CutNext = true
+					err = c1.Order(env, 0)
+					Expect(err).ToNot(HaveOccurred())
+
+					network.exec(func(c *chain) {
+						Eventually(c.support.WriteBlockCallCount, defaultTimeout).Should(Equal(2))
+					})
+				}
 			)
 
 			BeforeEach(func() {
@@ -1381,7 +1515,9 @@ var _ = Describe("Chain", func() {
 			})
 
 			Context("reconfiguration", func() {
-				It("trying to simultaneously add and remove nodes in one config update", func() {
+				It("adding and removing nodes in one config update", func() {
+					// Scenario: replace node 2 with a new node in one config update. The new node is
+					// added first, and node 2 is removed only once the new node caught up with the leader.
 
 					updatedRaftMetadata := proto.Clone(raftMetadata).(*raftprotos.RaftMetadata)
 					// remove second consenter
@@ -1410,13 +1546,79 @@ var _ = Describe("Chain", func() {
 						},
 					}
 
+					pendingConfChanges := func() float64 {
+						gauge := c1.fakeFields.fakePendingConfChanges
+						return gauge.SetArgsForCall(gauge.SetCallCount() - 1)
+					}
+
 					By("creating new configuration with removed node and new one")
 					configEnv := newConfigEnv(channelID, common.HeaderType_CONFIG, newConfigUpdateEnv(channelID, value))
 					c1.cutter.CutNext = true
 
 					By("sending config transaction")
 					err := c1.Configure(configEnv, 0)
-					Expect(err).To(MatchError("update of more than one consenters at a time is not supported"))
+					Expect(err).ToNot(HaveOccurred())
+
+					network.exec(func(c *chain) {
+						Eventually(c.support.WriteConfigBlockCallCount, defaultTimeout).Should(Equal(1))
+					})
+
+					_, raftmetabytes := c1.support.WriteConfigBlockArgsForCall(0)
+					meta := &common.Metadata{Value: raftmetabytes}
+					raftmeta, err := etcdraft.ReadRaftMetadata(meta, nil)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(raftmeta.Consenters).To(HaveLen(3))
+					Expect(raftmeta.Consenters).To(HaveKey(uint64(4)))
+					Expect(raftmeta.Consenters).NotTo(HaveKey(uint64(2)))
+
+					c4 := newChain(timeout, channelID, dataDir, 4, raftmeta)
+					c4.init()
+
+					// if we join a node to existing network, it MUST already obtained blocks
+					// till the config block that adds this node to cluster.
+					c4.support.WriteBlock(c1.support.WriteBlockArgsForCall(0))
+					c4.support.WriteConfigBlock(c1.support.WriteConfigBlockArgsForCall(0))
+
+					network.addChain(c4)
+
+					By("waiting for the new node to catch up before removing node 2")
+					Eventually(pendingConfChanges, defaultTimeout).Should(Equal(float64(1)))
+					for i := 0; i < 3; i++ {
+						c1.clock.Increment(interval)
+					}
+					Consistently(pendingConfChanges).Should(Equal(float64(1)))
+
+					c4.Start()
+
+					Eventually(func() <-chan raft.SoftState {
+						c1.clock.Increment(interval)
+						return c4.observe
+					}, defaultTimeout).Should(Receive(Equal(raft.SoftState{Lead: 1, RaftState: raft.StateFollower})))
+
+					By("removing node 2 once the new node caught up")
+					Eventually(func() float64 {
+						c1.clock.Increment(interval)
+						return pendingConfChanges()
+					}, defaultTimeout).Should(Equal(float64(0)))
+
+					By("submitting new transaction to the new node")
+					c1.cutter.CutNext = true
+					err = c4.Order(env, 0)
+					Expect(err).ToNot(HaveOccurred())
+
+					network.exec(func(c *chain) {
+						Eventually(c.support.WriteBlockCallCount, defaultTimeout).Should(Equal(2))
+					}, 1, 3, 4)
+					// node 2 has been removed, hence should not get the new block
+					Consistently(c2.support.WriteBlockCallCount).Should(Equal(1))
+				})
+
+				It("rotating the TLS certificates of a node", func() {
+					rotateCertificates(2)
+				})
+
+				It("rotating the TLS certificates of several nodes in one config update", func() {
+					rotateCertificates(1, 2, 3)
 				})
 
 				It("adding node to the cluster", func() {
@@ -1769,18 +1971,37 @@ var _ = Describe("Chain", func() {
 							Eventually(c.support.WriteConfigBlockCallCount, LongEventualTimeout).Should(Equal(1))
 						})
 
-					// Assert c1 has exited
-					Eventually(c1.Errored, LongEventualTimeout).Should(BeClosed())
+					// Assert c1 has exited, ticking it until its attempt to
+					// transfer leadership times out since it cannot learn
+					// about the new leader after it is removed
+					Eventually(func() <-chan struct{} {
+						c1.clock.Increment(interval)
+						return c1.Errored()
+					}, LongEventualTimeout).Should(BeClosed())
 					close(c1.stopped)
 
 					By("making sure remaining two nodes will elect new leader")
 
-					// deterministically select nodeID == 2 to be a leader
-					network.elect(2)
+					// the removed leader transfers its leadership to one of the remaining nodes
+					var newLeader *chain
+					for newLeader == nil {
+						var state raft.SoftState
+						select {
+						case state = <-c2.observe:
+						case state = <-c3.observe:
+						case <-time.After(LongEventualTimeout):
+							Fail("Expected a new leader to be elected")
+						}
+
+						if state.RaftState == raft.StateLeader {
+							newLeader = network.chains[state.Lead]
+						}
+					}
+					network.leader = newLeader.id
 
 					By("submitting transaction to new leader")
-					c2.cutter.CutNext = true
-					err = c2.Order(env, 0)
+					newLeader.cutter.CutNext = true
+					err = newLeader.Order(env, 0)
 					Expect(err).ToNot(HaveOccurred())
 
 					Eventually(c2.support.WriteBlockCallCount, LongEventualTimeout).Should(Equal(2))
@@ -2283,6 +2504,64 @@ var _ = Describe("Chain", func() {
 				})
 			})
 
+			Context("leadership transfer", func() {
+				It("transfers leadership to the most up-to-date follower upon halt", func() {
+					network.disconnect(2)
+
+					c1.cutter.CutNext = true
+					err := c1.Order(env, 0)
+					Expect(err).NotTo(HaveOccurred())
+					Eventually(c1.support.WriteBlockCallCount, LongEventualTimeout).Should(Equal(1))
+					Eventually(c3.support.WriteBlockCallCount, LongEventualTimeout).Should(Equal(1))
+
+					network.connect(2)
+					network.stop(1)
+					Eventually(c3.observe, LongEventualTimeout).Should(Receive(StateEqual(3, raft.StateLeader)))
+					network.leader = 3
+
+					By("order envelope on new leader without an election timeout")
+					c3.cutter.CutNext = true
+					err = c3.Order(env, 0)
+					Expect(err).NotTo(HaveOccurred())
+					Eventually(c2.support.WriteBlockCallCount, LongEventualTimeout).Should(Equal(2))
+					Eventually(c3.support.WriteBlockCallCount, LongEventualTimeout).Should(Equal(2))
+				})
+
+				It("transfers leadership to a given consenter on the leader", func() {
+					Expect(c1.TransferLeadership(3)).To(Succeed())
+					Eventually(c3.observe, LongEventualTimeout).Should(Receive(StateEqual(3, raft.StateLeader)))
+					network.leader = 3
+
+					c3.cutter.CutNext = true
+					err := c3.Order(env, 0)
+					Expect(err).NotTo(HaveOccurred())
+					network.exec(func(c *chain) {
+						Eventually(c.support.WriteBlockCallCount, LongEventualTimeout).Should(Equal(1))
+					})
+				})
+
+				It("transfers leadership to the transferee when invoked on it", func() {
+					Expect(c2.TransferLeadership(2)).To(Succeed())
+					Eventually(c2.observe, LongEventualTimeout).Should(Receive(StateEqual(2, raft.StateLeader)))
+					network.leader = 2
+				})
+
+				It("does nothing when the consenter is already the leader", func() {
+					Expect(c2.TransferLeadership(1)).To(Succeed())
+					Consistently(c1.observe).ShouldNot(Receive())
+				})
+
+				It("refuses to transfer leadership to a node that is not a consenter", func() {
+					err := c1.TransferLeadership(4)
+					Expect(err).To(MatchError("node 4 is not a consenter of channel multi-node-channel"))
+				})
+
+				It("refuses to transfer leadership on a follower other than the transferee", func() {
+					err := c2.TransferLeadership(3)
+					Expect(err).To(MatchError("node 2 is neither the leader (1) nor the transferee (3)"))
+				})
+			})
+
 			Context("failover", func() {
 				It("follower should step up as leader upon failover", func() {
 					network.stop(1)
@@ -2554,6 +2833,7 @@ type chain struct {
 	clock        *fakeclock.FakeClock
 	opts         etcdraft.Options
 	puller       *mocks.FakeBlockPuller
+	fakeFields   *fakeMetricsFields
 
 	// store written blocks to be returned by mock block puller
 	ledger       map[uint64]*common.Block
@@ -2571,6 +2851,7 @@ func newChain(timeout time.Duration, channel string, dataDir string, id uint64,
 	rpc := &mocks.FakeRPC{}
 	clock := fakeclock.NewFakeClock(time.Now())
 	storage := raft.NewMemoryStorage()
+	fakeFields := newFakeMetricsFields()
 
 	opts := etcdraft.Options{
 		RaftID:          uint64(id),
@@ -2585,6 +2866,7 @@ func newChain(timeout time.Duration, channel string, dataDir string, id uint64,
 		MemoryStorage:   storage,
 		WALDir:          path.Join(dataDir, "wal"),
 		SnapDir:         path.Join(dataDir, "snapshot"),
+		Metrics:         newFakeMetrics(fakeFields),
 	}
 
 	support := &consensusmocks.FakeConsenterSupport{}
@@ -2621,6 +2903,7 @@ func newChain(timeout time.Duration, channel string, dataDir string, id uint64,
 		stopped:      make(chan struct{}),
 		configurator: configurator,
 		puller:       puller,
+		fakeFields:   fakeFields,
 		ledger: map[uint64]*common.Block{
 			0: getSeedBlock(), // Very first block
 		},
@@ -2843,7 +3126,20 @@ func (n *network) stop(ids ...uint64) {
 
 	for _, id := range nodes {
 		c := n.chains[id]
-		c.Halt()
+
+		// A leader transfers its leadership before halting, which
+		// requires ticking its clock if the transfer does not complete.
+		halted := make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			c.Halt()
+			close(halted)
+		}()
+		Eventually(func() <-chan struct{} {
+			c.clock.Increment(interval)
+			return halted
+		}, LongEventualTimeout).Should(BeClosed())
+
 		Eventually(c.Errored).Should(BeClosed())
 		select {
 		case <-c.stopped:
@@ -3006,3 +3302,67 @@ func getSeedBlock() *common.Block {
 func StateEqual(lead uint64, state raft.StateType) types.GomegaMatcher {
 	return Equal(raft.SoftState{Lead: lead, RaftState: state})
 }
+
+type fakeMetricsFields struct {
+	fakeClusterSize             *metricsfakes.Gauge
+	fakeIsLeader                *metricsfakes.Gauge
+	fakeLeaderID                *metricsfakes.Gauge
+	fakeCommittedBlockNumber    *metricsfakes.Gauge
+	fakeSnapshotBlockNumber     *metricsfakes.Gauge
+	fakeLeaderChanges           *metricsfakes.Counter
+	fakeProposalFailures        *metricsfakes.Counter
+	fakeDataPersistDuration     *metricsfakes.Histogram
+	fakeNormalProposalsReceived *metricsfakes.Counter
+	fakeConfigProposalsReceived *metricsfakes.Counter
+	fakePendingConfChanges      *metricsfakes.Gauge
+}
+
+func newFakeMetricsFields() *fakeMetricsFields {
+	return &fakeMetricsFields{
+		fakeClusterSize:             newFakeGauge(),
+		fakeIsLeader:                newFakeGauge(),
+		fakeLeaderID:                newFakeGauge(),
+		fakeCommittedBlockNumber:    newFakeGauge(),
+		fakeSnapshotBlockNumber:     newFakeGauge(),
+		fakeLeaderChanges:           newFakeCounter(),
+		fakeProposalFailures:        newFakeCounter(),
+		fakeDataPersistDuration:     newFakeHistogram(),
+		fakeNormalProposalsReceived: newFakeCounter(),
+		fakeConfigProposalsReceived: newFakeCounter(),
+		fakePendingConfChanges:      newFakeGauge(),
+	}
+}
+
+func newFakeMetrics(fakeFields *fakeMetricsFields) *etcdraft.Metrics {
+	return &etcdraft.Metrics{
+		ClusterSize:             fakeFields.fakeClusterSize,
+		IsLeader:                fakeFields.fakeIsLeader,
+		LeaderID:                fakeFields.fakeLeaderID,
+		CommittedBlockNumber:    fakeFields.fakeCommittedBlockNumber,
+		SnapshotBlockNumber:     fakeFields.fakeSnapshotBlockNumber,
+		LeaderChanges:           fakeFields.fakeLeaderChanges,
+		ProposalFailures:        fakeFields.fakeProposalFailures,
+		DataPersistDuration:     fakeFields.fakeDataPersistDuration,
+		NormalProposalsReceived: fakeFields.fakeNormalProposalsReceived,
+		ConfigProposalsReceived: fakeFields.fakeConfigProposalsReceived,
+		PendingConfChanges:      fakeFields.fakePendingConfChanges,
+	}
+}
+
+func newFakeGauge() *metricsfakes.Gauge {
+	fakeGauge := &metricsfakes.Gauge{}
+	fakeGauge.WithReturns(fakeGauge)
+	return fakeGauge
+}
+
+func newFakeCounter() *metricsfakes.Counter {
+	fakeCounter := &metricsfakes.Counter{}
+	fakeCounter.WithReturns(fakeCounter)
+	return fakeCounter
+}
+
+func newFakeHistogram() *metricsfakes.Histogram {
+	fakeHistogram := &metricsfakes.Histogram{}
+	fakeHistogram.WithReturns(fakeHistogram)
+	return fakeHistogram
+}
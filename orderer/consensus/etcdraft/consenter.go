@@ -16,6 +16,7 @@ import (
 	"github.com/coreos/etcd/raft"
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/common/viperutil"
 	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/orderer/common/cluster"
@@ -69,6 +70,7 @@ type Consenter struct {
 	EtcdRaftConfig Config
 	OrdererConfig  localconfig.TopLevel
 	Cert           []byte
+	Metrics        *Metrics
 }
 
 // TargetChannel extracts the channel from the given proto.Message.
@@ -94,10 +96,12 @@ func (c *Consenter) ReceiverByChain(channelID string) MessageReceiver {
 	if cs.Chain == nil {
 		c.Logger.Panicf("Programming error - Chain %s is nil although it exists in the mapping", channelID)
 	}
-	if etcdRaftChain, isEtcdRaftChain := cs.Chain.(*Chain); isEtcdRaftChain {
-		return etcdRaftChain
+	// The cluster communication is shared with the other consensus types
+	// that are built on it, so any chain that receives cluster messages is returned.
+	if receiver, isReceiver := cs.Chain.(MessageReceiver); isReceiver {
+		return receiver
 	}
-	c.Logger.Warningf("Chain %s is of type %v and not etcdraft.Chain", channelID, reflect.TypeOf(cs.Chain))
+	c.Logger.Warningf("Chain %s is of type %v and does not receive cluster messages", channelID, reflect.TypeOf(cs.Chain))
 	return nil
 }
 
@@ -138,6 +142,12 @@ func (c *Consenter) HandleChain(support consensus.ConsenterSupport, metadata *co
 
 	id, err := c.detectSelfID(raftMetadata.Consenters)
 	if err != nil {
+		// Without a system channel there are no inactive chains to track,
+		// so the blocks of the channel are pulled until this node becomes a consenter.
+		if c.InactiveChainRegistry == nil {
+			c.Logger.Infof("Following channel %s, as this node is not one of its consenters", support.ChainID())
+			return c.newFollower(support), nil
+		}
 		c.InactiveChainRegistry.TrackChain(support.ChainID(), support.Block(0), func() {
 			c.CreateChain(support.ChainID())
 		})
@@ -157,7 +167,9 @@ func (c *Consenter) HandleChain(support consensus.ConsenterSupport, metadata *co
 		MaxSizePerMsg:   m.Options.MaxSizePerMsg,
 		SnapInterval:    m.Options.SnapshotInterval,
 
-		RaftMetadata: raftMetadata,
+		RaftMetadata:  raftMetadata,
+		Metrics:       c.Metrics,
+		MigrationInit: detectMigration(support, raftMetadata),
 
 		WALDir:  path.Join(c.EtcdRaftConfig.WALDir, support.ChainID()),
 		SnapDir: path.Join(c.EtcdRaftConfig.SnapDir, support.ChainID()),
@@ -180,6 +192,38 @@ func (c *Consenter) HandleChain(support consensus.ConsenterSupport, metadata *co
 	)
 }
 
+func (c *Consenter) newFollower(support consensus.ConsenterSupport) *Follower {
+	createPuller := func() (ChannelPuller, error) {
+		puller, err := newBlockPuller(support, c.Dialer, c.OrdererConfig.General.Cluster)
+		if err != nil {
+			return nil, err
+		}
+		puller.MaxPullBlockRetries = uint64(c.OrdererConfig.General.Cluster.ReplicationMaxRetries)
+		return puller, nil
+	}
+
+	return NewFollower(
+		support,
+		createPuller,
+		ConsenterCertificate(c.Cert).IsConsenterOfChannel,
+		func() { c.CreateChain(support.ChainID()) },
+		clock.NewClock(),
+		DefaultFollowerPullInterval,
+		c.Logger,
+	)
+}
+
+// detectMigration detects whether the chain starts right after its consensus-type migration to Raft, i.e. its last
+// config carries the committed migration, and no block has been ordered by Raft since.
+func detectMigration(support consensus.ConsenterSupport, raftMetadata *etcdraft.RaftMetadata) bool {
+	switch support.SharedConfig().ConsensusMigrationState() {
+	case orderer.ConsensusType_MIG_STATE_COMMIT, orderer.ConsensusType_MIG_STATE_CONTEXT:
+		return raftMetadata.RaftIndex == 0
+	default:
+		return false
+	}
+}
+
 // ReadRaftMetadata attempts to read raft metadata from block metadata, if available.
 // otherwise, it reads raft metadata from config metadata supplied.
 func ReadRaftMetadata(blockMetadata *common.Metadata, configMetadata *etcdraft.Metadata) (*etcdraft.RaftMetadata, error) {
@@ -211,6 +255,7 @@ func New(
 	srv *comm.GRPCServer,
 	r *multichannel.Registrar,
 	icr InactiveChainRegistry,
+	metricsProvider metrics.Provider,
 ) *Consenter {
 	logger := flogging.MustGetLogger("orderer.consensus.etcdraft")
 
@@ -228,6 +273,7 @@ func New(
 		EtcdRaftConfig:        cfg,
 		OrdererConfig:         *conf,
 		Dialer:                clusterDialer,
+		Metrics:               NewMetrics(metricsProvider),
 	}
 	consenter.Dispatcher = &Dispatcher{
 		Logger:        logger,
@@ -14,6 +14,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/crypto/tlsgen"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
 	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
 	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/orderer/common/cluster"
@@ -192,6 +193,34 @@ var _ = Describe("Consenter", func() {
 		consenter.icr.AssertNumberOfCalls(testingInstance, "TrackChain", 1)
 	})
 
+	It("follows the chain if no matching cert found and there is no inactive chain registry", func() {
+		m := &etcdraftproto.Metadata{
+			Consenters: []*etcdraftproto.Consenter{
+				{ServerTlsCert: []byte("cert.orderer1.org1")},
+			},
+			Options: &etcdraftproto.Options{
+				TickInterval:    500,
+				ElectionTick:    10,
+				HeartbeatTick:   1,
+				MaxInflightMsgs: 256,
+				MaxSizePerMsg:   1048576,
+			},
+		}
+		metadata := utils.MarshalOrPanic(m)
+		support := &consensusmocks.FakeConsenterSupport{}
+		support.SharedConfigReturns(&mockconfig.Orderer{ConsensusMetadataVal: metadata})
+		support.ChainIDReturns("foo")
+
+		consenter := newConsenter(chainGetter)
+		consenter.InactiveChainRegistry = nil
+
+		chain, err := consenter.HandleChain(support, &common.Metadata{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(chain).To(BeAssignableToTypeOf(&etcdraft.Follower{}))
+		Expect(chain.Order(nil, 0)).To(MatchError("orderer is a follower of channel foo"))
+		consenter.icr.AssertNotCalled(testingInstance, "TrackChain")
+	})
+
 	It("fails to handle chain if etcdraft options have not been provided", func() {
 		m := &etcdraftproto.Metadata{
 			Consenters: []*etcdraftproto.Consenter{
@@ -228,6 +257,7 @@ func newConsenter(chainGetter *mocks.ChainGetter) *consenter {
 		Cert:                  []byte("cert.orderer0.org0"),
 		Logger:                flogging.MustGetLogger("test"),
 		Chains:                chainGetter,
+		Metrics:               etcdraft.NewMetrics(&disabled.Provider{}),
 		Dispatcher: &etcdraft.Dispatcher{
 			Logger:        flogging.MustGetLogger("test"),
 			ChainSelector: &mocks.ReceiverGetter{},
@@ -0,0 +1,209 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package etcdraft
+
+import (
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/orderer/common/types"
+	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// DefaultFollowerPullInterval is the interval at which a Follower pulls
+// the blocks of its channel, when it is up to date with the cluster.
+const DefaultFollowerPullInterval = 10 * time.Second
+
+// ChannelPuller pulls blocks of a channel, and reports the heights
+// of the channel among the orderers it pulls from.
+type ChannelPuller interface {
+	BlockPuller
+	HeightsByEndpoints() (map[string]uint64, error)
+}
+
+// CreateChannelPuller is a function to create ChannelPuller on demand.
+type CreateChannelPuller func() (ChannelPuller, error)
+
+// Follower implements a consensus.Chain for a channel whose Raft cluster
+// this node is not a member of. It periodically pulls the blocks of the channel
+// from the orderers of the channel and commits them, until a config block adds
+// this node to the consenters of the channel. The channel is then created anew,
+// which makes the node join the Raft cluster.
+type Follower struct {
+	Support      consensus.ConsenterSupport
+	CreatePuller CreateChannelPuller
+	// IsConsenter returns nil if the given config block makes this node a consenter
+	IsConsenter  func(configBlock *common.Block) error
+	CreateChain  func()
+	Clock        clock.Clock
+	PullInterval time.Duration
+	Logger       *flogging.FabricLogger
+
+	startC   chan struct{}
+	haltC    chan struct{}
+	doneC    chan struct{}
+	haltOnce sync.Once
+}
+
+// NewFollower creates a Follower of the channel of the given support.
+func NewFollower(
+	support consensus.ConsenterSupport,
+	createPuller CreateChannelPuller,
+	isConsenter func(configBlock *common.Block) error,
+	createChain func(),
+	clock clock.Clock,
+	pullInterval time.Duration,
+	logger *flogging.FabricLogger,
+) *Follower {
+	return &Follower{
+		Support:      support,
+		CreatePuller: createPuller,
+		IsConsenter:  isConsenter,
+		CreateChain:  createChain,
+		Clock:        clock,
+		PullInterval: pullInterval,
+		Logger:       logger.With("channel", support.ChainID()),
+		startC:       make(chan struct{}),
+		haltC:        make(chan struct{}),
+		doneC:        make(chan struct{}),
+	}
+}
+
+// Order rejects the envelope, as a Follower does not service the channel.
+func (f *Follower) Order(env *common.Envelope, configSeq uint64) error {
+	return f.notServiced()
+}
+
+// Configure rejects the config envelope, as a Follower does not service the channel.
+func (f *Follower) Configure(config *common.Envelope, configSeq uint64) error {
+	return f.notServiced()
+}
+
+// WaitReady returns an error, as a Follower does not accept envelopes.
+func (f *Follower) WaitReady() error {
+	return f.notServiced()
+}
+
+// Errored returns a channel that is closed when the Follower halts.
+// Blocks can be delivered from the channel until then.
+func (f *Follower) Errored() <-chan struct{} {
+	return f.doneC
+}
+
+// Start starts pulling the blocks of the channel.
+func (f *Follower) Start() {
+	close(f.startC)
+	go f.run()
+}
+
+// Halt stops pulling the blocks of the channel, and waits for the
+// block that is being pulled, if any.
+func (f *Follower) Halt() {
+	f.haltOnce.Do(func() { close(f.haltC) })
+
+	select {
+	case <-f.startC:
+	default:
+		return
+	}
+	<-f.doneC
+}
+
+// StatusReport reports that the orderer follows the channel.
+func (f *Follower) StatusReport() (types.ConsensusRelation, types.Status) {
+	return types.ConsensusRelationFollower, types.StatusActive
+}
+
+func (f *Follower) notServiced() error {
+	return errors.Errorf("orderer is a follower of channel %s", f.Support.ChainID())
+}
+
+func (f *Follower) run() {
+	isConsenter := f.follow()
+	// The chain is created anew after the Follower is done,
+	// since creating it halts the Follower.
+	close(f.doneC)
+	if isConsenter {
+		f.Logger.Infof("This node is a consenter of the channel as of block %d, creating its Raft chain", f.Support.Height()-1)
+		f.CreateChain()
+	}
+}
+
+// follow pulls the blocks of the channel until the Follower halts,
+// or until this node becomes a consenter of the channel, in which case it returns true.
+func (f *Follower) follow() bool {
+	ticker := f.Clock.NewTicker(f.PullInterval)
+	defer ticker.Stop()
+
+	for {
+		isConsenter, err := f.pull()
+		if err != nil {
+			f.Logger.Warningf("Failed pulling blocks: %s", err)
+		}
+		if isConsenter {
+			return true
+		}
+
+		select {
+		case <-ticker.C():
+		case <-f.haltC:
+			return false
+		}
+	}
+}
+
+// pull pulls and commits the blocks the Follower is missing, and returns
+// whether a config block that makes this node a consenter was committed.
+func (f *Follower) pull() (bool, error) {
+	puller, err := f.CreatePuller()
+	if err != nil {
+		return false, errors.Wrap(err, "failed creating block puller")
+	}
+	defer puller.Close()
+
+	heights, err := puller.HeightsByEndpoints()
+	if err != nil {
+		return false, errors.Wrap(err, "failed obtaining the heights of the channel")
+	}
+	var target uint64
+	for _, height := range heights {
+		if height > target {
+			target = height
+		}
+	}
+
+	for next := f.Support.Height(); next < target; next++ {
+		select {
+		case <-f.haltC:
+			return false, nil
+		default:
+		}
+
+		block := puller.PullBlock(next)
+		if block == nil {
+			return false, errors.Errorf("failed pulling block %d", next)
+		}
+
+		if !utils.IsConfigBlock(block) {
+			f.Support.WriteBlock(block, nil)
+			continue
+		}
+
+		// The Raft metadata of the block is kept, as it is needed once this node becomes a consenter
+		f.Support.WriteConfigBlock(block, nil)
+		if f.IsConsenter(block) == nil {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,202 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package etcdraft_test
+
+import (
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/orderer/common/types"
+	"github.com/hyperledger/fabric/orderer/consensus/etcdraft"
+	consensusmocks "github.com/hyperledger/fabric/orderer/consensus/mocks"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+// channelPuller pulls blocks from a slice of blocks that can grow
+type channelPuller struct {
+	sync.Mutex
+	blocks []*common.Block
+}
+
+func (cp *channelPuller) append(blocks ...*common.Block) {
+	cp.Lock()
+	defer cp.Unlock()
+	cp.blocks = append(cp.blocks, blocks...)
+}
+
+func (cp *channelPuller) PullBlock(seq uint64) *common.Block {
+	cp.Lock()
+	defer cp.Unlock()
+	if seq >= uint64(len(cp.blocks)) {
+		return nil
+	}
+	return cp.blocks[seq]
+}
+
+func (cp *channelPuller) HeightsByEndpoints() (map[string]uint64, error) {
+	cp.Lock()
+	defer cp.Unlock()
+	return map[string]uint64{"orderer1:7050": uint64(len(cp.blocks)), "orderer2:7050": 1}, nil
+}
+
+func (cp *channelPuller) Close() {}
+
+var _ = Describe("Follower", func() {
+	var (
+		support      *consensusmocks.FakeConsenterSupport
+		puller       *channelPuller
+		clock        *fakeclock.FakeClock
+		ledgerLock   sync.Mutex
+		ledger       []*common.Block
+		configBlocks []*common.Block
+		consenterAt  uint64
+		createChainC chan struct{}
+		follower     *etcdraft.Follower
+		pullInterval = 10 * time.Second
+	)
+
+	configBlock := func(number uint64) *common.Block {
+		env, err := utils.CreateSignedEnvelope(common.HeaderType_CONFIG, "mychannel", nil, &common.ConfigEnvelope{}, 0, 0)
+		Expect(err).NotTo(HaveOccurred())
+		block := common.NewBlock(number, nil)
+		block.Data.Data = [][]byte{utils.MarshalOrPanic(env)}
+		return block
+	}
+
+	ledgerHeight := func() uint64 {
+		ledgerLock.Lock()
+		defer ledgerLock.Unlock()
+		return uint64(len(ledger))
+	}
+
+	BeforeEach(func() {
+		ledger = []*common.Block{common.NewBlock(0, nil)}
+		configBlocks = nil
+		consenterAt = 0
+		createChainC = make(chan struct{}, 1)
+
+		support = &consensusmocks.FakeConsenterSupport{}
+		support.ChainIDReturns("mychannel")
+		support.HeightStub = ledgerHeight
+		support.WriteBlockStub = func(block *common.Block, metadata []byte) {
+			Expect(metadata).To(BeNil())
+			ledgerLock.Lock()
+			defer ledgerLock.Unlock()
+			ledger = append(ledger, block)
+		}
+		support.WriteConfigBlockStub = func(block *common.Block, metadata []byte) {
+			Expect(metadata).To(BeNil())
+			ledgerLock.Lock()
+			defer ledgerLock.Unlock()
+			ledger = append(ledger, block)
+			configBlocks = append(configBlocks, block)
+		}
+
+		puller = &channelPuller{}
+		puller.append(common.NewBlock(0, nil), common.NewBlock(1, nil), configBlock(2), common.NewBlock(3, nil))
+		clock = fakeclock.NewFakeClock(time.Now())
+
+		follower = etcdraft.NewFollower(
+			support,
+			func() (etcdraft.ChannelPuller, error) { return puller, nil },
+			func(block *common.Block) error {
+				if consenterAt != 0 && block.Header.Number == consenterAt {
+					return nil
+				}
+				return errors.New("not a consenter")
+			},
+			func() { createChainC <- struct{}{} },
+			clock,
+			pullInterval,
+			flogging.MustGetLogger("test"),
+		)
+	})
+
+	It("does not service the channel", func() {
+		Expect(follower.Order(nil, 0)).To(MatchError("orderer is a follower of channel mychannel"))
+		Expect(follower.Configure(nil, 0)).To(MatchError("orderer is a follower of channel mychannel"))
+		Expect(follower.WaitReady()).To(MatchError("orderer is a follower of channel mychannel"))
+
+		relation, status := follower.StatusReport()
+		Expect(relation).To(Equal(types.ConsensusRelationFollower))
+		Expect(status).To(Equal(types.StatusActive))
+	})
+
+	It("halts without having started", func() {
+		follower.Halt()
+		Expect(follower.Errored()).NotTo(BeClosed())
+		Expect(ledgerHeight()).To(Equal(uint64(1)))
+	})
+
+	It("pulls and commits the missing blocks", func() {
+		follower.Start()
+		defer follower.Halt()
+
+		Eventually(ledgerHeight).Should(Equal(uint64(4)))
+		ledgerLock.Lock()
+		Expect(configBlocks).To(HaveLen(1))
+		Expect(configBlocks[0].Header.Number).To(Equal(uint64(2)))
+		ledgerLock.Unlock()
+		Expect(follower.Errored()).NotTo(BeClosed())
+
+		By("pulling the blocks the cluster commits afterwards")
+		puller.append(common.NewBlock(4, nil), common.NewBlock(5, nil))
+		Consistently(ledgerHeight).Should(Equal(uint64(4)))
+		Eventually(clock.WatcherCount).Should(Equal(1))
+		clock.Increment(pullInterval)
+		Eventually(ledgerHeight).Should(Equal(uint64(6)))
+		Expect(createChainC).NotTo(Receive())
+	})
+
+	It("creates the chain when a config block makes this node a consenter", func() {
+		consenterAt = 2
+		follower.Start()
+
+		Eventually(createChainC).Should(Receive())
+		Expect(ledgerHeight()).To(Equal(uint64(3)))
+		Expect(follower.Errored()).To(BeClosed())
+
+		// Halting the Follower, as creating the chain does, returns
+		follower.Halt()
+	})
+
+	It("stops pulling once halted", func() {
+		follower.Start()
+		Eventually(ledgerHeight).Should(Equal(uint64(4)))
+		Eventually(clock.WatcherCount).Should(Equal(1))
+
+		follower.Halt()
+		Expect(follower.Errored()).To(BeClosed())
+		puller.append(common.NewBlock(4, nil))
+		clock.Increment(pullInterval)
+		Consistently(ledgerHeight).Should(Equal(uint64(4)))
+	})
+
+	It("retries when the blocks cannot be pulled", func() {
+		failures := 1
+		follower.CreatePuller = func() (etcdraft.ChannelPuller, error) {
+			if failures > 0 {
+				failures--
+				return nil, errors.New("no endpoints")
+			}
+			return puller, nil
+		}
+		follower.Start()
+		defer follower.Halt()
+
+		Eventually(clock.WatcherCount).Should(Equal(1))
+		Consistently(ledgerHeight).Should(Equal(uint64(1)))
+		clock.Increment(pullInterval)
+		Eventually(ledgerHeight).Should(Equal(uint64(4)))
+	})
+})
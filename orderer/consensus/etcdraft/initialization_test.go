@@ -9,6 +9,7 @@ package etcdraft_test
 import (
 	"testing"
 
+	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/orderer/common/cluster"
 	"github.com/hyperledger/fabric/orderer/common/localconfig"
@@ -27,7 +28,7 @@ func TestNewEtcdRaftConsenter(t *testing.T) {
 		SecOpts: &comm.SecureOptions{
 			Certificate: []byte{1, 2, 3},
 		},
-	}, srv, &multichannel.Registrar{}, &mocks.InactiveChainRegistry{})
+	}, srv, &multichannel.Registrar{}, &mocks.InactiveChainRegistry{}, &disabled.Provider{})
 
 	// Assert that the certificate from the gRPC server was passed to the consenter
 	assert.Equal(t, []byte{1, 2, 3}, consenter.Cert)
@@ -37,4 +38,5 @@ func TestNewEtcdRaftConsenter(t *testing.T) {
 	assert.NotNil(t, consenter.ChainSelector)
 	assert.NotNil(t, consenter.Dispatcher)
 	assert.NotNil(t, consenter.Logger)
+	assert.NotNil(t, consenter.Metrics)
 }
@@ -0,0 +1,107 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package etcdraft
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger/fabric/common/flogging"
+)
+
+// LeadershipPath is the path of the operations endpoint that transfers
+// the Raft leadership of a channel.
+const LeadershipPath = "/etcdraft/leadership"
+
+//go:generate mockery -dir . -name LeadershipTransferer -case underscore -output mocks
+
+// LeadershipTransferer transfers the Raft leadership of a chain
+type LeadershipTransferer interface {
+	// TransferLeadership transfers the leadership to the given consenter.
+	TransferLeadership(transferee uint64) error
+}
+
+// LeadershipTransfer is the payload of a request to transfer the leadership
+// of the given channel to the given consenter.
+type LeadershipTransfer struct {
+	Channel   string `json:"channel"`
+	Consenter uint64 `json:"consenter"`
+}
+
+// errorResponse is the payload returned when a request fails.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// LeadershipHandler serves requests to transfer the Raft leadership of a channel
+type LeadershipHandler struct {
+	Chains ChainGetter
+	Logger *flogging.FabricLogger
+}
+
+// NewLeadershipHandler creates a LeadershipHandler that looks up chains with the given ChainGetter
+func NewLeadershipHandler(chains ChainGetter) *LeadershipHandler {
+	return &LeadershipHandler{
+		Chains: chains,
+		Logger: flogging.MustGetLogger("orderer.consensus.etcdraft.leadership"),
+	}
+}
+
+func (h *LeadershipHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPut {
+		h.sendResponse(resp, http.StatusBadRequest, fmt.Errorf("invalid request method: %s", req.Method))
+		return
+	}
+
+	var transfer LeadershipTransfer
+	decoder := json.NewDecoder(req.Body)
+	if err := decoder.Decode(&transfer); err != nil {
+		h.sendResponse(resp, http.StatusBadRequest, err)
+		return
+	}
+	req.Body.Close()
+
+	if transfer.Channel == "" || transfer.Consenter == 0 {
+		h.sendResponse(resp, http.StatusBadRequest, fmt.Errorf("channel and consenter must be specified"))
+		return
+	}
+
+	cs := h.Chains.GetChain(transfer.Channel)
+	if cs == nil {
+		h.sendResponse(resp, http.StatusNotFound, fmt.Errorf("channel %s does not exist", transfer.Channel))
+		return
+	}
+
+	chain, ok := cs.Chain.(LeadershipTransferer)
+	if !ok {
+		h.sendResponse(resp, http.StatusBadRequest, fmt.Errorf("channel %s is not an etcdraft channel", transfer.Channel))
+		return
+	}
+
+	h.Logger.Infof("Transferring leadership of channel %s to %d", transfer.Channel, transfer.Consenter)
+	if err := chain.TransferLeadership(transfer.Consenter); err != nil {
+		h.sendResponse(resp, http.StatusServiceUnavailable, err)
+		return
+	}
+
+	resp.WriteHeader(http.StatusNoContent)
+}
+
+func (h *LeadershipHandler) sendResponse(resp http.ResponseWriter, code int, payload interface{}) {
+	encoder := json.NewEncoder(resp)
+	if err, ok := payload.(error); ok {
+		payload = &errorResponse{Error: err.Error()}
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(code)
+
+	if err := encoder.Encode(payload); err != nil {
+		h.Logger.Errorw("failed to encode payload", "error", err)
+	}
+}
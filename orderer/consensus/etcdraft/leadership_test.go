@@ -0,0 +1,137 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package etcdraft_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/orderer/common/multichannel"
+	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/orderer/consensus/etcdraft"
+	"github.com/hyperledger/fabric/orderer/consensus/etcdraft/mocks"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+)
+
+type transferableChain struct {
+	consensus.Chain
+	*mocks.LeadershipTransferer
+}
+
+type nonRaftChain struct {
+	consensus.Chain
+}
+
+var _ = Describe("LeadershipHandler", func() {
+	var (
+		chainGetter *mocks.ChainGetter
+		transferer  *mocks.LeadershipTransferer
+		handler     *etcdraft.LeadershipHandler
+	)
+
+	BeforeEach(func() {
+		transferer = &mocks.LeadershipTransferer{}
+		chainGetter = &mocks.ChainGetter{}
+		chainGetter.On("GetChain", "mychannel").Return(&multichannel.ChainSupport{
+			Chain: &transferableChain{LeadershipTransferer: transferer},
+		})
+		chainGetter.On("GetChain", "notraft").Return(&multichannel.ChainSupport{
+			Chain: &nonRaftChain{},
+		})
+		chainGetter.On("GetChain", mock.Anything).Return(nil)
+
+		handler = &etcdraft.LeadershipHandler{
+			Chains: chainGetter,
+			Logger: flogging.MustGetLogger("test"),
+		}
+	})
+
+	It("transfers the leadership of the channel to the given consenter", func() {
+		transferer.On("TransferLeadership", uint64(2)).Return(nil)
+
+		req := httptest.NewRequest("PUT", etcdraft.LeadershipPath, strings.NewReader(`{"channel": "mychannel", "consenter": 2}`))
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+
+		Expect(resp.Code).To(Equal(http.StatusNoContent))
+		transferer.AssertCalled(GinkgoT(), "TransferLeadership", uint64(2))
+	})
+
+	Context("when the request method is not PUT", func() {
+		It("responds with an error payload", func() {
+			req := httptest.NewRequest("GET", etcdraft.LeadershipPath, nil)
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+
+			Expect(resp.Code).To(Equal(http.StatusBadRequest))
+			Expect(resp.Body).To(MatchJSON(`{"error": "invalid request method: GET"}`))
+			Expect(resp.Header().Get("Content-Type")).To(Equal("application/json"))
+		})
+	})
+
+	Context("when the payload cannot be decoded", func() {
+		It("responds with an error payload", func() {
+			req := httptest.NewRequest("PUT", etcdraft.LeadershipPath, strings.NewReader(`goo`))
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+
+			Expect(resp.Code).To(Equal(http.StatusBadRequest))
+			Expect(resp.Body).To(MatchJSON(`{"error": "invalid character 'g' looking for beginning of value"}`))
+		})
+	})
+
+	Context("when the consenter is missing", func() {
+		It("responds with an error payload", func() {
+			req := httptest.NewRequest("PUT", etcdraft.LeadershipPath, strings.NewReader(`{"channel": "mychannel"}`))
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+
+			Expect(resp.Code).To(Equal(http.StatusBadRequest))
+			Expect(resp.Body).To(MatchJSON(`{"error": "channel and consenter must be specified"}`))
+		})
+	})
+
+	Context("when the channel does not exist", func() {
+		It("responds with an error payload", func() {
+			req := httptest.NewRequest("PUT", etcdraft.LeadershipPath, strings.NewReader(`{"channel": "nonexistent", "consenter": 2}`))
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+
+			Expect(resp.Code).To(Equal(http.StatusNotFound))
+			Expect(resp.Body).To(MatchJSON(`{"error": "channel nonexistent does not exist"}`))
+		})
+	})
+
+	Context("when the channel is not an etcdraft channel", func() {
+		It("responds with an error payload", func() {
+			req := httptest.NewRequest("PUT", etcdraft.LeadershipPath, strings.NewReader(`{"channel": "notraft", "consenter": 2}`))
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+
+			Expect(resp.Code).To(Equal(http.StatusBadRequest))
+			Expect(resp.Body).To(MatchJSON(`{"error": "channel notraft is not an etcdraft channel"}`))
+		})
+	})
+
+	Context("when the leadership transfer fails", func() {
+		It("responds with an error payload", func() {
+			transferer.On("TransferLeadership", uint64(3)).Return(errors.New("timed out transferring leadership to 3"))
+
+			req := httptest.NewRequest("PUT", etcdraft.LeadershipPath, strings.NewReader(`{"channel": "mychannel", "consenter": 3}`))
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+
+			Expect(resp.Code).To(Equal(http.StatusServiceUnavailable))
+			Expect(resp.Body).To(MatchJSON(`{"error": "timed out transferring leadership to 3"}`))
+		})
+	})
+})
@@ -0,0 +1,149 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package etcdraft
+
+import "github.com/hyperledger/fabric/common/metrics"
+
+var (
+	clusterSizeOpts = metrics.GaugeOpts{
+		Namespace:    "consensus",
+		Subsystem:    "etcdraft",
+		Name:         "cluster_size",
+		Help:         "Number of nodes in this channel.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	isLeaderOpts = metrics.GaugeOpts{
+		Namespace:    "consensus",
+		Subsystem:    "etcdraft",
+		Name:         "is_leader",
+		Help:         "The leadership status of the current node: 1 if it is the leader else 0.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	leaderIDOpts = metrics.GaugeOpts{
+		Namespace:    "consensus",
+		Subsystem:    "etcdraft",
+		Name:         "leader_id",
+		Help:         "The Raft ID of the current leader, 0 if there is no leader.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	committedBlockNumberOpts = metrics.GaugeOpts{
+		Namespace:    "consensus",
+		Subsystem:    "etcdraft",
+		Name:         "committed_block_number",
+		Help:         "The block number of the latest block committed.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	snapshotBlockNumberOpts = metrics.GaugeOpts{
+		Namespace:    "consensus",
+		Subsystem:    "etcdraft",
+		Name:         "snapshot_block_number",
+		Help:         "The block number of the latest snapshot.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	leaderChangesOpts = metrics.CounterOpts{
+		Namespace:    "consensus",
+		Subsystem:    "etcdraft",
+		Name:         "leader_changes",
+		Help:         "The number of leader changes since process start.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	proposalFailuresOpts = metrics.CounterOpts{
+		Namespace:    "consensus",
+		Subsystem:    "etcdraft",
+		Name:         "proposal_failures",
+		Help:         "The number of proposal failures.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	dataPersistDurationOpts = metrics.HistogramOpts{
+		Namespace:    "consensus",
+		Subsystem:    "etcdraft",
+		Name:         "data_persist_duration",
+		Help:         "The time taken for etcd/raft data to be persisted in storage (in seconds).",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	normalProposalsReceivedOpts = metrics.CounterOpts{
+		Namespace:    "consensus",
+		Subsystem:    "etcdraft",
+		Name:         "normal_proposals_received",
+		Help:         "The total number of proposals received for normal type transactions.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	pendingConfChangesOpts = metrics.GaugeOpts{
+		Namespace:    "consensus",
+		Subsystem:    "etcdraft",
+		Name:         "pending_conf_changes",
+		Help:         "The number of Raft configuration changes of the last config block that are not applied yet.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	configProposalsReceivedOpts = metrics.CounterOpts{
+		Namespace:    "consensus",
+		Subsystem:    "etcdraft",
+		Name:         "config_proposals_received",
+		Help:         "The total number of proposals received for config type transactions.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+)
+
+// Metrics contains the metrics of the Raft chains
+type Metrics struct {
+	ClusterSize             metrics.Gauge
+	IsLeader                metrics.Gauge
+	LeaderID                metrics.Gauge
+	CommittedBlockNumber    metrics.Gauge
+	SnapshotBlockNumber     metrics.Gauge
+	LeaderChanges           metrics.Counter
+	ProposalFailures        metrics.Counter
+	DataPersistDuration     metrics.Histogram
+	NormalProposalsReceived metrics.Counter
+	ConfigProposalsReceived metrics.Counter
+	PendingConfChanges      metrics.Gauge
+}
+
+// NewMetrics creates the metrics of the Raft chains with the given provider
+func NewMetrics(p metrics.Provider) *Metrics {
+	return &Metrics{
+		ClusterSize:             p.NewGauge(clusterSizeOpts),
+		IsLeader:                p.NewGauge(isLeaderOpts),
+		LeaderID:                p.NewGauge(leaderIDOpts),
+		CommittedBlockNumber:    p.NewGauge(committedBlockNumberOpts),
+		SnapshotBlockNumber:     p.NewGauge(snapshotBlockNumberOpts),
+		LeaderChanges:           p.NewCounter(leaderChangesOpts),
+		ProposalFailures:        p.NewCounter(proposalFailuresOpts),
+		DataPersistDuration:     p.NewHistogram(dataPersistDurationOpts),
+		NormalProposalsReceived: p.NewCounter(normalProposalsReceivedOpts),
+		ConfigProposalsReceived: p.NewCounter(configProposalsReceivedOpts),
+		PendingConfChanges:      p.NewGauge(pendingConfChangesOpts),
+	}
+}
+
+// forChannel returns the metrics with the channel label set to the given channel
+func (m *Metrics) forChannel(channel string) *Metrics {
+	return &Metrics{
+		ClusterSize:             m.ClusterSize.With("channel", channel),
+		IsLeader:                m.IsLeader.With("channel", channel),
+		LeaderID:                m.LeaderID.With("channel", channel),
+		CommittedBlockNumber:    m.CommittedBlockNumber.With("channel", channel),
+		SnapshotBlockNumber:     m.SnapshotBlockNumber.With("channel", channel),
+		LeaderChanges:           m.LeaderChanges.With("channel", channel),
+		ProposalFailures:        m.ProposalFailures.With("channel", channel),
+		DataPersistDuration:     m.DataPersistDuration.With("channel", channel),
+		NormalProposalsReceived: m.NormalProposalsReceived.With("channel", channel),
+		ConfigProposalsReceived: m.ConfigProposalsReceived.With("channel", channel),
+		PendingConfChanges:      m.PendingConfChanges.With("channel", channel),
+	}
+}
@@ -0,0 +1,57 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package etcdraft_test
+
+import (
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/hyperledger/fabric/orderer/consensus/etcdraft"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Metrics", func() {
+	Describe("NewMetrics", func() {
+		var (
+			fakeProvider  *metricsfakes.Provider
+			fakeGauge     *metricsfakes.Gauge
+			fakeCounter   *metricsfakes.Counter
+			fakeHistogram *metricsfakes.Histogram
+		)
+
+		BeforeEach(func() {
+			fakeGauge = newFakeGauge()
+			fakeCounter = newFakeCounter()
+			fakeHistogram = newFakeHistogram()
+
+			fakeProvider = &metricsfakes.Provider{}
+			fakeProvider.NewGaugeReturns(fakeGauge)
+			fakeProvider.NewCounterReturns(fakeCounter)
+			fakeProvider.NewHistogramReturns(fakeHistogram)
+		})
+
+		It("uses the provider to initialize all fields", func() {
+			metrics := etcdraft.NewMetrics(fakeProvider)
+			Expect(metrics).To(Equal(&etcdraft.Metrics{
+				ClusterSize:             fakeGauge,
+				IsLeader:                fakeGauge,
+				LeaderID:                fakeGauge,
+				CommittedBlockNumber:    fakeGauge,
+				SnapshotBlockNumber:     fakeGauge,
+				LeaderChanges:           fakeCounter,
+				ProposalFailures:        fakeCounter,
+				DataPersistDuration:     fakeHistogram,
+				NormalProposalsReceived: fakeCounter,
+				ConfigProposalsReceived: fakeCounter,
+				PendingConfChanges:      fakeGauge,
+			}))
+
+			Expect(fakeProvider.NewGaugeCallCount()).To(Equal(6))
+			Expect(fakeProvider.NewCounterCallCount()).To(Equal(4))
+			Expect(fakeProvider.NewHistogramCallCount()).To(Equal(1))
+		})
+	})
+})
@@ -7,7 +7,9 @@ SPDX-License-Identifier: Apache-2.0
 package etcdraft
 
 import (
+	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"code.cloudfoundry.org/clock"
@@ -37,6 +39,9 @@ type node struct {
 	clock        clock.Clock
 
 	metadata *etcdraft.RaftMetadata
+	metrics  *Metrics
+
+	subscriberC chan chan uint64 // used to get notified of the next leader change
 
 	raft.Node
 }
@@ -67,20 +72,39 @@ func (n *node) run() {
 		n.chain.snapC <- &s
 	}
 
+	var lead uint64
+	var subscribers []chan uint64
+
 	for {
 		select {
 		case <-ticker.C():
 			n.Tick()
 
+		case notifyC := <-n.subscriberC:
+			subscribers = append(subscribers, notifyC)
+
 		case rd := <-n.Ready():
+			startStoring := n.clock.Now()
 			if err := n.storage.Store(rd.Entries, rd.HardState, rd.Snapshot); err != nil {
 				n.logger.Panicf("Failed to persist etcd/raft data: %s", err)
 			}
+			n.metrics.DataPersistDuration.Observe(n.clock.Since(startStoring).Seconds())
 
 			if !raft.IsEmptySnap(rd.Snapshot) {
 				n.chain.snapC <- &rd.Snapshot
 			}
 
+			if rd.SoftState != nil {
+				newLead := atomic.LoadUint64(&rd.SoftState.Lead) // etcdraft requires atomic access
+				if newLead != raft.None && newLead != lead {
+					for _, notifyC := range subscribers {
+						notifyC <- newLead
+					}
+					subscribers = nil
+				}
+				lead = newLead
+			}
+
 			n.chain.applyC <- apply{rd.CommittedEntries, rd.SoftState}
 			n.Advance()
 
@@ -99,6 +123,67 @@ func (n *node) run() {
 	}
 }
 
+// abdicateLeader transfers the leadership to the most up-to-date active follower
+// if this node is the Raft leader, so that the cluster does not need to wait for
+// an election timeout to elect a new leader once this node stops.
+func (n *node) abdicateLeader() {
+	status := n.Status()
+	if status.RaftState != raft.StateLeader {
+		return
+	}
+
+	var transferee, match uint64
+	for id, pr := range status.Progress {
+		if id == status.ID || !pr.RecentActive || pr.IsLearner {
+			continue
+		}
+
+		if transferee == raft.None || pr.Match > match || (pr.Match == match && id < transferee) {
+			transferee, match = id, pr.Match
+		}
+	}
+
+	if transferee == raft.None {
+		n.logger.Infof("No active follower to transfer leadership to")
+		return
+	}
+
+	n.logger.Infof("Transferring leadership to %d before stopping", transferee)
+	newLead := n.transferLeadership(status.ID, transferee)
+	if newLead == raft.None {
+		n.logger.Warnf("Leadership transfer to %d timed out", transferee)
+		return
+	}
+
+	n.logger.Infof("Leadership transferred to %d", newLead)
+}
+
+// transferLeadership asks the given leader to transfer its leadership to the
+// transferee, and waits until a new leader is known to this node. It returns the
+// new leader, or raft.None if no leader is elected within an election timeout.
+func (n *node) transferLeadership(lead, transferee uint64) uint64 {
+	notifyC := make(chan uint64, 1)
+	select {
+	case n.subscriberC <- notifyC:
+	case <-n.chain.doneC:
+		return raft.None
+	}
+
+	n.TransferLeadership(context.TODO(), lead, transferee)
+
+	timer := n.clock.NewTimer(time.Duration(n.config.ElectionTick) * n.tickInterval)
+	defer timer.Stop()
+
+	select {
+	case newLead := <-notifyC:
+		return newLead
+	case <-timer.C():
+		return raft.None
+	case <-n.chain.doneC:
+		return raft.None
+	}
+}
+
 func (n *node) send(msgs []raftpb.Message) {
 	n.unreachableLock.RLock()
 	defer n.unreachableLock.RUnlock()
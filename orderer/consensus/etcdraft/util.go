@@ -9,6 +9,7 @@ package etcdraft
 import (
 	"bytes"
 	"encoding/pem"
+	"sort"
 
 	"github.com/coreos/etcd/raft"
 	"github.com/coreos/etcd/raft/raftpb"
@@ -31,51 +32,56 @@ import (
 type MembershipChanges struct {
 	AddedNodes   []*etcdraft.Consenter
 	RemovedNodes []*etcdraft.Consenter
+	RotatedNodes map[uint64]*etcdraft.Consenter // consenters whose TLS certificates are rotated, by Raft ID
 	TotalChanges uint32
 }
 
+// Rotated returns true if the membership changes rotate the TLS certificates of consenters
+func (mc *MembershipChanges) Rotated() bool {
+	return mc != nil && len(mc.RotatedNodes) > 0
+}
+
 // UpdateRaftMetadataAndConfChange given the membership changes and RaftMetadata method calculates
 // updates to be applied to the raft  cluster configuration in addition updates mapping between
-// consenter and its id within metadata
+// consenter and its id within metadata. All the membership changes are applied to the metadata,
+// while the returned ConfChange is the first one of the Raft configuration changes that are
+// needed to reflect them, the remaining ones are computed with ConfChange once it is applied
 func (mc *MembershipChanges) UpdateRaftMetadataAndConfChange(raftMetadata *etcdraft.RaftMetadata) *raftpb.ConfChange {
-	if mc == nil || mc.TotalChanges == 0 {
+	if mc == nil {
 		return nil
 	}
 
-	var confChange *raftpb.ConfChange
+	// a consenter whose TLS certificates are rotated keeps its Raft ID,
+	// hence it needs no Raft configuration change
+	for nodeID, c := range mc.RotatedNodes {
+		raftMetadata.Consenters[nodeID] = c
+	}
 
-	// producing corresponding raft configuration changes
-	if len(mc.AddedNodes) > 0 {
-		nodeID := raftMetadata.NextConsenterId
-		raftMetadata.Consenters[nodeID] = mc.AddedNodes[0]
+	if mc.TotalChanges == 0 {
+		return nil
+	}
+
+	// the Raft configuration state is in sync with the consenters before the update
+	confState := &raftpb.ConfState{Nodes: SliceOfConsentersIDs(raftMetadata.Consenters)}
+
+	for _, c := range mc.AddedNodes {
+		raftMetadata.Consenters[raftMetadata.NextConsenterId] = c
 		raftMetadata.NextConsenterId++
-		confChange = &raftpb.ConfChange{
-			ID:     raftMetadata.ConfChangeCounts,
-			NodeID: nodeID,
-			Type:   raftpb.ConfChangeAddNode,
-		}
-		raftMetadata.ConfChangeCounts++
-		return confChange
-	}
-
-	if len(mc.RemovedNodes) > 0 {
-		for _, c := range mc.RemovedNodes {
-			for nodeID, node := range raftMetadata.Consenters {
-				if bytes.Equal(c.ClientTlsCert, node.ClientTlsCert) {
-					delete(raftMetadata.Consenters, nodeID)
-					confChange = &raftpb.ConfChange{
-						ID:     raftMetadata.ConfChangeCounts,
-						NodeID: nodeID,
-						Type:   raftpb.ConfChangeRemoveNode,
-					}
-					raftMetadata.ConfChangeCounts++
-					break
-				}
+	}
+
+	for _, c := range mc.RemovedNodes {
+		for nodeID, node := range raftMetadata.Consenters {
+			if bytes.Equal(c.ClientTlsCert, node.ClientTlsCert) {
+				delete(raftMetadata.Consenters, nodeID)
+				break
 			}
 		}
 	}
 
-	return confChange
+	// producing corresponding raft configuration changes
+	raftMetadata.ConfChangeCounts += uint64(mc.TotalChanges)
+
+	return ConfChange(raftMetadata, confState)
 }
 
 // EndpointconfigFromFromSupport extracts TLS CA certificates and endpoints from the ConsenterSupport
@@ -181,25 +187,53 @@ func MembershipByCert(consenters map[uint64]*etcdraft.Consenter) map[string]stru
 }
 
 // ComputeMembershipChanges computes membership update based on information about new conseters, returns
-// two slices: a slice of added consenters and a slice of consenters to be removed
+// two slices: a slice of added consenters and a slice of consenters to be removed. A consenter
+// whose certificates change while its endpoint stays the same is not added and removed, but
+// rotated in place, so that it keeps its Raft ID
 func ComputeMembershipChanges(oldConsenters map[uint64]*etcdraft.Consenter, newConsenters []*etcdraft.Consenter) *MembershipChanges {
 	result := &MembershipChanges{
 		AddedNodes:   []*etcdraft.Consenter{},
 		RemovedNodes: []*etcdraft.Consenter{},
+		RotatedNodes: map[uint64]*etcdraft.Consenter{},
 	}
 
+	var added []*etcdraft.Consenter
 	currentConsentersSet := MembershipByCert(oldConsenters)
 	for _, c := range newConsenters {
 		if _, exists := currentConsentersSet[string(c.ClientTlsCert)]; !exists {
-			result.AddedNodes = append(result.AddedNodes, c)
-			result.TotalChanges++
+			added = append(added, c)
 		}
 	}
 
+	var removed []uint64
 	newConsentersSet := ConsentersToMap(newConsenters)
-	for _, c := range oldConsenters {
+	for nodeID, c := range oldConsenters {
 		if _, exists := newConsentersSet[string(c.ClientTlsCert)]; !exists {
-			result.RemovedNodes = append(result.RemovedNodes, c)
+			removed = append(removed, nodeID)
+		}
+	}
+	sort.Slice(removed, func(i, j int) bool { return removed[i] < removed[j] })
+
+	for _, nodeID := range removed {
+		old := oldConsenters[nodeID]
+		rotated := false
+		for i, c := range added {
+			if c != nil && c.Host == old.Host && c.Port == old.Port {
+				result.RotatedNodes[nodeID] = c
+				added[i] = nil
+				rotated = true
+				break
+			}
+		}
+		if !rotated {
+			result.RemovedNodes = append(result.RemovedNodes, old)
+			result.TotalChanges++
+		}
+	}
+
+	for _, c := range added {
+		if c != nil {
+			result.AddedNodes = append(result.AddedNodes, c)
 			result.TotalChanges++
 		}
 	}
@@ -353,32 +387,58 @@ func NodeExists(id uint64, nodes []uint64) bool {
 }
 
 // ConfChange computes Raft configuration changes based on current Raft configuration state and
-// consenters mapping stored in RaftMetadata
+// consenters mapping stored in RaftMetadata. Membership changes are applied one node at a time,
+// hence the next change is returned, or nil if the Raft configuration is in sync with the consenters.
+// Nodes are added before others are removed as long as there are at least as many nodes to add
+// as to remove, so that the size of the cluster stays between its sizes before and after the update
 func ConfChange(raftMetadata *etcdraft.RaftMetadata, confState *raftpb.ConfState) *raftpb.ConfChange {
+	added, removed := membershipDiff(raftMetadata, confState)
+	if len(added) == 0 && len(removed) == 0 {
+		return nil
+	}
+
 	raftConfChange := &raftpb.ConfChange{}
 
-	raftConfChange.ID = raftMetadata.ConfChangeCounts
+	// ConfChangeCounts already accounts for all the changes of the last config update,
+	// hence the pending ones are numbered backwards from it to get a distinct ID for each
+	raftConfChange.ID = raftMetadata.ConfChangeCounts - uint64(len(added)+len(removed))
 	// need to compute conf changes to propose
-	if len(confState.Nodes) < len(raftMetadata.Consenters) {
+	if len(added) > 0 && len(added) >= len(removed) {
 		// adding new node
 		raftConfChange.Type = raftpb.ConfChangeAddNode
-		for consenterID := range raftMetadata.Consenters {
-			if NodeExists(consenterID, confState.Nodes) {
-				continue
-			}
-			raftConfChange.NodeID = consenterID
-		}
+		raftConfChange.NodeID = added[0]
 	} else {
 		// removing node
 		raftConfChange.Type = raftpb.ConfChangeRemoveNode
-		consentersIDs := SliceOfConsentersIDs(raftMetadata.Consenters)
-		for _, nodeID := range confState.Nodes {
-			if NodeExists(nodeID, consentersIDs) {
-				continue
-			}
-			raftConfChange.NodeID = nodeID
-		}
+		raftConfChange.NodeID = removed[0]
 	}
 
 	return raftConfChange
 }
+
+// PendingConfChanges returns the number of Raft configuration changes needed to bring
+// the Raft configuration state in sync with the consenters mapping stored in RaftMetadata
+func PendingConfChanges(raftMetadata *etcdraft.RaftMetadata, confState *raftpb.ConfState) int {
+	added, removed := membershipDiff(raftMetadata, confState)
+	return len(added) + len(removed)
+}
+
+// membershipDiff returns the sorted IDs of the consenters which are not part of the Raft
+// configuration yet and of the Raft nodes which are not consenters anymore
+func membershipDiff(raftMetadata *etcdraft.RaftMetadata, confState *raftpb.ConfState) (added, removed []uint64) {
+	consentersIDs := SliceOfConsentersIDs(raftMetadata.Consenters)
+	for _, consenterID := range consentersIDs {
+		if !NodeExists(consenterID, confState.Nodes) {
+			added = append(added, consenterID)
+		}
+	}
+	for _, nodeID := range confState.Nodes {
+		if !NodeExists(nodeID, consentersIDs) {
+			removed = append(removed, nodeID)
+		}
+	}
+
+	sort.Slice(added, func(i, j int) bool { return added[i] < added[j] })
+	sort.Slice(removed, func(i, j int) bool { return removed[i] < removed[j] })
+	return added, removed
+}
@@ -12,6 +12,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/coreos/etcd/raft/raftpb"
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/crypto/tlsgen"
 	"github.com/hyperledger/fabric/core/comm"
@@ -20,6 +21,7 @@ import (
 	"github.com/hyperledger/fabric/orderer/consensus"
 	"github.com/hyperledger/fabric/orderer/mocks/common/multichannel"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/orderer/etcdraft"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/stretchr/testify/assert"
 )
@@ -262,3 +264,169 @@ func TestNewBlockPuller(t *testing.T) {
 		})
 	}
 }
+
+func TestConfChange(t *testing.T) {
+	consenters := func(ids ...uint64) map[uint64]*etcdraft.Consenter {
+		result := map[uint64]*etcdraft.Consenter{}
+		for _, id := range ids {
+			result[id] = &etcdraft.Consenter{}
+		}
+		return result
+	}
+
+	for _, testCase := range []struct {
+		name               string
+		consenters         map[uint64]*etcdraft.Consenter
+		nodes              []uint64
+		expectedConfChange *raftpb.ConfChange
+		expectedPending    int
+	}{
+		{
+			name:       "in sync",
+			consenters: consenters(1, 2, 3),
+			nodes:      []uint64{3, 1, 2},
+		},
+		{
+			name:               "add node",
+			consenters:         consenters(1, 2, 3, 4),
+			nodes:              []uint64{1, 2, 3},
+			expectedConfChange: &raftpb.ConfChange{ID: 6, NodeID: 4, Type: raftpb.ConfChangeAddNode},
+			expectedPending:    1,
+		},
+		{
+			name:               "remove node",
+			consenters:         consenters(1, 3),
+			nodes:              []uint64{1, 2, 3},
+			expectedConfChange: &raftpb.ConfChange{ID: 6, NodeID: 2, Type: raftpb.ConfChangeRemoveNode},
+			expectedPending:    1,
+		},
+		{
+			name:               "add nodes before removing as many",
+			consenters:         consenters(3, 4, 5),
+			nodes:              []uint64{1, 2, 3},
+			expectedConfChange: &raftpb.ConfChange{ID: 3, NodeID: 4, Type: raftpb.ConfChangeAddNode},
+			expectedPending:    4,
+		},
+		{
+			name:               "remove nodes before adding fewer",
+			consenters:         consenters(3, 4),
+			nodes:              []uint64{2, 1, 3},
+			expectedConfChange: &raftpb.ConfChange{ID: 4, NodeID: 1, Type: raftpb.ConfChangeRemoveNode},
+			expectedPending:    3,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			raftMetadata := &etcdraft.RaftMetadata{Consenters: testCase.consenters, ConfChangeCounts: 7}
+			confState := &raftpb.ConfState{Nodes: testCase.nodes}
+			assert.Equal(t, testCase.expectedConfChange, ConfChange(raftMetadata, confState))
+			assert.Equal(t, testCase.expectedPending, PendingConfChanges(raftMetadata, confState))
+		})
+	}
+}
+
+func TestUpdateRaftMetadataAndConfChange(t *testing.T) {
+	consenter := func(cert string) *etcdraft.Consenter {
+		return &etcdraft.Consenter{Host: cert, ClientTlsCert: []byte(cert)}
+	}
+
+	raftMetadata := &etcdraft.RaftMetadata{
+		Consenters: map[uint64]*etcdraft.Consenter{
+			1: consenter("a"),
+			2: consenter("b"),
+			3: consenter("c"),
+		},
+		NextConsenterId:  4,
+		ConfChangeCounts: 5,
+	}
+
+	var changes *MembershipChanges
+	assert.Nil(t, changes.UpdateRaftMetadataAndConfChange(raftMetadata))
+	changes = ComputeMembershipChanges(raftMetadata.Consenters, []*etcdraft.Consenter{consenter("a"), consenter("b"), consenter("c")})
+	assert.Nil(t, changes.UpdateRaftMetadataAndConfChange(raftMetadata))
+
+	// replace node 1 and add another node
+	changes = ComputeMembershipChanges(raftMetadata.Consenters,
+		[]*etcdraft.Consenter{consenter("b"), consenter("c"), consenter("d"), consenter("e")})
+	assert.Equal(t, uint32(3), changes.TotalChanges)
+	confChange := changes.UpdateRaftMetadataAndConfChange(raftMetadata)
+	assert.Equal(t, &raftpb.ConfChange{ID: 5, NodeID: 4, Type: raftpb.ConfChangeAddNode}, confChange)
+	assert.Equal(t, map[uint64]*etcdraft.Consenter{
+		2: consenter("b"),
+		3: consenter("c"),
+		4: consenter("d"),
+		5: consenter("e"),
+	}, raftMetadata.Consenters)
+	assert.Equal(t, uint64(6), raftMetadata.NextConsenterId)
+	assert.Equal(t, uint64(8), raftMetadata.ConfChangeCounts)
+
+	// apply the ConfChanges one at a time until the Raft configuration is in sync
+	confState := &raftpb.ConfState{Nodes: []uint64{1, 2, 3}}
+	var applied []raftpb.ConfChange
+	for confChange != nil {
+		applied = append(applied, *confChange)
+		switch confChange.Type {
+		case raftpb.ConfChangeAddNode:
+			confState.Nodes = append(confState.Nodes, confChange.NodeID)
+		case raftpb.ConfChangeRemoveNode:
+			var nodes []uint64
+			for _, node := range confState.Nodes {
+				if node != confChange.NodeID {
+					nodes = append(nodes, node)
+				}
+			}
+			confState.Nodes = nodes
+		}
+		assert.Equal(t, 3-len(applied), PendingConfChanges(raftMetadata, confState))
+		confChange = ConfChange(raftMetadata, confState)
+	}
+
+	assert.Equal(t, []raftpb.ConfChange{
+		{ID: 5, NodeID: 4, Type: raftpb.ConfChangeAddNode},
+		{ID: 6, NodeID: 5, Type: raftpb.ConfChangeAddNode},
+		{ID: 7, NodeID: 1, Type: raftpb.ConfChangeRemoveNode},
+	}, applied)
+}
+
+func TestUpdateRaftMetadataAndConfChangeWithRotation(t *testing.T) {
+	consenter := func(host, cert string) *etcdraft.Consenter {
+		return &etcdraft.Consenter{Host: host, Port: 7050, ClientTlsCert: []byte(cert)}
+	}
+
+	raftMetadata := &etcdraft.RaftMetadata{
+		Consenters: map[uint64]*etcdraft.Consenter{
+			1: consenter("a", "a1"),
+			2: consenter("b", "b1"),
+			3: consenter("c", "c1"),
+		},
+		NextConsenterId:  4,
+		ConfChangeCounts: 5,
+	}
+
+	// rotate the certificates of nodes 1 and 3
+	changes := ComputeMembershipChanges(raftMetadata.Consenters,
+		[]*etcdraft.Consenter{consenter("a", "a2"), consenter("b", "b1"), consenter("c", "c2")})
+	assert.True(t, changes.Rotated())
+	assert.Equal(t, uint32(0), changes.TotalChanges)
+	assert.Nil(t, changes.UpdateRaftMetadataAndConfChange(raftMetadata))
+	assert.Equal(t, map[uint64]*etcdraft.Consenter{
+		1: consenter("a", "a2"),
+		2: consenter("b", "b1"),
+		3: consenter("c", "c2"),
+	}, raftMetadata.Consenters)
+	assert.Equal(t, uint64(4), raftMetadata.NextConsenterId)
+	assert.Equal(t, uint64(5), raftMetadata.ConfChangeCounts)
+
+	// rotate the certificate of node 2 and replace node 3
+	changes = ComputeMembershipChanges(raftMetadata.Consenters,
+		[]*etcdraft.Consenter{consenter("a", "a2"), consenter("b", "b2"), consenter("d", "d1")})
+	assert.True(t, changes.Rotated())
+	assert.Equal(t, uint32(2), changes.TotalChanges)
+	confChange := changes.UpdateRaftMetadataAndConfChange(raftMetadata)
+	assert.Equal(t, &raftpb.ConfChange{ID: 5, NodeID: 4, Type: raftpb.ConfChangeAddNode}, confChange)
+	assert.Equal(t, map[uint64]*etcdraft.Consenter{
+		1: consenter("a", "a2"),
+		2: consenter("b", "b2"),
+		4: consenter("d", "d1"),
+	}, raftMetadata.Consenters)
+	assert.Equal(t, uint64(7), raftMetadata.ConfChangeCounts)
+}
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package inactive
 
 import (
+	"github.com/hyperledger/fabric/orderer/common/types"
 	"github.com/hyperledger/fabric/protos/common"
 )
 
@@ -42,3 +43,8 @@ func (c *Chain) Start() {
 func (c *Chain) Halt() {
 
 }
+
+// StatusReport reports that the orderer only tracks the configuration of the channel.
+func (c *Chain) StatusReport() (types.ConsensusRelation, types.Status) {
+	return types.ConsensusRelationConfigTracker, types.StatusInactive
+}
@@ -15,9 +15,11 @@ import (
 
 	"github.com/Shopify/sarama"
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	localconfig "github.com/hyperledger/fabric/orderer/common/localconfig"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
 	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/orderer/consensus/migration"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 	"github.com/hyperledger/fabric/protos/utils"
@@ -63,6 +65,9 @@ func newChain(
 		close(doneReprocessingMsgInFlight)
 	}
 
+	migrationStatusStepper := migration.NewStatusStepper(support.IsSystemChannel(), support.ChainID(), "kafka")
+	migrationStatusStepper.SetStateContext(support.SharedConfig().ConsensusMigrationState(), support.SharedConfig().ConsensusMigrationContext())
+
 	return &chainImpl{
 		consenter:                   consenter,
 		ConsenterSupport:            support,
@@ -75,6 +80,8 @@ func newChain(
 		haltChan:                    make(chan struct{}),
 		startChan:                   make(chan struct{}),
 		doneReprocessingMsgInFlight: doneReprocessingMsgInFlight,
+		migrationStatusStepper:      migrationStatusStepper,
+		migrationController:         consenter.migrationController(),
 	}, nil
 }
 
@@ -120,6 +127,17 @@ type chainImpl struct {
 	timer <-chan time.Time
 
 	replicaIDs []int32
+
+	// provides access to the consensus-type migration status of the chain,
+	// and allows stepping through the migration state machine.
+	migrationStatusStepper migration.StatusStepper
+	// coordinates the consensus-type migration with the other chains.
+	migrationController migration.Controller
+}
+
+// MigrationStatus provides access to the consensus-type migration status of the chain.
+func (chain *chainImpl) MigrationStatus() migration.Status {
+	return chain.migrationStatusStepper
 }
 
 // Errored returns a channel which will close when a partition consumer error
@@ -212,6 +230,10 @@ func (chain *chainImpl) Order(env *cb.Envelope, configSeq uint64) error {
 }
 
 func (chain *chainImpl) order(env *cb.Envelope, configSeq uint64, originalOffset int64) error {
+	if chain.migrationStatusStepper.IsPending() || chain.migrationStatusStepper.IsCommitted() {
+		return fmt.Errorf("cannot enqueue, consensus-type migration pending")
+	}
+
 	marshaledEnv, err := utils.Marshal(env)
 	if err != nil {
 		return fmt.Errorf("cannot enqueue, unable to marshal envelope because = %s", err)
@@ -613,6 +635,13 @@ func (chain *chainImpl) processRegular(regularMessage *ab.KafkaMessageRegular, r
 	// - if the message is re-validated and re-ordered, this value should be the `OriginalOffset` of that
 	//   Kafka message, so that `lastOriginalOffsetProcessed` is advanced
 	commitNormalMsg := func(message *cb.Envelope, newOffset int64) {
+		if chain.migrationStatusStepper.IsPending() || chain.migrationStatusStepper.IsCommitted() {
+			// Normal messages that were ordered before consensus-type migration started are dropped
+			logger.Warningf("[channel: %s] Dropping normal message, consensus-type migration pending", chain.ChainID())
+			chain.lastOriginalOffsetProcessed = newOffset
+			return
+		}
+
 		batches, pending := chain.BlockCutter().Ordered(message)
 		logger.Debugf("[channel: %s] Ordering results: items in batch = %d, pending = %v", chain.ChainID(), len(batches), pending)
 
@@ -622,8 +651,9 @@ func (chain *chainImpl) processRegular(regularMessage *ab.KafkaMessageRegular, r
 			chain.timer = nil
 		case chain.timer == nil && pending:
 			// Timer is not already running and there are messages pending, so start it
-			chain.timer = time.After(chain.SharedConfig().BatchTimeout())
-			logger.Debugf("[channel: %s] Just began %s batch timer", chain.ChainID(), chain.SharedConfig().BatchTimeout().String())
+			batchTimeout := blockcutter.BatchTimeout(chain.BlockCutter(), chain.SharedConfig())
+			chain.timer = time.After(batchTimeout)
+			logger.Debugf("[channel: %s] Just began %s batch timer", chain.ChainID(), batchTimeout.String())
 		default:
 			// Do nothing when:
 			// 1. Timer is already running and there are messages pending
@@ -702,17 +732,34 @@ func (chain *chainImpl) processRegular(regularMessage *ab.KafkaMessageRegular, r
 			chain.lastCutBlockNumber++
 		}
 
-		logger.Debugf("[channel: %s] Creating isolated block for config message", chain.ChainID())
 		chain.lastOriginalOffsetProcessed = newOffset
+		chain.timer = nil
+
+		commitBlock, _, raftMetadata, err := migration.StepConfigMsg(
+			chain.migrationStatusStepper, chain.ChainID(), message, chain.lastCutBlockNumber, chain.migrationController)
+		if err != nil {
+			logger.Warningf("[channel: %s] Dropping config message, failed to evaluate consensus-type migration: %s", chain.ChainID(), err)
+			return
+		}
+		if !commitBlock {
+			logger.Warningf("[channel: %s] Dropping config message, rejected by consensus-type migration; Status: %s",
+				chain.ChainID(), chain.migrationStatusStepper)
+			return
+		}
+
+		logger.Debugf("[channel: %s] Creating isolated block for config message", chain.ChainID())
 		block := chain.CreateNextBlock([]*cb.Envelope{message})
 		metadata := utils.MarshalOrPanic(&ab.KafkaMetadata{
 			LastOffsetPersisted:         receivedOffset,
 			LastOriginalOffsetProcessed: chain.lastOriginalOffsetProcessed,
 			LastResubmittedConfigOffset: chain.lastResubmittedConfigOffset,
 		})
+		if raftMetadata != nil {
+			// The Raft chain that takes over after the migration reads its metadata from this block
+			metadata = raftMetadata
+		}
 		chain.WriteConfigBlock(block, metadata)
 		chain.lastCutBlockNumber++
-		chain.timer = nil
 	}
 
 	seq := chain.Sequence()
@@ -18,6 +18,7 @@ import (
 	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
 	lmock "github.com/hyperledger/fabric/orderer/consensus/kafka/mock"
+	"github.com/hyperledger/fabric/orderer/consensus/migration"
 	mockblockcutter "github.com/hyperledger/fabric/orderer/mocks/common/blockcutter"
 	mockmultichannel "github.com/hyperledger/fabric/orderer/mocks/common/multichannel"
 	cb "github.com/hyperledger/fabric/protos/common"
@@ -406,6 +407,42 @@ func TestChain(t *testing.T) {
 			// We don't need to create a legit envelope here as it's not inspected during this test
 			assert.NoError(t, chain.Order(&cb.Envelope{}, uint64(0)), "Expect Order successfully")
 		})
+
+		t.Run("ErrorIfMigrationPending", func(t *testing.T) {
+			mockChannel, mockBroker, mockSupport := newMocks(t)
+			defer func() { mockBroker.Close() }()
+			chain, _ := newChain(mockConsenter, mockSupport, newestOffset-1, lastOriginalOffsetProcessed, lastResubmittedConfigOffset)
+
+			mockBroker.SetHandlerByMap(map[string]sarama.MockResponse{
+				"MetadataRequest": sarama.NewMockMetadataResponse(t).
+					SetBroker(mockBroker.Addr(), mockBroker.BrokerID()).
+					SetLeader(mockChannel.topic(), mockChannel.partition(), mockBroker.BrokerID()),
+				"ProduceRequest": sarama.NewMockProduceResponse(t).
+					SetError(mockChannel.topic(), mockChannel.partition(), sarama.ErrNoError),
+				"OffsetRequest": sarama.NewMockOffsetResponse(t).
+					SetOffset(mockChannel.topic(), mockChannel.partition(), sarama.OffsetOldest, oldestOffset).
+					SetOffset(mockChannel.topic(), mockChannel.partition(), sarama.OffsetNewest, newestOffset),
+				"FetchRequest": sarama.NewMockFetchResponse(t, 1).
+					SetMessage(mockChannel.topic(), mockChannel.partition(), newestOffset, message),
+			})
+
+			chain.Start()
+			defer chain.Halt()
+
+			select {
+			case <-chain.startChan:
+				logger.Debug("startChan is closed as it should be")
+			case <-time.After(shortTimeout):
+				t.Fatal("startChan should have been closed by now")
+			}
+
+			chain.MigrationStatus().SetStateContext(ab.ConsensusType_MIG_STATE_CONTEXT, 5)
+			assert.EqualError(t, chain.Order(&cb.Envelope{}, uint64(0)), "cannot enqueue, consensus-type migration pending")
+
+			// Aborting the migration resumes ordering
+			chain.MigrationStatus().SetStateContext(ab.ConsensusType_MIG_STATE_ABORT, 5)
+			assert.NoError(t, chain.Order(&cb.Envelope{}, uint64(0)), "Expect Order successfully")
+		})
 	})
 
 	t.Run("Configure", func(t *testing.T) {
@@ -999,6 +1036,7 @@ func TestProcessMessagesToBlocks(t *testing.T) {
 				errorChan:                      errorChan,
 				haltChan:                       haltChan,
 				doneProcessingMessagesToBlocks: make(chan struct{}),
+				migrationStatusStepper:         migration.NewStatusStepper(false, channelNameForTest(t), "kafka"),
 			}
 
 			// We need the mock blockcutter to deliver a non-empty batch
@@ -1071,6 +1109,7 @@ func TestProcessMessagesToBlocks(t *testing.T) {
 				errorChan:                      errorChan,
 				haltChan:                       haltChan,
 				doneProcessingMessagesToBlocks: make(chan struct{}),
+				migrationStatusStepper:         migration.NewStatusStepper(false, channelNameForTest(t), "kafka"),
 			}
 
 			// We need the mock blockcutter to deliver a non-empty batch
@@ -1131,6 +1170,7 @@ func TestProcessMessagesToBlocks(t *testing.T) {
 				errorChan:                      errorChan,
 				haltChan:                       haltChan,
 				doneProcessingMessagesToBlocks: make(chan struct{}),
+				migrationStatusStepper:         migration.NewStatusStepper(false, channelNameForTest(t), "kafka"),
 			}
 
 			var counts []uint64
@@ -1181,6 +1221,7 @@ func TestProcessMessagesToBlocks(t *testing.T) {
 				errorChan:                      errorChan,
 				haltChan:                       haltChan,
 				doneProcessingMessagesToBlocks: make(chan struct{}),
+				migrationStatusStepper:         migration.NewStatusStepper(false, channelNameForTest(t), "kafka"),
 			}
 
 			var counts []uint64
@@ -1231,6 +1272,7 @@ func TestProcessMessagesToBlocks(t *testing.T) {
 				errorChan:                      errorChan,
 				haltChan:                       haltChan,
 				doneProcessingMessagesToBlocks: make(chan struct{}),
+				migrationStatusStepper:         migration.NewStatusStepper(false, channelNameForTest(t), "kafka"),
 			}
 
 			var counts []uint64
@@ -1276,6 +1318,7 @@ func TestProcessMessagesToBlocks(t *testing.T) {
 				errorChan:                      errorChan,
 				haltChan:                       haltChan,
 				doneProcessingMessagesToBlocks: make(chan struct{}),
+				migrationStatusStepper:         migration.NewStatusStepper(false, channelNameForTest(t), "kafka"),
 			}
 
 			var counts []uint64
@@ -1320,6 +1363,7 @@ func TestProcessMessagesToBlocks(t *testing.T) {
 				errorChan:                      errorChan,
 				haltChan:                       haltChan,
 				doneProcessingMessagesToBlocks: make(chan struct{}),
+				migrationStatusStepper:         migration.NewStatusStepper(false, channelNameForTest(t), "kafka"),
 			}
 
 			var counts []uint64
@@ -1374,6 +1418,7 @@ func TestProcessMessagesToBlocks(t *testing.T) {
 					errorChan:                      errorChan,
 					haltChan:                       haltChan,
 					doneProcessingMessagesToBlocks: make(chan struct{}),
+					migrationStatusStepper:         migration.NewStatusStepper(false, channelNameForTest(t), "kafka"),
 				}
 
 				var counts []uint64
@@ -1429,7 +1474,9 @@ func TestProcessMessagesToBlocks(t *testing.T) {
 
 					errorChan:                      errorChan,
 					haltChan:                       haltChan,
-					doneProcessingMessagesToBlocks: make(chan struct{})}
+					doneProcessingMessagesToBlocks: make(chan struct{}),
+					migrationStatusStepper:         migration.NewStatusStepper(false, channelNameForTest(t), "kafka"),
+				}
 
 				var counts []uint64
 				done := make(chan struct{})
@@ -1493,6 +1540,7 @@ func TestProcessMessagesToBlocks(t *testing.T) {
 					errorChan:                      errorChan,
 					haltChan:                       haltChan,
 					doneProcessingMessagesToBlocks: make(chan struct{}),
+					migrationStatusStepper:         migration.NewStatusStepper(false, channelNameForTest(t), "kafka"),
 				}
 
 				var counts []uint64
@@ -1577,6 +1625,7 @@ func TestProcessMessagesToBlocks(t *testing.T) {
 					errorChan:                      errorChan,
 					haltChan:                       haltChan,
 					doneProcessingMessagesToBlocks: make(chan struct{}),
+					migrationStatusStepper:         migration.NewStatusStepper(false, channelNameForTest(t), "kafka"),
 				}
 
 				var counts []uint64
@@ -1633,6 +1682,7 @@ func TestProcessMessagesToBlocks(t *testing.T) {
 					errorChan:                      errorChan,
 					haltChan:                       haltChan,
 					doneProcessingMessagesToBlocks: make(chan struct{}),
+					migrationStatusStepper:         migration.NewStatusStepper(false, channelNameForTest(t), "kafka"),
 				}
 
 				var counts []uint64
@@ -1688,6 +1738,7 @@ func TestProcessMessagesToBlocks(t *testing.T) {
 					errorChan:                      errorChan,
 					haltChan:                       haltChan,
 					doneProcessingMessagesToBlocks: make(chan struct{}),
+					migrationStatusStepper:         migration.NewStatusStepper(false, channelNameForTest(t), "kafka"),
 				}
 
 				var counts []uint64
@@ -1739,6 +1790,7 @@ func TestProcessMessagesToBlocks(t *testing.T) {
 					errorChan:                      errorChan,
 					haltChan:                       haltChan,
 					doneProcessingMessagesToBlocks: make(chan struct{}),
+					migrationStatusStepper:         migration.NewStatusStepper(false, channelNameForTest(t), "kafka"),
 				}
 
 				var counts []uint64
@@ -1801,6 +1853,7 @@ func TestProcessMessagesToBlocks(t *testing.T) {
 					errorChan:                      errorChan,
 					haltChan:                       haltChan,
 					doneProcessingMessagesToBlocks: make(chan struct{}),
+					migrationStatusStepper:         migration.NewStatusStepper(false, channelNameForTest(t), "kafka"),
 				}
 
 				var counts []uint64
@@ -1859,6 +1912,7 @@ func TestProcessMessagesToBlocks(t *testing.T) {
 					errorChan:                      errorChan,
 					haltChan:                       haltChan,
 					doneProcessingMessagesToBlocks: make(chan struct{}),
+					migrationStatusStepper:         migration.NewStatusStepper(false, channelNameForTest(t), "kafka"),
 				}
 
 				var counts []uint64
@@ -1935,6 +1989,7 @@ func TestProcessMessagesToBlocks(t *testing.T) {
 					errorChan:                      errorChan,
 					haltChan:                       haltChan,
 					doneProcessingMessagesToBlocks: make(chan struct{}),
+					migrationStatusStepper:         migration.NewStatusStepper(false, channelNameForTest(t), "kafka"),
 				}
 
 				var counts []uint64
@@ -2010,6 +2065,7 @@ func TestProcessMessagesToBlocks(t *testing.T) {
 					errorChan:                      errorChan,
 					haltChan:                       haltChan,
 					doneProcessingMessagesToBlocks: make(chan struct{}),
+					migrationStatusStepper:         migration.NewStatusStepper(false, channelNameForTest(t), "kafka"),
 				}
 
 				var counts []uint64
@@ -2098,6 +2154,7 @@ func TestProcessMessagesToBlocks(t *testing.T) {
 					errorChan:                      errorChan,
 					haltChan:                       haltChan,
 					doneProcessingMessagesToBlocks: make(chan struct{}),
+					migrationStatusStepper:         migration.NewStatusStepper(false, channelNameForTest(t), "kafka"),
 				}
 
 				var counts []uint64
@@ -2160,6 +2217,7 @@ func TestProcessMessagesToBlocks(t *testing.T) {
 					errorChan:                      errorChan,
 					haltChan:                       haltChan,
 					doneProcessingMessagesToBlocks: make(chan struct{}),
+					migrationStatusStepper:         migration.NewStatusStepper(false, channelNameForTest(t), "kafka"),
 				}
 
 				var counts []uint64
@@ -2245,6 +2303,7 @@ func TestProcessMessagesToBlocks(t *testing.T) {
 					errorChan:                      errorChan,
 					haltChan:                       haltChan,
 					doneProcessingMessagesToBlocks: make(chan struct{}),
+					migrationStatusStepper:         migration.NewStatusStepper(false, channelNameForTest(t), "kafka"),
 				}
 
 				var counts []uint64
@@ -2312,6 +2371,7 @@ func TestProcessMessagesToBlocks(t *testing.T) {
 				errorChan:                      errorChan,
 				haltChan:                       haltChan,
 				doneProcessingMessagesToBlocks: make(chan struct{}),
+				migrationStatusStepper:         migration.NewStatusStepper(false, channelNameForTest(t), "kafka"),
 			}
 
 			var counts []uint64
@@ -2380,6 +2440,7 @@ func TestProcessMessagesToBlocks(t *testing.T) {
 				errorChan:                      errorChan,
 				haltChan:                       haltChan,
 				doneProcessingMessagesToBlocks: make(chan struct{}),
+				migrationStatusStepper:         migration.NewStatusStepper(false, channelNameForTest(t), "kafka"),
 			}
 
 			done := make(chan struct{})
@@ -2502,6 +2563,7 @@ func TestResubmission(t *testing.T) {
 				errorChan:                      errorChan,
 				haltChan:                       haltChan,
 				doneProcessingMessagesToBlocks: make(chan struct{}),
+				migrationStatusStepper:         migration.NewStatusStepper(false, channelNameForTest(t), "kafka"),
 			}
 
 			var counts []uint64
@@ -2577,6 +2639,7 @@ func TestResubmission(t *testing.T) {
 				errorChan:                      errorChan,
 				haltChan:                       haltChan,
 				doneProcessingMessagesToBlocks: make(chan struct{}),
+				migrationStatusStepper:         migration.NewStatusStepper(false, channelNameForTest(t), "kafka"),
 			}
 
 			var counts []uint64
@@ -2658,6 +2721,7 @@ func TestResubmission(t *testing.T) {
 				errorChan:                      errorChan,
 				haltChan:                       haltChan,
 				doneProcessingMessagesToBlocks: make(chan struct{}),
+				migrationStatusStepper:         migration.NewStatusStepper(false, channelNameForTest(t), "kafka"),
 			}
 
 			var counts []uint64
@@ -2766,6 +2830,7 @@ func TestResubmission(t *testing.T) {
 				haltChan:                       haltChan,
 				doneProcessingMessagesToBlocks: make(chan struct{}),
 				doneReprocessingMsgInFlight:    doneReprocessing,
+				migrationStatusStepper:         migration.NewStatusStepper(false, channelNameForTest(t), "kafka"),
 			}
 
 			var counts []uint64
@@ -2867,6 +2932,7 @@ func TestResubmission(t *testing.T) {
 				errorChan:                      errorChan,
 				haltChan:                       haltChan,
 				doneProcessingMessagesToBlocks: make(chan struct{}),
+				migrationStatusStepper:         migration.NewStatusStepper(false, channelNameForTest(t), "kafka"),
 			}
 
 			var counts []uint64
@@ -2944,6 +3010,7 @@ func TestResubmission(t *testing.T) {
 				haltChan:                       haltChan,
 				doneProcessingMessagesToBlocks: make(chan struct{}),
 				doneReprocessingMsgInFlight:    doneReprocessing,
+				migrationStatusStepper:         migration.NewStatusStepper(false, channelNameForTest(t), "kafka"),
 			}
 
 			var counts []uint64
@@ -3055,6 +3122,7 @@ func TestResubmission(t *testing.T) {
 				haltChan:                       haltChan,
 				doneProcessingMessagesToBlocks: make(chan struct{}),
 				doneReprocessingMsgInFlight:    make(chan struct{}),
+				migrationStatusStepper:         migration.NewStatusStepper(false, channelNameForTest(t), "kafka"),
 			}
 
 			// WaitReady should block at beginning since we are in the middle of reprocessing
@@ -3125,6 +3193,7 @@ func TestResubmission(t *testing.T) {
 				errorChan:                      errorChan,
 				haltChan:                       haltChan,
 				doneProcessingMessagesToBlocks: make(chan struct{}),
+				migrationStatusStepper:         migration.NewStatusStepper(false, channelNameForTest(t), "kafka"),
 			}
 
 			var counts []uint64
@@ -3234,6 +3303,7 @@ func TestResubmission(t *testing.T) {
 				haltChan:                       haltChan,
 				doneProcessingMessagesToBlocks: make(chan struct{}),
 				doneReprocessingMsgInFlight:    doneReprocessing,
+				migrationStatusStepper:         migration.NewStatusStepper(false, channelNameForTest(t), "kafka"),
 			}
 
 			var counts []uint64
@@ -3313,7 +3383,21 @@ func newMockConfigEnvelope() *cb.Envelope {
 	return &cb.Envelope{Payload: utils.MarshalOrPanic(&cb.Payload{
 		Header: &cb.Header{ChannelHeader: utils.MarshalOrPanic(
 			&cb.ChannelHeader{Type: int32(cb.HeaderType_CONFIG), ChannelId: "foo"})},
-		Data: utils.MarshalOrPanic(&cb.ConfigEnvelope{}),
+		Data: utils.MarshalOrPanic(&cb.ConfigEnvelope{
+			Config: &cb.Config{
+				ChannelGroup: &cb.ConfigGroup{
+					Groups: map[string]*cb.ConfigGroup{
+						channelconfig.OrdererGroupKey: {
+							Values: map[string]*cb.ConfigValue{
+								channelconfig.ConsensusTypeKey: {
+									Value: utils.MarshalOrPanic(&ab.ConsensusType{Type: "kafka"}),
+								},
+							},
+						},
+					},
+				},
+			},
+		}),
 	})}
 }
 
@@ -3387,6 +3471,7 @@ func TestDeliverSession(t *testing.T) {
 		support := &mockConsenterSupport{}
 		support.On("Height").Return(uint64(height))
 		support.On("ChainID").Return(topic)
+		support.On("IsSystemChannel").Return(false)
 		support.On("Sequence").Return(uint64(0))
 		support.On("SharedConfig").Return(&mockconfig.Orderer{KafkaBrokersVal: []string{broker0.Addr()}})
 		support.On("ClassifyMsg", mock.Anything).Return(msgprocessor.NormalMsg, nil)
@@ -3433,7 +3518,7 @@ func TestDeliverSession(t *testing.T) {
 		defer env.broker2.Close()
 
 		// initialize consenter
-		consenter, _ := New(mockLocalConfig.Kafka, &lmock.MetricsProvider{}, &lmock.HealthChecker{})
+		consenter, _ := New(mockLocalConfig.Kafka, &lmock.MetricsProvider{}, &lmock.HealthChecker{}, nil)
 
 		// initialize chain
 		metadata := &cb.Metadata{Value: utils.MarshalOrPanic(&ab.KafkaMetadata{LastOffsetPersisted: env.height})}
@@ -3522,7 +3607,7 @@ func TestDeliverSession(t *testing.T) {
 		defer env.broker0.Close()
 
 		// initialize consenter
-		consenter, _ := New(mockLocalConfig.Kafka, &lmock.MetricsProvider{}, &lmock.HealthChecker{})
+		consenter, _ := New(mockLocalConfig.Kafka, &lmock.MetricsProvider{}, &lmock.HealthChecker{}, nil)
 
 		// initialize chain
 		metadata := &cb.Metadata{Value: utils.MarshalOrPanic(&ab.KafkaMetadata{LastOffsetPersisted: env.height})}
@@ -3584,7 +3669,7 @@ func TestDeliverSession(t *testing.T) {
 		defer env.broker0.Close()
 
 		// initialize consenter
-		consenter, _ := New(mockLocalConfig.Kafka, &lmock.MetricsProvider{}, &lmock.HealthChecker{})
+		consenter, _ := New(mockLocalConfig.Kafka, &lmock.MetricsProvider{}, &lmock.HealthChecker{}, nil)
 
 		// initialize chain
 		metadata := &cb.Metadata{Value: utils.MarshalOrPanic(&ab.KafkaMetadata{LastOffsetPersisted: env.height})}
@@ -3776,3 +3861,8 @@ func (c *mockConsenterSupport) Height() uint64 {
 	args := c.Called()
 	return args.Get(0).(uint64)
 }
+
+func (c *mockConsenterSupport) IsSystemChannel() bool {
+	args := c.Called()
+	return args.Bool(0)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/hyperledger/fabric/common/metrics"
 	localconfig "github.com/hyperledger/fabric/orderer/common/localconfig"
 	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/orderer/consensus/migration"
 	cb "github.com/hyperledger/fabric/protos/common"
 
 	"github.com/Shopify/sarama"
@@ -25,7 +26,7 @@ type healthChecker interface {
 }
 
 // New creates a Kafka-based consenter. Called by orderer's main.go.
-func New(config localconfig.Kafka, metricsProvider metrics.Provider, healthChecker healthChecker) (consensus.Consenter, *Metrics) {
+func New(config localconfig.Kafka, metricsProvider metrics.Provider, healthChecker healthChecker, migController migration.Controller) (consensus.Consenter, *Metrics) {
 	if config.Verbose {
 		logging.SetLevel(logging.DEBUG, "orderer.consensus.kafka.sarama")
 	}
@@ -46,7 +47,8 @@ func New(config localconfig.Kafka, metricsProvider metrics.Provider, healthCheck
 			NumPartitions:     1,
 			ReplicationFactor: config.Topic.ReplicationFactor,
 		},
-		healthChecker: healthChecker,
+		healthChecker:    healthChecker,
+		migControllerVal: migController,
 	}, NewMetrics(metricsProvider, brokerConfig.MetricRegistry)
 }
 
@@ -60,6 +62,9 @@ type consenterImpl struct {
 	kafkaVersionVal sarama.KafkaVersion
 	topicDetailVal  *sarama.TopicDetail
 	healthChecker   healthChecker
+
+	// migControllerVal coordinates the consensus-type migration of the chains.
+	migControllerVal migration.Controller
 }
 
 // HandleChain creates/returns a reference to a consensus.Chain object for the
@@ -85,6 +90,7 @@ type commonConsenter interface {
 	brokerConfig() *sarama.Config
 	retryOptions() localconfig.Retry
 	topicDetail() *sarama.TopicDetail
+	migrationController() migration.Controller
 }
 
 func (consenter *consenterImpl) brokerConfig() *sarama.Config {
@@ -98,3 +104,7 @@ func (consenter *consenterImpl) retryOptions() localconfig.Retry {
 func (consenter *consenterImpl) topicDetail() *sarama.TopicDetail {
 	return consenter.topicDetailVal
 }
+
+func (consenter *consenterImpl) migrationController() migration.Controller {
+	return consenter.migControllerVal
+}
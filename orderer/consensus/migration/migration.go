@@ -7,8 +7,13 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
 )
 
 // Status provides access to the consensus-type migration status of the underlying chain.
@@ -63,7 +68,7 @@ type Controller interface {
 	// by inspecting the status of the system channel.
 	ConsensusMigrationPending() bool
 
-	// ConsensusMigrationStart marks every standard channel as "START" with the given context.
+	// ConsensusMigrationStart marks the system channel and every standard channel as "START" with the given context.
 	// It should first check that consensus-type migration is not pending on any of the standard channels.
 	// This call is always triggered by a MigrationState="START" config update on the system channel.
 	// The context is the height of the system channel config block that carries said config update.
@@ -73,14 +78,16 @@ type Controller interface {
 	// are met, and if so, marks the system channel as committed.
 	// The conditions are:
 	// 1. system channel mast be at START with context >0;
-	// 2. all standard channels must be at START with the same context as the system channel.
+	// 2. all standard channels must be at CONTEXT with the same context as the system channel.
 	ConsensusMigrationCommit() (err error)
 
 	// ConsensusMigrationAbort verifies that the conditions for aborting the consensus-type migration
-	// are met, and if so, marks the system channel as aborted.
+	// are met, and if so, marks the system channel and every standard channel as "ABORT", which releases
+	// them from the pending state.
 	// The conditions are:
 	// 1. system channel mast be at START
 	// 2. all standard channels must be at START or CONTEXT
+	// This call is always triggered by a MigrationState="ABORT" config update on the system channel.
 	ConsensusMigrationAbort() (err error)
 }
 
@@ -97,14 +104,19 @@ type StatusImpl struct {
 
 	// systemChannel does not need to be protected by mutex since it is immutable after creation.
 	systemChannel bool
+	// consensusType is the type of the chain that is migrated to Raft, i.e. Kafka or Solo.
+	// It does not need to be protected by mutex since it is immutable after creation.
+	consensusType string
 
 	logger *flogging.FabricLogger
 }
 
-// NewStatusStepper generates a new StatusStepper implementation.
-func NewStatusStepper(sysChan bool, chainID string) StatusStepper {
+// NewStatusStepper generates a new StatusStepper implementation for a chain of the given consensus type
+// (i.e. "kafka" or "solo"), which is the type the chain is migrated from.
+func NewStatusStepper(sysChan bool, chainID string, consensusType string) StatusStepper {
 	return &StatusImpl{
 		systemChannel: sysChan,
+		consensusType: consensusType,
 		logger:        flogging.MustGetLogger("orderer.consensus.migration").With("channel", chainID),
 	}
 }
@@ -153,7 +165,7 @@ func (ms *StatusImpl) String() string {
 	ms.mutex.Lock()
 	defer ms.mutex.Unlock()
 
-	return fmt.Sprintf("State=%s, Context=%d, Sys=%t", ms.state, ms.context, ms.systemChannel)
+	return fmt.Sprintf("State=%s, Context=%d, Sys=%t, Type=%s", ms.state, ms.context, ms.systemChannel, ms.consensusType)
 }
 
 // Step evaluates the migration state machine of a particular chain. It returns whether
@@ -168,6 +180,15 @@ func (ms *StatusImpl) String() string {
 // channel), we also return commitMigration=true, which will cause the caller to replace the bootstrap file
 // (genesis block), as well as commit the block to the ledger.
 //
+// The config blocks carrying a COMMIT on the system channel or a CONTEXT on a standard channel are committed with the
+// Raft metadata generated by InitialRaftMetadata (see StepConfigMsg), so that the Raft chain can take over both Kafka
+// and Solo chains.
+//
+// When we get a message that is an ABORT (this can only happen on the system channel), we return commitBlock=true if
+// the migrationController accepts the abort, in which case it marks the system and standard channels as ABORT. A
+// standard channel that is marked as ABORT then only accepts a config update with state NONE, which reverts its
+// consensus type back to the type it is migrated from.
+//
 // Note: the method may call the multichannel.Registrar (migrationController). The Registrar takes a mutex, and then
 // calls individual migration.Status objects (.i.e. the lock of the migration.Status mutex is nested within the lock of
 // Registrar mutex). In order to avoid deadlocks, here we only call the Registrar (migrationController) when the
@@ -229,8 +250,18 @@ func (ms *StatusImpl) stepSystem(
 					currState, nextMigState, nextMigContext, currContext)
 			}
 		case orderer.ConsensusType_MIG_STATE_ABORT:
-			ms.logger.Panicf("Consensus-type migration: Not implemented yet, transition %s to %s", currState, nextMigState)
-			//TODO implement abort path
+			if currContext == nextMigContext {
+				err := migrationController.ConsensusMigrationAbort()
+				if err != nil {
+					ms.logger.Warningf("Consensus-type migration: Reject Config tx on system channel, migrationAbort failed; error=%s", err)
+				} else {
+					ms.logger.Infof("Consensus-type migration: aborted; Status: %s", ms)
+					commitBlock = true
+				}
+			} else {
+				ms.logger.Warningf("Consensus-type migration: Reject Config tx on system channel; %s to %s, because of bad context:(tx=%d/exp=%d)",
+					currState, nextMigState, nextMigContext, currContext)
+			}
 		default:
 			unexpectedTransitionResponse(currState, nextMigState)
 		}
@@ -251,6 +282,10 @@ func (ms *StatusImpl) stepSystem(
 				commitBlock = true
 			}
 		case orderer.ConsensusType_MIG_STATE_NONE:
+			if currState == orderer.ConsensusType_MIG_STATE_ABORT {
+				ms.SetStateContext(nextMigState, 0)
+				ms.logger.Infof("Consensus-type migration: config after abort accepted; Status: %s", ms)
+			}
 			commitBlock = true
 		default:
 			unexpectedTransitionResponse(currState, nextMigState)
@@ -276,6 +311,12 @@ func (ms *StatusImpl) stepStandard(
 		commitBlock = false
 	}
 
+	abortOnStandardChannelResponse := func(from, to orderer.ConsensusType_MigrationState) {
+		ms.logger.Warningf("Consensus-type migration: Reject Config tx on standard channel; %s to %s, because migration can only be aborted on the system channel",
+			from, to)
+		commitBlock = false
+	}
+
 	currState, currContext := ms.StateContext()
 
 	switch currState {
@@ -293,14 +334,13 @@ func (ms *StatusImpl) stepStandard(
 					migrationController.ConsensusMigrationPending(), nextMigContext, currContext)
 			}
 		case orderer.ConsensusType_MIG_STATE_ABORT:
-			ms.logger.Panicf("Consensus-type migration: Not implemented yet, transition %s to %s", currState, nextMigState)
-			//TODO implement abort path
+			abortOnStandardChannelResponse(currState, nextMigState)
 		default:
 			unexpectedTransitionResponse(currState, nextMigState)
 		}
 
-	case orderer.ConsensusType_MIG_STATE_NONE, orderer.ConsensusType_MIG_STATE_ABORT:
-		//=== Migration not started or aborted, expect NONE (START is set by system channel, not message)
+	case orderer.ConsensusType_MIG_STATE_NONE:
+		//=== Migration not started, expect NONE (START is set by system channel, not message)
 		switch nextMigState {
 		case orderer.ConsensusType_MIG_STATE_NONE:
 			commitBlock = true
@@ -308,12 +348,28 @@ func (ms *StatusImpl) stepStandard(
 			unexpectedTransitionResponse(currState, nextMigState)
 		}
 
+	case orderer.ConsensusType_MIG_STATE_ABORT:
+		//=== Migration aborted (ABORT is set by system channel, via migrationController, not message),
+		// expect NONE with the type the chain is migrated from, which reverts the context, if any
+		switch nextMigState {
+		case orderer.ConsensusType_MIG_STATE_NONE:
+			if nextConsensusType == ms.consensusType {
+				ms.SetStateContext(nextMigState, 0)
+				ms.logger.Infof("Consensus-type migration: config after abort accepted; Status: %s", ms)
+				commitBlock = true
+			} else {
+				ms.logger.Warningf("Consensus-type migration: Reject Config tx on standard channel; %s to %s, because of bad type:(tx=%s/exp=%s)",
+					currState, nextMigState, nextConsensusType, ms.consensusType)
+			}
+		default:
+			unexpectedTransitionResponse(currState, nextMigState)
+		}
+
 	case orderer.ConsensusType_MIG_STATE_CONTEXT:
-		//=== Migration pending, expect ABORT, or nothing else to do (restart to Raft)
+		//=== Migration pending, expect ABORT on the system channel, or nothing else to do (restart to Raft)
 		switch nextMigState {
 		case orderer.ConsensusType_MIG_STATE_ABORT:
-			ms.logger.Panicf("Consensus-type migration: Not implemented yet, transition %s to %s", currState, nextMigState)
-			//TODO implement abort path
+			abortOnStandardChannelResponse(currState, nextMigState)
 		default:
 			unexpectedTransitionResponse(currState, nextMigState)
 		}
@@ -325,3 +381,92 @@ func (ms *StatusImpl) stepStandard(
 
 	return commitBlock
 }
+
+// StepConfigMsg evaluates the migration state machine of a Kafka or Solo chain for a config message that is about
+// to be committed, with the consensus type, migration state and context of the config it carries. It returns whether
+// the config block should be committed or dropped (commitBlock), and whether the migration is committed
+// (commitMigration), as Step does. The messages that create channels on the system channel carry no config of their
+// own; they are committed unless migration is pending or committed, since a channel created meanwhile would not take
+// part in the migration.
+//
+// When the config carries the COMMIT on the system channel or the CONTEXT on a standard channel, it also returns the
+// marshaled initial Raft metadata (raftMetadata), which the chain must write as the orderer metadata of the config
+// block instead of its own. Otherwise raftMetadata is nil.
+func StepConfigMsg(
+	stepper StatusStepper,
+	chainID string,
+	configMsg *common.Envelope,
+	lastCutBlockNumber uint64,
+	migrationController Controller,
+) (commitBlock bool, commitMigration bool, raftMetadata []byte, err error) {
+	payload, err := utils.UnmarshalPayload(configMsg.Payload)
+	if err != nil {
+		return false, false, nil, err
+	}
+	if payload.Header == nil {
+		return false, false, nil, errors.New("missing header in config message")
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return false, false, nil, err
+	}
+
+	if common.HeaderType(chdr.Type) != common.HeaderType_CONFIG {
+		return !stepper.IsPending() && !stepper.IsCommitted(), false, nil, nil
+	}
+
+	consensusType, err := configConsensusType(payload.Data)
+	if err != nil {
+		return false, false, nil, err
+	}
+
+	// The Raft metadata is generated before stepping, so that a config with a bad consenter set is dropped
+	// without changing the migration status.
+	if consensusType.MigrationState == orderer.ConsensusType_MIG_STATE_COMMIT ||
+		consensusType.MigrationState == orderer.ConsensusType_MIG_STATE_CONTEXT {
+		initialMetadata, err := InitialRaftMetadata(consensusType.Metadata)
+		if err != nil {
+			return false, false, nil, errors.WithMessage(err, "failed to generate the initial Raft metadata")
+		}
+		if raftMetadata, err = proto.Marshal(initialMetadata); err != nil {
+			return false, false, nil, errors.Wrap(err, "failed to marshal the initial Raft metadata")
+		}
+	}
+
+	commitBlock, commitMigration = stepper.Step(
+		chainID,
+		consensusType.Type,
+		consensusType.MigrationState,
+		consensusType.MigrationContext,
+		lastCutBlockNumber,
+		migrationController,
+	)
+	if !commitBlock {
+		return false, false, nil, nil
+	}
+	return commitBlock, commitMigration, raftMetadata, nil
+}
+
+// configConsensusType extracts the orderer consensus type from the given marshaled config envelope.
+func configConsensusType(configEnvBytes []byte) (*orderer.ConsensusType, error) {
+	configEnv := &common.ConfigEnvelope{}
+	if err := proto.Unmarshal(configEnvBytes, configEnv); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal config envelope")
+	}
+	if configEnv.Config == nil || configEnv.Config.ChannelGroup == nil {
+		return nil, errors.New("missing channel group in config envelope")
+	}
+	ordererGroup, exists := configEnv.Config.ChannelGroup.Groups[channelconfig.OrdererGroupKey]
+	if !exists {
+		return nil, errors.New("missing orderer group in config")
+	}
+	consensusTypeValue, exists := ordererGroup.Values[channelconfig.ConsensusTypeKey]
+	if !exists {
+		return nil, errors.New("missing consensus type in orderer config")
+	}
+	consensusType := &orderer.ConsensusType{}
+	if err := proto.Unmarshal(consensusTypeValue.Value, consensusType); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal consensus type")
+	}
+	return consensusType, nil
+}
@@ -17,14 +17,14 @@ func TestStateContextSystem(t *testing.T) {
 	sysChan := true
 
 	t.Run("Get", func(t *testing.T) {
-		status := migration.NewStatusStepper(sysChan, "test")
+		status := migration.NewStatusStepper(sysChan, "test", "kafka")
 		state, context := status.StateContext()
 		assert.Equal(t, orderer.ConsensusType_MIG_STATE_NONE, state, "Must be initialized to %s", orderer.ConsensusType_MIG_STATE_NONE)
 		assert.Equal(t, uint64(0), context, "Must be initialized to 0")
 	})
 
 	t.Run("Green", func(t *testing.T) {
-		status := migration.NewStatusStepper(sysChan, "test")
+		status := migration.NewStatusStepper(sysChan, "test", "kafka")
 		t.Logf("status: %s", status.String())
 		status.SetStateContext(orderer.ConsensusType_MIG_STATE_START, 2)
 		assert.True(t, status.IsPending())
@@ -38,7 +38,7 @@ func TestStateContextSystem(t *testing.T) {
 	})
 
 	t.Run("Abort", func(t *testing.T) {
-		status := migration.NewStatusStepper(sysChan, "test")
+		status := migration.NewStatusStepper(sysChan, "test", "kafka")
 		t.Logf("status: %s", status.String())
 		status.SetStateContext(orderer.ConsensusType_MIG_STATE_START, 2)
 		assert.True(t, status.IsPending())
@@ -56,14 +56,14 @@ func TestStateContextStandard(t *testing.T) {
 	sysChan := false
 
 	t.Run("Get", func(t *testing.T) {
-		status := migration.NewStatusStepper(sysChan, "test")
+		status := migration.NewStatusStepper(sysChan, "test", "kafka")
 		state, context := status.StateContext()
 		assert.Equal(t, orderer.ConsensusType_MIG_STATE_NONE, state, "Must be initialized to %s", orderer.ConsensusType_MIG_STATE_NONE)
 		assert.Equal(t, uint64(0), context, "Must be initialized to 0")
 	})
 
 	t.Run("Green", func(t *testing.T) {
-		status := migration.NewStatusStepper(sysChan, "test")
+		status := migration.NewStatusStepper(sysChan, "test", "kafka")
 		t.Logf("status: %s", status.String())
 		status.SetStateContext(orderer.ConsensusType_MIG_STATE_START, 2)
 		assert.True(t, status.IsPending())
@@ -77,7 +77,7 @@ func TestStateContextStandard(t *testing.T) {
 	})
 
 	t.Run("Abort", func(t *testing.T) {
-		status := migration.NewStatusStepper(sysChan, "test")
+		status := migration.NewStatusStepper(sysChan, "test", "kafka")
 		t.Logf("status: %s", status.String())
 		status.SetStateContext(orderer.ConsensusType_MIG_STATE_START, 2)
 		assert.True(t, status.IsPending())
@@ -91,7 +91,7 @@ func TestStateContextStandard(t *testing.T) {
 func TestStepSysFromNone(t *testing.T) {
 	sysChan := true
 	migController := mocks.FakeMigrationController{}
-	status := migration.NewStatusStepper(sysChan, "test")
+	status := migration.NewStatusStepper(sysChan, "test", "kafka")
 
 	t.Run("None-None", func(t *testing.T) {
 		t.Logf("status before: %s", status.String())
@@ -130,7 +130,13 @@ func TestStepSysFromNone(t *testing.T) {
 	})
 
 	t.Run("None-Abort", func(t *testing.T) {
-		//TODO test abort when implemented
+		t.Logf("status before: %s", status.String())
+
+		migController.ConsensusMigrationAbortReturns(nil)
+		commitBlock, commitMig := status.Step("Foo", "kafka", orderer.ConsensusType_MIG_STATE_ABORT, 7, 7, &migController)
+		assert.True(t, !commitBlock)
+		assert.True(t, !commitMig)
+		assert.Equal(t, 0, migController.ConsensusMigrationAbortCallCount())
 	})
 
 	t.Run("None-Context", func(t *testing.T) {
@@ -145,7 +151,7 @@ func TestStepSysFromNone(t *testing.T) {
 func TestStepSysFromStart(t *testing.T) {
 	sysChan := true
 	migController := mocks.FakeMigrationController{}
-	status := migration.NewStatusStepper(sysChan, "test")
+	status := migration.NewStatusStepper(sysChan, "test", "kafka")
 	lastBlockCut := uint64(6)
 	context := lastBlockCut + 1
 	status.SetStateContext(orderer.ConsensusType_MIG_STATE_START, context)
@@ -191,7 +197,28 @@ func TestStepSysFromStart(t *testing.T) {
 	})
 
 	t.Run("Start-Abort", func(t *testing.T) {
-		//TODO test abort when implemented
+		t.Logf("status before: %s", status.String())
+
+		migController.ConsensusMigrationAbortReturns(nil)
+		commitBlock, commitMig := status.Step("Foo", "kafka", orderer.ConsensusType_MIG_STATE_ABORT, context-1, 0, &migController)
+		assert.True(t, !commitBlock)
+		assert.True(t, !commitMig)
+
+		commitBlock, commitMig = status.Step("Foo", "kafka", orderer.ConsensusType_MIG_STATE_ABORT, context+1, 0, &migController)
+		assert.True(t, !commitBlock)
+		assert.True(t, !commitMig)
+		assert.Equal(t, 0, migController.ConsensusMigrationAbortCallCount())
+
+		migController.ConsensusMigrationAbortReturns(fmt.Errorf("Cannot abort"))
+		commitBlock, commitMig = status.Step("Foo", "kafka", orderer.ConsensusType_MIG_STATE_ABORT, context, 0, &migController)
+		assert.True(t, !commitBlock)
+		assert.True(t, !commitMig)
+
+		migController.ConsensusMigrationAbortReturns(nil)
+		commitBlock, commitMig = status.Step("Foo", "kafka", orderer.ConsensusType_MIG_STATE_ABORT, context, 0, &migController)
+		assert.True(t, commitBlock)
+		assert.True(t, !commitMig)
+		assert.Equal(t, 2, migController.ConsensusMigrationAbortCallCount())
 	})
 
 	t.Run("Start-Context", func(t *testing.T) {
@@ -206,7 +233,7 @@ func TestStepSysFromStart(t *testing.T) {
 func TestStepSysFromCommit(t *testing.T) {
 	sysChan := true
 	migController := mocks.FakeMigrationController{}
-	status := migration.NewStatusStepper(sysChan, "test")
+	status := migration.NewStatusStepper(sysChan, "test", "kafka")
 	lastBlockCut := uint64(6)
 	context := lastBlockCut + 1
 	status.SetStateContext(orderer.ConsensusType_MIG_STATE_COMMIT, context)
@@ -247,7 +274,13 @@ func TestStepSysFromCommit(t *testing.T) {
 	})
 
 	t.Run("Commit-Abort", func(t *testing.T) {
-		//TODO test abort when implemented
+		t.Logf("status before: %s", status.String())
+
+		migController.ConsensusMigrationAbortReturns(nil)
+		commitBlock, commitMig := status.Step("Foo", "kafka", orderer.ConsensusType_MIG_STATE_ABORT, context, 0, &migController)
+		assert.True(t, !commitBlock)
+		assert.True(t, !commitMig)
+		assert.Equal(t, 0, migController.ConsensusMigrationAbortCallCount())
 	})
 
 	t.Run("Commit-Context", func(t *testing.T) {
@@ -260,13 +293,63 @@ func TestStepSysFromCommit(t *testing.T) {
 }
 
 func TestStepSysFromAbort(t *testing.T) {
-	//TODO test abort when implemented
+	sysChan := true
+	migController := mocks.FakeMigrationController{}
+	status := migration.NewStatusStepper(sysChan, "test", "kafka")
+	lastBlockCut := uint64(6)
+	context := lastBlockCut + 1
+
+	t.Run("Abort-Bad", func(t *testing.T) {
+		status.SetStateContext(orderer.ConsensusType_MIG_STATE_ABORT, context)
+		t.Logf("status before: %s", status.String())
+
+		migController.ConsensusMigrationCommitReturns(nil)
+		migController.ConsensusMigrationAbortReturns(nil)
+		states := [...]orderer.ConsensusType_MigrationState{
+			orderer.ConsensusType_MIG_STATE_COMMIT, orderer.ConsensusType_MIG_STATE_ABORT,
+			orderer.ConsensusType_MIG_STATE_CONTEXT,
+		}
+		for _, st := range states {
+			commitBlock, commitMig := status.Step("Foo", "kafka", st, context, 0, &migController)
+			assert.True(t, !commitBlock)
+			assert.True(t, !commitMig)
+		}
+		assert.Equal(t, 0, migController.ConsensusMigrationCommitCallCount())
+		assert.Equal(t, 0, migController.ConsensusMigrationAbortCallCount())
+		state, ctx := status.StateContext()
+		assert.Equal(t, orderer.ConsensusType_MIG_STATE_ABORT, state)
+		assert.Equal(t, context, ctx)
+	})
+
+	t.Run("Abort-None", func(t *testing.T) {
+		status.SetStateContext(orderer.ConsensusType_MIG_STATE_ABORT, context)
+		t.Logf("status before: %s", status.String())
+
+		commitBlock, commitMig := status.Step("Foo", "kafka", orderer.ConsensusType_MIG_STATE_NONE, 0, context+1, &migController)
+		assert.True(t, commitBlock)
+		assert.True(t, !commitMig)
+		state, ctx := status.StateContext()
+		assert.Equal(t, orderer.ConsensusType_MIG_STATE_NONE, state)
+		assert.Equal(t, uint64(0), ctx)
+		assert.True(t, !status.IsPending())
+	})
+
+	t.Run("Abort-Start", func(t *testing.T) {
+		status.SetStateContext(orderer.ConsensusType_MIG_STATE_ABORT, context)
+		t.Logf("status before: %s", status.String())
+
+		migController.ConsensusMigrationStartReturns(nil)
+		commitBlock, commitMig := status.Step("Foo", "kafka", orderer.ConsensusType_MIG_STATE_START, 0, context+4, &migController)
+		assert.True(t, commitBlock)
+		assert.True(t, !commitMig)
+		assert.Equal(t, context+5, migController.ConsensusMigrationStartArgsForCall(0))
+	})
 }
 
 func TestStepSysFromContext(t *testing.T) {
 	sysChan := true
 	migController := mocks.FakeMigrationController{}
-	status := migration.NewStatusStepper(sysChan, "test")
+	status := migration.NewStatusStepper(sysChan, "test", "kafka")
 	lastBlockCut := uint64(6)
 	context := lastBlockCut + 1
 	status.SetStateContext(orderer.ConsensusType_MIG_STATE_CONTEXT, context)
@@ -301,7 +384,7 @@ func TestStepSysFromContext(t *testing.T) {
 func TestStepStdFromNone(t *testing.T) {
 	sysChan := false
 	migController := mocks.FakeMigrationController{}
-	status := migration.NewStatusStepper(sysChan, "test")
+	status := migration.NewStatusStepper(sysChan, "test", "kafka")
 
 	t.Run("None-None", func(t *testing.T) {
 		t.Logf("status before: %s", status.String())
@@ -328,7 +411,11 @@ func TestStepStdFromNone(t *testing.T) {
 	})
 
 	t.Run("None-Abort", func(t *testing.T) {
-		//TODO test abort when implemented
+		t.Logf("status before: %s", status.String())
+
+		commitBlock, commitMig := status.Step("Foo", "kafka", orderer.ConsensusType_MIG_STATE_ABORT, 7, 0, &migController)
+		assert.True(t, !commitBlock)
+		assert.True(t, !commitMig)
 	})
 
 	t.Run("None-Context", func(t *testing.T) {
@@ -343,7 +430,7 @@ func TestStepStdFromNone(t *testing.T) {
 func TestStepStdFromStart(t *testing.T) {
 	sysChan := false
 	migController := mocks.FakeMigrationController{}
-	status := migration.NewStatusStepper(sysChan, "test")
+	status := migration.NewStatusStepper(sysChan, "test", "kafka")
 	lastBlockCut := uint64(6)
 	context := lastBlockCut + 1
 	status.SetStateContext(orderer.ConsensusType_MIG_STATE_START, context)
@@ -353,8 +440,7 @@ func TestStepStdFromStart(t *testing.T) {
 
 		states := [...]orderer.ConsensusType_MigrationState{
 			orderer.ConsensusType_MIG_STATE_NONE, orderer.ConsensusType_MIG_STATE_START,
-			orderer.ConsensusType_MIG_STATE_COMMIT,
-			// TODO when implemented orderer.ConsensusType_MIG_STATE_ABORT,
+			orderer.ConsensusType_MIG_STATE_COMMIT, orderer.ConsensusType_MIG_STATE_ABORT,
 		}
 
 		for _, st := range states {
@@ -392,7 +478,7 @@ func TestStepStdFromStart(t *testing.T) {
 func TestStepStdFromContext(t *testing.T) {
 	sysChan := false
 	migController := mocks.FakeMigrationController{}
-	status := migration.NewStatusStepper(sysChan, "test")
+	status := migration.NewStatusStepper(sysChan, "test", "kafka")
 	lastBlockCut := uint64(6)
 	context := lastBlockCut + 1
 	status.SetStateContext(orderer.ConsensusType_MIG_STATE_CONTEXT, context)
@@ -427,14 +513,61 @@ func TestStepStdFromContext(t *testing.T) {
 
 	t.Run("Context-Abort", func(t *testing.T) {
 		t.Logf("status before: %s", status.String())
-		// TODO when implemented orderer.ConsensusType_MIG_STATE_ABORT,
+
+		commitBlock, commitMig := status.Step("Foo", "kafka", orderer.ConsensusType_MIG_STATE_ABORT, context, 0, &migController)
+		assert.True(t, !commitBlock)
+		assert.True(t, !commitMig)
+		assert.True(t, status.IsPending())
+	})
+}
+
+func TestStepStdFromAbort(t *testing.T) {
+	sysChan := false
+	migController := mocks.FakeMigrationController{}
+	status := migration.NewStatusStepper(sysChan, "test", "kafka")
+	lastBlockCut := uint64(6)
+	context := lastBlockCut + 1
+	status.SetStateContext(orderer.ConsensusType_MIG_STATE_ABORT, context)
+	assert.True(t, !status.IsPending())
+
+	t.Run("Abort-Bad", func(t *testing.T) {
+		t.Logf("status before: %s", status.String())
+
+		migController.ConsensusMigrationPendingReturns(true)
+		states := [...]orderer.ConsensusType_MigrationState{
+			orderer.ConsensusType_MIG_STATE_START, orderer.ConsensusType_MIG_STATE_COMMIT,
+			orderer.ConsensusType_MIG_STATE_ABORT, orderer.ConsensusType_MIG_STATE_CONTEXT,
+		}
+		for _, st := range states {
+			commitBlock, commitMig := status.Step("Foo", "etcdraft", st, context, 0, &migController)
+			assert.True(t, !commitBlock)
+			assert.True(t, !commitMig)
+		}
+
+		// the context must be reverted to Kafka
+		commitBlock, commitMig := status.Step("Foo", "etcdraft", orderer.ConsensusType_MIG_STATE_NONE, 0, 0, &migController)
+		assert.True(t, !commitBlock)
+		assert.True(t, !commitMig)
+		state, _ := status.StateContext()
+		assert.Equal(t, orderer.ConsensusType_MIG_STATE_ABORT, state)
+	})
+
+	t.Run("Abort-None", func(t *testing.T) {
+		t.Logf("status before: %s", status.String())
+
+		commitBlock, commitMig := status.Step("Foo", "kafka", orderer.ConsensusType_MIG_STATE_NONE, 0, 0, &migController)
+		assert.True(t, commitBlock)
+		assert.True(t, !commitMig)
+		state, ctx := status.StateContext()
+		assert.Equal(t, orderer.ConsensusType_MIG_STATE_NONE, state)
+		assert.Equal(t, uint64(0), ctx)
 	})
 }
 
 func TestStepStdFromCommit(t *testing.T) {
 	sysChan := false
 	migController := mocks.FakeMigrationController{}
-	status := migration.NewStatusStepper(sysChan, "test")
+	status := migration.NewStatusStepper(sysChan, "test", "kafka")
 	lastBlockCut := uint64(6)
 	context := lastBlockCut + 1
 
@@ -454,3 +587,59 @@ func TestStepStdFromCommit(t *testing.T) {
 		}
 	})
 }
+
+//=== Solo ===
+
+func TestStepSolo(t *testing.T) {
+	lastBlockCut := uint64(3)
+	context := lastBlockCut + 1
+
+	t.Run("System-Green", func(t *testing.T) {
+		migController := mocks.FakeMigrationController{}
+		status := migration.NewStatusStepper(true, "test", "solo")
+		t.Logf("status before: %s", status.String())
+
+		migController.ConsensusMigrationStartReturns(nil)
+		commitBlock, commitMig := status.Step("Foo", "solo", orderer.ConsensusType_MIG_STATE_START, 0, lastBlockCut, &migController)
+		assert.True(t, commitBlock)
+		assert.True(t, !commitMig)
+		assert.Equal(t, context, migController.ConsensusMigrationStartArgsForCall(0))
+
+		status.SetStateContext(orderer.ConsensusType_MIG_STATE_START, context)
+		migController.ConsensusMigrationCommitReturns(nil)
+		commitBlock, commitMig = status.Step("Foo", "etcdraft", orderer.ConsensusType_MIG_STATE_COMMIT, context, lastBlockCut+1, &migController)
+		assert.True(t, commitBlock)
+		assert.True(t, commitMig)
+	})
+
+	t.Run("Standard-Green", func(t *testing.T) {
+		migController := mocks.FakeMigrationController{}
+		status := migration.NewStatusStepper(false, "test", "solo")
+		status.SetStateContext(orderer.ConsensusType_MIG_STATE_START, context)
+		t.Logf("status before: %s", status.String())
+
+		migController.ConsensusMigrationPendingReturns(true)
+		commitBlock, commitMig := status.Step("Foo", "etcdraft", orderer.ConsensusType_MIG_STATE_CONTEXT, context, 0, &migController)
+		assert.True(t, commitBlock)
+		assert.True(t, !commitMig)
+		assert.True(t, status.IsPending())
+	})
+
+	t.Run("Standard-Abort", func(t *testing.T) {
+		migController := mocks.FakeMigrationController{}
+		status := migration.NewStatusStepper(false, "test", "solo")
+		status.SetStateContext(orderer.ConsensusType_MIG_STATE_ABORT, context)
+		t.Logf("status before: %s", status.String())
+
+		commitBlock, commitMig := status.Step("Foo", "kafka", orderer.ConsensusType_MIG_STATE_NONE, 0, 0, &migController)
+		assert.True(t, !commitBlock)
+		assert.True(t, !commitMig)
+
+		commitBlock, commitMig = status.Step("Foo", "solo", orderer.ConsensusType_MIG_STATE_NONE, 0, 0, &migController)
+		assert.True(t, commitBlock)
+		assert.True(t, !commitMig)
+		state, ctx := status.StateContext()
+		assert.Equal(t, orderer.ConsensusType_MIG_STATE_NONE, state)
+		assert.Equal(t, uint64(0), ctx)
+	})
+}
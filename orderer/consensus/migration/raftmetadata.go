@@ -0,0 +1,53 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package migration
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/orderer/etcdraft"
+	"github.com/pkg/errors"
+)
+
+// InitialRaftMetadata generates the initial Raft metadata of a chain that is migrated to Raft, from the consenter set
+// in the etcdraft consensus metadata of the config update that carries the migration step, i.e. the COMMIT config
+// update on the system channel and the CONTEXT config update on a standard channel.
+//
+// The chain that commits such a config block writes the generated metadata as the orderer metadata of the block, which
+// the Raft chain that takes over after the restart reads. The Raft IDs are assigned in the order of the consenter set,
+// so that every orderer derives the same mapping. This is needed in particular when migrating from Solo, which, unlike
+// Kafka, does not need a consenter set before the migration.
+func InitialRaftMetadata(consensusMetadata []byte) (*etcdraft.RaftMetadata, error) {
+	configMetadata := &etcdraft.Metadata{}
+	if err := proto.Unmarshal(consensusMetadata, configMetadata); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal etcdraft consensus metadata")
+	}
+	if configMetadata.Options == nil {
+		return nil, errors.New("etcdraft options have not been provided")
+	}
+	if len(configMetadata.Consenters) == 0 {
+		return nil, errors.New("etcdraft consenter set is empty")
+	}
+
+	raftMetadata := &etcdraft.RaftMetadata{
+		Consenters:      map[uint64]*etcdraft.Consenter{},
+		NextConsenterId: 1,
+	}
+	certs := map[string]struct{}{}
+	for _, consenter := range configMetadata.Consenters {
+		if _, exists := certs[string(consenter.ClientTlsCert)]; exists {
+			return nil, errors.Errorf("consenter %s appears more than once in the consenter set", endpoint(consenter))
+		}
+		certs[string(consenter.ClientTlsCert)] = struct{}{}
+		raftMetadata.Consenters[raftMetadata.NextConsenterId] = consenter
+		raftMetadata.NextConsenterId++
+	}
+
+	return raftMetadata, nil
+}
+
+func endpoint(consenter *etcdraft.Consenter) string {
+	return fmt.Sprintf("%s:%d", consenter.Host, consenter.Port)
+}
@@ -0,0 +1,54 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package migration_test
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/orderer/consensus/migration"
+	"github.com/hyperledger/fabric/protos/orderer/etcdraft"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInitialRaftMetadata(t *testing.T) {
+	consenters := []*etcdraft.Consenter{
+		{Host: "orderer1", Port: 7050, ClientTlsCert: []byte("cert1"), ServerTlsCert: []byte("cert1")},
+		{Host: "orderer2", Port: 7050, ClientTlsCert: []byte("cert2"), ServerTlsCert: []byte("cert2")},
+		{Host: "orderer3", Port: 7050, ClientTlsCert: []byte("cert3"), ServerTlsCert: []byte("cert3")},
+	}
+	options := &etcdraft.Options{TickInterval: 100, ElectionTick: 10, HeartbeatTick: 1}
+
+	t.Run("Green path", func(t *testing.T) {
+		metadata, err := proto.Marshal(&etcdraft.Metadata{Consenters: consenters, Options: options})
+		assert.NoError(t, err)
+
+		raftMetadata, err := migration.InitialRaftMetadata(metadata)
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(&etcdraft.RaftMetadata{
+			Consenters:      map[uint64]*etcdraft.Consenter{1: consenters[0], 2: consenters[1], 3: consenters[2]},
+			NextConsenterId: 4,
+		}, raftMetadata))
+	})
+
+	t.Run("Bad metadata", func(t *testing.T) {
+		_, err := migration.InitialRaftMetadata([]byte{1, 2, 3})
+		assert.Contains(t, err.Error(), "failed to unmarshal etcdraft consensus metadata")
+
+		metadata, err := proto.Marshal(&etcdraft.Metadata{Consenters: consenters})
+		assert.NoError(t, err)
+		_, err = migration.InitialRaftMetadata(metadata)
+		assert.EqualError(t, err, "etcdraft options have not been provided")
+
+		metadata, err = proto.Marshal(&etcdraft.Metadata{Options: options})
+		assert.NoError(t, err)
+		_, err = migration.InitialRaftMetadata(metadata)
+		assert.EqualError(t, err, "etcdraft consenter set is empty")
+
+		metadata, err = proto.Marshal(&etcdraft.Metadata{Consenters: append(consenters, consenters[1]), Options: options})
+		assert.NoError(t, err)
+		_, err = migration.InitialRaftMetadata(metadata)
+		assert.EqualError(t, err, "consenter orderer2:7050 appears more than once in the consenter set")
+	})
+}
@@ -181,6 +181,15 @@ type FakeConsenterSupport struct {
 	heightReturnsOnCall map[int]struct {
 		result1 uint64
 	}
+	IsSystemChannelStub        func() bool
+	isSystemChannelMutex       sync.RWMutex
+	isSystemChannelArgsForCall []struct{}
+	isSystemChannelReturns     struct {
+		result1 bool
+	}
+	isSystemChannelReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -906,6 +915,46 @@ func (fake *FakeConsenterSupport) HeightReturnsOnCall(i int, result1 uint64) {
 	}{result1}
 }
 
+func (fake *FakeConsenterSupport) IsSystemChannel() bool {
+	fake.isSystemChannelMutex.Lock()
+	ret, specificReturn := fake.isSystemChannelReturnsOnCall[len(fake.isSystemChannelArgsForCall)]
+	fake.isSystemChannelArgsForCall = append(fake.isSystemChannelArgsForCall, struct{}{})
+	fake.recordInvocation("IsSystemChannel", []interface{}{})
+	fake.isSystemChannelMutex.Unlock()
+	if fake.IsSystemChannelStub != nil {
+		return fake.IsSystemChannelStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.isSystemChannelReturns.result1
+}
+
+func (fake *FakeConsenterSupport) IsSystemChannelCallCount() int {
+	fake.isSystemChannelMutex.RLock()
+	defer fake.isSystemChannelMutex.RUnlock()
+	return len(fake.isSystemChannelArgsForCall)
+}
+
+func (fake *FakeConsenterSupport) IsSystemChannelReturns(result1 bool) {
+	fake.IsSystemChannelStub = nil
+	fake.isSystemChannelReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeConsenterSupport) IsSystemChannelReturnsOnCall(i int, result1 bool) {
+	fake.IsSystemChannelStub = nil
+	if fake.isSystemChannelReturnsOnCall == nil {
+		fake.isSystemChannelReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.isSystemChannelReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeConsenterSupport) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -941,6 +990,8 @@ func (fake *FakeConsenterSupport) Invocations() map[string][][]interface{} {
 	defer fake.chainIDMutex.RUnlock()
 	fake.heightMutex.RLock()
 	defer fake.heightMutex.RUnlock()
+	fake.isSystemChannelMutex.RLock()
+	defer fake.isSystemChannelMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -11,18 +11,25 @@ import (
 	"time"
 
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/orderer/consensus/migration"
 	cb "github.com/hyperledger/fabric/protos/common"
 )
 
 var logger = flogging.MustGetLogger("orderer.consensus.solo")
 
-type consenter struct{}
+type consenter struct {
+	migrationController migration.Controller
+}
 
 type chain struct {
 	support  consensus.ConsenterSupport
 	sendChan chan *message
 	exitChan chan struct{}
+
+	migrationStatusStepper migration.StatusStepper
+	migrationController    migration.Controller
 }
 
 type message struct {
@@ -34,20 +41,27 @@ type message struct {
 // New creates a new consenter for the solo consensus scheme.
 // The solo consensus scheme is very simple, and allows only one consenter for a given chain (this process).
 // It accepts messages being delivered via Order/Configure, orders them, and then uses the blockcutter to form the messages
-// into blocks before writing to the given ledger
-func New() consensus.Consenter {
-	return &consenter{}
+// into blocks before writing to the given ledger. The migrationController coordinates the consensus-type migration
+// of the chains to Raft.
+func New(migrationController migration.Controller) consensus.Consenter {
+	return &consenter{migrationController: migrationController}
 }
 
 func (solo *consenter) HandleChain(support consensus.ConsenterSupport, metadata *cb.Metadata) (consensus.Chain, error) {
-	return newChain(support), nil
+	return newChain(support, solo.migrationController), nil
 }
 
-func newChain(support consensus.ConsenterSupport) *chain {
+func newChain(support consensus.ConsenterSupport, migrationController migration.Controller) *chain {
+	migrationStatusStepper := migration.NewStatusStepper(support.IsSystemChannel(), support.ChainID(), "solo")
+	migrationStatusStepper.SetStateContext(support.SharedConfig().ConsensusMigrationState(), support.SharedConfig().ConsensusMigrationContext())
+
 	return &chain{
 		support:  support,
 		sendChan: make(chan *message),
 		exitChan: make(chan struct{}),
+
+		migrationStatusStepper: migrationStatusStepper,
+		migrationController:    migrationController,
 	}
 }
 
@@ -68,8 +82,17 @@ func (ch *chain) WaitReady() error {
 	return nil
 }
 
+// MigrationStatus provides access to the consensus-type migration status of the chain.
+func (ch *chain) MigrationStatus() migration.Status {
+	return ch.migrationStatusStepper
+}
+
 // Order accepts normal messages for ordering
 func (ch *chain) Order(env *cb.Envelope, configSeq uint64) error {
+	if ch.migrationStatusStepper.IsPending() || ch.migrationStatusStepper.IsCommitted() {
+		return fmt.Errorf("cannot enqueue, consensus-type migration pending")
+	}
+
 	select {
 	case ch.sendChan <- &message{
 		configSeq: configSeq,
@@ -110,6 +133,10 @@ func (ch *chain) main() {
 		case msg := <-ch.sendChan:
 			if msg.configMsg == nil {
 				// NormalMsg
+				if ch.migrationStatusStepper.IsPending() || ch.migrationStatusStepper.IsCommitted() {
+					logger.Warningf("Discarding normal message, consensus-type migration pending")
+					continue
+				}
 				if msg.configSeq < seq {
 					_, err = ch.support.ProcessNormalMsg(msg.normalMsg)
 					if err != nil {
@@ -130,8 +157,9 @@ func (ch *chain) main() {
 					timer = nil
 				case timer == nil && pending:
 					// Timer is not already running and there are messages pending, so start it
-					timer = time.After(ch.support.SharedConfig().BatchTimeout())
-					logger.Debugf("Just began %s batch timer", ch.support.SharedConfig().BatchTimeout().String())
+					batchTimeout := blockcutter.BatchTimeout(ch.support.BlockCutter(), ch.support.SharedConfig())
+					timer = time.After(batchTimeout)
+					logger.Debugf("Just began %s batch timer", batchTimeout.String())
 				default:
 					// Do nothing when:
 					// 1. Timer is already running and there are messages pending
@@ -152,10 +180,22 @@ func (ch *chain) main() {
 					block := ch.support.CreateNextBlock(batch)
 					ch.support.WriteBlock(block, nil)
 				}
+				timer = nil
+
+				commitBlock, _, raftMetadata, err := migration.StepConfigMsg(
+					ch.migrationStatusStepper, ch.support.ChainID(), msg.configMsg, ch.support.Height()-1, ch.migrationController)
+				if err != nil {
+					logger.Warningf("Discarding config message, failed to evaluate consensus-type migration: %s", err)
+					continue
+				}
+				if !commitBlock {
+					logger.Warningf("Discarding config message, rejected by consensus-type migration; Status: %s", ch.migrationStatusStepper)
+					continue
+				}
 
 				block := ch.support.CreateNextBlock([]*cb.Envelope{msg.configMsg})
-				ch.support.WriteConfigBlock(block, nil)
-				timer = nil
+				// The Raft chain that takes over after the migration reads its metadata from this block
+				ch.support.WriteConfigBlock(block, raftMetadata)
 			}
 		case <-timer:
 			//clear the timer
@@ -11,11 +11,16 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/flogging"
 	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
+	"github.com/hyperledger/fabric/orderer/consensus/mocks"
 	mockblockcutter "github.com/hyperledger/fabric/orderer/mocks/common/blockcutter"
 	mockmultichannel "github.com/hyperledger/fabric/orderer/mocks/common/multichannel"
 	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/orderer/etcdraft"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/stretchr/testify/assert"
 )
@@ -60,7 +65,7 @@ func TestHaltBeforeTimeout(t *testing.T) {
 		SharedConfigVal: &mockconfig.Orderer{BatchTimeoutVal: batchTimeout},
 	}
 	defer close(support.BlockCutterVal.Block)
-	bs := newChain(support)
+	bs := newChain(support, nil)
 	wg := goWithWait(bs.main)
 	defer bs.Halt()
 
@@ -81,7 +86,7 @@ func TestStart(t *testing.T) {
 		SharedConfigVal: &mockconfig.Orderer{BatchTimeoutVal: batchTimeout},
 	}
 	close(support.BlockCutterVal.Block)
-	bs, _ := New().HandleChain(support, nil)
+	bs, _ := New(nil).HandleChain(support, nil)
 	bs.Start()
 	defer bs.Halt()
 
@@ -102,7 +107,7 @@ func TestOrderAfterHalt(t *testing.T) {
 		SharedConfigVal: &mockconfig.Orderer{BatchTimeoutVal: batchTimeout},
 	}
 	defer close(support.BlockCutterVal.Block)
-	bs := newChain(support)
+	bs := newChain(support, nil)
 	bs.Halt()
 	assert.NotNil(t, bs.Order(testMessage, 0), "Order should not be accepted after halt")
 	select {
@@ -120,7 +125,7 @@ func TestBatchTimer(t *testing.T) {
 		SharedConfigVal: &mockconfig.Orderer{BatchTimeoutVal: batchTimeout},
 	}
 	defer close(support.BlockCutterVal.Block)
-	bs := newChain(support)
+	bs := newChain(support, nil)
 	wg := goWithWait(bs.main)
 	defer bs.Halt()
 
@@ -164,7 +169,7 @@ func TestBatchTimerHaltOnFilledBatch(t *testing.T) {
 	}
 	defer close(support.BlockCutterVal.Block)
 
-	bs := newChain(support)
+	bs := newChain(support, nil)
 	wg := goWithWait(bs.main)
 	defer bs.Halt()
 
@@ -206,7 +211,7 @@ func TestLargeMsgStyleMultiBatch(t *testing.T) {
 		SharedConfigVal: &mockconfig.Orderer{BatchTimeoutVal: batchTimeout},
 	}
 	defer close(support.BlockCutterVal.Block)
-	bs := newChain(support)
+	bs := newChain(support, nil)
 	wg := goWithWait(bs.main)
 	defer bs.Halt()
 
@@ -242,7 +247,7 @@ func TestConfigMsg(t *testing.T) {
 		SharedConfigVal: &mockconfig.Orderer{BatchTimeoutVal: batchTimeout},
 	}
 	defer close(support.BlockCutterVal.Block)
-	bs := newChain(support)
+	bs := newChain(support, nil)
 	wg := goWithWait(bs.main)
 	defer bs.Halt()
 
@@ -279,7 +284,7 @@ func TestRecoverFromError(t *testing.T) {
 		SharedConfigVal: &mockconfig.Orderer{BatchTimeoutVal: batchTimeout},
 	}
 	defer close(support.BlockCutterVal.Block)
-	bs := newChain(support)
+	bs := newChain(support, nil)
 	go bs.main()
 	defer bs.Halt()
 
@@ -312,7 +317,7 @@ func TestRevalidation(t *testing.T) {
 		SequenceVal:     uint64(1),
 	}
 	defer close(support.BlockCutterVal.Block)
-	bs := newChain(support)
+	bs := newChain(support, nil)
 	wg := goWithWait(bs.main)
 	defer bs.Halt()
 
@@ -386,7 +391,7 @@ func TestPendingMsgCutByTimeout(t *testing.T) {
 	}
 	defer close(support.BlockCutterVal.Block)
 
-	bs := newChain(support)
+	bs := newChain(support, nil)
 	wg := goWithWait(bs.main)
 	defer bs.Halt()
 
@@ -413,3 +418,127 @@ func TestPendingMsgCutByTimeout(t *testing.T) {
 	case <-wg.done:
 	}
 }
+
+func makeConsensusTypeConfigMsg(consensusType *ab.ConsensusType) *cb.Envelope {
+	return &cb.Envelope{
+		Payload: utils.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{Type: int32(cb.HeaderType_CONFIG), ChannelId: "foo"})},
+			Data: utils.MarshalOrPanic(&cb.ConfigEnvelope{
+				Config: &cb.Config{
+					ChannelGroup: &cb.ConfigGroup{
+						Groups: map[string]*cb.ConfigGroup{
+							channelconfig.OrdererGroupKey: {
+								Values: map[string]*cb.ConfigValue{
+									channelconfig.ConsensusTypeKey: {Value: utils.MarshalOrPanic(consensusType)},
+								},
+							},
+						},
+					},
+				},
+			}),
+		}),
+	}
+}
+
+// This test checks that a standard channel of the solo consenter prepares its consensus-type migration to Raft, by
+// committing the CONTEXT config block with the initial Raft metadata, and orders transactions again once the
+// migration is aborted.
+func TestMigration(t *testing.T) {
+	support := &mockmultichannel.ConsenterSupport{
+		Blocks:          make(chan *cb.Block),
+		BlockCutterVal:  mockblockcutter.NewReceiver(),
+		SharedConfigVal: &mockconfig.Orderer{BatchTimeoutVal: time.Hour},
+		ChainIDVal:      "foo",
+		HeightVal:       3,
+	}
+	defer close(support.BlockCutterVal.Block)
+
+	migController := &mocks.FakeMigrationController{}
+	migController.ConsensusMigrationPendingReturns(true)
+
+	bs := newChain(support, migController)
+	wg := goWithWait(bs.main)
+	defer bs.Halt()
+
+	// START is set by the system channel, via the migration controller
+	bs.MigrationStatus().SetStateContext(ab.ConsensusType_MIG_STATE_START, 7)
+	assert.EqualError(t, bs.Order(testMessage, 0), "cannot enqueue, consensus-type migration pending")
+
+	consenters := []*etcdraft.Consenter{
+		{Host: "orderer1", Port: 7050, ClientTlsCert: []byte("cert1"), ServerTlsCert: []byte("cert1")},
+		{Host: "orderer2", Port: 7050, ClientTlsCert: []byte("cert2"), ServerTlsCert: []byte("cert2")},
+	}
+	options := &etcdraft.Options{TickInterval: 100, ElectionTick: 10, HeartbeatTick: 1}
+
+	// A context without a consenter set cannot be migrated to Raft
+	assert.Nil(t, bs.Configure(makeConsensusTypeConfigMsg(&ab.ConsensusType{
+		Type:             "etcdraft",
+		Metadata:         utils.MarshalOrPanic(&etcdraft.Metadata{Options: options}),
+		MigrationState:   ab.ConsensusType_MIG_STATE_CONTEXT,
+		MigrationContext: 7,
+	}), 0))
+	select {
+	case <-support.Blocks:
+		t.Fatalf("Expected no block to be cut")
+	case <-time.After(100 * time.Millisecond):
+	}
+	state, context := bs.MigrationStatus().StateContext()
+	assert.Equal(t, ab.ConsensusType_MIG_STATE_START, state)
+	assert.Equal(t, uint64(7), context)
+
+	assert.Nil(t, bs.Configure(makeConsensusTypeConfigMsg(&ab.ConsensusType{
+		Type:             "etcdraft",
+		Metadata:         utils.MarshalOrPanic(&etcdraft.Metadata{Consenters: consenters, Options: options}),
+		MigrationState:   ab.ConsensusType_MIG_STATE_CONTEXT,
+		MigrationContext: 7,
+	}), 0))
+	select {
+	case block := <-support.Blocks:
+		metadata, err := utils.GetMetadataFromBlock(block, cb.BlockMetadataIndex_ORDERER)
+		assert.NoError(t, err)
+		raftMetadata := &etcdraft.RaftMetadata{}
+		assert.NoError(t, proto.Unmarshal(metadata.Value, raftMetadata))
+		assert.True(t, proto.Equal(&etcdraft.RaftMetadata{
+			Consenters:      map[uint64]*etcdraft.Consenter{1: consenters[0], 2: consenters[1]},
+			NextConsenterId: 3,
+		}, raftMetadata))
+	case <-time.After(time.Second):
+		t.Fatalf("Expected the context config block to be cut")
+	}
+	state, context = bs.MigrationStatus().StateContext()
+	assert.Equal(t, ab.ConsensusType_MIG_STATE_CONTEXT, state)
+	assert.Equal(t, uint64(7), context)
+	// The context without a consenter set was dropped before stepping through the migration state machine
+	assert.Equal(t, 1, migController.ConsensusMigrationPendingCallCount())
+
+	// ABORT is set by the system channel, via the migration controller
+	bs.MigrationStatus().SetStateContext(ab.ConsensusType_MIG_STATE_ABORT, 7)
+
+	assert.Nil(t, bs.Configure(makeConsensusTypeConfigMsg(&ab.ConsensusType{Type: "solo"}), 0))
+	select {
+	case block := <-support.Blocks:
+		metadata, err := utils.GetMetadataFromBlock(block, cb.BlockMetadataIndex_ORDERER)
+		assert.NoError(t, err)
+		assert.Empty(t, metadata.Value)
+	case <-time.After(time.Second):
+		t.Fatalf("Expected the config block that completes the abort to be cut")
+	}
+	state, context = bs.MigrationStatus().StateContext()
+	assert.Equal(t, ab.ConsensusType_MIG_STATE_NONE, state)
+	assert.Equal(t, uint64(0), context)
+
+	support.BlockCutterVal.CutNext = true
+	syncQueueMessage(testMessage, bs, support.BlockCutterVal)
+	select {
+	case <-support.Blocks:
+	case <-time.After(time.Second):
+		t.Fatalf("Expected block to be cut once the migration is aborted")
+	}
+
+	bs.Halt()
+	select {
+	case <-time.After(time.Second):
+		t.Fatalf("Should have exited")
+	case <-wg.done:
+	}
+}
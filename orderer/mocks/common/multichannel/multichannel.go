@@ -66,6 +66,9 @@ type ConsenterSupport struct {
 
 	// BlockVerificationErr is returned by VerifyBlockSignature
 	BlockVerificationErr error
+
+	// SystemChannelVal is returned by IsSystemChannel
+	SystemChannelVal bool
 }
 
 // Block returns the block with the given number or nil if not found
@@ -119,6 +122,11 @@ func (mcs *ConsenterSupport) Height() uint64 {
 	return mcs.HeightVal
 }
 
+// IsSystemChannel returns SystemChannelVal
+func (mcs *ConsenterSupport) IsSystemChannel() bool {
+	return mcs.SystemChannelVal
+}
+
 // Sign returns the bytes passed in
 func (mcs *ConsenterSupport) Sign(message []byte) ([]byte, error) {
 	return message, nil
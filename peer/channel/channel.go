@@ -40,6 +40,9 @@ var (
 	channelTxFile string
 	outputBlock   string
 	timeout       time.Duration
+
+	// purgepvtdata related variables
+	retainFromBlock uint64
 )
 
 // Cmd returns the cobra command for Node
@@ -53,6 +56,7 @@ func Cmd(cf *ChannelCmdFactory) *cobra.Command {
 	channelCmd.AddCommand(updateCmd(cf))
 	channelCmd.AddCommand(signconfigtxCmd(cf))
 	channelCmd.AddCommand(getinfoCmd(cf))
+	channelCmd.AddCommand(purgepvtdataCmd(cf))
 
 	return channelCmd
 }
@@ -77,6 +81,7 @@ func resetFlags() {
 	flags.StringVarP(&channelTxFile, "file", "f", "", "Configuration transaction file generated by a tool such as configtxgen for submitting to orderer")
 	flags.StringVarP(&outputBlock, "outputBlock", "", common.UndefinedParamValue, `The path to write the genesis block for the channel. (default ./<channelID>.block)`)
 	flags.DurationVarP(&timeout, "timeout", "t", 10*time.Second, "Channel creation timeout")
+	flags.Uint64VarP(&retainFromBlock, "retainFromBlock", "", 0, "The block number from which the private data is retained")
 }
 
 func attachFlags(cmd *cobra.Command, names []string) {
@@ -92,8 +97,8 @@ func attachFlags(cmd *cobra.Command, names []string) {
 
 var channelCmd = &cobra.Command{
 	Use:   "channel",
-	Short: "Operate a channel: create|fetch|join|list|update|signconfigtx|getinfo.",
-	Long:  "Operate a channel: create|fetch|join|list|update|signconfigtx|getinfo.",
+	Short: "Operate a channel: create|fetch|join|list|update|signconfigtx|getinfo|purgepvtdata.",
+	Long:  "Operate a channel: create|fetch|join|list|update|signconfigtx|getinfo|purgepvtdata.",
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
 		common.InitCmd(cmd, args)
 		common.SetOrdererEnv(cmd, args)
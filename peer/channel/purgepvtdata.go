@@ -0,0 +1,115 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hyperledger/fabric/core/scc/cscc"
+	"github.com/hyperledger/fabric/peer/common"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func purgepvtdataCmd(cf *ChannelCmdFactory) *cobra.Command {
+	purgepvtdataCmd := &cobra.Command{
+		Use:   "purgepvtdata",
+		Short: "Purge the private data of a specified channel.",
+		Long:  "Purge the private data of all the collections of a specified channel that was committed below a given block number. Requires '-c' and '--retainFromBlock'.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return purgepvtdata(cmd, cf)
+		},
+	}
+	flagList := []string{
+		"channelID",
+		"retainFromBlock",
+	}
+	attachFlags(purgepvtdataCmd, flagList)
+
+	return purgepvtdataCmd
+}
+
+func (cc *endorserClient) purgePrivateData(blockNum uint64) (uint64, error) {
+	var err error
+
+	invocation := &pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			Type:        pb.ChaincodeSpec_Type(pb.ChaincodeSpec_Type_value["GOLANG"]),
+			ChaincodeId: &pb.ChaincodeID{Name: "cscc"},
+			Input: &pb.ChaincodeInput{Args: [][]byte{
+				[]byte(cscc.PurgePrivateData),
+				[]byte(channelID),
+				[]byte(strconv.FormatUint(blockNum, 10)),
+			}},
+		},
+	}
+
+	var prop *pb.Proposal
+	c, _ := cc.cf.Signer.Serialize()
+	prop, _, err = utils.CreateProposalFromCIS(cb.HeaderType_ENDORSER_TRANSACTION, "", invocation, c)
+	if err != nil {
+		return 0, errors.WithMessage(err, "cannot create proposal")
+	}
+
+	var signedProp *pb.SignedProposal
+	signedProp, err = utils.GetSignedProposal(prop, cc.cf.Signer)
+	if err != nil {
+		return 0, errors.WithMessage(err, "cannot create signed proposal")
+	}
+
+	proposalResp, err := cc.cf.EndorserClient.ProcessProposal(context.Background(), signedProp)
+	if err != nil {
+		return 0, errors.WithMessage(err, "failed sending proposal")
+	}
+
+	if proposalResp.Response == nil || proposalResp.Response.Status != 200 {
+		return 0, errors.Errorf("received bad response, status %d: %s", proposalResp.Response.Status, proposalResp.Response.Message)
+	}
+
+	minBlockNum, err := strconv.ParseUint(string(proposalResp.Response.Payload), 10, 64)
+	if err != nil {
+		return 0, errors.Wrap(err, "cannot read cscc response")
+	}
+
+	return minBlockNum, nil
+}
+
+func purgepvtdata(cmd *cobra.Command, cf *ChannelCmdFactory) error {
+	//the global chainID filled by the "-c" command
+	if channelID == common.UndefinedParamValue {
+		return errors.New("Must supply channel ID")
+	}
+	if !cmd.Flags().Changed("retainFromBlock") {
+		return errors.New("Must supply the block number to retain the private data from")
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory(EndorserRequired, PeerDeliverNotRequired, OrdererNotRequired)
+		if err != nil {
+			return err
+		}
+	}
+
+	client := &endorserClient{cf}
+
+	minBlockNum, err := client.purgePrivateData(retainFromBlock)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Private data is retained from block %d\n", minBlockNum)
+
+	return nil
+}
@@ -0,0 +1,100 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/peer/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPurgePvtData(t *testing.T) {
+	InitMSP()
+	resetFlags()
+
+	mockResponse := &pb.ProposalResponse{
+		Response: &pb.Response{
+			Status:  200,
+			Payload: []byte("10"),
+		},
+		Endorsement: &pb.Endorsement{},
+	}
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+
+	mockCF := &ChannelCmdFactory{
+		EndorserClient:   common.GetMockEndorserClient(mockResponse, nil),
+		BroadcastFactory: mockBroadcastClientFactory,
+		Signer:           signer,
+	}
+
+	cmd := purgepvtdataCmd(mockCF)
+	AddFlags(cmd)
+
+	args := []string{"-c", mockChannel, "--retainFromBlock", "10"}
+	cmd.SetArgs(args)
+
+	assert.NoError(t, cmd.Execute())
+}
+
+func TestPurgePvtDataBadResponse(t *testing.T) {
+	InitMSP()
+	resetFlags()
+
+	mockResponse := &pb.ProposalResponse{
+		Response: &pb.Response{
+			Status:  500,
+			Message: "access denied",
+		},
+		Endorsement: &pb.Endorsement{},
+	}
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+
+	mockCF := &ChannelCmdFactory{
+		EndorserClient:   common.GetMockEndorserClient(mockResponse, nil),
+		BroadcastFactory: mockBroadcastClientFactory,
+		Signer:           signer,
+	}
+
+	cmd := purgepvtdataCmd(mockCF)
+	AddFlags(cmd)
+
+	args := []string{"-c", mockChannel, "--retainFromBlock", "10"}
+	cmd.SetArgs(args)
+
+	assert.EqualError(t, cmd.Execute(), "received bad response, status 500: access denied")
+}
+
+func TestPurgePvtDataMissingArgs(t *testing.T) {
+	InitMSP()
+	resetFlags()
+
+	signer, err := common.GetDefaultSigner()
+	if err != nil {
+		t.Fatalf("Get default signer error: %v", err)
+	}
+
+	mockCF := &ChannelCmdFactory{
+		Signer: signer,
+	}
+
+	cmd := purgepvtdataCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"--retainFromBlock", "10"})
+	assert.EqualError(t, cmd.Execute(), "Must supply channel ID")
+
+	resetFlags()
+	cmd = purgepvtdataCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"-c", mockChannel})
+	assert.EqualError(t, cmd.Execute(), "Must supply the block number to retain the private data from")
+}
@@ -0,0 +1,42 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollbackCmd(t *testing.T) {
+	testPath, err := ioutil.TempDir("", "rollbackcmd")
+	assert.NoError(t, err)
+	defer os.RemoveAll(testPath)
+	viper.Set("peer.fileSystemPath", testPath)
+	defer viper.Reset()
+
+	t.Run("missing-channel", func(t *testing.T) {
+		cmd := rollbackCmd()
+		cmd.SetArgs([]string{"-b", "10"})
+		assert.EqualError(t, cmd.Execute(), "Must supply channel ID")
+	})
+
+	t.Run("trailing-args", func(t *testing.T) {
+		cmd := rollbackCmd()
+		cmd.SetArgs([]string{"-c", "ch1", "-b", "10", "extra"})
+		assert.EqualError(t, cmd.Execute(), "trailing args detected")
+	})
+
+	t.Run("non-existing-channel", func(t *testing.T) {
+		cmd := rollbackCmd()
+		cmd.SetArgs([]string{"-c", "ch1", "-b", "10"})
+		assert.EqualError(t, cmd.Execute(), "ledger [ch1] does not exist")
+	})
+}
@@ -55,6 +55,7 @@ import (
 	endorsement2 "github.com/hyperledger/fabric/core/handlers/endorsement/api"
 	endorsement3 "github.com/hyperledger/fabric/core/handlers/endorsement/api/identities"
 	"github.com/hyperledger/fabric/core/handlers/library"
+	"github.com/hyperledger/fabric/core/handlers/statedatabase/api"
 	validation "github.com/hyperledger/fabric/core/handlers/validation/api"
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
@@ -186,6 +187,13 @@ func serve(args []string) error {
 		ChannelConfigSource:          peer.Default,
 	}
 
+	// the handlers are loaded before the ledgers because the state database may be provided by a handler
+	libConf := library.Config{}
+	if err = viperutil.EnhancedExactUnmarshalKey("peer.handlers", &libConf); err != nil {
+		return errors.WithMessage(err, "could not load YAML config")
+	}
+	reg := library.InitRegistry(libConf)
+
 	//initialize resource management exit
 	ledgermgmt.Initialize(
 		&ledgermgmt.Initializer{
@@ -195,6 +203,7 @@ func serve(args []string) error {
 			MembershipInfoProvider:        membershipInfoProvider,
 			MetricsProvider:               metricsProvider,
 			HealthCheckRegistry:           opsSystem,
+			StateDBProviderFactories:      reg.Lookup(library.StateDatabase).(map[string]statedatabase.PluginFactory),
 		},
 	)
 
@@ -398,12 +407,6 @@ func serve(args []string) error {
 		logger.Panicf("Failed serializing self identity: %v", err)
 	}
 
-	libConf := library.Config{}
-	if err = viperutil.EnhancedExactUnmarshalKey("peer.handlers", &libConf); err != nil {
-		return errors.WithMessage(err, "could not load YAML config")
-	}
-	reg := library.InitRegistry(libConf)
-
 	authFilters := reg.Lookup(library.Auth).([]authHandler.Filter)
 	endorserSupport := &endorser.SupportImpl{
 		SignerSupport:    signingIdentity,
@@ -896,6 +899,7 @@ func registerProverService(peerServer *comm.GRPCServer, aclProvider aclmgmt.ACLP
 			IssueTokens:    resources.Token_Issue,
 			TransferTokens: resources.Token_Transfer,
 			ListTokens:     resources.Token_List,
+			AuditTokens:    resources.Token_Audit,
 		},
 	}
 
@@ -905,17 +909,19 @@ func registerProverService(peerServer *comm.GRPCServer, aclProvider aclmgmt.ACLP
 		return err
 	}
 
+	capabilityChecker := &server.TokenCapabilityChecker{
+		PeerOps: peer.Default,
+	}
 	prover := &server.Prover{
-		CapabilityChecker: &server.TokenCapabilityChecker{
-			PeerOps: peer.Default,
-		},
-		Marshaler:     responseMarshaler,
-		PolicyChecker: policyChecker,
+		CapabilityChecker: capabilityChecker,
+		Marshaler:         responseMarshaler,
+		PolicyChecker:     policyChecker,
 		TMSManager: &server.Manager{
 			LedgerManager: &server.PeerLedgerManager{},
 			TokenOwnerValidatorManager: &server.PeerTokenOwnerValidatorManager{
 				IdentityDeserializerManager: &manager.FabricIdentityDeserializerManager{},
 			},
+			CapabilityChecker: capabilityChecker,
 		},
 	}
 	token.RegisterProverServer(peerServer.Server(), prover)
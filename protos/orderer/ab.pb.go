@@ -7,6 +7,7 @@ import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
 import common "github.com/hyperledger/fabric/protos/common"
+import peer "github.com/hyperledger/fabric/protos/peer"
 
 import (
 	context "golang.org/x/net/context"
@@ -44,7 +45,34 @@ func (x SeekInfo_SeekBehavior) String() string {
 	return proto.EnumName(SeekInfo_SeekBehavior_name, int32(x))
 }
 func (SeekInfo_SeekBehavior) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_ab_86effae0ebc2388c, []int{5, 0}
+	return fileDescriptor_ab_721163307403b25c, []int{5, 0}
+}
+
+// SeekContentType indicates what type of content to deliver in response to a request.
+type SeekInfo_SeekContentType int32
+
+const (
+	SeekInfo_BLOCK           SeekInfo_SeekContentType = 0
+	SeekInfo_HEADER_WITH_SIG SeekInfo_SeekContentType = 1
+	SeekInfo_FILTERED        SeekInfo_SeekContentType = 2
+)
+
+var SeekInfo_SeekContentType_name = map[int32]string{
+	0: "BLOCK",
+	1: "HEADER_WITH_SIG",
+	2: "FILTERED",
+}
+var SeekInfo_SeekContentType_value = map[string]int32{
+	"BLOCK":           0,
+	"HEADER_WITH_SIG": 1,
+	"FILTERED":        2,
+}
+
+func (x SeekInfo_SeekContentType) String() string {
+	return proto.EnumName(SeekInfo_SeekContentType_name, int32(x))
+}
+func (SeekInfo_SeekContentType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_ab_721163307403b25c, []int{5, 1}
 }
 
 type BroadcastResponse struct {
@@ -61,7 +89,7 @@ func (m *BroadcastResponse) Reset()         { *m = BroadcastResponse{} }
 func (m *BroadcastResponse) String() string { return proto.CompactTextString(m) }
 func (*BroadcastResponse) ProtoMessage()    {}
 func (*BroadcastResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_86effae0ebc2388c, []int{0}
+	return fileDescriptor_ab_721163307403b25c, []int{0}
 }
 func (m *BroadcastResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BroadcastResponse.Unmarshal(m, b)
@@ -105,7 +133,7 @@ func (m *SeekNewest) Reset()         { *m = SeekNewest{} }
 func (m *SeekNewest) String() string { return proto.CompactTextString(m) }
 func (*SeekNewest) ProtoMessage()    {}
 func (*SeekNewest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_86effae0ebc2388c, []int{1}
+	return fileDescriptor_ab_721163307403b25c, []int{1}
 }
 func (m *SeekNewest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SeekNewest.Unmarshal(m, b)
@@ -135,7 +163,7 @@ func (m *SeekOldest) Reset()         { *m = SeekOldest{} }
 func (m *SeekOldest) String() string { return proto.CompactTextString(m) }
 func (*SeekOldest) ProtoMessage()    {}
 func (*SeekOldest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_86effae0ebc2388c, []int{2}
+	return fileDescriptor_ab_721163307403b25c, []int{2}
 }
 func (m *SeekOldest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SeekOldest.Unmarshal(m, b)
@@ -166,7 +194,7 @@ func (m *SeekSpecified) Reset()         { *m = SeekSpecified{} }
 func (m *SeekSpecified) String() string { return proto.CompactTextString(m) }
 func (*SeekSpecified) ProtoMessage()    {}
 func (*SeekSpecified) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_86effae0ebc2388c, []int{3}
+	return fileDescriptor_ab_721163307403b25c, []int{3}
 }
 func (m *SeekSpecified) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SeekSpecified.Unmarshal(m, b)
@@ -208,7 +236,7 @@ func (m *SeekPosition) Reset()         { *m = SeekPosition{} }
 func (m *SeekPosition) String() string { return proto.CompactTextString(m) }
 func (*SeekPosition) ProtoMessage()    {}
 func (*SeekPosition) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_86effae0ebc2388c, []int{4}
+	return fileDescriptor_ab_721163307403b25c, []int{4}
 }
 func (m *SeekPosition) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SeekPosition.Unmarshal(m, b)
@@ -380,19 +408,20 @@ func _SeekPosition_OneofSizer(msg proto.Message) (n int) {
 // as they are created, behavior should be set to BLOCK_UNTIL_READY and the stop should be set to
 // specified with a number of MAX_UINT64
 type SeekInfo struct {
-	Start                *SeekPosition         `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
-	Stop                 *SeekPosition         `protobuf:"bytes,2,opt,name=stop,proto3" json:"stop,omitempty"`
-	Behavior             SeekInfo_SeekBehavior `protobuf:"varint,3,opt,name=behavior,proto3,enum=orderer.SeekInfo_SeekBehavior" json:"behavior,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+	Start                *SeekPosition            `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	Stop                 *SeekPosition            `protobuf:"bytes,2,opt,name=stop,proto3" json:"stop,omitempty"`
+	Behavior             SeekInfo_SeekBehavior    `protobuf:"varint,3,opt,name=behavior,proto3,enum=orderer.SeekInfo_SeekBehavior" json:"behavior,omitempty"`
+	ContentType          SeekInfo_SeekContentType `protobuf:"varint,4,opt,name=content_type,json=contentType,proto3,enum=orderer.SeekInfo_SeekContentType" json:"content_type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
 }
 
 func (m *SeekInfo) Reset()         { *m = SeekInfo{} }
 func (m *SeekInfo) String() string { return proto.CompactTextString(m) }
 func (*SeekInfo) ProtoMessage()    {}
 func (*SeekInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_86effae0ebc2388c, []int{5}
+	return fileDescriptor_ab_721163307403b25c, []int{5}
 }
 func (m *SeekInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SeekInfo.Unmarshal(m, b)
@@ -433,10 +462,18 @@ func (m *SeekInfo) GetBehavior() SeekInfo_SeekBehavior {
 	return SeekInfo_BLOCK_UNTIL_READY
 }
 
+func (m *SeekInfo) GetContentType() SeekInfo_SeekContentType {
+	if m != nil {
+		return m.ContentType
+	}
+	return SeekInfo_BLOCK
+}
+
 type DeliverResponse struct {
 	// Types that are valid to be assigned to Type:
 	//	*DeliverResponse_Status
 	//	*DeliverResponse_Block
+	//	*DeliverResponse_FilteredBlock
 	Type                 isDeliverResponse_Type `protobuf_oneof:"Type"`
 	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
 	XXX_unrecognized     []byte                 `json:"-"`
@@ -447,7 +484,7 @@ func (m *DeliverResponse) Reset()         { *m = DeliverResponse{} }
 func (m *DeliverResponse) String() string { return proto.CompactTextString(m) }
 func (*DeliverResponse) ProtoMessage()    {}
 func (*DeliverResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_86effae0ebc2388c, []int{6}
+	return fileDescriptor_ab_721163307403b25c, []int{6}
 }
 func (m *DeliverResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeliverResponse.Unmarshal(m, b)
@@ -479,10 +516,16 @@ type DeliverResponse_Block struct {
 	Block *common.Block `protobuf:"bytes,2,opt,name=block,proto3,oneof"`
 }
 
+type DeliverResponse_FilteredBlock struct {
+	FilteredBlock *peer.FilteredBlock `protobuf:"bytes,3,opt,name=filtered_block,json=filteredBlock,proto3,oneof"`
+}
+
 func (*DeliverResponse_Status) isDeliverResponse_Type() {}
 
 func (*DeliverResponse_Block) isDeliverResponse_Type() {}
 
+func (*DeliverResponse_FilteredBlock) isDeliverResponse_Type() {}
+
 func (m *DeliverResponse) GetType() isDeliverResponse_Type {
 	if m != nil {
 		return m.Type
@@ -504,11 +547,19 @@ func (m *DeliverResponse) GetBlock() *common.Block {
 	return nil
 }
 
+func (m *DeliverResponse) GetFilteredBlock() *peer.FilteredBlock {
+	if x, ok := m.GetType().(*DeliverResponse_FilteredBlock); ok {
+		return x.FilteredBlock
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*DeliverResponse) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _DeliverResponse_OneofMarshaler, _DeliverResponse_OneofUnmarshaler, _DeliverResponse_OneofSizer, []interface{}{
 		(*DeliverResponse_Status)(nil),
 		(*DeliverResponse_Block)(nil),
+		(*DeliverResponse_FilteredBlock)(nil),
 	}
 }
 
@@ -524,6 +575,11 @@ func _DeliverResponse_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.Block); err != nil {
 			return err
 		}
+	case *DeliverResponse_FilteredBlock:
+		b.EncodeVarint(3<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.FilteredBlock); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("DeliverResponse.Type has unexpected type %T", x)
@@ -549,6 +605,14 @@ func _DeliverResponse_OneofUnmarshaler(msg proto.Message, tag, wire int, b *prot
 		err := b.DecodeMessage(msg)
 		m.Type = &DeliverResponse_Block{msg}
 		return true, err
+	case 3: // Type.filtered_block
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(peer.FilteredBlock)
+		err := b.DecodeMessage(msg)
+		m.Type = &DeliverResponse_FilteredBlock{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -566,6 +630,11 @@ func _DeliverResponse_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *DeliverResponse_FilteredBlock:
+		s := proto.Size(x.FilteredBlock)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -582,6 +651,7 @@ func init() {
 	proto.RegisterType((*SeekInfo)(nil), "orderer.SeekInfo")
 	proto.RegisterType((*DeliverResponse)(nil), "orderer.DeliverResponse")
 	proto.RegisterEnum("orderer.SeekInfo_SeekBehavior", SeekInfo_SeekBehavior_name, SeekInfo_SeekBehavior_value)
+	proto.RegisterEnum("orderer.SeekInfo_SeekContentType", SeekInfo_SeekContentType_name, SeekInfo_SeekContentType_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -757,40 +827,47 @@ var _AtomicBroadcast_serviceDesc = grpc.ServiceDesc{
 	Metadata: "orderer/ab.proto",
 }
 
-func init() { proto.RegisterFile("orderer/ab.proto", fileDescriptor_ab_86effae0ebc2388c) }
-
-var fileDescriptor_ab_86effae0ebc2388c = []byte{
-	// 505 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x93, 0xdf, 0x6e, 0x12, 0x51,
-	0x10, 0xc6, 0x59, 0xa5, 0xb4, 0x8c, 0x2d, 0xa5, 0xa7, 0x69, 0x43, 0xb8, 0x30, 0xcd, 0x26, 0x55,
-	0x8c, 0xba, 0x6b, 0x30, 0xf1, 0x42, 0x4d, 0x0c, 0x6b, 0xdb, 0x40, 0x24, 0x60, 0x16, 0x7a, 0xa1,
-	0x37, 0x64, 0x77, 0x19, 0x60, 0xed, 0xb2, 0x67, 0x73, 0xce, 0x01, 0xd3, 0xa7, 0xf0, 0x45, 0x7c,
-	0x24, 0x1f, 0xc6, 0x9c, 0x3f, 0xbb, 0x48, 0xd5, 0x5e, 0xb1, 0x33, 0xf3, 0xfb, 0xce, 0x7c, 0x33,
-	0x19, 0xa0, 0x4e, 0xd9, 0x14, 0x19, 0x32, 0x37, 0x08, 0x9d, 0x8c, 0x51, 0x41, 0xc9, 0xae, 0xc9,
-	0x34, 0x8f, 0x23, 0xba, 0x5c, 0xd2, 0xd4, 0xd5, 0x3f, 0xba, 0x6a, 0x0f, 0xe1, 0xc8, 0x63, 0x34,
-	0x98, 0x46, 0x01, 0x17, 0x3e, 0xf2, 0x8c, 0xa6, 0x1c, 0xc9, 0x13, 0xa8, 0x70, 0x11, 0x88, 0x15,
-	0x6f, 0x58, 0x67, 0x56, 0xab, 0xd6, 0xae, 0x39, 0x46, 0x33, 0x52, 0x59, 0xdf, 0x54, 0x09, 0x81,
-	0x72, 0x9c, 0xce, 0x68, 0xe3, 0xc1, 0x99, 0xd5, 0xaa, 0xfa, 0xea, 0xdb, 0xde, 0x07, 0x18, 0x21,
-	0xde, 0x0c, 0xf0, 0x3b, 0x72, 0x91, 0x47, 0xc3, 0x64, 0x2a, 0xa3, 0xa7, 0x70, 0x20, 0xa3, 0x51,
-	0x86, 0x51, 0x3c, 0x8b, 0x71, 0x4a, 0x4e, 0xa1, 0x92, 0xae, 0x96, 0x21, 0x32, 0xd5, 0xa8, 0xec,
-	0x9b, 0xc8, 0xfe, 0x69, 0xc1, 0xbe, 0x24, 0x3f, 0x53, 0x1e, 0x8b, 0x98, 0xa6, 0xe4, 0x25, 0x54,
-	0x52, 0xf5, 0xa2, 0x02, 0x1f, 0xb5, 0x8f, 0x1d, 0x33, 0x95, 0xb3, 0x69, 0xd6, 0x2d, 0xf9, 0x06,
-	0x92, 0x38, 0x55, 0x2d, 0x95, 0xb5, 0xbb, 0xb8, 0x76, 0x23, 0x71, 0x0d, 0x91, 0x37, 0x50, 0xe5,
-	0xb9, 0xa7, 0xc6, 0x43, 0xa5, 0x38, 0xdd, 0x52, 0x14, 0x8e, 0xbb, 0x25, 0x7f, 0x83, 0x7a, 0x15,
-	0x28, 0x8f, 0x6f, 0x33, 0xb4, 0x7f, 0x59, 0xb0, 0x27, 0xb1, 0x5e, 0x3a, 0xa3, 0xe4, 0x39, 0xec,
-	0x70, 0x11, 0xb0, 0xdc, 0xe9, 0xc9, 0xd6, 0x43, 0xf9, 0x40, 0xbe, 0x66, 0xc8, 0x33, 0x28, 0x73,
-	0x41, 0x33, 0x63, 0xf3, 0x3f, 0xac, 0x42, 0xc8, 0x5b, 0xd8, 0x0b, 0x71, 0x11, 0xac, 0x63, 0xca,
-	0x94, 0xc7, 0x5a, 0xfb, 0xf1, 0x16, 0x2e, 0x9b, 0xab, 0x0f, 0xcf, 0x50, 0x7e, 0xc1, 0xdb, 0xef,
-	0xf5, 0x3a, 0xf3, 0x0a, 0x39, 0x81, 0x23, 0xaf, 0x3f, 0xfc, 0xf8, 0x69, 0x72, 0x3d, 0x18, 0xf7,
-	0xfa, 0x13, 0xff, 0xb2, 0x73, 0xf1, 0xa5, 0x5e, 0x92, 0xe9, 0xab, 0x4e, 0xaf, 0x3f, 0xe9, 0x5d,
-	0x4d, 0x06, 0xc3, 0xb1, 0x49, 0x5b, 0xf6, 0x37, 0x38, 0xbc, 0xc0, 0x24, 0x5e, 0x23, 0x2b, 0x2e,
-	0xa4, 0x75, 0xff, 0x85, 0xc8, 0xdd, 0x9a, 0x1b, 0x39, 0x87, 0x9d, 0x30, 0xa1, 0xd1, 0x8d, 0x19,
-	0xf1, 0x20, 0x07, 0x3d, 0x99, 0xec, 0x96, 0x7c, 0x5d, 0xcd, 0x57, 0xd9, 0xfe, 0x61, 0xc1, 0x61,
-	0x47, 0xd0, 0x65, 0x1c, 0x15, 0x67, 0x49, 0x3e, 0x40, 0x75, 0x13, 0xd4, 0xf3, 0x07, 0x2e, 0xd3,
-	0x35, 0x26, 0x34, 0xc3, 0x66, 0xb3, 0x58, 0xc3, 0x5f, 0x97, 0x6c, 0x97, 0x5a, 0xd6, 0x2b, 0x8b,
-	0xbc, 0x83, 0x5d, 0x33, 0xc0, 0x3f, 0xe4, 0x8d, 0x42, 0x7e, 0x67, 0x48, 0x2d, 0xf6, 0xae, 0xe1,
-	0x9c, 0xb2, 0xb9, 0xb3, 0xb8, 0xcd, 0x90, 0x25, 0x38, 0x9d, 0x23, 0x73, 0x66, 0x41, 0xc8, 0xe2,
-	0x48, 0xff, 0x83, 0x78, 0x2e, 0xff, 0xfa, 0x62, 0x1e, 0x8b, 0xc5, 0x2a, 0x94, 0x0d, 0xdc, 0x3f,
-	0x68, 0x57, 0xd3, 0xae, 0xa6, 0x5d, 0x43, 0x87, 0x15, 0x15, 0xbf, 0xfe, 0x1d, 0x00, 0x00, 0xff,
-	0xff, 0x4b, 0x88, 0xa4, 0x39, 0xb1, 0x03, 0x00, 0x00,
+func init() { proto.RegisterFile("orderer/ab.proto", fileDescriptor_ab_721163307403b25c) }
+
+var fileDescriptor_ab_721163307403b25c = []byte{
+	// 610 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x93, 0x5d, 0x6e, 0xd3, 0x40,
+	0x10, 0xc7, 0xe3, 0x34, 0x4d, 0x9b, 0x69, 0x9a, 0xa4, 0x5b, 0xb5, 0xb2, 0xf2, 0x80, 0x8a, 0xa5,
+	0x42, 0x10, 0x60, 0xa3, 0x20, 0xf1, 0x00, 0x88, 0x2a, 0x69, 0x12, 0x62, 0x11, 0x35, 0x68, 0x93,
+	0x0a, 0xc1, 0x8b, 0x65, 0x3b, 0x93, 0xd6, 0x6a, 0xe2, 0xb5, 0xd6, 0xdb, 0xa0, 0x9e, 0x82, 0x7b,
+	0x20, 0x6e, 0xc5, 0x45, 0x90, 0xd7, 0x6b, 0xa7, 0x2d, 0x55, 0x9f, 0xec, 0x99, 0xf9, 0xfd, 0xe7,
+	0x63, 0x77, 0x16, 0x1a, 0x8c, 0xcf, 0x90, 0x23, 0xb7, 0x5c, 0xcf, 0x8c, 0x38, 0x13, 0x8c, 0x6c,
+	0x29, 0x4f, 0x73, 0xdf, 0x67, 0xcb, 0x25, 0x0b, 0xad, 0xf4, 0x93, 0x46, 0x9b, 0x7b, 0x11, 0x22,
+	0xb7, 0x70, 0x85, 0xa1, 0x88, 0x53, 0x97, 0x31, 0x86, 0xbd, 0x2e, 0x67, 0xee, 0xcc, 0x77, 0x63,
+	0x41, 0x31, 0x8e, 0x58, 0x18, 0x23, 0x79, 0x06, 0xe5, 0x58, 0xb8, 0xe2, 0x3a, 0xd6, 0xb5, 0x23,
+	0xad, 0x55, 0x6b, 0xd7, 0x4c, 0x95, 0x66, 0x22, 0xbd, 0x54, 0x45, 0x09, 0x81, 0x52, 0x10, 0xce,
+	0x99, 0x5e, 0x3c, 0xd2, 0x5a, 0x15, 0x2a, 0xff, 0x8d, 0x2a, 0xc0, 0x04, 0xf1, 0xea, 0x0c, 0x7f,
+	0x62, 0x2c, 0x32, 0x6b, 0xbc, 0x98, 0x25, 0xd6, 0x73, 0xd8, 0x4d, 0xac, 0x49, 0x84, 0x7e, 0x30,
+	0x0f, 0x70, 0x46, 0x0e, 0xa1, 0x1c, 0x5e, 0x2f, 0x3d, 0xe4, 0xb2, 0x50, 0x89, 0x2a, 0xcb, 0xf8,
+	0xa3, 0x41, 0x35, 0x21, 0xbf, 0xb2, 0x38, 0x10, 0x01, 0x0b, 0xc9, 0x6b, 0x28, 0x87, 0x32, 0xa3,
+	0x04, 0x77, 0xda, 0xfb, 0xa6, 0x1a, 0xd4, 0x5c, 0x17, 0x1b, 0x16, 0xa8, 0x82, 0x12, 0x9c, 0xc9,
+	0x92, 0x7a, 0xf1, 0x01, 0x3c, 0xed, 0x26, 0xc1, 0x53, 0x88, 0xbc, 0x83, 0x4a, 0x9c, 0xf5, 0xa4,
+	0x6f, 0x48, 0xc5, 0xe1, 0x1d, 0x45, 0xde, 0xf1, 0xb0, 0x40, 0xd7, 0x68, 0xb7, 0x0c, 0xa5, 0xe9,
+	0x4d, 0x84, 0xc6, 0xdf, 0x22, 0x6c, 0x27, 0x98, 0x1d, 0xce, 0x19, 0x79, 0x09, 0x9b, 0xb1, 0x70,
+	0x79, 0xd6, 0xe9, 0xc1, 0x9d, 0x44, 0xd9, 0x40, 0x34, 0x65, 0xc8, 0x0b, 0x28, 0xc5, 0x82, 0x45,
+	0x7a, 0xf1, 0x31, 0x56, 0x22, 0xe4, 0x3d, 0x6c, 0x7b, 0x78, 0xe9, 0xae, 0x02, 0xc6, 0x65, 0x8f,
+	0xb5, 0xf6, 0x93, 0x3b, 0x78, 0x52, 0x5c, 0xfe, 0x74, 0x15, 0x45, 0x73, 0x9e, 0xf4, 0xa0, 0xea,
+	0xb3, 0x50, 0x60, 0x28, 0x1c, 0x71, 0x13, 0xa1, 0x5e, 0x92, 0xfa, 0xa7, 0x0f, 0xeb, 0x4f, 0x53,
+	0x32, 0x99, 0x8c, 0xee, 0xf8, 0x6b, 0xc3, 0xf8, 0x08, 0xd5, 0xdb, 0xf9, 0xc9, 0x01, 0xec, 0x75,
+	0x47, 0xe3, 0xd3, 0x2f, 0xce, 0xf9, 0xd9, 0xd4, 0x1e, 0x39, 0xb4, 0xdf, 0xe9, 0x7d, 0x6f, 0x14,
+	0x12, 0xf7, 0xa0, 0x63, 0x8f, 0x1c, 0x7b, 0xe0, 0x9c, 0x8d, 0xa7, 0xca, 0xad, 0x19, 0x27, 0x50,
+	0xbf, 0x97, 0x9d, 0x54, 0x60, 0x53, 0x26, 0x68, 0x14, 0xc8, 0x3e, 0xd4, 0x87, 0xfd, 0x4e, 0xaf,
+	0x4f, 0x9d, 0x6f, 0xf6, 0x74, 0xe8, 0x4c, 0xec, 0xcf, 0x0d, 0x8d, 0x54, 0x61, 0x7b, 0x60, 0x8f,
+	0xa6, 0x7d, 0xda, 0xef, 0x35, 0x8a, 0xc6, 0x6f, 0x0d, 0xea, 0x3d, 0x5c, 0x04, 0x2b, 0xe4, 0xf9,
+	0xa6, 0xb6, 0x1e, 0xdf, 0xd4, 0xe4, 0x8e, 0xd5, 0xae, 0x1e, 0xc3, 0xa6, 0xb7, 0x60, 0xfe, 0x95,
+	0x3a, 0xea, 0xdd, 0x0c, 0xec, 0x26, 0xce, 0x61, 0x81, 0xa6, 0x51, 0xf2, 0x09, 0x6a, 0xf3, 0x60,
+	0x21, 0x90, 0xe3, 0xcc, 0x49, 0xf9, 0x0d, 0x75, 0x35, 0xf2, 0xbd, 0xc4, 0xe6, 0x40, 0x45, 0x33,
+	0xdd, 0xee, 0xfc, 0xb6, 0x23, 0x5b, 0x89, 0xf6, 0x2f, 0x0d, 0xea, 0x1d, 0xc1, 0x96, 0x81, 0x9f,
+	0x3f, 0x2f, 0x72, 0x02, 0x95, 0xb5, 0xd1, 0xc8, 0x1a, 0xe8, 0x87, 0x2b, 0x5c, 0xb0, 0x08, 0x9b,
+	0xcd, 0xfc, 0x3a, 0xfe, 0x7b, 0x91, 0x46, 0xa1, 0xa5, 0xbd, 0xd1, 0xc8, 0x07, 0xd8, 0x52, 0x07,
+	0xf0, 0x80, 0x5c, 0xcf, 0xe5, 0xf7, 0x0e, 0x29, 0x15, 0x77, 0xcf, 0xe1, 0x98, 0xf1, 0x0b, 0xf3,
+	0xf2, 0x26, 0x42, 0xbe, 0xc0, 0xd9, 0x05, 0x72, 0x73, 0xee, 0x7a, 0x3c, 0xf0, 0xb3, 0xc9, 0x94,
+	0xfc, 0xc7, 0xab, 0x8b, 0x40, 0x5c, 0x5e, 0x7b, 0x49, 0x01, 0xeb, 0x16, 0x6d, 0xa5, 0xb4, 0x95,
+	0xd2, 0x96, 0xa2, 0xbd, 0xb2, 0xb4, 0xdf, 0xfe, 0x1b, 0x00, 0xf2, 0xb6, 0x08, 0x51, 0x8c, 0x04,
+	0x00, 0x00,
 }
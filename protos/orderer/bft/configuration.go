@@ -0,0 +1,116 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/orderer"
+)
+
+// TypeKey is the string with which this consensus implementation is identified across Fabric.
+const TypeKey = "bft"
+
+func init() {
+	orderer.ConsensusTypeMetadataMap[TypeKey] = ConsensusTypeMetadataFactory{}
+}
+
+// ConsensusTypeMetadataFactory allows this implementation's proto messages to register
+// their type with the orderer's proto messages. This is needed for protolator to work.
+type ConsensusTypeMetadataFactory struct{}
+
+// NewMessage implements the Orderer.ConsensusTypeMetadataFactory interface.
+func (dogf ConsensusTypeMetadataFactory) NewMessage() proto.Message {
+	return &ConfigMetadata{}
+}
+
+// Marshal serializes this implementation's proto messages. It is called by the encoder package
+// during the creation of the Orderer ConfigGroup.
+func Marshal(md *ConfigMetadata) ([]byte, error) {
+	for _, c := range md.Consenters {
+		// Expect the user to set the config value for the identity and the client/server certs
+		// to the path where they are persisted locally, then load these files to memory.
+		identity, err := ioutil.ReadFile(string(c.GetIdentity()))
+		if err != nil {
+			return nil, fmt.Errorf("cannot load identity for consenter %s:%d: %s", c.GetHost(), c.GetPort(), err)
+		}
+		c.Identity = identity
+
+		clientCert, err := ioutil.ReadFile(string(c.GetClientTlsCert()))
+		if err != nil {
+			return nil, fmt.Errorf("cannot load client cert for consenter %s:%d: %s", c.GetHost(), c.GetPort(), err)
+		}
+		c.ClientTlsCert = clientCert
+
+		serverCert, err := ioutil.ReadFile(string(c.GetServerTlsCert()))
+		if err != nil {
+			return nil, fmt.Errorf("cannot load server cert for consenter %s:%d: %s", c.GetHost(), c.GetPort(), err)
+		}
+		c.ServerTlsCert = serverCert
+	}
+	return proto.Marshal(md)
+}
+
+// MaxFaulty returns the number of faulty consenters, f, that a channel with
+// n consenters tolerates, so that n >= 3f+1.
+func MaxFaulty(n int) int {
+	return (n - 1) / 3
+}
+
+// QuorumSize returns the number of consenters that must agree on a block of a
+// channel with n consenters. This is 2f+1 when n = 3f+1, and it is picked so that
+// any two quorums intersect in at least one correct consenter.
+func QuorumSize(n int) int {
+	return (n + MaxFaulty(n) + 2) / 2
+}
+
+// BlockValidationPolicy returns the block validation policy of a channel with the given
+// consenters, which requires the blocks of the channel to be signed by a quorum of them.
+func BlockValidationPolicy(consenters []*Consenter) *common.Policy {
+	var rules []*common.SignaturePolicy
+	var identities []*msp.MSPPrincipal
+	for i, c := range consenters {
+		rules = append(rules, &common.SignaturePolicy{
+			Type: &common.SignaturePolicy_SignedBy{SignedBy: int32(i)},
+		})
+		identities = append(identities, &msp.MSPPrincipal{
+			PrincipalClassification: msp.MSPPrincipal_IDENTITY,
+			Principal: marshalOrPanic(&msp.SerializedIdentity{
+				Mspid:   c.MspId,
+				IdBytes: c.Identity,
+			}),
+		})
+	}
+
+	return &common.Policy{
+		Type: int32(common.Policy_SIGNATURE),
+		Value: marshalOrPanic(&common.SignaturePolicyEnvelope{
+			Version: 0,
+			Rule: &common.SignaturePolicy{
+				Type: &common.SignaturePolicy_NOutOf_{
+					NOutOf: &common.SignaturePolicy_NOutOf{
+						N:     int32(QuorumSize(len(consenters))),
+						Rules: rules,
+					},
+				},
+			},
+			Identities: identities,
+		}),
+	}
+}
+
+func marshalOrPanic(msg proto.Message) []byte {
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
@@ -0,0 +1,109 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/orderer/bft"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshal(t *testing.T) {
+	md := &bft.ConfigMetadata{
+		Consenters: []*bft.Consenter{
+			{
+				Id:            1,
+				Host:          "node-1.example.com",
+				Port:          7050,
+				Identity:      []byte("testdata/tls-client-1.pem"),
+				ClientTlsCert: []byte("testdata/tls-client-1.pem"),
+				ServerTlsCert: []byte("testdata/tls-server-1.pem"),
+			},
+			{
+				Id:            2,
+				Host:          "node-2.example.com",
+				Port:          7050,
+				Identity:      []byte("testdata/tls-client-2.pem"),
+				ClientTlsCert: []byte("testdata/tls-client-2.pem"),
+				ServerTlsCert: []byte("testdata/tls-server-2.pem"),
+			},
+		},
+	}
+	packed, err := bft.Marshal(md)
+	require.Nil(t, err, "marshalling should succeed")
+
+	unpacked := &bft.ConfigMetadata{}
+	require.Nil(t, proto.Unmarshal(packed, unpacked), "unmarshalling should succeed")
+
+	for i, c := range unpacked.GetConsenters() {
+		identity, _ := ioutil.ReadFile(fmt.Sprintf("testdata/tls-client-%d.pem", i+1))
+		serverCert, _ := ioutil.ReadFile(fmt.Sprintf("testdata/tls-server-%d.pem", i+1))
+		require.Equal(t, identity, c.GetIdentity())
+		require.Equal(t, identity, c.GetClientTlsCert())
+		require.Equal(t, serverCert, c.GetServerTlsCert())
+	}
+
+	md.Consenters[0].Identity = []byte("testdata/missing.pem")
+	_, err = bft.Marshal(md)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "cannot load identity for consenter node-1.example.com:7050")
+}
+
+func TestQuorumSize(t *testing.T) {
+	for _, testCase := range []struct {
+		n, f, q int
+	}{
+		{n: 1, f: 0, q: 1},
+		{n: 2, f: 0, q: 2},
+		{n: 3, f: 0, q: 2},
+		{n: 4, f: 1, q: 3},
+		{n: 5, f: 1, q: 4},
+		{n: 6, f: 1, q: 4},
+		{n: 7, f: 2, q: 5},
+		{n: 10, f: 3, q: 7},
+	} {
+		require.Equal(t, testCase.f, bft.MaxFaulty(testCase.n), "faulty consenters of %d", testCase.n)
+		require.Equal(t, testCase.q, bft.QuorumSize(testCase.n), "quorum of %d", testCase.n)
+	}
+}
+
+func TestBlockValidationPolicy(t *testing.T) {
+	var consenters []*bft.Consenter
+	for i := 1; i <= 4; i++ {
+		consenters = append(consenters, &bft.Consenter{
+			Id:       uint64(i),
+			MspId:    fmt.Sprintf("OrdererOrg%d", i),
+			Identity: []byte(fmt.Sprintf("identity-%d", i)),
+		})
+	}
+
+	policy := bft.BlockValidationPolicy(consenters)
+	require.Equal(t, int32(common.Policy_SIGNATURE), policy.Type)
+
+	envelope := &common.SignaturePolicyEnvelope{}
+	require.NoError(t, proto.Unmarshal(policy.Value, envelope))
+	require.Equal(t, int32(3), envelope.Rule.GetNOutOf().N)
+	require.Len(t, envelope.Rule.GetNOutOf().Rules, 4)
+	require.Len(t, envelope.Identities, 4)
+
+	for i, principal := range envelope.Identities {
+		require.Equal(t, int32(i), envelope.Rule.GetNOutOf().Rules[i].GetSignedBy())
+		require.Equal(t, msp.MSPPrincipal_IDENTITY, principal.PrincipalClassification)
+		identity := &msp.SerializedIdentity{}
+		require.NoError(t, proto.Unmarshal(principal.Principal, identity))
+		require.Equal(t, consenters[i].MspId, identity.Mspid)
+		require.Equal(t, consenters[i].Identity, identity.IdBytes)
+	}
+
+	require.True(t, proto.Equal(policy, bft.BlockValidationPolicy(consenters)))
+}
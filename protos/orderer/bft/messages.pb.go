@@ -0,0 +1,746 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: orderer/bft/messages.proto
+
+package bft // import "github.com/hyperledger/fabric/protos/orderer/bft"
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import common "github.com/hyperledger/fabric/protos/common"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// Message is sent between the consenters of a BFT channel
+// as the payload of a ConsensusRequest.
+type Message struct {
+	// Types that are valid to be assigned to Content:
+	//	*Message_PrePrepare
+	//	*Message_Prepare
+	//	*Message_Commit
+	//	*Message_ViewChange
+	//	*Message_ViewData
+	//	*Message_NewView
+	Content              isMessage_Content `protobuf_oneof:"content"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Message) Reset()         { *m = Message{} }
+func (m *Message) String() string { return proto.CompactTextString(m) }
+func (*Message) ProtoMessage()    {}
+func (*Message) Descriptor() ([]byte, []int) {
+	return fileDescriptor_messages_40a07024ea2fb593, []int{0}
+}
+func (m *Message) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Message.Unmarshal(m, b)
+}
+func (m *Message) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Message.Marshal(b, m, deterministic)
+}
+func (dst *Message) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Message.Merge(dst, src)
+}
+func (m *Message) XXX_Size() int {
+	return xxx_messageInfo_Message.Size(m)
+}
+func (m *Message) XXX_DiscardUnknown() {
+	xxx_messageInfo_Message.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Message proto.InternalMessageInfo
+
+type isMessage_Content interface {
+	isMessage_Content()
+}
+
+type Message_PrePrepare struct {
+	PrePrepare *PrePrepare `protobuf:"bytes,1,opt,name=pre_prepare,json=prePrepare,proto3,oneof"`
+}
+
+type Message_Prepare struct {
+	Prepare *SignedMessage `protobuf:"bytes,2,opt,name=prepare,proto3,oneof"`
+}
+
+type Message_Commit struct {
+	Commit *Commit `protobuf:"bytes,3,opt,name=commit,proto3,oneof"`
+}
+
+type Message_ViewChange struct {
+	ViewChange *ViewChange `protobuf:"bytes,4,opt,name=view_change,json=viewChange,proto3,oneof"`
+}
+
+type Message_ViewData struct {
+	ViewData *SignedMessage `protobuf:"bytes,5,opt,name=view_data,json=viewData,proto3,oneof"`
+}
+
+type Message_NewView struct {
+	NewView *NewView `protobuf:"bytes,6,opt,name=new_view,json=newView,proto3,oneof"`
+}
+
+func (*Message_PrePrepare) isMessage_Content() {}
+
+func (*Message_Prepare) isMessage_Content() {}
+
+func (*Message_Commit) isMessage_Content() {}
+
+func (*Message_ViewChange) isMessage_Content() {}
+
+func (*Message_ViewData) isMessage_Content() {}
+
+func (*Message_NewView) isMessage_Content() {}
+
+func (m *Message) GetContent() isMessage_Content {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+func (m *Message) GetPrePrepare() *PrePrepare {
+	if x, ok := m.GetContent().(*Message_PrePrepare); ok {
+		return x.PrePrepare
+	}
+	return nil
+}
+
+func (m *Message) GetPrepare() *SignedMessage {
+	if x, ok := m.GetContent().(*Message_Prepare); ok {
+		return x.Prepare
+	}
+	return nil
+}
+
+func (m *Message) GetCommit() *Commit {
+	if x, ok := m.GetContent().(*Message_Commit); ok {
+		return x.Commit
+	}
+	return nil
+}
+
+func (m *Message) GetViewChange() *ViewChange {
+	if x, ok := m.GetContent().(*Message_ViewChange); ok {
+		return x.ViewChange
+	}
+	return nil
+}
+
+func (m *Message) GetViewData() *SignedMessage {
+	if x, ok := m.GetContent().(*Message_ViewData); ok {
+		return x.ViewData
+	}
+	return nil
+}
+
+func (m *Message) GetNewView() *NewView {
+	if x, ok := m.GetContent().(*Message_NewView); ok {
+		return x.NewView
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*Message) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _Message_OneofMarshaler, _Message_OneofUnmarshaler, _Message_OneofSizer, []interface{}{
+		(*Message_PrePrepare)(nil),
+		(*Message_Prepare)(nil),
+		(*Message_Commit)(nil),
+		(*Message_ViewChange)(nil),
+		(*Message_ViewData)(nil),
+		(*Message_NewView)(nil),
+	}
+}
+
+func _Message_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*Message)
+	// content
+	switch x := m.Content.(type) {
+	case *Message_PrePrepare:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.PrePrepare); err != nil {
+			return err
+		}
+	case *Message_Prepare:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Prepare); err != nil {
+			return err
+		}
+	case *Message_Commit:
+		b.EncodeVarint(3<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Commit); err != nil {
+			return err
+		}
+	case *Message_ViewChange:
+		b.EncodeVarint(4<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ViewChange); err != nil {
+			return err
+		}
+	case *Message_ViewData:
+		b.EncodeVarint(5<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ViewData); err != nil {
+			return err
+		}
+	case *Message_NewView:
+		b.EncodeVarint(6<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.NewView); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("Message.Content has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _Message_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*Message)
+	switch tag {
+	case 1: // content.pre_prepare
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(PrePrepare)
+		err := b.DecodeMessage(msg)
+		m.Content = &Message_PrePrepare{msg}
+		return true, err
+	case 2: // content.prepare
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(SignedMessage)
+		err := b.DecodeMessage(msg)
+		m.Content = &Message_Prepare{msg}
+		return true, err
+	case 3: // content.commit
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Commit)
+		err := b.DecodeMessage(msg)
+		m.Content = &Message_Commit{msg}
+		return true, err
+	case 4: // content.view_change
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ViewChange)
+		err := b.DecodeMessage(msg)
+		m.Content = &Message_ViewChange{msg}
+		return true, err
+	case 5: // content.view_data
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(SignedMessage)
+		err := b.DecodeMessage(msg)
+		m.Content = &Message_ViewData{msg}
+		return true, err
+	case 6: // content.new_view
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(NewView)
+		err := b.DecodeMessage(msg)
+		m.Content = &Message_NewView{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _Message_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*Message)
+	// content
+	switch x := m.Content.(type) {
+	case *Message_PrePrepare:
+		s := proto.Size(x.PrePrepare)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Message_Prepare:
+		s := proto.Size(x.Prepare)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Message_Commit:
+		s := proto.Size(x.Commit)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Message_ViewChange:
+		s := proto.Size(x.ViewChange)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Message_ViewData:
+		s := proto.Size(x.ViewData)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Message_NewView:
+		s := proto.Size(x.NewView)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+// SignedMessage carries a message signed by a consenter, so that
+// it can be relayed to the other consenters by any consenter.
+type SignedMessage struct {
+	Payload              []byte   `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signer               uint64   `protobuf:"varint,2,opt,name=signer,proto3" json:"signer,omitempty"`
+	Signature            []byte   `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignedMessage) Reset()         { *m = SignedMessage{} }
+func (m *SignedMessage) String() string { return proto.CompactTextString(m) }
+func (*SignedMessage) ProtoMessage()    {}
+func (*SignedMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_messages_40a07024ea2fb593, []int{1}
+}
+func (m *SignedMessage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignedMessage.Unmarshal(m, b)
+}
+func (m *SignedMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignedMessage.Marshal(b, m, deterministic)
+}
+func (dst *SignedMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignedMessage.Merge(dst, src)
+}
+func (m *SignedMessage) XXX_Size() int {
+	return xxx_messageInfo_SignedMessage.Size(m)
+}
+func (m *SignedMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignedMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignedMessage proto.InternalMessageInfo
+
+func (m *SignedMessage) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *SignedMessage) GetSigner() uint64 {
+	if m != nil {
+		return m.Signer
+	}
+	return 0
+}
+
+func (m *SignedMessage) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// PrePrepare is sent by the leader of a view to propose the next block.
+type PrePrepare struct {
+	View                 uint64        `protobuf:"varint,1,opt,name=view,proto3" json:"view,omitempty"`
+	Seq                  uint64        `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	Proposal             *common.Block `protobuf:"bytes,3,opt,name=proposal,proto3" json:"proposal,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *PrePrepare) Reset()         { *m = PrePrepare{} }
+func (m *PrePrepare) String() string { return proto.CompactTextString(m) }
+func (*PrePrepare) ProtoMessage()    {}
+func (*PrePrepare) Descriptor() ([]byte, []int) {
+	return fileDescriptor_messages_40a07024ea2fb593, []int{2}
+}
+func (m *PrePrepare) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PrePrepare.Unmarshal(m, b)
+}
+func (m *PrePrepare) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PrePrepare.Marshal(b, m, deterministic)
+}
+func (dst *PrePrepare) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrePrepare.Merge(dst, src)
+}
+func (m *PrePrepare) XXX_Size() int {
+	return xxx_messageInfo_PrePrepare.Size(m)
+}
+func (m *PrePrepare) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrePrepare.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrePrepare proto.InternalMessageInfo
+
+func (m *PrePrepare) GetView() uint64 {
+	if m != nil {
+		return m.View
+	}
+	return 0
+}
+
+func (m *PrePrepare) GetSeq() uint64 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
+func (m *PrePrepare) GetProposal() *common.Block {
+	if m != nil {
+		return m.Proposal
+	}
+	return nil
+}
+
+// Prepare is sent by a consenter that accepted the proposal with the given digest,
+// which is the hash of the header of the proposed block.
+type Prepare struct {
+	View                 uint64   `protobuf:"varint,1,opt,name=view,proto3" json:"view,omitempty"`
+	Seq                  uint64   `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	Digest               []byte   `protobuf:"bytes,3,opt,name=digest,proto3" json:"digest,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Prepare) Reset()         { *m = Prepare{} }
+func (m *Prepare) String() string { return proto.CompactTextString(m) }
+func (*Prepare) ProtoMessage()    {}
+func (*Prepare) Descriptor() ([]byte, []int) {
+	return fileDescriptor_messages_40a07024ea2fb593, []int{3}
+}
+func (m *Prepare) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Prepare.Unmarshal(m, b)
+}
+func (m *Prepare) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Prepare.Marshal(b, m, deterministic)
+}
+func (dst *Prepare) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Prepare.Merge(dst, src)
+}
+func (m *Prepare) XXX_Size() int {
+	return xxx_messageInfo_Prepare.Size(m)
+}
+func (m *Prepare) XXX_DiscardUnknown() {
+	xxx_messageInfo_Prepare.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Prepare proto.InternalMessageInfo
+
+func (m *Prepare) GetView() uint64 {
+	if m != nil {
+		return m.View
+	}
+	return 0
+}
+
+func (m *Prepare) GetSeq() uint64 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
+func (m *Prepare) GetDigest() []byte {
+	if m != nil {
+		return m.Digest
+	}
+	return nil
+}
+
+// Commit is sent by a consenter that received a quorum of prepares for a proposal,
+// and carries its signature over the header of the proposed block.
+type Commit struct {
+	View                 uint64                    `protobuf:"varint,1,opt,name=view,proto3" json:"view,omitempty"`
+	Seq                  uint64                    `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	Digest               []byte                    `protobuf:"bytes,3,opt,name=digest,proto3" json:"digest,omitempty"`
+	Signature            *common.MetadataSignature `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *Commit) Reset()         { *m = Commit{} }
+func (m *Commit) String() string { return proto.CompactTextString(m) }
+func (*Commit) ProtoMessage()    {}
+func (*Commit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_messages_40a07024ea2fb593, []int{4}
+}
+func (m *Commit) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Commit.Unmarshal(m, b)
+}
+func (m *Commit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Commit.Marshal(b, m, deterministic)
+}
+func (dst *Commit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Commit.Merge(dst, src)
+}
+func (m *Commit) XXX_Size() int {
+	return xxx_messageInfo_Commit.Size(m)
+}
+func (m *Commit) XXX_DiscardUnknown() {
+	xxx_messageInfo_Commit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Commit proto.InternalMessageInfo
+
+func (m *Commit) GetView() uint64 {
+	if m != nil {
+		return m.View
+	}
+	return 0
+}
+
+func (m *Commit) GetSeq() uint64 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
+func (m *Commit) GetDigest() []byte {
+	if m != nil {
+		return m.Digest
+	}
+	return nil
+}
+
+func (m *Commit) GetSignature() *common.MetadataSignature {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// ViewChange is sent by a consenter that suspects the leader of the current view.
+type ViewChange struct {
+	NextView             uint64   `protobuf:"varint,1,opt,name=next_view,json=nextView,proto3" json:"next_view,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ViewChange) Reset()         { *m = ViewChange{} }
+func (m *ViewChange) String() string { return proto.CompactTextString(m) }
+func (*ViewChange) ProtoMessage()    {}
+func (*ViewChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_messages_40a07024ea2fb593, []int{5}
+}
+func (m *ViewChange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ViewChange.Unmarshal(m, b)
+}
+func (m *ViewChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ViewChange.Marshal(b, m, deterministic)
+}
+func (dst *ViewChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ViewChange.Merge(dst, src)
+}
+func (m *ViewChange) XXX_Size() int {
+	return xxx_messageInfo_ViewChange.Size(m)
+}
+func (m *ViewChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_ViewChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ViewChange proto.InternalMessageInfo
+
+func (m *ViewChange) GetNextView() uint64 {
+	if m != nil {
+		return m.NextView
+	}
+	return 0
+}
+
+// ViewData is sent to the leader of the next view once a quorum of consenters
+// agreed to change the view.
+type ViewData struct {
+	NextView uint64 `protobuf:"varint,1,opt,name=next_view,json=nextView,proto3" json:"next_view,omitempty"`
+	// The header of the last block committed by the consenter, and its signatures
+	LastDecision           *common.BlockHeader         `protobuf:"bytes,2,opt,name=last_decision,json=lastDecision,proto3" json:"last_decision,omitempty"`
+	LastDecisionSignatures []*common.MetadataSignature `protobuf:"bytes,3,rep,name=last_decision_signatures,json=lastDecisionSignatures,proto3" json:"last_decision_signatures,omitempty"`
+	// The proposal that follows the last decision, if the consenter received
+	// a quorum of prepares for it
+	Prepared             *PrePrepare      `protobuf:"bytes,4,opt,name=prepared,proto3" json:"prepared,omitempty"`
+	Prepares             []*SignedMessage `protobuf:"bytes,5,rep,name=prepares,proto3" json:"prepares,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ViewData) Reset()         { *m = ViewData{} }
+func (m *ViewData) String() string { return proto.CompactTextString(m) }
+func (*ViewData) ProtoMessage()    {}
+func (*ViewData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_messages_40a07024ea2fb593, []int{6}
+}
+func (m *ViewData) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ViewData.Unmarshal(m, b)
+}
+func (m *ViewData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ViewData.Marshal(b, m, deterministic)
+}
+func (dst *ViewData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ViewData.Merge(dst, src)
+}
+func (m *ViewData) XXX_Size() int {
+	return xxx_messageInfo_ViewData.Size(m)
+}
+func (m *ViewData) XXX_DiscardUnknown() {
+	xxx_messageInfo_ViewData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ViewData proto.InternalMessageInfo
+
+func (m *ViewData) GetNextView() uint64 {
+	if m != nil {
+		return m.NextView
+	}
+	return 0
+}
+
+func (m *ViewData) GetLastDecision() *common.BlockHeader {
+	if m != nil {
+		return m.LastDecision
+	}
+	return nil
+}
+
+func (m *ViewData) GetLastDecisionSignatures() []*common.MetadataSignature {
+	if m != nil {
+		return m.LastDecisionSignatures
+	}
+	return nil
+}
+
+func (m *ViewData) GetPrepared() *PrePrepare {
+	if m != nil {
+		return m.Prepared
+	}
+	return nil
+}
+
+func (m *ViewData) GetPrepares() []*SignedMessage {
+	if m != nil {
+		return m.Prepares
+	}
+	return nil
+}
+
+// NewView is sent by the leader of a view, and carries the view data
+// of a quorum of consenters.
+type NewView struct {
+	View                 uint64           `protobuf:"varint,1,opt,name=view,proto3" json:"view,omitempty"`
+	ViewData             []*SignedMessage `protobuf:"bytes,2,rep,name=view_data,json=viewData,proto3" json:"view_data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *NewView) Reset()         { *m = NewView{} }
+func (m *NewView) String() string { return proto.CompactTextString(m) }
+func (*NewView) ProtoMessage()    {}
+func (*NewView) Descriptor() ([]byte, []int) {
+	return fileDescriptor_messages_40a07024ea2fb593, []int{7}
+}
+func (m *NewView) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewView.Unmarshal(m, b)
+}
+func (m *NewView) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewView.Marshal(b, m, deterministic)
+}
+func (dst *NewView) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewView.Merge(dst, src)
+}
+func (m *NewView) XXX_Size() int {
+	return xxx_messageInfo_NewView.Size(m)
+}
+func (m *NewView) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewView.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewView proto.InternalMessageInfo
+
+func (m *NewView) GetView() uint64 {
+	if m != nil {
+		return m.View
+	}
+	return 0
+}
+
+func (m *NewView) GetViewData() []*SignedMessage {
+	if m != nil {
+		return m.ViewData
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Message)(nil), "bft.Message")
+	proto.RegisterType((*SignedMessage)(nil), "bft.SignedMessage")
+	proto.RegisterType((*PrePrepare)(nil), "bft.PrePrepare")
+	proto.RegisterType((*Prepare)(nil), "bft.Prepare")
+	proto.RegisterType((*Commit)(nil), "bft.Commit")
+	proto.RegisterType((*ViewChange)(nil), "bft.ViewChange")
+	proto.RegisterType((*ViewData)(nil), "bft.ViewData")
+	proto.RegisterType((*NewView)(nil), "bft.NewView")
+}
+
+func init() {
+	proto.RegisterFile("orderer/bft/messages.proto", fileDescriptor_messages_40a07024ea2fb593)
+}
+
+var fileDescriptor_messages_40a07024ea2fb593 = []byte{
+	// 559 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0x51, 0x6b, 0xdb, 0x3c,
+	0x14, 0x6d, 0xe2, 0xd4, 0x4e, 0x6e, 0x12, 0xbe, 0x0f, 0x15, 0x82, 0xd7, 0xed, 0x21, 0x18, 0x06,
+	0x0b, 0x03, 0x7b, 0xeb, 0x1e, 0xb6, 0xe7, 0xb4, 0xb0, 0xbc, 0xb4, 0x14, 0x07, 0xfa, 0x30, 0x28,
+	0x46, 0xb6, 0x6f, 0x1c, 0xb3, 0xc4, 0xf2, 0x24, 0xb5, 0x59, 0x9f, 0xf6, 0x23, 0xf6, 0x4b, 0xf6,
+	0x0f, 0x87, 0x64, 0xd9, 0x71, 0xa0, 0x0b, 0x8c, 0x3d, 0x59, 0xf7, 0xea, 0xdc, 0xe3, 0xa3, 0x73,
+	0x75, 0x05, 0xe7, 0x8c, 0xa7, 0xc8, 0x91, 0x07, 0xf1, 0x4a, 0x06, 0x5b, 0x14, 0x82, 0x66, 0x28,
+	0xfc, 0x92, 0x33, 0xc9, 0x88, 0x15, 0xaf, 0xe4, 0xf9, 0x59, 0xc2, 0xb6, 0x5b, 0x56, 0x04, 0xd5,
+	0xa7, 0xda, 0xf1, 0x7e, 0x75, 0xc1, 0xb9, 0xae, 0xc0, 0xe4, 0x02, 0x86, 0x25, 0xc7, 0xa8, 0xe4,
+	0x58, 0x52, 0x8e, 0x6e, 0x67, 0xda, 0x79, 0x33, 0xbc, 0xf8, 0xcf, 0x8f, 0x57, 0xd2, 0xbf, 0xe5,
+	0x78, 0x5b, 0xa5, 0x17, 0x27, 0x21, 0x94, 0x4d, 0x44, 0x7c, 0x70, 0x6a, 0x7c, 0x57, 0xe3, 0x89,
+	0xc6, 0x2f, 0xf3, 0xac, 0xc0, 0xd4, 0x10, 0x2f, 0x4e, 0xc2, 0x1a, 0x44, 0x5e, 0x83, 0xad, 0xfe,
+	0x9f, 0x4b, 0xd7, 0xd2, 0xf0, 0xa1, 0x86, 0x5f, 0xea, 0xd4, 0xe2, 0x24, 0x34, 0x9b, 0x4a, 0xca,
+	0x63, 0x8e, 0xbb, 0x28, 0x59, 0xd3, 0x22, 0x43, 0xb7, 0xd7, 0x92, 0x72, 0x97, 0xe3, 0xee, 0x52,
+	0xa7, 0x95, 0x94, 0xc7, 0x26, 0x22, 0xef, 0x61, 0xa0, 0x6b, 0x52, 0x2a, 0xa9, 0x7b, 0x7a, 0x44,
+	0x4c, 0x5f, 0xc1, 0xae, 0xa8, 0xa4, 0x64, 0x06, 0xfd, 0x02, 0x77, 0x91, 0x8a, 0x5d, 0x5b, 0x57,
+	0x8c, 0x74, 0xc5, 0x0d, 0xee, 0xd4, 0x6f, 0x94, 0xf0, 0xa2, 0x5a, 0xce, 0x07, 0xe0, 0x24, 0xac,
+	0x90, 0x58, 0x48, 0x2f, 0x82, 0xf1, 0x01, 0x25, 0x71, 0xc1, 0x29, 0xe9, 0xd3, 0x86, 0xd1, 0x54,
+	0x9b, 0x36, 0x0a, 0xeb, 0x90, 0x4c, 0xc0, 0x16, 0x0a, 0xca, 0xb5, 0x3b, 0xbd, 0xd0, 0x44, 0xe4,
+	0x15, 0x0c, 0xd4, 0x8a, 0xca, 0x07, 0x8e, 0xda, 0x89, 0x51, 0xb8, 0x4f, 0x78, 0xf7, 0x00, 0x7b,
+	0xc3, 0x09, 0x81, 0x9e, 0x16, 0xd8, 0xd1, 0x0c, 0x7a, 0x4d, 0xfe, 0x07, 0x4b, 0xe0, 0x37, 0x43,
+	0xaa, 0x96, 0xea, 0x28, 0x25, 0x67, 0x25, 0x13, 0x74, 0x63, 0xac, 0x1d, 0xfb, 0xa6, 0xd3, 0xf3,
+	0x0d, 0x4b, 0xbe, 0x86, 0xcd, 0xb6, 0xf7, 0x19, 0x9c, 0xbf, 0xe3, 0x9e, 0x80, 0x9d, 0xe6, 0x19,
+	0x0a, 0x69, 0xa4, 0x9a, 0xc8, 0xfb, 0x01, 0x76, 0xd5, 0xb9, 0x7f, 0xe3, 0x21, 0x1f, 0xdb, 0x6e,
+	0x54, 0xbd, 0x7e, 0x51, 0x8b, 0xbf, 0x46, 0x49, 0x55, 0x47, 0x97, 0x35, 0xa0, 0x6d, 0xd4, 0x0c,
+	0x60, 0x7f, 0x1d, 0xc8, 0x4b, 0x18, 0x14, 0xf8, 0x5d, 0x46, 0x2d, 0x25, 0x7d, 0x95, 0x50, 0x10,
+	0xef, 0x67, 0x17, 0xfa, 0x77, 0x75, 0xdf, 0x8f, 0x21, 0xc9, 0x27, 0x18, 0x6f, 0xa8, 0x90, 0x51,
+	0x8a, 0x49, 0x2e, 0x72, 0x56, 0x98, 0x8b, 0x7d, 0x76, 0x60, 0xe7, 0x02, 0x69, 0x8a, 0x3c, 0x1c,
+	0x29, 0xe4, 0x95, 0x01, 0x92, 0x25, 0xb8, 0x07, 0x95, 0x51, 0xa3, 0x54, 0xb8, 0xd6, 0xd4, 0x3a,
+	0x7e, 0xac, 0x49, 0x9b, 0xaa, 0x49, 0x0b, 0xf2, 0x56, 0x35, 0x56, 0x77, 0x2b, 0x3d, 0x98, 0x83,
+	0xfd, 0x0d, 0x09, 0x1b, 0x00, 0xf1, 0x1b, 0xb0, 0x70, 0x4f, 0xa7, 0xd6, 0xf3, 0x23, 0xd0, 0xe0,
+	0x85, 0x77, 0x03, 0x8e, 0xb9, 0xeb, 0xcf, 0xb6, 0x30, 0x68, 0x8f, 0x54, 0xf7, 0xcf, 0x7c, 0xf5,
+	0x40, 0xcd, 0xef, 0x61, 0xc6, 0x78, 0xe6, 0xaf, 0x9f, 0x4a, 0xe4, 0x1b, 0x4c, 0x33, 0xe4, 0xfe,
+	0x8a, 0xc6, 0x3c, 0x4f, 0xaa, 0xe7, 0x46, 0xf8, 0xe6, 0x91, 0x52, 0x24, 0x5f, 0xde, 0x65, 0xb9,
+	0x5c, 0x3f, 0xc4, 0xca, 0x92, 0xa0, 0x55, 0x11, 0x54, 0x15, 0x41, 0x55, 0x11, 0xb4, 0x9e, 0xb5,
+	0xd8, 0xd6, 0xb9, 0x0f, 0xbf, 0x07, 0x00, 0x8d, 0xfa, 0x2c, 0x34, 0xec, 0x04, 0x00, 0x00,
+}
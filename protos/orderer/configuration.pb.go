@@ -6,6 +6,7 @@ package orderer // import "github.com/hyperledger/fabric/protos/orderer"
 import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
+import common "github.com/hyperledger/fabric/protos/common"
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
@@ -49,7 +50,7 @@ func (x ConsensusType_MigrationState) String() string {
 	return proto.EnumName(ConsensusType_MigrationState_name, int32(x))
 }
 func (ConsensusType_MigrationState) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_50e2c0f98937cf5a, []int{0, 0}
+	return fileDescriptor_configuration_80371071150758b2, []int{0, 0}
 }
 
 type ConsensusType struct {
@@ -76,7 +77,7 @@ func (m *ConsensusType) Reset()         { *m = ConsensusType{} }
 func (m *ConsensusType) String() string { return proto.CompactTextString(m) }
 func (*ConsensusType) ProtoMessage()    {}
 func (*ConsensusType) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_50e2c0f98937cf5a, []int{0}
+	return fileDescriptor_configuration_80371071150758b2, []int{0}
 }
 func (m *ConsensusType) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ConsensusType.Unmarshal(m, b)
@@ -133,17 +134,20 @@ type BatchSize struct {
 	AbsoluteMaxBytes uint32 `protobuf:"varint,2,opt,name=absolute_max_bytes,json=absoluteMaxBytes,proto3" json:"absolute_max_bytes,omitempty"`
 	// The byte count of the serialized messages in a batch should not
 	// exceed this value.
-	PreferredMaxBytes    uint32   `protobuf:"varint,3,opt,name=preferred_max_bytes,json=preferredMaxBytes,proto3" json:"preferred_max_bytes,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	PreferredMaxBytes uint32 `protobuf:"varint,3,opt,name=preferred_max_bytes,json=preferredMaxBytes,proto3" json:"preferred_max_bytes,omitempty"`
+	// The classes of the transactions which are ordered with priority. A batch
+	// is cut early once it holds enough transactions of a priority class.
+	PriorityClasses      []*PriorityClass `protobuf:"bytes,4,rep,name=priority_classes,json=priorityClasses,proto3" json:"priority_classes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
 func (m *BatchSize) Reset()         { *m = BatchSize{} }
 func (m *BatchSize) String() string { return proto.CompactTextString(m) }
 func (*BatchSize) ProtoMessage()    {}
 func (*BatchSize) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_50e2c0f98937cf5a, []int{1}
+	return fileDescriptor_configuration_80371071150758b2, []int{1}
 }
 func (m *BatchSize) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BatchSize.Unmarshal(m, b)
@@ -184,10 +188,23 @@ func (m *BatchSize) GetPreferredMaxBytes() uint32 {
 	return 0
 }
 
+func (m *BatchSize) GetPriorityClasses() []*PriorityClass {
+	if m != nil {
+		return m.PriorityClasses
+	}
+	return nil
+}
+
 type BatchTimeout struct {
 	// Any duration string parseable by ParseDuration():
 	// https://golang.org/pkg/time/#ParseDuration
-	Timeout              string   `protobuf:"bytes,1,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	Timeout string `protobuf:"bytes,1,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// The bounds within which the batch timeout adapts to the load of the
+	// channel. The batch timeout shrinks towards min_timeout when the batches
+	// are cut with few transactions, and grows towards max_timeout when they
+	// are cut full. The batch timeout does not adapt if they are not set.
+	MinTimeout           string   `protobuf:"bytes,2,opt,name=min_timeout,json=minTimeout,proto3" json:"min_timeout,omitempty"`
+	MaxTimeout           string   `protobuf:"bytes,3,opt,name=max_timeout,json=maxTimeout,proto3" json:"max_timeout,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -197,7 +214,7 @@ func (m *BatchTimeout) Reset()         { *m = BatchTimeout{} }
 func (m *BatchTimeout) String() string { return proto.CompactTextString(m) }
 func (*BatchTimeout) ProtoMessage()    {}
 func (*BatchTimeout) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_50e2c0f98937cf5a, []int{2}
+	return fileDescriptor_configuration_80371071150758b2, []int{2}
 }
 func (m *BatchTimeout) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BatchTimeout.Unmarshal(m, b)
@@ -224,6 +241,20 @@ func (m *BatchTimeout) GetTimeout() string {
 	return ""
 }
 
+func (m *BatchTimeout) GetMinTimeout() string {
+	if m != nil {
+		return m.MinTimeout
+	}
+	return ""
+}
+
+func (m *BatchTimeout) GetMaxTimeout() string {
+	if m != nil {
+		return m.MaxTimeout
+	}
+	return ""
+}
+
 // Carries a list of bootstrap brokers, i.e. this is not the exclusive set of
 // brokers an ordering service
 type KafkaBrokers struct {
@@ -239,7 +270,7 @@ func (m *KafkaBrokers) Reset()         { *m = KafkaBrokers{} }
 func (m *KafkaBrokers) String() string { return proto.CompactTextString(m) }
 func (*KafkaBrokers) ProtoMessage()    {}
 func (*KafkaBrokers) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_50e2c0f98937cf5a, []int{3}
+	return fileDescriptor_configuration_80371071150758b2, []int{3}
 }
 func (m *KafkaBrokers) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KafkaBrokers.Unmarshal(m, b)
@@ -278,7 +309,7 @@ func (m *ChannelRestrictions) Reset()         { *m = ChannelRestrictions{} }
 func (m *ChannelRestrictions) String() string { return proto.CompactTextString(m) }
 func (*ChannelRestrictions) ProtoMessage()    {}
 func (*ChannelRestrictions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_50e2c0f98937cf5a, []int{4}
+	return fileDescriptor_configuration_80371071150758b2, []int{4}
 }
 func (m *ChannelRestrictions) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChannelRestrictions.Unmarshal(m, b)
@@ -305,48 +336,140 @@ func (m *ChannelRestrictions) GetMaxCount() uint64 {
 	return 0
 }
 
+// PriorityClass is a class of urgent transactions, which force the pending
+// batch to be cut early. A transaction belongs to the class if it matches
+// all of the criteria of the class which are set.
+type PriorityClass struct {
+	// The name of the class.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The header types of the transactions of the class.
+	HeaderTypes []common.HeaderType `protobuf:"varint,2,rep,packed,name=header_types,json=headerTypes,proto3,enum=common.HeaderType" json:"header_types,omitempty"`
+	// The minimum priority which the transactions of the class carry
+	// in the extension of their header.
+	MinPriority uint32 `protobuf:"varint,3,opt,name=min_priority,json=minPriority,proto3" json:"min_priority,omitempty"`
+	// The policy which the creators of the transactions of the class satisfy.
+	Policy string `protobuf:"bytes,4,opt,name=policy,proto3" json:"policy,omitempty"`
+	// The number of transactions of the class in the pending batch which
+	// cause the batch to be cut. A value of 0 or 1 cuts the batch as soon as
+	// a transaction of the class is enqueued.
+	MaxMessageCount      uint32   `protobuf:"varint,5,opt,name=max_message_count,json=maxMessageCount,proto3" json:"max_message_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PriorityClass) Reset()         { *m = PriorityClass{} }
+func (m *PriorityClass) String() string { return proto.CompactTextString(m) }
+func (*PriorityClass) ProtoMessage()    {}
+func (*PriorityClass) Descriptor() ([]byte, []int) {
+	return fileDescriptor_configuration_80371071150758b2, []int{5}
+}
+func (m *PriorityClass) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PriorityClass.Unmarshal(m, b)
+}
+func (m *PriorityClass) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PriorityClass.Marshal(b, m, deterministic)
+}
+func (dst *PriorityClass) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PriorityClass.Merge(dst, src)
+}
+func (m *PriorityClass) XXX_Size() int {
+	return xxx_messageInfo_PriorityClass.Size(m)
+}
+func (m *PriorityClass) XXX_DiscardUnknown() {
+	xxx_messageInfo_PriorityClass.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PriorityClass proto.InternalMessageInfo
+
+func (m *PriorityClass) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PriorityClass) GetHeaderTypes() []common.HeaderType {
+	if m != nil {
+		return m.HeaderTypes
+	}
+	return nil
+}
+
+func (m *PriorityClass) GetMinPriority() uint32 {
+	if m != nil {
+		return m.MinPriority
+	}
+	return 0
+}
+
+func (m *PriorityClass) GetPolicy() string {
+	if m != nil {
+		return m.Policy
+	}
+	return ""
+}
+
+func (m *PriorityClass) GetMaxMessageCount() uint32 {
+	if m != nil {
+		return m.MaxMessageCount
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*ConsensusType)(nil), "orderer.ConsensusType")
 	proto.RegisterType((*BatchSize)(nil), "orderer.BatchSize")
 	proto.RegisterType((*BatchTimeout)(nil), "orderer.BatchTimeout")
 	proto.RegisterType((*KafkaBrokers)(nil), "orderer.KafkaBrokers")
 	proto.RegisterType((*ChannelRestrictions)(nil), "orderer.ChannelRestrictions")
+	proto.RegisterType((*PriorityClass)(nil), "orderer.PriorityClass")
 	proto.RegisterEnum("orderer.ConsensusType_MigrationState", ConsensusType_MigrationState_name, ConsensusType_MigrationState_value)
 }
 
 func init() {
-	proto.RegisterFile("orderer/configuration.proto", fileDescriptor_configuration_50e2c0f98937cf5a)
-}
-
-var fileDescriptor_configuration_50e2c0f98937cf5a = []byte{
-	// 460 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x92, 0xdf, 0x8a, 0xda, 0x40,
-	0x14, 0xc6, 0x1b, 0x95, 0xee, 0x7a, 0x58, 0x35, 0x8e, 0x2d, 0x84, 0xee, 0x8d, 0x08, 0x0b, 0xd2,
-	0x2e, 0x11, 0xb6, 0x4f, 0x60, 0x82, 0x94, 0xa5, 0x44, 0x61, 0x9c, 0x42, 0xe9, 0x4d, 0x98, 0xc4,
-	0x63, 0x0c, 0x6b, 0x32, 0x61, 0x66, 0x02, 0xb1, 0x7d, 0x8e, 0x3e, 0x4c, 0xdf, 0xae, 0xe4, 0x9f,
-	0x7f, 0xee, 0xce, 0xf7, 0x7d, 0xbf, 0x9c, 0x99, 0x39, 0x39, 0xf0, 0x28, 0xe4, 0x0e, 0x25, 0xca,
-	0x45, 0x28, 0xd2, 0x7d, 0x1c, 0xe5, 0x92, 0xeb, 0x58, 0xa4, 0x76, 0x26, 0x85, 0x16, 0xe4, 0xae,
-	0x09, 0x67, 0xff, 0x3a, 0x30, 0x70, 0x45, 0xaa, 0x30, 0x55, 0xb9, 0x62, 0xa7, 0x0c, 0x09, 0x81,
-	0x9e, 0x3e, 0x65, 0x68, 0x19, 0x53, 0x63, 0xde, 0xa7, 0x55, 0x4d, 0x3e, 0xc1, 0x7d, 0x82, 0x9a,
-	0xef, 0xb8, 0xe6, 0x56, 0x67, 0x6a, 0xcc, 0x1f, 0xe8, 0x59, 0x93, 0x35, 0x8c, 0x92, 0x38, 0xaa,
-	0xbb, 0xfb, 0x4a, 0x73, 0x8d, 0x56, 0x77, 0x6a, 0xcc, 0x87, 0x2f, 0x4f, 0x76, 0x73, 0x88, 0x7d,
-	0x73, 0x80, 0xed, 0xb5, 0xf4, 0xb6, 0x84, 0xe9, 0x30, 0xb9, 0xd1, 0xe4, 0x0b, 0x8c, 0x2f, 0xfd,
-	0x42, 0x91, 0x6a, 0x2c, 0xb4, 0xd5, 0x9b, 0x1a, 0xf3, 0x1e, 0x35, 0xcf, 0x81, 0x5b, 0xfb, 0xb3,
-	0x3f, 0x30, 0xbc, 0x6d, 0x47, 0x08, 0x0c, 0xbd, 0xd7, 0x6f, 0xfe, 0x96, 0x2d, 0xd9, 0xca, 0x5f,
-	0x6f, 0xd6, 0x2b, 0xf3, 0x1d, 0x99, 0xc0, 0xe8, 0xe2, 0x6d, 0xd9, 0x92, 0x32, 0xd3, 0x20, 0x1f,
-	0xc0, 0xbc, 0x98, 0xee, 0xc6, 0xf3, 0x5e, 0x99, 0xd9, 0xb9, 0x45, 0x97, 0xce, 0x86, 0x32, 0xb3,
-	0x4b, 0x3e, 0xc2, 0xf8, 0x1a, 0x5d, 0xb3, 0xd5, 0x4f, 0x66, 0xf6, 0x66, 0x7f, 0x0d, 0xe8, 0x3b,
-	0x5c, 0x87, 0x87, 0x6d, 0xfc, 0x1b, 0xc9, 0x67, 0x18, 0x27, 0xbc, 0xf0, 0x13, 0x54, 0x8a, 0x47,
-	0xe8, 0x87, 0x22, 0x4f, 0x75, 0x35, 0xc4, 0x01, 0x1d, 0x25, 0xbc, 0xf0, 0x6a, 0xdf, 0x2d, 0x6d,
-	0xf2, 0x0c, 0x84, 0x07, 0x4a, 0x1c, 0x73, 0x8d, 0x7e, 0xf9, 0x51, 0x70, 0xd2, 0xa8, 0xaa, 0xc9,
-	0x0e, 0xa8, 0xd9, 0x26, 0x1e, 0x2f, 0x9c, 0xd2, 0x27, 0x36, 0x4c, 0x32, 0x89, 0x7b, 0x94, 0x12,
-	0x77, 0x57, 0x78, 0xb7, 0xc2, 0xc7, 0xe7, 0xa8, 0xe5, 0x67, 0x73, 0x78, 0xa8, 0xae, 0xc5, 0xe2,
-	0x04, 0x45, 0xae, 0x89, 0x05, 0x77, 0xba, 0x2e, 0x9b, 0x9f, 0xda, 0xca, 0x92, 0xfc, 0xce, 0xf7,
-	0x6f, 0xdc, 0x91, 0xe2, 0x0d, 0xa5, 0x2a, 0xc9, 0xa0, 0x2e, 0x2d, 0x63, 0xda, 0x2d, 0xc9, 0x46,
-	0xce, 0x5e, 0x60, 0xe2, 0x1e, 0x78, 0x9a, 0xe2, 0x91, 0xa2, 0xd2, 0x32, 0x0e, 0xcb, 0x89, 0x2b,
-	0xf2, 0x08, 0xfd, 0xf2, 0x42, 0x97, 0xc7, 0xf6, 0xe8, 0x7d, 0xc2, 0x8b, 0xea, 0x95, 0xce, 0x0f,
-	0x78, 0x12, 0x32, 0xb2, 0x0f, 0xa7, 0x0c, 0xe5, 0x11, 0x77, 0x11, 0x4a, 0x7b, 0xcf, 0x03, 0x19,
-	0x87, 0xf5, 0x12, 0xaa, 0x76, 0x3f, 0x7e, 0x3d, 0x47, 0xb1, 0x3e, 0xe4, 0x81, 0x1d, 0x8a, 0x64,
-	0x71, 0x45, 0x2f, 0x6a, 0x7a, 0x51, 0xd3, 0x8b, 0x86, 0x0e, 0xde, 0x57, 0xfa, 0xeb, 0xff, 0x00,
-	0x00, 0x00, 0xff, 0xff, 0x9f, 0x9f, 0xe4, 0x13, 0xe1, 0x02, 0x00, 0x00,
+	proto.RegisterFile("orderer/configuration.proto", fileDescriptor_configuration_80371071150758b2)
+}
+
+var fileDescriptor_configuration_80371071150758b2 = []byte{
+	// 609 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x93, 0xdb, 0x6e, 0xda, 0x40,
+	0x10, 0x86, 0x6b, 0x4c, 0x93, 0x30, 0xe1, 0x60, 0x96, 0x36, 0xb2, 0x92, 0x8b, 0x52, 0x4b, 0x91,
+	0xac, 0x36, 0x32, 0x12, 0x55, 0x1f, 0x00, 0x50, 0xd4, 0x46, 0x15, 0xa4, 0x32, 0xae, 0x54, 0xf5,
+	0xc6, 0x5a, 0xcc, 0x00, 0x6e, 0xb0, 0xd7, 0xda, 0x5d, 0x24, 0xd3, 0x3e, 0x59, 0x2f, 0xfb, 0x16,
+	0x7d, 0x9c, 0x6a, 0x7d, 0x02, 0xa4, 0x5c, 0x31, 0x33, 0xff, 0xc7, 0x8e, 0x77, 0xe6, 0x5f, 0xb8,
+	0x61, 0x7c, 0x89, 0x1c, 0xf9, 0x20, 0x60, 0xf1, 0x2a, 0x5c, 0xef, 0x38, 0x95, 0x21, 0x8b, 0x9d,
+	0x84, 0x33, 0xc9, 0xc8, 0x79, 0x21, 0x5e, 0xf7, 0x02, 0x16, 0x45, 0x2c, 0x1e, 0xe4, 0x3f, 0xb9,
+	0x6a, 0xfd, 0xa9, 0x41, 0x6b, 0xc2, 0x62, 0x81, 0xb1, 0xd8, 0x09, 0x6f, 0x9f, 0x20, 0x21, 0x50,
+	0x97, 0xfb, 0x04, 0x4d, 0xad, 0xaf, 0xd9, 0x0d, 0x37, 0x8b, 0xc9, 0x35, 0x5c, 0x44, 0x28, 0xe9,
+	0x92, 0x4a, 0x6a, 0xd6, 0xfa, 0x9a, 0xdd, 0x74, 0xab, 0x9c, 0xcc, 0xa0, 0x13, 0x85, 0xeb, 0xbc,
+	0xa5, 0x2f, 0x24, 0x95, 0x68, 0xea, 0x7d, 0xcd, 0x6e, 0x0f, 0x6f, 0x9d, 0xa2, 0xb3, 0x73, 0xd2,
+	0xc0, 0x99, 0x96, 0xf4, 0x5c, 0xc1, 0x6e, 0x3b, 0x3a, 0xc9, 0xc9, 0x7b, 0xe8, 0x1e, 0xce, 0x0b,
+	0x58, 0x2c, 0x31, 0x95, 0x66, 0xbd, 0xaf, 0xd9, 0x75, 0xd7, 0xa8, 0x84, 0x49, 0x5e, 0xb7, 0x7e,
+	0x43, 0xfb, 0xf4, 0x38, 0x42, 0xa0, 0x3d, 0x7d, 0xf8, 0xe4, 0xcf, 0xbd, 0x91, 0x77, 0xef, 0xcf,
+	0x1e, 0x67, 0xf7, 0xc6, 0x0b, 0xd2, 0x83, 0xce, 0xa1, 0x36, 0xf7, 0x46, 0xae, 0x67, 0x68, 0xe4,
+	0x15, 0x18, 0x87, 0xe2, 0xe4, 0x71, 0x3a, 0x7d, 0xf0, 0x8c, 0xda, 0x29, 0x3a, 0x1a, 0x3f, 0xba,
+	0x9e, 0xa1, 0x93, 0xd7, 0xd0, 0x3d, 0x46, 0x67, 0xde, 0xfd, 0x77, 0xcf, 0xa8, 0x5b, 0xff, 0x34,
+	0x68, 0x8c, 0xa9, 0x0c, 0x36, 0xf3, 0xf0, 0x17, 0x92, 0x77, 0xd0, 0x8d, 0x68, 0xea, 0x47, 0x28,
+	0x04, 0x5d, 0xa3, 0x1f, 0xb0, 0x5d, 0x2c, 0xb3, 0x21, 0xb6, 0xdc, 0x4e, 0x44, 0xd3, 0x69, 0x5e,
+	0x9f, 0xa8, 0x32, 0xb9, 0x03, 0x42, 0x17, 0x82, 0x6d, 0x77, 0x12, 0x7d, 0xf5, 0xa7, 0xc5, 0x5e,
+	0xa2, 0xc8, 0x26, 0xdb, 0x72, 0x8d, 0x52, 0x99, 0xd2, 0x74, 0xac, 0xea, 0xc4, 0x81, 0x5e, 0xc2,
+	0x71, 0x85, 0x9c, 0xe3, 0xf2, 0x08, 0xd7, 0x33, 0xbc, 0x5b, 0x49, 0x15, 0x3f, 0x02, 0x23, 0xe1,
+	0x21, 0xe3, 0xa1, 0xdc, 0xfb, 0xc1, 0x96, 0x0a, 0x81, 0xc2, 0xac, 0xf7, 0x75, 0xfb, 0x72, 0x78,
+	0x55, 0xad, 0xe4, 0x6b, 0x01, 0x4c, 0x94, 0xee, 0x76, 0x92, 0xe3, 0x14, 0x85, 0xf5, 0x13, 0x9a,
+	0xd9, 0xcd, 0xbc, 0x30, 0x42, 0xb6, 0x93, 0xc4, 0x84, 0x73, 0x99, 0x87, 0x85, 0x2f, 0xca, 0x94,
+	0xbc, 0x81, 0xcb, 0x28, 0x8c, 0xfd, 0x52, 0xad, 0x65, 0x2a, 0x44, 0x61, 0xec, 0x1d, 0x01, 0x34,
+	0xad, 0x00, 0xbd, 0x00, 0x68, 0x5a, 0x00, 0x96, 0x0d, 0xcd, 0x2f, 0x74, 0xf5, 0x44, 0xc7, 0x9c,
+	0x3d, 0x21, 0x17, 0xaa, 0xd7, 0x22, 0x0f, 0x4d, 0xad, 0xaf, 0xab, 0x5e, 0x45, 0x6a, 0x0d, 0xa1,
+	0x37, 0xd9, 0xd0, 0x38, 0xc6, 0xad, 0x8b, 0x42, 0xf2, 0x30, 0x50, 0x6b, 0x17, 0xe4, 0x06, 0x1a,
+	0xaa, 0xc3, 0x61, 0xe2, 0x75, 0xf7, 0x22, 0xa2, 0x69, 0x36, 0x6a, 0xeb, 0xaf, 0x06, 0xad, 0x93,
+	0xcb, 0x2a, 0x83, 0xc7, 0x34, 0xaa, 0x0c, 0xae, 0x62, 0xf2, 0x11, 0x9a, 0x1b, 0xa4, 0x4b, 0xe4,
+	0xbe, 0xf2, 0xbb, 0x5a, 0x85, 0x6e, 0xb7, 0x87, 0xc4, 0x29, 0xde, 0xca, 0xe7, 0x4c, 0x53, 0xee,
+	0x75, 0x2f, 0x37, 0x55, 0x2c, 0xc8, 0x5b, 0x68, 0xaa, 0xcb, 0x97, 0xd3, 0x2b, 0x56, 0xa2, 0x06,
+	0x52, 0xb6, 0x24, 0x57, 0x70, 0x96, 0xb0, 0x6d, 0x18, 0xec, 0x33, 0x0f, 0x37, 0xdc, 0x22, 0x7b,
+	0xde, 0x2e, 0x2f, 0x9f, 0xb5, 0xcb, 0xf8, 0x1b, 0xdc, 0x32, 0xbe, 0x76, 0x36, 0xfb, 0x04, 0xf9,
+	0x16, 0x97, 0x6b, 0xe4, 0xce, 0x8a, 0x2e, 0x78, 0x18, 0xe4, 0x8f, 0x58, 0x94, 0x5b, 0xfd, 0x71,
+	0xb7, 0x0e, 0xe5, 0x66, 0xb7, 0x50, 0x9f, 0x3d, 0x38, 0xa2, 0x07, 0x39, 0x3d, 0xc8, 0xe9, 0x41,
+	0x41, 0x2f, 0xce, 0xb2, 0xfc, 0xc3, 0xff, 0x01, 0x00, 0xd3, 0x97, 0xa6, 0x74, 0x3f, 0x04, 0x00,
+	0x00,
 }
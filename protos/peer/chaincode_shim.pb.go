@@ -104,7 +104,7 @@ func (x ChaincodeMessage_Type) String() string {
 	return proto.EnumName(ChaincodeMessage_Type_name, int32(x))
 }
 func (ChaincodeMessage_Type) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{0, 0}
+	return fileDescriptor_chaincode_shim_0cd1a1892e02338e, []int{0, 0}
 }
 
 type ChaincodeMessage struct {
@@ -128,7 +128,7 @@ func (m *ChaincodeMessage) Reset()         { *m = ChaincodeMessage{} }
 func (m *ChaincodeMessage) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeMessage) ProtoMessage()    {}
 func (*ChaincodeMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{0}
+	return fileDescriptor_chaincode_shim_0cd1a1892e02338e, []int{0}
 }
 func (m *ChaincodeMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeMessage.Unmarshal(m, b)
@@ -212,7 +212,7 @@ func (m *GetState) Reset()         { *m = GetState{} }
 func (m *GetState) String() string { return proto.CompactTextString(m) }
 func (*GetState) ProtoMessage()    {}
 func (*GetState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{1}
+	return fileDescriptor_chaincode_shim_0cd1a1892e02338e, []int{1}
 }
 func (m *GetState) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetState.Unmarshal(m, b)
@@ -258,7 +258,7 @@ func (m *GetStateMetadata) Reset()         { *m = GetStateMetadata{} }
 func (m *GetStateMetadata) String() string { return proto.CompactTextString(m) }
 func (*GetStateMetadata) ProtoMessage()    {}
 func (*GetStateMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{2}
+	return fileDescriptor_chaincode_shim_0cd1a1892e02338e, []int{2}
 }
 func (m *GetStateMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetStateMetadata.Unmarshal(m, b)
@@ -309,7 +309,7 @@ func (m *PutState) Reset()         { *m = PutState{} }
 func (m *PutState) String() string { return proto.CompactTextString(m) }
 func (*PutState) ProtoMessage()    {}
 func (*PutState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{3}
+	return fileDescriptor_chaincode_shim_0cd1a1892e02338e, []int{3}
 }
 func (m *PutState) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PutState.Unmarshal(m, b)
@@ -363,7 +363,7 @@ func (m *PutStateMetadata) Reset()         { *m = PutStateMetadata{} }
 func (m *PutStateMetadata) String() string { return proto.CompactTextString(m) }
 func (*PutStateMetadata) ProtoMessage()    {}
 func (*PutStateMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{4}
+	return fileDescriptor_chaincode_shim_0cd1a1892e02338e, []int{4}
 }
 func (m *PutStateMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PutStateMetadata.Unmarshal(m, b)
@@ -420,7 +420,7 @@ func (m *DelState) Reset()         { *m = DelState{} }
 func (m *DelState) String() string { return proto.CompactTextString(m) }
 func (*DelState) ProtoMessage()    {}
 func (*DelState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{5}
+	return fileDescriptor_chaincode_shim_0cd1a1892e02338e, []int{5}
 }
 func (m *DelState) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DelState.Unmarshal(m, b)
@@ -472,7 +472,7 @@ func (m *GetStateByRange) Reset()         { *m = GetStateByRange{} }
 func (m *GetStateByRange) String() string { return proto.CompactTextString(m) }
 func (*GetStateByRange) ProtoMessage()    {}
 func (*GetStateByRange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{6}
+	return fileDescriptor_chaincode_shim_0cd1a1892e02338e, []int{6}
 }
 func (m *GetStateByRange) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetStateByRange.Unmarshal(m, b)
@@ -537,7 +537,7 @@ func (m *GetQueryResult) Reset()         { *m = GetQueryResult{} }
 func (m *GetQueryResult) String() string { return proto.CompactTextString(m) }
 func (*GetQueryResult) ProtoMessage()    {}
 func (*GetQueryResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{7}
+	return fileDescriptor_chaincode_shim_0cd1a1892e02338e, []int{7}
 }
 func (m *GetQueryResult) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetQueryResult.Unmarshal(m, b)
@@ -578,7 +578,7 @@ func (m *GetQueryResult) GetMetadata() []byte {
 	return nil
 }
 
-// QueryMetadata is the metadata of a GetStateByRange and GetQueryResult.
+// QueryMetadata is the metadata of a GetStateByRange, GetQueryResult, and GetHistoryForKey.
 // It contains a pageSize which denotes the number of records to be fetched
 // and a bookmark.
 type QueryMetadata struct {
@@ -593,7 +593,7 @@ func (m *QueryMetadata) Reset()         { *m = QueryMetadata{} }
 func (m *QueryMetadata) String() string { return proto.CompactTextString(m) }
 func (*QueryMetadata) ProtoMessage()    {}
 func (*QueryMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{8}
+	return fileDescriptor_chaincode_shim_0cd1a1892e02338e, []int{8}
 }
 func (m *QueryMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryMetadata.Unmarshal(m, b)
@@ -628,19 +628,23 @@ func (m *QueryMetadata) GetBookmark() string {
 }
 
 // GetHistoryForKey is the payload of a ChaincodeMessage. It contains a key
-// for which the historical values need to be retrieved.
+// for which the historical values need to be retrieved. The options, if
+// specified, restrict the history and the order of the results. The metadata
+// hold the byte representation of QueryMetadata.
 type GetHistoryForKey struct {
-	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Key                  string               `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Metadata             []byte               `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Options              *HistoryQueryOptions `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *GetHistoryForKey) Reset()         { *m = GetHistoryForKey{} }
 func (m *GetHistoryForKey) String() string { return proto.CompactTextString(m) }
 func (*GetHistoryForKey) ProtoMessage()    {}
 func (*GetHistoryForKey) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{9}
+	return fileDescriptor_chaincode_shim_0cd1a1892e02338e, []int{9}
 }
 func (m *GetHistoryForKey) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetHistoryForKey.Unmarshal(m, b)
@@ -667,6 +671,95 @@ func (m *GetHistoryForKey) GetKey() string {
 	return ""
 }
 
+func (m *GetHistoryForKey) GetMetadata() []byte {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *GetHistoryForKey) GetOptions() *HistoryQueryOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+// HistoryQueryOptions restricts the history of a key to the modifications made
+// by the transactions in the blocks [start_block, end_block) and with a timestamp
+// in [start_time, end_time). A zero end_block and an unset start_time or end_time
+// leave the corresponding bound open. The modifications are returned in the order
+// of the commit, or in the reverse order if descending is set.
+type HistoryQueryOptions struct {
+	StartBlock           uint64               `protobuf:"varint,1,opt,name=start_block,json=startBlock,proto3" json:"start_block,omitempty"`
+	EndBlock             uint64               `protobuf:"varint,2,opt,name=end_block,json=endBlock,proto3" json:"end_block,omitempty"`
+	StartTime            *timestamp.Timestamp `protobuf:"bytes,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime              *timestamp.Timestamp `protobuf:"bytes,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Descending           bool                 `protobuf:"varint,5,opt,name=descending,proto3" json:"descending,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *HistoryQueryOptions) Reset()         { *m = HistoryQueryOptions{} }
+func (m *HistoryQueryOptions) String() string { return proto.CompactTextString(m) }
+func (*HistoryQueryOptions) ProtoMessage()    {}
+func (*HistoryQueryOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaincode_shim_0cd1a1892e02338e, []int{10}
+}
+func (m *HistoryQueryOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HistoryQueryOptions.Unmarshal(m, b)
+}
+func (m *HistoryQueryOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HistoryQueryOptions.Marshal(b, m, deterministic)
+}
+func (dst *HistoryQueryOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HistoryQueryOptions.Merge(dst, src)
+}
+func (m *HistoryQueryOptions) XXX_Size() int {
+	return xxx_messageInfo_HistoryQueryOptions.Size(m)
+}
+func (m *HistoryQueryOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_HistoryQueryOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HistoryQueryOptions proto.InternalMessageInfo
+
+func (m *HistoryQueryOptions) GetStartBlock() uint64 {
+	if m != nil {
+		return m.StartBlock
+	}
+	return 0
+}
+
+func (m *HistoryQueryOptions) GetEndBlock() uint64 {
+	if m != nil {
+		return m.EndBlock
+	}
+	return 0
+}
+
+func (m *HistoryQueryOptions) GetStartTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartTime
+	}
+	return nil
+}
+
+func (m *HistoryQueryOptions) GetEndTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.EndTime
+	}
+	return nil
+}
+
+func (m *HistoryQueryOptions) GetDescending() bool {
+	if m != nil {
+		return m.Descending
+	}
+	return false
+}
+
 type QueryStateNext struct {
 	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -678,7 +771,7 @@ func (m *QueryStateNext) Reset()         { *m = QueryStateNext{} }
 func (m *QueryStateNext) String() string { return proto.CompactTextString(m) }
 func (*QueryStateNext) ProtoMessage()    {}
 func (*QueryStateNext) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{10}
+	return fileDescriptor_chaincode_shim_0cd1a1892e02338e, []int{11}
 }
 func (m *QueryStateNext) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryStateNext.Unmarshal(m, b)
@@ -716,7 +809,7 @@ func (m *QueryStateClose) Reset()         { *m = QueryStateClose{} }
 func (m *QueryStateClose) String() string { return proto.CompactTextString(m) }
 func (*QueryStateClose) ProtoMessage()    {}
 func (*QueryStateClose) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{11}
+	return fileDescriptor_chaincode_shim_0cd1a1892e02338e, []int{12}
 }
 func (m *QueryStateClose) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryStateClose.Unmarshal(m, b)
@@ -755,7 +848,7 @@ func (m *QueryResultBytes) Reset()         { *m = QueryResultBytes{} }
 func (m *QueryResultBytes) String() string { return proto.CompactTextString(m) }
 func (*QueryResultBytes) ProtoMessage()    {}
 func (*QueryResultBytes) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{12}
+	return fileDescriptor_chaincode_shim_0cd1a1892e02338e, []int{13}
 }
 func (m *QueryResultBytes) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryResultBytes.Unmarshal(m, b)
@@ -801,7 +894,7 @@ func (m *QueryResponse) Reset()         { *m = QueryResponse{} }
 func (m *QueryResponse) String() string { return proto.CompactTextString(m) }
 func (*QueryResponse) ProtoMessage()    {}
 func (*QueryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{13}
+	return fileDescriptor_chaincode_shim_0cd1a1892e02338e, []int{14}
 }
 func (m *QueryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryResponse.Unmarshal(m, b)
@@ -863,7 +956,7 @@ func (m *QueryResponseMetadata) Reset()         { *m = QueryResponseMetadata{} }
 func (m *QueryResponseMetadata) String() string { return proto.CompactTextString(m) }
 func (*QueryResponseMetadata) ProtoMessage()    {}
 func (*QueryResponseMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{14}
+	return fileDescriptor_chaincode_shim_0cd1a1892e02338e, []int{15}
 }
 func (m *QueryResponseMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryResponseMetadata.Unmarshal(m, b)
@@ -909,7 +1002,7 @@ func (m *StateMetadata) Reset()         { *m = StateMetadata{} }
 func (m *StateMetadata) String() string { return proto.CompactTextString(m) }
 func (*StateMetadata) ProtoMessage()    {}
 func (*StateMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{15}
+	return fileDescriptor_chaincode_shim_0cd1a1892e02338e, []int{16}
 }
 func (m *StateMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StateMetadata.Unmarshal(m, b)
@@ -954,7 +1047,7 @@ func (m *StateMetadataResult) Reset()         { *m = StateMetadataResult{} }
 func (m *StateMetadataResult) String() string { return proto.CompactTextString(m) }
 func (*StateMetadataResult) ProtoMessage()    {}
 func (*StateMetadataResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{16}
+	return fileDescriptor_chaincode_shim_0cd1a1892e02338e, []int{17}
 }
 func (m *StateMetadataResult) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StateMetadataResult.Unmarshal(m, b)
@@ -992,6 +1085,7 @@ func init() {
 	proto.RegisterType((*GetQueryResult)(nil), "protos.GetQueryResult")
 	proto.RegisterType((*QueryMetadata)(nil), "protos.QueryMetadata")
 	proto.RegisterType((*GetHistoryForKey)(nil), "protos.GetHistoryForKey")
+	proto.RegisterType((*HistoryQueryOptions)(nil), "protos.HistoryQueryOptions")
 	proto.RegisterType((*QueryStateNext)(nil), "protos.QueryStateNext")
 	proto.RegisterType((*QueryStateClose)(nil), "protos.QueryStateClose")
 	proto.RegisterType((*QueryResultBytes)(nil), "protos.QueryResultBytes")
@@ -1107,74 +1201,81 @@ var _ChaincodeSupport_serviceDesc = grpc.ServiceDesc{
 }
 
 func init() {
-	proto.RegisterFile("peer/chaincode_shim.proto", fileDescriptor_chaincode_shim_b04d3028f86b65a2)
-}
-
-var fileDescriptor_chaincode_shim_b04d3028f86b65a2 = []byte{
-	// 1027 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0xcf, 0x73, 0xda, 0x46,
-	0x14, 0x0e, 0x06, 0x8c, 0x78, 0xd8, 0x78, 0xb3, 0x0e, 0x2e, 0x66, 0x26, 0x2d, 0x65, 0x7a, 0xa0,
-	0x17, 0x68, 0x68, 0x0f, 0x3d, 0x74, 0x26, 0x83, 0x61, 0x8d, 0x19, 0xdb, 0x40, 0x56, 0xb2, 0x27,
-	0xee, 0x45, 0x23, 0xa4, 0xb5, 0xd0, 0x58, 0x68, 0x55, 0x69, 0x49, 0x43, 0x6f, 0xbd, 0xf6, 0xd8,
-	0x3f, 0xae, 0x7f, 0x4f, 0x67, 0xf5, 0xcb, 0x80, 0xeb, 0xa4, 0x93, 0x13, 0xfa, 0xde, 0xfb, 0xf6,
-	0x7b, 0xbf, 0xf6, 0x21, 0xc1, 0xa9, 0xcf, 0x58, 0xd0, 0x35, 0x17, 0x86, 0xe3, 0x99, 0xdc, 0x62,
-	0x7a, 0xb8, 0x70, 0x96, 0x1d, 0x3f, 0xe0, 0x82, 0xe3, 0xfd, 0xe8, 0x27, 0x6c, 0x34, 0x76, 0x28,
-	0xec, 0x03, 0xf3, 0x44, 0xcc, 0x69, 0x1c, 0x47, 0x3e, 0x3f, 0xe0, 0x3e, 0x0f, 0x0d, 0x37, 0x31,
-	0x7e, 0x63, 0x73, 0x6e, 0xbb, 0xac, 0x1b, 0xa1, 0xf9, 0xea, 0xbe, 0x2b, 0x9c, 0x25, 0x0b, 0x85,
-	0xb1, 0xf4, 0x63, 0x42, 0xeb, 0x9f, 0x22, 0xa0, 0x41, 0xaa, 0x77, 0xcd, 0xc2, 0xd0, 0xb0, 0x19,
-	0x7e, 0x03, 0x05, 0xb1, 0xf6, 0x59, 0x3d, 0xd7, 0xcc, 0xb5, 0xab, 0xbd, 0xd7, 0x31, 0x35, 0xec,
-	0xec, 0xf2, 0x3a, 0xda, 0xda, 0x67, 0x34, 0xa2, 0xe2, 0x9f, 0xa1, 0x9c, 0x49, 0xd7, 0xf7, 0x9a,
-	0xb9, 0x76, 0xa5, 0xd7, 0xe8, 0xc4, 0xc1, 0x3b, 0x69, 0xf0, 0x8e, 0x96, 0x32, 0xe8, 0x23, 0x19,
-	0xd7, 0xa1, 0xe4, 0x1b, 0x6b, 0x97, 0x1b, 0x56, 0x3d, 0xdf, 0xcc, 0xb5, 0x0f, 0x68, 0x0a, 0x31,
-	0x86, 0x82, 0xf8, 0xe8, 0x58, 0xf5, 0x42, 0x33, 0xd7, 0x2e, 0xd3, 0xe8, 0x19, 0xf7, 0x40, 0x49,
-	0x4b, 0xac, 0x17, 0xa3, 0x30, 0x27, 0x69, 0x7a, 0xaa, 0x63, 0x7b, 0xcc, 0x9a, 0x25, 0x5e, 0x9a,
-	0xf1, 0xf0, 0x5b, 0x38, 0xda, 0x69, 0x59, 0x7d, 0x7f, 0xfb, 0x68, 0x56, 0x19, 0x91, 0x5e, 0x5a,
-	0x35, 0xb7, 0x30, 0x7e, 0x0d, 0x60, 0x2e, 0x0c, 0xcf, 0x63, 0xae, 0xee, 0x58, 0xf5, 0x52, 0x94,
-	0x4e, 0x39, 0xb1, 0x8c, 0xad, 0xd6, 0xdf, 0x79, 0x28, 0xc8, 0x56, 0xe0, 0x43, 0x28, 0xdf, 0x4c,
-	0x86, 0xe4, 0x7c, 0x3c, 0x21, 0x43, 0xf4, 0x02, 0x1f, 0x80, 0x42, 0xc9, 0x68, 0xac, 0x6a, 0x84,
-	0xa2, 0x1c, 0xae, 0x02, 0xa4, 0x88, 0x0c, 0xd1, 0x1e, 0x56, 0xa0, 0x30, 0x9e, 0x8c, 0x35, 0x94,
-	0xc7, 0x65, 0x28, 0x52, 0xd2, 0x1f, 0xde, 0xa1, 0x02, 0x3e, 0x82, 0x8a, 0x46, 0xfb, 0x13, 0xb5,
-	0x3f, 0xd0, 0xc6, 0xd3, 0x09, 0x2a, 0x4a, 0xc9, 0xc1, 0xf4, 0x7a, 0x76, 0x45, 0x34, 0x32, 0x44,
-	0xfb, 0x92, 0x4a, 0x28, 0x9d, 0x52, 0x54, 0x92, 0x9e, 0x11, 0xd1, 0x74, 0x55, 0xeb, 0x6b, 0x04,
-	0x29, 0x12, 0xce, 0x6e, 0x52, 0x58, 0x96, 0x70, 0x48, 0xae, 0x12, 0x08, 0xf8, 0x15, 0xa0, 0xf1,
-	0xe4, 0x76, 0x7a, 0x49, 0xf4, 0xc1, 0x45, 0x7f, 0x3c, 0x19, 0x4c, 0x87, 0x04, 0x55, 0xe2, 0x04,
-	0xd5, 0xd9, 0x74, 0xa2, 0x12, 0x74, 0x88, 0x4f, 0x00, 0x67, 0x82, 0xfa, 0xd9, 0x9d, 0x4e, 0xfb,
-	0x93, 0x11, 0x41, 0x55, 0x79, 0x56, 0xda, 0xdf, 0xdd, 0x10, 0x7a, 0xa7, 0x53, 0xa2, 0xde, 0x5c,
-	0x69, 0xe8, 0x48, 0x5a, 0x63, 0x4b, 0xcc, 0x9f, 0x90, 0xf7, 0x1a, 0x42, 0xb8, 0x06, 0x2f, 0x37,
-	0xad, 0x83, 0xab, 0xa9, 0x4a, 0xd0, 0x4b, 0x99, 0xcd, 0x25, 0x21, 0xb3, 0xfe, 0xd5, 0xf8, 0x96,
-	0x20, 0x8c, 0xbf, 0x82, 0x63, 0xa9, 0x78, 0x31, 0x56, 0xb5, 0x29, 0xbd, 0xd3, 0xcf, 0xa7, 0x54,
-	0xbf, 0x24, 0x77, 0xe8, 0x78, 0x3b, 0x85, 0x6b, 0xa2, 0xf5, 0x87, 0x7d, 0xad, 0x8f, 0x5e, 0x49,
-	0x7b, 0x56, 0xdc, 0xa3, 0xbd, 0x86, 0x4f, 0xa1, 0x26, 0xf9, 0x33, 0x3a, 0xbe, 0x95, 0x1e, 0x69,
-	0xd5, 0x2f, 0xfa, 0xea, 0x05, 0x3a, 0x69, 0xfd, 0x02, 0xca, 0x88, 0x09, 0x55, 0x18, 0x82, 0x61,
-	0x04, 0xf9, 0x07, 0xb6, 0x8e, 0xae, 0x73, 0x99, 0xca, 0x47, 0xfc, 0x35, 0x80, 0xc9, 0x5d, 0x97,
-	0x99, 0xc2, 0xe1, 0x5e, 0x74, 0x5f, 0xcb, 0x74, 0xc3, 0xd2, 0x1a, 0x02, 0x4a, 0x4f, 0x5f, 0x33,
-	0x61, 0x58, 0x86, 0x30, 0xbe, 0x40, 0x85, 0x82, 0x32, 0x5b, 0x3d, 0x9b, 0xc3, 0x2b, 0x28, 0x7e,
-	0x30, 0xdc, 0x15, 0x8b, 0x0e, 0x1e, 0xd0, 0x18, 0xec, 0x68, 0xe6, 0x9f, 0x68, 0xfe, 0x0e, 0x28,
-	0xd5, 0xfc, 0xdf, 0x99, 0x3d, 0x51, 0xc1, 0x6f, 0x40, 0x59, 0x26, 0xa7, 0xa3, 0xf5, 0xaa, 0xf4,
-	0x6a, 0xd9, 0x1a, 0x6d, 0x4a, 0xd3, 0x8c, 0x26, 0x1b, 0x3a, 0x64, 0xee, 0x97, 0x36, 0xf4, 0xcf,
-	0x1c, 0x1c, 0xa5, 0x1d, 0x3d, 0x5b, 0x53, 0xc3, 0xb3, 0x19, 0x6e, 0x80, 0x12, 0x0a, 0x23, 0x10,
-	0x97, 0x99, 0x54, 0x86, 0xf1, 0x09, 0xec, 0x33, 0xcf, 0x92, 0x9e, 0x58, 0x2b, 0x41, 0x9f, 0x2d,
-	0xac, 0xb1, 0x53, 0xd8, 0xc1, 0x46, 0x05, 0x73, 0xa8, 0x8e, 0x98, 0x78, 0xb7, 0x62, 0xc1, 0x9a,
-	0xb2, 0x70, 0xe5, 0x0a, 0x39, 0x82, 0xdf, 0x24, 0x4c, 0xc2, 0xc7, 0xe0, 0x73, 0xb5, 0x6c, 0xc5,
-	0xc8, 0xef, 0xc4, 0x18, 0xc1, 0x61, 0x14, 0x20, 0x9b, 0x4d, 0x03, 0x14, 0xdf, 0xb0, 0x99, 0xea,
-	0xfc, 0x11, 0xff, 0x9f, 0x16, 0x69, 0x86, 0xa5, 0x6f, 0xce, 0xf9, 0xc3, 0xd2, 0x08, 0x1e, 0x92,
-	0x30, 0x19, 0x6e, 0x7d, 0x17, 0xdd, 0xc0, 0x0b, 0x27, 0x14, 0x3c, 0x58, 0x9f, 0xf3, 0x40, 0x16,
-	0xff, 0xa4, 0xed, 0xad, 0x26, 0x54, 0xa3, 0x70, 0x51, 0x5f, 0x27, 0xec, 0xa3, 0xc0, 0x55, 0xd8,
-	0x73, 0xac, 0x84, 0xb2, 0xe7, 0x58, 0xad, 0x6f, 0xe1, 0xe8, 0x91, 0x31, 0x70, 0x79, 0xc8, 0x9e,
-	0x50, 0x7e, 0x02, 0xb4, 0xd1, 0x94, 0xb3, 0xb5, 0x60, 0x21, 0x6e, 0x42, 0x25, 0x78, 0x84, 0x11,
-	0xf9, 0x80, 0x6e, 0x9a, 0x5a, 0x7f, 0xe5, 0x92, 0x52, 0x29, 0x0b, 0x7d, 0xee, 0x85, 0x0c, 0xf7,
-	0xa0, 0x14, 0x13, 0x24, 0x3f, 0xdf, 0xae, 0xf4, 0xea, 0xe9, 0x9d, 0xda, 0x95, 0xa7, 0x29, 0x11,
-	0x9f, 0x82, 0xb2, 0x30, 0x42, 0x7d, 0xc9, 0x83, 0x78, 0x0f, 0x14, 0x5a, 0x5a, 0x18, 0xe1, 0x35,
-	0x0f, 0xd2, 0x34, 0xf3, 0x69, 0x9a, 0x9f, 0x1c, 0xad, 0x0d, 0xb5, 0xad, 0x5c, 0xb2, 0xf6, 0xf7,
-	0xa0, 0x76, 0xcf, 0x84, 0xb9, 0x60, 0x96, 0x1e, 0x30, 0x93, 0x07, 0x56, 0xa8, 0x9b, 0x7c, 0xe5,
-	0x89, 0x64, 0x16, 0xc7, 0x89, 0x93, 0xc6, 0xbe, 0x81, 0x74, 0x7d, 0x72, 0x2c, 0x6f, 0xe1, 0x70,
-	0x7b, 0xf7, 0xea, 0x50, 0x92, 0x59, 0x3c, 0xce, 0x25, 0x85, 0xff, 0xbd, 0xdf, 0xad, 0x73, 0x38,
-	0xde, 0xde, 0xb0, 0xf8, 0x26, 0x76, 0xa1, 0xc4, 0x3c, 0x11, 0x38, 0x2c, 0xed, 0xdd, 0x33, 0xfb,
-	0x98, 0xb2, 0x7a, 0xef, 0x37, 0xde, 0xdb, 0xea, 0xca, 0xf7, 0x79, 0x20, 0xf0, 0x10, 0x14, 0xca,
-	0x6c, 0x27, 0x14, 0x2c, 0xc0, 0xf5, 0xe7, 0xde, 0xda, 0x8d, 0x67, 0x3d, 0xad, 0x17, 0xed, 0xdc,
-	0x0f, 0xb9, 0xb3, 0x29, 0xb4, 0x78, 0x60, 0x77, 0x16, 0x6b, 0x9f, 0x05, 0x2e, 0xb3, 0x6c, 0x16,
-	0x74, 0xee, 0x8d, 0x79, 0xe0, 0x98, 0xe9, 0x39, 0xf9, 0xa1, 0xf1, 0xeb, 0xf7, 0xb6, 0x23, 0x16,
-	0xab, 0x79, 0xc7, 0xe4, 0xcb, 0xee, 0x06, 0xb5, 0x1b, 0x53, 0xe3, 0x0f, 0x8e, 0xb0, 0x2b, 0xa9,
-	0xf3, 0xf8, 0xeb, 0xe5, 0xc7, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0x3e, 0x82, 0x3d, 0x52, 0xe1,
-	0x08, 0x00, 0x00,
+	proto.RegisterFile("peer/chaincode_shim.proto", fileDescriptor_chaincode_shim_0cd1a1892e02338e)
+}
+
+var fileDescriptor_chaincode_shim_0cd1a1892e02338e = []byte{
+	// 1142 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0x5f, 0x73, 0xda, 0x46,
+	0x10, 0x0f, 0x06, 0x1b, 0xb1, 0xd8, 0xf8, 0x72, 0x8e, 0x5d, 0x42, 0x26, 0x89, 0xcb, 0x13, 0x7d,
+	0x81, 0x86, 0x36, 0x33, 0xed, 0x4c, 0x67, 0x32, 0x18, 0xce, 0x98, 0xb1, 0x0d, 0xe4, 0x24, 0x67,
+	0xe2, 0xbe, 0x68, 0x84, 0x74, 0x01, 0x8d, 0x85, 0x4e, 0x95, 0x8e, 0x34, 0xf4, 0xad, 0xaf, 0x7d,
+	0xec, 0x87, 0xeb, 0x37, 0xe8, 0xf7, 0xe8, 0xdc, 0xe9, 0x8f, 0x01, 0xc7, 0xf1, 0x34, 0x4f, 0xf0,
+	0xdb, 0xfd, 0xed, 0xde, 0x6f, 0xf7, 0xf6, 0x4e, 0x07, 0x4f, 0x03, 0xc6, 0xc2, 0x96, 0x3d, 0xb3,
+	0x5c, 0xdf, 0xe6, 0x0e, 0x33, 0xa3, 0x99, 0x3b, 0x6f, 0x06, 0x21, 0x17, 0x1c, 0xef, 0xa8, 0x9f,
+	0xa8, 0x56, 0xdb, 0xa0, 0xb0, 0x8f, 0xcc, 0x17, 0x31, 0xa7, 0x76, 0xa0, 0x7c, 0x41, 0xc8, 0x03,
+	0x1e, 0x59, 0x5e, 0x62, 0x7c, 0x39, 0xe5, 0x7c, 0xea, 0xb1, 0x96, 0x42, 0x93, 0xc5, 0x87, 0x96,
+	0x70, 0xe7, 0x2c, 0x12, 0xd6, 0x3c, 0x88, 0x09, 0xf5, 0x7f, 0xb6, 0x01, 0x75, 0xd3, 0x7c, 0x97,
+	0x2c, 0x8a, 0xac, 0x29, 0xc3, 0xaf, 0xa0, 0x20, 0x96, 0x01, 0xab, 0xe6, 0x8e, 0x73, 0x8d, 0x4a,
+	0xfb, 0x79, 0x4c, 0x8d, 0x9a, 0x9b, 0xbc, 0xa6, 0xb1, 0x0c, 0x18, 0x55, 0x54, 0xfc, 0x13, 0x94,
+	0xb2, 0xd4, 0xd5, 0xad, 0xe3, 0x5c, 0xa3, 0xdc, 0xae, 0x35, 0xe3, 0xc5, 0x9b, 0xe9, 0xe2, 0x4d,
+	0x23, 0x65, 0xd0, 0x5b, 0x32, 0xae, 0x42, 0x31, 0xb0, 0x96, 0x1e, 0xb7, 0x9c, 0x6a, 0xfe, 0x38,
+	0xd7, 0xd8, 0xa5, 0x29, 0xc4, 0x18, 0x0a, 0xe2, 0x93, 0xeb, 0x54, 0x0b, 0xc7, 0xb9, 0x46, 0x89,
+	0xaa, 0xff, 0xb8, 0x0d, 0x5a, 0x5a, 0x62, 0x75, 0x5b, 0x2d, 0x73, 0x94, 0xca, 0xd3, 0xdd, 0xa9,
+	0xcf, 0x9c, 0x71, 0xe2, 0xa5, 0x19, 0x0f, 0xbf, 0x81, 0xfd, 0x8d, 0x96, 0x55, 0x77, 0xd6, 0x43,
+	0xb3, 0xca, 0x88, 0xf4, 0xd2, 0x8a, 0xbd, 0x86, 0xf1, 0x73, 0x00, 0x7b, 0x66, 0xf9, 0x3e, 0xf3,
+	0x4c, 0xd7, 0xa9, 0x16, 0x95, 0x9c, 0x52, 0x62, 0x19, 0x38, 0xf5, 0xbf, 0xf3, 0x50, 0x90, 0xad,
+	0xc0, 0x7b, 0x50, 0xba, 0x1a, 0xf6, 0xc8, 0xe9, 0x60, 0x48, 0x7a, 0xe8, 0x11, 0xde, 0x05, 0x8d,
+	0x92, 0xfe, 0x40, 0x37, 0x08, 0x45, 0x39, 0x5c, 0x01, 0x48, 0x11, 0xe9, 0xa1, 0x2d, 0xac, 0x41,
+	0x61, 0x30, 0x1c, 0x18, 0x28, 0x8f, 0x4b, 0xb0, 0x4d, 0x49, 0xa7, 0x77, 0x8d, 0x0a, 0x78, 0x1f,
+	0xca, 0x06, 0xed, 0x0c, 0xf5, 0x4e, 0xd7, 0x18, 0x8c, 0x86, 0x68, 0x5b, 0xa6, 0xec, 0x8e, 0x2e,
+	0xc7, 0x17, 0xc4, 0x20, 0x3d, 0xb4, 0x23, 0xa9, 0x84, 0xd2, 0x11, 0x45, 0x45, 0xe9, 0xe9, 0x13,
+	0xc3, 0xd4, 0x8d, 0x8e, 0x41, 0x90, 0x26, 0xe1, 0xf8, 0x2a, 0x85, 0x25, 0x09, 0x7b, 0xe4, 0x22,
+	0x81, 0x80, 0x9f, 0x00, 0x1a, 0x0c, 0xdf, 0x8d, 0xce, 0x89, 0xd9, 0x3d, 0xeb, 0x0c, 0x86, 0xdd,
+	0x51, 0x8f, 0xa0, 0x72, 0x2c, 0x50, 0x1f, 0x8f, 0x86, 0x3a, 0x41, 0x7b, 0xf8, 0x08, 0x70, 0x96,
+	0xd0, 0x3c, 0xb9, 0x36, 0x69, 0x67, 0xd8, 0x27, 0xa8, 0x22, 0x63, 0xa5, 0xfd, 0xed, 0x15, 0xa1,
+	0xd7, 0x26, 0x25, 0xfa, 0xd5, 0x85, 0x81, 0xf6, 0xa5, 0x35, 0xb6, 0xc4, 0xfc, 0x21, 0x79, 0x6f,
+	0x20, 0x84, 0x0f, 0xe1, 0xf1, 0xaa, 0xb5, 0x7b, 0x31, 0xd2, 0x09, 0x7a, 0x2c, 0xd5, 0x9c, 0x13,
+	0x32, 0xee, 0x5c, 0x0c, 0xde, 0x11, 0x84, 0xf1, 0x37, 0x70, 0x20, 0x33, 0x9e, 0x0d, 0x74, 0x63,
+	0x44, 0xaf, 0xcd, 0xd3, 0x11, 0x35, 0xcf, 0xc9, 0x35, 0x3a, 0x58, 0x97, 0x70, 0x49, 0x8c, 0x4e,
+	0xaf, 0x63, 0x74, 0xd0, 0x13, 0x69, 0x1f, 0x5f, 0xdd, 0xb1, 0x1f, 0xe2, 0xa7, 0x70, 0x28, 0xf9,
+	0x63, 0x3a, 0x78, 0x27, 0x3d, 0xd2, 0x6a, 0x9e, 0x75, 0xf4, 0x33, 0x74, 0x54, 0xff, 0x05, 0xb4,
+	0x3e, 0x13, 0xba, 0xb0, 0x04, 0xc3, 0x08, 0xf2, 0x37, 0x6c, 0xa9, 0xc6, 0xb9, 0x44, 0xe5, 0x5f,
+	0xfc, 0x02, 0xc0, 0xe6, 0x9e, 0xc7, 0x6c, 0xe1, 0x72, 0x5f, 0xcd, 0x6b, 0x89, 0xae, 0x58, 0xea,
+	0x3d, 0x40, 0x69, 0xf4, 0x25, 0x13, 0x96, 0x63, 0x09, 0xeb, 0x2b, 0xb2, 0x50, 0xd0, 0xc6, 0x8b,
+	0x7b, 0x35, 0x3c, 0x81, 0xed, 0x8f, 0x96, 0xb7, 0x60, 0x2a, 0x70, 0x97, 0xc6, 0x60, 0x23, 0x67,
+	0xfe, 0x4e, 0xce, 0xdf, 0x01, 0x8d, 0x17, 0xff, 0x53, 0xd9, 0x9d, 0x2c, 0xf8, 0x15, 0x68, 0xf3,
+	0x24, 0x5a, 0x1d, 0xaf, 0x72, 0xfb, 0x30, 0x3b, 0x46, 0xab, 0xa9, 0x69, 0x46, 0x93, 0x0d, 0xed,
+	0x31, 0xef, 0x6b, 0x1b, 0xfa, 0x67, 0x0e, 0xf6, 0xd3, 0x8e, 0x9e, 0x2c, 0xa9, 0xe5, 0x4f, 0x19,
+	0xae, 0x81, 0x16, 0x09, 0x2b, 0x14, 0xe7, 0x59, 0xaa, 0x0c, 0xe3, 0x23, 0xd8, 0x61, 0xbe, 0x23,
+	0x3d, 0x71, 0xae, 0x04, 0x3d, 0x58, 0x58, 0x6d, 0xa3, 0xb0, 0xdd, 0x95, 0x0a, 0x26, 0x50, 0xe9,
+	0x33, 0xf1, 0x76, 0xc1, 0xc2, 0x25, 0x65, 0xd1, 0xc2, 0x13, 0x72, 0x0b, 0x7e, 0x93, 0x30, 0x59,
+	0x3e, 0x06, 0x0f, 0xd5, 0xb2, 0xb6, 0x46, 0x7e, 0x63, 0x8d, 0x3e, 0xec, 0xa9, 0x05, 0xb2, 0xbd,
+	0xa9, 0x81, 0x16, 0x58, 0x53, 0xa6, 0xbb, 0x7f, 0xc4, 0xf7, 0xe9, 0x36, 0xcd, 0xb0, 0xf4, 0x4d,
+	0x38, 0xbf, 0x99, 0x5b, 0xe1, 0x4d, 0xb2, 0x4c, 0x86, 0xe5, 0x3e, 0xf7, 0x99, 0x38, 0x73, 0x23,
+	0xc1, 0xc3, 0xe5, 0x29, 0x0f, 0x65, 0xf1, 0x77, 0xdb, 0xbe, 0x2a, 0x65, 0x6b, 0x5d, 0x0a, 0x7e,
+	0x0d, 0x45, 0x1e, 0x48, 0xc1, 0x91, 0x52, 0x59, 0x6e, 0x3f, 0x4b, 0xb7, 0x38, 0xc9, 0xaa, 0x84,
+	0x8e, 0x62, 0x0a, 0x4d, 0xb9, 0xf5, 0x7f, 0x73, 0x70, 0xf0, 0x19, 0x02, 0x7e, 0x09, 0x65, 0xb5,
+	0x3b, 0xe6, 0xc4, 0xe3, 0xf6, 0x8d, 0x12, 0x51, 0xa0, 0xa0, 0x4c, 0x27, 0xd2, 0x82, 0x9f, 0x41,
+	0x89, 0xf9, 0x4e, 0xe2, 0xde, 0x52, 0x6e, 0x8d, 0xf9, 0x4e, 0xec, 0xfc, 0x19, 0x62, 0xaa, 0x29,
+	0x2f, 0xfe, 0x6a, 0xfe, 0xe1, 0x0f, 0x84, 0x62, 0x4b, 0x8c, 0x5f, 0x83, 0x4c, 0x13, 0x07, 0x16,
+	0x1e, 0x0c, 0x2c, 0x32, 0xdf, 0x51, 0x61, 0x2f, 0x00, 0x1c, 0x16, 0xd9, 0xcc, 0x77, 0x5c, 0x7f,
+	0xaa, 0xbe, 0x15, 0x1a, 0x5d, 0xb1, 0xd4, 0x8f, 0xa1, 0xa2, 0xea, 0x53, 0x23, 0x39, 0x64, 0x9f,
+	0x04, 0xae, 0xc0, 0x96, 0xeb, 0x24, 0xdd, 0xdd, 0x72, 0x9d, 0xfa, 0xb7, 0xb0, 0x7f, 0xcb, 0xe8,
+	0x7a, 0x3c, 0x62, 0x77, 0x28, 0x3f, 0x02, 0x5a, 0x99, 0xa7, 0x93, 0xa5, 0x60, 0x11, 0x3e, 0x86,
+	0x72, 0x78, 0x0b, 0x15, 0x79, 0x97, 0xae, 0x9a, 0xea, 0x7f, 0xe5, 0x92, 0x29, 0xa1, 0x2c, 0x0a,
+	0xb8, 0x1f, 0x31, 0xdc, 0x86, 0x62, 0x4c, 0x90, 0xfc, 0x7c, 0xa3, 0xdc, 0xae, 0xa6, 0x7b, 0xb5,
+	0x99, 0x9e, 0xa6, 0x44, 0xfc, 0x14, 0xb4, 0x99, 0x15, 0x99, 0x73, 0x1e, 0xc6, 0x57, 0x88, 0x46,
+	0x8b, 0x33, 0x2b, 0xba, 0xe4, 0x61, 0x2a, 0x33, 0x9f, 0xca, 0xfc, 0xe2, 0xa9, 0x98, 0xc2, 0xe1,
+	0x9a, 0x96, 0x6c, 0x72, 0xdb, 0x70, 0xf8, 0x81, 0x09, 0x7b, 0xc6, 0x1c, 0x33, 0x64, 0x36, 0x0f,
+	0x9d, 0xc8, 0xb4, 0xf9, 0xc2, 0x17, 0xc9, 0x18, 0x1f, 0x24, 0x4e, 0x1a, 0xfb, 0xba, 0xd2, 0xf5,
+	0xc5, 0x89, 0x7e, 0x03, 0x7b, 0xeb, 0xd7, 0x56, 0x15, 0x8a, 0x52, 0xc5, 0xed, 0x48, 0xa7, 0xf0,
+	0xf3, 0x57, 0x63, 0xfd, 0x14, 0x0e, 0xd6, 0x2f, 0xa7, 0xf8, 0x10, 0xb7, 0xa0, 0xc8, 0x7c, 0x11,
+	0xba, 0x2c, 0xed, 0xdd, 0x3d, 0x57, 0x59, 0xca, 0x6a, 0xbf, 0x5f, 0x79, 0xf2, 0xe8, 0x8b, 0x20,
+	0xe0, 0xa1, 0xc0, 0x3d, 0xd0, 0x28, 0x9b, 0xba, 0x91, 0x60, 0x21, 0xae, 0xde, 0xf7, 0xe0, 0xa9,
+	0xdd, 0xeb, 0xa9, 0x3f, 0x6a, 0xe4, 0xbe, 0xcf, 0x9d, 0x8c, 0xa0, 0xce, 0xc3, 0x69, 0x73, 0xb6,
+	0x0c, 0x58, 0xe8, 0x31, 0x67, 0xca, 0xc2, 0xe6, 0x07, 0x6b, 0x12, 0xba, 0x76, 0x1a, 0x27, 0xdf,
+	0x68, 0xbf, 0x7e, 0x37, 0x75, 0xc5, 0x6c, 0x31, 0x69, 0xda, 0x7c, 0xde, 0x5a, 0xa1, 0xb6, 0x62,
+	0x6a, 0xfc, 0x56, 0x8b, 0x5a, 0x92, 0x3a, 0x89, 0x1f, 0x7e, 0x3f, 0xfc, 0x37, 0x00, 0x90, 0x64,
+	0x1f, 0x3d, 0x1c, 0x0a, 0x00, 0x00,
 }
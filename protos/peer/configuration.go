@@ -102,6 +102,8 @@ func (ccv *DynamicApplicationConfigValue) VariablyOpaqueFieldProto(name string)
 		return &common.Capabilities{}, nil
 	case "ACLs":
 		return &ACLs{}, nil
+	case "TokenTypes":
+		return &TokenTypes{}, nil
 	default:
 		return nil, fmt.Errorf("Unknown Application ConfigValue name: %s", ccv.name)
 	}
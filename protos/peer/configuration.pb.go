@@ -30,7 +30,7 @@ func (m *AnchorPeers) Reset()         { *m = AnchorPeers{} }
 func (m *AnchorPeers) String() string { return proto.CompactTextString(m) }
 func (*AnchorPeers) ProtoMessage()    {}
 func (*AnchorPeers) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_d9ec63ae33c182ef, []int{0}
+	return fileDescriptor_configuration_2f9333cd8463f675, []int{0}
 }
 func (m *AnchorPeers) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AnchorPeers.Unmarshal(m, b)
@@ -73,7 +73,7 @@ func (m *AnchorPeer) Reset()         { *m = AnchorPeer{} }
 func (m *AnchorPeer) String() string { return proto.CompactTextString(m) }
 func (*AnchorPeer) ProtoMessage()    {}
 func (*AnchorPeer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_d9ec63ae33c182ef, []int{1}
+	return fileDescriptor_configuration_2f9333cd8463f675, []int{1}
 }
 func (m *AnchorPeer) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AnchorPeer.Unmarshal(m, b)
@@ -120,7 +120,7 @@ func (m *APIResource) Reset()         { *m = APIResource{} }
 func (m *APIResource) String() string { return proto.CompactTextString(m) }
 func (*APIResource) ProtoMessage()    {}
 func (*APIResource) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_d9ec63ae33c182ef, []int{2}
+	return fileDescriptor_configuration_2f9333cd8463f675, []int{2}
 }
 func (m *APIResource) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_APIResource.Unmarshal(m, b)
@@ -160,7 +160,7 @@ func (m *ACLs) Reset()         { *m = ACLs{} }
 func (m *ACLs) String() string { return proto.CompactTextString(m) }
 func (*ACLs) ProtoMessage()    {}
 func (*ACLs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_d9ec63ae33c182ef, []int{3}
+	return fileDescriptor_configuration_2f9333cd8463f675, []int{3}
 }
 func (m *ACLs) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ACLs.Unmarshal(m, b)
@@ -187,37 +187,144 @@ func (m *ACLs) GetAcls() map[string]*APIResource {
 	return nil
 }
 
+// TokenTypes defines the token types of a channel. When it is set, only the
+// defined token types may be issued.
+type TokenTypes struct {
+	TokenTypes           map[string]*TokenType `protobuf:"bytes,1,rep,name=token_types,json=tokenTypes,proto3" json:"token_types,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *TokenTypes) Reset()         { *m = TokenTypes{} }
+func (m *TokenTypes) String() string { return proto.CompactTextString(m) }
+func (*TokenTypes) ProtoMessage()    {}
+func (*TokenTypes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_configuration_2f9333cd8463f675, []int{4}
+}
+func (m *TokenTypes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenTypes.Unmarshal(m, b)
+}
+func (m *TokenTypes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenTypes.Marshal(b, m, deterministic)
+}
+func (dst *TokenTypes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenTypes.Merge(dst, src)
+}
+func (m *TokenTypes) XXX_Size() int {
+	return xxx_messageInfo_TokenTypes.Size(m)
+}
+func (m *TokenTypes) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenTypes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenTypes proto.InternalMessageInfo
+
+func (m *TokenTypes) GetTokenTypes() map[string]*TokenType {
+	if m != nil {
+		return m.TokenTypes
+	}
+	return nil
+}
+
+// TokenType defines a token type
+type TokenType struct {
+	// The maximum quantity of tokens of the type which are issued and not redeemed
+	MaxSupply uint64 `protobuf:"varint,1,opt,name=max_supply,json=maxSupply,proto3" json:"max_supply,omitempty"`
+	// The number of decimal places of the quantities of the type
+	Decimals uint32 `protobuf:"varint,2,opt,name=decimals,proto3" json:"decimals,omitempty"`
+	// The MSP ID of the organization whose members issue tokens of the type
+	Issuer               string   `protobuf:"bytes,3,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TokenType) Reset()         { *m = TokenType{} }
+func (m *TokenType) String() string { return proto.CompactTextString(m) }
+func (*TokenType) ProtoMessage()    {}
+func (*TokenType) Descriptor() ([]byte, []int) {
+	return fileDescriptor_configuration_2f9333cd8463f675, []int{5}
+}
+func (m *TokenType) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenType.Unmarshal(m, b)
+}
+func (m *TokenType) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenType.Marshal(b, m, deterministic)
+}
+func (dst *TokenType) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenType.Merge(dst, src)
+}
+func (m *TokenType) XXX_Size() int {
+	return xxx_messageInfo_TokenType.Size(m)
+}
+func (m *TokenType) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenType.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenType proto.InternalMessageInfo
+
+func (m *TokenType) GetMaxSupply() uint64 {
+	if m != nil {
+		return m.MaxSupply
+	}
+	return 0
+}
+
+func (m *TokenType) GetDecimals() uint32 {
+	if m != nil {
+		return m.Decimals
+	}
+	return 0
+}
+
+func (m *TokenType) GetIssuer() string {
+	if m != nil {
+		return m.Issuer
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*AnchorPeers)(nil), "protos.AnchorPeers")
 	proto.RegisterType((*AnchorPeer)(nil), "protos.AnchorPeer")
 	proto.RegisterType((*APIResource)(nil), "protos.APIResource")
 	proto.RegisterType((*ACLs)(nil), "protos.ACLs")
 	proto.RegisterMapType((map[string]*APIResource)(nil), "protos.ACLs.AclsEntry")
+	proto.RegisterType((*TokenTypes)(nil), "protos.TokenTypes")
+	proto.RegisterMapType((map[string]*TokenType)(nil), "protos.TokenTypes.TokenTypesEntry")
+	proto.RegisterType((*TokenType)(nil), "protos.TokenType")
 }
 
 func init() {
-	proto.RegisterFile("peer/configuration.proto", fileDescriptor_configuration_d9ec63ae33c182ef)
-}
-
-var fileDescriptor_configuration_d9ec63ae33c182ef = []byte{
-	// 295 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x91, 0xdf, 0x4b, 0xfb, 0x30,
-	0x14, 0xc5, 0xe9, 0x7e, 0x7c, 0x61, 0xb7, 0xdf, 0x07, 0x89, 0x20, 0x45, 0x10, 0x46, 0x9f, 0x36,
-	0x91, 0x14, 0xa6, 0x82, 0xf8, 0x56, 0xa7, 0x0f, 0xc2, 0xc0, 0x91, 0x47, 0x5f, 0x46, 0x16, 0x6f,
-	0x7f, 0x60, 0x6d, 0xca, 0x4d, 0x2a, 0xf4, 0xcd, 0x3f, 0x5d, 0x9a, 0x6c, 0xab, 0x4f, 0x39, 0x39,
-	0xf9, 0x9c, 0xcb, 0x21, 0x17, 0xa2, 0x06, 0x91, 0x12, 0xa5, 0xeb, 0xac, 0xcc, 0x5b, 0x92, 0xb6,
-	0xd4, 0x35, 0x6f, 0x48, 0x5b, 0xcd, 0xfe, 0xb9, 0xc3, 0xc4, 0xcf, 0x10, 0xa6, 0xb5, 0x2a, 0x34,
-	0x6d, 0x11, 0xc9, 0xb0, 0x7b, 0xf8, 0x2f, 0xdd, 0x75, 0xd7, 0x27, 0x4d, 0x14, 0xcc, 0xc7, 0x8b,
-	0x70, 0xc5, 0x7c, 0xc8, 0xf0, 0x01, 0x15, 0xa1, 0x1c, 0x62, 0xf1, 0x1d, 0xc0, 0xf0, 0xc4, 0x18,
-	0x4c, 0x0a, 0x6d, 0x6c, 0x14, 0xcc, 0x83, 0xc5, 0x4c, 0x38, 0xdd, 0x7b, 0x8d, 0x26, 0x1b, 0x8d,
-	0xe6, 0xc1, 0x62, 0x2a, 0x9c, 0x8e, 0x6f, 0x20, 0x4c, 0xb7, 0xaf, 0x02, 0x8d, 0x6e, 0x49, 0x21,
-	0xbb, 0x02, 0x68, 0x74, 0x55, 0xaa, 0x6e, 0x47, 0x98, 0x1d, 0xc2, 0x33, 0xef, 0x08, 0xcc, 0xe2,
-	0x9f, 0x00, 0x26, 0xe9, 0x7a, 0x63, 0xd8, 0x35, 0x4c, 0xa4, 0xaa, 0x8e, 0xdd, 0x2e, 0x4e, 0xdd,
-	0xd6, 0x1b, 0xc3, 0x53, 0x55, 0x99, 0x97, 0xda, 0x52, 0x27, 0x1c, 0x73, 0xb9, 0x81, 0xd9, 0xc9,
-	0x62, 0x67, 0x30, 0xfe, 0xc4, 0xee, 0x30, 0xb9, 0x97, 0x6c, 0x09, 0xd3, 0x6f, 0x59, 0xb5, 0xe8,
-	0x6a, 0x85, 0xab, 0xf3, 0xd3, 0xac, 0xa1, 0x96, 0xf0, 0xc4, 0xe3, 0xe8, 0x21, 0x78, 0x7a, 0x83,
-	0x58, 0x53, 0xce, 0x8b, 0xae, 0x41, 0xaa, 0xf0, 0x23, 0x47, 0xe2, 0x99, 0xdc, 0x53, 0xa9, 0x8e,
-	0xb9, 0xfe, 0xd3, 0xde, 0x97, 0x79, 0x69, 0x8b, 0x76, 0xcf, 0x95, 0xfe, 0x4a, 0xfe, 0xa0, 0x89,
-	0x47, 0x13, 0x8f, 0x26, 0x3d, 0xba, 0xf7, 0x5b, 0xb8, 0xfd, 0x0d, 0x00, 0x00, 0xff, 0xff, 0xae,
-	0x0a, 0x1d, 0x41, 0xa8, 0x01, 0x00, 0x00,
+	proto.RegisterFile("peer/configuration.proto", fileDescriptor_configuration_2f9333cd8463f675)
+}
+
+var fileDescriptor_configuration_2f9333cd8463f675 = []byte{
+	// 398 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x92, 0x5f, 0x8b, 0xd4, 0x30,
+	0x14, 0xc5, 0xe9, 0xce, 0xec, 0x62, 0x6f, 0x15, 0x35, 0xc2, 0x52, 0x06, 0x84, 0xa1, 0x2f, 0xce,
+	0x8a, 0xb4, 0xb0, 0x2a, 0x88, 0x6f, 0xe3, 0xe8, 0x83, 0x30, 0xe0, 0x10, 0xf7, 0xc9, 0x07, 0x87,
+	0x4c, 0xf6, 0xf6, 0x0f, 0xdb, 0x36, 0x21, 0x49, 0x65, 0xfb, 0xe6, 0x77, 0xf1, 0x8b, 0x4a, 0x92,
+	0x4e, 0x3b, 0xfe, 0x79, 0xea, 0x3d, 0x27, 0xe7, 0x9e, 0xfe, 0x08, 0x81, 0x58, 0x22, 0xaa, 0x8c,
+	0x8b, 0x36, 0xaf, 0x8a, 0x4e, 0x31, 0x53, 0x89, 0x36, 0x95, 0x4a, 0x18, 0x41, 0x2e, 0xdc, 0x47,
+	0x27, 0x1f, 0x21, 0x5a, 0xb7, 0xbc, 0x14, 0x6a, 0x87, 0xa8, 0x34, 0x79, 0x0b, 0x0f, 0x99, 0x93,
+	0x7b, 0xbb, 0xa9, 0xe3, 0x60, 0x39, 0x5b, 0x45, 0xd7, 0xc4, 0x2f, 0xe9, 0x74, 0x8a, 0xd2, 0x88,
+	0x4d, 0x6b, 0xc9, 0x1b, 0x80, 0xe9, 0x88, 0x10, 0x98, 0x97, 0x42, 0x9b, 0x38, 0x58, 0x06, 0xab,
+	0x90, 0xba, 0xd9, 0x7a, 0x52, 0x28, 0x13, 0x9f, 0x2d, 0x83, 0xd5, 0x39, 0x75, 0x73, 0xf2, 0x0a,
+	0xa2, 0xf5, 0xee, 0x33, 0x45, 0x2d, 0x3a, 0xc5, 0x91, 0x3c, 0x07, 0x90, 0xa2, 0xae, 0x78, 0xbf,
+	0x57, 0x98, 0x0f, 0xcb, 0xa1, 0x77, 0x28, 0xe6, 0xc9, 0xcf, 0x00, 0xe6, 0xeb, 0xcd, 0x56, 0x93,
+	0x97, 0x30, 0x67, 0xbc, 0x3e, 0xb2, 0x5d, 0x8e, 0x6c, 0x9b, 0xad, 0x4e, 0xd7, 0xbc, 0xd6, 0x9f,
+	0x5a, 0xa3, 0x7a, 0xea, 0x32, 0x8b, 0x2d, 0x84, 0xa3, 0x45, 0x9e, 0xc0, 0xec, 0x0e, 0xfb, 0xa1,
+	0xd9, 0x8e, 0xe4, 0x0a, 0xce, 0x7f, 0xb0, 0xba, 0x43, 0x87, 0x15, 0x5d, 0x3f, 0x1b, 0xbb, 0x26,
+	0x2c, 0xea, 0x13, 0xef, 0xcf, 0xde, 0x05, 0xc9, 0xaf, 0x00, 0xe0, 0x46, 0xdc, 0x61, 0x7b, 0xd3,
+	0x4b, 0xd4, 0x64, 0x03, 0x91, 0xb1, 0x6a, 0x6f, 0xac, 0x1c, 0x78, 0x92, 0x63, 0xc7, 0x14, 0x3c,
+	0x19, 0x3d, 0x1b, 0x98, 0xd1, 0x58, 0xec, 0xe0, 0xf1, 0x5f, 0xc7, 0xff, 0xe1, 0x7c, 0xf1, 0x27,
+	0xe7, 0xd3, 0x7f, 0xfe, 0x71, 0x4a, 0xf9, 0x1d, 0xc2, 0xd1, 0xb7, 0x97, 0xda, 0xb0, 0xfb, 0xbd,
+	0xee, 0xa4, 0xac, 0x7d, 0xe5, 0x9c, 0x86, 0x0d, 0xbb, 0xff, 0xea, 0x0c, 0xb2, 0x80, 0x07, 0xb7,
+	0xc8, 0xab, 0x86, 0xd5, 0xda, 0x75, 0x3f, 0xa2, 0xa3, 0x26, 0x97, 0x70, 0x51, 0x69, 0xdd, 0xa1,
+	0x8a, 0x67, 0x8e, 0x64, 0x50, 0x1f, 0xbe, 0x40, 0x22, 0x54, 0x91, 0x96, 0xbd, 0x44, 0x55, 0xe3,
+	0x6d, 0x81, 0x2a, 0xcd, 0xd9, 0x41, 0x55, 0xfc, 0x48, 0x65, 0x9f, 0xce, 0xb7, 0xab, 0xa2, 0x32,
+	0x65, 0x77, 0x48, 0xb9, 0x68, 0xb2, 0x93, 0x68, 0xe6, 0xa3, 0x99, 0x8f, 0x66, 0x36, 0x7a, 0xf0,
+	0x6f, 0xf1, 0xf5, 0xef, 0x01, 0x00, 0xd5, 0xb1, 0x5b, 0x0c, 0xae, 0x02, 0x00, 0x00,
 }
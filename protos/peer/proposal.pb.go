@@ -27,22 +27,22 @@ const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 // When an endorser receives a SignedProposal message, it should verify the
 // signature over the proposal bytes. This verification requires the following
 // steps:
-// 1. Verification of the validity of the certificate that was used to produce
-//    the signature.  The certificate will be available once proposalBytes has
-//    been unmarshalled to a Proposal message, and Proposal.header has been
-//    unmarshalled to a Header message. While this unmarshalling-before-verifying
-//    might not be ideal, it is unavoidable because i) the signature needs to also
-//    protect the signing certificate; ii) it is desirable that Header is created
-//    once by the client and never changed (for the sake of accountability and
-//    non-repudiation). Note also that it is actually impossible to conclusively
-//    verify the validity of the certificate included in a Proposal, because the
-//    proposal needs to first be endorsed and ordered with respect to certificate
-//    expiration transactions. Still, it is useful to pre-filter expired
-//    certificates at this stage.
-// 2. Verification that the certificate is trusted (signed by a trusted CA) and
-//    that it is allowed to transact with us (with respect to some ACLs);
-// 3. Verification that the signature on proposalBytes is valid;
-// 4. Detect replay attacks;
+//  1. Verification of the validity of the certificate that was used to produce
+//     the signature.  The certificate will be available once proposalBytes has
+//     been unmarshalled to a Proposal message, and Proposal.header has been
+//     unmarshalled to a Header message. While this unmarshalling-before-verifying
+//     might not be ideal, it is unavoidable because i) the signature needs to also
+//     protect the signing certificate; ii) it is desirable that Header is created
+//     once by the client and never changed (for the sake of accountability and
+//     non-repudiation). Note also that it is actually impossible to conclusively
+//     verify the validity of the certificate included in a Proposal, because the
+//     proposal needs to first be endorsed and ordered with respect to certificate
+//     expiration transactions. Still, it is useful to pre-filter expired
+//     certificates at this stage.
+//  2. Verification that the certificate is trusted (signed by a trusted CA) and
+//     that it is allowed to transact with us (with respect to some ACLs);
+//  3. Verification that the signature on proposalBytes is valid;
+//  4. Detect replay attacks;
 type SignedProposal struct {
 	// The bytes of Proposal
 	ProposalBytes []byte `protobuf:"bytes,1,opt,name=proposal_bytes,json=proposalBytes,proto3" json:"proposal_bytes,omitempty"`
@@ -59,7 +59,7 @@ func (m *SignedProposal) Reset()         { *m = SignedProposal{} }
 func (m *SignedProposal) String() string { return proto.CompactTextString(m) }
 func (*SignedProposal) ProtoMessage()    {}
 func (*SignedProposal) Descriptor() ([]byte, []int) {
-	return fileDescriptor_proposal_9ea47813bdcd2121, []int{0}
+	return fileDescriptor_proposal_00ee57ae3e469a3a, []int{0}
 }
 func (m *SignedProposal) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SignedProposal.Unmarshal(m, b)
@@ -94,24 +94,24 @@ func (m *SignedProposal) GetSignature() []byte {
 }
 
 // A Proposal is sent to an endorser for endorsement.  The proposal contains:
-// 1. A header which should be unmarshaled to a Header message.  Note that
-//    Header is both the header of a Proposal and of a Transaction, in that i)
-//    both headers should be unmarshaled to this message; and ii) it is used to
-//    compute cryptographic hashes and signatures.  The header has fields common
-//    to all proposals/transactions.  In addition it has a type field for
-//    additional customization. An example of this is the ChaincodeHeaderExtension
-//    message used to extend the Header for type CHAINCODE.
-// 2. A payload whose type depends on the header's type field.
-// 3. An extension whose type depends on the header's type field.
+//  1. A header which should be unmarshaled to a Header message.  Note that
+//     Header is both the header of a Proposal and of a Transaction, in that i)
+//     both headers should be unmarshaled to this message; and ii) it is used to
+//     compute cryptographic hashes and signatures.  The header has fields common
+//     to all proposals/transactions.  In addition it has a type field for
+//     additional customization. An example of this is the ChaincodeHeaderExtension
+//     message used to extend the Header for type CHAINCODE.
+//  2. A payload whose type depends on the header's type field.
+//  3. An extension whose type depends on the header's type field.
 //
 // Let us see an example. For type CHAINCODE (see the Header message),
 // we have the following:
-// 1. The header is a Header message whose extensions field is a
-//    ChaincodeHeaderExtension message.
-// 2. The payload is a ChaincodeProposalPayload message.
-// 3. The extension is a ChaincodeAction that might be used to ask the
-//    endorsers to endorse a specific ChaincodeAction, thus emulating the
-//    submitting peer model.
+//  1. The header is a Header message whose extensions field is a
+//     ChaincodeHeaderExtension message.
+//  2. The payload is a ChaincodeProposalPayload message.
+//  3. The extension is a ChaincodeAction that might be used to ask the
+//     endorsers to endorse a specific ChaincodeAction, thus emulating the
+//     submitting peer model.
 type Proposal struct {
 	// The header of the proposal. It is the bytes of the Header
 	Header []byte `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
@@ -131,7 +131,7 @@ func (m *Proposal) Reset()         { *m = Proposal{} }
 func (m *Proposal) String() string { return proto.CompactTextString(m) }
 func (*Proposal) ProtoMessage()    {}
 func (*Proposal) Descriptor() ([]byte, []int) {
-	return fileDescriptor_proposal_9ea47813bdcd2121, []int{1}
+	return fileDescriptor_proposal_00ee57ae3e469a3a, []int{1}
 }
 func (m *Proposal) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Proposal.Unmarshal(m, b)
@@ -189,17 +189,20 @@ type ChaincodeHeaderExtension struct {
 	// this field impacts the content of ProposalResponsePayload.proposalHash.
 	PayloadVisibility []byte `protobuf:"bytes,1,opt,name=payload_visibility,json=payloadVisibility,proto3" json:"payload_visibility,omitempty"`
 	// The ID of the chaincode to target.
-	ChaincodeId          *ChaincodeID `protobuf:"bytes,2,opt,name=chaincode_id,json=chaincodeId,proto3" json:"chaincode_id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+	ChaincodeId *ChaincodeID `protobuf:"bytes,2,opt,name=chaincode_id,json=chaincodeId,proto3" json:"chaincode_id,omitempty"`
+	// The priority with which the orderer orders the transaction, when the
+	// priority classes of the channel admit it.
+	Priority             uint32   `protobuf:"varint,3,opt,name=priority,proto3" json:"priority,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ChaincodeHeaderExtension) Reset()         { *m = ChaincodeHeaderExtension{} }
 func (m *ChaincodeHeaderExtension) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeHeaderExtension) ProtoMessage()    {}
 func (*ChaincodeHeaderExtension) Descriptor() ([]byte, []int) {
-	return fileDescriptor_proposal_9ea47813bdcd2121, []int{2}
+	return fileDescriptor_proposal_00ee57ae3e469a3a, []int{2}
 }
 func (m *ChaincodeHeaderExtension) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeHeaderExtension.Unmarshal(m, b)
@@ -233,6 +236,13 @@ func (m *ChaincodeHeaderExtension) GetChaincodeId() *ChaincodeID {
 	return nil
 }
 
+func (m *ChaincodeHeaderExtension) GetPriority() uint32 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
 // ChaincodeProposalPayload is the Proposal's payload message to be used when
 // the Header's type is CHAINCODE.  It contains the arguments for this
 // invocation.
@@ -255,7 +265,7 @@ func (m *ChaincodeProposalPayload) Reset()         { *m = ChaincodeProposalPaylo
 func (m *ChaincodeProposalPayload) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeProposalPayload) ProtoMessage()    {}
 func (*ChaincodeProposalPayload) Descriptor() ([]byte, []int) {
-	return fileDescriptor_proposal_9ea47813bdcd2121, []int{3}
+	return fileDescriptor_proposal_00ee57ae3e469a3a, []int{3}
 }
 func (m *ChaincodeProposalPayload) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeProposalPayload.Unmarshal(m, b)
@@ -318,7 +328,7 @@ func (m *ChaincodeAction) Reset()         { *m = ChaincodeAction{} }
 func (m *ChaincodeAction) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeAction) ProtoMessage()    {}
 func (*ChaincodeAction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_proposal_9ea47813bdcd2121, []int{4}
+	return fileDescriptor_proposal_00ee57ae3e469a3a, []int{4}
 }
 func (m *ChaincodeAction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeAction.Unmarshal(m, b)
@@ -382,39 +392,40 @@ func init() {
 	proto.RegisterType((*ChaincodeAction)(nil), "protos.ChaincodeAction")
 }
 
-func init() { proto.RegisterFile("peer/proposal.proto", fileDescriptor_proposal_9ea47813bdcd2121) }
-
-var fileDescriptor_proposal_9ea47813bdcd2121 = []byte{
-	// 490 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x53, 0x4f, 0x6f, 0xd3, 0x30,
-	0x14, 0x57, 0x5a, 0x36, 0x36, 0xb7, 0x6c, 0xad, 0x37, 0x41, 0x54, 0xed, 0x30, 0x45, 0x42, 0x1a,
-	0x12, 0x24, 0x52, 0x91, 0x10, 0xe2, 0x82, 0x28, 0xab, 0xc4, 0x0e, 0x48, 0x53, 0x18, 0x3b, 0xec,
-	0x52, 0x9c, 0xe4, 0x91, 0x5a, 0x0d, 0xb6, 0x65, 0x3b, 0xd5, 0x72, 0xe4, 0xe3, 0xf1, 0x69, 0xf8,
-	0x0a, 0xc8, 0xb1, 0x9d, 0x76, 0xeb, 0x85, 0x4b, 0x9b, 0xf7, 0x7e, 0xef, 0xf7, 0x7b, 0x7f, 0x8d,
-	0x4e, 0x04, 0x80, 0x4c, 0x84, 0xe4, 0x82, 0x2b, 0x52, 0xc5, 0x42, 0x72, 0xcd, 0xf1, 0x7e, 0xfb,
-	0xa7, 0x26, 0xa7, 0x2d, 0x98, 0x2f, 0x09, 0x65, 0x39, 0x2f, 0xc0, 0xa2, 0x93, 0xb3, 0x07, 0x94,
-	0x85, 0x04, 0x25, 0x38, 0x53, 0x1e, 0x0d, 0x35, 0x5f, 0x01, 0x4b, 0xe0, 0x5e, 0x40, 0xae, 0x89,
-	0xa6, 0x9c, 0x29, 0x8b, 0x44, 0xdf, 0xd1, 0xd1, 0x37, 0x5a, 0x32, 0x28, 0xae, 0x1d, 0x15, 0xbf,
-	0x44, 0x47, 0x9d, 0x4c, 0xd6, 0x68, 0x50, 0x61, 0x70, 0x1e, 0x5c, 0x0c, 0xd3, 0x67, 0xde, 0x3b,
-	0x33, 0x4e, 0x7c, 0x86, 0x0e, 0x15, 0x2d, 0x19, 0xd1, 0xb5, 0x84, 0xb0, 0xd7, 0x46, 0x6c, 0x1c,
-	0xd1, 0x1d, 0x3a, 0xe8, 0x04, 0x9f, 0xa3, 0xfd, 0x25, 0x90, 0x02, 0xa4, 0x13, 0x72, 0x16, 0x0e,
-	0xd1, 0x53, 0x41, 0x9a, 0x8a, 0x93, 0xc2, 0xf1, 0xbd, 0x69, 0xb4, 0xe1, 0x5e, 0x03, 0x53, 0x94,
-	0xb3, 0xb0, 0x6f, 0xb5, 0x3b, 0x47, 0xf4, 0x3b, 0x40, 0xe1, 0x67, 0xdf, 0xfe, 0x97, 0x56, 0x6b,
-	0xee, 0x41, 0xfc, 0x06, 0x61, 0xa7, 0xb2, 0x58, 0x53, 0x45, 0x33, 0x5a, 0x51, 0xdd, 0xb8, 0xc4,
-	0x63, 0x87, 0xdc, 0x76, 0x00, 0x7e, 0x87, 0x86, 0xdd, 0x24, 0x17, 0xd4, 0x16, 0x32, 0x98, 0x9e,
-	0xd8, 0xe1, 0xa8, 0xb8, 0x4b, 0x73, 0x75, 0x99, 0x0e, 0xba, 0xc0, 0xab, 0x22, 0xfa, 0xb3, 0x5d,
-	0x83, 0xef, 0xf4, 0xda, 0x95, 0x7f, 0x8a, 0xf6, 0x28, 0x13, 0xb5, 0x76, 0x69, 0xad, 0x81, 0x6f,
-	0xd1, 0xf0, 0x46, 0x12, 0xa6, 0x28, 0x30, 0xfd, 0x95, 0x88, 0xb0, 0x77, 0xde, 0xbf, 0x18, 0x4c,
-	0xa7, 0x3b, 0xa9, 0x1e, 0xa9, 0xc5, 0xdb, 0xa4, 0x39, 0xd3, 0xb2, 0x49, 0x1f, 0xe8, 0x4c, 0x3e,
-	0xa2, 0xf1, 0x4e, 0x08, 0x1e, 0xa1, 0xfe, 0x0a, 0x6c, 0xdf, 0x87, 0xa9, 0xf9, 0x34, 0x45, 0xad,
-	0x49, 0x55, 0xfb, 0x5d, 0x59, 0xe3, 0x43, 0xef, 0x7d, 0x10, 0xfd, 0x0d, 0xd0, 0x71, 0x97, 0xfd,
-	0x53, 0x6e, 0xae, 0xc3, 0xec, 0x46, 0x82, 0xaa, 0x2b, 0xed, 0xb7, 0xef, 0x4d, 0xb3, 0x4d, 0x58,
-	0x03, 0xd3, 0xca, 0x09, 0x39, 0x0b, 0xbf, 0x46, 0x07, 0xfe, 0xe8, 0xda, 0x95, 0x0d, 0xa6, 0x23,
-	0xdf, 0x5a, 0xea, 0xfc, 0x69, 0x17, 0xb1, 0x33, 0xf7, 0x27, 0xff, 0x37, 0x77, 0x7c, 0x89, 0xc6,
-	0xed, 0x29, 0x2f, 0xb6, 0x4e, 0x39, 0xdc, 0x6b, 0xc9, 0x2f, 0xe2, 0x16, 0x89, 0x6f, 0xcc, 0xef,
-	0x7c, 0x03, 0xa7, 0x23, 0xfd, 0xc8, 0x33, 0xfb, 0x81, 0x22, 0x2e, 0xcb, 0x78, 0xd9, 0x08, 0x90,
-	0x15, 0x14, 0x25, 0xc8, 0xf8, 0x27, 0xc9, 0x24, 0xcd, 0x7d, 0x7e, 0xf3, 0x98, 0x66, 0xc7, 0x9b,
-	0x4d, 0xe4, 0x2b, 0x52, 0xc2, 0xdd, 0xab, 0x92, 0xea, 0x65, 0x9d, 0xc5, 0x39, 0xff, 0x95, 0x6c,
-	0x71, 0x13, 0xcb, 0x4d, 0x2c, 0x37, 0x31, 0xdc, 0xcc, 0x3e, 0xd6, 0xb7, 0xff, 0x02, 0x00, 0x00,
-	0xff, 0xff, 0x4f, 0x36, 0x2a, 0x4b, 0xca, 0x03, 0x00, 0x00,
+func init() { proto.RegisterFile("peer/proposal.proto", fileDescriptor_proposal_00ee57ae3e469a3a) }
+
+var fileDescriptor_proposal_00ee57ae3e469a3a = []byte{
+	// 502 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x53, 0xcd, 0x6a, 0x1b, 0x31,
+	0x10, 0xc6, 0x76, 0x93, 0x3a, 0xb2, 0x93, 0xd8, 0x4a, 0x68, 0x17, 0x93, 0x43, 0x58, 0x28, 0xa4,
+	0xd0, 0xee, 0x82, 0x0b, 0xa5, 0xf4, 0x52, 0xea, 0xc6, 0xd0, 0x1c, 0x0a, 0x61, 0x9b, 0xe6, 0x90,
+	0x8b, 0x2b, 0xef, 0x4e, 0xd7, 0xc2, 0x5b, 0x49, 0x48, 0xb2, 0xc9, 0x3e, 0x4c, 0x1f, 0xa8, 0x4f,
+	0xd3, 0x57, 0x28, 0x5a, 0xfd, 0xd8, 0x89, 0x2f, 0xb9, 0xd8, 0xfb, 0xcd, 0xa7, 0xef, 0x9b, 0xd1,
+	0xcc, 0x08, 0x9d, 0x08, 0x00, 0x99, 0x0a, 0xc9, 0x05, 0x57, 0xa4, 0x4a, 0x84, 0xe4, 0x9a, 0xe3,
+	0xfd, 0xe6, 0x4f, 0x8d, 0x4e, 0x1b, 0x32, 0x5f, 0x10, 0xca, 0x72, 0x5e, 0x80, 0x65, 0x47, 0x67,
+	0x0f, 0x24, 0x33, 0x09, 0x4a, 0x70, 0xa6, 0x3c, 0x1b, 0x69, 0xbe, 0x04, 0x96, 0xc2, 0xbd, 0x80,
+	0x5c, 0x13, 0x4d, 0x39, 0x53, 0x96, 0x89, 0x7f, 0xa0, 0xa3, 0xef, 0xb4, 0x64, 0x50, 0x5c, 0x3b,
+	0x29, 0x7e, 0x85, 0x8e, 0x82, 0xcd, 0xbc, 0xd6, 0xa0, 0xa2, 0xd6, 0x79, 0xeb, 0xa2, 0x9f, 0x1d,
+	0xfa, 0xe8, 0xc4, 0x04, 0xf1, 0x19, 0x3a, 0x50, 0xb4, 0x64, 0x44, 0xaf, 0x24, 0x44, 0xed, 0xe6,
+	0xc4, 0x26, 0x10, 0xdf, 0xa1, 0x6e, 0x30, 0x7c, 0x81, 0xf6, 0x17, 0x40, 0x0a, 0x90, 0xce, 0xc8,
+	0x21, 0x1c, 0xa1, 0xe7, 0x82, 0xd4, 0x15, 0x27, 0x85, 0xd3, 0x7b, 0x68, 0xbc, 0xe1, 0x5e, 0x03,
+	0x53, 0x94, 0xb3, 0xa8, 0x63, 0xbd, 0x43, 0x20, 0xfe, 0xd3, 0x42, 0xd1, 0x17, 0x7f, 0xfd, 0xaf,
+	0x8d, 0xd7, 0xd4, 0x93, 0xf8, 0x2d, 0xc2, 0xce, 0x65, 0xb6, 0xa6, 0x8a, 0xce, 0x69, 0x45, 0x75,
+	0xed, 0x12, 0x0f, 0x1d, 0x73, 0x1b, 0x08, 0xfc, 0x1e, 0xf5, 0x43, 0x27, 0x67, 0xd4, 0x16, 0xd2,
+	0x1b, 0x9f, 0xd8, 0xe6, 0xa8, 0x24, 0xa4, 0xb9, 0xba, 0xcc, 0x7a, 0xe1, 0xe0, 0x55, 0x81, 0x47,
+	0xa8, 0x2b, 0x24, 0xe5, 0xd2, 0x98, 0x9b, 0x02, 0x0f, 0xb3, 0x80, 0xe3, 0xbf, 0xdb, 0xf5, 0xf9,
+	0x2e, 0x5c, 0xbb, 0xab, 0x9d, 0xa2, 0x3d, 0xca, 0xc4, 0x4a, 0xbb, 0x92, 0x2c, 0xc0, 0xb7, 0xa8,
+	0x7f, 0x23, 0x09, 0x53, 0x14, 0x98, 0xfe, 0x46, 0x44, 0xd4, 0x3e, 0xef, 0x5c, 0xf4, 0xc6, 0xe3,
+	0x9d, 0x32, 0x1e, 0xb9, 0x25, 0xdb, 0xa2, 0x29, 0xd3, 0xb2, 0xce, 0x1e, 0xf8, 0x8c, 0x3e, 0xa1,
+	0xe1, 0xce, 0x11, 0x3c, 0x40, 0x9d, 0x25, 0xd8, 0x9e, 0x1c, 0x64, 0xe6, 0xd3, 0x14, 0xb5, 0x26,
+	0xd5, 0xca, 0xcf, 0xd1, 0x82, 0x8f, 0xed, 0x0f, 0xad, 0xf8, 0x5f, 0x0b, 0x1d, 0x87, 0xec, 0x9f,
+	0x73, 0xb3, 0x39, 0x66, 0x6e, 0x12, 0xd4, 0xaa, 0xd2, 0x7e, 0x33, 0x3c, 0x34, 0x93, 0x86, 0x35,
+	0x30, 0xad, 0x9c, 0x91, 0x43, 0xf8, 0x0d, 0xea, 0xfa, 0x85, 0x6c, 0xba, 0xd5, 0x1b, 0x0f, 0xfc,
+	0xd5, 0x32, 0x17, 0xcf, 0xc2, 0x89, 0x9d, 0x99, 0x3c, 0x7b, 0xe2, 0x4c, 0x2e, 0xd1, 0xb0, 0x59,
+	0xf3, 0xd9, 0xd6, 0x9a, 0x47, 0x7b, 0x8d, 0xf8, 0x65, 0xd2, 0x30, 0xc9, 0x8d, 0xf9, 0x9d, 0x6e,
+	0xe8, 0x6c, 0xa0, 0x1f, 0x45, 0x26, 0x3f, 0x51, 0xcc, 0x65, 0x99, 0x2c, 0x6a, 0x01, 0xb2, 0x82,
+	0xa2, 0x04, 0x99, 0xfc, 0x22, 0x73, 0x49, 0x73, 0x9f, 0xdf, 0x3c, 0xb4, 0xc9, 0xf1, 0x66, 0x12,
+	0xf9, 0x92, 0x94, 0x70, 0xf7, 0xba, 0xa4, 0x7a, 0xb1, 0x9a, 0x27, 0x39, 0xff, 0x9d, 0x6e, 0x69,
+	0x53, 0xab, 0x4d, 0xad, 0x36, 0x35, 0xda, 0xb9, 0x7d, 0xc8, 0xef, 0xfe, 0x0f, 0x00, 0xf9, 0x50,
+	0x6d, 0x4c, 0xe6, 0x03, 0x00, 0x00,
 }
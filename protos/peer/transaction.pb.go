@@ -114,7 +114,7 @@ func (x TxValidationCode) String() string {
 	return proto.EnumName(TxValidationCode_name, int32(x))
 }
 func (TxValidationCode) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_ebe070fb19096d76, []int{0}
+	return fileDescriptor_transaction_255bb754d122111b, []int{0}
 }
 
 // Reserved entries in the key-level metadata map
@@ -138,7 +138,7 @@ func (x MetaDataKeys) String() string {
 	return proto.EnumName(MetaDataKeys_name, int32(x))
 }
 func (MetaDataKeys) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_ebe070fb19096d76, []int{1}
+	return fileDescriptor_transaction_255bb754d122111b, []int{1}
 }
 
 // This message is necessary to facilitate the verification of the signature
@@ -161,7 +161,7 @@ func (m *SignedTransaction) Reset()         { *m = SignedTransaction{} }
 func (m *SignedTransaction) String() string { return proto.CompactTextString(m) }
 func (*SignedTransaction) ProtoMessage()    {}
 func (*SignedTransaction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_ebe070fb19096d76, []int{0}
+	return fileDescriptor_transaction_255bb754d122111b, []int{0}
 }
 func (m *SignedTransaction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SignedTransaction.Unmarshal(m, b)
@@ -215,7 +215,7 @@ func (m *ProcessedTransaction) Reset()         { *m = ProcessedTransaction{} }
 func (m *ProcessedTransaction) String() string { return proto.CompactTextString(m) }
 func (*ProcessedTransaction) ProtoMessage()    {}
 func (*ProcessedTransaction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_ebe070fb19096d76, []int{1}
+	return fileDescriptor_transaction_255bb754d122111b, []int{1}
 }
 func (m *ProcessedTransaction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ProcessedTransaction.Unmarshal(m, b)
@@ -249,6 +249,46 @@ func (m *ProcessedTransaction) GetValidationCode() int32 {
 	return 0
 }
 
+// ProcessedTransactions wraps a list of processed transactions, as returned by the
+// queries on the secondary transaction indexes of the block storage
+type ProcessedTransactions struct {
+	Transactions         []*ProcessedTransaction `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *ProcessedTransactions) Reset()         { *m = ProcessedTransactions{} }
+func (m *ProcessedTransactions) String() string { return proto.CompactTextString(m) }
+func (*ProcessedTransactions) ProtoMessage()    {}
+func (*ProcessedTransactions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_255bb754d122111b, []int{2}
+}
+func (m *ProcessedTransactions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProcessedTransactions.Unmarshal(m, b)
+}
+func (m *ProcessedTransactions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProcessedTransactions.Marshal(b, m, deterministic)
+}
+func (dst *ProcessedTransactions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProcessedTransactions.Merge(dst, src)
+}
+func (m *ProcessedTransactions) XXX_Size() int {
+	return xxx_messageInfo_ProcessedTransactions.Size(m)
+}
+func (m *ProcessedTransactions) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProcessedTransactions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProcessedTransactions proto.InternalMessageInfo
+
+func (m *ProcessedTransactions) GetTransactions() []*ProcessedTransaction {
+	if m != nil {
+		return m.Transactions
+	}
+	return nil
+}
+
 // The transaction to be sent to the ordering service. A transaction contains
 // one or more TransactionAction. Each TransactionAction binds a proposal to
 // potentially multiple actions. The transaction is atomic meaning that either
@@ -274,7 +314,7 @@ func (m *Transaction) Reset()         { *m = Transaction{} }
 func (m *Transaction) String() string { return proto.CompactTextString(m) }
 func (*Transaction) ProtoMessage()    {}
 func (*Transaction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_ebe070fb19096d76, []int{2}
+	return fileDescriptor_transaction_255bb754d122111b, []int{3}
 }
 func (m *Transaction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Transaction.Unmarshal(m, b)
@@ -318,7 +358,7 @@ func (m *TransactionAction) Reset()         { *m = TransactionAction{} }
 func (m *TransactionAction) String() string { return proto.CompactTextString(m) }
 func (*TransactionAction) ProtoMessage()    {}
 func (*TransactionAction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_ebe070fb19096d76, []int{3}
+	return fileDescriptor_transaction_255bb754d122111b, []int{4}
 }
 func (m *TransactionAction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TransactionAction.Unmarshal(m, b)
@@ -377,7 +417,7 @@ func (m *ChaincodeActionPayload) Reset()         { *m = ChaincodeActionPayload{}
 func (m *ChaincodeActionPayload) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeActionPayload) ProtoMessage()    {}
 func (*ChaincodeActionPayload) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_ebe070fb19096d76, []int{4}
+	return fileDescriptor_transaction_255bb754d122111b, []int{5}
 }
 func (m *ChaincodeActionPayload) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeActionPayload.Unmarshal(m, b)
@@ -430,7 +470,7 @@ func (m *ChaincodeEndorsedAction) Reset()         { *m = ChaincodeEndorsedAction
 func (m *ChaincodeEndorsedAction) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeEndorsedAction) ProtoMessage()    {}
 func (*ChaincodeEndorsedAction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_ebe070fb19096d76, []int{5}
+	return fileDescriptor_transaction_255bb754d122111b, []int{6}
 }
 func (m *ChaincodeEndorsedAction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeEndorsedAction.Unmarshal(m, b)
@@ -467,6 +507,7 @@ func (m *ChaincodeEndorsedAction) GetEndorsements() []*Endorsement {
 func init() {
 	proto.RegisterType((*SignedTransaction)(nil), "protos.SignedTransaction")
 	proto.RegisterType((*ProcessedTransaction)(nil), "protos.ProcessedTransaction")
+	proto.RegisterType((*ProcessedTransactions)(nil), "protos.ProcessedTransactions")
 	proto.RegisterType((*Transaction)(nil), "protos.Transaction")
 	proto.RegisterType((*TransactionAction)(nil), "protos.TransactionAction")
 	proto.RegisterType((*ChaincodeActionPayload)(nil), "protos.ChaincodeActionPayload")
@@ -475,62 +516,64 @@ func init() {
 	proto.RegisterEnum("protos.MetaDataKeys", MetaDataKeys_name, MetaDataKeys_value)
 }
 
-func init() { proto.RegisterFile("peer/transaction.proto", fileDescriptor_transaction_ebe070fb19096d76) }
-
-var fileDescriptor_transaction_ebe070fb19096d76 = []byte{
-	// 864 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x55, 0xcf, 0x6f, 0xe2, 0x46,
-	0x14, 0x5e, 0x76, 0x9b, 0xa4, 0x79, 0x90, 0x64, 0x18, 0x08, 0x21, 0x34, 0x6a, 0x57, 0x1c, 0xaa,
-	0xed, 0x56, 0x02, 0x29, 0x7b, 0xa8, 0x54, 0xf5, 0x32, 0xd8, 0x93, 0x60, 0xad, 0x99, 0xb1, 0xc6,
-	0x03, 0x21, 0x3d, 0x74, 0xe4, 0xc0, 0x94, 0xa0, 0x12, 0x1b, 0xd9, 0xde, 0x55, 0x73, 0xed, 0x1f,
-	0xd0, 0x5e, 0xfa, 0xf7, 0xb6, 0xd5, 0xf8, 0x07, 0x90, 0xec, 0xf6, 0x82, 0x99, 0xef, 0xfb, 0xde,
-	0x7b, 0xdf, 0x7b, 0x0f, 0xc6, 0xd0, 0x5a, 0x6b, 0x1d, 0xf7, 0xd3, 0x38, 0x08, 0x93, 0x60, 0x96,
-	0x2e, 0xa3, 0xb0, 0xb7, 0x8e, 0xa3, 0x34, 0xc2, 0xfb, 0xd9, 0x23, 0xe9, 0x5c, 0x64, 0xfc, 0x3a,
-	0x8e, 0xd6, 0x51, 0x12, 0xac, 0x54, 0xac, 0x93, 0x75, 0x14, 0x26, 0x3a, 0x57, 0x75, 0x1a, 0xb3,
-	0xe8, 0xe1, 0x21, 0x0a, 0xfb, 0xf9, 0x23, 0x07, 0xbb, 0xbf, 0x40, 0xdd, 0x5f, 0x2e, 0x42, 0x3d,
-	0x97, 0xdb, 0xac, 0xf8, 0x7b, 0xa8, 0xef, 0x14, 0x51, 0x77, 0x8f, 0xa9, 0x4e, 0xda, 0x95, 0xd7,
-	0x95, 0x37, 0x35, 0x81, 0x76, 0x88, 0x81, 0xc1, 0xf1, 0x05, 0x1c, 0x26, 0xcb, 0x45, 0x18, 0xa4,
-	0x1f, 0x62, 0xdd, 0x7e, 0x99, 0x89, 0xb6, 0x40, 0xf7, 0x8f, 0x0a, 0x34, 0xbd, 0x38, 0x9a, 0xe9,
-	0x24, 0x79, 0x5a, 0x63, 0x00, 0x8d, 0x9d, 0x54, 0x34, 0xfc, 0xa8, 0x57, 0xd1, 0x5a, 0x67, 0x55,
-	0xaa, 0x97, 0xa8, 0x57, 0x98, 0x2c, 0x71, 0xf1, 0x39, 0x31, 0xfe, 0x16, 0x8e, 0x3f, 0x06, 0xab,
-	0xe5, 0x3c, 0x30, 0xa8, 0x15, 0xcd, 0xf3, 0xfa, 0x7b, 0xe2, 0x19, 0xda, 0x1d, 0x40, 0x75, 0xb7,
-	0xf4, 0x3b, 0x38, 0xc8, 0xbf, 0x99, 0xa6, 0x5e, 0xbd, 0xa9, 0x5e, 0x9e, 0xe7, 0xc3, 0x48, 0x7a,
-	0x3b, 0x2a, 0x92, 0x7d, 0x8a, 0x52, 0xd9, 0xa5, 0x50, 0xff, 0x84, 0xc5, 0x2d, 0xd8, 0xbf, 0xd7,
-	0xc1, 0x5c, 0xc7, 0xc5, 0x74, 0x8a, 0x13, 0x6e, 0xc3, 0xc1, 0x3a, 0x78, 0x5c, 0x45, 0xc1, 0xbc,
-	0x98, 0x48, 0x79, 0xec, 0xfe, 0x55, 0x81, 0x96, 0x75, 0x1f, 0x2c, 0xc3, 0x59, 0x34, 0xd7, 0x79,
-	0x16, 0x2f, 0xa7, 0xf0, 0x4f, 0xd0, 0x99, 0x95, 0x8c, 0xda, 0x2c, 0xb1, 0xcc, 0x93, 0x17, 0x68,
-	0x6f, 0x14, 0x5e, 0x21, 0x28, 0xa3, 0x7f, 0x80, 0xfd, 0xdc, 0x5a, 0x56, 0xb1, 0x7a, 0xf9, 0x4d,
-	0xd9, 0xd3, 0xa6, 0x1a, 0x0d, 0xe7, 0x51, 0x9c, 0xe8, 0x79, 0xd1, 0x59, 0x21, 0xef, 0xfe, 0x59,
-	0x81, 0xb3, 0xff, 0xd1, 0xe0, 0x1f, 0xe1, 0xfc, 0x93, 0x5f, 0xd3, 0x33, 0x47, 0x67, 0xa5, 0x40,
-	0x14, 0xfc, 0xd6, 0x50, 0x4d, 0xe7, 0xd9, 0x1e, 0x74, 0x98, 0x26, 0xed, 0x97, 0xd9, 0xa8, 0x1b,
-	0xa5, 0x2d, 0xba, 0xe5, 0xc4, 0x13, 0xe1, 0xdb, 0xbf, 0xf7, 0x00, 0xc9, 0xdf, 0x27, 0x4f, 0x56,
-	0x88, 0x0f, 0x61, 0x6f, 0x42, 0x5c, 0xc7, 0x46, 0x2f, 0x30, 0x82, 0x1a, 0x73, 0x5c, 0x45, 0xd9,
-	0x84, 0xba, 0xdc, 0xa3, 0xa8, 0x82, 0x4f, 0xa0, 0x3a, 0x20, 0xb6, 0xf2, 0xc8, 0xad, 0xcb, 0x89,
-	0x8d, 0x5e, 0xe2, 0x53, 0xa8, 0x1b, 0xc0, 0xe2, 0xa3, 0x11, 0x67, 0x6a, 0x48, 0x89, 0x4d, 0x05,
-	0x7a, 0x85, 0xcf, 0xe1, 0x34, 0x83, 0x05, 0x25, 0x92, 0x0b, 0xe5, 0x3b, 0xd7, 0x8c, 0xc8, 0xb1,
-	0xa0, 0xe8, 0x0b, 0xfc, 0x1a, 0x2e, 0x1c, 0x96, 0x55, 0x50, 0x94, 0xd9, 0x5c, 0xf8, 0x54, 0x28,
-	0x29, 0x08, 0xf3, 0x89, 0x25, 0x1d, 0xce, 0xd0, 0x1e, 0xfe, 0x1a, 0x3a, 0xa5, 0xc2, 0xe2, 0xec,
-	0xca, 0xb9, 0x7e, 0xc2, 0xef, 0xe3, 0x0e, 0xb4, 0xc6, 0xcc, 0x1f, 0x7b, 0x1e, 0x17, 0x92, 0xda,
-	0x4a, 0x4e, 0x37, 0x7e, 0x0e, 0x4a, 0x3f, 0x9e, 0xe0, 0x1e, 0xf7, 0x89, 0xab, 0xe4, 0xd4, 0xb1,
-	0xd1, 0x97, 0x18, 0xc3, 0xb1, 0x3d, 0xf6, 0x5c, 0xc7, 0x22, 0x92, 0xe6, 0xd8, 0xa1, 0x29, 0x53,
-	0x18, 0x18, 0x51, 0x26, 0x95, 0xc7, 0x5d, 0xc7, 0xba, 0x55, 0x57, 0xc4, 0x71, 0x8d, 0x51, 0xc0,
-	0x2d, 0xc0, 0xa3, 0x89, 0x65, 0x29, 0x41, 0x49, 0x6e, 0xc4, 0x75, 0x2c, 0x89, 0xaa, 0xa6, 0x37,
-	0x6f, 0x48, 0x98, 0xe4, 0xa3, 0x67, 0x54, 0x0d, 0x37, 0xe0, 0x64, 0xcc, 0xde, 0x33, 0x7e, 0xc3,
-	0x8c, 0x2b, 0x79, 0xeb, 0x51, 0x74, 0x64, 0xec, 0x4a, 0x22, 0xae, 0xa9, 0x54, 0xd6, 0x90, 0x38,
-	0x4c, 0x31, 0x2e, 0xd5, 0x15, 0x1f, 0x33, 0x1b, 0x1d, 0xe3, 0x26, 0xa0, 0x11, 0x11, 0xfe, 0x30,
-	0x73, 0xaa, 0xa8, 0x10, 0x5c, 0xa0, 0x93, 0x72, 0xee, 0x72, 0x5a, 0xb4, 0x8c, 0x4c, 0x5b, 0x74,
-	0xea, 0x39, 0x82, 0xda, 0x79, 0x12, 0x8b, 0xdb, 0x14, 0xd5, 0x4d, 0x0b, 0x9b, 0xa3, 0x9a, 0x50,
-	0xe1, 0x3b, 0x9c, 0x6d, 0xfd, 0x60, 0xdc, 0x86, 0xa6, 0x99, 0x46, 0xbe, 0x16, 0x45, 0xa7, 0x92,
-	0x32, 0x23, 0x41, 0x0d, 0xd3, 0x5c, 0xb6, 0xa0, 0x21, 0x61, 0x8c, 0xba, 0xe5, 0xe2, 0x9a, 0x65,
-	0x84, 0xa0, 0xbe, 0xc7, 0x99, 0x4f, 0x37, 0x93, 0x3d, 0xc5, 0x47, 0x70, 0x98, 0x31, 0x37, 0x3e,
-	0x95, 0xa8, 0x65, 0x9c, 0x3b, 0xae, 0x4b, 0xaf, 0x89, 0xab, 0x6e, 0x84, 0x23, 0xa9, 0x41, 0xcf,
-	0x32, 0xb4, 0x58, 0xdd, 0x06, 0x6d, 0x63, 0x0c, 0x47, 0xa6, 0xe9, 0x0c, 0x27, 0x92, 0xda, 0xe8,
-	0x9f, 0x0a, 0x3e, 0x87, 0x66, 0xa9, 0xe4, 0x72, 0x48, 0x85, 0x99, 0xa5, 0xcf, 0x19, 0xfa, 0xb7,
-	0xf2, 0x96, 0x42, 0x6d, 0xa4, 0xd3, 0xc0, 0x0e, 0xd2, 0xe0, 0xbd, 0x7e, 0x4c, 0x8c, 0xa7, 0x22,
-	0xd4, 0xb4, 0xe7, 0x11, 0x41, 0x46, 0x54, 0x52, 0x81, 0x5e, 0xe0, 0xaf, 0xe0, 0xec, 0x73, 0x8c,
-	0x9a, 0x5c, 0xa2, 0xca, 0x60, 0x06, 0xdd, 0x28, 0x5e, 0xf4, 0xee, 0x1f, 0xd7, 0x3a, 0x5e, 0xe9,
-	0xf9, 0x42, 0xc7, 0xbd, 0x5f, 0x83, 0xbb, 0x78, 0x39, 0x2b, 0xff, 0x18, 0xe6, 0x0e, 0x1f, 0xe0,
-	0x9d, 0xbb, 0xc6, 0x0b, 0x66, 0xbf, 0x05, 0x0b, 0xfd, 0xf3, 0x77, 0x8b, 0x65, 0x7a, 0xff, 0xe1,
-	0xce, 0x5c, 0x8d, 0xfd, 0x9d, 0xf0, 0x7e, 0x1e, 0xde, 0xcf, 0xc3, 0xfb, 0x26, 0xfc, 0x2e, 0x7f,
-	0x21, 0xbc, 0xfb, 0x2f, 0x00, 0x00, 0xff, 0xff, 0x08, 0x94, 0x90, 0xd9, 0x31, 0x06, 0x00, 0x00,
+func init() { proto.RegisterFile("peer/transaction.proto", fileDescriptor_transaction_255bb754d122111b) }
+
+var fileDescriptor_transaction_255bb754d122111b = []byte{
+	// 882 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x55, 0x5f, 0x6f, 0xe2, 0xc6,
+	0x17, 0x5d, 0xb2, 0xbf, 0x24, 0xbf, 0x5c, 0x48, 0x32, 0x0c, 0x84, 0x10, 0x1a, 0xb5, 0x2b, 0x1e,
+	0xaa, 0xed, 0x56, 0x02, 0x29, 0xfb, 0x50, 0xa9, 0xea, 0x43, 0x07, 0x7b, 0x12, 0xac, 0x35, 0x33,
+	0xd6, 0x78, 0x20, 0xa4, 0x0f, 0x1d, 0x39, 0x30, 0x25, 0xa8, 0xc4, 0x46, 0x36, 0xbb, 0x6a, 0x5e,
+	0xfb, 0x01, 0xda, 0x97, 0x7e, 0xde, 0xb6, 0x1a, 0xff, 0xe1, 0x4f, 0x36, 0x7d, 0x42, 0x3e, 0xe7,
+	0xdc, 0x7b, 0xcf, 0xb9, 0x33, 0xd8, 0xd0, 0x58, 0x6a, 0x1d, 0x77, 0x57, 0x71, 0x10, 0x26, 0xc1,
+	0x64, 0x35, 0x8f, 0xc2, 0xce, 0x32, 0x8e, 0x56, 0x11, 0x3e, 0x48, 0x7f, 0x92, 0xd6, 0x65, 0xca,
+	0x2f, 0xe3, 0x68, 0x19, 0x25, 0xc1, 0x42, 0xc5, 0x3a, 0x59, 0x46, 0x61, 0xa2, 0x33, 0x55, 0xab,
+	0x36, 0x89, 0x1e, 0x1f, 0xa3, 0xb0, 0x9b, 0xfd, 0x64, 0x60, 0xfb, 0x67, 0xa8, 0xfa, 0xf3, 0x59,
+	0xa8, 0xa7, 0x72, 0xd3, 0x15, 0x7f, 0x0b, 0xd5, 0xad, 0x21, 0xea, 0xfe, 0x69, 0xa5, 0x93, 0x66,
+	0xe9, 0x4d, 0xe9, 0x6d, 0x45, 0xa0, 0x2d, 0xa2, 0x67, 0x70, 0x7c, 0x09, 0x47, 0xc9, 0x7c, 0x16,
+	0x06, 0xab, 0x8f, 0xb1, 0x6e, 0xee, 0xa5, 0xa2, 0x0d, 0xd0, 0xfe, 0xbd, 0x04, 0x75, 0x2f, 0x8e,
+	0x26, 0x3a, 0x49, 0x76, 0x67, 0xf4, 0xa0, 0xb6, 0xd5, 0x8a, 0x86, 0x9f, 0xf4, 0x22, 0x5a, 0xea,
+	0x74, 0x4a, 0xf9, 0x0a, 0x75, 0x72, 0x93, 0x05, 0x2e, 0x5e, 0x12, 0xe3, 0xaf, 0xe1, 0xe4, 0x53,
+	0xb0, 0x98, 0x4f, 0x03, 0x83, 0x5a, 0xd1, 0x34, 0x9b, 0xbf, 0x2f, 0x9e, 0xa1, 0xed, 0x3b, 0x38,
+	0x7b, 0xc9, 0x43, 0x82, 0x7f, 0x84, 0xca, 0x56, 0x5f, 0x93, 0xf1, 0xf5, 0xdb, 0xf2, 0xd5, 0x65,
+	0xb6, 0x9b, 0xa4, 0xf3, 0x52, 0x91, 0xd8, 0xa9, 0x68, 0xf7, 0xa0, 0xbc, 0x9d, 0xea, 0x3d, 0x1c,
+	0xee, 0xf6, 0xba, 0x28, 0x7a, 0x6d, 0xa9, 0x48, 0xd6, 0xa8, 0x50, 0xb6, 0x29, 0x54, 0x3f, 0x63,
+	0x71, 0x03, 0x0e, 0x1e, 0x74, 0x30, 0xd5, 0x71, 0xbe, 0xf8, 0xfc, 0x09, 0x37, 0xe1, 0x70, 0x19,
+	0x3c, 0x2d, 0xa2, 0x60, 0x9a, 0x2f, 0xbb, 0x78, 0x6c, 0xff, 0x59, 0x82, 0x86, 0xf5, 0x10, 0xcc,
+	0xc3, 0x49, 0x34, 0xd5, 0x59, 0x17, 0x2f, 0xa3, 0xf0, 0x0f, 0xd0, 0x9a, 0x14, 0x8c, 0x5a, 0xdf,
+	0x8f, 0xa2, 0x4f, 0x36, 0xa0, 0xb9, 0x56, 0x78, 0xb9, 0xa0, 0xa8, 0xfe, 0x0e, 0x0e, 0x32, 0x6b,
+	0xe9, 0xc4, 0xf2, 0xd5, 0x57, 0x45, 0xa6, 0xf5, 0x34, 0x1a, 0x4e, 0xa3, 0x38, 0xd1, 0xd3, 0x3c,
+	0x59, 0x2e, 0x6f, 0xff, 0x51, 0x82, 0xf3, 0xff, 0xd0, 0xe0, 0xef, 0xe1, 0xe2, 0xb3, 0x8b, 0xfa,
+	0xcc, 0xd1, 0x79, 0x21, 0x10, 0x39, 0xbf, 0x31, 0x54, 0xd1, 0x59, 0xb7, 0x47, 0x1d, 0xae, 0x92,
+	0xe6, 0x5e, 0xba, 0xea, 0x5a, 0x61, 0x8b, 0x6e, 0x38, 0xb1, 0x23, 0x7c, 0xf7, 0xd7, 0x3e, 0x20,
+	0xf9, 0xdb, 0x68, 0xe7, 0x76, 0xe0, 0x23, 0xd8, 0x1f, 0x11, 0xd7, 0xb1, 0xd1, 0x2b, 0x8c, 0xa0,
+	0xc2, 0x1c, 0x57, 0x51, 0x36, 0xa2, 0x2e, 0xf7, 0x28, 0x2a, 0xe1, 0x53, 0x28, 0xf7, 0x88, 0xad,
+	0x3c, 0x72, 0xe7, 0x72, 0x62, 0xa3, 0x3d, 0x7c, 0x06, 0x55, 0x03, 0x58, 0x7c, 0x30, 0xe0, 0x4c,
+	0xf5, 0x29, 0xb1, 0xa9, 0x40, 0xaf, 0xf1, 0x05, 0x9c, 0xa5, 0xb0, 0xa0, 0x44, 0x72, 0xa1, 0x7c,
+	0xe7, 0x86, 0x11, 0x39, 0x14, 0x14, 0xfd, 0x0f, 0xbf, 0x81, 0x4b, 0x87, 0xa5, 0x13, 0x14, 0x65,
+	0x36, 0x17, 0x3e, 0x15, 0x4a, 0x0a, 0xc2, 0x7c, 0x62, 0x49, 0x87, 0x33, 0xb4, 0x8f, 0xbf, 0x84,
+	0x56, 0xa1, 0xb0, 0x38, 0xbb, 0x76, 0x6e, 0x76, 0xf8, 0x03, 0xdc, 0x82, 0xc6, 0x90, 0xf9, 0x43,
+	0xcf, 0xe3, 0x42, 0x52, 0x5b, 0xc9, 0xf1, 0xda, 0xcf, 0x61, 0xe1, 0xc7, 0x13, 0xdc, 0xe3, 0x3e,
+	0x71, 0x95, 0x1c, 0x3b, 0x36, 0xfa, 0x3f, 0xc6, 0x70, 0x62, 0x0f, 0x3d, 0xd7, 0xb1, 0x88, 0xa4,
+	0x19, 0x76, 0x64, 0xc6, 0xe4, 0x06, 0x06, 0x94, 0x49, 0xe5, 0x71, 0xd7, 0xb1, 0xee, 0xd4, 0x35,
+	0x71, 0x5c, 0x63, 0x14, 0x70, 0x03, 0xf0, 0x60, 0x64, 0x59, 0x4a, 0x50, 0x92, 0x19, 0x71, 0x1d,
+	0x4b, 0xa2, 0xb2, 0xc9, 0xe6, 0xf5, 0x09, 0x93, 0x7c, 0xf0, 0x8c, 0xaa, 0xe0, 0x1a, 0x9c, 0x0e,
+	0xd9, 0x07, 0xc6, 0x6f, 0x99, 0x71, 0x25, 0xef, 0x3c, 0x8a, 0x8e, 0x8d, 0x5d, 0x49, 0xc4, 0x0d,
+	0x95, 0xca, 0xea, 0x13, 0x87, 0x29, 0xc6, 0xa5, 0xba, 0xe6, 0x43, 0x66, 0xa3, 0x13, 0x5c, 0x07,
+	0x34, 0x20, 0xc2, 0xef, 0xa7, 0x4e, 0x15, 0x15, 0x82, 0x0b, 0x74, 0x5a, 0xec, 0x5d, 0x8e, 0xf3,
+	0xc8, 0xc8, 0xc4, 0xa2, 0x63, 0xcf, 0x11, 0xd4, 0xce, 0x9a, 0x58, 0xdc, 0xa6, 0xa8, 0x6a, 0x22,
+	0xac, 0x1f, 0xd5, 0x88, 0x0a, 0xdf, 0xe1, 0x6c, 0xe3, 0x07, 0xe3, 0x26, 0xd4, 0xcd, 0x36, 0xb2,
+	0x63, 0x51, 0x74, 0x2c, 0x29, 0x33, 0x12, 0x54, 0x33, 0xe1, 0xd2, 0x03, 0xea, 0x13, 0xc6, 0xa8,
+	0x5b, 0x1c, 0x5c, 0xbd, 0xa8, 0x10, 0xd4, 0xf7, 0x38, 0xf3, 0xe9, 0x7a, 0xb3, 0x67, 0xf8, 0x18,
+	0x8e, 0x52, 0xe6, 0xd6, 0xa7, 0x12, 0x35, 0x8c, 0x73, 0xc7, 0x75, 0xe9, 0x0d, 0x71, 0xd5, 0xad,
+	0x70, 0x24, 0x35, 0xe8, 0x79, 0x8a, 0xe6, 0x47, 0xb7, 0x46, 0x9b, 0x18, 0xc3, 0xb1, 0x09, 0x9d,
+	0xe2, 0x44, 0x52, 0x1b, 0xfd, 0x5d, 0xc2, 0x17, 0x50, 0x2f, 0x94, 0x5c, 0xf6, 0xa9, 0x30, 0xbb,
+	0xf4, 0x39, 0x43, 0xff, 0x94, 0xde, 0x51, 0xa8, 0x0c, 0xf4, 0x2a, 0xb0, 0x83, 0x55, 0xf0, 0x41,
+	0x3f, 0x25, 0xc6, 0x53, 0x5e, 0x6a, 0xe2, 0x79, 0x44, 0x90, 0x01, 0x95, 0x54, 0xa0, 0x57, 0xf8,
+	0x0b, 0x38, 0x7f, 0x89, 0x51, 0xa3, 0x2b, 0x54, 0xea, 0x4d, 0xa0, 0x1d, 0xc5, 0xb3, 0xce, 0xc3,
+	0xd3, 0x52, 0xc7, 0x0b, 0x3d, 0x9d, 0xe9, 0xb8, 0xf3, 0x4b, 0x70, 0x1f, 0xcf, 0x27, 0xc5, 0x1f,
+	0xc3, 0x7c, 0x1e, 0x7a, 0x78, 0xeb, 0x5d, 0xe3, 0x05, 0x93, 0x5f, 0x83, 0x99, 0xfe, 0xe9, 0x9b,
+	0xd9, 0x7c, 0xf5, 0xf0, 0xf1, 0xde, 0xbc, 0x75, 0xbb, 0x5b, 0xe5, 0xdd, 0xac, 0xbc, 0x9b, 0x95,
+	0x77, 0x4d, 0xf9, 0x7d, 0xf6, 0xad, 0x79, 0xff, 0xef, 0x00, 0x6f, 0x23, 0x89, 0xb8, 0x8c, 0x06,
+	0x00, 0x00,
 }
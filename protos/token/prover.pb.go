@@ -41,7 +41,7 @@ func (m *TokenToIssue) Reset()         { *m = TokenToIssue{} }
 func (m *TokenToIssue) String() string { return proto.CompactTextString(m) }
 func (*TokenToIssue) ProtoMessage()    {}
 func (*TokenToIssue) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_4e2f2c6c1ca6a17e, []int{0}
+	return fileDescriptor_prover_271dcd6644346dfe, []int{0}
 }
 func (m *TokenToIssue) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TokenToIssue.Unmarshal(m, b)
@@ -97,7 +97,7 @@ func (m *RecipientTransferShare) Reset()         { *m = RecipientTransferShare{}
 func (m *RecipientTransferShare) String() string { return proto.CompactTextString(m) }
 func (*RecipientTransferShare) ProtoMessage()    {}
 func (*RecipientTransferShare) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_4e2f2c6c1ca6a17e, []int{1}
+	return fileDescriptor_prover_271dcd6644346dfe, []int{1}
 }
 func (m *RecipientTransferShare) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RecipientTransferShare.Unmarshal(m, b)
@@ -148,7 +148,7 @@ func (m *TokenOutput) Reset()         { *m = TokenOutput{} }
 func (m *TokenOutput) String() string { return proto.CompactTextString(m) }
 func (*TokenOutput) ProtoMessage()    {}
 func (*TokenOutput) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_4e2f2c6c1ca6a17e, []int{2}
+	return fileDescriptor_prover_271dcd6644346dfe, []int{2}
 }
 func (m *TokenOutput) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TokenOutput.Unmarshal(m, b)
@@ -201,7 +201,7 @@ func (m *UnspentTokens) Reset()         { *m = UnspentTokens{} }
 func (m *UnspentTokens) String() string { return proto.CompactTextString(m) }
 func (*UnspentTokens) ProtoMessage()    {}
 func (*UnspentTokens) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_4e2f2c6c1ca6a17e, []int{3}
+	return fileDescriptor_prover_271dcd6644346dfe, []int{3}
 }
 func (m *UnspentTokens) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UnspentTokens.Unmarshal(m, b)
@@ -240,7 +240,7 @@ func (m *ListRequest) Reset()         { *m = ListRequest{} }
 func (m *ListRequest) String() string { return proto.CompactTextString(m) }
 func (*ListRequest) ProtoMessage()    {}
 func (*ListRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_4e2f2c6c1ca6a17e, []int{4}
+	return fileDescriptor_prover_271dcd6644346dfe, []int{4}
 }
 func (m *ListRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListRequest.Unmarshal(m, b)
@@ -283,7 +283,7 @@ func (m *ImportRequest) Reset()         { *m = ImportRequest{} }
 func (m *ImportRequest) String() string { return proto.CompactTextString(m) }
 func (*ImportRequest) ProtoMessage()    {}
 func (*ImportRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_4e2f2c6c1ca6a17e, []int{5}
+	return fileDescriptor_prover_271dcd6644346dfe, []int{5}
 }
 func (m *ImportRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ImportRequest.Unmarshal(m, b)
@@ -331,7 +331,7 @@ func (m *TransferRequest) Reset()         { *m = TransferRequest{} }
 func (m *TransferRequest) String() string { return proto.CompactTextString(m) }
 func (*TransferRequest) ProtoMessage()    {}
 func (*TransferRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_4e2f2c6c1ca6a17e, []int{6}
+	return fileDescriptor_prover_271dcd6644346dfe, []int{6}
 }
 func (m *TransferRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TransferRequest.Unmarshal(m, b)
@@ -390,7 +390,7 @@ func (m *RedeemRequest) Reset()         { *m = RedeemRequest{} }
 func (m *RedeemRequest) String() string { return proto.CompactTextString(m) }
 func (*RedeemRequest) ProtoMessage()    {}
 func (*RedeemRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_4e2f2c6c1ca6a17e, []int{7}
+	return fileDescriptor_prover_271dcd6644346dfe, []int{7}
 }
 func (m *RedeemRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RedeemRequest.Unmarshal(m, b)
@@ -449,7 +449,7 @@ func (m *ExpectationRequest) Reset()         { *m = ExpectationRequest{} }
 func (m *ExpectationRequest) String() string { return proto.CompactTextString(m) }
 func (*ExpectationRequest) ProtoMessage()    {}
 func (*ExpectationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_4e2f2c6c1ca6a17e, []int{8}
+	return fileDescriptor_prover_271dcd6644346dfe, []int{8}
 }
 func (m *ExpectationRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ExpectationRequest.Unmarshal(m, b)
@@ -515,7 +515,7 @@ func (m *Header) Reset()         { *m = Header{} }
 func (m *Header) String() string { return proto.CompactTextString(m) }
 func (*Header) ProtoMessage()    {}
 func (*Header) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_4e2f2c6c1ca6a17e, []int{9}
+	return fileDescriptor_prover_271dcd6644346dfe, []int{9}
 }
 func (m *Header) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Header.Unmarshal(m, b)
@@ -582,6 +582,8 @@ type Command struct {
 	//	*Command_ListRequest
 	//	*Command_RedeemRequest
 	//	*Command_ExpectationRequest
+	//	*Command_ExchangeRequest
+	//	*Command_SupplyRequest
 	Payload              isCommand_Payload `protobuf_oneof:"payload"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
 	XXX_unrecognized     []byte            `json:"-"`
@@ -592,7 +594,7 @@ func (m *Command) Reset()         { *m = Command{} }
 func (m *Command) String() string { return proto.CompactTextString(m) }
 func (*Command) ProtoMessage()    {}
 func (*Command) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_4e2f2c6c1ca6a17e, []int{10}
+	return fileDescriptor_prover_271dcd6644346dfe, []int{10}
 }
 func (m *Command) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Command.Unmarshal(m, b)
@@ -643,6 +645,14 @@ type Command_ExpectationRequest struct {
 	ExpectationRequest *ExpectationRequest `protobuf:"bytes,6,opt,name=expectation_request,json=expectationRequest,proto3,oneof"`
 }
 
+type Command_ExchangeRequest struct {
+	ExchangeRequest *ExchangeRequest `protobuf:"bytes,7,opt,name=exchange_request,json=exchangeRequest,proto3,oneof"`
+}
+
+type Command_SupplyRequest struct {
+	SupplyRequest *SupplyRequest `protobuf:"bytes,8,opt,name=supply_request,json=supplyRequest,proto3,oneof"`
+}
+
 func (*Command_ImportRequest) isCommand_Payload() {}
 
 func (*Command_TransferRequest) isCommand_Payload() {}
@@ -653,6 +663,10 @@ func (*Command_RedeemRequest) isCommand_Payload() {}
 
 func (*Command_ExpectationRequest) isCommand_Payload() {}
 
+func (*Command_ExchangeRequest) isCommand_Payload() {}
+
+func (*Command_SupplyRequest) isCommand_Payload() {}
+
 func (m *Command) GetPayload() isCommand_Payload {
 	if m != nil {
 		return m.Payload
@@ -695,6 +709,20 @@ func (m *Command) GetExpectationRequest() *ExpectationRequest {
 	return nil
 }
 
+func (m *Command) GetExchangeRequest() *ExchangeRequest {
+	if x, ok := m.GetPayload().(*Command_ExchangeRequest); ok {
+		return x.ExchangeRequest
+	}
+	return nil
+}
+
+func (m *Command) GetSupplyRequest() *SupplyRequest {
+	if x, ok := m.GetPayload().(*Command_SupplyRequest); ok {
+		return x.SupplyRequest
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*Command) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _Command_OneofMarshaler, _Command_OneofUnmarshaler, _Command_OneofSizer, []interface{}{
@@ -703,6 +731,8 @@ func (*Command) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error
 		(*Command_ListRequest)(nil),
 		(*Command_RedeemRequest)(nil),
 		(*Command_ExpectationRequest)(nil),
+		(*Command_ExchangeRequest)(nil),
+		(*Command_SupplyRequest)(nil),
 	}
 }
 
@@ -735,6 +765,16 @@ func _Command_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.ExpectationRequest); err != nil {
 			return err
 		}
+	case *Command_ExchangeRequest:
+		b.EncodeVarint(7<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ExchangeRequest); err != nil {
+			return err
+		}
+	case *Command_SupplyRequest:
+		b.EncodeVarint(8<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.SupplyRequest); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("Command.Payload has unexpected type %T", x)
@@ -785,6 +825,22 @@ func _Command_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer
 		err := b.DecodeMessage(msg)
 		m.Payload = &Command_ExpectationRequest{msg}
 		return true, err
+	case 7: // payload.exchange_request
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ExchangeRequest)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Command_ExchangeRequest{msg}
+		return true, err
+	case 8: // payload.supply_request
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(SupplyRequest)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Command_SupplyRequest{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -819,6 +875,16 @@ func _Command_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *Command_ExchangeRequest:
+		s := proto.Size(x.ExchangeRequest)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Command_SupplyRequest:
+		s := proto.Size(x.SupplyRequest)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -841,7 +907,7 @@ func (m *SignedCommand) Reset()         { *m = SignedCommand{} }
 func (m *SignedCommand) String() string { return proto.CompactTextString(m) }
 func (*SignedCommand) ProtoMessage()    {}
 func (*SignedCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_4e2f2c6c1ca6a17e, []int{11}
+	return fileDescriptor_prover_271dcd6644346dfe, []int{11}
 }
 func (m *SignedCommand) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SignedCommand.Unmarshal(m, b)
@@ -895,7 +961,7 @@ func (m *CommandResponseHeader) Reset()         { *m = CommandResponseHeader{} }
 func (m *CommandResponseHeader) String() string { return proto.CompactTextString(m) }
 func (*CommandResponseHeader) ProtoMessage()    {}
 func (*CommandResponseHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_4e2f2c6c1ca6a17e, []int{12}
+	return fileDescriptor_prover_271dcd6644346dfe, []int{12}
 }
 func (m *CommandResponseHeader) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandResponseHeader.Unmarshal(m, b)
@@ -951,7 +1017,7 @@ func (m *Error) Reset()         { *m = Error{} }
 func (m *Error) String() string { return proto.CompactTextString(m) }
 func (*Error) ProtoMessage()    {}
 func (*Error) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_4e2f2c6c1ca6a17e, []int{13}
+	return fileDescriptor_prover_271dcd6644346dfe, []int{13}
 }
 func (m *Error) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Error.Unmarshal(m, b)
@@ -995,6 +1061,7 @@ type CommandResponse struct {
 	//	*CommandResponse_Err
 	//	*CommandResponse_TokenTransaction
 	//	*CommandResponse_UnspentTokens
+	//	*CommandResponse_TokenSupplies
 	Payload              isCommandResponse_Payload `protobuf_oneof:"payload"`
 	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
 	XXX_unrecognized     []byte                    `json:"-"`
@@ -1005,7 +1072,7 @@ func (m *CommandResponse) Reset()         { *m = CommandResponse{} }
 func (m *CommandResponse) String() string { return proto.CompactTextString(m) }
 func (*CommandResponse) ProtoMessage()    {}
 func (*CommandResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_4e2f2c6c1ca6a17e, []int{14}
+	return fileDescriptor_prover_271dcd6644346dfe, []int{14}
 }
 func (m *CommandResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandResponse.Unmarshal(m, b)
@@ -1048,12 +1115,18 @@ type CommandResponse_UnspentTokens struct {
 	UnspentTokens *UnspentTokens `protobuf:"bytes,4,opt,name=unspent_tokens,json=unspentTokens,proto3,oneof"`
 }
 
+type CommandResponse_TokenSupplies struct {
+	TokenSupplies *TokenSupplies `protobuf:"bytes,5,opt,name=token_supplies,json=tokenSupplies,proto3,oneof"`
+}
+
 func (*CommandResponse_Err) isCommandResponse_Payload() {}
 
 func (*CommandResponse_TokenTransaction) isCommandResponse_Payload() {}
 
 func (*CommandResponse_UnspentTokens) isCommandResponse_Payload() {}
 
+func (*CommandResponse_TokenSupplies) isCommandResponse_Payload() {}
+
 func (m *CommandResponse) GetPayload() isCommandResponse_Payload {
 	if m != nil {
 		return m.Payload
@@ -1082,12 +1155,20 @@ func (m *CommandResponse) GetUnspentTokens() *UnspentTokens {
 	return nil
 }
 
+func (m *CommandResponse) GetTokenSupplies() *TokenSupplies {
+	if x, ok := m.GetPayload().(*CommandResponse_TokenSupplies); ok {
+		return x.TokenSupplies
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*CommandResponse) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _CommandResponse_OneofMarshaler, _CommandResponse_OneofUnmarshaler, _CommandResponse_OneofSizer, []interface{}{
 		(*CommandResponse_Err)(nil),
 		(*CommandResponse_TokenTransaction)(nil),
 		(*CommandResponse_UnspentTokens)(nil),
+		(*CommandResponse_TokenSupplies)(nil),
 	}
 }
 
@@ -1110,6 +1191,11 @@ func _CommandResponse_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.UnspentTokens); err != nil {
 			return err
 		}
+	case *CommandResponse_TokenSupplies:
+		b.EncodeVarint(5<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.TokenSupplies); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("CommandResponse.Payload has unexpected type %T", x)
@@ -1144,6 +1230,14 @@ func _CommandResponse_OneofUnmarshaler(msg proto.Message, tag, wire int, b *prot
 		err := b.DecodeMessage(msg)
 		m.Payload = &CommandResponse_UnspentTokens{msg}
 		return true, err
+	case 5: // payload.token_supplies
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(TokenSupplies)
+		err := b.DecodeMessage(msg)
+		m.Payload = &CommandResponse_TokenSupplies{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -1168,6 +1262,11 @@ func _CommandResponse_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *CommandResponse_TokenSupplies:
+		s := proto.Size(x.TokenSupplies)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -1190,7 +1289,7 @@ func (m *SignedCommandResponse) Reset()         { *m = SignedCommandResponse{} }
 func (m *SignedCommandResponse) String() string { return proto.CompactTextString(m) }
 func (*SignedCommandResponse) ProtoMessage()    {}
 func (*SignedCommandResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_4e2f2c6c1ca6a17e, []int{15}
+	return fileDescriptor_prover_271dcd6644346dfe, []int{15}
 }
 func (m *SignedCommandResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SignedCommandResponse.Unmarshal(m, b)
@@ -1224,6 +1323,258 @@ func (m *SignedCommandResponse) GetSignature() []byte {
 	return nil
 }
 
+// ExchangeRequest is used to request a transaction exchanging tokens between several owners
+type ExchangeRequest struct {
+	Credential []byte `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
+	// The contributions of the owners taking part in the exchange
+	Contributions        []*ExchangeContribution `protobuf:"bytes,2,rep,name=contributions,proto3" json:"contributions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *ExchangeRequest) Reset()         { *m = ExchangeRequest{} }
+func (m *ExchangeRequest) String() string { return proto.CompactTextString(m) }
+func (*ExchangeRequest) ProtoMessage()    {}
+func (*ExchangeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_271dcd6644346dfe, []int{16}
+}
+func (m *ExchangeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExchangeRequest.Unmarshal(m, b)
+}
+func (m *ExchangeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExchangeRequest.Marshal(b, m, deterministic)
+}
+func (dst *ExchangeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExchangeRequest.Merge(dst, src)
+}
+func (m *ExchangeRequest) XXX_Size() int {
+	return xxx_messageInfo_ExchangeRequest.Size(m)
+}
+func (m *ExchangeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExchangeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExchangeRequest proto.InternalMessageInfo
+
+func (m *ExchangeRequest) GetCredential() []byte {
+	if m != nil {
+		return m.Credential
+	}
+	return nil
+}
+
+func (m *ExchangeRequest) GetContributions() []*ExchangeContribution {
+	if m != nil {
+		return m.Contributions
+	}
+	return nil
+}
+
+// ExchangeContribution specifies the inputs an owner contributes to an exchange
+// and the outputs created in their place
+type ExchangeContribution struct {
+	// The serialized identity owning the inputs, which signs the exchange
+	Owner []byte `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	// The inputs contributed by the owner
+	TokenIds []*TokenId `protobuf:"bytes,2,rep,name=token_ids,json=tokenIds,proto3" json:"token_ids,omitempty"`
+	// The outputs created in place of the inputs
+	Shares               []*ExchangeShare `protobuf:"bytes,3,rep,name=shares,proto3" json:"shares,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ExchangeContribution) Reset()         { *m = ExchangeContribution{} }
+func (m *ExchangeContribution) String() string { return proto.CompactTextString(m) }
+func (*ExchangeContribution) ProtoMessage()    {}
+func (*ExchangeContribution) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_271dcd6644346dfe, []int{17}
+}
+func (m *ExchangeContribution) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExchangeContribution.Unmarshal(m, b)
+}
+func (m *ExchangeContribution) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExchangeContribution.Marshal(b, m, deterministic)
+}
+func (dst *ExchangeContribution) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExchangeContribution.Merge(dst, src)
+}
+func (m *ExchangeContribution) XXX_Size() int {
+	return xxx_messageInfo_ExchangeContribution.Size(m)
+}
+func (m *ExchangeContribution) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExchangeContribution.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExchangeContribution proto.InternalMessageInfo
+
+func (m *ExchangeContribution) GetOwner() []byte {
+	if m != nil {
+		return m.Owner
+	}
+	return nil
+}
+
+func (m *ExchangeContribution) GetTokenIds() []*TokenId {
+	if m != nil {
+		return m.TokenIds
+	}
+	return nil
+}
+
+func (m *ExchangeContribution) GetShares() []*ExchangeShare {
+	if m != nil {
+		return m.Shares
+	}
+	return nil
+}
+
+// ExchangeShare specifies the quantity of a token type that a recipient gets in an exchange
+type ExchangeShare struct {
+	// Recipient refers to the owner of the output
+	Recipient *TokenOwner `protobuf:"bytes,1,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	// Type is the type of the token
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// Quantity is the number of tokens of the type
+	Quantity             uint64   `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExchangeShare) Reset()         { *m = ExchangeShare{} }
+func (m *ExchangeShare) String() string { return proto.CompactTextString(m) }
+func (*ExchangeShare) ProtoMessage()    {}
+func (*ExchangeShare) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_271dcd6644346dfe, []int{18}
+}
+func (m *ExchangeShare) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExchangeShare.Unmarshal(m, b)
+}
+func (m *ExchangeShare) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExchangeShare.Marshal(b, m, deterministic)
+}
+func (dst *ExchangeShare) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExchangeShare.Merge(dst, src)
+}
+func (m *ExchangeShare) XXX_Size() int {
+	return xxx_messageInfo_ExchangeShare.Size(m)
+}
+func (m *ExchangeShare) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExchangeShare.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExchangeShare proto.InternalMessageInfo
+
+func (m *ExchangeShare) GetRecipient() *TokenOwner {
+	if m != nil {
+		return m.Recipient
+	}
+	return nil
+}
+
+func (m *ExchangeShare) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *ExchangeShare) GetQuantity() uint64 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+// SupplyRequest is used to query the quantities of token types that have been issued and redeemed
+type SupplyRequest struct {
+	Credential []byte `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
+	// The token types to query, all the types if empty
+	Types                []string `protobuf:"bytes,2,rep,name=types,proto3" json:"types,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SupplyRequest) Reset()         { *m = SupplyRequest{} }
+func (m *SupplyRequest) String() string { return proto.CompactTextString(m) }
+func (*SupplyRequest) ProtoMessage()    {}
+func (*SupplyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_271dcd6644346dfe, []int{19}
+}
+func (m *SupplyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SupplyRequest.Unmarshal(m, b)
+}
+func (m *SupplyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SupplyRequest.Marshal(b, m, deterministic)
+}
+func (dst *SupplyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SupplyRequest.Merge(dst, src)
+}
+func (m *SupplyRequest) XXX_Size() int {
+	return xxx_messageInfo_SupplyRequest.Size(m)
+}
+func (m *SupplyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SupplyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SupplyRequest proto.InternalMessageInfo
+
+func (m *SupplyRequest) GetCredential() []byte {
+	if m != nil {
+		return m.Credential
+	}
+	return nil
+}
+
+func (m *SupplyRequest) GetTypes() []string {
+	if m != nil {
+		return m.Types
+	}
+	return nil
+}
+
+// TokenSupplies is used to hold the supplies of token types
+type TokenSupplies struct {
+	Supplies             []*TokenSupply `protobuf:"bytes,1,rep,name=supplies,proto3" json:"supplies,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *TokenSupplies) Reset()         { *m = TokenSupplies{} }
+func (m *TokenSupplies) String() string { return proto.CompactTextString(m) }
+func (*TokenSupplies) ProtoMessage()    {}
+func (*TokenSupplies) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_271dcd6644346dfe, []int{20}
+}
+func (m *TokenSupplies) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenSupplies.Unmarshal(m, b)
+}
+func (m *TokenSupplies) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenSupplies.Marshal(b, m, deterministic)
+}
+func (dst *TokenSupplies) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenSupplies.Merge(dst, src)
+}
+func (m *TokenSupplies) XXX_Size() int {
+	return xxx_messageInfo_TokenSupplies.Size(m)
+}
+func (m *TokenSupplies) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenSupplies.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenSupplies proto.InternalMessageInfo
+
+func (m *TokenSupplies) GetSupplies() []*TokenSupply {
+	if m != nil {
+		return m.Supplies
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*TokenToIssue)(nil), "token.TokenToIssue")
 	proto.RegisterType((*RecipientTransferShare)(nil), "token.RecipientTransferShare")
@@ -1241,6 +1592,11 @@ func init() {
 	proto.RegisterType((*Error)(nil), "token.Error")
 	proto.RegisterType((*CommandResponse)(nil), "token.CommandResponse")
 	proto.RegisterType((*SignedCommandResponse)(nil), "token.SignedCommandResponse")
+	proto.RegisterType((*ExchangeRequest)(nil), "token.ExchangeRequest")
+	proto.RegisterType((*ExchangeContribution)(nil), "token.ExchangeContribution")
+	proto.RegisterType((*ExchangeShare)(nil), "token.ExchangeShare")
+	proto.RegisterType((*SupplyRequest)(nil), "token.SupplyRequest")
+	proto.RegisterType((*TokenSupplies)(nil), "token.TokenSupplies")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1323,68 +1679,78 @@ var _Prover_serviceDesc = grpc.ServiceDesc{
 	Metadata: "token/prover.proto",
 }
 
-func init() { proto.RegisterFile("token/prover.proto", fileDescriptor_prover_4e2f2c6c1ca6a17e) }
-
-var fileDescriptor_prover_4e2f2c6c1ca6a17e = []byte{
-	// 953 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0x4b, 0x6f, 0x23, 0x45,
-	0x10, 0xf6, 0xc4, 0x8f, 0xc4, 0x65, 0x3b, 0xc9, 0xf6, 0xbe, 0x06, 0x2b, 0x0b, 0x66, 0x10, 0x52,
-	0xb4, 0x80, 0x2d, 0x05, 0x10, 0xa0, 0x68, 0x2f, 0x1b, 0xed, 0xe2, 0x48, 0x2b, 0x91, 0xed, 0x35,
-	0x17, 0x24, 0x64, 0x75, 0x66, 0x2a, 0x76, 0x8b, 0xf1, 0xf4, 0x6c, 0x77, 0x0f, 0x90, 0x1f, 0xc0,
-	0x85, 0x03, 0x37, 0x6e, 0xfc, 0x0d, 0x7e, 0x1d, 0x17, 0xd4, 0x8f, 0xb1, 0x67, 0xbc, 0xd1, 0x2a,
-	0x80, 0xb8, 0x4d, 0x3d, 0xba, 0xea, 0xab, 0xaa, 0xaf, 0xba, 0x07, 0x88, 0x16, 0x3f, 0x60, 0x36,
-	0xc9, 0xa5, 0xf8, 0x11, 0xe5, 0x38, 0x97, 0x42, 0x0b, 0xd2, 0xb6, 0xba, 0xe1, 0x7b, 0x0b, 0x21,
-	0x16, 0x29, 0x4e, 0xac, 0xf2, 0xb2, 0xb8, 0x9a, 0x68, 0xbe, 0x42, 0xa5, 0xd9, 0x2a, 0x77, 0x7e,
-	0xc3, 0xd0, 0x9d, 0xc5, 0x9f, 0x73, 0x8c, 0x35, 0xd3, 0x5c, 0x64, 0xca, 0x5b, 0x1e, 0x3a, 0x8b,
-	0x96, 0x2c, 0x53, 0x2c, 0x36, 0x16, 0x67, 0x88, 0x04, 0xf4, 0x67, 0xc6, 0x34, 0x13, 0xe7, 0x4a,
-	0x15, 0x48, 0x26, 0xd0, 0x95, 0x18, 0xf3, 0x9c, 0x63, 0xa6, 0xc3, 0x60, 0x14, 0x1c, 0xf7, 0x4e,
-	0xee, 0x8c, 0xed, 0xe1, 0xb1, 0xf5, 0xfb, 0xe6, 0xa7, 0x0c, 0x25, 0xdd, 0xf8, 0x10, 0x02, 0x2d,
-	0x7d, 0x9d, 0x63, 0xb8, 0x33, 0x0a, 0x8e, 0xbb, 0xd4, 0x7e, 0x93, 0x21, 0xec, 0xbd, 0x2e, 0x58,
-	0xa6, 0xb9, 0xbe, 0x0e, 0x9b, 0xa3, 0xe0, 0xb8, 0x45, 0xd7, 0x72, 0x84, 0xf0, 0x80, 0x96, 0x87,
-	0x67, 0x06, 0xce, 0x15, 0xca, 0x57, 0x4b, 0x26, 0xff, 0x45, 0xea, 0x6a, 0x9a, 0x9d, 0xad, 0x34,
-	0xdf, 0x43, 0xcf, 0x1d, 0x2a, 0x74, 0x5e, 0x68, 0xf2, 0x2e, 0xec, 0xf0, 0xc4, 0x07, 0xdd, 0xaf,
-	0x06, 0x3d, 0x4f, 0xe8, 0x0e, 0x4f, 0xfe, 0x71, 0x15, 0xa7, 0x30, 0xf8, 0x36, 0x53, 0xb9, 0xa9,
-	0xc1, 0x44, 0x51, 0xe4, 0x31, 0x74, 0x6c, 0x54, 0x15, 0x06, 0xa3, 0xe6, 0x71, 0xef, 0x84, 0xd4,
-	0x90, 0x5b, 0x10, 0xd4, 0x7b, 0x44, 0x9f, 0x40, 0xef, 0x05, 0x57, 0x9a, 0xe2, 0xeb, 0x02, 0x95,
-	0xc1, 0x06, 0xb1, 0xc4, 0x04, 0x33, 0xcd, 0x59, 0x6a, 0x31, 0xf6, 0x69, 0x45, 0x13, 0xa5, 0x30,
-	0x38, 0x5f, 0xe5, 0x42, 0xde, 0xf6, 0x00, 0x39, 0x85, 0x03, 0x97, 0x69, 0xae, 0xc5, 0x9c, 0x9b,
-	0xb1, 0x86, 0x3b, 0x16, 0xd4, 0xdd, 0x2a, 0x28, 0x3f, 0x71, 0x3a, 0x70, 0xbe, 0x5e, 0x8c, 0x7e,
-	0x0f, 0xe0, 0xa0, 0x9c, 0xcb, 0x6d, 0x13, 0x7e, 0x04, 0x5d, 0x1b, 0x64, 0xce, 0x13, 0xe5, 0x53,
-	0x6d, 0x37, 0x79, 0x4f, 0xbb, 0x0f, 0x45, 0x3e, 0x87, 0x8e, 0x32, 0xf3, 0x56, 0x61, 0xd3, 0x7a,
-	0x3e, 0xf2, 0x9e, 0x37, 0xb3, 0x82, 0x7a, 0xe7, 0xe8, 0xd7, 0x00, 0x06, 0x14, 0x13, 0xc4, 0xd5,
-	0xff, 0x82, 0xea, 0x63, 0x20, 0xe5, 0x70, 0x4d, 0xd7, 0xa4, 0xcd, 0xe4, 0xc7, 0x7e, 0x58, 0x5a,
-	0x66, 0xc2, 0x21, 0x88, 0xfe, 0x08, 0x80, 0x3c, 0xdb, 0x6c, 0xd9, 0x6d, 0x11, 0x7d, 0x05, 0xbd,
-	0xca, 0x6e, 0x5a, 0xb2, 0xf5, 0x4e, 0x1e, 0x56, 0x31, 0x55, 0x83, 0x56, 0x7d, 0xeb, 0xc5, 0x34,
-	0xdf, 0x5e, 0x4c, 0xf4, 0x67, 0x00, 0x9d, 0x29, 0xb2, 0x04, 0x25, 0xf9, 0x12, 0xba, 0xeb, 0x5b,
-	0xc2, 0xf3, 0x7f, 0x38, 0x76, 0xf7, 0xc8, 0xb8, 0xbc, 0x47, 0xc6, 0xb3, 0xd2, 0x83, 0x6e, 0x9c,
-	0xc9, 0x23, 0x80, 0x78, 0xc9, 0xb2, 0x0c, 0xd3, 0x39, 0x4f, 0xfc, 0x62, 0x74, 0xbd, 0xe6, 0x3c,
-	0x21, 0xf7, 0xa0, 0x9d, 0x89, 0x2c, 0x46, 0xdb, 0xa3, 0x3e, 0x75, 0x02, 0x09, 0x61, 0x37, 0x96,
-	0xc8, 0xb4, 0x90, 0x61, 0xcb, 0xea, 0x4b, 0x91, 0x44, 0x30, 0xd0, 0xa9, 0x9a, 0xc7, 0x28, 0xf5,
-	0x7c, 0xc9, 0xd4, 0x32, 0x6c, 0x5b, 0x7b, 0x4f, 0xa7, 0xea, 0x0c, 0xa5, 0x9e, 0x32, 0xb5, 0x8c,
-	0x7e, 0x69, 0xc2, 0xee, 0x99, 0x58, 0xad, 0x58, 0x96, 0x90, 0x0f, 0xa1, 0xb3, 0xb4, 0x25, 0x78,
-	0xd4, 0x03, 0x5f, 0xad, 0xab, 0x8b, 0x7a, 0x23, 0x79, 0x02, 0xfb, 0xdc, 0x2e, 0xc7, 0x5c, 0xba,
-	0x21, 0xf8, 0xae, 0xde, 0xf3, 0xee, 0xb5, 0xcd, 0x99, 0x36, 0xe8, 0x80, 0xd7, 0x56, 0xe9, 0x0c,
-	0x0e, 0xb5, 0xa7, 0xdb, 0x3a, 0x40, 0xd3, 0x06, 0x78, 0x50, 0x76, 0xb7, 0xbe, 0x0b, 0xd3, 0x06,
-	0x3d, 0xd0, 0x5b, 0xeb, 0xf1, 0x05, 0xf4, 0x53, 0xae, 0x36, 0x08, 0x5a, 0x36, 0x40, 0x79, 0x03,
-	0x54, 0x56, 0x7d, 0xda, 0xa0, 0xbd, 0xb4, 0xb2, 0xf9, 0x4f, 0x60, 0xdf, 0x11, 0x6d, 0x7d, 0xb4,
-	0x5d, 0x03, 0x5f, 0xe3, 0xbb, 0x01, 0x2f, 0x6b, 0x0b, 0xf0, 0x02, 0xee, 0x56, 0x28, 0xb2, 0x8e,
-	0xd1, 0xb1, 0x31, 0xde, 0xf1, 0x31, 0xde, 0xa4, 0xe9, 0xb4, 0x41, 0x09, 0xbe, 0xa1, 0x7d, 0xda,
-	0x85, 0xdd, 0x9c, 0x5d, 0xa7, 0x82, 0x25, 0xd1, 0xd7, 0x30, 0x78, 0xc5, 0x17, 0x19, 0x26, 0xe5,
-	0x30, 0xcc, 0x58, 0xdd, 0xa7, 0x67, 0x75, 0x29, 0x92, 0x23, 0xe8, 0x2a, 0xbe, 0xc8, 0x98, 0x2e,
-	0xa4, 0xbb, 0x3d, 0xfb, 0x74, 0xa3, 0x88, 0x7e, 0x0b, 0xe0, 0xbe, 0x8f, 0x41, 0x51, 0xe5, 0x22,
-	0x53, 0xf8, 0x9f, 0x79, 0xf9, 0x3e, 0xf4, 0x7d, 0x72, 0xc7, 0x23, 0x97, 0xb4, 0xe7, 0x75, 0x86,
-	0x47, 0x55, 0x16, 0x36, 0x6b, 0x2c, 0x8c, 0x4e, 0xa1, 0xfd, 0x4c, 0x4a, 0x21, 0x8d, 0xcb, 0x0a,
-	0x95, 0x62, 0x0b, 0xb4, 0xd9, 0xbb, 0xb4, 0x14, 0x8d, 0xc5, 0xf7, 0xc1, 0x87, 0x5e, 0xb7, 0xe5,
-	0xaf, 0x00, 0x0e, 0xb6, 0xaa, 0x21, 0x9f, 0x6d, 0xd1, 0xf4, 0xc8, 0xb7, 0xfd, 0xc6, 0xaa, 0xd7,
-	0xac, 0x1d, 0x41, 0x13, 0xa5, 0xf4, 0x54, 0xed, 0x97, 0x93, 0x32, 0xc0, 0xa6, 0x0d, 0x6a, 0x4c,
-	0xe4, 0x39, 0xdc, 0x71, 0xfb, 0x5e, 0x79, 0xb2, 0x3d, 0x33, 0x6b, 0x17, 0xc6, 0x6c, 0x63, 0x9e,
-	0x36, 0xe8, 0xa1, 0xde, 0xd2, 0x19, 0x8a, 0x15, 0xee, 0xa1, 0x9a, 0xfb, 0xf7, 0xa9, 0x55, 0xa3,
-	0x58, 0xed, 0x15, 0x33, 0x14, 0x2b, 0xaa, 0x8a, 0x2a, 0x29, 0x5e, 0xc2, 0xfd, 0x1a, 0x29, 0xd6,
-	0x2d, 0x18, 0xc2, 0x9e, 0xf4, 0xdf, 0x9e, 0x1d, 0x6b, 0xf9, 0xed, 0xf4, 0x38, 0xb9, 0x80, 0xce,
-	0x85, 0xfd, 0xcf, 0x21, 0xcf, 0x61, 0xff, 0x42, 0x8a, 0x18, 0x95, 0x2a, 0x29, 0x57, 0x02, 0xac,
-	0xe5, 0x1c, 0x1e, 0xdd, 0xa4, 0x2d, 0x91, 0x44, 0x8d, 0xa7, 0x2f, 0xe1, 0x03, 0x21, 0x17, 0xe3,
-	0xe5, 0x75, 0x8e, 0x32, 0xc5, 0x64, 0x81, 0x72, 0x7c, 0xc5, 0x2e, 0x25, 0x8f, 0x1d, 0xa9, 0x94,
-	0x3b, 0xfe, 0xdd, 0xe3, 0x05, 0xd7, 0xcb, 0xe2, 0x72, 0x1c, 0x8b, 0xd5, 0xa4, 0xe2, 0x3b, 0x71,
-	0xbe, 0xee, 0x07, 0x4b, 0x4d, 0xac, 0xef, 0x65, 0xc7, 0x4a, 0x9f, 0xfe, 0x1d, 0x00, 0x00, 0xff,
-	0xff, 0x5e, 0xe3, 0x52, 0xc2, 0x99, 0x09, 0x00, 0x00,
+func init() { proto.RegisterFile("token/prover.proto", fileDescriptor_prover_271dcd6644346dfe) }
+
+var fileDescriptor_prover_271dcd6644346dfe = []byte{
+	// 1107 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0x5b, 0x6f, 0x1b, 0xc5,
+	0x17, 0xf7, 0xfa, 0x96, 0xf8, 0xd8, 0xdb, 0xb4, 0xd3, 0xb4, 0xdd, 0xbf, 0xff, 0x29, 0x98, 0x45,
+	0x48, 0x51, 0x01, 0x5b, 0x0a, 0x20, 0x40, 0x11, 0x42, 0x34, 0x4a, 0x71, 0xa4, 0x4a, 0xa4, 0x13,
+	0xf3, 0x82, 0x84, 0xac, 0xcd, 0xee, 0xc4, 0x1e, 0xb1, 0xde, 0xd9, 0xce, 0xcc, 0x42, 0xf3, 0x09,
+	0x10, 0x3c, 0xf0, 0xc6, 0x1b, 0x5f, 0x83, 0x17, 0x3e, 0x1d, 0x9a, 0xcb, 0xae, 0x67, 0x9d, 0x88,
+	0xa4, 0xa0, 0xbe, 0xed, 0xb9, 0x5f, 0xe6, 0x77, 0xce, 0xcc, 0x02, 0x92, 0xec, 0x07, 0x92, 0x4d,
+	0x72, 0xce, 0x7e, 0x24, 0x7c, 0x9c, 0x73, 0x26, 0x19, 0xea, 0x68, 0xde, 0xf0, 0xed, 0x05, 0x63,
+	0x8b, 0x94, 0x4c, 0x34, 0xf3, 0xbc, 0xb8, 0x98, 0x48, 0xba, 0x22, 0x42, 0x46, 0xab, 0xdc, 0xe8,
+	0x0d, 0x03, 0x63, 0x4b, 0x5e, 0xe5, 0x24, 0x96, 0x91, 0xa4, 0x2c, 0x13, 0x56, 0xf2, 0xc8, 0x48,
+	0x24, 0x8f, 0x32, 0x11, 0xc5, 0x4a, 0x62, 0x04, 0x21, 0x83, 0xc1, 0x4c, 0x89, 0x66, 0xec, 0x44,
+	0x88, 0x82, 0xa0, 0x09, 0xf4, 0x38, 0x89, 0x69, 0x4e, 0x49, 0x26, 0x03, 0x6f, 0xe4, 0xed, 0xf7,
+	0x0f, 0xee, 0x8d, 0xb5, 0xf1, 0x58, 0xeb, 0x7d, 0xf3, 0x53, 0x46, 0x38, 0x5e, 0xeb, 0x20, 0x04,
+	0x6d, 0x79, 0x99, 0x93, 0xa0, 0x39, 0xf2, 0xf6, 0x7b, 0x58, 0x7f, 0xa3, 0x21, 0x6c, 0xbf, 0x2c,
+	0xa2, 0x4c, 0x52, 0x79, 0x19, 0xb4, 0x46, 0xde, 0x7e, 0x1b, 0x57, 0x74, 0x48, 0xe0, 0x21, 0x2e,
+	0x8d, 0x67, 0x2a, 0x9d, 0x0b, 0xc2, 0xcf, 0x96, 0x11, 0xff, 0x17, 0xa1, 0xdd, 0x30, 0xcd, 0x8d,
+	0x30, 0xdf, 0x43, 0xdf, 0x18, 0x15, 0x32, 0x2f, 0x24, 0x7a, 0x0b, 0x9a, 0x34, 0xb1, 0x4e, 0xef,
+	0xb8, 0x4e, 0x4f, 0x12, 0xdc, 0xa4, 0xc9, 0x6b, 0x57, 0x71, 0x08, 0xfe, 0xb7, 0x99, 0xc8, 0x55,
+	0x0d, 0xca, 0x8b, 0x40, 0x4f, 0xa0, 0xab, 0xbd, 0x8a, 0xc0, 0x1b, 0xb5, 0xf6, 0xfb, 0x07, 0xa8,
+	0x96, 0xb9, 0x4e, 0x02, 0x5b, 0x8d, 0xf0, 0x43, 0xe8, 0x3f, 0xa7, 0x42, 0x62, 0xf2, 0xb2, 0x20,
+	0x42, 0xe5, 0x06, 0x31, 0x27, 0x09, 0xc9, 0x24, 0x8d, 0x52, 0x9d, 0xe3, 0x00, 0x3b, 0x9c, 0x30,
+	0x05, 0xff, 0x64, 0x95, 0x33, 0x7e, 0x5b, 0x03, 0x74, 0x08, 0x3b, 0x26, 0xd2, 0x5c, 0xb2, 0x39,
+	0x55, 0xc7, 0x1a, 0x34, 0x75, 0x52, 0xf7, 0xdd, 0xa4, 0xec, 0x89, 0x63, 0xdf, 0xe8, 0x5a, 0x32,
+	0xfc, 0xdd, 0x83, 0x9d, 0xf2, 0x5c, 0x6e, 0x1b, 0xf0, 0x7d, 0xe8, 0x69, 0x27, 0x73, 0x9a, 0x08,
+	0x1b, 0x6a, 0xb3, 0xc9, 0xdb, 0xd2, 0x7c, 0x08, 0xf4, 0x09, 0x74, 0x85, 0x3a, 0x6f, 0x11, 0xb4,
+	0xb4, 0xe6, 0x63, 0xab, 0x79, 0x3d, 0x2a, 0xb0, 0x55, 0x0e, 0x7f, 0xf5, 0xc0, 0xc7, 0x24, 0x21,
+	0x64, 0xf5, 0x46, 0xb2, 0xfa, 0x00, 0x50, 0x79, 0xb8, 0xaa, 0x6b, 0x5c, 0x47, 0xb2, 0xc7, 0x7e,
+	0xb7, 0x94, 0xcc, 0x98, 0xc9, 0x20, 0xfc, 0xc3, 0x03, 0x74, 0xbc, 0x9e, 0xb2, 0xdb, 0x66, 0xf4,
+	0x39, 0xf4, 0x9d, 0xd9, 0xd4, 0x60, 0xeb, 0x1f, 0x3c, 0x72, 0x73, 0x72, 0x9d, 0xba, 0xba, 0xf5,
+	0x62, 0x5a, 0xff, 0x5c, 0x4c, 0xf8, 0xa7, 0x07, 0xdd, 0x29, 0x89, 0x12, 0xc2, 0xd1, 0x67, 0xd0,
+	0xab, 0xb6, 0x84, 0xc5, 0xff, 0x70, 0x6c, 0xf6, 0xc8, 0xb8, 0xdc, 0x23, 0xe3, 0x59, 0xa9, 0x81,
+	0xd7, 0xca, 0xe8, 0x31, 0x40, 0xbc, 0x8c, 0xb2, 0x8c, 0xa4, 0x73, 0x9a, 0xd8, 0xc1, 0xe8, 0x59,
+	0xce, 0x49, 0x82, 0x76, 0xa1, 0x93, 0xb1, 0x2c, 0x26, 0xba, 0x47, 0x03, 0x6c, 0x08, 0x14, 0xc0,
+	0x56, 0xcc, 0x49, 0x24, 0x19, 0x0f, 0xda, 0x9a, 0x5f, 0x92, 0x28, 0x04, 0x5f, 0xa6, 0x62, 0x1e,
+	0x13, 0x2e, 0xe7, 0xcb, 0x48, 0x2c, 0x83, 0x8e, 0x96, 0xf7, 0x65, 0x2a, 0x8e, 0x08, 0x97, 0xd3,
+	0x48, 0x2c, 0xc3, 0x5f, 0xda, 0xb0, 0x75, 0xc4, 0x56, 0xab, 0x28, 0x4b, 0xd0, 0x7b, 0xd0, 0x5d,
+	0xea, 0x12, 0x6c, 0xd6, 0xbe, 0xad, 0xd6, 0xd4, 0x85, 0xad, 0x10, 0x7d, 0x01, 0x77, 0xa8, 0x1e,
+	0x8e, 0x39, 0x37, 0x87, 0x60, 0xbb, 0xba, 0x6b, 0xd5, 0x6b, 0x93, 0x33, 0x6d, 0x60, 0x9f, 0xba,
+	0x0c, 0x74, 0x04, 0x77, 0xa5, 0x85, 0x5b, 0xe5, 0xa0, 0xa5, 0x1d, 0x3c, 0x2c, 0xbb, 0x5b, 0x9f,
+	0x85, 0x69, 0x03, 0xef, 0xc8, 0x3a, 0x0b, 0x7d, 0x0a, 0x83, 0x94, 0x8a, 0x75, 0x06, 0xed, 0x91,
+	0xe7, 0x6c, 0x00, 0x67, 0xd4, 0xa7, 0x0d, 0xdc, 0x4f, 0xd7, 0xa4, 0x4a, 0xde, 0x00, 0xad, 0x32,
+	0xed, 0xd4, 0x92, 0xaf, 0xe1, 0x5d, 0x25, 0xcf, 0x5d, 0x06, 0x7a, 0x0e, 0xf7, 0x1d, 0x88, 0x54,
+	0x3e, 0xba, 0xda, 0xc7, 0xff, 0xac, 0x8f, 0xab, 0x30, 0x9d, 0x36, 0x30, 0x22, 0x57, 0xb8, 0xaa,
+	0x15, 0xe4, 0x95, 0x3a, 0xdf, 0x05, 0xa9, 0x5c, 0x6d, 0xd5, 0x5a, 0x71, 0x6c, 0xc5, 0x4e, 0x2b,
+	0x48, 0x9d, 0xa5, 0x2a, 0x12, 0x45, 0x9e, 0xa7, 0x97, 0x95, 0x8b, 0xed, 0x5a, 0x45, 0x67, 0x5a,
+	0xe8, 0x54, 0x24, 0x5c, 0xc6, 0xd3, 0x1e, 0x6c, 0xe5, 0xd1, 0x65, 0xca, 0xa2, 0x24, 0xfc, 0x1a,
+	0xfc, 0x33, 0xba, 0xc8, 0x48, 0x52, 0x02, 0x42, 0x41, 0xcb, 0x7c, 0xda, 0xc9, 0x2a, 0x49, 0xb4,
+	0x07, 0x3d, 0x41, 0x17, 0x59, 0x24, 0x0b, 0x6e, 0x36, 0xf8, 0x00, 0xaf, 0x19, 0xe1, 0x6f, 0x1e,
+	0x3c, 0xb0, 0x3e, 0x30, 0x11, 0x39, 0xcb, 0x04, 0xf9, 0xcf, 0xb3, 0xf1, 0x0e, 0x0c, 0x6c, 0x70,
+	0x83, 0x65, 0x13, 0xb4, 0x6f, 0x79, 0x0a, 0xcb, 0xee, 0x24, 0xb4, 0x6a, 0x93, 0x10, 0x1e, 0x42,
+	0xe7, 0x98, 0x73, 0xc6, 0x95, 0xca, 0x8a, 0x08, 0x11, 0x2d, 0x88, 0x8e, 0xde, 0xc3, 0x25, 0x89,
+	0x82, 0xaa, 0x0f, 0xd6, 0x75, 0xd5, 0x96, 0xbf, 0x9a, 0xb0, 0xb3, 0x51, 0x0d, 0xfa, 0x78, 0x63,
+	0x54, 0xf6, 0x6c, 0xb3, 0xaf, 0xad, 0xba, 0x9a, 0x9c, 0x11, 0xb4, 0x08, 0xe7, 0x76, 0x5c, 0x06,
+	0xe5, 0x11, 0xab, 0xc4, 0xa6, 0x0d, 0xac, 0x44, 0xe8, 0x19, 0xdc, 0x33, 0x3b, 0xc7, 0x79, 0x36,
+	0x04, 0xad, 0xab, 0x4b, 0x6b, 0xb6, 0x16, 0x4f, 0x1b, 0xf8, 0xae, 0xdc, 0xe0, 0x29, 0x50, 0x14,
+	0xe6, 0xb2, 0x9c, 0xdb, 0x3b, 0xb2, 0x5d, 0x03, 0x45, 0xed, 0x26, 0x55, 0xa0, 0x28, 0x5c, 0x86,
+	0x32, 0x37, 0x69, 0x68, 0xac, 0x50, 0x22, 0x36, 0xa6, 0x44, 0xab, 0x9d, 0x59, 0x99, 0x32, 0x97,
+	0x2e, 0xc3, 0xc5, 0xd4, 0x0b, 0x78, 0x50, 0xc3, 0x54, 0xd5, 0xc1, 0x21, 0x6c, 0x73, 0xfb, 0x6d,
+	0xc1, 0x55, 0xd1, 0x37, 0xa0, 0x4b, 0xc2, 0xce, 0xc6, 0x58, 0xdc, 0x78, 0x0b, 0x7c, 0x05, 0x7e,
+	0xcc, 0x32, 0xc9, 0xe9, 0x79, 0xa1, 0x9f, 0x68, 0xf6, 0x6e, 0xfa, 0xff, 0xc6, 0x94, 0x1d, 0x39,
+	0x3a, 0xb8, 0x6e, 0x11, 0xfe, 0xec, 0xc1, 0xee, 0x75, 0x7a, 0x6a, 0x2b, 0x33, 0xf5, 0x4c, 0xb2,
+	0x61, 0x0d, 0xf1, 0xba, 0x37, 0x61, 0xfd, 0x7e, 0xde, 0xdd, 0xc8, 0xab, 0x7e, 0x2d, 0xe7, 0xe0,
+	0xd7, 0x04, 0x6f, 0xfe, 0x01, 0x79, 0x0c, 0x7e, 0x6d, 0x8b, 0xdc, 0xd8, 0xef, 0x5d, 0xe8, 0x28,
+	0xa7, 0xa6, 0xf2, 0x1e, 0x36, 0x44, 0xf8, 0x25, 0xf8, 0x35, 0xe0, 0xa0, 0x31, 0x6c, 0x57, 0x00,
+	0xbb, 0xe6, 0x0d, 0x67, 0x63, 0x56, 0x3a, 0x07, 0xa7, 0xd0, 0x3d, 0xd5, 0x8f, 0x74, 0xf4, 0x0c,
+	0xee, 0x9c, 0x72, 0x16, 0x13, 0x21, 0xca, 0x5d, 0x55, 0xad, 0x3b, 0x17, 0x6d, 0xc3, 0xbd, 0xeb,
+	0xb8, 0x25, 0x06, 0xc3, 0xc6, 0xd3, 0x17, 0xf0, 0x2e, 0xe3, 0x8b, 0xf1, 0xf2, 0x32, 0x27, 0x3c,
+	0x25, 0xc9, 0x82, 0xf0, 0xf1, 0x45, 0x74, 0xce, 0x69, 0x6c, 0xb6, 0x91, 0x30, 0xe6, 0xdf, 0x3d,
+	0x59, 0x50, 0xb9, 0x2c, 0xce, 0xc7, 0x31, 0x5b, 0x4d, 0x1c, 0xdd, 0x89, 0xd1, 0x35, 0x7f, 0x07,
+	0x62, 0xa2, 0x75, 0xcf, 0xbb, 0x9a, 0xfa, 0xe8, 0xef, 0x01, 0x00, 0x2a, 0x79, 0x81, 0x67, 0x56,
+	0x0c, 0x00, 0x00,
 }
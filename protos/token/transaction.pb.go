@@ -21,21 +21,34 @@ const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 type TokenOwner_Type int32
 
 const (
+	// The raw is a serialized identity
 	TokenOwner_MSP_IDENTIFIER TokenOwner_Type = 0
+	// The raw is the serialization of a ChaincodeOwner
+	TokenOwner_CHAINCODE_ID TokenOwner_Type = 1
+	// The raw is the serialization of a MultiSigOwner
+	TokenOwner_MULTI_SIG TokenOwner_Type = 2
+	// The raw is the serialization of a NymOwner
+	TokenOwner_IDEMIX_NYM TokenOwner_Type = 3
 )
 
 var TokenOwner_Type_name = map[int32]string{
 	0: "MSP_IDENTIFIER",
+	1: "CHAINCODE_ID",
+	2: "MULTI_SIG",
+	3: "IDEMIX_NYM",
 }
 var TokenOwner_Type_value = map[string]int32{
 	"MSP_IDENTIFIER": 0,
+	"CHAINCODE_ID":   1,
+	"MULTI_SIG":      2,
+	"IDEMIX_NYM":     3,
 }
 
 func (x TokenOwner_Type) String() string {
 	return proto.EnumName(TokenOwner_Type_name, int32(x))
 }
 func (TokenOwner_Type) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_f6e57d1df9c99e48, []int{2, 0}
+	return fileDescriptor_transaction_7f3b25d6e10f1883, []int{2, 0}
 }
 
 // TokenTransaction governs the structure of Payload.data, when
@@ -46,17 +59,21 @@ type TokenTransaction struct {
 	//
 	// Types that are valid to be assigned to Action:
 	//	*TokenTransaction_PlainAction
-	Action               isTokenTransaction_Action `protobuf_oneof:"action"`
-	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
-	XXX_unrecognized     []byte                    `json:"-"`
-	XXX_sizecache        int32                     `json:"-"`
+	//	*TokenTransaction_ConfidentialAction
+	Action isTokenTransaction_Action `protobuf_oneof:"action"`
+	// signatures carries the signatures of the owners of the inputs of this
+	// transaction, other than its creator, on the marshaled action.
+	Signatures           []*TokenSignature `protobuf:"bytes,2,rep,name=signatures,proto3" json:"signatures,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *TokenTransaction) Reset()         { *m = TokenTransaction{} }
 func (m *TokenTransaction) String() string { return proto.CompactTextString(m) }
 func (*TokenTransaction) ProtoMessage()    {}
 func (*TokenTransaction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_f6e57d1df9c99e48, []int{0}
+	return fileDescriptor_transaction_7f3b25d6e10f1883, []int{0}
 }
 func (m *TokenTransaction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TokenTransaction.Unmarshal(m, b)
@@ -84,8 +101,14 @@ type TokenTransaction_PlainAction struct {
 	PlainAction *PlainTokenAction `protobuf:"bytes,1,opt,name=plain_action,json=plainAction,proto3,oneof"`
 }
 
+type TokenTransaction_ConfidentialAction struct {
+	ConfidentialAction *ConfidentialTokenAction `protobuf:"bytes,3,opt,name=confidential_action,json=confidentialAction,proto3,oneof"`
+}
+
 func (*TokenTransaction_PlainAction) isTokenTransaction_Action() {}
 
+func (*TokenTransaction_ConfidentialAction) isTokenTransaction_Action() {}
+
 func (m *TokenTransaction) GetAction() isTokenTransaction_Action {
 	if m != nil {
 		return m.Action
@@ -100,10 +123,25 @@ func (m *TokenTransaction) GetPlainAction() *PlainTokenAction {
 	return nil
 }
 
+func (m *TokenTransaction) GetConfidentialAction() *ConfidentialTokenAction {
+	if x, ok := m.GetAction().(*TokenTransaction_ConfidentialAction); ok {
+		return x.ConfidentialAction
+	}
+	return nil
+}
+
+func (m *TokenTransaction) GetSignatures() []*TokenSignature {
+	if m != nil {
+		return m.Signatures
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*TokenTransaction) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _TokenTransaction_OneofMarshaler, _TokenTransaction_OneofUnmarshaler, _TokenTransaction_OneofSizer, []interface{}{
 		(*TokenTransaction_PlainAction)(nil),
+		(*TokenTransaction_ConfidentialAction)(nil),
 	}
 }
 
@@ -116,6 +154,11 @@ func _TokenTransaction_OneofMarshaler(msg proto.Message, b *proto.Buffer) error
 		if err := b.EncodeMessage(x.PlainAction); err != nil {
 			return err
 		}
+	case *TokenTransaction_ConfidentialAction:
+		b.EncodeVarint(3<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ConfidentialAction); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("TokenTransaction.Action has unexpected type %T", x)
@@ -134,6 +177,14 @@ func _TokenTransaction_OneofUnmarshaler(msg proto.Message, tag, wire int, b *pro
 		err := b.DecodeMessage(msg)
 		m.Action = &TokenTransaction_PlainAction{msg}
 		return true, err
+	case 3: // action.confidential_action
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ConfidentialTokenAction)
+		err := b.DecodeMessage(msg)
+		m.Action = &TokenTransaction_ConfidentialAction{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -148,6 +199,11 @@ func _TokenTransaction_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *TokenTransaction_ConfidentialAction:
+		s := proto.Size(x.ConfidentialAction)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -162,6 +218,7 @@ type PlainTokenAction struct {
 	//	*PlainTokenAction_PlainImport
 	//	*PlainTokenAction_PlainTransfer
 	//	*PlainTokenAction_PlainRedeem
+	//	*PlainTokenAction_PlainExchange
 	Data                 isPlainTokenAction_Data `protobuf_oneof:"data"`
 	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
 	XXX_unrecognized     []byte                  `json:"-"`
@@ -172,7 +229,7 @@ func (m *PlainTokenAction) Reset()         { *m = PlainTokenAction{} }
 func (m *PlainTokenAction) String() string { return proto.CompactTextString(m) }
 func (*PlainTokenAction) ProtoMessage()    {}
 func (*PlainTokenAction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_f6e57d1df9c99e48, []int{1}
+	return fileDescriptor_transaction_7f3b25d6e10f1883, []int{1}
 }
 func (m *PlainTokenAction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlainTokenAction.Unmarshal(m, b)
@@ -208,12 +265,18 @@ type PlainTokenAction_PlainRedeem struct {
 	PlainRedeem *PlainTransfer `protobuf:"bytes,3,opt,name=plain_redeem,json=plainRedeem,proto3,oneof"`
 }
 
+type PlainTokenAction_PlainExchange struct {
+	PlainExchange *PlainTransfer `protobuf:"bytes,4,opt,name=plain_exchange,json=plainExchange,proto3,oneof"`
+}
+
 func (*PlainTokenAction_PlainImport) isPlainTokenAction_Data() {}
 
 func (*PlainTokenAction_PlainTransfer) isPlainTokenAction_Data() {}
 
 func (*PlainTokenAction_PlainRedeem) isPlainTokenAction_Data() {}
 
+func (*PlainTokenAction_PlainExchange) isPlainTokenAction_Data() {}
+
 func (m *PlainTokenAction) GetData() isPlainTokenAction_Data {
 	if m != nil {
 		return m.Data
@@ -242,12 +305,20 @@ func (m *PlainTokenAction) GetPlainRedeem() *PlainTransfer {
 	return nil
 }
 
+func (m *PlainTokenAction) GetPlainExchange() *PlainTransfer {
+	if x, ok := m.GetData().(*PlainTokenAction_PlainExchange); ok {
+		return x.PlainExchange
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*PlainTokenAction) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _PlainTokenAction_OneofMarshaler, _PlainTokenAction_OneofUnmarshaler, _PlainTokenAction_OneofSizer, []interface{}{
 		(*PlainTokenAction_PlainImport)(nil),
 		(*PlainTokenAction_PlainTransfer)(nil),
 		(*PlainTokenAction_PlainRedeem)(nil),
+		(*PlainTokenAction_PlainExchange)(nil),
 	}
 }
 
@@ -270,6 +341,11 @@ func _PlainTokenAction_OneofMarshaler(msg proto.Message, b *proto.Buffer) error
 		if err := b.EncodeMessage(x.PlainRedeem); err != nil {
 			return err
 		}
+	case *PlainTokenAction_PlainExchange:
+		b.EncodeVarint(4<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.PlainExchange); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("PlainTokenAction.Data has unexpected type %T", x)
@@ -304,6 +380,14 @@ func _PlainTokenAction_OneofUnmarshaler(msg proto.Message, tag, wire int, b *pro
 		err := b.DecodeMessage(msg)
 		m.Data = &PlainTokenAction_PlainRedeem{msg}
 		return true, err
+	case 4: // data.plain_exchange
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(PlainTransfer)
+		err := b.DecodeMessage(msg)
+		m.Data = &PlainTokenAction_PlainExchange{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -328,6 +412,11 @@ func _PlainTokenAction_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *PlainTokenAction_PlainExchange:
+		s := proto.Size(x.PlainExchange)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -350,7 +439,7 @@ func (m *TokenOwner) Reset()         { *m = TokenOwner{} }
 func (m *TokenOwner) String() string { return proto.CompactTextString(m) }
 func (*TokenOwner) ProtoMessage()    {}
 func (*TokenOwner) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_f6e57d1df9c99e48, []int{2}
+	return fileDescriptor_transaction_7f3b25d6e10f1883, []int{2}
 }
 func (m *TokenOwner) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TokenOwner.Unmarshal(m, b)
@@ -397,7 +486,7 @@ func (m *PlainImport) Reset()         { *m = PlainImport{} }
 func (m *PlainImport) String() string { return proto.CompactTextString(m) }
 func (*PlainImport) ProtoMessage()    {}
 func (*PlainImport) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_f6e57d1df9c99e48, []int{3}
+	return fileDescriptor_transaction_7f3b25d6e10f1883, []int{3}
 }
 func (m *PlainImport) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlainImport.Unmarshal(m, b)
@@ -439,7 +528,7 @@ func (m *PlainTransfer) Reset()         { *m = PlainTransfer{} }
 func (m *PlainTransfer) String() string { return proto.CompactTextString(m) }
 func (*PlainTransfer) ProtoMessage()    {}
 func (*PlainTransfer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_f6e57d1df9c99e48, []int{4}
+	return fileDescriptor_transaction_7f3b25d6e10f1883, []int{4}
 }
 func (m *PlainTransfer) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlainTransfer.Unmarshal(m, b)
@@ -490,7 +579,7 @@ func (m *PlainOutput) Reset()         { *m = PlainOutput{} }
 func (m *PlainOutput) String() string { return proto.CompactTextString(m) }
 func (*PlainOutput) ProtoMessage()    {}
 func (*PlainOutput) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_f6e57d1df9c99e48, []int{5}
+	return fileDescriptor_transaction_7f3b25d6e10f1883, []int{5}
 }
 func (m *PlainOutput) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlainOutput.Unmarshal(m, b)
@@ -546,7 +635,7 @@ func (m *TokenId) Reset()         { *m = TokenId{} }
 func (m *TokenId) String() string { return proto.CompactTextString(m) }
 func (*TokenId) ProtoMessage()    {}
 func (*TokenId) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_f6e57d1df9c99e48, []int{6}
+	return fileDescriptor_transaction_7f3b25d6e10f1883, []int{6}
 }
 func (m *TokenId) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TokenId.Unmarshal(m, b)
@@ -580,6 +669,678 @@ func (m *TokenId) GetIndex() uint32 {
 	return 0
 }
 
+// MultiSigOwner holds the identities of the joint owners of a token.
+// The token is spent by a transaction which is created or signed by at least
+// threshold of the identities.
+type MultiSigOwner struct {
+	// The number of identities which sign the transactions spending the token
+	Threshold uint32 `protobuf:"varint,1,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	// The serialized identities of the owners
+	Identities           [][]byte `protobuf:"bytes,2,rep,name=identities,proto3" json:"identities,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MultiSigOwner) Reset()         { *m = MultiSigOwner{} }
+func (m *MultiSigOwner) String() string { return proto.CompactTextString(m) }
+func (*MultiSigOwner) ProtoMessage()    {}
+func (*MultiSigOwner) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_7f3b25d6e10f1883, []int{7}
+}
+func (m *MultiSigOwner) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MultiSigOwner.Unmarshal(m, b)
+}
+func (m *MultiSigOwner) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MultiSigOwner.Marshal(b, m, deterministic)
+}
+func (dst *MultiSigOwner) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MultiSigOwner.Merge(dst, src)
+}
+func (m *MultiSigOwner) XXX_Size() int {
+	return xxx_messageInfo_MultiSigOwner.Size(m)
+}
+func (m *MultiSigOwner) XXX_DiscardUnknown() {
+	xxx_messageInfo_MultiSigOwner.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MultiSigOwner proto.InternalMessageInfo
+
+func (m *MultiSigOwner) GetThreshold() uint32 {
+	if m != nil {
+		return m.Threshold
+	}
+	return 0
+}
+
+func (m *MultiSigOwner) GetIdentities() [][]byte {
+	if m != nil {
+		return m.Identities
+	}
+	return nil
+}
+
+// ChaincodeOwner holds the name of the chaincode which owns a token.
+// The token is spent by a transaction whose action the chaincode approved,
+// when invoked, by writing the key of the approval to its state.
+type ChaincodeOwner struct {
+	// The name of the chaincode
+	ChaincodeName        string   `protobuf:"bytes,1,opt,name=chaincode_name,json=chaincodeName,proto3" json:"chaincode_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChaincodeOwner) Reset()         { *m = ChaincodeOwner{} }
+func (m *ChaincodeOwner) String() string { return proto.CompactTextString(m) }
+func (*ChaincodeOwner) ProtoMessage()    {}
+func (*ChaincodeOwner) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_7f3b25d6e10f1883, []int{8}
+}
+func (m *ChaincodeOwner) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChaincodeOwner.Unmarshal(m, b)
+}
+func (m *ChaincodeOwner) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChaincodeOwner.Marshal(b, m, deterministic)
+}
+func (dst *ChaincodeOwner) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChaincodeOwner.Merge(dst, src)
+}
+func (m *ChaincodeOwner) XXX_Size() int {
+	return xxx_messageInfo_ChaincodeOwner.Size(m)
+}
+func (m *ChaincodeOwner) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChaincodeOwner.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChaincodeOwner proto.InternalMessageInfo
+
+func (m *ChaincodeOwner) GetChaincodeName() string {
+	if m != nil {
+		return m.ChaincodeName
+	}
+	return ""
+}
+
+// TokenSignature is the signature of an owner of the inputs of a token transaction
+type TokenSignature struct {
+	// The serialized identity of the signer
+	Signer []byte `protobuf:"bytes,1,opt,name=signer,proto3" json:"signer,omitempty"`
+	// The signature on the marshaled action of the transaction
+	Signature            []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TokenSignature) Reset()         { *m = TokenSignature{} }
+func (m *TokenSignature) String() string { return proto.CompactTextString(m) }
+func (*TokenSignature) ProtoMessage()    {}
+func (*TokenSignature) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_7f3b25d6e10f1883, []int{9}
+}
+func (m *TokenSignature) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenSignature.Unmarshal(m, b)
+}
+func (m *TokenSignature) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenSignature.Marshal(b, m, deterministic)
+}
+func (dst *TokenSignature) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenSignature.Merge(dst, src)
+}
+func (m *TokenSignature) XXX_Size() int {
+	return xxx_messageInfo_TokenSignature.Size(m)
+}
+func (m *TokenSignature) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenSignature.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenSignature proto.InternalMessageInfo
+
+func (m *TokenSignature) GetSigner() []byte {
+	if m != nil {
+		return m.Signer
+	}
+	return nil
+}
+
+func (m *TokenSignature) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// ConfidentialTokenAction governs the structure of a token action whose
+// quantities are hidden in commitments
+type ConfidentialTokenAction struct {
+	// Types that are valid to be assigned to Data:
+	//	*ConfidentialTokenAction_ConfidentialImport
+	//	*ConfidentialTokenAction_ConfidentialTransfer
+	//	*ConfidentialTokenAction_ConfidentialRedeem
+	Data                 isConfidentialTokenAction_Data `protobuf_oneof:"data"`
+	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
+	XXX_unrecognized     []byte                         `json:"-"`
+	XXX_sizecache        int32                          `json:"-"`
+}
+
+func (m *ConfidentialTokenAction) Reset()         { *m = ConfidentialTokenAction{} }
+func (m *ConfidentialTokenAction) String() string { return proto.CompactTextString(m) }
+func (*ConfidentialTokenAction) ProtoMessage()    {}
+func (*ConfidentialTokenAction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_7f3b25d6e10f1883, []int{10}
+}
+func (m *ConfidentialTokenAction) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfidentialTokenAction.Unmarshal(m, b)
+}
+func (m *ConfidentialTokenAction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfidentialTokenAction.Marshal(b, m, deterministic)
+}
+func (dst *ConfidentialTokenAction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfidentialTokenAction.Merge(dst, src)
+}
+func (m *ConfidentialTokenAction) XXX_Size() int {
+	return xxx_messageInfo_ConfidentialTokenAction.Size(m)
+}
+func (m *ConfidentialTokenAction) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfidentialTokenAction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfidentialTokenAction proto.InternalMessageInfo
+
+type isConfidentialTokenAction_Data interface {
+	isConfidentialTokenAction_Data()
+}
+
+type ConfidentialTokenAction_ConfidentialImport struct {
+	ConfidentialImport *ConfidentialImport `protobuf:"bytes,1,opt,name=confidential_import,json=confidentialImport,proto3,oneof"`
+}
+
+type ConfidentialTokenAction_ConfidentialTransfer struct {
+	ConfidentialTransfer *ConfidentialTransfer `protobuf:"bytes,2,opt,name=confidential_transfer,json=confidentialTransfer,proto3,oneof"`
+}
+
+type ConfidentialTokenAction_ConfidentialRedeem struct {
+	ConfidentialRedeem *ConfidentialTransfer `protobuf:"bytes,3,opt,name=confidential_redeem,json=confidentialRedeem,proto3,oneof"`
+}
+
+func (*ConfidentialTokenAction_ConfidentialImport) isConfidentialTokenAction_Data() {}
+
+func (*ConfidentialTokenAction_ConfidentialTransfer) isConfidentialTokenAction_Data() {}
+
+func (*ConfidentialTokenAction_ConfidentialRedeem) isConfidentialTokenAction_Data() {}
+
+func (m *ConfidentialTokenAction) GetData() isConfidentialTokenAction_Data {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *ConfidentialTokenAction) GetConfidentialImport() *ConfidentialImport {
+	if x, ok := m.GetData().(*ConfidentialTokenAction_ConfidentialImport); ok {
+		return x.ConfidentialImport
+	}
+	return nil
+}
+
+func (m *ConfidentialTokenAction) GetConfidentialTransfer() *ConfidentialTransfer {
+	if x, ok := m.GetData().(*ConfidentialTokenAction_ConfidentialTransfer); ok {
+		return x.ConfidentialTransfer
+	}
+	return nil
+}
+
+func (m *ConfidentialTokenAction) GetConfidentialRedeem() *ConfidentialTransfer {
+	if x, ok := m.GetData().(*ConfidentialTokenAction_ConfidentialRedeem); ok {
+		return x.ConfidentialRedeem
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*ConfidentialTokenAction) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _ConfidentialTokenAction_OneofMarshaler, _ConfidentialTokenAction_OneofUnmarshaler, _ConfidentialTokenAction_OneofSizer, []interface{}{
+		(*ConfidentialTokenAction_ConfidentialImport)(nil),
+		(*ConfidentialTokenAction_ConfidentialTransfer)(nil),
+		(*ConfidentialTokenAction_ConfidentialRedeem)(nil),
+	}
+}
+
+func _ConfidentialTokenAction_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*ConfidentialTokenAction)
+	// data
+	switch x := m.Data.(type) {
+	case *ConfidentialTokenAction_ConfidentialImport:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ConfidentialImport); err != nil {
+			return err
+		}
+	case *ConfidentialTokenAction_ConfidentialTransfer:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ConfidentialTransfer); err != nil {
+			return err
+		}
+	case *ConfidentialTokenAction_ConfidentialRedeem:
+		b.EncodeVarint(3<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ConfidentialRedeem); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("ConfidentialTokenAction.Data has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _ConfidentialTokenAction_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*ConfidentialTokenAction)
+	switch tag {
+	case 1: // data.confidential_import
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ConfidentialImport)
+		err := b.DecodeMessage(msg)
+		m.Data = &ConfidentialTokenAction_ConfidentialImport{msg}
+		return true, err
+	case 2: // data.confidential_transfer
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ConfidentialTransfer)
+		err := b.DecodeMessage(msg)
+		m.Data = &ConfidentialTokenAction_ConfidentialTransfer{msg}
+		return true, err
+	case 3: // data.confidential_redeem
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ConfidentialTransfer)
+		err := b.DecodeMessage(msg)
+		m.Data = &ConfidentialTokenAction_ConfidentialRedeem{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _ConfidentialTokenAction_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*ConfidentialTokenAction)
+	// data
+	switch x := m.Data.(type) {
+	case *ConfidentialTokenAction_ConfidentialImport:
+		s := proto.Size(x.ConfidentialImport)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *ConfidentialTokenAction_ConfidentialTransfer:
+		s := proto.Size(x.ConfidentialTransfer)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *ConfidentialTokenAction_ConfidentialRedeem:
+		s := proto.Size(x.ConfidentialRedeem)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+// ConfidentialImport specifies an import of one or more confidential tokens
+type ConfidentialImport struct {
+	// An import transaction may contain one or more outputs
+	Outputs              []*ConfidentialOutput `protobuf:"bytes,1,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *ConfidentialImport) Reset()         { *m = ConfidentialImport{} }
+func (m *ConfidentialImport) String() string { return proto.CompactTextString(m) }
+func (*ConfidentialImport) ProtoMessage()    {}
+func (*ConfidentialImport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_7f3b25d6e10f1883, []int{11}
+}
+func (m *ConfidentialImport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfidentialImport.Unmarshal(m, b)
+}
+func (m *ConfidentialImport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfidentialImport.Marshal(b, m, deterministic)
+}
+func (dst *ConfidentialImport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfidentialImport.Merge(dst, src)
+}
+func (m *ConfidentialImport) XXX_Size() int {
+	return xxx_messageInfo_ConfidentialImport.Size(m)
+}
+func (m *ConfidentialImport) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfidentialImport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfidentialImport proto.InternalMessageInfo
+
+func (m *ConfidentialImport) GetOutputs() []*ConfidentialOutput {
+	if m != nil {
+		return m.Outputs
+	}
+	return nil
+}
+
+// ConfidentialTransfer specifies a transfer of one or more confidential tokens to one or more outputs
+type ConfidentialTransfer struct {
+	// The inputs to the transfer transaction are specified by their ID
+	Inputs []*TokenId `protobuf:"bytes,1,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	// A transfer transaction may contain one or more outputs
+	Outputs []*ConfidentialOutput `protobuf:"bytes,2,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	// The quantity of tokens taken out of circulation by a redeem transaction
+	RedeemedQuantity uint64 `protobuf:"varint,3,opt,name=redeemed_quantity,json=redeemedQuantity,proto3" json:"redeemed_quantity,omitempty"`
+	// The proof that the inputs commit to the same quantity as the outputs
+	// and the redeemed quantity together
+	BalanceProof []byte `protobuf:"bytes,4,opt,name=balance_proof,json=balanceProof,proto3" json:"balance_proof,omitempty"`
+	// The proofs that the creator of the transaction owns the inputs owned by pseudonyms
+	NymProofs            []*NymProof `protobuf:"bytes,5,rep,name=nym_proofs,json=nymProofs,proto3" json:"nym_proofs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ConfidentialTransfer) Reset()         { *m = ConfidentialTransfer{} }
+func (m *ConfidentialTransfer) String() string { return proto.CompactTextString(m) }
+func (*ConfidentialTransfer) ProtoMessage()    {}
+func (*ConfidentialTransfer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_7f3b25d6e10f1883, []int{12}
+}
+func (m *ConfidentialTransfer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfidentialTransfer.Unmarshal(m, b)
+}
+func (m *ConfidentialTransfer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfidentialTransfer.Marshal(b, m, deterministic)
+}
+func (dst *ConfidentialTransfer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfidentialTransfer.Merge(dst, src)
+}
+func (m *ConfidentialTransfer) XXX_Size() int {
+	return xxx_messageInfo_ConfidentialTransfer.Size(m)
+}
+func (m *ConfidentialTransfer) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfidentialTransfer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfidentialTransfer proto.InternalMessageInfo
+
+func (m *ConfidentialTransfer) GetInputs() []*TokenId {
+	if m != nil {
+		return m.Inputs
+	}
+	return nil
+}
+
+func (m *ConfidentialTransfer) GetOutputs() []*ConfidentialOutput {
+	if m != nil {
+		return m.Outputs
+	}
+	return nil
+}
+
+func (m *ConfidentialTransfer) GetRedeemedQuantity() uint64 {
+	if m != nil {
+		return m.RedeemedQuantity
+	}
+	return 0
+}
+
+func (m *ConfidentialTransfer) GetBalanceProof() []byte {
+	if m != nil {
+		return m.BalanceProof
+	}
+	return nil
+}
+
+func (m *ConfidentialTransfer) GetNymProofs() []*NymProof {
+	if m != nil {
+		return m.NymProofs
+	}
+	return nil
+}
+
+// A ConfidentialOutput is the result of import and transfer transactions using confidential tokens
+type ConfidentialOutput struct {
+	// The owner of the output
+	Owner *TokenOwner `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	// The token type
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// The commitment to the quantity of tokens
+	Commitment []byte `protobuf:"bytes,3,opt,name=commitment,proto3" json:"commitment,omitempty"`
+	// The proof that the commitment hides a quantity in the allowed range
+	RangeProof           []byte   `protobuf:"bytes,4,opt,name=range_proof,json=rangeProof,proto3" json:"range_proof,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConfidentialOutput) Reset()         { *m = ConfidentialOutput{} }
+func (m *ConfidentialOutput) String() string { return proto.CompactTextString(m) }
+func (*ConfidentialOutput) ProtoMessage()    {}
+func (*ConfidentialOutput) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_7f3b25d6e10f1883, []int{13}
+}
+func (m *ConfidentialOutput) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfidentialOutput.Unmarshal(m, b)
+}
+func (m *ConfidentialOutput) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfidentialOutput.Marshal(b, m, deterministic)
+}
+func (dst *ConfidentialOutput) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfidentialOutput.Merge(dst, src)
+}
+func (m *ConfidentialOutput) XXX_Size() int {
+	return xxx_messageInfo_ConfidentialOutput.Size(m)
+}
+func (m *ConfidentialOutput) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfidentialOutput.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfidentialOutput proto.InternalMessageInfo
+
+func (m *ConfidentialOutput) GetOwner() *TokenOwner {
+	if m != nil {
+		return m.Owner
+	}
+	return nil
+}
+
+func (m *ConfidentialOutput) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *ConfidentialOutput) GetCommitment() []byte {
+	if m != nil {
+		return m.Commitment
+	}
+	return nil
+}
+
+func (m *ConfidentialOutput) GetRangeProof() []byte {
+	if m != nil {
+		return m.RangeProof
+	}
+	return nil
+}
+
+// NymOwner holds an idemix pseudonym owning a token
+type NymOwner struct {
+	// The serialization of the pseudonym
+	Nym []byte `protobuf:"bytes,1,opt,name=nym,proto3" json:"nym,omitempty"`
+	// The hash of the public key of the issuer of the credential the pseudonym is derived from
+	IssuerPublicKeyHash  []byte   `protobuf:"bytes,2,opt,name=issuer_public_key_hash,json=issuerPublicKeyHash,proto3" json:"issuer_public_key_hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NymOwner) Reset()         { *m = NymOwner{} }
+func (m *NymOwner) String() string { return proto.CompactTextString(m) }
+func (*NymOwner) ProtoMessage()    {}
+func (*NymOwner) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_7f3b25d6e10f1883, []int{14}
+}
+func (m *NymOwner) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NymOwner.Unmarshal(m, b)
+}
+func (m *NymOwner) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NymOwner.Marshal(b, m, deterministic)
+}
+func (dst *NymOwner) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NymOwner.Merge(dst, src)
+}
+func (m *NymOwner) XXX_Size() int {
+	return xxx_messageInfo_NymOwner.Size(m)
+}
+func (m *NymOwner) XXX_DiscardUnknown() {
+	xxx_messageInfo_NymOwner.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NymOwner proto.InternalMessageInfo
+
+func (m *NymOwner) GetNym() []byte {
+	if m != nil {
+		return m.Nym
+	}
+	return nil
+}
+
+func (m *NymOwner) GetIssuerPublicKeyHash() []byte {
+	if m != nil {
+		return m.IssuerPublicKeyHash
+	}
+	return nil
+}
+
+// NymProof proves that the creator of a transaction knows the secrets of the pseudonym owning an input
+type NymProof struct {
+	// The index of the input
+	Input uint32 `protobuf:"varint,1,opt,name=input,proto3" json:"input,omitempty"`
+	// The serialization of the public key of the issuer
+	IssuerPublicKey []byte `protobuf:"bytes,2,opt,name=issuer_public_key,json=issuerPublicKey,proto3" json:"issuer_public_key,omitempty"`
+	// The serialization of the pseudonym signature on the marshaled action,
+	// whose nym proofs are empty
+	Signature            []byte   `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NymProof) Reset()         { *m = NymProof{} }
+func (m *NymProof) String() string { return proto.CompactTextString(m) }
+func (*NymProof) ProtoMessage()    {}
+func (*NymProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_7f3b25d6e10f1883, []int{15}
+}
+func (m *NymProof) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NymProof.Unmarshal(m, b)
+}
+func (m *NymProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NymProof.Marshal(b, m, deterministic)
+}
+func (dst *NymProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NymProof.Merge(dst, src)
+}
+func (m *NymProof) XXX_Size() int {
+	return xxx_messageInfo_NymProof.Size(m)
+}
+func (m *NymProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_NymProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NymProof proto.InternalMessageInfo
+
+func (m *NymProof) GetInput() uint32 {
+	if m != nil {
+		return m.Input
+	}
+	return 0
+}
+
+func (m *NymProof) GetIssuerPublicKey() []byte {
+	if m != nil {
+		return m.IssuerPublicKey
+	}
+	return nil
+}
+
+func (m *NymProof) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// TokenSupply records the quantities of a token type that have been issued and redeemed
+type TokenSupply struct {
+	// The token type
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// The quantity of tokens of the type that have been issued
+	Issued uint64 `protobuf:"varint,2,opt,name=issued,proto3" json:"issued,omitempty"`
+	// The quantity of tokens of the type that have been redeemed
+	Redeemed             uint64   `protobuf:"varint,3,opt,name=redeemed,proto3" json:"redeemed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TokenSupply) Reset()         { *m = TokenSupply{} }
+func (m *TokenSupply) String() string { return proto.CompactTextString(m) }
+func (*TokenSupply) ProtoMessage()    {}
+func (*TokenSupply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_7f3b25d6e10f1883, []int{16}
+}
+func (m *TokenSupply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenSupply.Unmarshal(m, b)
+}
+func (m *TokenSupply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenSupply.Marshal(b, m, deterministic)
+}
+func (dst *TokenSupply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenSupply.Merge(dst, src)
+}
+func (m *TokenSupply) XXX_Size() int {
+	return xxx_messageInfo_TokenSupply.Size(m)
+}
+func (m *TokenSupply) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenSupply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenSupply proto.InternalMessageInfo
+
+func (m *TokenSupply) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *TokenSupply) GetIssued() uint64 {
+	if m != nil {
+		return m.Issued
+	}
+	return 0
+}
+
+func (m *TokenSupply) GetRedeemed() uint64 {
+	if m != nil {
+		return m.Redeemed
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*TokenTransaction)(nil), "token.TokenTransaction")
 	proto.RegisterType((*PlainTokenAction)(nil), "token.PlainTokenAction")
@@ -588,40 +1349,82 @@ func init() {
 	proto.RegisterType((*PlainTransfer)(nil), "token.PlainTransfer")
 	proto.RegisterType((*PlainOutput)(nil), "token.PlainOutput")
 	proto.RegisterType((*TokenId)(nil), "token.TokenId")
+	proto.RegisterType((*MultiSigOwner)(nil), "token.MultiSigOwner")
+	proto.RegisterType((*ChaincodeOwner)(nil), "token.ChaincodeOwner")
+	proto.RegisterType((*TokenSignature)(nil), "token.TokenSignature")
+	proto.RegisterType((*ConfidentialTokenAction)(nil), "token.ConfidentialTokenAction")
+	proto.RegisterType((*ConfidentialImport)(nil), "token.ConfidentialImport")
+	proto.RegisterType((*ConfidentialTransfer)(nil), "token.ConfidentialTransfer")
+	proto.RegisterType((*ConfidentialOutput)(nil), "token.ConfidentialOutput")
+	proto.RegisterType((*NymOwner)(nil), "token.NymOwner")
+	proto.RegisterType((*NymProof)(nil), "token.NymProof")
+	proto.RegisterType((*TokenSupply)(nil), "token.TokenSupply")
 	proto.RegisterEnum("token.TokenOwner_Type", TokenOwner_Type_name, TokenOwner_Type_value)
 }
 
 func init() {
-	proto.RegisterFile("token/transaction.proto", fileDescriptor_transaction_f6e57d1df9c99e48)
-}
-
-var fileDescriptor_transaction_f6e57d1df9c99e48 = []byte{
-	// 431 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x53, 0x4d, 0x6f, 0x13, 0x31,
-	0x10, 0x4d, 0x9a, 0x8f, 0x96, 0x49, 0x13, 0x85, 0xa1, 0xa2, 0x51, 0x4f, 0xd5, 0x1e, 0x00, 0x55,
-	0xd5, 0xae, 0x54, 0x90, 0x10, 0x02, 0x0e, 0x44, 0x14, 0x75, 0x0f, 0xd0, 0xca, 0xec, 0xa9, 0x97,
-	0xc8, 0xc9, 0x7a, 0x5b, 0x8b, 0xc6, 0x36, 0x8e, 0x57, 0xcd, 0xfe, 0x48, 0xfe, 0x13, 0xda, 0xb1,
-	0xd3, 0x6e, 0x8b, 0x44, 0x6f, 0x1e, 0xbf, 0xf7, 0x66, 0xde, 0x3c, 0xcb, 0xb0, 0xef, 0xf4, 0x2f,
-	0xa1, 0x12, 0x67, 0xb9, 0x5a, 0xf1, 0x85, 0x93, 0x5a, 0xc5, 0xc6, 0x6a, 0xa7, 0xb1, 0x47, 0x40,
-	0x74, 0x09, 0xe3, 0xac, 0x3e, 0x64, 0xf7, 0x04, 0xfc, 0x04, 0xbb, 0xe6, 0x86, 0x4b, 0x35, 0xf3,
-	0xf5, 0xa4, 0x7d, 0xd8, 0x7e, 0x33, 0x38, 0xd9, 0x8f, 0x49, 0x11, 0x5f, 0xd4, 0x10, 0x69, 0xbe,
-	0x10, 0x7c, 0xd6, 0x62, 0x03, 0xa2, 0xfb, 0x72, 0xba, 0x03, 0x7d, 0xaf, 0x8b, 0xfe, 0xb4, 0x61,
-	0xfc, 0x98, 0x8d, 0xef, 0x37, 0xcd, 0xe5, 0xd2, 0x68, 0xeb, 0x42, 0x73, 0x6c, 0x36, 0x4f, 0x09,
-	0xb9, 0xeb, 0xeb, 0x4b, 0xfc, 0x0c, 0x23, 0x2f, 0xa4, 0x5d, 0x0a, 0x61, 0x27, 0x5b, 0x24, 0xdd,
-	0x7b, 0xe0, 0x2b, 0x60, 0x67, 0x2d, 0x36, 0x34, 0xcd, 0x0b, 0xfc, 0xb0, 0x99, 0x6b, 0x45, 0x2e,
-	0xc4, 0x72, 0xd2, 0xf9, 0xaf, 0xd8, 0x4f, 0x66, 0x44, 0x9d, 0xf6, 0xa1, 0x9b, 0x73, 0xc7, 0xa3,
-	0x02, 0x80, 0x36, 0x39, 0xbf, 0x55, 0xc2, 0xe2, 0x11, 0x74, 0x5d, 0x65, 0x04, 0x2d, 0x30, 0x3a,
-	0x79, 0x19, 0x1a, 0xdd, 0x13, 0xe2, 0xac, 0x32, 0x82, 0x11, 0x07, 0xc7, 0xd0, 0xb1, 0xfc, 0x96,
-	0x0c, 0xef, 0xb2, 0xfa, 0x18, 0x1d, 0x40, 0xb7, 0xc6, 0x11, 0x61, 0xf4, 0xfd, 0xe7, 0xc5, 0x2c,
-	0xfd, 0x7a, 0xfa, 0x23, 0x4b, 0xbf, 0xa5, 0xa7, 0x6c, 0xdc, 0x8a, 0x3e, 0xc2, 0xa0, 0x91, 0x03,
-	0x1e, 0xc3, 0xb6, 0x2e, 0x9d, 0x29, 0xdd, 0x6a, 0xd2, 0x3e, 0xec, 0x3c, 0x0e, 0xeb, 0x9c, 0x20,
-	0xb6, 0xa1, 0x44, 0x02, 0x86, 0x0f, 0x96, 0xc1, 0x57, 0xd0, 0x97, 0xaa, 0xa1, 0x1e, 0x35, 0x9d,
-	0xa6, 0x39, 0x0b, 0x68, 0x73, 0xcc, 0xd6, 0xd3, 0x63, 0x8a, 0xe0, 0xd1, 0xdf, 0xe3, 0x6b, 0xe8,
-	0xe9, 0x7a, 0xe9, 0xf0, 0x9c, 0xcf, 0xff, 0x49, 0x83, 0x79, 0x1c, 0x31, 0xa4, 0x56, 0x47, 0xf1,
-	0x2c, 0xa4, 0x73, 0x00, 0x3b, 0xbf, 0x4b, 0xae, 0x9c, 0x74, 0x15, 0x3d, 0x4b, 0x97, 0xdd, 0xd5,
-	0xd1, 0x3b, 0xd8, 0x0e, 0x46, 0xf1, 0x05, 0xf4, 0xdc, 0x7a, 0x26, 0x73, 0x9a, 0x51, 0x6b, 0xd7,
-	0x69, 0x8e, 0x7b, 0xd0, 0x93, 0x2a, 0x17, 0x6b, 0x6a, 0x38, 0x64, 0xbe, 0x98, 0x1e, 0x5f, 0x1e,
-	0x5d, 0x49, 0x77, 0x5d, 0xce, 0xe3, 0x85, 0x5e, 0x26, 0xd7, 0x95, 0x11, 0xf6, 0x46, 0xe4, 0x57,
-	0xc2, 0x26, 0x05, 0x9f, 0x5b, 0xb9, 0x48, 0xe8, 0x0f, 0xac, 0x12, 0x72, 0x39, 0xef, 0x53, 0xf5,
-	0xf6, 0x6f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x2c, 0x42, 0x1d, 0xa7, 0x2c, 0x03, 0x00, 0x00,
+	proto.RegisterFile("token/transaction.proto", fileDescriptor_transaction_7f3b25d6e10f1883)
+}
+
+var fileDescriptor_transaction_7f3b25d6e10f1883 = []byte{
+	// 937 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0x5b, 0x6f, 0xe3, 0x44,
+	0x14, 0xae, 0x73, 0xdb, 0xf6, 0xe4, 0xb2, 0xee, 0x69, 0xb7, 0x0d, 0x17, 0x95, 0xca, 0x08, 0x58,
+	0x95, 0x55, 0x22, 0xb5, 0xa0, 0x15, 0x02, 0x1e, 0xb6, 0x6d, 0x96, 0x5a, 0xbb, 0x49, 0xdb, 0x69,
+	0x56, 0x02, 0x5e, 0x2c, 0xc7, 0x9e, 0x24, 0x66, 0xe3, 0x0b, 0xf6, 0x58, 0x5b, 0xff, 0x07, 0x9e,
+	0xe0, 0x81, 0x3f, 0xc8, 0x5f, 0xe0, 0x1d, 0x79, 0x66, 0x9c, 0xd8, 0x4e, 0x60, 0x2b, 0xf1, 0x96,
+	0xf3, 0x9d, 0xfb, 0x37, 0xfe, 0x8e, 0x02, 0x87, 0xcc, 0x7f, 0x4b, 0xbd, 0x3e, 0x0b, 0x4d, 0x2f,
+	0x32, 0x2d, 0xe6, 0xf8, 0x5e, 0x2f, 0x08, 0x7d, 0xe6, 0x63, 0x9d, 0x3b, 0xb4, 0xbf, 0x14, 0x50,
+	0xc7, 0xe9, 0xaf, 0xf1, 0x2a, 0x02, 0xbf, 0x83, 0x56, 0xb0, 0x30, 0x1d, 0xcf, 0x10, 0x76, 0x57,
+	0x39, 0x56, 0x9e, 0x36, 0x4f, 0x0f, 0x7b, 0x3c, 0xa5, 0x77, 0x93, 0xba, 0x78, 0xce, 0x0b, 0xee,
+	0xbe, 0xda, 0x22, 0x4d, 0x1e, 0x2e, 0x4c, 0xfc, 0x1a, 0x20, 0x72, 0x66, 0x9e, 0xc9, 0xe2, 0x90,
+	0x46, 0xdd, 0xca, 0x71, 0xf5, 0x69, 0xf3, 0xf4, 0x89, 0xcc, 0xe5, 0x69, 0x77, 0x99, 0x97, 0xe4,
+	0x02, 0xf1, 0x16, 0xf6, 0x2c, 0xdf, 0x9b, 0x3a, 0x36, 0xf5, 0x98, 0x63, 0x2e, 0xb2, 0xde, 0x55,
+	0xde, 0xfb, 0x48, 0xe6, 0x5f, 0xe4, 0x22, 0x8a, 0x23, 0x60, 0x3e, 0x59, 0xa0, 0xe7, 0xdb, 0xd0,
+	0x10, 0x55, 0xb4, 0xdf, 0x2a, 0xa0, 0x96, 0xe7, 0xc6, 0xe7, 0xd9, 0x9a, 0x8e, 0x1b, 0xf8, 0x21,
+	0x93, 0x6b, 0x62, 0x7e, 0x4d, 0x9d, 0x7b, 0x96, 0x1b, 0x0a, 0x13, 0xbf, 0x87, 0x8e, 0x48, 0xe4,
+	0xb4, 0x4e, 0x69, 0xd8, 0xad, 0xf0, 0xd4, 0xfd, 0x02, 0x43, 0xd2, 0x77, 0xb5, 0x45, 0xda, 0x41,
+	0x1e, 0xc0, 0x6f, 0xb2, 0xbe, 0x21, 0xb5, 0x29, 0x75, 0xbb, 0xd5, 0xff, 0x4c, 0x16, 0x9d, 0x09,
+	0x0f, 0x5d, 0x75, 0xa6, 0xf7, 0xd6, 0xdc, 0xf4, 0x66, 0xb4, 0x5b, 0x7b, 0x40, 0xe7, 0x81, 0x0c,
+	0x3e, 0x6f, 0x40, 0xcd, 0x36, 0x99, 0xa9, 0xfd, 0xa9, 0x00, 0x70, 0x26, 0xae, 0xdf, 0x79, 0x34,
+	0xc4, 0x13, 0xa8, 0xb1, 0x24, 0xa0, 0x9c, 0x80, 0xce, 0xe9, 0x41, 0xfe, 0xad, 0x78, 0x40, 0x6f,
+	0x9c, 0x04, 0x94, 0xf0, 0x18, 0x54, 0xa1, 0x1a, 0x9a, 0xef, 0xf8, 0xc2, 0x2d, 0x92, 0xfe, 0xd4,
+	0x5e, 0x41, 0x2d, 0xf5, 0x23, 0x42, 0x67, 0x78, 0x77, 0x63, 0xe8, 0x97, 0x83, 0xd1, 0x58, 0x7f,
+	0xa9, 0x0f, 0x88, 0xba, 0x85, 0x2a, 0xb4, 0x2e, 0xae, 0x5e, 0xe8, 0xa3, 0x8b, 0xeb, 0xcb, 0x81,
+	0xa1, 0x5f, 0xaa, 0x0a, 0xb6, 0x61, 0x67, 0xf8, 0xe6, 0xf5, 0x58, 0x37, 0xee, 0xf4, 0x1f, 0xd4,
+	0x0a, 0x76, 0x00, 0xf4, 0xcb, 0xc1, 0x50, 0xff, 0xd1, 0x18, 0xfd, 0x34, 0x54, 0xab, 0xda, 0xb7,
+	0xd0, 0xcc, 0x11, 0x8f, 0xcf, 0xe0, 0x91, 0x1f, 0xb3, 0x20, 0x66, 0x51, 0x57, 0x39, 0xae, 0x96,
+	0x5f, 0xe7, 0x9a, 0xbb, 0x48, 0x16, 0xa2, 0x51, 0x68, 0x17, 0x08, 0xc0, 0xcf, 0xa1, 0xe1, 0x78,
+	0xb9, 0xec, 0x4e, 0x7e, 0x35, 0xdd, 0x26, 0xd2, 0x9b, 0x6f, 0x53, 0x79, 0x7f, 0x9b, 0x29, 0x34,
+	0x73, 0x38, 0x7e, 0x01, 0x75, 0x3f, 0x65, 0x49, 0x7e, 0x3f, 0xbb, 0x6b, 0xf4, 0x11, 0xe1, 0x47,
+	0x94, 0x34, 0xa7, 0xdc, 0xed, 0x48, 0x3a, 0x3f, 0x84, 0xed, 0x5f, 0x63, 0xd3, 0x63, 0x0e, 0x4b,
+	0xf8, 0x77, 0x50, 0x23, 0x4b, 0x5b, 0xfb, 0x0a, 0x1e, 0xc9, 0x41, 0x71, 0x0f, 0xea, 0xec, 0xde,
+	0x70, 0xec, 0xae, 0x22, 0x73, 0xef, 0x75, 0x1b, 0xf7, 0xa1, 0xee, 0x78, 0x36, 0xbd, 0xe7, 0x05,
+	0xdb, 0x44, 0x18, 0xda, 0x10, 0xda, 0xc3, 0x78, 0xc1, 0x9c, 0x3b, 0x67, 0x26, 0x5e, 0xf7, 0x63,
+	0xd8, 0x61, 0xf3, 0x90, 0x46, 0x73, 0x7f, 0x21, 0xf2, 0xdb, 0x64, 0x05, 0xe0, 0x11, 0x80, 0x50,
+	0x0d, 0x73, 0xa4, 0x5a, 0x5b, 0x24, 0x87, 0x68, 0xcf, 0xa1, 0x73, 0x31, 0x37, 0x1d, 0xcf, 0xf2,
+	0x6d, 0x2a, 0xea, 0x7d, 0x06, 0x1d, 0x2b, 0x43, 0x0c, 0xcf, 0x74, 0xa9, 0x1c, 0xaa, 0xbd, 0x44,
+	0x47, 0xa6, 0x4b, 0xb5, 0x97, 0xd0, 0x29, 0xaa, 0x1d, 0x0f, 0xa0, 0x91, 0xea, 0x5d, 0x32, 0xd5,
+	0x22, 0xd2, 0x4a, 0x07, 0x5c, 0xde, 0x01, 0xf9, 0x61, 0xad, 0x00, 0xed, 0x8f, 0x0a, 0x1c, 0xfe,
+	0x8b, 0xec, 0xf1, 0x75, 0xe9, 0x66, 0x14, 0x84, 0xfc, 0xc1, 0x86, 0x9b, 0xb1, 0xd4, 0x33, 0x5a,
+	0x6b, 0x28, 0x12, 0x78, 0x52, 0xa8, 0x56, 0x52, 0xf7, 0x47, 0x9b, 0x6e, 0xd0, 0x4a, 0x6a, 0xfb,
+	0xd6, 0x06, 0x1c, 0x47, 0xa5, 0x09, 0x0b, 0x92, 0x7f, 0x4f, 0xc5, 0xc2, 0x8c, 0xe2, 0x00, 0x2c,
+	0x15, 0xac, 0x03, 0xae, 0xef, 0x85, 0x67, 0x65, 0xb9, 0x6c, 0xe2, 0xa0, 0xfc, 0x39, 0xff, 0xad,
+	0xc0, 0xfe, 0xa6, 0x09, 0x1e, 0xac, 0x9e, 0xb3, 0xb2, 0x7a, 0x1e, 0xd0, 0x15, 0xbf, 0x84, 0x5d,
+	0xc1, 0x05, 0xb5, 0x8d, 0x92, 0x02, 0xd4, 0xcc, 0x71, 0x2b, 0x71, 0xfc, 0x14, 0xda, 0x13, 0x73,
+	0x61, 0x7a, 0x16, 0x35, 0x82, 0xd0, 0xf7, 0xa7, 0xfc, 0xea, 0xb5, 0x48, 0x4b, 0x82, 0x37, 0x29,
+	0x86, 0x3d, 0x00, 0x2f, 0x71, 0x45, 0x40, 0xd4, 0xad, 0xf3, 0x49, 0x1e, 0xcb, 0x49, 0x46, 0x89,
+	0xcb, 0x83, 0xc8, 0x8e, 0x27, 0x7f, 0x45, 0xda, 0xef, 0x0a, 0xe0, 0xfa, 0x84, 0xff, 0x4f, 0xce,
+	0x47, 0x00, 0x96, 0xef, 0xba, 0x0e, 0x73, 0xa9, 0xc7, 0xf8, 0x3a, 0x2d, 0x92, 0x43, 0xf0, 0x13,
+	0x68, 0x86, 0xe9, 0x25, 0x2e, 0xac, 0x01, 0x1c, 0xe2, 0x53, 0x69, 0xb7, 0xb0, 0x3d, 0x4a, 0x5c,
+	0x21, 0x34, 0x15, 0xaa, 0x5e, 0xe2, 0x4a, 0xb1, 0xa4, 0x3f, 0xf1, 0x0c, 0x0e, 0x9c, 0x28, 0x8a,
+	0x69, 0x68, 0x04, 0xf1, 0x64, 0xe1, 0x58, 0xc6, 0x5b, 0x9a, 0x18, 0x73, 0x33, 0x9a, 0x4b, 0xd9,
+	0xec, 0x09, 0xef, 0x0d, 0x77, 0xbe, 0xa2, 0xc9, 0x95, 0x19, 0xcd, 0xb5, 0x5f, 0x78, 0x49, 0xc1,
+	0x11, 0x3f, 0x19, 0x41, 0xcc, 0xe4, 0x1d, 0x10, 0x06, 0x9e, 0xc0, 0xee, 0x5a, 0x59, 0x59, 0xf1,
+	0x71, 0xa9, 0x62, 0x51, 0xac, 0xd5, 0xb2, 0x58, 0xdf, 0x40, 0x53, 0x88, 0x3e, 0x0e, 0x82, 0x45,
+	0xb2, 0xa4, 0x48, 0xc9, 0x51, 0x74, 0x00, 0x0d, 0x5e, 0xd3, 0xe6, 0x1d, 0x6a, 0x44, 0x5a, 0xe9,
+	0x25, 0xcc, 0xde, 0x3d, 0xbb, 0x84, 0x99, 0x7d, 0xfe, 0xec, 0xe7, 0x93, 0x99, 0xc3, 0xe6, 0xf1,
+	0xa4, 0x67, 0xf9, 0x6e, 0x7f, 0x9e, 0x04, 0x34, 0x5c, 0x50, 0x7b, 0x46, 0xc3, 0xfe, 0xd4, 0x9c,
+	0x84, 0x8e, 0xd5, 0xe7, 0xff, 0x69, 0xa2, 0x3e, 0x7f, 0xaa, 0x49, 0x83, 0x5b, 0x67, 0xff, 0x0c,
+	0x00, 0x75, 0x93, 0xbe, 0xea, 0xfc, 0x08, 0x00, 0x00,
 }
@@ -9,9 +9,11 @@ package client
 import (
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/token"
 	tk "github.com/hyperledger/fabric/token"
+	"github.com/pkg/errors"
 )
 
 //go:generate counterfeiter -o mock/prover.go -fake-name Prover . Prover
@@ -37,9 +39,21 @@ type Prover interface {
 	// possibly another output to transfer the remaining tokens, if any, to the same user
 	RequestRedeem(tokenIDs []*token.TokenId, quantity uint64, signingIdentity tk.SigningIdentity) ([]byte, error)
 
+	// RequestExchange allows the client to submit an exchange request to a prover peer service;
+	// the function takes as parameters the contributions of the owners taking part in the exchange,
+	// each with the identifiers of the tokens they spend and the outputs created in their place;
+	// it returns a response in bytes and an error message in the case the request fails
+	RequestExchange(contributions []*token.ExchangeContribution, signingIdentity tk.SigningIdentity) ([]byte, error)
+
 	// ListTokens allows the client to submit a list request to a prover peer service;
 	// it returns a list of TokenOutput and an error message in the case the request fails
 	ListTokens(signingIdentity tk.SigningIdentity) ([]*token.TokenOutput, error)
+
+	// RequestSupply allows the client to submit a supply request to a prover peer service;
+	// it returns the quantities of the passed token types, or of all the token types if no
+	// type is passed, that have been issued and redeemed, and an error message in the case
+	// the request fails
+	RequestSupply(types []string, signingIdentity tk.SigningIdentity) ([]*token.TokenSupply, error)
 }
 
 //go:generate counterfeiter -o mock/fabric_tx_submitter.go -fake-name FabricTxSubmitter . FabricTxSubmitter
@@ -170,8 +184,108 @@ func (c *Client) Redeem(tokenIDs []*token.TokenId, quantity uint64, waitTimeout
 	return txEnvelope, txid, ordererStatus, committed, err
 }
 
+// PrepareTransfer requests a transfer to the prover peer without submitting it.
+// It returns the serialized TokenTransaction, which the joint owners of the tokens
+// sign with SignTokenTransaction before it is submitted with SubmitTokenTransaction.
+func (c *Client) PrepareTransfer(tokenIDs []*token.TokenId, shares []*token.RecipientTransferShare) ([]byte, error) {
+	return c.Prover.RequestTransfer(tokenIDs, shares, c.SigningIdentity)
+}
+
+// PrepareRedeem requests a redemption to the prover peer without submitting it.
+// It returns the serialized TokenTransaction, which the joint owners of the tokens
+// sign with SignTokenTransaction before it is submitted with SubmitTokenTransaction.
+func (c *Client) PrepareRedeem(tokenIDs []*token.TokenId, quantity uint64) ([]byte, error) {
+	return c.Prover.RequestRedeem(tokenIDs, quantity, c.SigningIdentity)
+}
+
+// PrepareExchange requests an exchange between the owners of the contributions to the prover peer.
+// It returns the serialized TokenTransaction, which each owner signs with SignTokenTransaction
+// before it is submitted with SubmitTokenTransaction. The exchange is committed atomically,
+// so no owner spends their tokens unless all the outputs are created.
+func (c *Client) PrepareExchange(contributions []*token.ExchangeContribution) ([]byte, error) {
+	return c.Prover.RequestExchange(contributions, c.SigningIdentity)
+}
+
+// SignTokenTransaction adds the signature of the client on the action of the
+// serialized TokenTransaction, as a joint owner of the tokens that it spends or
+// as an owner contributing tokens to an exchange.
+// It returns the serialized TokenTransaction carrying the signature.
+func (c *Client) SignTokenTransaction(tokenTx []byte) ([]byte, error) {
+	tx := &token.TokenTransaction{}
+	err := proto.Unmarshal(tokenTx, tx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling token transaction")
+	}
+	if tx.GetPlainAction() == nil {
+		return nil, errors.New("no plain action in token transaction")
+	}
+
+	action, err := proto.Marshal(tx.GetPlainAction())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed marshaling token action")
+	}
+	signer, err := c.SigningIdentity.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	signature, err := c.SigningIdentity.Sign(action)
+	if err != nil {
+		return nil, err
+	}
+
+	tx.Signatures = append(tx.Signatures, &token.TokenSignature{Signer: signer, Signature: signature})
+	return proto.Marshal(tx)
+}
+
+// SubmitTokenTransaction submits a serialized TokenTransaction, as returned by
+// PrepareTransfer, PrepareRedeem, PrepareExchange and SignTokenTransaction, to the orderer.
+// The 'waitTimeout' parameter and the values returned are the same as for Transfer.
+func (c *Client) SubmitTokenTransaction(tokenTx []byte, waitTimeout time.Duration) (*common.Envelope, string, *common.Status, bool, error) {
+	txEnvelope, txid, err := c.TxSubmitter.CreateTxEnvelope(tokenTx)
+	if err != nil {
+		return nil, "", nil, false, err
+	}
+
+	ordererStatus, committed, err := c.TxSubmitter.Submit(txEnvelope, waitTimeout)
+	return txEnvelope, txid, ordererStatus, committed, err
+}
+
+// NewMultiSigOwner returns a TokenOwner for the tokens jointly owned by the passed
+// serialized identities, which are spent by transactions created or signed by at
+// least threshold of them.
+func NewMultiSigOwner(threshold uint32, identities ...[]byte) (*token.TokenOwner, error) {
+	if threshold == 0 || int(threshold) > len(identities) {
+		return nil, errors.Errorf("threshold %d is not between 1 and the number of identities (%d)", threshold, len(identities))
+	}
+	raw, err := proto.Marshal(&token.MultiSigOwner{Threshold: threshold, Identities: identities})
+	if err != nil {
+		return nil, err
+	}
+	return &token.TokenOwner{Type: token.TokenOwner_MULTI_SIG, Raw: raw}, nil
+}
+
+// NewChaincodeOwner returns a TokenOwner for the tokens owned by the passed chaincode,
+// which are spent by transactions that the chaincode approves.
+func NewChaincodeOwner(chaincodeName string) (*token.TokenOwner, error) {
+	if chaincodeName == "" {
+		return nil, errors.New("missing chaincode name")
+	}
+	raw, err := proto.Marshal(&token.ChaincodeOwner{ChaincodeName: chaincodeName})
+	if err != nil {
+		return nil, err
+	}
+	return &token.TokenOwner{Type: token.TokenOwner_CHAINCODE_ID, Raw: raw}, nil
+}
+
 // ListTokens allows the client to submit a list request to a prover peer service;
 // it returns a list of TokenOutput and an error in the case the request fails
 func (c *Client) ListTokens() ([]*token.TokenOutput, error) {
 	return c.Prover.ListTokens(c.SigningIdentity)
 }
+
+// QuerySupply allows an auditor to query the quantities of the passed token types,
+// or of all the token types if no type is passed, that have been issued and redeemed;
+// it returns an error in the case the request fails
+func (c *Client) QuerySupply(types []string) ([]*token.TokenSupply, error) {
+	return c.Prover.RequestSupply(types, c.SigningIdentity)
+}
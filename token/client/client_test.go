@@ -11,6 +11,7 @@ import (
 	"net"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/token"
 	"github.com/hyperledger/fabric/token/client"
@@ -45,6 +46,7 @@ var _ = Describe("Client", func() {
 		fakeProver.RequestImportReturns(payload.Data, nil) // same data as payload
 		fakeProver.RequestTransferReturns(payload.Data, nil)
 		fakeProver.RequestRedeemReturns(payload.Data, nil)
+		fakeProver.RequestExchangeReturns(payload.Data, nil)
 
 		fakeSigningIdentity = &mock.SigningIdentity{}
 		fakeSigningIdentity.SerializeReturns([]byte("creator"), nil) // same signature as envelope
@@ -357,6 +359,162 @@ var _ = Describe("Client", func() {
 		})
 	})
 
+	Describe("PrepareTransfer, PrepareRedeem and PrepareExchange", func() {
+		var tokenIDs []*token.TokenId
+
+		BeforeEach(func() {
+			tokenIDs = []*token.TokenId{{TxId: "id1", Index: 0}}
+		})
+
+		It("returns the transfer of the prover without submitting it", func() {
+			shares := []*token.RecipientTransferShare{{Recipient: &token.TokenOwner{Raw: []byte("alice")}, Quantity: 100}}
+			tokenTx, err := tokenClient.PrepareTransfer(tokenIDs, shares)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tokenTx).To(Equal(payload.Data))
+
+			Expect(fakeProver.RequestTransferCallCount()).To(Equal(1))
+			ids, requestShares, signingIdentity := fakeProver.RequestTransferArgsForCall(0)
+			Expect(ids).To(Equal(tokenIDs))
+			Expect(requestShares).To(Equal(shares))
+			Expect(signingIdentity).To(Equal(fakeSigningIdentity))
+			Expect(fakeTxSubmitter.CreateTxEnvelopeCallCount()).To(Equal(0))
+		})
+
+		It("returns the redemption of the prover without submitting it", func() {
+			tokenTx, err := tokenClient.PrepareRedeem(tokenIDs, 50)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tokenTx).To(Equal(payload.Data))
+
+			Expect(fakeProver.RequestRedeemCallCount()).To(Equal(1))
+			ids, quantity, signingIdentity := fakeProver.RequestRedeemArgsForCall(0)
+			Expect(ids).To(Equal(tokenIDs))
+			Expect(quantity).To(Equal(uint64(50)))
+			Expect(signingIdentity).To(Equal(fakeSigningIdentity))
+			Expect(fakeTxSubmitter.CreateTxEnvelopeCallCount()).To(Equal(0))
+		})
+
+		It("returns the exchange of the prover without submitting it", func() {
+			contributions := []*token.ExchangeContribution{
+				{
+					Owner:    []byte("creator"),
+					TokenIds: tokenIDs,
+					Shares:   []*token.ExchangeShare{{Recipient: &token.TokenOwner{Raw: []byte("bob")}, Type: "TOK1", Quantity: 100}},
+				},
+				{
+					Owner:    []byte("bob"),
+					TokenIds: []*token.TokenId{{TxId: "id2", Index: 0}},
+					Shares:   []*token.ExchangeShare{{Recipient: &token.TokenOwner{Raw: []byte("creator")}, Type: "TOK2", Quantity: 10}},
+				},
+			}
+			tokenTx, err := tokenClient.PrepareExchange(contributions)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tokenTx).To(Equal(payload.Data))
+
+			Expect(fakeProver.RequestExchangeCallCount()).To(Equal(1))
+			requestContributions, signingIdentity := fakeProver.RequestExchangeArgsForCall(0)
+			Expect(requestContributions).To(Equal(contributions))
+			Expect(signingIdentity).To(Equal(fakeSigningIdentity))
+			Expect(fakeTxSubmitter.CreateTxEnvelopeCallCount()).To(Equal(0))
+		})
+	})
+
+	Describe("SignTokenTransaction", func() {
+		var (
+			tokenTx *token.TokenTransaction
+			action  *token.PlainTokenAction
+		)
+
+		BeforeEach(func() {
+			action = &token.PlainTokenAction{
+				Data: &token.PlainTokenAction_PlainTransfer{
+					PlainTransfer: &token.PlainTransfer{
+						Inputs:  []*token.TokenId{{TxId: "id1", Index: 0}},
+						Outputs: []*token.PlainOutput{{Owner: &token.TokenOwner{Raw: []byte("alice")}, Type: "TOK1", Quantity: 100}},
+					},
+				},
+			}
+			tokenTx = &token.TokenTransaction{
+				Action:     &token.TokenTransaction_PlainAction{PlainAction: action},
+				Signatures: []*token.TokenSignature{{Signer: []byte("bob"), Signature: []byte("bob-signature")}},
+			}
+		})
+
+		It("adds the signature of the client on the action", func() {
+			signedTx, err := tokenClient.SignTokenTransaction(ProtoMarshal(tokenTx))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeSigningIdentity.SignCallCount()).To(Equal(1))
+			Expect(fakeSigningIdentity.SignArgsForCall(0)).To(Equal(ProtoMarshal(action)))
+
+			tx := &token.TokenTransaction{}
+			err = proto.Unmarshal(signedTx, tx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Equal(tx.GetPlainAction(), action)).To(BeTrue())
+			Expect(tx.Signatures).To(HaveLen(2))
+			Expect(proto.Equal(tx.Signatures[0], &token.TokenSignature{Signer: []byte("bob"), Signature: []byte("bob-signature")})).To(BeTrue())
+			Expect(proto.Equal(tx.Signatures[1], &token.TokenSignature{Signer: []byte("creator"), Signature: []byte("tx-signature")})).To(BeTrue())
+		})
+
+		Context("when the transaction cannot be unmarshaled", func() {
+			It("returns an error", func() {
+				_, err := tokenClient.SignTokenTransaction([]byte("garbage"))
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("failed unmarshaling token transaction"))
+			})
+		})
+
+		Context("when the transaction has no plain action", func() {
+			It("returns an error", func() {
+				_, err := tokenClient.SignTokenTransaction(ProtoMarshal(&token.TokenTransaction{}))
+				Expect(err).To(MatchError("no plain action in token transaction"))
+			})
+		})
+
+		Context("when signing fails", func() {
+			BeforeEach(func() {
+				fakeSigningIdentity.SignReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := tokenClient.SignTokenTransaction(ProtoMarshal(tokenTx))
+				Expect(err).To(MatchError("wild-banana"))
+			})
+		})
+	})
+
+	Describe("SubmitTokenTransaction", func() {
+		It("submits the transaction", func() {
+			txEnvelope, txid, ordererStatus, committed, err := tokenClient.SubmitTokenTransaction([]byte("signed-tx"), 10*time.Second)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(txEnvelope).To(Equal(envelope))
+			Expect(txid).To(Equal(expectedTxid))
+			Expect(*ordererStatus).To(Equal(common.Status_SUCCESS))
+			Expect(committed).To(Equal(true))
+
+			Expect(fakeTxSubmitter.CreateTxEnvelopeCallCount()).To(Equal(1))
+			Expect(fakeTxSubmitter.CreateTxEnvelopeArgsForCall(0)).To(Equal([]byte("signed-tx")))
+			Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(1))
+			_, waitTime := fakeTxSubmitter.SubmitArgsForCall(0)
+			Expect(waitTime).To(Equal(10 * time.Second))
+		})
+
+		Context("when TxSubmitter CreateTxEnvelope fails", func() {
+			BeforeEach(func() {
+				fakeTxSubmitter.CreateTxEnvelopeReturns(nil, "", errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				txEnvelope, txid, ordererStatus, committed, err := tokenClient.SubmitTokenTransaction([]byte("signed-tx"), 0)
+				Expect(err).To(MatchError("wild-banana"))
+				Expect(txEnvelope).To(BeNil())
+				Expect(txid).To(Equal(""))
+				Expect(ordererStatus).To(BeNil())
+				Expect(committed).To(Equal(false))
+				Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(0))
+			})
+		})
+	})
+
 	Describe("ListTokens", func() {
 		var (
 			expectedTokens []*token.TokenOutput
@@ -397,6 +555,41 @@ var _ = Describe("Client", func() {
 		})
 	})
 
+	Describe("QuerySupply", func() {
+		var (
+			expectedSupplies []*token.TokenSupply
+		)
+
+		BeforeEach(func() {
+			expectedSupplies = []*token.TokenSupply{
+				{Type: "typeaz", Issued: 135, Redeemed: 35},
+			}
+			fakeProver.RequestSupplyReturns(expectedSupplies, nil)
+		})
+
+		It("returns the supplies", func() {
+			supplies, err := tokenClient.QuerySupply([]string{"typeaz"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(supplies).To(Equal(expectedSupplies))
+
+			Expect(fakeProver.RequestSupplyCallCount()).To(Equal(1))
+			types, signingIdentity := fakeProver.RequestSupplyArgsForCall(0)
+			Expect(types).To(Equal([]string{"typeaz"}))
+			Expect(signingIdentity).To(Equal(tokenClient.SigningIdentity))
+		})
+
+		Context("when prover.RequestSupply returns an error", func() {
+			BeforeEach(func() {
+				fakeProver.RequestSupplyReturns(nil, errors.New("banana-loop"))
+			})
+
+			It("returns an error", func() {
+				_, err := tokenClient.QuerySupply(nil)
+				Expect(err).To(MatchError("banana-loop"))
+			})
+		})
+	})
+
 	Describe("NewClient", func() {
 		var (
 			config          *client.ClientConfig
@@ -521,3 +714,44 @@ var _ = Describe("Client", func() {
 		})
 	})
 })
+
+var _ = Describe("NewMultiSigOwner", func() {
+	It("returns a multi-signature token owner", func() {
+		owner, err := client.NewMultiSigOwner(2, []byte("alice"), []byte("bob"), []byte("charlie"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(owner.Type).To(Equal(token.TokenOwner_MULTI_SIG))
+
+		multiSigOwner := &token.MultiSigOwner{}
+		err = proto.Unmarshal(owner.Raw, multiSigOwner)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(multiSigOwner.Threshold).To(Equal(uint32(2)))
+		Expect(multiSigOwner.Identities).To(Equal([][]byte{[]byte("alice"), []byte("bob"), []byte("charlie")}))
+	})
+
+	Context("when the threshold exceeds the number of identities", func() {
+		It("returns an error", func() {
+			_, err := client.NewMultiSigOwner(3, []byte("alice"), []byte("bob"))
+			Expect(err).To(MatchError("threshold 3 is not between 1 and the number of identities (2)"))
+		})
+	})
+})
+
+var _ = Describe("NewChaincodeOwner", func() {
+	It("returns a chaincode token owner", func() {
+		owner, err := client.NewChaincodeOwner("escrow")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(owner.Type).To(Equal(token.TokenOwner_CHAINCODE_ID))
+
+		chaincodeOwner := &token.ChaincodeOwner{}
+		err = proto.Unmarshal(owner.Raw, chaincodeOwner)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(chaincodeOwner.ChaincodeName).To(Equal("escrow"))
+	})
+
+	Context("when the chaincode name is missing", func() {
+		It("returns an error", func() {
+			_, err := client.NewChaincodeOwner("")
+			Expect(err).To(MatchError("missing chaincode name"))
+		})
+	})
+})
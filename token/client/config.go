@@ -7,18 +7,20 @@ SPDX-License-Identifier: Apache-2.0
 package client
 
 import (
+	"io/ioutil"
 	"time"
 
 	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
 )
 
 // ConnectionConfig contains data required to establish grpc connection to a peer or orderer
 type ConnectionConfig struct {
-	Address            string
-	ConnectionTimeout  time.Duration
-	TLSEnabled         bool
-	TLSRootCertFile    string
-	ServerNameOverride string
+	Address            string        `yaml:"address"`
+	ConnectionTimeout  time.Duration `yaml:"connectionTimeout"`
+	TLSEnabled         bool          `yaml:"tlsEnabled"`
+	TLSRootCertFile    string        `yaml:"tlsRootCertFile"`
+	ServerNameOverride string        `yaml:"serverNameOverride"`
 }
 
 type MSPInfo struct {
@@ -30,11 +32,25 @@ type MSPInfo struct {
 // ClientConfig will be updated after the CR for token client config is merged, where the config data
 // will be populated based on a config file.
 type ClientConfig struct {
-	ChannelID     string
-	MSPInfo       MSPInfo
-	Orderer       ConnectionConfig
-	CommitterPeer ConnectionConfig
-	ProverPeer    ConnectionConfig
+	ChannelID     string           `yaml:"channelId"`
+	MSPInfo       MSPInfo          `yaml:"mspInfo"`
+	Orderer       ConnectionConfig `yaml:"orderer"`
+	CommitterPeer ConnectionConfig `yaml:"committerPeer"`
+	ProverPeer    ConnectionConfig `yaml:"proverPeer"`
+}
+
+// ConfigFromFile loads the given YAML or JSON file and converts it to a ClientConfig
+func ConfigFromFile(file string) (*ClientConfig, error) {
+	configData, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed reading config file %s", file)
+	}
+
+	config := &ClientConfig{}
+	if err := yaml.Unmarshal(configData, config); err != nil {
+		return nil, errors.Wrapf(err, "failed unmarshaling config file %s", file)
+	}
+	return config, nil
 }
 
 func ValidateClientConfig(config ClientConfig) error {
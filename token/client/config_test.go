@@ -7,9 +7,15 @@ SPDX-License-Identifier: Apache-2.0
 package client_test
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
 	"github.com/hyperledger/fabric/token/client"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	yaml "gopkg.in/yaml.v2"
 )
 
 var _ = Describe("ClientConfig", func() {
@@ -150,4 +156,63 @@ var _ = Describe("ClientConfig", func() {
 			Expect(err).To(MatchError("missing prover peer TLSRootCertFile"))
 		})
 	})
+
+	Describe("ConfigFromFile", func() {
+		var tempDir string
+
+		BeforeEach(func() {
+			var err error
+			tempDir, err = ioutil.TempDir("", "token-client-config")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(tempDir)
+		})
+
+		It("loads the config", func() {
+			configFile := filepath.Join(tempDir, "config.yaml")
+			configData, err := yaml.Marshal(config)
+			Expect(err).NotTo(HaveOccurred())
+			err = ioutil.WriteFile(configFile, configData, 0600)
+			Expect(err).NotTo(HaveOccurred())
+
+			loaded, err := client.ConfigFromFile(configFile)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*loaded).To(Equal(config))
+		})
+
+		It("loads a JSON config", func() {
+			configFile := filepath.Join(tempDir, "config.json")
+			configData := `{"channelId": "test-channel", "proverPeer": {"address": "127.0.0.1:0", "connectionTimeout": "10s"}}`
+			err := ioutil.WriteFile(configFile, []byte(configData), 0600)
+			Expect(err).NotTo(HaveOccurred())
+
+			loaded, err := client.ConfigFromFile(configFile)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(loaded.ChannelID).To(Equal("test-channel"))
+			Expect(loaded.ProverPeer.Address).To(Equal("127.0.0.1:0"))
+			Expect(loaded.ProverPeer.ConnectionTimeout).To(Equal(10 * time.Second))
+		})
+
+		Context("when the file does not exist", func() {
+			It("returns an error", func() {
+				_, err := client.ConfigFromFile(filepath.Join(tempDir, "missing.yaml"))
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("failed reading config file"))
+			})
+		})
+
+		Context("when the file is not valid", func() {
+			It("returns an error", func() {
+				configFile := filepath.Join(tempDir, "config.yaml")
+				err := ioutil.WriteFile(configFile, []byte("channelId: [test-channel"), 0600)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = client.ConfigFromFile(configFile)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("failed unmarshaling config file"))
+			})
+		})
+	})
 })
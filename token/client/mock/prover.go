@@ -23,6 +23,20 @@ type Prover struct {
 		result1 []*token.TokenOutput
 		result2 error
 	}
+	RequestExchangeStub        func([]*token.ExchangeContribution, tokena.SigningIdentity) ([]byte, error)
+	requestExchangeMutex       sync.RWMutex
+	requestExchangeArgsForCall []struct {
+		arg1 []*token.ExchangeContribution
+		arg2 tokena.SigningIdentity
+	}
+	requestExchangeReturns struct {
+		result1 []byte
+		result2 error
+	}
+	requestExchangeReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
 	RequestImportStub        func([]*token.TokenToIssue, tokena.SigningIdentity) ([]byte, error)
 	requestImportMutex       sync.RWMutex
 	requestImportArgsForCall []struct {
@@ -52,6 +66,20 @@ type Prover struct {
 		result1 []byte
 		result2 error
 	}
+	RequestSupplyStub        func([]string, tokena.SigningIdentity) ([]*token.TokenSupply, error)
+	requestSupplyMutex       sync.RWMutex
+	requestSupplyArgsForCall []struct {
+		arg1 []string
+		arg2 tokena.SigningIdentity
+	}
+	requestSupplyReturns struct {
+		result1 []*token.TokenSupply
+		result2 error
+	}
+	requestSupplyReturnsOnCall map[int]struct {
+		result1 []*token.TokenSupply
+		result2 error
+	}
 	RequestTransferStub        func([]*token.TokenId, []*token.RecipientTransferShare, tokena.SigningIdentity) ([]byte, error)
 	requestTransferMutex       sync.RWMutex
 	requestTransferArgsForCall []struct {
@@ -134,6 +162,75 @@ func (fake *Prover) ListTokensReturnsOnCall(i int, result1 []*token.TokenOutput,
 	}{result1, result2}
 }
 
+func (fake *Prover) RequestExchange(arg1 []*token.ExchangeContribution, arg2 tokena.SigningIdentity) ([]byte, error) {
+	var arg1Copy []*token.ExchangeContribution
+	if arg1 != nil {
+		arg1Copy = make([]*token.ExchangeContribution, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.requestExchangeMutex.Lock()
+	ret, specificReturn := fake.requestExchangeReturnsOnCall[len(fake.requestExchangeArgsForCall)]
+	fake.requestExchangeArgsForCall = append(fake.requestExchangeArgsForCall, struct {
+		arg1 []*token.ExchangeContribution
+		arg2 tokena.SigningIdentity
+	}{arg1Copy, arg2})
+	fake.recordInvocation("RequestExchange", []interface{}{arg1Copy, arg2})
+	fake.requestExchangeMutex.Unlock()
+	if fake.RequestExchangeStub != nil {
+		return fake.RequestExchangeStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.requestExchangeReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Prover) RequestExchangeCallCount() int {
+	fake.requestExchangeMutex.RLock()
+	defer fake.requestExchangeMutex.RUnlock()
+	return len(fake.requestExchangeArgsForCall)
+}
+
+func (fake *Prover) RequestExchangeCalls(stub func([]*token.ExchangeContribution, tokena.SigningIdentity) ([]byte, error)) {
+	fake.requestExchangeMutex.Lock()
+	defer fake.requestExchangeMutex.Unlock()
+	fake.RequestExchangeStub = stub
+}
+
+func (fake *Prover) RequestExchangeArgsForCall(i int) ([]*token.ExchangeContribution, tokena.SigningIdentity) {
+	fake.requestExchangeMutex.RLock()
+	defer fake.requestExchangeMutex.RUnlock()
+	argsForCall := fake.requestExchangeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Prover) RequestExchangeReturns(result1 []byte, result2 error) {
+	fake.requestExchangeMutex.Lock()
+	defer fake.requestExchangeMutex.Unlock()
+	fake.RequestExchangeStub = nil
+	fake.requestExchangeReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) RequestExchangeReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.requestExchangeMutex.Lock()
+	defer fake.requestExchangeMutex.Unlock()
+	fake.RequestExchangeStub = nil
+	if fake.requestExchangeReturnsOnCall == nil {
+		fake.requestExchangeReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.requestExchangeReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Prover) RequestImport(arg1 []*token.TokenToIssue, arg2 tokena.SigningIdentity) ([]byte, error) {
 	var arg1Copy []*token.TokenToIssue
 	if arg1 != nil {
@@ -273,6 +370,75 @@ func (fake *Prover) RequestRedeemReturnsOnCall(i int, result1 []byte, result2 er
 	}{result1, result2}
 }
 
+func (fake *Prover) RequestSupply(arg1 []string, arg2 tokena.SigningIdentity) ([]*token.TokenSupply, error) {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.requestSupplyMutex.Lock()
+	ret, specificReturn := fake.requestSupplyReturnsOnCall[len(fake.requestSupplyArgsForCall)]
+	fake.requestSupplyArgsForCall = append(fake.requestSupplyArgsForCall, struct {
+		arg1 []string
+		arg2 tokena.SigningIdentity
+	}{arg1Copy, arg2})
+	fake.recordInvocation("RequestSupply", []interface{}{arg1Copy, arg2})
+	fake.requestSupplyMutex.Unlock()
+	if fake.RequestSupplyStub != nil {
+		return fake.RequestSupplyStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.requestSupplyReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Prover) RequestSupplyCallCount() int {
+	fake.requestSupplyMutex.RLock()
+	defer fake.requestSupplyMutex.RUnlock()
+	return len(fake.requestSupplyArgsForCall)
+}
+
+func (fake *Prover) RequestSupplyCalls(stub func([]string, tokena.SigningIdentity) ([]*token.TokenSupply, error)) {
+	fake.requestSupplyMutex.Lock()
+	defer fake.requestSupplyMutex.Unlock()
+	fake.RequestSupplyStub = stub
+}
+
+func (fake *Prover) RequestSupplyArgsForCall(i int) ([]string, tokena.SigningIdentity) {
+	fake.requestSupplyMutex.RLock()
+	defer fake.requestSupplyMutex.RUnlock()
+	argsForCall := fake.requestSupplyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Prover) RequestSupplyReturns(result1 []*token.TokenSupply, result2 error) {
+	fake.requestSupplyMutex.Lock()
+	defer fake.requestSupplyMutex.Unlock()
+	fake.RequestSupplyStub = nil
+	fake.requestSupplyReturns = struct {
+		result1 []*token.TokenSupply
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) RequestSupplyReturnsOnCall(i int, result1 []*token.TokenSupply, result2 error) {
+	fake.requestSupplyMutex.Lock()
+	defer fake.requestSupplyMutex.Unlock()
+	fake.RequestSupplyStub = nil
+	if fake.requestSupplyReturnsOnCall == nil {
+		fake.requestSupplyReturnsOnCall = make(map[int]struct {
+			result1 []*token.TokenSupply
+			result2 error
+		})
+	}
+	fake.requestSupplyReturnsOnCall[i] = struct {
+		result1 []*token.TokenSupply
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Prover) RequestTransfer(arg1 []*token.TokenId, arg2 []*token.RecipientTransferShare, arg3 tokena.SigningIdentity) ([]byte, error) {
 	var arg1Copy []*token.TokenId
 	if arg1 != nil {
@@ -353,10 +519,14 @@ func (fake *Prover) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.listTokensMutex.RLock()
 	defer fake.listTokensMutex.RUnlock()
+	fake.requestExchangeMutex.RLock()
+	defer fake.requestExchangeMutex.RUnlock()
 	fake.requestImportMutex.RLock()
 	defer fake.requestImportMutex.RUnlock()
 	fake.requestRedeemMutex.RLock()
 	defer fake.requestRedeemMutex.RUnlock()
+	fake.requestSupplyMutex.RLock()
+	defer fake.requestSupplyMutex.RUnlock()
 	fake.requestTransferMutex.RLock()
 	defer fake.requestTransferMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
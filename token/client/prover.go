@@ -139,6 +139,24 @@ func (prover *ProverPeer) RequestRedeem(tokenIDs []*token.TokenId, quantity uint
 	return prover.SendCommand(context.Background(), sc)
 }
 
+// RequestExchange allows the client to submit an exchange request to a prover peer service;
+// the function takes as parameters the contributions of the owners taking part in the exchange;
+// it returns a marshalled token transaction, to be signed by the owners, and an error message
+// in the case the request fails
+func (prover *ProverPeer) RequestExchange(contributions []*token.ExchangeContribution, signingIdentity tk.SigningIdentity) ([]byte, error) {
+	er := &token.ExchangeRequest{
+		Contributions: contributions,
+	}
+	payload := &token.Command_ExchangeRequest{ExchangeRequest: er}
+
+	sc, err := prover.CreateSignedCommand(payload, signingIdentity)
+	if err != nil {
+		return nil, err
+	}
+
+	return prover.SendCommand(context.Background(), sc)
+}
+
 // ListTokens allows the client to submit a list request to a prover peer service;
 // it returns a list of TokenOutput and an error message in the case the request fails
 func (prover *ProverPeer) ListTokens(signingIdentity tk.SigningIdentity) ([]*token.TokenOutput, error) {
@@ -159,6 +177,27 @@ func (prover *ProverPeer) ListTokens(signingIdentity tk.SigningIdentity) ([]*tok
 	return commandResp.GetUnspentTokens().GetTokens(), nil
 }
 
+// RequestSupply allows the client to submit a supply request to a prover peer service;
+// it returns the issued and redeemed quantities of the passed token types, or of all the
+// token types if no type is passed, and an error message in the case the request fails
+func (prover *ProverPeer) RequestSupply(types []string, signingIdentity tk.SigningIdentity) ([]*token.TokenSupply, error) {
+	payload := &token.Command_SupplyRequest{SupplyRequest: &token.SupplyRequest{Types: types}}
+	sc, err := prover.CreateSignedCommand(payload, signingIdentity)
+	if err != nil {
+		return nil, err
+	}
+
+	commandResp, err := prover.processCommand(context.Background(), sc)
+	if err != nil {
+		return nil, err
+	}
+
+	if commandResp.GetTokenSupplies() == nil {
+		return nil, errors.New("no TokenSupplies in command response")
+	}
+	return commandResp.GetTokenSupplies().GetSupplies(), nil
+}
+
 // SendCommand is for issue, transfer and redeem commands that will create a token transaction.
 // It calls prover to process command and returns marshalled token transaction.
 func (prover *ProverPeer) SendCommand(ctx context.Context, sc *token.SignedCommand) ([]byte, error) {
@@ -263,6 +302,10 @@ func commandFromPayload(payload interface{}) (*token.Command, error) {
 		return &token.Command{Payload: t}, nil
 	case *token.Command_ListRequest:
 		return &token.Command{Payload: t}, nil
+	case *token.Command_ExchangeRequest:
+		return &token.Command{Payload: t}, nil
+	case *token.Command_SupplyRequest:
+		return &token.Command{Payload: t}, nil
 	default:
 		return nil, errors.Errorf("command type not recognized: %T", t)
 	}
@@ -456,6 +456,82 @@ var _ = Describe("TokenClient", func() {
 		})
 	})
 
+	Describe("RequestExchange", func() {
+		var (
+			contributions     []*token.ExchangeContribution
+			marshalledCommand []byte
+			signedCommand     *token.SignedCommand
+		)
+
+		BeforeEach(func() {
+			// input data for exchange
+			contributions = []*token.ExchangeContribution{
+				{
+					Owner:    []byte("alice"),
+					TokenIds: []*token.TokenId{{TxId: "id1", Index: 0}},
+					Shares:   []*token.ExchangeShare{{Recipient: &token.TokenOwner{Raw: []byte("bob")}, Type: "TOK1", Quantity: 100}},
+				},
+				{
+					Owner:    []byte("bob"),
+					TokenIds: []*token.TokenId{{TxId: "id2", Index: 0}},
+					Shares:   []*token.ExchangeShare{{Recipient: &token.TokenOwner{Raw: []byte("alice")}, Type: "TOK2", Quantity: 10}},
+				},
+			}
+
+			command := &token.Command{
+				Header: commandHeader,
+				Payload: &token.Command_ExchangeRequest{
+					ExchangeRequest: &token.ExchangeRequest{
+						Contributions: contributions,
+					},
+				},
+			}
+			marshalledCommand = ProtoMarshal(command)
+			signedCommand = &token.SignedCommand{
+				Command:   marshalledCommand,
+				Signature: []byte("pineapple"),
+			}
+		})
+
+		It("returns serialized token transaction", func() {
+			response, err := prover.RequestExchange(contributions, fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response).To(Equal(serializedTokenTx))
+
+			Expect(fakeSigningIdentity.SignCallCount()).To(Equal(1))
+			raw := fakeSigningIdentity.SignArgsForCall(0)
+			Expect(raw).To(Equal(marshalledCommand))
+
+			Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(1))
+			_, sc, _ := fakeProverClient.ProcessCommandArgsForCall(0)
+			Expect(sc).To(Equal(signedCommand))
+		})
+
+		Context("when SigningIdentity sign fails", func() {
+			BeforeEach(func() {
+				fakeSigningIdentity.SignReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := prover.RequestExchange(contributions, fakeSigningIdentity)
+				Expect(err).To(MatchError("wild-banana"))
+				Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when processcommand fails", func() {
+			BeforeEach(func() {
+				fakeProverClient.ProcessCommandReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := prover.RequestExchange(contributions, fakeSigningIdentity)
+				Expect(err).To(MatchError("wild-banana"))
+				Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(1))
+			})
+		})
+	})
+
 	Describe("ListTokens", func() {
 		var (
 			marshalledCommand []byte
@@ -606,6 +682,96 @@ var _ = Describe("TokenClient", func() {
 		})
 	})
 
+	Describe("RequestSupply", func() {
+		var (
+			marshalledCommand []byte
+			signedCommand     *token.SignedCommand
+			expectedSupplies  []*token.TokenSupply
+		)
+		BeforeEach(func() {
+			command := &token.Command{
+				Header: commandHeader,
+				Payload: &token.Command_SupplyRequest{
+					SupplyRequest: &token.SupplyRequest{Types: []string{"typeaz"}},
+				},
+			}
+			marshalledCommand = ProtoMarshal(command)
+			signedCommand = &token.SignedCommand{
+				Command:   marshalledCommand,
+				Signature: []byte("pineapple"),
+			}
+
+			expectedSupplies = []*token.TokenSupply{
+				{Type: "typeaz", Issued: 135, Redeemed: 35},
+			}
+			commandResp := &token.CommandResponse{
+				Payload: &token.CommandResponse_TokenSupplies{
+					TokenSupplies: &token.TokenSupplies{
+						Supplies: expectedSupplies,
+					},
+				},
+			}
+			signedCommandResp = &token.SignedCommandResponse{
+				Response:  ProtoMarshal(commandResp),
+				Signature: []byte("response-signature"),
+			}
+
+			fakeProverClient.ProcessCommandReturns(signedCommandResp, nil)
+		})
+
+		It("returns the supplies", func() {
+			supplies, err := prover.RequestSupply([]string{"typeaz"}, fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(supplies).To(HaveLen(1))
+			Expect(proto.Equal(supplies[0], expectedSupplies[0])).To(BeTrue())
+
+			Expect(fakeSigningIdentity.SignCallCount()).To(Equal(1))
+			Expect(fakeSigningIdentity.SignArgsForCall(0)).To(Equal(marshalledCommand))
+
+			Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(1))
+			_, sc, _ := fakeProverClient.ProcessCommandArgsForCall(0)
+			Expect(sc).To(Equal(signedCommand))
+		})
+
+		Context("when SigningIdentity fails to sign", func() {
+			BeforeEach(func() {
+				fakeSigningIdentity.SignReturns(nil, errors.New("banana-seesaw"))
+			})
+
+			It("returns an error", func() {
+				_, err := prover.RequestSupply([]string{"typeaz"}, fakeSigningIdentity)
+				Expect(err).To(MatchError("banana-seesaw"))
+				Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when ProcessCommand returns an error", func() {
+			BeforeEach(func() {
+				fakeProverClient.ProcessCommandReturns(nil, errors.New("banana-loop"))
+			})
+
+			It("returns an error", func() {
+				_, err := prover.RequestSupply([]string{"typeaz"}, fakeSigningIdentity)
+				Expect(err).To(MatchError("banana-loop"))
+			})
+		})
+
+		Context("when ProcessCommand does not return TokenSupplies", func() {
+			BeforeEach(func() {
+				signedCommandResp = &token.SignedCommandResponse{
+					Response:  ProtoMarshal(&token.CommandResponse{}),
+					Signature: []byte("response-signature"),
+				}
+				fakeProverClient.ProcessCommandReturns(signedCommandResp, nil)
+			})
+
+			It("returns an error", func() {
+				_, err := prover.RequestSupply([]string{"typeaz"}, fakeSigningIdentity)
+				Expect(err).To(MatchError("no TokenSupplies in command response"))
+			})
+		})
+	})
+
 	Describe("SendCommand", func() {
 		var (
 			signedCommand *token.SignedCommand
@@ -0,0 +1,185 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/hyperledger/fabric/cmd/common"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/pkg/errors"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const (
+	IssueCommand    = "issue"
+	TransferCommand = "transfer"
+	RedeemCommand   = "redeem"
+	ListCommand     = "list"
+)
+
+var (
+	// responseParserWriter defines the stdout
+	responseParserWriter = os.Stdout
+)
+
+const (
+	defaultWaitTimeout = time.Second * 30
+)
+
+//go:generate counterfeiter -o mock/stub.go -fake-name Stub . Stub
+
+// Stub represents the token client
+type Stub interface {
+	// Setup creates the token client from the given config file. The channel and
+	// the MSP of the config are overridden by the given ones, when they are not empty.
+	Setup(configFile, channel, mspPath, mspID string) error
+
+	// Issue issues the given tokens, and waits for the transaction to be committed
+	Issue(tokensToIssue []*token.TokenToIssue, waitTimeout time.Duration) (*OperationResponse, error)
+
+	// Transfer transfers the given tokens to the recipients of the shares,
+	// and waits for the transaction to be committed
+	Transfer(tokenIDs []*token.TokenId, shares []*token.RecipientTransferShare, waitTimeout time.Duration) (*OperationResponse, error)
+
+	// Redeem redeems the given quantity of the given tokens,
+	// and waits for the transaction to be committed
+	Redeem(tokenIDs []*token.TokenId, quantity uint64, waitTimeout time.Duration) (*OperationResponse, error)
+
+	// ListTokens lists the unspent tokens of the client
+	ListTokens() ([]*token.TokenOutput, error)
+}
+
+//go:generate counterfeiter -o mock/response_parser.go -fake-name ResponseParser . ResponseParser
+
+// ResponseParser parses responses of the token client
+type ResponseParser interface {
+	// ParseResponse parses the response and uses the given output when emitting data
+	ParseResponse(response interface{}) error
+}
+
+//go:generate counterfeiter -o mock/command_registrar.go -fake-name CommandRegistrar . CommandRegistrar
+
+// CommandRegistrar registers commands
+type CommandRegistrar interface {
+	// Command adds a new top-level command to the CLI
+	Command(name, help string, onCommand common.CLICommand) *kingpin.CmdClause
+}
+
+// OperationResponse is the outcome of a token transaction
+type OperationResponse struct {
+	TxID      string
+	Status    string
+	Committed bool
+}
+
+// JSONResponseParser emits responses as JSON
+type JSONResponseParser struct {
+	io.Writer
+}
+
+// ParseResponse emits the given response as JSON
+func (parser *JSONResponseParser) ParseResponse(response interface{}) error {
+	b, err := json.MarshalIndent(response, "", "\t")
+	if err != nil {
+		return errors.Wrap(err, "failed marshaling response")
+	}
+	fmt.Fprintln(parser.Writer, string(b))
+	return nil
+}
+
+// AddCommands registers the token commands to the given CommandRegistrar
+func AddCommands(cli CommandRegistrar) {
+	parser := &JSONResponseParser{Writer: responseParserWriter}
+
+	issueCmd := NewIssueCmd(&TokenClientStub{}, parser)
+	issue := cli.Command(IssueCommand, "Issue tokens", issueCmd.Execute)
+	addClientFlags(issue, &issueCmd.clientFlags)
+	issueCmd.SetType(issue.Flag("type", "Sets the type of the tokens to issue").String())
+	issueCmd.SetQuantity(issue.Flag("quantity", "Sets the quantity of the tokens to issue").Uint64())
+	issueCmd.SetRecipient(issue.Flag("recipient", "Sets the recipient of the tokens as an MSP ID and the path of its MSP directory or certificate").PlaceHolder("MSPID:PATH").String())
+	issueCmd.SetWaitTimeout(issue.Flag("waitTimeout", "Sets the time to wait for the transaction to be committed").Default(defaultWaitTimeout.String()).Duration())
+
+	transferCmd := NewTransferCmd(&TokenClientStub{}, parser)
+	transfer := cli.Command(TransferCommand, "Transfer tokens", transferCmd.Execute)
+	addClientFlags(transfer, &transferCmd.clientFlags)
+	transferCmd.SetTokenIDs(transfer.Flag("tokenIDs", "Sets the IDs of the tokens to transfer, as JSON or the path of a JSON file").String())
+	transferCmd.SetShares(transfer.Flag("shares", "Sets the shares of the recipients, as JSON or the path of a JSON file").String())
+	transferCmd.SetWaitTimeout(transfer.Flag("waitTimeout", "Sets the time to wait for the transaction to be committed").Default(defaultWaitTimeout.String()).Duration())
+
+	redeemCmd := NewRedeemCmd(&TokenClientStub{}, parser)
+	redeem := cli.Command(RedeemCommand, "Redeem tokens", redeemCmd.Execute)
+	addClientFlags(redeem, &redeemCmd.clientFlags)
+	redeemCmd.SetTokenIDs(redeem.Flag("tokenIDs", "Sets the IDs of the tokens to redeem, as JSON or the path of a JSON file").String())
+	redeemCmd.SetQuantity(redeem.Flag("quantity", "Sets the quantity of the tokens to redeem").Uint64())
+	redeemCmd.SetWaitTimeout(redeem.Flag("waitTimeout", "Sets the time to wait for the transaction to be committed").Default(defaultWaitTimeout.String()).Duration())
+
+	listCmd := NewListCmd(&TokenClientStub{}, parser)
+	list := cli.Command(ListCommand, "List the unspent tokens", listCmd.Execute)
+	addClientFlags(list, &listCmd.clientFlags)
+}
+
+// clientFlags are the flags of the token client, shared by the token commands
+type clientFlags struct {
+	config  *string
+	channel *string
+	mspPath *string
+	mspID   *string
+}
+
+func addClientFlags(cmd *kingpin.CmdClause, flags *clientFlags) {
+	flags.SetConfig(cmd.Flag("config", "Sets the path of the token client config file").String())
+	flags.SetChannel(cmd.Flag("channel", "(Optional) Overrides the channel of the config file").String())
+	flags.SetMSPPath(cmd.Flag("mspPath", "(Optional) Overrides the MSP directory of the config file").String())
+	flags.SetMSPID(cmd.Flag("mspId", "(Optional) Overrides the MSP ID of the config file").String())
+}
+
+// SetConfig sets the path of the token client config file
+func (f *clientFlags) SetConfig(config *string) {
+	f.config = config
+}
+
+// SetChannel sets the channel that overrides the channel of the config file
+func (f *clientFlags) SetChannel(channel *string) {
+	f.channel = channel
+}
+
+// SetMSPPath sets the MSP directory that overrides the MSP directory of the config file
+func (f *clientFlags) SetMSPPath(mspPath *string) {
+	f.mspPath = mspPath
+}
+
+// SetMSPID sets the MSP ID that overrides the MSP ID of the config file
+func (f *clientFlags) SetMSPID(mspID *string) {
+	f.mspID = mspID
+}
+
+// setup sets up the given stub with the flags of the token client
+func (f *clientFlags) setup(stub Stub) error {
+	if f.config == nil || *f.config == "" {
+		return errors.New("no config file specified")
+	}
+	return stub.Setup(*f.config, valueOf(f.channel), valueOf(f.mspPath), valueOf(f.mspID))
+}
+
+func valueOf(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func waitTimeoutOf(d *time.Duration) time.Duration {
+	if d == nil {
+		return defaultWaitTimeout
+	}
+	return *d
+}
@@ -0,0 +1,78 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token_test
+
+import (
+	"github.com/hyperledger/fabric/cmd/common"
+	token "github.com/hyperledger/fabric/token/cmd"
+	"github.com/hyperledger/fabric/token/cmd/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+var _ = Describe("AddCommands", func() {
+	var (
+		app *kingpin.Application
+		cli *mock.CommandRegistrar
+	)
+
+	BeforeEach(func() {
+		app = kingpin.New("foo", "bar")
+		cli = &mock.CommandRegistrar{}
+		cli.CommandStub = func(name, help string, onCommand common.CLICommand) *kingpin.CmdClause {
+			return app.Command(name, help)
+		}
+	})
+
+	It("registers the token commands", func() {
+		token.AddCommands(cli)
+		Expect(cli.CommandCallCount()).To(Equal(4))
+
+		for _, cmd := range []string{token.IssueCommand, token.TransferCommand, token.RedeemCommand, token.ListCommand} {
+			Expect(app.GetCommand(cmd)).NotTo(BeNil())
+			Expect(app.GetCommand(cmd).GetFlag("config")).NotTo(BeNil())
+			Expect(app.GetCommand(cmd).GetFlag("channel")).NotTo(BeNil())
+			Expect(app.GetCommand(cmd).GetFlag("mspPath")).NotTo(BeNil())
+			Expect(app.GetCommand(cmd).GetFlag("mspId")).NotTo(BeNil())
+		}
+		for _, cmd := range []string{token.IssueCommand, token.TransferCommand, token.RedeemCommand} {
+			Expect(app.GetCommand(cmd).GetFlag("waitTimeout")).NotTo(BeNil())
+		}
+
+		Expect(app.GetCommand(token.IssueCommand).GetFlag("type")).NotTo(BeNil())
+		Expect(app.GetCommand(token.IssueCommand).GetFlag("quantity")).NotTo(BeNil())
+		Expect(app.GetCommand(token.IssueCommand).GetFlag("recipient")).NotTo(BeNil())
+		Expect(app.GetCommand(token.TransferCommand).GetFlag("tokenIDs")).NotTo(BeNil())
+		Expect(app.GetCommand(token.TransferCommand).GetFlag("shares")).NotTo(BeNil())
+		Expect(app.GetCommand(token.RedeemCommand).GetFlag("tokenIDs")).NotTo(BeNil())
+		Expect(app.GetCommand(token.RedeemCommand).GetFlag("quantity")).NotTo(BeNil())
+	})
+})
+
+var _ = Describe("JSONResponseParser", func() {
+	It("emits the response as JSON", func() {
+		buffer := gbytes.NewBuffer()
+		parser := &token.JSONResponseParser{Writer: buffer}
+
+		err := parser.ParseResponse(&token.OperationResponse{TxID: "txid", Status: "SUCCESS", Committed: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(buffer).To(gbytes.Say(`"TxID": "txid"`))
+		Expect(buffer).To(gbytes.Say(`"Status": "SUCCESS"`))
+		Expect(buffer).To(gbytes.Say(`"Committed": true`))
+	})
+
+	Context("when the response cannot be marshaled", func() {
+		It("returns an error", func() {
+			parser := &token.JSONResponseParser{Writer: gbytes.NewBuffer()}
+			err := parser.ParseResponse(make(chan int))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failed marshaling response"))
+		})
+	})
+})
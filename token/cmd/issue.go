@@ -0,0 +1,96 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"time"
+
+	"github.com/hyperledger/fabric/cmd/common"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/pkg/errors"
+)
+
+// NewIssueCmd creates a new IssueCmd with the given Stub and ResponseParser
+func NewIssueCmd(stub Stub, parser ResponseParser) *IssueCmd {
+	return &IssueCmd{
+		stub:   stub,
+		parser: parser,
+	}
+}
+
+// IssueCmd executes the command that issues tokens
+type IssueCmd struct {
+	clientFlags
+	stub        Stub
+	parser      ResponseParser
+	tokenType   *string
+	quantity    *uint64
+	recipient   *string
+	waitTimeout *time.Duration
+}
+
+// SetType sets the type of the tokens to issue
+func (cmd *IssueCmd) SetType(tokenType *string) {
+	cmd.tokenType = tokenType
+}
+
+// SetQuantity sets the quantity of the tokens to issue
+func (cmd *IssueCmd) SetQuantity(quantity *uint64) {
+	cmd.quantity = quantity
+}
+
+// SetRecipient sets the recipient of the tokens to issue
+func (cmd *IssueCmd) SetRecipient(recipient *string) {
+	cmd.recipient = recipient
+}
+
+// SetWaitTimeout sets the time to wait for the transaction to be committed
+func (cmd *IssueCmd) SetWaitTimeout(waitTimeout *time.Duration) {
+	cmd.waitTimeout = waitTimeout
+}
+
+// Execute executes the command
+func (cmd *IssueCmd) Execute(conf common.Config) error {
+	if cmd.tokenType == nil || *cmd.tokenType == "" {
+		return errors.New("no token type specified")
+	}
+	if cmd.quantity == nil || *cmd.quantity == 0 {
+		return errors.New("no quantity specified")
+	}
+	if cmd.recipient == nil || *cmd.recipient == "" {
+		return errors.New("no recipient specified")
+	}
+	recipient, err := LoadTokenOwner(*cmd.recipient)
+	if err != nil {
+		return err
+	}
+
+	if err := cmd.setup(cmd.stub); err != nil {
+		return err
+	}
+
+	tokensToIssue := []*token.TokenToIssue{
+		{
+			Recipient: recipient,
+			Type:      *cmd.tokenType,
+			Quantity:  *cmd.quantity,
+		},
+	}
+	res, err := cmd.stub.Issue(tokensToIssue, waitTimeoutOf(cmd.waitTimeout))
+	return parseOperationResponse(cmd.parser, res, err)
+}
+
+// parseOperationResponse parses the response of a token transaction, if any, and returns the given error.
+// The response is parsed even when the transaction failed, so that its ID is known.
+func parseOperationResponse(parser ResponseParser, res *OperationResponse, err error) error {
+	if res != nil {
+		if parseErr := parser.ParseResponse(res); parseErr != nil {
+			return parseErr
+		}
+	}
+	return err
+}
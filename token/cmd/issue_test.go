@@ -0,0 +1,192 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token_test
+
+import (
+	"time"
+
+	"github.com/hyperledger/fabric/cmd/common"
+	token "github.com/hyperledger/fabric/token/cmd"
+	"github.com/hyperledger/fabric/token/cmd/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("IssueCmd", func() {
+	var (
+		stub        *mock.Stub
+		parser      *mock.ResponseParser
+		cmd         *token.IssueCmd
+		config      string
+		tokenType   string
+		quantity    uint64
+		recipient   string
+		waitTimeout time.Duration
+		response    *token.OperationResponse
+	)
+
+	BeforeEach(func() {
+		stub = &mock.Stub{}
+		parser = &mock.ResponseParser{}
+		cmd = token.NewIssueCmd(stub, parser)
+
+		config = "config.yaml"
+		tokenType = "USD"
+		quantity = 100
+		recipient = "SampleOrg:../../sampleconfig/msp"
+		waitTimeout = time.Minute
+		cmd.SetConfig(&config)
+		cmd.SetType(&tokenType)
+		cmd.SetQuantity(&quantity)
+		cmd.SetRecipient(&recipient)
+		cmd.SetWaitTimeout(&waitTimeout)
+
+		response = &token.OperationResponse{TxID: "txid", Status: "SUCCESS", Committed: true}
+		stub.IssueReturns(response, nil)
+	})
+
+	It("issues the tokens and parses the response", func() {
+		err := cmd.Execute(common.Config{})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(stub.SetupCallCount()).To(Equal(1))
+		configFile, channel, mspPath, mspID := stub.SetupArgsForCall(0)
+		Expect(configFile).To(Equal("config.yaml"))
+		Expect(channel).To(BeEmpty())
+		Expect(mspPath).To(BeEmpty())
+		Expect(mspID).To(BeEmpty())
+
+		Expect(stub.IssueCallCount()).To(Equal(1))
+		tokensToIssue, timeout := stub.IssueArgsForCall(0)
+		Expect(timeout).To(Equal(time.Minute))
+		Expect(tokensToIssue).To(HaveLen(1))
+		Expect(tokensToIssue[0].Type).To(Equal("USD"))
+		Expect(tokensToIssue[0].Quantity).To(Equal(uint64(100)))
+		expectedRecipient, err := token.LoadTokenOwner(recipient)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tokensToIssue[0].Recipient).To(Equal(expectedRecipient))
+
+		Expect(parser.ParseResponseCallCount()).To(Equal(1))
+		Expect(parser.ParseResponseArgsForCall(0)).To(Equal(response))
+	})
+
+	Context("when the config and the MSP are overridden", func() {
+		BeforeEach(func() {
+			channel := "mychannel"
+			mspPath := "msp"
+			mspID := "Org1MSP"
+			cmd.SetChannel(&channel)
+			cmd.SetMSPPath(&mspPath)
+			cmd.SetMSPID(&mspID)
+		})
+
+		It("sets up the stub with them", func() {
+			err := cmd.Execute(common.Config{})
+			Expect(err).NotTo(HaveOccurred())
+			configFile, channel, mspPath, mspID := stub.SetupArgsForCall(0)
+			Expect(configFile).To(Equal("config.yaml"))
+			Expect(channel).To(Equal("mychannel"))
+			Expect(mspPath).To(Equal("msp"))
+			Expect(mspID).To(Equal("Org1MSP"))
+		})
+	})
+
+	Context("when no config file is specified", func() {
+		BeforeEach(func() {
+			cmd.SetConfig(nil)
+		})
+
+		It("returns an error", func() {
+			err := cmd.Execute(common.Config{})
+			Expect(err).To(MatchError("no config file specified"))
+			Expect(stub.IssueCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when no token type is specified", func() {
+		BeforeEach(func() {
+			tokenType = ""
+		})
+
+		It("returns an error", func() {
+			err := cmd.Execute(common.Config{})
+			Expect(err).To(MatchError("no token type specified"))
+		})
+	})
+
+	Context("when no quantity is specified", func() {
+		BeforeEach(func() {
+			quantity = 0
+		})
+
+		It("returns an error", func() {
+			err := cmd.Execute(common.Config{})
+			Expect(err).To(MatchError("no quantity specified"))
+		})
+	})
+
+	Context("when no recipient is specified", func() {
+		BeforeEach(func() {
+			recipient = ""
+		})
+
+		It("returns an error", func() {
+			err := cmd.Execute(common.Config{})
+			Expect(err).To(MatchError("no recipient specified"))
+		})
+	})
+
+	Context("when the recipient is invalid", func() {
+		BeforeEach(func() {
+			recipient = "SampleOrg"
+		})
+
+		It("returns an error", func() {
+			err := cmd.Execute(common.Config{})
+			Expect(err).To(MatchError("invalid recipient SampleOrg, expected MSPID:PATH"))
+		})
+	})
+
+	Context("when the stub cannot be set up", func() {
+		BeforeEach(func() {
+			stub.SetupReturns(errors.New("wild-banana"))
+		})
+
+		It("returns the error", func() {
+			err := cmd.Execute(common.Config{})
+			Expect(err).To(MatchError("wild-banana"))
+			Expect(stub.IssueCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the transaction is not committed", func() {
+		BeforeEach(func() {
+			response.Committed = false
+			stub.IssueReturns(response, errors.New("timed out"))
+		})
+
+		It("parses the response and returns the error", func() {
+			err := cmd.Execute(common.Config{})
+			Expect(err).To(MatchError("timed out"))
+			Expect(parser.ParseResponseCallCount()).To(Equal(1))
+			Expect(parser.ParseResponseArgsForCall(0)).To(Equal(response))
+		})
+	})
+
+	Context("when the transaction is not submitted", func() {
+		BeforeEach(func() {
+			stub.IssueReturns(nil, errors.New("no prover"))
+		})
+
+		It("returns the error", func() {
+			err := cmd.Execute(common.Config{})
+			Expect(err).To(MatchError("no prover"))
+			Expect(parser.ParseResponseCallCount()).To(Equal(0))
+		})
+	})
+})
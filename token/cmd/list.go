@@ -0,0 +1,43 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"github.com/hyperledger/fabric/cmd/common"
+	"github.com/hyperledger/fabric/protos/token"
+)
+
+// NewListCmd creates a new ListCmd with the given Stub and ResponseParser
+func NewListCmd(stub Stub, parser ResponseParser) *ListCmd {
+	return &ListCmd{
+		stub:   stub,
+		parser: parser,
+	}
+}
+
+// ListCmd executes the command that lists the unspent tokens
+type ListCmd struct {
+	clientFlags
+	stub   Stub
+	parser ResponseParser
+}
+
+// Execute executes the command
+func (cmd *ListCmd) Execute(conf common.Config) error {
+	if err := cmd.setup(cmd.stub); err != nil {
+		return err
+	}
+
+	outputs, err := cmd.stub.ListTokens()
+	if err != nil {
+		return err
+	}
+	if outputs == nil {
+		outputs = []*token.TokenOutput{}
+	}
+	return cmd.parser.ParseResponse(outputs)
+}
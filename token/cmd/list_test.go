@@ -0,0 +1,74 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token_test
+
+import (
+	"github.com/hyperledger/fabric/cmd/common"
+	pb "github.com/hyperledger/fabric/protos/token"
+	token "github.com/hyperledger/fabric/token/cmd"
+	"github.com/hyperledger/fabric/token/cmd/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("ListCmd", func() {
+	var (
+		stub    *mock.Stub
+		parser  *mock.ResponseParser
+		cmd     *token.ListCmd
+		config  string
+		outputs []*pb.TokenOutput
+	)
+
+	BeforeEach(func() {
+		stub = &mock.Stub{}
+		parser = &mock.ResponseParser{}
+		cmd = token.NewListCmd(stub, parser)
+
+		config = "config.yaml"
+		cmd.SetConfig(&config)
+
+		outputs = []*pb.TokenOutput{
+			{Id: &pb.TokenId{TxId: "txid", Index: 1}, Type: "USD", Quantity: 100},
+		}
+		stub.ListTokensReturns(outputs, nil)
+	})
+
+	It("lists the tokens and parses them", func() {
+		err := cmd.Execute(common.Config{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stub.SetupCallCount()).To(Equal(1))
+		Expect(stub.ListTokensCallCount()).To(Equal(1))
+		Expect(parser.ParseResponseCallCount()).To(Equal(1))
+		Expect(parser.ParseResponseArgsForCall(0)).To(Equal(outputs))
+	})
+
+	Context("when there are no tokens", func() {
+		BeforeEach(func() {
+			stub.ListTokensReturns(nil, nil)
+		})
+
+		It("parses an empty list", func() {
+			err := cmd.Execute(common.Config{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parser.ParseResponseArgsForCall(0)).To(Equal([]*pb.TokenOutput{}))
+		})
+	})
+
+	Context("when the list fails", func() {
+		BeforeEach(func() {
+			stub.ListTokensReturns(nil, errors.New("wild-banana"))
+		})
+
+		It("returns the error", func() {
+			err := cmd.Execute(common.Config{})
+			Expect(err).To(MatchError("wild-banana"))
+			Expect(parser.ParseResponseCallCount()).To(Equal(0))
+		})
+	})
+})
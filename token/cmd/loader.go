@@ -0,0 +1,138 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/pkg/errors"
+)
+
+// shareJSON is the JSON representation of a RecipientTransferShare,
+// whose recipient is given as an MSP ID and the path of its MSP directory or certificate
+type shareJSON struct {
+	Recipient string `json:"recipient"`
+	Quantity  uint64 `json:"quantity"`
+}
+
+// LoadTokenOwner returns the token owner given as an MSP ID and the path of
+// its MSP directory or certificate, separated by a colon.
+func LoadTokenOwner(s string) (*token.TokenOwner, error) {
+	res := strings.SplitN(s, ":", 2)
+	if len(res) != 2 || res[0] == "" || res[1] == "" {
+		return nil, errors.Errorf("invalid recipient %s, expected MSPID:PATH", s)
+	}
+	mspID, path := res[0], res[1]
+
+	certPath, err := certificatePath(path)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := ioutil.ReadFile(certPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed reading certificate of recipient %s", s)
+	}
+	if block, _ := pem.Decode(cert); block == nil {
+		return nil, errors.Errorf("no PEM encoded certificate found in %s", certPath)
+	}
+
+	raw, err := proto.Marshal(&msp.SerializedIdentity{Mspid: mspID, IdBytes: cert})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed marshaling recipient identity")
+	}
+	return &token.TokenOwner{Type: token.TokenOwner_MSP_IDENTIFIER, Raw: raw}, nil
+}
+
+// certificatePath returns the given path if it is a file, or the path of
+// the signing certificate of the MSP if it is an MSP directory.
+func certificatePath(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed accessing %s", path)
+	}
+	if !info.IsDir() {
+		return path, nil
+	}
+
+	signcerts := filepath.Join(path, "signcerts")
+	files, err := ioutil.ReadDir(signcerts)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed reading signing certificates of MSP %s", path)
+	}
+	for _, f := range files {
+		if !f.IsDir() {
+			return filepath.Join(signcerts, f.Name()), nil
+		}
+	}
+	return "", errors.Errorf("no signing certificate found in MSP %s", path)
+}
+
+// LoadTokenIDs returns the token IDs given as JSON, or as the path of a JSON file,
+// in the form [{"tx_id": "TXID", "index": 0}].
+func LoadTokenIDs(s string) ([]*token.TokenId, error) {
+	data, err := jsonOrFile(s)
+	if err != nil {
+		return nil, err
+	}
+
+	var tokenIDs []*token.TokenId
+	if err := json.Unmarshal(data, &tokenIDs); err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling token IDs")
+	}
+	if len(tokenIDs) == 0 {
+		return nil, errors.New("no token IDs found")
+	}
+	return tokenIDs, nil
+}
+
+// LoadShares returns the shares given as JSON, or as the path of a JSON file,
+// in the form [{"recipient": "MSPID:PATH", "quantity": 1}].
+func LoadShares(s string) ([]*token.RecipientTransferShare, error) {
+	data, err := jsonOrFile(s)
+	if err != nil {
+		return nil, err
+	}
+
+	var sharesJSON []shareJSON
+	if err := json.Unmarshal(data, &sharesJSON); err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling shares")
+	}
+	if len(sharesJSON) == 0 {
+		return nil, errors.New("no shares found")
+	}
+
+	var shares []*token.RecipientTransferShare
+	for _, share := range sharesJSON {
+		recipient, err := LoadTokenOwner(share.Recipient)
+		if err != nil {
+			return nil, err
+		}
+		shares = append(shares, &token.RecipientTransferShare{Recipient: recipient, Quantity: share.Quantity})
+	}
+	return shares, nil
+}
+
+// jsonOrFile returns the given string if it is JSON, or the content of the file it names.
+func jsonOrFile(s string) ([]byte, error) {
+	trimmed := strings.TrimSpace(s)
+	if strings.HasPrefix(trimmed, "[") || strings.HasPrefix(trimmed, "{") {
+		return []byte(trimmed), nil
+	}
+	data, err := ioutil.ReadFile(s)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed reading %s", s)
+	}
+	return data, nil
+}
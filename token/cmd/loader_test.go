@@ -0,0 +1,149 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric/protos/token"
+	token "github.com/hyperledger/fabric/token/cmd"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Loader", func() {
+	var (
+		tempDir string
+		cert    []byte
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = ioutil.TempDir("", "token-loader")
+		Expect(err).NotTo(HaveOccurred())
+		cert, err = ioutil.ReadFile("../../sampleconfig/msp/signcerts/peer.pem")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tempDir)
+	})
+
+	Describe("LoadTokenOwner", func() {
+		expectOwner := func(owner *pb.TokenOwner) {
+			Expect(owner.Type).To(Equal(pb.TokenOwner_MSP_IDENTIFIER))
+			si := &msp.SerializedIdentity{}
+			err := proto.Unmarshal(owner.Raw, si)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(si.Mspid).To(Equal("SampleOrg"))
+			Expect(si.IdBytes).To(Equal(cert))
+		}
+
+		It("loads the signing certificate of an MSP directory", func() {
+			owner, err := token.LoadTokenOwner("SampleOrg:../../sampleconfig/msp")
+			Expect(err).NotTo(HaveOccurred())
+			expectOwner(owner)
+		})
+
+		It("loads a certificate file", func() {
+			owner, err := token.LoadTokenOwner("SampleOrg:../../sampleconfig/msp/signcerts/peer.pem")
+			Expect(err).NotTo(HaveOccurred())
+			expectOwner(owner)
+		})
+
+		Context("when the MSP ID is missing", func() {
+			It("returns an error", func() {
+				_, err := token.LoadTokenOwner(":../../sampleconfig/msp")
+				Expect(err).To(MatchError("invalid recipient :../../sampleconfig/msp, expected MSPID:PATH"))
+			})
+		})
+
+		Context("when the path does not exist", func() {
+			It("returns an error", func() {
+				_, err := token.LoadTokenOwner("SampleOrg:" + filepath.Join(tempDir, "missing"))
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("failed accessing"))
+			})
+		})
+
+		Context("when the MSP directory has no signing certificate", func() {
+			It("returns an error", func() {
+				err := os.Mkdir(filepath.Join(tempDir, "signcerts"), 0700)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = token.LoadTokenOwner("SampleOrg:" + tempDir)
+				Expect(err).To(MatchError("no signing certificate found in MSP " + tempDir))
+			})
+		})
+
+		Context("when the file is not a PEM certificate", func() {
+			It("returns an error", func() {
+				certFile := filepath.Join(tempDir, "cert.pem")
+				err := ioutil.WriteFile(certFile, []byte("garbage"), 0600)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = token.LoadTokenOwner("SampleOrg:" + certFile)
+				Expect(err).To(MatchError("no PEM encoded certificate found in " + certFile))
+			})
+		})
+	})
+
+	Describe("LoadTokenIDs", func() {
+		It("loads the token IDs from JSON", func() {
+			tokenIDs, err := token.LoadTokenIDs(` [{"tx_id": "txid", "index": 1}]`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tokenIDs).To(HaveLen(1))
+			Expect(proto.Equal(tokenIDs[0], &pb.TokenId{TxId: "txid", Index: 1})).To(BeTrue())
+		})
+
+		It("loads the token IDs from a file", func() {
+			file := filepath.Join(tempDir, "token-ids.json")
+			err := ioutil.WriteFile(file, []byte(`[{"tx_id": "txid", "index": 1}]`), 0600)
+			Expect(err).NotTo(HaveOccurred())
+
+			tokenIDs, err := token.LoadTokenIDs(file)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tokenIDs).To(HaveLen(1))
+			Expect(proto.Equal(tokenIDs[0], &pb.TokenId{TxId: "txid", Index: 1})).To(BeTrue())
+		})
+
+		Context("when there are no token IDs", func() {
+			It("returns an error", func() {
+				_, err := token.LoadTokenIDs("[]")
+				Expect(err).To(MatchError("no token IDs found"))
+			})
+		})
+	})
+
+	Describe("LoadShares", func() {
+		It("loads the shares from JSON", func() {
+			shares, err := token.LoadShares(`[{"recipient": "SampleOrg:../../sampleconfig/msp", "quantity": 10}]`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(shares).To(HaveLen(1))
+			Expect(shares[0].Quantity).To(Equal(uint64(10)))
+			owner, err := token.LoadTokenOwner("SampleOrg:../../sampleconfig/msp")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Equal(shares[0].Recipient, owner)).To(BeTrue())
+		})
+
+		Context("when there are no shares", func() {
+			It("returns an error", func() {
+				_, err := token.LoadShares("[]")
+				Expect(err).To(MatchError("no shares found"))
+			})
+		})
+
+		Context("when a recipient is invalid", func() {
+			It("returns an error", func() {
+				_, err := token.LoadShares(`[{"recipient": "SampleOrg", "quantity": 10}]`)
+				Expect(err).To(MatchError("invalid recipient SampleOrg, expected MSPID:PATH"))
+			})
+		})
+	})
+})
@@ -0,0 +1,68 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"time"
+
+	"github.com/hyperledger/fabric/cmd/common"
+	"github.com/pkg/errors"
+)
+
+// NewRedeemCmd creates a new RedeemCmd with the given Stub and ResponseParser
+func NewRedeemCmd(stub Stub, parser ResponseParser) *RedeemCmd {
+	return &RedeemCmd{
+		stub:   stub,
+		parser: parser,
+	}
+}
+
+// RedeemCmd executes the command that redeems tokens
+type RedeemCmd struct {
+	clientFlags
+	stub        Stub
+	parser      ResponseParser
+	tokenIDs    *string
+	quantity    *uint64
+	waitTimeout *time.Duration
+}
+
+// SetTokenIDs sets the IDs of the tokens to redeem, as JSON or the path of a JSON file
+func (cmd *RedeemCmd) SetTokenIDs(tokenIDs *string) {
+	cmd.tokenIDs = tokenIDs
+}
+
+// SetQuantity sets the quantity of the tokens to redeem
+func (cmd *RedeemCmd) SetQuantity(quantity *uint64) {
+	cmd.quantity = quantity
+}
+
+// SetWaitTimeout sets the time to wait for the transaction to be committed
+func (cmd *RedeemCmd) SetWaitTimeout(waitTimeout *time.Duration) {
+	cmd.waitTimeout = waitTimeout
+}
+
+// Execute executes the command
+func (cmd *RedeemCmd) Execute(conf common.Config) error {
+	if cmd.tokenIDs == nil || *cmd.tokenIDs == "" {
+		return errors.New("no token IDs specified")
+	}
+	if cmd.quantity == nil || *cmd.quantity == 0 {
+		return errors.New("no quantity specified")
+	}
+	tokenIDs, err := LoadTokenIDs(*cmd.tokenIDs)
+	if err != nil {
+		return err
+	}
+
+	if err := cmd.setup(cmd.stub); err != nil {
+		return err
+	}
+
+	res, err := cmd.stub.Redeem(tokenIDs, *cmd.quantity, waitTimeoutOf(cmd.waitTimeout))
+	return parseOperationResponse(cmd.parser, res, err)
+}
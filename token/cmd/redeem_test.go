@@ -0,0 +1,115 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/cmd/common"
+	pb "github.com/hyperledger/fabric/protos/token"
+	token "github.com/hyperledger/fabric/token/cmd"
+	"github.com/hyperledger/fabric/token/cmd/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("RedeemCmd", func() {
+	var (
+		stub        *mock.Stub
+		parser      *mock.ResponseParser
+		cmd         *token.RedeemCmd
+		tempDir     string
+		config      string
+		tokenIDs    string
+		quantity    uint64
+		waitTimeout time.Duration
+		response    *token.OperationResponse
+	)
+
+	BeforeEach(func() {
+		stub = &mock.Stub{}
+		parser = &mock.ResponseParser{}
+		cmd = token.NewRedeemCmd(stub, parser)
+
+		var err error
+		tempDir, err = ioutil.TempDir("", "token-redeem")
+		Expect(err).NotTo(HaveOccurred())
+		tokenIDs = filepath.Join(tempDir, "token-ids.json")
+		err = ioutil.WriteFile(tokenIDs, []byte(`[{"tx_id": "txid"}, {"tx_id": "txid", "index": 2}]`), 0600)
+		Expect(err).NotTo(HaveOccurred())
+
+		config = "config.yaml"
+		quantity = 50
+		waitTimeout = 0
+		cmd.SetConfig(&config)
+		cmd.SetTokenIDs(&tokenIDs)
+		cmd.SetQuantity(&quantity)
+		cmd.SetWaitTimeout(&waitTimeout)
+
+		response = &token.OperationResponse{TxID: "txid2", Status: "SUCCESS"}
+		stub.RedeemReturns(response, nil)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tempDir)
+	})
+
+	It("redeems the tokens of the file and parses the response", func() {
+		err := cmd.Execute(common.Config{})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(stub.RedeemCallCount()).To(Equal(1))
+		ids, redeemQuantity, timeout := stub.RedeemArgsForCall(0)
+		Expect(timeout).To(Equal(time.Duration(0)))
+		Expect(redeemQuantity).To(Equal(uint64(50)))
+		Expect(ids).To(HaveLen(2))
+		Expect(proto.Equal(ids[0], &pb.TokenId{TxId: "txid"})).To(BeTrue())
+		Expect(proto.Equal(ids[1], &pb.TokenId{TxId: "txid", Index: 2})).To(BeTrue())
+
+		Expect(parser.ParseResponseCallCount()).To(Equal(1))
+		Expect(parser.ParseResponseArgsForCall(0)).To(Equal(response))
+	})
+
+	Context("when no quantity is specified", func() {
+		BeforeEach(func() {
+			quantity = 0
+		})
+
+		It("returns an error", func() {
+			err := cmd.Execute(common.Config{})
+			Expect(err).To(MatchError("no quantity specified"))
+		})
+	})
+
+	Context("when the file of the token IDs does not exist", func() {
+		BeforeEach(func() {
+			tokenIDs = filepath.Join(tempDir, "missing.json")
+		})
+
+		It("returns an error", func() {
+			err := cmd.Execute(common.Config{})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failed reading"))
+		})
+	})
+
+	Context("when the redemption fails", func() {
+		BeforeEach(func() {
+			stub.RedeemReturns(nil, errors.New("wild-banana"))
+		})
+
+		It("returns the error", func() {
+			err := cmd.Execute(common.Config{})
+			Expect(err).To(MatchError("wild-banana"))
+		})
+	})
+})
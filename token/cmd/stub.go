@@ -0,0 +1,97 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"time"
+
+	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/client"
+	"github.com/pkg/errors"
+)
+
+// TokenClientStub is a stub that communicates with the prover peer, the orderer
+// and the committer peer using the token client implementation
+type TokenClientStub struct {
+	client *client.Client
+}
+
+// Setup creates the token client from the given config file. The channel and
+// the MSP of the config are overridden by the given ones, when they are not empty.
+func (stub *TokenClientStub) Setup(configFile, channel, mspPath, mspID string) error {
+	config, err := client.ConfigFromFile(configFile)
+	if err != nil {
+		return err
+	}
+	if channel != "" {
+		config.ChannelID = channel
+	}
+	if mspPath != "" {
+		config.MSPInfo.MSPConfigPath = mspPath
+	}
+	if mspID != "" {
+		config.MSPInfo.MSPID = mspID
+	}
+	if config.MSPInfo.MSPType == "" {
+		config.MSPInfo.MSPType = "bccsp"
+	}
+	if err := client.ValidateClientConfig(*config); err != nil {
+		return errors.WithMessage(err, "invalid config")
+	}
+
+	err = mspmgmt.LoadLocalMspWithType(config.MSPInfo.MSPConfigPath, nil, config.MSPInfo.MSPID, config.MSPInfo.MSPType)
+	if err != nil {
+		return errors.WithMessage(err, "failed loading the MSP")
+	}
+	signingIdentity, err := mspmgmt.GetLocalMSP().GetDefaultSigningIdentity()
+	if err != nil {
+		return errors.WithMessage(err, "failed getting the signing identity")
+	}
+
+	stub.client, err = client.NewClient(*config, signingIdentity)
+	return err
+}
+
+// Issue issues the given tokens, and waits for the transaction to be committed
+func (stub *TokenClientStub) Issue(tokensToIssue []*token.TokenToIssue, waitTimeout time.Duration) (*OperationResponse, error) {
+	_, txid, status, committed, err := stub.client.Issue(tokensToIssue, waitTimeout)
+	return operationResponse(txid, status, committed), err
+}
+
+// Transfer transfers the given tokens to the recipients of the shares,
+// and waits for the transaction to be committed
+func (stub *TokenClientStub) Transfer(tokenIDs []*token.TokenId, shares []*token.RecipientTransferShare, waitTimeout time.Duration) (*OperationResponse, error) {
+	_, txid, status, committed, err := stub.client.Transfer(tokenIDs, shares, waitTimeout)
+	return operationResponse(txid, status, committed), err
+}
+
+// Redeem redeems the given quantity of the given tokens,
+// and waits for the transaction to be committed
+func (stub *TokenClientStub) Redeem(tokenIDs []*token.TokenId, quantity uint64, waitTimeout time.Duration) (*OperationResponse, error) {
+	_, txid, status, committed, err := stub.client.Redeem(tokenIDs, quantity, waitTimeout)
+	return operationResponse(txid, status, committed), err
+}
+
+// ListTokens lists the unspent tokens of the client
+func (stub *TokenClientStub) ListTokens() ([]*token.TokenOutput, error) {
+	return stub.client.ListTokens()
+}
+
+// operationResponse returns the response of a token transaction,
+// or nil if the transaction was not submitted to the orderer.
+func operationResponse(txid string, status *cb.Status, committed bool) *OperationResponse {
+	if status == nil {
+		return nil
+	}
+	return &OperationResponse{
+		TxID:      txid,
+		Status:    status.String(),
+		Committed: committed,
+	}
+}
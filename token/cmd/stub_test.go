@@ -0,0 +1,98 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hyperledger/fabric/token/client"
+	token "github.com/hyperledger/fabric/token/cmd"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	yaml "gopkg.in/yaml.v2"
+)
+
+var _ = Describe("TokenClientStub", func() {
+	var (
+		tempDir    string
+		configFile string
+		config     client.ClientConfig
+		stub       *token.TokenClientStub
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = ioutil.TempDir("", "token-stub")
+		Expect(err).NotTo(HaveOccurred())
+		configFile = filepath.Join(tempDir, "config.yaml")
+
+		config = client.ClientConfig{
+			ChannelID: "mychannel",
+			MSPInfo: client.MSPInfo{
+				MSPConfigPath: "../../sampleconfig/msp",
+				MSPID:         "SampleOrg",
+			},
+			Orderer:       client.ConnectionConfig{Address: "127.0.0.1:0", ConnectionTimeout: 100 * time.Millisecond},
+			CommitterPeer: client.ConnectionConfig{Address: "127.0.0.1:0", ConnectionTimeout: 100 * time.Millisecond},
+			ProverPeer:    client.ConnectionConfig{Address: "127.0.0.1:0", ConnectionTimeout: 100 * time.Millisecond},
+		}
+		stub = &token.TokenClientStub{}
+	})
+
+	JustBeforeEach(func() {
+		configData, err := yaml.Marshal(config)
+		Expect(err).NotTo(HaveOccurred())
+		err = ioutil.WriteFile(configFile, configData, 0600)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tempDir)
+	})
+
+	It("loads the MSP of the config and connects to the orderer", func() {
+		err := stub.Setup(configFile, "", "", "")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("failed to connect to orderer 127.0.0.1:0"))
+	})
+
+	Context("when the config file does not exist", func() {
+		It("returns an error", func() {
+			err := stub.Setup(filepath.Join(tempDir, "missing.yaml"), "", "", "")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failed reading config file"))
+		})
+	})
+
+	Context("when the config is invalid", func() {
+		BeforeEach(func() {
+			config.ChannelID = ""
+		})
+
+		It("returns an error", func() {
+			err := stub.Setup(configFile, "", "", "")
+			Expect(err).To(MatchError("invalid config: missing channel id"))
+		})
+
+		It("is overridden by the given channel", func() {
+			err := stub.Setup(configFile, "mychannel", "", "")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failed to connect to orderer"))
+		})
+	})
+
+	Context("when the MSP cannot be loaded", func() {
+		It("returns an error", func() {
+			err := stub.Setup(configFile, "", filepath.Join(tempDir, "missing"), "")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failed loading the MSP"))
+		})
+	})
+})
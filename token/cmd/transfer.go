@@ -0,0 +1,72 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"time"
+
+	"github.com/hyperledger/fabric/cmd/common"
+	"github.com/pkg/errors"
+)
+
+// NewTransferCmd creates a new TransferCmd with the given Stub and ResponseParser
+func NewTransferCmd(stub Stub, parser ResponseParser) *TransferCmd {
+	return &TransferCmd{
+		stub:   stub,
+		parser: parser,
+	}
+}
+
+// TransferCmd executes the command that transfers tokens
+type TransferCmd struct {
+	clientFlags
+	stub        Stub
+	parser      ResponseParser
+	tokenIDs    *string
+	shares      *string
+	waitTimeout *time.Duration
+}
+
+// SetTokenIDs sets the IDs of the tokens to transfer, as JSON or the path of a JSON file
+func (cmd *TransferCmd) SetTokenIDs(tokenIDs *string) {
+	cmd.tokenIDs = tokenIDs
+}
+
+// SetShares sets the shares of the recipients, as JSON or the path of a JSON file
+func (cmd *TransferCmd) SetShares(shares *string) {
+	cmd.shares = shares
+}
+
+// SetWaitTimeout sets the time to wait for the transaction to be committed
+func (cmd *TransferCmd) SetWaitTimeout(waitTimeout *time.Duration) {
+	cmd.waitTimeout = waitTimeout
+}
+
+// Execute executes the command
+func (cmd *TransferCmd) Execute(conf common.Config) error {
+	if cmd.tokenIDs == nil || *cmd.tokenIDs == "" {
+		return errors.New("no token IDs specified")
+	}
+	if cmd.shares == nil || *cmd.shares == "" {
+		return errors.New("no shares specified")
+	}
+	tokenIDs, err := LoadTokenIDs(*cmd.tokenIDs)
+	if err != nil {
+		return err
+	}
+	shares, err := LoadShares(*cmd.shares)
+	if err != nil {
+		return err
+	}
+
+	if err := cmd.setup(cmd.stub); err != nil {
+		return err
+	}
+
+	res, err := cmd.stub.Transfer(tokenIDs, shares, waitTimeoutOf(cmd.waitTimeout))
+	return parseOperationResponse(cmd.parser, res, err)
+}
@@ -0,0 +1,126 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token_test
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/cmd/common"
+	pb "github.com/hyperledger/fabric/protos/token"
+	token "github.com/hyperledger/fabric/token/cmd"
+	"github.com/hyperledger/fabric/token/cmd/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("TransferCmd", func() {
+	var (
+		stub     *mock.Stub
+		parser   *mock.ResponseParser
+		cmd      *token.TransferCmd
+		config   string
+		tokenIDs string
+		shares   string
+		response *token.OperationResponse
+	)
+
+	BeforeEach(func() {
+		stub = &mock.Stub{}
+		parser = &mock.ResponseParser{}
+		cmd = token.NewTransferCmd(stub, parser)
+
+		config = "config.yaml"
+		tokenIDs = `[{"tx_id": "txid", "index": 1}]`
+		shares = `[{"recipient": "SampleOrg:../../sampleconfig/msp", "quantity": 10}]`
+		cmd.SetConfig(&config)
+		cmd.SetTokenIDs(&tokenIDs)
+		cmd.SetShares(&shares)
+
+		response = &token.OperationResponse{TxID: "txid2", Status: "SUCCESS", Committed: true}
+		stub.TransferReturns(response, nil)
+	})
+
+	It("transfers the tokens and parses the response", func() {
+		err := cmd.Execute(common.Config{})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(stub.SetupCallCount()).To(Equal(1))
+		Expect(stub.TransferCallCount()).To(Equal(1))
+		ids, transferShares, timeout := stub.TransferArgsForCall(0)
+		Expect(timeout).To(Equal(30 * time.Second))
+		Expect(ids).To(HaveLen(1))
+		Expect(proto.Equal(ids[0], &pb.TokenId{TxId: "txid", Index: 1})).To(BeTrue())
+		Expect(transferShares).To(HaveLen(1))
+		Expect(transferShares[0].Quantity).To(Equal(uint64(10)))
+		Expect(transferShares[0].Recipient.Type).To(Equal(pb.TokenOwner_MSP_IDENTIFIER))
+
+		Expect(parser.ParseResponseCallCount()).To(Equal(1))
+		Expect(parser.ParseResponseArgsForCall(0)).To(Equal(response))
+	})
+
+	Context("when no token IDs are specified", func() {
+		BeforeEach(func() {
+			tokenIDs = ""
+		})
+
+		It("returns an error", func() {
+			err := cmd.Execute(common.Config{})
+			Expect(err).To(MatchError("no token IDs specified"))
+		})
+	})
+
+	Context("when no shares are specified", func() {
+		BeforeEach(func() {
+			shares = ""
+		})
+
+		It("returns an error", func() {
+			err := cmd.Execute(common.Config{})
+			Expect(err).To(MatchError("no shares specified"))
+		})
+	})
+
+	Context("when the token IDs are invalid", func() {
+		BeforeEach(func() {
+			tokenIDs = "[garbage"
+		})
+
+		It("returns an error", func() {
+			err := cmd.Execute(common.Config{})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failed unmarshaling token IDs"))
+			Expect(stub.SetupCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the shares are invalid", func() {
+		BeforeEach(func() {
+			shares = "[garbage"
+		})
+
+		It("returns an error", func() {
+			err := cmd.Execute(common.Config{})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failed unmarshaling shares"))
+			Expect(stub.SetupCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the transfer fails", func() {
+		BeforeEach(func() {
+			stub.TransferReturns(nil, errors.New("wild-banana"))
+		})
+
+		It("returns the error", func() {
+			err := cmd.Execute(common.Config{})
+			Expect(err).To(MatchError("wild-banana"))
+			Expect(parser.ParseResponseCallCount()).To(Equal(0))
+		})
+	})
+})
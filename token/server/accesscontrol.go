@@ -25,6 +25,7 @@ type ACLResources struct {
 	IssueTokens    string
 	TransferTokens string
 	ListTokens     string
+	AuditTokens    string
 }
 
 // PolicyBasedAccessControl implements token command access control functions.
@@ -67,6 +68,19 @@ func (ac *PolicyBasedAccessControl) Check(sc *token.SignedCommand, c *token.Comm
 			c.Header.ChannelId,
 			signedData,
 		)
+	case *token.Command_ExchangeRequest:
+		// Exchange has same policy as transfer
+		return ac.ACLProvider.CheckACL(
+			ac.ACLResources.TransferTokens,
+			c.Header.ChannelId,
+			signedData,
+		)
+	case *token.Command_SupplyRequest:
+		return ac.ACLProvider.CheckACL(
+			ac.ACLResources.AuditTokens,
+			c.Header.ChannelId,
+			signedData,
+		)
 
 	case *token.Command_ExpectationRequest:
 		if c.GetExpectationRequest().GetExpectation() == nil {
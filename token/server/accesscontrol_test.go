@@ -117,6 +117,58 @@ var _ = Describe("AccessControl", func() {
 		}))
 	})
 
+	It("validates the transfer policy for exchange command", func() {
+		aclResources.TransferTokens = "papaya"
+		exchangeCommand := &token.Command{
+			Header: header,
+			Payload: &token.Command_ExchangeRequest{
+				ExchangeRequest: &token.ExchangeRequest{},
+			},
+		}
+		signedExchangeCommand := &token.SignedCommand{
+			Command:   ProtoMarshal(exchangeCommand),
+			Signature: []byte("signature"),
+		}
+		err := pbac.Check(signedExchangeCommand, exchangeCommand)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeACLProvider.CheckACLCallCount()).To(Equal(1))
+		resourceName, channelID, signedData := fakeACLProvider.CheckACLArgsForCall(0)
+		Expect(resourceName).To(Equal("papaya"))
+		Expect(channelID).To(Equal("channel-id"))
+		Expect(signedData).To(ConsistOf(&common.SignedData{
+			Data:      signedExchangeCommand.Command,
+			Identity:  []byte("creator"),
+			Signature: []byte("signature"),
+		}))
+	})
+
+	It("checks the audit policy for supply commands", func() {
+		aclResources.AuditTokens = "guava"
+		supplyCommand := &token.Command{
+			Header: header,
+			Payload: &token.Command_SupplyRequest{
+				SupplyRequest: &token.SupplyRequest{},
+			},
+		}
+		signedSupplyCommand := &token.SignedCommand{
+			Command:   ProtoMarshal(supplyCommand),
+			Signature: []byte("signature"),
+		}
+		err := pbac.Check(signedSupplyCommand, supplyCommand)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeACLProvider.CheckACLCallCount()).To(Equal(1))
+		resourceName, channelID, signedData := fakeACLProvider.CheckACLArgsForCall(0)
+		Expect(resourceName).To(Equal("guava"))
+		Expect(channelID).To(Equal("channel-id"))
+		Expect(signedData).To(ConsistOf(&common.SignedData{
+			Data:      signedSupplyCommand.Command,
+			Identity:  []byte("creator"),
+			Signature: []byte("signature"),
+		}))
+	})
+
 	Context("when the policy checker returns an error", func() {
 		BeforeEach(func() {
 			fakeACLProvider.CheckACLReturns(errors.New("wild-banana"))
@@ -15,6 +15,8 @@ import (
 // CapabilityChecker is used to check whether or not a channel supports token functions.
 type CapabilityChecker interface {
 	FabToken(channelId string) (bool, error)
+	// ConfidentialFabToken returns true if the tokens of the channel are managed by the confidential TMS
+	ConfidentialFabToken(channelId string) (bool, error)
 }
 
 // TokenCapabilityChecker implements CapabilityChecker interface
@@ -29,3 +31,11 @@ func (c *TokenCapabilityChecker) FabToken(channelId string) (bool, error) {
 	}
 	return ac.Capabilities().FabToken(), nil
 }
+
+func (c *TokenCapabilityChecker) ConfidentialFabToken(channelId string) (bool, error) {
+	ac, ok := c.PeerOps.GetChannelConfig(channelId).ApplicationConfig()
+	if !ok {
+		return false, errors.Errorf("no application config found for channel %s", channelId)
+	}
+	return ac.Capabilities().ConfidentialFabToken(), nil
+}
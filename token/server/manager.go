@@ -18,12 +18,35 @@ import (
 type Manager struct {
 	LedgerManager              ledger.LedgerManager
 	TokenOwnerValidatorManager identity.TokenOwnerValidatorManager
+	// CapabilityChecker tells the channels whose tokens are managed by the confidential TMS,
+	// which are not served. All the channels are assumed to use the plain TMS if it is nil
+	CapabilityChecker CapabilityChecker
+}
+
+// checkPlain returns an error if the tokens of the passed channel are not managed by the plain TMS,
+// since the plain token actions would be invalidated by the committers of the channel
+func (m *Manager) checkPlain(channel string) error {
+	if m.CapabilityChecker == nil {
+		return nil
+	}
+	isConfidential, err := m.CapabilityChecker.ConfidentialFabToken(channel)
+	if err != nil {
+		return errors.Wrapf(err, "failed checking capabilities of channel: %s", channel)
+	}
+	if isConfidential {
+		return errors.Errorf("the tokens of channel %s are managed by the confidential TMS, which is not supported", channel)
+	}
+	return nil
 }
 
 // For now it returns a plain issuer.
 // After lscc-based tms configuration is available, it will be updated
 // to return an issuer configured for the specific channel
 func (m *Manager) GetIssuer(channel string, privateCredential, publicCredential []byte) (Issuer, error) {
+	if err := m.checkPlain(channel); err != nil {
+		return nil, err
+	}
+
 	tokenOwnerValidator, err := m.TokenOwnerValidatorManager.Get(channel)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed getting token owner validator for channel: %s", channel)
@@ -35,6 +58,10 @@ func (m *Manager) GetIssuer(channel string, privateCredential, publicCredential
 // GetTransactor returns a Transactor bound to the passed channel and whose credential
 // is the tuple (privateCredential, publicCredential).
 func (m *Manager) GetTransactor(channel string, privateCredential, publicCredential []byte) (Transactor, error) {
+	if err := m.checkPlain(channel); err != nil {
+		return nil, err
+	}
+
 	ledger, err := m.LedgerManager.GetLedgerReader(channel)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed getting ledger for channel: %s", channel)
@@ -50,3 +77,17 @@ func (m *Manager) GetTransactor(channel string, privateCredential, publicCredent
 		PublicCredential:    publicCredential,
 		TokenOwnerValidator: tokenOwnerValidator}, nil
 }
+
+// GetAuditor returns an Auditor bound to the passed channel.
+func (m *Manager) GetAuditor(channel string) (Auditor, error) {
+	if err := m.checkPlain(channel); err != nil {
+		return nil, err
+	}
+
+	ledger, err := m.LedgerManager.GetLedgerReader(channel)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed getting ledger for channel: %s", channel)
+	}
+
+	return &plain.Auditor{Ledger: ledger}, nil
+}
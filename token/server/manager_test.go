@@ -12,6 +12,7 @@ import (
 	mock3 "github.com/hyperledger/fabric/token/identity/mock"
 	"github.com/hyperledger/fabric/token/ledger/mock"
 	"github.com/hyperledger/fabric/token/server"
+	mock2 "github.com/hyperledger/fabric/token/server/mock"
 	"github.com/hyperledger/fabric/token/tms/plain"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -27,6 +28,28 @@ var _ = Describe("Manager", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(issuer).To(Equal(&plain.Issuer{TokenOwnerValidator: &TestTokenOwnerValidator{}}))
 		})
+
+		Context("when the tokens of the channel are managed by the confidential TMS", func() {
+			It("returns an error", func() {
+				fakeCapabilityChecker := &mock2.CapabilityChecker{}
+				fakeCapabilityChecker.ConfidentialFabTokenReturns(true, nil)
+				manager := &server.Manager{TokenOwnerValidatorManager: &mock3.TokenOwnerValidatorManager{}, CapabilityChecker: fakeCapabilityChecker}
+				issuer, err := manager.GetIssuer("test-channel", []byte("private-credential"), []byte("public-credential"))
+				Expect(err).To(MatchError("the tokens of channel test-channel are managed by the confidential TMS, which is not supported"))
+				Expect(issuer).To(BeNil())
+				Expect(fakeCapabilityChecker.ConfidentialFabTokenArgsForCall(0)).To(Equal("test-channel"))
+			})
+		})
+
+		Context("when the capabilities of the channel cannot be checked", func() {
+			It("returns an error", func() {
+				fakeCapabilityChecker := &mock2.CapabilityChecker{}
+				fakeCapabilityChecker.ConfidentialFabTokenReturns(false, errors.New("no config"))
+				manager := &server.Manager{TokenOwnerValidatorManager: &mock3.TokenOwnerValidatorManager{}, CapabilityChecker: fakeCapabilityChecker}
+				_, err := manager.GetIssuer("test-channel", []byte("private-credential"), []byte("public-credential"))
+				Expect(err).To(MatchError("failed checking capabilities of channel: test-channel: no config"))
+			})
+		})
 	})
 
 	Describe("GetTransactor", func() {
@@ -63,5 +86,68 @@ var _ = Describe("Manager", func() {
 			Expect(err.Error()).To(Equal("failed getting ledger for channel: test-channel: banana ledger"))
 			Expect(transactor).To(BeNil())
 		})
+
+		Context("when the tokens of the channel are managed by the plain TMS", func() {
+			It("returns a plain transactor", func() {
+				fakeCapabilityChecker := &mock2.CapabilityChecker{}
+				manager := &server.Manager{LedgerManager: fakeLedgerManager, TokenOwnerValidatorManager: fakeTokenOwnerValidatorManager, CapabilityChecker: fakeCapabilityChecker}
+				fakeLedgerManager.GetLedgerReaderReturns(fakeLedgerReader, nil)
+				transactor, err := manager.GetTransactor("test-channel", []byte("private-credential"), []byte("public-credential"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(transactor).To(BeAssignableToTypeOf(&plain.Transactor{}))
+			})
+		})
+
+		Context("when the tokens of the channel are managed by the confidential TMS", func() {
+			It("returns an error", func() {
+				fakeCapabilityChecker := &mock2.CapabilityChecker{}
+				fakeCapabilityChecker.ConfidentialFabTokenReturns(true, nil)
+				manager := &server.Manager{LedgerManager: fakeLedgerManager, TokenOwnerValidatorManager: fakeTokenOwnerValidatorManager, CapabilityChecker: fakeCapabilityChecker}
+				transactor, err := manager.GetTransactor("test-channel", []byte("private-credential"), []byte("public-credential"))
+				Expect(err).To(MatchError("the tokens of channel test-channel are managed by the confidential TMS, which is not supported"))
+				Expect(transactor).To(BeNil())
+				Expect(fakeLedgerManager.GetLedgerReaderCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("GetAuditor", func() {
+		var (
+			fakeLedgerReader  *mock.LedgerReader
+			fakeLedgerManager *mock.LedgerManager
+			manager           *server.Manager
+		)
+
+		BeforeEach(func() {
+			fakeLedgerReader = &mock.LedgerReader{}
+			fakeLedgerManager = &mock.LedgerManager{}
+			manager = &server.Manager{LedgerManager: fakeLedgerManager}
+		})
+
+		It("returns a plain auditor", func() {
+			fakeLedgerManager.GetLedgerReaderReturns(fakeLedgerReader, nil)
+			auditor, err := manager.GetAuditor("test-channel")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(auditor).To(Equal(&plain.Auditor{Ledger: fakeLedgerReader}))
+			Expect(fakeLedgerManager.GetLedgerReaderArgsForCall(0)).To(Equal("test-channel"))
+		})
+
+		It("returns an error", func() {
+			fakeLedgerManager.GetLedgerReaderReturns(nil, errors.New("banana ledger"))
+			auditor, err := manager.GetAuditor("test-channel")
+			Expect(err).To(MatchError("failed getting ledger for channel: test-channel: banana ledger"))
+			Expect(auditor).To(BeNil())
+		})
+
+		Context("when the tokens of the channel are managed by the confidential TMS", func() {
+			It("returns an error", func() {
+				fakeCapabilityChecker := &mock2.CapabilityChecker{}
+				fakeCapabilityChecker.ConfidentialFabTokenReturns(true, nil)
+				manager.CapabilityChecker = fakeCapabilityChecker
+				auditor, err := manager.GetAuditor("test-channel")
+				Expect(err).To(MatchError("the tokens of channel test-channel are managed by the confidential TMS, which is not supported"))
+				Expect(auditor).To(BeNil())
+			})
+		})
 	})
 })
@@ -8,6 +8,19 @@ import (
 )
 
 type CapabilityChecker struct {
+	ConfidentialFabTokenStub        func(channelId string) (bool, error)
+	confidentialFabTokenMutex       sync.RWMutex
+	confidentialFabTokenArgsForCall []struct {
+		channelId string
+	}
+	confidentialFabTokenReturns struct {
+		result1 bool
+		result2 error
+	}
+	confidentialFabTokenReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	FabTokenStub        func(channelId string) (bool, error)
 	fabTokenMutex       sync.RWMutex
 	fabTokenArgsForCall []struct {
@@ -25,6 +38,57 @@ type CapabilityChecker struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *CapabilityChecker) ConfidentialFabToken(channelId string) (bool, error) {
+	fake.confidentialFabTokenMutex.Lock()
+	ret, specificReturn := fake.confidentialFabTokenReturnsOnCall[len(fake.confidentialFabTokenArgsForCall)]
+	fake.confidentialFabTokenArgsForCall = append(fake.confidentialFabTokenArgsForCall, struct {
+		channelId string
+	}{channelId})
+	fake.recordInvocation("ConfidentialFabToken", []interface{}{channelId})
+	fake.confidentialFabTokenMutex.Unlock()
+	if fake.ConfidentialFabTokenStub != nil {
+		return fake.ConfidentialFabTokenStub(channelId)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.confidentialFabTokenReturns.result1, fake.confidentialFabTokenReturns.result2
+}
+
+func (fake *CapabilityChecker) ConfidentialFabTokenCallCount() int {
+	fake.confidentialFabTokenMutex.RLock()
+	defer fake.confidentialFabTokenMutex.RUnlock()
+	return len(fake.confidentialFabTokenArgsForCall)
+}
+
+func (fake *CapabilityChecker) ConfidentialFabTokenArgsForCall(i int) string {
+	fake.confidentialFabTokenMutex.RLock()
+	defer fake.confidentialFabTokenMutex.RUnlock()
+	return fake.confidentialFabTokenArgsForCall[i].channelId
+}
+
+func (fake *CapabilityChecker) ConfidentialFabTokenReturns(result1 bool, result2 error) {
+	fake.ConfidentialFabTokenStub = nil
+	fake.confidentialFabTokenReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CapabilityChecker) ConfidentialFabTokenReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.ConfidentialFabTokenStub = nil
+	if fake.confidentialFabTokenReturnsOnCall == nil {
+		fake.confidentialFabTokenReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.confidentialFabTokenReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CapabilityChecker) FabToken(channelId string) (bool, error) {
 	fake.fabTokenMutex.Lock()
 	ret, specificReturn := fake.fabTokenReturnsOnCall[len(fake.fabTokenArgsForCall)]
@@ -79,6 +143,8 @@ func (fake *CapabilityChecker) FabTokenReturnsOnCall(i int, result1 bool, result
 func (fake *CapabilityChecker) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.confidentialFabTokenMutex.RLock()
+	defer fake.confidentialFabTokenMutex.RUnlock()
 	fake.fabTokenMutex.RLock()
 	defer fake.fabTokenMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
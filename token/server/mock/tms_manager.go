@@ -8,6 +8,19 @@ import (
 )
 
 type TMSManager struct {
+	GetAuditorStub        func(string) (server.Auditor, error)
+	getAuditorMutex       sync.RWMutex
+	getAuditorArgsForCall []struct {
+		arg1 string
+	}
+	getAuditorReturns struct {
+		result1 server.Auditor
+		result2 error
+	}
+	getAuditorReturnsOnCall map[int]struct {
+		result1 server.Auditor
+		result2 error
+	}
 	GetIssuerStub        func(string, []byte, []byte) (server.Issuer, error)
 	getIssuerMutex       sync.RWMutex
 	getIssuerArgsForCall []struct {
@@ -42,6 +55,69 @@ type TMSManager struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *TMSManager) GetAuditor(arg1 string) (server.Auditor, error) {
+	fake.getAuditorMutex.Lock()
+	ret, specificReturn := fake.getAuditorReturnsOnCall[len(fake.getAuditorArgsForCall)]
+	fake.getAuditorArgsForCall = append(fake.getAuditorArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("GetAuditor", []interface{}{arg1})
+	fake.getAuditorMutex.Unlock()
+	if fake.GetAuditorStub != nil {
+		return fake.GetAuditorStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getAuditorReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *TMSManager) GetAuditorCallCount() int {
+	fake.getAuditorMutex.RLock()
+	defer fake.getAuditorMutex.RUnlock()
+	return len(fake.getAuditorArgsForCall)
+}
+
+func (fake *TMSManager) GetAuditorCalls(stub func(string) (server.Auditor, error)) {
+	fake.getAuditorMutex.Lock()
+	defer fake.getAuditorMutex.Unlock()
+	fake.GetAuditorStub = stub
+}
+
+func (fake *TMSManager) GetAuditorArgsForCall(i int) string {
+	fake.getAuditorMutex.RLock()
+	defer fake.getAuditorMutex.RUnlock()
+	argsForCall := fake.getAuditorArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *TMSManager) GetAuditorReturns(result1 server.Auditor, result2 error) {
+	fake.getAuditorMutex.Lock()
+	defer fake.getAuditorMutex.Unlock()
+	fake.GetAuditorStub = nil
+	fake.getAuditorReturns = struct {
+		result1 server.Auditor
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TMSManager) GetAuditorReturnsOnCall(i int, result1 server.Auditor, result2 error) {
+	fake.getAuditorMutex.Lock()
+	defer fake.getAuditorMutex.Unlock()
+	fake.GetAuditorStub = nil
+	if fake.getAuditorReturnsOnCall == nil {
+		fake.getAuditorReturnsOnCall = make(map[int]struct {
+			result1 server.Auditor
+			result2 error
+		})
+	}
+	fake.getAuditorReturnsOnCall[i] = struct {
+		result1 server.Auditor
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *TMSManager) GetIssuer(arg1 string, arg2 []byte, arg3 []byte) (server.Issuer, error) {
 	var arg2Copy []byte
 	if arg2 != nil {
@@ -195,6 +271,8 @@ func (fake *TMSManager) GetTransactorReturnsOnCall(i int, result1 server.Transac
 func (fake *TMSManager) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.getAuditorMutex.RLock()
+	defer fake.getAuditorMutex.RUnlock()
 	fake.getIssuerMutex.RLock()
 	defer fake.getIssuerMutex.RUnlock()
 	fake.getTransactorMutex.RLock()
@@ -25,6 +25,19 @@ type Transactor struct {
 		result1 *token.UnspentTokens
 		result2 error
 	}
+	RequestExchangeStub        func(*token.ExchangeRequest) (*token.TokenTransaction, error)
+	requestExchangeMutex       sync.RWMutex
+	requestExchangeArgsForCall []struct {
+		arg1 *token.ExchangeRequest
+	}
+	requestExchangeReturns struct {
+		result1 *token.TokenTransaction
+		result2 error
+	}
+	requestExchangeReturnsOnCall map[int]struct {
+		result1 *token.TokenTransaction
+		result2 error
+	}
 	RequestExpectationStub        func(*token.ExpectationRequest) (*token.TokenTransaction, error)
 	requestExpectationMutex       sync.RWMutex
 	requestExpectationArgsForCall []struct {
@@ -146,6 +159,69 @@ func (fake *Transactor) ListTokensReturnsOnCall(i int, result1 *token.UnspentTok
 	}{result1, result2}
 }
 
+func (fake *Transactor) RequestExchange(arg1 *token.ExchangeRequest) (*token.TokenTransaction, error) {
+	fake.requestExchangeMutex.Lock()
+	ret, specificReturn := fake.requestExchangeReturnsOnCall[len(fake.requestExchangeArgsForCall)]
+	fake.requestExchangeArgsForCall = append(fake.requestExchangeArgsForCall, struct {
+		arg1 *token.ExchangeRequest
+	}{arg1})
+	fake.recordInvocation("RequestExchange", []interface{}{arg1})
+	fake.requestExchangeMutex.Unlock()
+	if fake.RequestExchangeStub != nil {
+		return fake.RequestExchangeStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.requestExchangeReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Transactor) RequestExchangeCallCount() int {
+	fake.requestExchangeMutex.RLock()
+	defer fake.requestExchangeMutex.RUnlock()
+	return len(fake.requestExchangeArgsForCall)
+}
+
+func (fake *Transactor) RequestExchangeCalls(stub func(*token.ExchangeRequest) (*token.TokenTransaction, error)) {
+	fake.requestExchangeMutex.Lock()
+	defer fake.requestExchangeMutex.Unlock()
+	fake.RequestExchangeStub = stub
+}
+
+func (fake *Transactor) RequestExchangeArgsForCall(i int) *token.ExchangeRequest {
+	fake.requestExchangeMutex.RLock()
+	defer fake.requestExchangeMutex.RUnlock()
+	argsForCall := fake.requestExchangeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Transactor) RequestExchangeReturns(result1 *token.TokenTransaction, result2 error) {
+	fake.requestExchangeMutex.Lock()
+	defer fake.requestExchangeMutex.Unlock()
+	fake.RequestExchangeStub = nil
+	fake.requestExchangeReturns = struct {
+		result1 *token.TokenTransaction
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Transactor) RequestExchangeReturnsOnCall(i int, result1 *token.TokenTransaction, result2 error) {
+	fake.requestExchangeMutex.Lock()
+	defer fake.requestExchangeMutex.Unlock()
+	fake.RequestExchangeStub = nil
+	if fake.requestExchangeReturnsOnCall == nil {
+		fake.requestExchangeReturnsOnCall = make(map[int]struct {
+			result1 *token.TokenTransaction
+			result2 error
+		})
+	}
+	fake.requestExchangeReturnsOnCall[i] = struct {
+		result1 *token.TokenTransaction
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Transactor) RequestExpectation(arg1 *token.ExpectationRequest) (*token.TokenTransaction, error) {
 	fake.requestExpectationMutex.Lock()
 	ret, specificReturn := fake.requestExpectationReturnsOnCall[len(fake.requestExpectationArgsForCall)]
@@ -342,6 +418,8 @@ func (fake *Transactor) Invocations() map[string][][]interface{} {
 	defer fake.doneMutex.RUnlock()
 	fake.listTokensMutex.RLock()
 	defer fake.listTokensMutex.RUnlock()
+	fake.requestExchangeMutex.RLock()
+	defer fake.requestExchangeMutex.RUnlock()
 	fake.requestExpectationMutex.RLock()
 	defer fake.requestExpectationMutex.RUnlock()
 	fake.requestRedeemMutex.RLock()
@@ -85,6 +85,10 @@ func (s *Prover) ProcessCommand(ctx context.Context, sc *token.SignedCommand) (c
 		payload, err = s.ListUnspentTokens(ctx, command.Header, t.ListRequest)
 	case *token.Command_ExpectationRequest:
 		payload, err = s.RequestExpectation(ctx, command.Header, t.ExpectationRequest)
+	case *token.Command_ExchangeRequest:
+		payload, err = s.RequestExchange(ctx, command.Header, t.ExchangeRequest)
+	case *token.Command_SupplyRequest:
+		payload, err = s.RequestSupply(ctx, command.Header, t.SupplyRequest)
 	default:
 		err = errors.Errorf("command type not recognized: %T", t)
 	}
@@ -144,6 +148,23 @@ func (s *Prover) RequestRedeem(ctx context.Context, header *token.Header, reques
 	return &token.CommandResponse_TokenTransaction{TokenTransaction: tokenTransaction}, nil
 }
 
+// RequestExchange gets a transactor and creates a token transaction exchanging the
+// inputs contributed by several owners, which the owners sign before submitting it
+func (s *Prover) RequestExchange(ctx context.Context, header *token.Header, request *token.ExchangeRequest) (*token.CommandResponse_TokenTransaction, error) {
+	transactor, err := s.TMSManager.GetTransactor(header.ChannelId, request.Credential, header.Creator)
+	if err != nil {
+		return nil, err
+	}
+	defer transactor.Done()
+
+	tokenTransaction, err := transactor.RequestExchange(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &token.CommandResponse_TokenTransaction{TokenTransaction: tokenTransaction}, nil
+}
+
 func (s *Prover) ListUnspentTokens(ctxt context.Context, header *token.Header, listRequest *token.ListRequest) (*token.CommandResponse_UnspentTokens, error) {
 	transactor, err := s.TMSManager.GetTransactor(header.ChannelId, listRequest.Credential, header.Creator)
 	if err != nil {
@@ -159,6 +180,23 @@ func (s *Prover) ListUnspentTokens(ctxt context.Context, header *token.Header, l
 	return &token.CommandResponse_UnspentTokens{UnspentTokens: tokens}, nil
 }
 
+// RequestSupply gets an auditor and returns the quantities of the requested token types
+// that have been issued and redeemed
+func (s *Prover) RequestSupply(ctx context.Context, header *token.Header, request *token.SupplyRequest) (*token.CommandResponse_TokenSupplies, error) {
+	auditor, err := s.TMSManager.GetAuditor(header.ChannelId)
+	if err != nil {
+		return nil, err
+	}
+	defer auditor.Done()
+
+	supplies, err := auditor.RequestSupply(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &token.CommandResponse_TokenSupplies{TokenSupplies: supplies}, nil
+}
+
 // RequestExpectation gets an issuer or transactor and creates a token transaction response
 // for import, transfer or redemption.
 func (s *Prover) RequestExpectation(ctx context.Context, header *token.Header, request *token.ExpectationRequest) (*token.CommandResponse_TokenTransaction, error) {
@@ -36,6 +36,7 @@ var _ = Describe("Prover", func() {
 		fakeMarshaler         *mock.Marshaler
 		fakeIssuer            *mock.Issuer
 		fakeTransactor        *mock.Transactor
+		fakeAuditor           *mock.Auditor
 		fakeTMSManager        *mock.TMSManager
 
 		prover *server.Prover
@@ -53,6 +54,12 @@ var _ = Describe("Prover", func() {
 		redeemRequest          *token.RedeemRequest
 		redeemTokenTransaction *token.TokenTransaction
 
+		exchangeRequest          *token.ExchangeRequest
+		exchangeTokenTransaction *token.TokenTransaction
+
+		supplyRequest *token.SupplyRequest
+		tokenSupplies *token.TokenSupplies
+
 		listRequest      *token.ListRequest
 		unspentTokens    *token.UnspentTokens
 		transactorTokens []*token.TokenOutput
@@ -129,6 +136,23 @@ var _ = Describe("Prover", func() {
 		}
 		fakeTransactor.RequestRedeemReturns(redeemTokenTransaction, nil)
 
+		exchangeTokenTransaction = &token.TokenTransaction{
+			Action: &token.TokenTransaction_PlainAction{
+				PlainAction: &token.PlainTokenAction{
+					Data: &token.PlainTokenAction_PlainExchange{
+						PlainExchange: &token.PlainTransfer{
+							Inputs: []*token.TokenId{{TxId: "txid-a", Index: 0}, {TxId: "txid-b", Index: 0}},
+							Outputs: []*token.PlainOutput{
+								{Owner: &token.TokenOwner{Raw: []byte("owner-b")}, Type: "PDQ", Quantity: 50},
+								{Owner: &token.TokenOwner{Raw: []byte("owner-a")}, Type: "XYZ", Quantity: 20},
+							},
+						},
+					},
+				},
+			},
+		}
+		fakeTransactor.RequestExchangeReturns(exchangeTokenTransaction, nil)
+
 		transactorTokens = []*token.TokenOutput{
 			{Id: &token.TokenId{TxId: "idaz", Index: 0}, Type: "typeaz", Quantity: 135},
 			{Id: &token.TokenId{TxId: "idby", Index: 0}, Type: "typeby", Quantity: 79},
@@ -141,6 +165,17 @@ var _ = Describe("Prover", func() {
 		fakeTMSManager.GetIssuerReturns(fakeIssuer, nil)
 		fakeTMSManager.GetTransactorReturns(fakeTransactor, nil)
 
+		supplyRequest = &token.SupplyRequest{
+			Credential: []byte("credential"),
+			Types:      []string{"PDQ"},
+		}
+		tokenSupplies = &token.TokenSupplies{
+			Supplies: []*token.TokenSupply{{Type: "PDQ", Issued: 1000, Redeemed: 100}},
+		}
+		fakeAuditor = &mock.Auditor{}
+		fakeAuditor.RequestSupplyReturns(tokenSupplies, nil)
+		fakeTMSManager.GetAuditorReturns(fakeAuditor, nil)
+
 		marshaledResponse = &token.SignedCommandResponse{Response: []byte("signed-command-response")}
 		fakeMarshaler = &mock.Marshaler{}
 		fakeMarshaler.MarshalCommandResponseReturns(marshaledResponse, nil)
@@ -191,6 +226,22 @@ var _ = Describe("Prover", func() {
 			QuantityToRedeem: 50,
 		}
 
+		exchangeRequest = &token.ExchangeRequest{
+			Credential: []byte("credential"),
+			Contributions: []*token.ExchangeContribution{
+				{
+					Owner:    []byte("owner-a"),
+					TokenIds: []*token.TokenId{{TxId: "txid-a", Index: 0}},
+					Shares:   []*token.ExchangeShare{{Recipient: &token.TokenOwner{Raw: []byte("owner-b")}, Type: "PDQ", Quantity: 50}},
+				},
+				{
+					Owner:    []byte("owner-b"),
+					TokenIds: []*token.TokenId{{TxId: "txid-b", Index: 0}},
+					Shares:   []*token.ExchangeShare{{Recipient: &token.TokenOwner{Raw: []byte("owner-a")}, Type: "XYZ", Quantity: 20}},
+				},
+			},
+		}
+
 		listRequest = &token.ListRequest{
 			Credential: []byte("credential"),
 		}
@@ -508,6 +559,73 @@ var _ = Describe("Prover", func() {
 		})
 	})
 
+	Describe("ProcessCommand_RequestExchange", func() {
+		BeforeEach(func() {
+			command = &token.Command{
+				Header: &token.Header{
+					ChannelId: "channel-id",
+					Creator:   []byte("creator"),
+					Nonce:     []byte("nonce"),
+				},
+				Payload: &token.Command_ExchangeRequest{
+					ExchangeRequest: exchangeRequest,
+				},
+			}
+			marshaledCommand = ProtoMarshal(command)
+			signedCommand = &token.SignedCommand{
+				Command:   marshaledCommand,
+				Signature: []byte("command-signature"),
+			}
+			fakeMarshaler.MarshalCommandResponseReturns(marshaledResponse, nil)
+		})
+
+		It("returns a signed command response", func() {
+			resp, err := prover.ProcessCommand(context.Background(), signedCommand)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(marshaledResponse))
+
+			Expect(fakeMarshaler.MarshalCommandResponseCallCount()).To(Equal(1))
+			cmd, payload := fakeMarshaler.MarshalCommandResponseArgsForCall(0)
+			Expect(cmd).To(Equal(marshaledCommand))
+			Expect(payload).To(Equal(&token.CommandResponse_TokenTransaction{
+				TokenTransaction: exchangeTokenTransaction,
+			}))
+		})
+	})
+
+	Describe("ProcessCommand_RequestSupply", func() {
+		BeforeEach(func() {
+			command = &token.Command{
+				Header: &token.Header{
+					ChannelId: "channel-id",
+					Creator:   []byte("creator"),
+					Nonce:     []byte("nonce"),
+				},
+				Payload: &token.Command_SupplyRequest{
+					SupplyRequest: supplyRequest,
+				},
+			}
+			marshaledCommand = ProtoMarshal(command)
+			signedCommand = &token.SignedCommand{
+				Command:   marshaledCommand,
+				Signature: []byte("command-signature"),
+			}
+		})
+
+		It("returns a signed command response", func() {
+			resp, err := prover.ProcessCommand(context.Background(), signedCommand)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(marshaledResponse))
+
+			Expect(fakeMarshaler.MarshalCommandResponseCallCount()).To(Equal(1))
+			cmd, payload := fakeMarshaler.MarshalCommandResponseArgsForCall(0)
+			Expect(cmd).To(Equal(marshaledCommand))
+			Expect(payload).To(Equal(&token.CommandResponse_TokenSupplies{
+				TokenSupplies: tokenSupplies,
+			}))
+		})
+	})
+
 	Describe("Process RequestImport command", func() {
 		It("returns a signed command response", func() {
 			resp, err := prover.ProcessCommand(context.Background(), signedCommand)
@@ -697,6 +815,92 @@ var _ = Describe("Prover", func() {
 		})
 	})
 
+	Describe("RequestExchange", func() {
+		It("gets a transactor", func() {
+			_, err := prover.RequestExchange(context.Background(), command.Header, exchangeRequest)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeTMSManager.GetTransactorCallCount()).To(Equal(1))
+			channel, cred, creator := fakeTMSManager.GetTransactorArgsForCall(0)
+			Expect(channel).To(Equal("channel-id"))
+			Expect(cred).To(Equal([]byte("credential")))
+			Expect(creator).To(Equal([]byte("creator")))
+		})
+
+		It("uses the transactor to request an exchange", func() {
+			resp, err := prover.RequestExchange(context.Background(), command.Header, exchangeRequest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&token.CommandResponse_TokenTransaction{
+				TokenTransaction: exchangeTokenTransaction,
+			}))
+
+			Expect(fakeTransactor.RequestExchangeCallCount()).To(Equal(1))
+			er := fakeTransactor.RequestExchangeArgsForCall(0)
+			Expect(er).To(Equal(exchangeRequest))
+			Expect(fakeTransactor.DoneCallCount()).To(Equal(1))
+		})
+
+		Context("when the TMS manager fails to get a transactor", func() {
+			BeforeEach(func() {
+				fakeTMSManager.GetTransactorReturns(nil, errors.New("boing boing"))
+			})
+
+			It("returns the error", func() {
+				_, err := prover.RequestExchange(context.Background(), command.Header, exchangeRequest)
+				Expect(err).To(MatchError("boing boing"))
+			})
+		})
+
+		Context("when the transactor fails to exchange", func() {
+			BeforeEach(func() {
+				fakeTransactor.RequestExchangeReturns(nil, errors.New("watermelon"))
+			})
+
+			It("returns the error", func() {
+				_, err := prover.RequestExchange(context.Background(), command.Header, exchangeRequest)
+				Expect(err).To(MatchError("watermelon"))
+			})
+		})
+	})
+
+	Describe("RequestSupply", func() {
+		It("uses an auditor of the channel to request the supply", func() {
+			resp, err := prover.RequestSupply(context.Background(), command.Header, supplyRequest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&token.CommandResponse_TokenSupplies{
+				TokenSupplies: tokenSupplies,
+			}))
+
+			Expect(fakeTMSManager.GetAuditorCallCount()).To(Equal(1))
+			Expect(fakeTMSManager.GetAuditorArgsForCall(0)).To(Equal("channel-id"))
+			Expect(fakeAuditor.RequestSupplyCallCount()).To(Equal(1))
+			Expect(fakeAuditor.RequestSupplyArgsForCall(0)).To(Equal(supplyRequest))
+			Expect(fakeAuditor.DoneCallCount()).To(Equal(1))
+		})
+
+		Context("when the TMS manager fails to get an auditor", func() {
+			BeforeEach(func() {
+				fakeTMSManager.GetAuditorReturns(nil, errors.New("boing boing"))
+			})
+
+			It("returns the error", func() {
+				_, err := prover.RequestSupply(context.Background(), command.Header, supplyRequest)
+				Expect(err).To(MatchError("boing boing"))
+			})
+		})
+
+		Context("when the auditor fails to request the supply", func() {
+			BeforeEach(func() {
+				fakeAuditor.RequestSupplyReturns(nil, errors.New("watermelon"))
+			})
+
+			It("returns the error", func() {
+				_, err := prover.RequestSupply(context.Background(), command.Header, supplyRequest)
+				Expect(err).To(MatchError("watermelon"))
+			})
+		})
+	})
+
 	Describe("RequestExpectation import", func() {
 		It("gets an issuer", func() {
 			_, err := prover.RequestExpectation(context.Background(), command.Header, importExpectationRequest)
@@ -43,10 +43,28 @@ type Transactor interface {
 	// It creates a token transaction with the outputs as specified in the expectation.
 	RequestExpectation(request *token.ExpectationRequest) (*token.TokenTransaction, error)
 
+	// RequestExchange creates a token transaction exchanging the inputs contributed
+	// by the owners in the request for the outputs they specify. It queries the ledger
+	// to read the type and quantity of each input, which must add up, per type, to the
+	// quantities of the outputs. The transaction must be signed by each owner.
+	RequestExchange(request *token.ExchangeRequest) (*token.TokenTransaction, error)
+
 	// Done releases any resources held by this transactor
 	Done()
 }
 
+//go:generate counterfeiter -o mock/auditor.go -fake-name Auditor . Auditor
+
+// An Auditor reports the supply of the token types of a channel
+type Auditor interface {
+	// RequestSupply returns the quantities of the requested token types that have
+	// been issued and redeemed, or of all the token types if the request has no types.
+	RequestSupply(request *token.SupplyRequest) (*token.TokenSupplies, error)
+
+	// Done releases any resources held by this auditor
+	Done()
+}
+
 //go:generate counterfeiter -o mock/tms_manager.go -fake-name TMSManager . TMSManager
 
 type TMSManager interface {
@@ -57,4 +75,7 @@ type TMSManager interface {
 	// GetTransactor returns a Transactor bound to the passed channel and whose credential
 	// is the tuple (privateCredential, publicCredential).
 	GetTransactor(channel string, privateCredential, publicCredential []byte) (Transactor, error)
+
+	// GetAuditor returns an Auditor bound to the passed channel.
+	GetAuditor(channel string) (Auditor, error)
 }
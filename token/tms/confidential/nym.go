@@ -0,0 +1,133 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package confidential
+
+import (
+	"bytes"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-amcl/amcl"
+	"github.com/hyperledger/fabric-amcl/amcl/FP256BN"
+	"github.com/hyperledger/fabric/idemix"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/pkg/errors"
+)
+
+// NewNymOwner returns a token owner which is the passed idemix pseudonym,
+// derived from a credential issued with the passed issuer public key
+func NewNymOwner(nym *FP256BN.ECP, ipk *idemix.IssuerPublicKey) (*token.TokenOwner, error) {
+	if nym == nil || ipk == nil {
+		return nil, errors.New("missing pseudonym or issuer public key")
+	}
+	raw, err := proto.Marshal(&token.NymOwner{
+		Nym:                 idemix.EcpToBytes(nym),
+		IssuerPublicKeyHash: ipk.Hash,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed marshaling pseudonym owner")
+	}
+	return &token.TokenOwner{Type: token.TokenOwner_IDEMIX_NYM, Raw: raw}, nil
+}
+
+// AddNymProof adds to the passed transfer or redeem the proof that the creator owns
+// the input with the passed index, which is owned by the pseudonym derived from
+// the secret key sk and the randomness randNym.
+// The proofs are on the transfer without its proofs, so they can be added in any order.
+func AddNymProof(ttx *token.TokenTransaction, input uint32, sk, randNym *FP256BN.BIG, ipk *idemix.IssuerPublicKey, rng *amcl.RAND) error {
+	transfer := getTransfer(ttx)
+	if transfer == nil {
+		return errors.New("no confidential transfer or redeem in token transaction")
+	}
+	if int(input) >= len(transfer.Inputs) {
+		return errors.Errorf("input %d out of range, transfer has %d inputs", input, len(transfer.Inputs))
+	}
+	msg, err := nymProofMessage(transfer)
+	if err != nil {
+		return err
+	}
+	nym := idemix.EcpFromProto(ipk.HSk).Mul2(sk, idemix.EcpFromProto(ipk.HRand), randNym)
+	signature, err := idemix.NewNymSignature(sk, nym, randNym, ipk, msg, rng)
+	if err != nil {
+		return errors.WithMessage(err, "failed creating pseudonym signature")
+	}
+	signatureBytes, err := proto.Marshal(signature)
+	if err != nil {
+		return errors.Wrap(err, "failed marshaling pseudonym signature")
+	}
+	ipkBytes, err := proto.Marshal(ipk)
+	if err != nil {
+		return errors.Wrap(err, "failed marshaling issuer public key")
+	}
+	transfer.NymProofs = append(transfer.NymProofs, &token.NymProof{
+		Input:           input,
+		IssuerPublicKey: ipkBytes,
+		Signature:       signatureBytes,
+	})
+	return nil
+}
+
+// verifyNymProof verifies that the proof is a signature of the pseudonym owner on the message
+func verifyNymProof(owner *token.TokenOwner, proof *token.NymProof, msg []byte) error {
+	nymOwner, err := unmarshalNymOwner(owner)
+	if err != nil {
+		return err
+	}
+	nym, err := pointFromBytes(nymOwner.Nym)
+	if err != nil {
+		return errors.WithMessage(err, "invalid pseudonym")
+	}
+
+	ipk := &idemix.IssuerPublicKey{}
+	err = proto.Unmarshal(proof.IssuerPublicKey, ipk)
+	if err != nil {
+		return errors.Wrap(err, "failed unmarshaling issuer public key")
+	}
+	// Check recomputes the hash of the key
+	err = ipk.Check()
+	if err != nil {
+		return errors.WithMessage(err, "invalid issuer public key")
+	}
+	if !bytes.Equal(ipk.Hash, nymOwner.IssuerPublicKeyHash) {
+		return errors.New("issuer public key does not match the pseudonym owner")
+	}
+
+	signature := &idemix.NymSignature{}
+	err = proto.Unmarshal(proof.Signature, signature)
+	if err != nil {
+		return errors.Wrap(err, "failed unmarshaling pseudonym signature")
+	}
+	return signature.Ver(nym, ipk, msg)
+}
+
+func unmarshalNymOwner(owner *token.TokenOwner) (*token.NymOwner, error) {
+	nymOwner := &token.NymOwner{}
+	err := proto.Unmarshal(owner.GetRaw(), nymOwner)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling pseudonym owner")
+	}
+	return nymOwner, nil
+}
+
+// nymProofMessage returns the message signed by the pseudonym owners of the inputs of the transfer
+func nymProofMessage(transfer *token.ConfidentialTransfer) ([]byte, error) {
+	unsigned := proto.Clone(transfer).(*token.ConfidentialTransfer)
+	unsigned.NymProofs = nil
+	msg, err := proto.Marshal(unsigned)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed marshaling confidential transfer")
+	}
+	return msg, nil
+}
+
+// getTransfer returns the transfer or the redeem of the passed transaction
+func getTransfer(ttx *token.TokenTransaction) *token.ConfidentialTransfer {
+	action := ttx.GetConfidentialAction()
+	if action.GetConfidentialTransfer() != nil {
+		return action.GetConfidentialTransfer()
+	}
+	return action.GetConfidentialRedeem()
+}
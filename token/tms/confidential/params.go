@@ -0,0 +1,104 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package confidential
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/hyperledger/fabric-amcl/amcl/FP256BN"
+	"github.com/hyperledger/fabric/idemix"
+	"github.com/pkg/errors"
+)
+
+// DefaultBitLength is the number of bits of the quantities of confidential tokens,
+// so that the quantities span the same range as the quantities of plain tokens
+const DefaultBitLength = 64
+
+// generatorLabel is hashed to the second generator of the commitments
+const generatorLabel = "github.com/hyperledger/fabric/token/tms/confidential/H"
+
+// Parameters are the public parameters of the commitments and of the proofs.
+type Parameters struct {
+	// G and H are the generators of the Pedersen commitments.
+	// H is hashed to the curve, so that nobody knows its discrete logarithm in base G.
+	G *FP256BN.ECP
+	H *FP256BN.ECP
+	// BitLength is the number of bits of the quantities proven by the range proofs
+	BitLength int
+}
+
+// NewParameters returns the parameters of the commitments to quantities of the given number of bits
+func NewParameters(bitLength int) (*Parameters, error) {
+	if bitLength <= 0 || bitLength > 64 {
+		return nil, errors.Errorf("invalid bit length %d, must be between 1 and 64", bitLength)
+	}
+	digest := sha256.Sum256([]byte(generatorLabel))
+	return &Parameters{
+		G:         FP256BN.ECP_generator(),
+		H:         FP256BN.ECP_mapit(digest[:]),
+		BitLength: bitLength,
+	}, nil
+}
+
+// Commit returns the Pedersen commitment quantity*G + blindingFactor*H
+func (p *Parameters) Commit(quantity uint64, blindingFactor *FP256BN.BIG) *FP256BN.ECP {
+	return p.G.Mul2(bigFromUint64(quantity), p.H, blindingFactor)
+}
+
+func bigFromUint64(v uint64) *FP256BN.BIG {
+	b := make([]byte, idemix.FieldBytes)
+	binary.BigEndian.PutUint64(b[idemix.FieldBytes-8:], v)
+	return FP256BN.FromBytes(b)
+}
+
+// pointFromBytes deserializes a point, which must be on the curve and not at infinity
+func pointFromBytes(b []byte) (*FP256BN.ECP, error) {
+	if len(b) != 2*idemix.FieldBytes+1 {
+		return nil, errors.Errorf("invalid point length %d", len(b))
+	}
+	p := FP256BN.ECP_fromBytes(b)
+	if p.Is_infinity() {
+		return nil, errors.New("invalid point")
+	}
+	return p, nil
+}
+
+// scalarFromBytes deserializes a scalar, which must be reduced modulo the group order
+func scalarFromBytes(b []byte) (*FP256BN.BIG, error) {
+	if len(b) != idemix.FieldBytes {
+		return nil, errors.Errorf("invalid scalar length %d", len(b))
+	}
+	s := FP256BN.FromBytes(b)
+	reduced := FP256BN.NewBIGcopy(s)
+	reduced.Mod(idemix.GroupOrder)
+	if !bytes.Equal(idemix.BigToBytes(reduced), b) {
+		return nil, errors.New("scalar is not reduced modulo the group order")
+	}
+	return s, nil
+}
+
+func equalScalars(a, b *FP256BN.BIG) bool {
+	return bytes.Equal(idemix.BigToBytes(a), idemix.BigToBytes(b))
+}
+
+// copyPoint returns a copy of the passed point, as the amcl operations modify their receiver
+func copyPoint(p *FP256BN.ECP) *FP256BN.ECP {
+	c := FP256BN.NewECP()
+	c.Copy(p)
+	return c
+}
+
+// challenge hashes the label and the passed points to a scalar
+func challenge(label string, points ...*FP256BN.ECP) *FP256BN.BIG {
+	data := []byte(label)
+	for _, p := range points {
+		data = append(data, idemix.EcpToBytes(p)...)
+	}
+	return idemix.HashModOrder(data)
+}
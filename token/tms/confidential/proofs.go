@@ -0,0 +1,213 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package confidential
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-amcl/amcl"
+	"github.com/hyperledger/fabric-amcl/amcl/FP256BN"
+	"github.com/hyperledger/fabric/idemix"
+	"github.com/pkg/errors"
+)
+
+const (
+	bitProofLabel     = "confidential.BitProof"
+	balanceProofLabel = "confidential.BalanceProof"
+)
+
+// NewRangeProof proves that commitment = quantity*G + blindingFactor*H hides a quantity
+// of params.BitLength bits. The quantity is split into bits, each bit is committed to
+// with a blinding factor such that the weighted sum of the bit commitments is the
+// commitment, and an OR proof shows that each bit commitment hides either 0 or 1.
+func NewRangeProof(params *Parameters, quantity uint64, blindingFactor *FP256BN.BIG, rng *amcl.RAND) (*RangeProof, error) {
+	if params.BitLength < 64 && quantity>>uint(params.BitLength) != 0 {
+		return nil, errors.Errorf("quantity %d does not fit in %d bits", quantity, params.BitLength)
+	}
+	commitment := params.Commit(quantity, blindingFactor)
+
+	// the blinding factor of the least significant bit is set so that
+	// the weighted sum of the blinding factors of the bits is blindingFactor
+	blindingFactors := make([]*FP256BN.BIG, params.BitLength)
+	sum := FP256BN.NewBIGint(0)
+	weight := FP256BN.NewBIGint(1)
+	for i := 1; i < params.BitLength; i++ {
+		weight = idemix.Modadd(weight, weight, idemix.GroupOrder)
+		blindingFactors[i] = idemix.RandModOrder(rng)
+		sum = idemix.Modadd(sum, FP256BN.Modmul(weight, blindingFactors[i], idemix.GroupOrder), idemix.GroupOrder)
+	}
+	blindingFactors[0] = idemix.Modsub(blindingFactor, sum, idemix.GroupOrder)
+
+	proof := &RangeProof{}
+	for i := 0; i < params.BitLength; i++ {
+		bit := (quantity >> uint(i)) & 1
+		bitProof, err := newBitProof(params, commitment, bit, blindingFactors[i], rng)
+		if err != nil {
+			return nil, err
+		}
+		proof.Bits = append(proof.Bits, bitProof)
+	}
+	return proof, nil
+}
+
+// newBitProof commits to the passed bit and proves that the commitment hides either 0 or 1.
+// The statement about the actual bit is proven, and the statement about the other bit
+// is simulated by choosing its challenge beforehand.
+func newBitProof(params *Parameters, commitment *FP256BN.ECP, bit uint64, blindingFactor *FP256BN.BIG, rng *amcl.RAND) (*BitProof, error) {
+	bitCommitment := params.Commit(bit, blindingFactor)
+	statements := bitStatements(params, bitCommitment)
+
+	challenges := make([]*FP256BN.BIG, 2)
+	responses := make([]*FP256BN.BIG, 2)
+	commitments := make([]*FP256BN.ECP, 2)
+
+	other := 1 - bit
+	challenges[other] = idemix.RandModOrder(rng)
+	responses[other] = idemix.RandModOrder(rng)
+	commitments[other] = params.H.Mul(responses[other])
+	commitments[other].Sub(statements[other].Mul(challenges[other]))
+
+	nonce := idemix.RandModOrder(rng)
+	commitments[bit] = params.H.Mul(nonce)
+
+	c := challenge(bitProofLabel, commitment, bitCommitment, commitments[0], commitments[1])
+	challenges[bit] = idemix.Modsub(c, challenges[other], idemix.GroupOrder)
+	responses[bit] = idemix.Modadd(nonce, FP256BN.Modmul(challenges[bit], blindingFactor, idemix.GroupOrder), idemix.GroupOrder)
+
+	return &BitProof{
+		Commitment:    idemix.EcpToBytes(bitCommitment),
+		ChallengeZero: idemix.BigToBytes(challenges[0]),
+		ResponseZero:  idemix.BigToBytes(responses[0]),
+		ChallengeOne:  idemix.BigToBytes(challenges[1]),
+		ResponseOne:   idemix.BigToBytes(responses[1]),
+	}, nil
+}
+
+// bitStatements returns the points whose discrete logarithm in base H is known
+// when the bit commitment hides 0 and 1 respectively
+func bitStatements(params *Parameters, bitCommitment *FP256BN.ECP) []*FP256BN.ECP {
+	one := copyPoint(bitCommitment)
+	one.Sub(params.G)
+	return []*FP256BN.ECP{copyPoint(bitCommitment), one}
+}
+
+// VerifyRangeProof verifies that the commitment hides a quantity of params.BitLength bits
+func VerifyRangeProof(params *Parameters, commitment *FP256BN.ECP, proof *RangeProof) error {
+	if len(proof.GetBits()) != params.BitLength {
+		return errors.Errorf("range proof has %d bits, %d expected", len(proof.GetBits()), params.BitLength)
+	}
+
+	sum := FP256BN.NewECP()
+	weight := FP256BN.NewBIGint(1)
+	for i, bitProof := range proof.Bits {
+		bitCommitment, err := verifyBitProof(params, commitment, bitProof)
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("invalid proof of bit %d", i))
+		}
+		sum.Add(bitCommitment.Mul(weight))
+		weight = idemix.Modadd(weight, weight, idemix.GroupOrder)
+	}
+	if !sum.Equals(commitment) {
+		return errors.New("bit commitments do not add up to the commitment")
+	}
+	return nil
+}
+
+func verifyBitProof(params *Parameters, commitment *FP256BN.ECP, proof *BitProof) (*FP256BN.ECP, error) {
+	bitCommitment, err := pointFromBytes(proof.Commitment)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid bit commitment")
+	}
+	challenges := make([]*FP256BN.BIG, 2)
+	responses := make([]*FP256BN.BIG, 2)
+	for i, b := range [][]byte{proof.ChallengeZero, proof.ResponseZero, proof.ChallengeOne, proof.ResponseOne} {
+		s, err := scalarFromBytes(b)
+		if err != nil {
+			return nil, err
+		}
+		if i%2 == 0 {
+			challenges[i/2] = s
+		} else {
+			responses[i/2] = s
+		}
+	}
+
+	statements := bitStatements(params, bitCommitment)
+	commitments := make([]*FP256BN.ECP, 2)
+	for i := range commitments {
+		commitments[i] = params.H.Mul(responses[i])
+		commitments[i].Sub(statements[i].Mul(challenges[i]))
+	}
+
+	c := challenge(bitProofLabel, commitment, bitCommitment, commitments[0], commitments[1])
+	if !equalScalars(c, idemix.Modadd(challenges[0], challenges[1], idemix.GroupOrder)) {
+		return nil, errors.New("bit proof is invalid")
+	}
+	return bitCommitment, nil
+}
+
+// NewBalanceProof proves that the difference between the sum of the input commitments and
+// the sum of the output commitments and of redeemedQuantity*G is a commitment to 0,
+// by proving knowledge of its blinding factor, which is the difference between the sum of
+// the blinding factors of the inputs and the sum of the blinding factors of the outputs.
+func NewBalanceProof(params *Parameters, inputs, outputs []*Opening, redeemedQuantity uint64, rng *amcl.RAND) (*BalanceProof, error) {
+	blindingFactor := FP256BN.NewBIGint(0)
+	difference := FP256BN.NewECP()
+	for _, input := range inputs {
+		r, err := scalarFromBytes(input.BlindingFactor)
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid blinding factor of input")
+		}
+		blindingFactor = idemix.Modadd(blindingFactor, r, idemix.GroupOrder)
+		difference.Add(params.Commit(input.Quantity, r))
+	}
+	for _, output := range outputs {
+		r, err := scalarFromBytes(output.BlindingFactor)
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid blinding factor of output")
+		}
+		blindingFactor = idemix.Modsub(blindingFactor, r, idemix.GroupOrder)
+		difference.Sub(params.Commit(output.Quantity, r))
+	}
+	difference.Sub(params.G.Mul(bigFromUint64(redeemedQuantity)))
+
+	nonce := idemix.RandModOrder(rng)
+	c := challenge(balanceProofLabel, difference, params.H.Mul(nonce))
+	return &BalanceProof{
+		Challenge: idemix.BigToBytes(c),
+		Response:  idemix.BigToBytes(idemix.Modadd(nonce, FP256BN.Modmul(c, blindingFactor, idemix.GroupOrder), idemix.GroupOrder)),
+	}, nil
+}
+
+// VerifyBalanceProof verifies that the inputs commit to the same quantity as
+// the outputs and redeemedQuantity together
+func VerifyBalanceProof(params *Parameters, inputs, outputs []*FP256BN.ECP, redeemedQuantity uint64, proof *BalanceProof) error {
+	c, err := scalarFromBytes(proof.GetChallenge())
+	if err != nil {
+		return errors.WithMessage(err, "invalid challenge")
+	}
+	s, err := scalarFromBytes(proof.GetResponse())
+	if err != nil {
+		return errors.WithMessage(err, "invalid response")
+	}
+
+	difference := FP256BN.NewECP()
+	for _, input := range inputs {
+		difference.Add(input)
+	}
+	for _, output := range outputs {
+		difference.Sub(output)
+	}
+	difference.Sub(params.G.Mul(bigFromUint64(redeemedQuantity)))
+
+	nonceCommitment := params.H.Mul(s)
+	nonceCommitment.Sub(difference.Mul(c))
+	if !equalScalars(c, challenge(balanceProofLabel, difference, nonceCommitment)) {
+		return errors.New("balance proof is invalid")
+	}
+	return nil
+}
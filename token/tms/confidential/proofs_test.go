@@ -0,0 +1,177 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package confidential_test
+
+import (
+	"github.com/hyperledger/fabric-amcl/amcl"
+	"github.com/hyperledger/fabric-amcl/amcl/FP256BN"
+	"github.com/hyperledger/fabric/idemix"
+	"github.com/hyperledger/fabric/token/tms/confidential"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Proofs", func() {
+	var (
+		params *confidential.Parameters
+		rng    *amcl.RAND
+	)
+
+	BeforeEach(func() {
+		var err error
+		params, err = confidential.NewParameters(8)
+		Expect(err).NotTo(HaveOccurred())
+		rng, err = idemix.GetRand()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("NewParameters", func() {
+		It("derives the same generators every time", func() {
+			other, err := confidential.NewParameters(confidential.DefaultBitLength)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(other.G.Equals(params.G)).To(BeTrue())
+			Expect(other.H.Equals(params.H)).To(BeTrue())
+			Expect(params.G.Equals(params.H)).To(BeFalse())
+		})
+
+		It("rejects invalid bit lengths", func() {
+			_, err := confidential.NewParameters(0)
+			Expect(err).To(MatchError("invalid bit length 0, must be between 1 and 64"))
+			_, err = confidential.NewParameters(65)
+			Expect(err).To(MatchError("invalid bit length 65, must be between 1 and 64"))
+		})
+	})
+
+	Describe("RangeProof", func() {
+		var (
+			blindingFactor *FP256BN.BIG
+			commitment     *FP256BN.ECP
+		)
+
+		BeforeEach(func() {
+			blindingFactor = idemix.RandModOrder(rng)
+			commitment = params.Commit(200, blindingFactor)
+		})
+
+		It("proves that the commitment hides a quantity in range", func() {
+			proof, err := confidential.NewRangeProof(params, 200, blindingFactor, rng)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proof.Bits).To(HaveLen(8))
+			err = confidential.VerifyRangeProof(params, commitment, proof)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("proves the bounds of the range", func() {
+			for _, quantity := range []uint64{0, 255} {
+				proof, err := confidential.NewRangeProof(params, quantity, blindingFactor, rng)
+				Expect(err).NotTo(HaveOccurred())
+				err = confidential.VerifyRangeProof(params, params.Commit(quantity, blindingFactor), proof)
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		Context("when the quantity is out of range", func() {
+			It("returns an error", func() {
+				_, err := confidential.NewRangeProof(params, 256, blindingFactor, rng)
+				Expect(err).To(MatchError("quantity 256 does not fit in 8 bits"))
+			})
+		})
+
+		Context("when the proof is for another commitment", func() {
+			It("fails verification", func() {
+				proof, err := confidential.NewRangeProof(params, 200, blindingFactor, rng)
+				Expect(err).NotTo(HaveOccurred())
+				err = confidential.VerifyRangeProof(params, params.Commit(201, blindingFactor), proof)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Context("when a bit proof is tampered with", func() {
+			It("fails verification", func() {
+				proof, err := confidential.NewRangeProof(params, 200, blindingFactor, rng)
+				Expect(err).NotTo(HaveOccurred())
+				proof.Bits[3].ResponseOne = idemix.BigToBytes(idemix.RandModOrder(rng))
+				err = confidential.VerifyRangeProof(params, commitment, proof)
+				Expect(err).To(MatchError("invalid proof of bit 3: bit proof is invalid"))
+			})
+		})
+
+		Context("when the proof has the wrong number of bits", func() {
+			It("fails verification", func() {
+				proof, err := confidential.NewRangeProof(params, 200, blindingFactor, rng)
+				Expect(err).NotTo(HaveOccurred())
+				proof.Bits = proof.Bits[:7]
+				err = confidential.VerifyRangeProof(params, commitment, proof)
+				Expect(err).To(MatchError("range proof has 7 bits, 8 expected"))
+			})
+		})
+
+		Context("when a scalar is not reduced", func() {
+			It("fails verification", func() {
+				proof, err := confidential.NewRangeProof(params, 200, blindingFactor, rng)
+				Expect(err).NotTo(HaveOccurred())
+				proof.Bits[0].ChallengeZero = idemix.BigToBytes(idemix.GroupOrder)
+				err = confidential.VerifyRangeProof(params, commitment, proof)
+				Expect(err).To(MatchError("invalid proof of bit 0: scalar is not reduced modulo the group order"))
+			})
+		})
+	})
+
+	Describe("BalanceProof", func() {
+		var (
+			inputs  []*confidential.Opening
+			outputs []*confidential.Opening
+		)
+
+		commitments := func(openings []*confidential.Opening) []*FP256BN.ECP {
+			var points []*FP256BN.ECP
+			for _, opening := range openings {
+				points = append(points, params.Commit(opening.Quantity, FP256BN.FromBytes(opening.BlindingFactor)))
+			}
+			return points
+		}
+
+		BeforeEach(func() {
+			inputs = []*confidential.Opening{confidential.NewOpening(100, rng), confidential.NewOpening(50, rng)}
+			outputs = []*confidential.Opening{confidential.NewOpening(120, rng)}
+		})
+
+		It("proves that the inputs balance the outputs and the redeemed quantity", func() {
+			proof, err := confidential.NewBalanceProof(params, inputs, outputs, 30, rng)
+			Expect(err).NotTo(HaveOccurred())
+			err = confidential.VerifyBalanceProof(params, commitments(inputs), commitments(outputs), 30, proof)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the redeemed quantity is different", func() {
+			It("fails verification", func() {
+				proof, err := confidential.NewBalanceProof(params, inputs, outputs, 30, rng)
+				Expect(err).NotTo(HaveOccurred())
+				err = confidential.VerifyBalanceProof(params, commitments(inputs), commitments(outputs), 31, proof)
+				Expect(err).To(MatchError("balance proof is invalid"))
+			})
+		})
+
+		Context("when the quantities do not balance", func() {
+			It("fails verification", func() {
+				outputs[0].Quantity = 121
+				proof, err := confidential.NewBalanceProof(params, inputs, outputs, 30, rng)
+				Expect(err).NotTo(HaveOccurred())
+				err = confidential.VerifyBalanceProof(params, commitments(inputs), commitments(outputs), 30, proof)
+				Expect(err).To(MatchError("balance proof is invalid"))
+			})
+		})
+
+		Context("when a blinding factor is invalid", func() {
+			It("returns an error", func() {
+				inputs[0].BlindingFactor = []byte("garbage")
+				_, err := confidential.NewBalanceProof(params, inputs, outputs, 30, rng)
+				Expect(err).To(MatchError("invalid blinding factor of input: invalid scalar length 7"))
+			})
+		})
+	})
+})
@@ -0,0 +1,210 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package confidential
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-amcl/amcl"
+	"github.com/hyperledger/fabric/idemix"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/pkg/errors"
+)
+
+// An Input is an unspent confidential output along with the opening of its commitment
+type Input struct {
+	Id      *token.TokenId
+	Opening *Opening
+}
+
+// NewOpening returns the opening of a commitment to the passed quantity with a random blinding factor
+func NewOpening(quantity uint64, rng *amcl.RAND) *Opening {
+	return &Opening{
+		Quantity:       quantity,
+		BlindingFactor: idemix.BigToBytes(idemix.RandModOrder(rng)),
+	}
+}
+
+// NewOutput returns an output of the given owner and type, committing to the passed opening
+func NewOutput(params *Parameters, owner *token.TokenOwner, tokenType string, opening *Opening, rng *amcl.RAND) (*token.ConfidentialOutput, error) {
+	blindingFactor, err := scalarFromBytes(opening.BlindingFactor)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid blinding factor")
+	}
+	rangeProof, err := NewRangeProof(params, opening.Quantity, blindingFactor, rng)
+	if err != nil {
+		return nil, err
+	}
+	rangeProofBytes, err := proto.Marshal(rangeProof)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed marshaling range proof")
+	}
+	return &token.ConfidentialOutput{
+		Owner:      owner,
+		Type:       tokenType,
+		Commitment: idemix.EcpToBytes(params.Commit(opening.Quantity, blindingFactor)),
+		RangeProof: rangeProofBytes,
+	}, nil
+}
+
+// NewImport returns a transaction importing tokens of the given type for the recipients
+// of the shares, along with the openings of its outputs, which are sent to the recipients
+func NewImport(params *Parameters, tokenType string, shares []*token.RecipientTransferShare, rng *amcl.RAND) (*token.TokenTransaction, []*Opening, error) {
+	if len(shares) == 0 {
+		return nil, nil, errors.New("no shares to import")
+	}
+	outputs, openings, err := newOutputs(params, tokenType, shares, rng)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &token.TokenTransaction{
+		Action: &token.TokenTransaction_ConfidentialAction{
+			ConfidentialAction: &token.ConfidentialTokenAction{
+				Data: &token.ConfidentialTokenAction_ConfidentialImport{
+					ConfidentialImport: &token.ConfidentialImport{
+						Outputs: outputs,
+					},
+				},
+			},
+		},
+	}, openings, nil
+}
+
+// NewTransfer returns a transaction transferring the inputs, of the given type, to the
+// recipients of the shares, along with the openings of its outputs.
+// The quantities of the shares must add up to the quantities of the inputs.
+// The inputs owned by pseudonyms need proofs added with AddNymProof.
+func NewTransfer(params *Parameters, tokenType string, inputs []*Input, shares []*token.RecipientTransferShare, rng *amcl.RAND) (*token.TokenTransaction, []*Opening, error) {
+	if len(shares) == 0 {
+		return nil, nil, errors.New("no shares to transfer")
+	}
+	transfer, openings, err := newTransfer(params, tokenType, inputs, shares, 0, rng)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &token.TokenTransaction{
+		Action: &token.TokenTransaction_ConfidentialAction{
+			ConfidentialAction: &token.ConfidentialTokenAction{
+				Data: &token.ConfidentialTokenAction_ConfidentialTransfer{
+					ConfidentialTransfer: transfer,
+				},
+			},
+		},
+	}, openings, nil
+}
+
+// NewRedeem returns a transaction redeeming the passed quantity of the inputs, of the given type.
+// The remaining tokens, if any, are transferred to the passed owner, which must be the owner of an input.
+// The opening of the output of the remaining tokens is returned along with the transaction.
+func NewRedeem(params *Parameters, tokenType string, inputs []*Input, quantity uint64, owner *token.TokenOwner, rng *amcl.RAND) (*token.TokenTransaction, []*Opening, error) {
+	if quantity == 0 {
+		return nil, nil, errors.New("quantity to redeem is 0")
+	}
+	sum, err := sumInputs(inputs)
+	if err != nil {
+		return nil, nil, err
+	}
+	if sum < quantity {
+		return nil, nil, errors.Errorf("total quantity [%d] from TokenIds is less than quantity [%d] to be redeemed", sum, quantity)
+	}
+	var shares []*token.RecipientTransferShare
+	if sum > quantity {
+		if owner == nil {
+			return nil, nil, errors.New("missing owner of the remaining tokens")
+		}
+		shares = append(shares, &token.RecipientTransferShare{Recipient: owner, Quantity: sum - quantity})
+	}
+	redeem, openings, err := newTransfer(params, tokenType, inputs, shares, quantity, rng)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &token.TokenTransaction{
+		Action: &token.TokenTransaction_ConfidentialAction{
+			ConfidentialAction: &token.ConfidentialTokenAction{
+				Data: &token.ConfidentialTokenAction_ConfidentialRedeem{
+					ConfidentialRedeem: redeem,
+				},
+			},
+		},
+	}, openings, nil
+}
+
+func newTransfer(params *Parameters, tokenType string, inputs []*Input, shares []*token.RecipientTransferShare, redeemedQuantity uint64, rng *amcl.RAND) (*token.ConfidentialTransfer, []*Opening, error) {
+	if len(inputs) == 0 {
+		return nil, nil, errors.New("no inputs")
+	}
+	sum, err := sumInputs(inputs)
+	if err != nil {
+		return nil, nil, err
+	}
+	outputSum := redeemedQuantity
+	for _, share := range shares {
+		outputSum += share.Quantity
+		if outputSum < share.Quantity {
+			return nil, nil, errors.New("quantity overflow in outputs")
+		}
+	}
+	if outputSum != sum {
+		return nil, nil, errors.Errorf("token sum mismatch in inputs and outputs (%d vs %d)", sum, outputSum)
+	}
+
+	outputs, openings, err := newOutputs(params, tokenType, shares, rng)
+	if err != nil {
+		return nil, nil, err
+	}
+	var ids []*token.TokenId
+	var inputOpenings []*Opening
+	for _, input := range inputs {
+		ids = append(ids, input.Id)
+		inputOpenings = append(inputOpenings, input.Opening)
+	}
+	balanceProof, err := NewBalanceProof(params, inputOpenings, openings, redeemedQuantity, rng)
+	if err != nil {
+		return nil, nil, err
+	}
+	balanceProofBytes, err := proto.Marshal(balanceProof)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed marshaling balance proof")
+	}
+	return &token.ConfidentialTransfer{
+		Inputs:           ids,
+		Outputs:          outputs,
+		RedeemedQuantity: redeemedQuantity,
+		BalanceProof:     balanceProofBytes,
+	}, openings, nil
+}
+
+func newOutputs(params *Parameters, tokenType string, shares []*token.RecipientTransferShare, rng *amcl.RAND) ([]*token.ConfidentialOutput, []*Opening, error) {
+	var outputs []*token.ConfidentialOutput
+	var openings []*Opening
+	for _, share := range shares {
+		if share.Recipient == nil {
+			return nil, nil, errors.New("missing recipient in share")
+		}
+		opening := NewOpening(share.Quantity, rng)
+		output, err := NewOutput(params, share.Recipient, tokenType, opening, rng)
+		if err != nil {
+			return nil, nil, err
+		}
+		outputs = append(outputs, output)
+		openings = append(openings, opening)
+	}
+	return outputs, openings, nil
+}
+
+func sumInputs(inputs []*Input) (uint64, error) {
+	sum := uint64(0)
+	for _, input := range inputs {
+		if input == nil || input.Id == nil || input.Opening == nil {
+			return 0, errors.New("input without ID or opening")
+		}
+		sum += input.Opening.Quantity
+		if sum < input.Opening.Quantity {
+			return 0, errors.New("quantity overflow in inputs")
+		}
+	}
+	return sum, nil
+}
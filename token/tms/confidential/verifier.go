@@ -0,0 +1,446 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package confidential
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-amcl/amcl/FP256BN"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/ledger/customtx"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/hyperledger/fabric/token/identity"
+	"github.com/hyperledger/fabric/token/ledger"
+	"github.com/pkg/errors"
+)
+
+const (
+	minUnicodeRuneValue   = 0            //U+0000
+	maxUnicodeRuneValue   = utf8.MaxRune //U+10FFFF - maximum (and unallocated) code point
+	compositeKeyNamespace = "\x00"
+	tokenOutput           = "confidentialTokenOutput"
+	tokenRedeem           = "confidentialTokenRedeem"
+	tokenInput            = "confidentialTokenInput"
+	tokenNameSpace        = "_fabtoken"
+)
+
+var verifierLogger = flogging.MustGetLogger("token.tms.confidential.verifier")
+
+// A Verifier validates and commits confidential token transactions.
+// The quantities of the outputs are hidden in commitments: a transaction carries
+// range proofs showing that its outputs commit to non-negative quantities and, for
+// transfers and redemptions, a balance proof showing that its inputs and outputs
+// commit to the same quantity.
+type Verifier struct {
+	Parameters          *Parameters
+	IssuingValidator    identity.IssuingValidator
+	TokenOwnerValidator identity.TokenOwnerValidator
+}
+
+// ProcessTx checks that transactions are correct wrt. the most recent ledger state.
+// ProcessTx checks are ones that shall be done sequentially, since transactions within a block may introduce dependencies.
+func (v *Verifier) ProcessTx(txID string, creator identity.PublicInfo, ttx *token.TokenTransaction, simulator ledger.LedgerWriter) error {
+	verifierLogger.Debugf("checking transaction with txID '%s'", txID)
+	err := v.checkProcess(txID, creator, ttx, simulator)
+	if err != nil {
+		return err
+	}
+
+	verifierLogger.Debugf("committing transaction with txID '%s'", txID)
+	err = v.commitAction(ttx.GetConfidentialAction(), txID, simulator)
+	if err != nil {
+		verifierLogger.Errorf("error committing transaction with txID '%s': %s", txID, err)
+		return err
+	}
+	verifierLogger.Debugf("successfully processed transaction with txID '%s'", txID)
+	return nil
+}
+
+func (v *Verifier) checkProcess(txID string, creator identity.PublicInfo, ttx *token.TokenTransaction, simulator ledger.LedgerReader) error {
+	action := ttx.GetConfidentialAction()
+	if action == nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("check process failed for transaction '%s': missing confidential token action", txID)}
+	}
+	if len(ttx.GetSignatures()) != 0 {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("check process failed for transaction '%s': owner signatures are not supported on confidential token actions", txID)}
+	}
+
+	switch data := action.Data.(type) {
+	case *token.ConfidentialTokenAction_ConfidentialImport:
+		return v.checkImportAction(creator, data.ConfidentialImport, txID, simulator)
+	case *token.ConfidentialTokenAction_ConfidentialTransfer:
+		if data.ConfidentialTransfer.GetRedeemedQuantity() != 0 {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("transfer transaction '%s' redeems tokens", txID)}
+		}
+		if len(data.ConfidentialTransfer.GetOutputs()) == 0 {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("no outputs in transaction: %s", txID)}
+		}
+		_, err := v.checkTransferAction(creator, data.ConfidentialTransfer, txID, simulator)
+		return err
+	case *token.ConfidentialTokenAction_ConfidentialRedeem:
+		return v.checkRedeemAction(creator, data.ConfidentialRedeem, txID, simulator)
+	default:
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("unknown confidential token action: %T", data)}
+	}
+}
+
+func (v *Verifier) checkImportAction(creator identity.PublicInfo, importAction *token.ConfidentialImport, txID string, simulator ledger.LedgerReader) error {
+	if len(importAction.GetOutputs()) == 0 {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("no outputs in transaction: %s", txID)}
+	}
+	_, _, err := v.checkOutputs(importAction.GetOutputs(), txID, simulator)
+	if err != nil {
+		return err
+	}
+	for _, output := range importAction.GetOutputs() {
+		err := v.IssuingValidator.Validate(creator, output.Type)
+		if err != nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("import policy check failed: %s", err)}
+		}
+	}
+	return nil
+}
+
+func (v *Verifier) checkRedeemAction(creator identity.PublicInfo, redeemAction *token.ConfidentialTransfer, txID string, simulator ledger.LedgerReader) error {
+	if redeemAction.GetRedeemedQuantity() == 0 {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("redeem transaction '%s' redeems no tokens", txID)}
+	}
+	// a redeem transaction has at most one output, which holds the remaining tokens
+	if len(redeemAction.GetOutputs()) > 1 {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("too many outputs (%d) in a redeem transaction", len(redeemAction.GetOutputs()))}
+	}
+	inputs, err := v.checkTransferAction(creator, redeemAction, txID, simulator)
+	if err != nil {
+		return err
+	}
+	if len(redeemAction.GetOutputs()) == 0 {
+		return nil
+	}
+	for _, input := range inputs {
+		if proto.Equal(input.Owner, redeemAction.Outputs[0].Owner) {
+			return nil
+		}
+	}
+	return &customtx.InvalidTxError{Msg: fmt.Sprintf("wrong owner for remaining tokens, should be the owner of an input")}
+}
+
+// checkTransferAction checks the inputs and the outputs of a transfer or of a redeem
+// and that they balance, and returns the inputs
+func (v *Verifier) checkTransferAction(creator identity.PublicInfo, transferAction *token.ConfidentialTransfer, txID string, simulator ledger.LedgerReader) ([]*token.ConfidentialOutput, error) {
+	outputType, outputCommitments, err := v.checkOutputs(transferAction.GetOutputs(), txID, simulator)
+	if err != nil {
+		return nil, err
+	}
+	inputs, err := v.checkInputs(creator, transferAction, txID, simulator)
+	if err != nil {
+		return nil, err
+	}
+
+	inputType := inputs[0].Type
+	var inputCommitments []*FP256BN.ECP
+	for _, input := range inputs {
+		if input.Type != inputType {
+			return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("multiple token types in input for txID: %s (%s, %s)", txID, inputType, input.Type)}
+		}
+		commitment, err := pointFromBytes(input.Commitment)
+		if err != nil {
+			return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("invalid commitment of input for txID '%s': %s", txID, err)}
+		}
+		inputCommitments = append(inputCommitments, commitment)
+	}
+	if len(outputCommitments) != 0 && outputType != inputType {
+		return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("token type mismatch in inputs and outputs for transaction ID %s (%s vs %s)", txID, outputType, inputType)}
+	}
+
+	balanceProof := &BalanceProof{}
+	err = proto.Unmarshal(transferAction.GetBalanceProof(), balanceProof)
+	if err != nil {
+		return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("error unmarshaling balance proof of transaction '%s': %s", txID, err)}
+	}
+	err = VerifyBalanceProof(v.Parameters, inputCommitments, outputCommitments, transferAction.GetRedeemedQuantity(), balanceProof)
+	if err != nil {
+		return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("token sum mismatch in inputs and outputs for transaction ID %s: %s", txID, err)}
+	}
+	return inputs, nil
+}
+
+// checkOutputs checks that the outputs are new, have valid owners, a single type and
+// commitments to quantities in range, and returns their type and commitments
+func (v *Verifier) checkOutputs(outputs []*token.ConfidentialOutput, txID string, simulator ledger.LedgerReader) (string, []*FP256BN.ECP, error) {
+	tokenType := ""
+	var commitments []*FP256BN.ECP
+	for i, output := range outputs {
+		outputID, err := createOutputKey(txID, i)
+		if err != nil {
+			return "", nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating output ID: %s", err)}
+		}
+		existingOutputBytes, err := simulator.GetState(tokenNameSpace, outputID)
+		if err != nil {
+			return "", nil, err
+		}
+		if existingOutputBytes != nil {
+			return "", nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("output already exists: %s", outputID)}
+		}
+
+		if i == 0 {
+			tokenType = output.GetType()
+		} else if tokenType != output.GetType() {
+			return "", nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("multiple token types ('%s', '%s') in output for txID '%s'", tokenType, output.GetType(), txID)}
+		}
+		err = v.checkOwner(output.GetOwner())
+		if err != nil {
+			return "", nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("invalid owner in output for txID '%s', err '%s'", txID, err)}
+		}
+
+		commitment, err := pointFromBytes(output.GetCommitment())
+		if err != nil {
+			return "", nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("invalid commitment in output %d for txID '%s': %s", i, txID, err)}
+		}
+		rangeProof := &RangeProof{}
+		err = proto.Unmarshal(output.GetRangeProof(), rangeProof)
+		if err != nil {
+			return "", nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("error unmarshaling range proof in output %d for txID '%s': %s", i, txID, err)}
+		}
+		err = VerifyRangeProof(v.Parameters, commitment, rangeProof)
+		if err != nil {
+			return "", nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("invalid range proof in output %d for txID '%s': %s", i, txID, err)}
+		}
+		commitments = append(commitments, commitment)
+	}
+	return tokenType, commitments, nil
+}
+
+// checkOwner checks that the owner is an identity, validated by the TokenOwnerValidator, or a pseudonym
+func (v *Verifier) checkOwner(owner *token.TokenOwner) error {
+	switch owner.GetType() {
+	case token.TokenOwner_MSP_IDENTIFIER:
+		return v.TokenOwnerValidator.Validate(owner)
+	case token.TokenOwner_IDEMIX_NYM:
+		nymOwner, err := unmarshalNymOwner(owner)
+		if err != nil {
+			return err
+		}
+		if len(nymOwner.IssuerPublicKeyHash) == 0 {
+			return errors.New("pseudonym owner has no issuer public key hash")
+		}
+		_, err = pointFromBytes(nymOwner.Nym)
+		if err != nil {
+			return errors.WithMessage(err, "invalid pseudonym")
+		}
+		return nil
+	default:
+		return errors.Errorf("owner type %s is not supported by confidential tokens", owner.GetType())
+	}
+}
+
+// checkInputs checks that the inputs exist, are unspent, are spent once and are owned
+// by the creator, and returns them
+func (v *Verifier) checkInputs(creator identity.PublicInfo, transferAction *token.ConfidentialTransfer, txID string, simulator ledger.LedgerReader) ([]*token.ConfidentialOutput, error) {
+	if len(transferAction.GetInputs()) == 0 {
+		return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("no inputs in transaction: %s", txID)}
+	}
+
+	nymProofs := map[uint32]*token.NymProof{}
+	for _, proof := range transferAction.GetNymProofs() {
+		if nymProofs[proof.Input] != nil {
+			return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("more than one pseudonym proof for input %d in transaction ID '%s'", proof.Input, txID)}
+		}
+		nymProofs[proof.Input] = proof
+	}
+	var nymProofMsg []byte
+
+	var inputs []*token.ConfidentialOutput
+	processedIDs := make(map[string]bool)
+	for i, id := range transferAction.GetInputs() {
+		inputKey, err := createOutputKey(id.TxId, int(id.Index))
+		if err != nil {
+			return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating output ID for transfer input: %s", err)}
+		}
+		if processedIDs[inputKey] {
+			return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("token input '%s' spent more than once in transaction ID '%s'", inputKey, txID)}
+		}
+		processedIDs[inputKey] = true
+
+		input, err := v.getOutput(inputKey, simulator)
+		if err != nil {
+			return nil, err
+		}
+		spentKey, err := createSpentKey(id.TxId, int(id.Index))
+		if err != nil {
+			return nil, err
+		}
+		spent, err := simulator.GetState(tokenNameSpace, spentKey)
+		if err != nil {
+			return nil, err
+		}
+		if spent != nil {
+			return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("input with ID %s for transfer has already been spent", inputKey)}
+		}
+
+		switch input.GetOwner().GetType() {
+		case token.TokenOwner_MSP_IDENTIFIER:
+			if !bytes.Equal(creator.Public(), input.Owner.Raw) {
+				return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("transfer input with ID %s not owned by creator", inputKey)}
+			}
+		case token.TokenOwner_IDEMIX_NYM:
+			proof := nymProofs[uint32(i)]
+			if proof == nil {
+				return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("missing pseudonym proof for transfer input with ID %s", inputKey)}
+			}
+			delete(nymProofs, uint32(i))
+			if nymProofMsg == nil {
+				nymProofMsg, err = nymProofMessage(transferAction)
+				if err != nil {
+					return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating pseudonym proof message for txID '%s': %s", txID, err)}
+				}
+			}
+			err = verifyNymProof(input.Owner, proof, nymProofMsg)
+			if err != nil {
+				return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("invalid pseudonym proof for transfer input with ID %s: %s", inputKey, err)}
+			}
+		default:
+			return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("transfer input with ID %s has unknown owner type %s", inputKey, input.GetOwner().GetType())}
+		}
+		inputs = append(inputs, input)
+	}
+	if len(nymProofs) != 0 {
+		return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("pseudonym proofs for inputs not owned by pseudonyms in transaction ID '%s'", txID)}
+	}
+	return inputs, nil
+}
+
+func (v *Verifier) getOutput(outputID string, simulator ledger.LedgerReader) (*token.ConfidentialOutput, error) {
+	outputBytes, err := simulator.GetState(tokenNameSpace, outputID)
+	if err != nil {
+		return nil, err
+	}
+	if len(outputBytes) == 0 {
+		return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("input with ID %s for transfer does not exist", outputID)}
+	}
+	output := &token.ConfidentialOutput{}
+	err = proto.Unmarshal(outputBytes, output)
+	if err != nil {
+		return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("unmarshaling error: %s", err)}
+	}
+	return output, nil
+}
+
+func (v *Verifier) commitAction(action *token.ConfidentialTokenAction, txID string, simulator ledger.LedgerWriter) error {
+	switch data := action.Data.(type) {
+	case *token.ConfidentialTokenAction_ConfidentialImport:
+		return v.addOutputs(data.ConfidentialImport.GetOutputs(), txID, simulator)
+	case *token.ConfidentialTokenAction_ConfidentialTransfer:
+		return v.commitTransferAction(data.ConfidentialTransfer, txID, simulator)
+	case *token.ConfidentialTokenAction_ConfidentialRedeem:
+		// the redeemed quantity is disclosed, and recorded as a plain output without owner
+		// of the type of the inputs
+		id := data.ConfidentialRedeem.Inputs[0]
+		inputKey, err := createOutputKey(id.TxId, int(id.Index))
+		if err != nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating output ID for redeem input: %s", err)}
+		}
+		input, err := v.getOutput(inputKey, simulator)
+		if err != nil {
+			return err
+		}
+		redeemKey, err := createRedeemKey(txID, 0)
+		if err != nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating redeem ID: %s", err)}
+		}
+		redeemed := &token.PlainOutput{Type: input.Type, Quantity: data.ConfidentialRedeem.RedeemedQuantity}
+		err = simulator.SetState(tokenNameSpace, redeemKey, utils.MarshalOrPanic(redeemed))
+		if err != nil {
+			return err
+		}
+		return v.commitTransferAction(data.ConfidentialRedeem, txID, simulator)
+	}
+	return nil
+}
+
+func (v *Verifier) commitTransferAction(transferAction *token.ConfidentialTransfer, txID string, simulator ledger.LedgerWriter) error {
+	err := v.addOutputs(transferAction.GetOutputs(), txID, simulator)
+	if err != nil {
+		return err
+	}
+	for _, id := range transferAction.GetInputs() {
+		spentKey, err := createSpentKey(id.TxId, int(id.Index))
+		if err != nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating spent key: %s", err)}
+		}
+		verifierLogger.Debugf("marking input '%s' as spent", spentKey)
+		err = simulator.SetState(tokenNameSpace, spentKey, []byte{1})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *Verifier) addOutputs(outputs []*token.ConfidentialOutput, txID string, simulator ledger.LedgerWriter) error {
+	for i, output := range outputs {
+		outputID, err := createOutputKey(txID, i)
+		if err != nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating output ID: %s", err)}
+		}
+		err = simulator.SetState(tokenNameSpace, outputID, utils.MarshalOrPanic(output))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Create a ledger key for an individual output in a token transaction, as a function of
+// the transaction ID, and the index of the output
+func createOutputKey(txID string, index int) (string, error) {
+	return createCompositeKey(tokenOutput, []string{txID, strconv.Itoa(index)})
+}
+
+// Create a ledger key for the redeemed quantity of a redeem transaction, as a function of
+// the transaction ID, and the index of the redemption
+func createRedeemKey(txID string, index int) (string, error) {
+	return createCompositeKey(tokenRedeem, []string{txID, strconv.Itoa(index)})
+}
+
+// Create a ledger key for a spent individual output in a token transaction, as a function of
+// the transaction ID, and the index of the output
+func createSpentKey(txID string, index int) (string, error) {
+	return createCompositeKey(tokenInput, []string{txID, strconv.Itoa(index)})
+}
+
+// createCompositeKey and its related functions and consts copied from core/chaincode/shim/chaincode.go
+func createCompositeKey(objectType string, attributes []string) (string, error) {
+	if err := validateCompositeKeyAttribute(objectType); err != nil {
+		return "", err
+	}
+	ck := compositeKeyNamespace + objectType + string(rune(minUnicodeRuneValue))
+	for _, att := range attributes {
+		if err := validateCompositeKeyAttribute(att); err != nil {
+			return "", err
+		}
+		ck += att + string(rune(minUnicodeRuneValue))
+	}
+	return ck, nil
+}
+
+func validateCompositeKeyAttribute(str string) error {
+	if !utf8.ValidString(str) {
+		return errors.Errorf("not a valid utf8 string: [%x]", str)
+	}
+	for index, runeValue := range str {
+		if runeValue == minUnicodeRuneValue || runeValue == maxUnicodeRuneValue {
+			return errors.Errorf(`input contain unicode %#U starting at position [%d]. %#U and %#U are not allowed in the input attribute of a composite key`,
+				runeValue, index, minUnicodeRuneValue, maxUnicodeRuneValue)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,354 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package confidential_test
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-amcl/amcl"
+	"github.com/hyperledger/fabric-amcl/amcl/FP256BN"
+	"github.com/hyperledger/fabric/core/ledger/customtx"
+	"github.com/hyperledger/fabric/idemix"
+	"github.com/hyperledger/fabric/protos/token"
+	mockid "github.com/hyperledger/fabric/token/identity/mock"
+	"github.com/hyperledger/fabric/token/tms/confidential"
+	"github.com/hyperledger/fabric/token/tms/plain"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("Verifier", func() {
+	var (
+		params                  *confidential.Parameters
+		rng                     *amcl.RAND
+		fakePublicInfo          *mockid.PublicInfo
+		fakeIssuingValidator    *mockid.IssuingValidator
+		fakeTokenOwnerValidator *mockid.TokenOwnerValidator
+		memoryLedger            *plain.MemoryLedger
+		verifier                *confidential.Verifier
+		alice                   *token.TokenOwner
+		bob                     *token.TokenOwner
+		importTransaction       *token.TokenTransaction
+		importOpenings          []*confidential.Opening
+	)
+
+	BeforeEach(func() {
+		var err error
+		params, err = confidential.NewParameters(8)
+		Expect(err).NotTo(HaveOccurred())
+		rng, err = idemix.GetRand()
+		Expect(err).NotTo(HaveOccurred())
+
+		fakePublicInfo = &mockid.PublicInfo{}
+		fakePublicInfo.PublicReturns([]byte("alice"))
+		fakeIssuingValidator = &mockid.IssuingValidator{}
+		fakeTokenOwnerValidator = &mockid.TokenOwnerValidator{}
+		memoryLedger = plain.NewMemoryLedger()
+		verifier = &confidential.Verifier{
+			Parameters:          params,
+			IssuingValidator:    fakeIssuingValidator,
+			TokenOwnerValidator: fakeTokenOwnerValidator,
+		}
+
+		alice = &token.TokenOwner{Type: token.TokenOwner_MSP_IDENTIFIER, Raw: []byte("alice")}
+		bob = &token.TokenOwner{Type: token.TokenOwner_MSP_IDENTIFIER, Raw: []byte("bob")}
+		importTransaction, importOpenings, err = confidential.NewImport(params, "TOK1", []*token.RecipientTransferShare{
+			{Recipient: alice, Quantity: 100},
+			{Recipient: alice, Quantity: 50},
+		}, rng)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("Import", func() {
+		It("stores the outputs without their quantities", func() {
+			err := verifier.ProcessTx("0", fakePublicInfo, importTransaction, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeIssuingValidator.ValidateCallCount()).To(Equal(2))
+			creator, tokenType := fakeIssuingValidator.ValidateArgsForCall(0)
+			Expect(creator).To(Equal(fakePublicInfo))
+			Expect(tokenType).To(Equal("TOK1"))
+			Expect(fakeTokenOwnerValidator.ValidateCallCount()).To(Equal(2))
+
+			outputBytes, err := memoryLedger.GetState("_fabtoken", "\x00confidentialTokenOutput\x000\x000\x00")
+			Expect(err).NotTo(HaveOccurred())
+			output := &token.ConfidentialOutput{}
+			err = proto.Unmarshal(outputBytes, output)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Equal(output, importTransaction.GetConfidentialAction().GetConfidentialImport().Outputs[0])).To(BeTrue())
+		})
+
+		Context("when the issuing policy rejects the creator", func() {
+			BeforeEach(func() {
+				fakeIssuingValidator.ValidateReturns(errors.New("no-way"))
+			})
+
+			It("returns an error", func() {
+				err := verifier.ProcessTx("0", fakePublicInfo, importTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "import policy check failed: no-way"}))
+			})
+		})
+
+		Context("when a range proof is invalid", func() {
+			BeforeEach(func() {
+				outputs := importTransaction.GetConfidentialAction().GetConfidentialImport().Outputs
+				outputs[1].RangeProof = outputs[0].RangeProof
+			})
+
+			It("returns an error", func() {
+				err := verifier.ProcessTx("0", fakePublicInfo, importTransaction, memoryLedger)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("invalid range proof in output 1 for txID '0'"))
+			})
+		})
+
+		Context("when an owner is not supported", func() {
+			BeforeEach(func() {
+				outputs := importTransaction.GetConfidentialAction().GetConfidentialImport().Outputs
+				outputs[0].Owner = &token.TokenOwner{Type: token.TokenOwner_MULTI_SIG}
+			})
+
+			It("returns an error", func() {
+				err := verifier.ProcessTx("0", fakePublicInfo, importTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "invalid owner in output for txID '0', err 'owner type MULTI_SIG is not supported by confidential tokens'"}))
+			})
+		})
+
+		Context("when the transaction is not confidential", func() {
+			It("returns an error", func() {
+				tx := &token.TokenTransaction{Action: &token.TokenTransaction_PlainAction{PlainAction: &token.PlainTokenAction{}}}
+				err := verifier.ProcessTx("0", fakePublicInfo, tx, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "check process failed for transaction '0': missing confidential token action"}))
+			})
+		})
+	})
+
+	Context("when tokens have been imported", func() {
+		var inputs []*confidential.Input
+
+		BeforeEach(func() {
+			err := verifier.ProcessTx("0", fakePublicInfo, importTransaction, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+			inputs = []*confidential.Input{
+				{Id: &token.TokenId{TxId: "0", Index: 0}, Opening: importOpenings[0]},
+				{Id: &token.TokenId{TxId: "0", Index: 1}, Opening: importOpenings[1]},
+			}
+		})
+
+		Describe("Transfer", func() {
+			It("transfers the tokens and spends the inputs", func() {
+				tx, openings, err := confidential.NewTransfer(params, "TOK1", inputs, []*token.RecipientTransferShare{
+					{Recipient: bob, Quantity: 30},
+					{Recipient: alice, Quantity: 120},
+				}, rng)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(openings).To(HaveLen(2))
+				Expect(openings[0].Quantity).To(Equal(uint64(30)))
+
+				err = verifier.ProcessTx("1", fakePublicInfo, tx, memoryLedger)
+				Expect(err).NotTo(HaveOccurred())
+
+				spent, err := memoryLedger.GetState("_fabtoken", "\x00confidentialTokenInput\x000\x001\x00")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(spent).NotTo(BeNil())
+
+				By("transferring the outputs again")
+				fakePublicInfo.PublicReturns([]byte("bob"))
+				tx, _, err = confidential.NewTransfer(params, "TOK1", []*confidential.Input{
+					{Id: &token.TokenId{TxId: "1", Index: 0}, Opening: openings[0]},
+				}, []*token.RecipientTransferShare{{Recipient: alice, Quantity: 30}}, rng)
+				Expect(err).NotTo(HaveOccurred())
+				err = verifier.ProcessTx("2", fakePublicInfo, tx, memoryLedger)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			Context("when an input has already been spent", func() {
+				It("returns an error", func() {
+					tx, _, err := confidential.NewTransfer(params, "TOK1", inputs[:1], []*token.RecipientTransferShare{{Recipient: bob, Quantity: 100}}, rng)
+					Expect(err).NotTo(HaveOccurred())
+					err = verifier.ProcessTx("1", fakePublicInfo, tx, memoryLedger)
+					Expect(err).NotTo(HaveOccurred())
+					err = verifier.ProcessTx("2", fakePublicInfo, tx, memoryLedger)
+					Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "input with ID \x00confidentialTokenOutput\x000\x000\x00 for transfer has already been spent"}))
+				})
+			})
+
+			Context("when the creator does not own an input", func() {
+				BeforeEach(func() {
+					fakePublicInfo.PublicReturns([]byte("bob"))
+				})
+
+				It("returns an error", func() {
+					tx, _, err := confidential.NewTransfer(params, "TOK1", inputs, []*token.RecipientTransferShare{{Recipient: bob, Quantity: 150}}, rng)
+					Expect(err).NotTo(HaveOccurred())
+					err = verifier.ProcessTx("1", fakePublicInfo, tx, memoryLedger)
+					Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "transfer input with ID \x00confidentialTokenOutput\x000\x000\x00 not owned by creator"}))
+				})
+			})
+
+			Context("when the outputs do not balance the inputs", func() {
+				It("returns an error", func() {
+					inputs[0].Opening = &confidential.Opening{Quantity: 110, BlindingFactor: inputs[0].Opening.BlindingFactor}
+					tx, _, err := confidential.NewTransfer(params, "TOK1", inputs, []*token.RecipientTransferShare{{Recipient: bob, Quantity: 160}}, rng)
+					Expect(err).NotTo(HaveOccurred())
+					err = verifier.ProcessTx("1", fakePublicInfo, tx, memoryLedger)
+					Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "token sum mismatch in inputs and outputs for transaction ID 1: balance proof is invalid"}))
+				})
+			})
+
+			Context("when the type of the outputs is different", func() {
+				It("returns an error", func() {
+					tx, _, err := confidential.NewTransfer(params, "TOK2", inputs, []*token.RecipientTransferShare{{Recipient: bob, Quantity: 150}}, rng)
+					Expect(err).NotTo(HaveOccurred())
+					err = verifier.ProcessTx("1", fakePublicInfo, tx, memoryLedger)
+					Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "token type mismatch in inputs and outputs for transaction ID 1 (TOK2 vs TOK1)"}))
+				})
+			})
+
+			Context("when the transfer redeems tokens", func() {
+				It("returns an error", func() {
+					tx, _, err := confidential.NewTransfer(params, "TOK1", inputs, []*token.RecipientTransferShare{{Recipient: bob, Quantity: 150}}, rng)
+					Expect(err).NotTo(HaveOccurred())
+					tx.GetConfidentialAction().GetConfidentialTransfer().RedeemedQuantity = 10
+					err = verifier.ProcessTx("1", fakePublicInfo, tx, memoryLedger)
+					Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "transfer transaction '1' redeems tokens"}))
+				})
+			})
+		})
+
+		Describe("Redeem", func() {
+			It("redeems the tokens and records the redeemed quantity", func() {
+				tx, openings, err := confidential.NewRedeem(params, "TOK1", inputs, 40, alice, rng)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(openings).To(HaveLen(1))
+				Expect(openings[0].Quantity).To(Equal(uint64(110)))
+
+				err = verifier.ProcessTx("1", fakePublicInfo, tx, memoryLedger)
+				Expect(err).NotTo(HaveOccurred())
+
+				redeemedBytes, err := memoryLedger.GetState("_fabtoken", "\x00confidentialTokenRedeem\x001\x000\x00")
+				Expect(err).NotTo(HaveOccurred())
+				redeemed := &token.PlainOutput{}
+				err = proto.Unmarshal(redeemedBytes, redeemed)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(proto.Equal(redeemed, &token.PlainOutput{Type: "TOK1", Quantity: 40})).To(BeTrue())
+			})
+
+			It("redeems all the tokens", func() {
+				tx, openings, err := confidential.NewRedeem(params, "TOK1", inputs, 150, nil, rng)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(openings).To(BeEmpty())
+				err = verifier.ProcessTx("1", fakePublicInfo, tx, memoryLedger)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			Context("when the remaining tokens go to someone else", func() {
+				It("returns an error", func() {
+					tx, _, err := confidential.NewRedeem(params, "TOK1", inputs, 40, bob, rng)
+					Expect(err).NotTo(HaveOccurred())
+					err = verifier.ProcessTx("1", fakePublicInfo, tx, memoryLedger)
+					Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "wrong owner for remaining tokens, should be the owner of an input"}))
+				})
+			})
+
+			Context("when the redeemed quantity is changed", func() {
+				It("returns an error", func() {
+					tx, _, err := confidential.NewRedeem(params, "TOK1", inputs, 40, alice, rng)
+					Expect(err).NotTo(HaveOccurred())
+					tx.GetConfidentialAction().GetConfidentialRedeem().RedeemedQuantity = 140
+					err = verifier.ProcessTx("1", fakePublicInfo, tx, memoryLedger)
+					Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "token sum mismatch in inputs and outputs for transaction ID 1: balance proof is invalid"}))
+				})
+			})
+		})
+	})
+
+	Describe("Pseudonym owners", func() {
+		var (
+			ipk     *idemix.IssuerPublicKey
+			sk      *FP256BN.BIG
+			randNym *FP256BN.BIG
+			input   *confidential.Input
+		)
+
+		BeforeEach(func() {
+			key, err := idemix.NewIssuerKey([]string{"OU", "Role"}, rng)
+			Expect(err).NotTo(HaveOccurred())
+			ipk = key.Ipk
+			sk = idemix.RandModOrder(rng)
+			var nym *FP256BN.ECP
+			nym, randNym = idemix.MakeNym(sk, ipk, rng)
+			nymOwner, err := confidential.NewNymOwner(nym, ipk)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nymOwner.Type).To(Equal(token.TokenOwner_IDEMIX_NYM))
+
+			importTransaction.GetConfidentialAction().GetConfidentialImport().Outputs[0].Owner = nymOwner
+			err = verifier.ProcessTx("0", fakePublicInfo, importTransaction, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeTokenOwnerValidator.ValidateCallCount()).To(Equal(1))
+
+			input = &confidential.Input{Id: &token.TokenId{TxId: "0", Index: 0}, Opening: importOpenings[0]}
+			fakePublicInfo.PublicReturns([]byte("anyone"))
+		})
+
+		It("lets whoever knows the secrets of the pseudonym spend its tokens", func() {
+			tx, _, err := confidential.NewTransfer(params, "TOK1", []*confidential.Input{input}, []*token.RecipientTransferShare{{Recipient: bob, Quantity: 100}}, rng)
+			Expect(err).NotTo(HaveOccurred())
+			err = confidential.AddNymProof(tx, 0, sk, randNym, ipk, rng)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = verifier.ProcessTx("1", fakePublicInfo, tx, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the proof is missing", func() {
+			It("returns an error", func() {
+				tx, _, err := confidential.NewTransfer(params, "TOK1", []*confidential.Input{input}, []*token.RecipientTransferShare{{Recipient: bob, Quantity: 100}}, rng)
+				Expect(err).NotTo(HaveOccurred())
+				err = verifier.ProcessTx("1", fakePublicInfo, tx, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "missing pseudonym proof for transfer input with ID \x00confidentialTokenOutput\x000\x000\x00"}))
+			})
+		})
+
+		Context("when the proof is made with another secret key", func() {
+			It("returns an error", func() {
+				tx, _, err := confidential.NewTransfer(params, "TOK1", []*confidential.Input{input}, []*token.RecipientTransferShare{{Recipient: bob, Quantity: 100}}, rng)
+				Expect(err).NotTo(HaveOccurred())
+				err = confidential.AddNymProof(tx, 0, idemix.RandModOrder(rng), randNym, ipk, rng)
+				Expect(err).NotTo(HaveOccurred())
+				err = verifier.ProcessTx("1", fakePublicInfo, tx, memoryLedger)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("invalid pseudonym proof for transfer input"))
+			})
+		})
+
+		Context("when the transfer is changed after the proof", func() {
+			It("returns an error", func() {
+				tx, _, err := confidential.NewTransfer(params, "TOK1", []*confidential.Input{input}, []*token.RecipientTransferShare{{Recipient: bob, Quantity: 100}}, rng)
+				Expect(err).NotTo(HaveOccurred())
+				err = confidential.AddNymProof(tx, 0, sk, randNym, ipk, rng)
+				Expect(err).NotTo(HaveOccurred())
+				tx.GetConfidentialAction().GetConfidentialTransfer().Outputs[0].Owner = alice
+				err = verifier.ProcessTx("1", fakePublicInfo, tx, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "invalid pseudonym proof for transfer input with ID \x00confidentialTokenOutput\x000\x000\x00: pseudonym signature invalid: zero-knowledge proof is invalid"}))
+			})
+		})
+
+		Context("when the issuer public key is not the one of the pseudonym", func() {
+			It("returns an error", func() {
+				tx, _, err := confidential.NewTransfer(params, "TOK1", []*confidential.Input{input}, []*token.RecipientTransferShare{{Recipient: bob, Quantity: 100}}, rng)
+				Expect(err).NotTo(HaveOccurred())
+				otherKey, err := idemix.NewIssuerKey([]string{"OU", "Role"}, rng)
+				Expect(err).NotTo(HaveOccurred())
+				err = confidential.AddNymProof(tx, 0, sk, randNym, otherKey.Ipk, rng)
+				Expect(err).NotTo(HaveOccurred())
+				err = verifier.ProcessTx("1", fakePublicInfo, tx, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "invalid pseudonym proof for transfer input with ID \x00confidentialTokenOutput\x000\x000\x00: issuer public key does not match the pseudonym owner"}))
+			})
+		})
+	})
+})
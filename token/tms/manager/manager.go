@@ -7,8 +7,11 @@ SPDX-License-Identifier: Apache-2.0
 package manager
 
 import (
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/msp/mgmt"
+	"github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/token/identity"
+	"github.com/hyperledger/fabric/token/tms/confidential"
 	"github.com/hyperledger/fabric/token/tms/plain"
 	"github.com/hyperledger/fabric/token/transaction"
 	"github.com/pkg/errors"
@@ -29,9 +32,64 @@ func (*FabricIdentityDeserializerManager) Deserializer(channel string) (identity
 	return id, nil
 }
 
+//go:generate counterfeiter -o mock/capability_checker.go -fake-name CapabilityChecker . CapabilityChecker
+
+// CapabilityChecker is used to check whether the tokens of a channel are managed by the confidential TMS.
+type CapabilityChecker interface {
+	ConfidentialFabToken(channel string) (bool, error)
+}
+
+// ChannelConfigCapabilityChecker implements CapabilityChecker
+// by reading the application capabilities of the channel config
+type ChannelConfigCapabilityChecker struct {
+	GetChannelConfig func(channel string) channelconfig.Resources
+}
+
+func (c *ChannelConfigCapabilityChecker) ConfidentialFabToken(channel string) (bool, error) {
+	resources := c.GetChannelConfig(channel)
+	if resources == nil {
+		return false, errors.Errorf("no channel config found for channel %s", channel)
+	}
+	ac, ok := resources.ApplicationConfig()
+	if !ok {
+		return false, errors.Errorf("no application config found for channel %s", channel)
+	}
+	return ac.Capabilities().ConfidentialFabToken(), nil
+}
+
+//go:generate counterfeiter -o mock/token_types_provider.go -fake-name TokenTypesProvider . TokenTypesProvider
+
+// TokenTypesProvider is used to get the token types defined for a channel.
+type TokenTypesProvider interface {
+	TokenTypes(channel string) (map[string]*peer.TokenType, error)
+}
+
+// ChannelConfigTokenTypesProvider implements TokenTypesProvider
+// by reading the token types of the application config of the channel
+type ChannelConfigTokenTypesProvider struct {
+	GetChannelConfig func(channel string) channelconfig.Resources
+}
+
+func (c *ChannelConfigTokenTypesProvider) TokenTypes(channel string) (map[string]*peer.TokenType, error) {
+	resources := c.GetChannelConfig(channel)
+	if resources == nil {
+		return nil, errors.Errorf("no channel config found for channel %s", channel)
+	}
+	ac, ok := resources.ApplicationConfig()
+	if !ok {
+		return nil, errors.Errorf("no application config found for channel %s", channel)
+	}
+	return ac.TokenTypes(), nil
+}
+
 // Manager is used to access TMS components.
 type Manager struct {
 	IdentityDeserializerManager identity.DeserializerManager
+	// CapabilityChecker selects the TMS of a channel, which is the plain TMS if it is nil
+	CapabilityChecker CapabilityChecker
+	// TokenTypesProvider provides the token types of a channel, whose issuance is
+	// then restricted to their issuers and capped by their maximum supply
+	TokenTypesProvider TokenTypesProvider
 }
 
 // GetTxProcessor returns a TMSTxProcessor that is used to process token transactions.
@@ -41,8 +99,40 @@ func (m *Manager) GetTxProcessor(channel string) (transaction.TMSTxProcessor, er
 		return nil, errors.Wrapf(err, "failed getting identity deserialiser manager for channel '%s'", channel)
 	}
 
-	return &plain.Verifier{
+	if m.CapabilityChecker != nil {
+		isConfidential, err := m.CapabilityChecker.ConfidentialFabToken(channel)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed checking capabilities of channel '%s'", channel)
+		}
+		if isConfidential {
+			// The channel config rejects token types on confidential channels, since the
+			// maximum supply of a type cannot be enforced on hidden quantities
+			parameters, err := confidential.NewParameters(confidential.DefaultBitLength)
+			if err != nil {
+				return nil, err
+			}
+			return &confidential.Verifier{
+				Parameters:          parameters,
+				IssuingValidator:    &AllIssuingValidator{Deserializer: identityDeserializerManager},
+				TokenOwnerValidator: &FabricTokenOwnerValidator{Deserializer: identityDeserializerManager},
+			}, nil
+		}
+	}
+
+	verifier := &plain.Verifier{
 		IssuingValidator:    &AllIssuingValidator{Deserializer: identityDeserializerManager},
 		TokenOwnerValidator: &FabricTokenOwnerValidator{Deserializer: identityDeserializerManager},
-	}, nil
+		Deserializer:        identityDeserializerManager,
+	}
+	if m.TokenTypesProvider != nil {
+		tokenTypes, err := m.TokenTypesProvider.TokenTypes(channel)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed getting token types of channel '%s'", channel)
+		}
+		if len(tokenTypes) != 0 {
+			verifier.IssuingValidator = &TokenTypeIssuingValidator{Deserializer: identityDeserializerManager, TokenTypes: tokenTypes}
+			verifier.TokenTypes = tokenTypes
+		}
+	}
+	return verifier, nil
 }
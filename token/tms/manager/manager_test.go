@@ -7,8 +7,11 @@ SPDX-License-Identifier: Apache-2.0
 package manager_test
 
 import (
+	"github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/token/identity/mock"
+	"github.com/hyperledger/fabric/token/tms/confidential"
 	"github.com/hyperledger/fabric/token/tms/manager"
+	mockmanager "github.com/hyperledger/fabric/token/tms/manager/mock"
 	"github.com/hyperledger/fabric/token/tms/plain"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -56,10 +59,114 @@ var _ = Describe("Manager", func() {
 					&plain.Verifier{
 						IssuingValidator:    &manager.AllIssuingValidator{Deserializer: fakeIdentityDeserializer},
 						TokenOwnerValidator: &manager.FabricTokenOwnerValidator{Deserializer: fakeIdentityDeserializer},
+						Deserializer:        fakeIdentityDeserializer,
 					}),
 				)
 			})
 		})
+
+		Context("when the channel has the confidential capability", func() {
+			var fakeCapabilityChecker *mockmanager.CapabilityChecker
+
+			BeforeEach(func() {
+				fakeCapabilityChecker = &mockmanager.CapabilityChecker{}
+				fakeCapabilityChecker.ConfidentialFabTokenReturns(true, nil)
+				mgm.CapabilityChecker = fakeCapabilityChecker
+			})
+
+			It("returns a confidential Verifier", func() {
+				txProcessor, err := mgm.GetTxProcessor(channel)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeCapabilityChecker.ConfidentialFabTokenArgsForCall(0)).To(Equal(channel))
+				verifier, ok := txProcessor.(*confidential.Verifier)
+				Expect(ok).To(BeTrue())
+				Expect(verifier.Parameters.BitLength).To(Equal(confidential.DefaultBitLength))
+				Expect(verifier.IssuingValidator).To(Equal(&manager.AllIssuingValidator{Deserializer: fakeIdentityDeserializer}))
+				Expect(verifier.TokenOwnerValidator).To(Equal(&manager.FabricTokenOwnerValidator{Deserializer: fakeIdentityDeserializer}))
+			})
+
+			Context("when the capability is not enabled", func() {
+				BeforeEach(func() {
+					fakeCapabilityChecker.ConfidentialFabTokenReturns(false, nil)
+				})
+
+				It("returns a plain Verifier", func() {
+					txProcessor, err := mgm.GetTxProcessor(channel)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(txProcessor).To(BeAssignableToTypeOf(&plain.Verifier{}))
+				})
+			})
+
+			Context("when the capabilities cannot be checked", func() {
+				BeforeEach(func() {
+					fakeCapabilityChecker.ConfidentialFabTokenReturns(false, errors.New("no-config"))
+				})
+
+				It("returns an error", func() {
+					_, err := mgm.GetTxProcessor(channel)
+					Expect(err).To(MatchError("failed checking capabilities of channel 'ch0': no-config"))
+				})
+			})
+		})
+
+		Context("when the channel defines token types", func() {
+			var (
+				fakeTokenTypesProvider *mockmanager.TokenTypesProvider
+				tokenTypes             map[string]*peer.TokenType
+			)
+
+			BeforeEach(func() {
+				tokenTypes = map[string]*peer.TokenType{
+					"USD": {MaxSupply: 1000, Decimals: 2, Issuer: "Org1MSP"},
+				}
+				fakeTokenTypesProvider = &mockmanager.TokenTypesProvider{}
+				fakeTokenTypesProvider.TokenTypesReturns(tokenTypes, nil)
+				mgm.TokenTypesProvider = fakeTokenTypesProvider
+			})
+
+			It("returns a plain Verifier restricting issuance to the token types", func() {
+				txProcessor, err := mgm.GetTxProcessor(channel)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeTokenTypesProvider.TokenTypesArgsForCall(0)).To(Equal(channel))
+				Expect(txProcessor).To(Equal(
+					&plain.Verifier{
+						IssuingValidator:    &manager.TokenTypeIssuingValidator{Deserializer: fakeIdentityDeserializer, TokenTypes: tokenTypes},
+						TokenOwnerValidator: &manager.FabricTokenOwnerValidator{Deserializer: fakeIdentityDeserializer},
+						Deserializer:        fakeIdentityDeserializer,
+						TokenTypes:          tokenTypes,
+					}),
+				)
+			})
+
+			Context("when no token types are defined", func() {
+				BeforeEach(func() {
+					fakeTokenTypesProvider.TokenTypesReturns(nil, nil)
+				})
+
+				It("returns a plain Verifier allowing all members to issue", func() {
+					txProcessor, err := mgm.GetTxProcessor(channel)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(txProcessor).To(Equal(
+						&plain.Verifier{
+							IssuingValidator:    &manager.AllIssuingValidator{Deserializer: fakeIdentityDeserializer},
+							TokenOwnerValidator: &manager.FabricTokenOwnerValidator{Deserializer: fakeIdentityDeserializer},
+							Deserializer:        fakeIdentityDeserializer,
+						}),
+					)
+				})
+			})
+
+			Context("when the token types cannot be read", func() {
+				BeforeEach(func() {
+					fakeTokenTypesProvider.TokenTypesReturns(nil, errors.New("no-config"))
+				})
+
+				It("returns an error", func() {
+					_, err := mgm.GetTxProcessor(channel)
+					Expect(err).To(MatchError("failed getting token types of channel 'ch0': no-config"))
+				})
+			})
+		})
 	})
 })
 
@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package manager
 
 import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/token"
 	"github.com/hyperledger/fabric/token/identity"
 	"github.com/pkg/errors"
@@ -33,6 +35,36 @@ func (p *AllIssuingValidator) Validate(creator identity.PublicInfo, tokenType st
 	return nil
 }
 
+// TokenTypeIssuingValidator allows the members of the issuer MSP of a token type,
+// as defined in the channel config, to issue new tokens of that type.
+type TokenTypeIssuingValidator struct {
+	Deserializer identity.Deserializer
+	TokenTypes   map[string]*peer.TokenType
+}
+
+// Validate returns no error if the passed creator can issue tokens of the passed type, an error otherwise.
+func (p *TokenTypeIssuingValidator) Validate(creator identity.PublicInfo, tokenType string) error {
+	definition, ok := p.TokenTypes[tokenType]
+	if !ok {
+		return errors.Errorf("token type '%s' is not defined", tokenType)
+	}
+
+	identity, err := p.Deserializer.DeserializeIdentity(creator.Public())
+	if err != nil {
+		return errors.Wrapf(err, "identity [0x%x] cannot be deserialised", creator.Public())
+	}
+
+	if err := identity.Validate(); err != nil {
+		return errors.Wrapf(err, "identity [0x%x] cannot be validated", creator.Public())
+	}
+
+	if identity.GetMSPIdentifier() != definition.Issuer {
+		return errors.Errorf("identity [0x%x] of MSP '%s' is not an issuer of token type '%s'", creator.Public(), identity.GetMSPIdentifier(), tokenType)
+	}
+
+	return nil
+}
+
 // FabricTokenOwnerValidator checks that an owner is valid identity in a given channel
 type FabricTokenOwnerValidator struct {
 	Deserializer identity.Deserializer
@@ -55,9 +87,51 @@ func (v *FabricTokenOwnerValidator) Validate(owner *token.TokenOwner) error {
 		if err := id.Validate(); err != nil {
 			return errors.Wrapf(err, "identity [0x%x] cannot be validated", owner)
 		}
+	case token.TokenOwner_MULTI_SIG:
+		return v.validateMultiSigOwner(owner.Raw)
+	case token.TokenOwner_CHAINCODE_ID:
+		chaincodeOwner := &token.ChaincodeOwner{}
+		if err := proto.Unmarshal(owner.Raw, chaincodeOwner); err != nil {
+			return errors.Wrap(err, "chaincode owner cannot be unmarshaled")
+		}
+		if chaincodeOwner.ChaincodeName == "" {
+			return errors.New("chaincode owner has no chaincode name")
+		}
 	default:
 		return errors.Errorf("identity's type '%s' not recognized", owner.Type)
 	}
 
 	return nil
 }
+
+// validateMultiSigOwner checks that the threshold of a multi-signature owner can be
+// reached by its identities, and that the identities are distinct valid identities
+func (v *FabricTokenOwnerValidator) validateMultiSigOwner(raw []byte) error {
+	multiSigOwner := &token.MultiSigOwner{}
+	if err := proto.Unmarshal(raw, multiSigOwner); err != nil {
+		return errors.Wrap(err, "multi-signature owner cannot be unmarshaled")
+	}
+
+	identities := multiSigOwner.GetIdentities()
+	if multiSigOwner.Threshold == 0 || int(multiSigOwner.Threshold) > len(identities) {
+		return errors.Errorf("multi-signature owner threshold %d is not between 1 and the number of identities (%d)", multiSigOwner.Threshold, len(identities))
+	}
+
+	seen := map[string]bool{}
+	for _, raw := range identities {
+		if seen[string(raw)] {
+			return errors.Errorf("identity [0x%x] appears more than once in multi-signature owner", raw)
+		}
+		seen[string(raw)] = true
+
+		id, err := v.Deserializer.DeserializeIdentity(raw)
+		if err != nil {
+			return errors.Wrapf(err, "identity [0x%x] cannot be deserialised", raw)
+		}
+		if err := id.Validate(); err != nil {
+			return errors.Wrapf(err, "identity [0x%x] cannot be validated", raw)
+		}
+	}
+
+	return nil
+}
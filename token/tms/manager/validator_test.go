@@ -7,6 +7,9 @@ SPDX-License-Identifier: Apache-2.0
 package manager_test
 
 import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/token"
 	mockid "github.com/hyperledger/fabric/token/identity/mock"
 	"github.com/hyperledger/fabric/token/tms/manager"
 	. "github.com/onsi/ginkgo"
@@ -77,3 +80,218 @@ var _ = Describe("AllIssuingValidator", func() {
 
 	})
 })
+
+var _ = Describe("TokenTypeIssuingValidator", func() {
+	var (
+		fakeCreatorInfo          *mockid.PublicInfo
+		fakeIdentityDeserializer *mockid.Deserializer
+		fakeIdentity             *mockid.Identity
+		policyValidator          *manager.TokenTypeIssuingValidator
+	)
+
+	BeforeEach(func() {
+		fakeCreatorInfo = &mockid.PublicInfo{}
+		fakeCreatorInfo.PublicReturns([]byte{1, 2, 3})
+		fakeIdentity = &mockid.Identity{}
+		fakeIdentity.GetMSPIdentifierReturns("Org1MSP")
+		fakeIdentityDeserializer = &mockid.Deserializer{}
+		fakeIdentityDeserializer.DeserializeIdentityReturns(fakeIdentity, nil)
+
+		policyValidator = &manager.TokenTypeIssuingValidator{
+			Deserializer: fakeIdentityDeserializer,
+			TokenTypes: map[string]*peer.TokenType{
+				"USD": {MaxSupply: 1000, Issuer: "Org1MSP"},
+			},
+		}
+	})
+
+	It("allows a member of the issuer MSP to issue the type", func() {
+		err := policyValidator.Validate(fakeCreatorInfo, "USD")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeIdentityDeserializer.DeserializeIdentityArgsForCall(0)).To(Equal([]byte{1, 2, 3}))
+		Expect(fakeIdentity.ValidateCallCount()).To(Equal(1))
+	})
+
+	Context("when the token type is not defined", func() {
+		It("returns an error", func() {
+			err := policyValidator.Validate(fakeCreatorInfo, "EUR")
+			Expect(err).To(MatchError("token type 'EUR' is not defined"))
+			Expect(fakeIdentityDeserializer.DeserializeIdentityCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the creator is not a member of the issuer MSP", func() {
+		BeforeEach(func() {
+			fakeIdentity.GetMSPIdentifierReturns("Org2MSP")
+		})
+
+		It("returns an error", func() {
+			err := policyValidator.Validate(fakeCreatorInfo, "USD")
+			Expect(err).To(MatchError("identity [0x010203] of MSP 'Org2MSP' is not an issuer of token type 'USD'"))
+		})
+	})
+
+	Context("when the creator cannot be deserialized", func() {
+		BeforeEach(func() {
+			fakeIdentityDeserializer.DeserializeIdentityReturns(nil, errors.New("no-way-man"))
+		})
+
+		It("returns an error", func() {
+			err := policyValidator.Validate(fakeCreatorInfo, "USD")
+			Expect(err).To(MatchError("identity [0x010203] cannot be deserialised: no-way-man"))
+		})
+	})
+
+	Context("when the creator cannot be validated", func() {
+		BeforeEach(func() {
+			fakeIdentity.ValidateReturns(errors.New("no-way-man"))
+		})
+
+		It("returns an error", func() {
+			err := policyValidator.Validate(fakeCreatorInfo, "USD")
+			Expect(err).To(MatchError("identity [0x010203] cannot be validated: no-way-man"))
+		})
+	})
+})
+
+var _ = Describe("FabricTokenOwnerValidator", func() {
+	var (
+		fakeIdentityDeserializer *mockid.Deserializer
+		fakeIdentity             *mockid.Identity
+		validator                *manager.FabricTokenOwnerValidator
+	)
+
+	BeforeEach(func() {
+		fakeIdentityDeserializer = &mockid.Deserializer{}
+		fakeIdentity = &mockid.Identity{}
+		fakeIdentityDeserializer.DeserializeIdentityReturns(fakeIdentity, nil)
+
+		validator = &manager.FabricTokenOwnerValidator{
+			Deserializer: fakeIdentityDeserializer,
+		}
+	})
+
+	It("validates the identity of an MSP owner", func() {
+		err := validator.Validate(&token.TokenOwner{Type: token.TokenOwner_MSP_IDENTIFIER, Raw: []byte("alice")})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeIdentityDeserializer.DeserializeIdentityCallCount()).To(Equal(1))
+		Expect(fakeIdentityDeserializer.DeserializeIdentityArgsForCall(0)).To(Equal([]byte("alice")))
+		Expect(fakeIdentity.ValidateCallCount()).To(Equal(1))
+	})
+
+	Context("when the owner is nil", func() {
+		It("returns an error", func() {
+			err := validator.Validate(nil)
+			Expect(err).To(MatchError("identity cannot be nil"))
+		})
+	})
+
+	Describe("multi-signature owners", func() {
+		var multiSigOwner *token.MultiSigOwner
+
+		BeforeEach(func() {
+			multiSigOwner = &token.MultiSigOwner{
+				Threshold:  2,
+				Identities: [][]byte{[]byte("alice"), []byte("bob"), []byte("charlie")},
+			}
+		})
+
+		validate := func() error {
+			raw, err := proto.Marshal(multiSigOwner)
+			Expect(err).NotTo(HaveOccurred())
+			return validator.Validate(&token.TokenOwner{Type: token.TokenOwner_MULTI_SIG, Raw: raw})
+		}
+
+		It("validates each identity", func() {
+			err := validate()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeIdentityDeserializer.DeserializeIdentityCallCount()).To(Equal(3))
+			Expect(fakeIdentityDeserializer.DeserializeIdentityArgsForCall(2)).To(Equal([]byte("charlie")))
+			Expect(fakeIdentity.ValidateCallCount()).To(Equal(3))
+		})
+
+		Context("when the raw is not a multi-signature owner", func() {
+			It("returns an error", func() {
+				err := validator.Validate(&token.TokenOwner{Type: token.TokenOwner_MULTI_SIG, Raw: []byte("garbage")})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("multi-signature owner cannot be unmarshaled"))
+			})
+		})
+
+		Context("when the threshold is 0", func() {
+			BeforeEach(func() {
+				multiSigOwner.Threshold = 0
+			})
+
+			It("returns an error", func() {
+				err := validate()
+				Expect(err).To(MatchError("multi-signature owner threshold 0 is not between 1 and the number of identities (3)"))
+			})
+		})
+
+		Context("when the threshold exceeds the number of identities", func() {
+			BeforeEach(func() {
+				multiSigOwner.Threshold = 4
+			})
+
+			It("returns an error", func() {
+				err := validate()
+				Expect(err).To(MatchError("multi-signature owner threshold 4 is not between 1 and the number of identities (3)"))
+			})
+		})
+
+		Context("when an identity appears twice", func() {
+			BeforeEach(func() {
+				multiSigOwner.Identities = append(multiSigOwner.Identities, []byte("bob"))
+			})
+
+			It("returns an error", func() {
+				err := validate()
+				Expect(err).To(MatchError("identity [0x626f62] appears more than once in multi-signature owner"))
+			})
+		})
+
+		Context("when an identity cannot be validated", func() {
+			BeforeEach(func() {
+				fakeIdentity.ValidateReturns(errors.New("Validate, no-way-man"))
+			})
+
+			It("returns an error", func() {
+				err := validate()
+				Expect(err).To(MatchError("identity [0x616c696365] cannot be validated: Validate, no-way-man"))
+			})
+		})
+	})
+
+	Describe("chaincode owners", func() {
+		It("accepts a chaincode name", func() {
+			raw, err := proto.Marshal(&token.ChaincodeOwner{ChaincodeName: "escrow"})
+			Expect(err).NotTo(HaveOccurred())
+			err = validator.Validate(&token.TokenOwner{Type: token.TokenOwner_CHAINCODE_ID, Raw: raw})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeIdentityDeserializer.DeserializeIdentityCallCount()).To(Equal(0))
+		})
+
+		Context("when the chaincode name is missing", func() {
+			It("returns an error", func() {
+				err := validator.Validate(&token.TokenOwner{Type: token.TokenOwner_CHAINCODE_ID})
+				Expect(err).To(MatchError("chaincode owner has no chaincode name"))
+			})
+		})
+
+		Context("when the raw is not a chaincode owner", func() {
+			It("returns an error", func() {
+				err := validator.Validate(&token.TokenOwner{Type: token.TokenOwner_CHAINCODE_ID, Raw: []byte("garbage")})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("chaincode owner cannot be unmarshaled"))
+			})
+		})
+	})
+
+	Context("when the owner type is unknown", func() {
+		It("returns an error", func() {
+			err := validator.Validate(&token.TokenOwner{Type: 42, Raw: []byte("alice")})
+			Expect(err).To(MatchError("identity's type '42' not recognized"))
+		})
+	})
+})
@@ -0,0 +1,84 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/ledger"
+	"github.com/pkg/errors"
+)
+
+// An Auditor reports the supply of the token types of a channel.
+// The supply of a type is tracked by the Verifier when the channel config defines token types.
+type Auditor struct {
+	Ledger ledger.LedgerReader
+}
+
+// RequestSupply returns the quantities of the requested token types that have been issued and redeemed.
+// If the request has no types, it returns the supply of all the types whose supply is tracked.
+func (a *Auditor) RequestSupply(request *token.SupplyRequest) (*token.TokenSupplies, error) {
+	if len(request.GetTypes()) == 0 {
+		return a.listSupplies()
+	}
+
+	supplies := make([]*token.TokenSupply, 0, len(request.GetTypes()))
+	for _, tokenType := range request.GetTypes() {
+		supply, err := getSupply(tokenType, a.Ledger)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed getting supply of type '%s'", tokenType)
+		}
+		supplies = append(supplies, supply)
+	}
+	return &token.TokenSupplies{Supplies: supplies}, nil
+}
+
+// listSupplies returns the supply records of all the token types
+func (a *Auditor) listSupplies() (*token.TokenSupplies, error) {
+	startKey, err := createPrefix(tokenSupply)
+	if err != nil {
+		return nil, err
+	}
+	endKey := startKey + string(maxUnicodeRuneValue)
+
+	iterator, err := a.Ledger.GetStateRangeScanIterator(tokenNameSpace, startKey, endKey)
+	if err != nil {
+		return nil, err
+	}
+	defer iterator.Close()
+
+	supplies := make([]*token.TokenSupply, 0)
+	for {
+		next, err := iterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		if next == nil {
+			// nil response from iterator indicates end of query results
+			return &token.TokenSupplies{Supplies: supplies}, nil
+		}
+
+		result, ok := next.(*queryresult.KV)
+		if !ok {
+			return nil, errors.New("failed to retrieve token supplies: casting error")
+		}
+		supply := &token.TokenSupply{}
+		err = proto.Unmarshal(result.Value, supply)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal supply with key '%s'", result.Key)
+		}
+		supplies = append(supplies, supply)
+	}
+}
+
+// Done releases any resources held by this auditor
+func (a *Auditor) Done() {
+	if a.Ledger != nil {
+		a.Ledger.Done()
+	}
+}
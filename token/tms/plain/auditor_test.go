@@ -0,0 +1,143 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain_test
+
+import (
+	"errors"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/ledger/mock"
+	"github.com/hyperledger/fabric/token/tms/plain"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Auditor", func() {
+	var (
+		fakeLedger   *mock.LedgerWriter
+		fakeIterator *mock.ResultsIterator
+		supplies     []*token.TokenSupply
+		auditor      *plain.Auditor
+	)
+
+	BeforeEach(func() {
+		supplies = []*token.TokenSupply{
+			{Type: "TOK1", Issued: 100, Redeemed: 10},
+			{Type: "TOK2", Issued: 200},
+		}
+		fakeIterator = &mock.ResultsIterator{}
+		for i, supply := range supplies {
+			fakeIterator.NextReturnsOnCall(i, &queryresult.KV{
+				Key:   "\x00tokenSupply\x00" + supply.Type + "\x00",
+				Value: marshal(supply),
+			}, nil)
+		}
+		fakeLedger = &mock.LedgerWriter{}
+		fakeLedger.GetStateRangeScanIteratorReturns(fakeIterator, nil)
+		fakeLedger.GetStateStub = func(namespace, key string) ([]byte, error) {
+			if key == "\x00tokenSupply\x00TOK1\x00" {
+				return marshal(supplies[0]), nil
+			}
+			return nil, nil
+		}
+		auditor = &plain.Auditor{Ledger: fakeLedger}
+	})
+
+	Describe("RequestSupply", func() {
+		It("returns the supply of the requested types", func() {
+			tokenSupplies, err := auditor.RequestSupply(&token.SupplyRequest{Types: []string{"TOK1", "TOK3"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Equal(tokenSupplies, &token.TokenSupplies{
+				Supplies: []*token.TokenSupply{
+					{Type: "TOK1", Issued: 100, Redeemed: 10},
+					{Type: "TOK3"},
+				},
+			})).To(BeTrue())
+
+			Expect(fakeLedger.GetStateCallCount()).To(Equal(2))
+			namespace, key := fakeLedger.GetStateArgsForCall(0)
+			Expect(namespace).To(Equal("_fabtoken"))
+			Expect(key).To(Equal("\x00tokenSupply\x00TOK1\x00"))
+		})
+
+		Context("when no types are requested", func() {
+			It("returns the supply of all the types", func() {
+				tokenSupplies, err := auditor.RequestSupply(&token.SupplyRequest{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(proto.Equal(tokenSupplies, &token.TokenSupplies{Supplies: supplies})).To(BeTrue())
+
+				Expect(fakeLedger.GetStateRangeScanIteratorCallCount()).To(Equal(1))
+				namespace, startKey, endKey := fakeLedger.GetStateRangeScanIteratorArgsForCall(0)
+				Expect(namespace).To(Equal("_fabtoken"))
+				Expect(startKey).To(Equal("\x00tokenSupply\x00"))
+				Expect(endKey).To(Equal("\x00tokenSupply\x00\U0010FFFF"))
+				Expect(fakeIterator.CloseCallCount()).To(Equal(1))
+			})
+
+			Context("when the range scan fails", func() {
+				BeforeEach(func() {
+					fakeLedger.GetStateRangeScanIteratorReturns(nil, errors.New("no-scan"))
+				})
+
+				It("returns an error", func() {
+					_, err := auditor.RequestSupply(&token.SupplyRequest{})
+					Expect(err).To(MatchError("no-scan"))
+				})
+			})
+
+			Context("when the iterator fails", func() {
+				BeforeEach(func() {
+					fakeIterator.NextReturnsOnCall(1, nil, errors.New("no-next"))
+				})
+
+				It("returns an error", func() {
+					_, err := auditor.RequestSupply(&token.SupplyRequest{})
+					Expect(err).To(MatchError("no-next"))
+				})
+			})
+
+			Context("when a supply record cannot be unmarshaled", func() {
+				BeforeEach(func() {
+					fakeIterator.NextReturnsOnCall(1, &queryresult.KV{Key: "bad-key", Value: []byte("garbage")}, nil)
+				})
+
+				It("returns an error", func() {
+					_, err := auditor.RequestSupply(&token.SupplyRequest{})
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("failed to unmarshal supply with key 'bad-key'"))
+				})
+			})
+		})
+
+		Context("when the ledger read fails", func() {
+			BeforeEach(func() {
+				fakeLedger.GetStateStub = nil
+				fakeLedger.GetStateReturns(nil, errors.New("no-read"))
+			})
+
+			It("returns an error", func() {
+				_, err := auditor.RequestSupply(&token.SupplyRequest{Types: []string{"TOK1"}})
+				Expect(err).To(MatchError("failed getting supply of type 'TOK1': no-read"))
+			})
+		})
+	})
+
+	Describe("Done", func() {
+		It("releases the ledger", func() {
+			auditor.Done()
+			Expect(fakeLedger.DoneCallCount()).To(Equal(1))
+		})
+	})
+})
+
+func marshal(m proto.Message) []byte {
+	b, err := proto.Marshal(m)
+	Expect(err).NotTo(HaveOccurred())
+	return b
+}
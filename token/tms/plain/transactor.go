@@ -40,7 +40,7 @@ func (t *Transactor) RequestTransfer(request *token.TransferRequest) (*token.Tok
 		return nil, errors.New("no shares in transfer request")
 	}
 
-	tokenType, _, err := t.getInputsFromTokenIds(request.GetTokenIds())
+	tokenType, _, _, err := t.getInputsFromTokenIds(request.GetTokenIds())
 	if err != nil {
 		return nil, err
 	}
@@ -83,7 +83,7 @@ func (t *Transactor) RequestRedeem(request *token.RedeemRequest) (*token.TokenTr
 		return nil, errors.Errorf("quantity to redeem [%d] must be greater than 0", request.GetQuantityToRedeem())
 	}
 
-	tokenType, quantitySum, err := t.getInputsFromTokenIds(request.GetTokenIds())
+	tokenType, quantitySum, inputsOwner, err := t.getInputsFromTokenIds(request.GetTokenIds())
 	if err != nil {
 		return nil, err
 	}
@@ -101,9 +101,13 @@ func (t *Transactor) RequestRedeem(request *token.RedeemRequest) (*token.TokenTr
 
 	// add another output if there is remaining quantity after redemption
 	if quantitySum > request.QuantityToRedeem {
+		// remaining tokens go back to the joint or chaincode owner of the inputs, if any
+		owner := &token.TokenOwner{Type: token.TokenOwner_MSP_IDENTIFIER, Raw: t.PublicCredential} // PublicCredential is serialized identity for the creator
+		if inputsOwner != nil && inputsOwner.Type != token.TokenOwner_MSP_IDENTIFIER {
+			owner = inputsOwner
+		}
 		outputs = append(outputs, &token.PlainOutput{
-			// note that tokenOwner type may change in the future depending on creator type
-			Owner:    &token.TokenOwner{Type: token.TokenOwner_MSP_IDENTIFIER, Raw: t.PublicCredential}, // PublicCredential is serialized identity for the creator
+			Owner:    owner,
 			Type:     tokenType,
 			Quantity: quantitySum - request.QuantityToRedeem,
 		})
@@ -126,53 +130,166 @@ func (t *Transactor) RequestRedeem(request *token.RedeemRequest) (*token.TokenTr
 	return transaction, nil
 }
 
-// read token data from ledger for each token ids and calculate the sum of quantities for all token ids
-// Returns TokenIds, token type, sum of token quantities, and error in the case of failure
-func (t *Transactor) getInputsFromTokenIds(tokenIds []*token.TokenId) (string, uint64, error) {
-	var tokenType = ""
-	var quantitySum uint64 = 0
-	for _, tokenId := range tokenIds {
-		// create the composite key from tokenId
-		inKey, err := createCompositeKey(tokenOutput, []string{tokenId.TxId, strconv.Itoa(int(tokenId.Index))})
-		if err != nil {
-			verifierLogger.Errorf("error getting creating input key: %s", err)
-			return "", 0, err
+// RequestExchange creates a TokenTransaction of type exchange request, whose inputs are
+// contributed by the owners in the request, who all sign the transaction
+func (t *Transactor) RequestExchange(request *token.ExchangeRequest) (*token.TokenTransaction, error) {
+	if len(request.GetContributions()) == 0 {
+		return nil, errors.New("no contributions in exchange request")
+	}
+
+	var inputs []*token.TokenId
+	var outputs []*token.PlainOutput
+	inputSums := make(map[string]uint64)
+	outputSums := make(map[string]uint64)
+	for i, contribution := range request.GetContributions() {
+		if len(contribution.GetOwner()) == 0 {
+			return nil, errors.Errorf("no owner in contribution %d of exchange request", i)
+		}
+		if len(contribution.GetTokenIds()) == 0 {
+			return nil, errors.Errorf("no token IDs in contribution %d of exchange request", i)
 		}
-		verifierLogger.Debugf("transferring token with ID: '%s'", inKey)
+		for _, tokenId := range contribution.GetTokenIds() {
+			input, err := t.getInput(tokenId)
+			if err != nil {
+				return nil, err
+			}
+			if !isOwner(contribution.GetOwner(), input.Owner) && input.Owner.GetType() != token.TokenOwner_CHAINCODE_ID {
+				return nil, errors.Errorf("the owner of contribution %d does not own inputs", i)
+			}
+			sum := inputSums[input.Type] + input.Quantity
+			if sum < input.Quantity {
+				return nil, errors.Errorf("input quantity of type '%s' overflows in exchange request", input.Type)
+			}
+			inputSums[input.Type] = sum
+			inputs = append(inputs, tokenId)
+		}
+		for _, share := range contribution.GetShares() {
+			err := t.TokenOwnerValidator.Validate(share.Recipient)
+			if err != nil {
+				return nil, errors.Errorf("invalid recipient in exchange request '%s'", err)
+			}
+			sum := outputSums[share.Type] + share.Quantity
+			if sum < share.Quantity {
+				return nil, errors.Errorf("output quantity of type '%s' overflows in exchange request", share.Type)
+			}
+			outputSums[share.Type] = sum
+			outputs = append(outputs, &token.PlainOutput{
+				Owner:    share.Recipient,
+				Type:     share.Type,
+				Quantity: share.Quantity,
+			})
+		}
+	}
 
-		// make sure the output exists in the ledger
-		verifierLogger.Debugf("getting output '%s' to spend from ledger", inKey)
-		inBytes, err := t.Ledger.GetState(tokenNameSpace, inKey)
-		if err != nil {
-			verifierLogger.Errorf("error getting output '%s' to spend from ledger: %s", inKey, err)
-			return "", 0, err
+	// the quantity of each type must be the same in the inputs and the outputs
+	for tokenType, inputSum := range inputSums {
+		if outputSums[tokenType] != inputSum {
+			return nil, errors.Errorf("token sum mismatch in inputs and outputs of type '%s' (%d vs %d)", tokenType, outputSums[tokenType], inputSum)
 		}
-		if len(inBytes) == 0 {
-			return "", 0, errors.New(fmt.Sprintf("input '%s' does not exist", inKey))
+	}
+	for tokenType := range outputSums {
+		if _, ok := inputSums[tokenType]; !ok {
+			return nil, errors.Errorf("no inputs of type '%s' in exchange request", tokenType)
 		}
-		input := &token.PlainOutput{}
-		err = proto.Unmarshal(inBytes, input)
+	}
+
+	transaction := &token.TokenTransaction{
+		Action: &token.TokenTransaction_PlainAction{
+			PlainAction: &token.PlainTokenAction{
+				Data: &token.PlainTokenAction_PlainExchange{
+					PlainExchange: &token.PlainTransfer{
+						Inputs:  inputs,
+						Outputs: outputs,
+					},
+				},
+			},
+		},
+	}
+
+	return transaction, nil
+}
+
+// read token data from ledger for each token ids and calculate the sum of quantities for all token ids
+// Returns token type, sum of token quantities, owner of the inputs (nil if they have different owners), and error in the case of failure
+func (t *Transactor) getInputsFromTokenIds(tokenIds []*token.TokenId) (string, uint64, *token.TokenOwner, error) {
+	var tokenType = ""
+	var quantitySum uint64 = 0
+	var owner *token.TokenOwner
+	for i, tokenId := range tokenIds {
+		input, err := t.getInput(tokenId)
 		if err != nil {
-			return "", 0, errors.New(fmt.Sprintf("error unmarshaling input bytes: '%s'", err))
+			return "", 0, nil, err
 		}
 
-		// check the owner of the token
-		if !bytes.Equal(t.PublicCredential, input.Owner.Raw) {
-			return "", 0, errors.New(fmt.Sprintf("the requestor does not own inputs"))
+		// check the owner of the token - inputs owned by a chaincode are spent if the chaincode approves
+		if !isOwner(t.PublicCredential, input.Owner) && input.Owner.GetType() != token.TokenOwner_CHAINCODE_ID {
+			return "", 0, nil, errors.New(fmt.Sprintf("the requestor does not own inputs"))
+		}
+		if i == 0 {
+			owner = input.Owner
+		} else if !proto.Equal(owner, input.Owner) {
+			owner = nil
 		}
 
 		// check the token type - only one type allowed per transfer
 		if tokenType == "" {
 			tokenType = input.Type
 		} else if tokenType != input.Type {
-			return "", 0, errors.New(fmt.Sprintf("two or more token types specified in input: '%s', '%s'", tokenType, input.Type))
+			return "", 0, nil, errors.New(fmt.Sprintf("two or more token types specified in input: '%s', '%s'", tokenType, input.Type))
 		}
 
 		// sum up the quantity
 		quantitySum += input.Quantity
 	}
 
-	return tokenType, quantitySum, nil
+	return tokenType, quantitySum, owner, nil
+}
+
+// getInput reads from the ledger the output with the passed token ID
+func (t *Transactor) getInput(tokenId *token.TokenId) (*token.PlainOutput, error) {
+	// create the composite key from tokenId
+	inKey, err := createCompositeKey(tokenOutput, []string{tokenId.TxId, strconv.Itoa(int(tokenId.Index))})
+	if err != nil {
+		verifierLogger.Errorf("error getting creating input key: %s", err)
+		return nil, err
+	}
+	verifierLogger.Debugf("transferring token with ID: '%s'", inKey)
+
+	// make sure the output exists in the ledger
+	verifierLogger.Debugf("getting output '%s' to spend from ledger", inKey)
+	inBytes, err := t.Ledger.GetState(tokenNameSpace, inKey)
+	if err != nil {
+		verifierLogger.Errorf("error getting output '%s' to spend from ledger: %s", inKey, err)
+		return nil, err
+	}
+	if len(inBytes) == 0 {
+		return nil, errors.New(fmt.Sprintf("input '%s' does not exist", inKey))
+	}
+	input := &token.PlainOutput{}
+	err = proto.Unmarshal(inBytes, input)
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("error unmarshaling input bytes: '%s'", err))
+	}
+	return input, nil
+}
+
+// isOwner returns true if the passed credential is the owner, or one of the joint owners, of a token
+func isOwner(credential []byte, owner *token.TokenOwner) bool {
+	switch owner.GetType() {
+	case token.TokenOwner_MSP_IDENTIFIER:
+		return bytes.Equal(credential, owner.Raw)
+	case token.TokenOwner_MULTI_SIG:
+		multiSigOwner := &token.MultiSigOwner{}
+		if err := proto.Unmarshal(owner.Raw, multiSigOwner); err != nil {
+			return false
+		}
+		for _, id := range multiSigOwner.Identities {
+			if bytes.Equal(credential, id) {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // ListTokens creates a TokenTransaction that lists the unspent tokens owned by owner.
@@ -210,7 +327,7 @@ func (t *Transactor) ListTokens() (*token.UnspentTokens, error) {
 				if err != nil {
 					return nil, errors.New("failed to retrieve unspent tokens: casting error")
 				}
-				if bytes.Equal(output.Owner.Raw, t.PublicCredential) {
+				if isOwner(t.PublicCredential, output.Owner) {
 					spent, err := t.isSpent(result.Key)
 					if err != nil {
 						return nil, err
@@ -252,7 +369,7 @@ func (t *Transactor) RequestExpectation(request *token.ExpectationRequest) (*tok
 		return nil, errors.New("no transfer expectation in ExpectationRequest")
 	}
 
-	inputType, inputSum, err := t.getInputsFromTokenIds(request.GetTokenIds())
+	inputType, inputSum, _, err := t.getInputsFromTokenIds(request.GetTokenIds())
 	if err != nil {
 		return nil, err
 	}
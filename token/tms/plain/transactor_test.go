@@ -9,6 +9,7 @@ package plain_test
 import (
 	"errors"
 	"fmt"
+	"math"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/protos/ledger/queryresult"
@@ -91,6 +92,28 @@ var _ = Describe("RequestListTokens", func() {
 			})
 		})
 
+		When("tokens are jointly owned", func() {
+			It("returns the unspent tokens of which the requestor is a joint owner", func() {
+				raw, err := proto.Marshal(&token.MultiSigOwner{Threshold: 1, Identities: [][]byte{[]byte("Bob"), []byte("Alice")}})
+				Expect(err).NotTo(HaveOccurred())
+				jointOutput, err := proto.Marshal(&token.PlainOutput{Owner: &token.TokenOwner{Type: token.TokenOwner_MULTI_SIG, Raw: raw}, Type: "TOK5", Quantity: 500})
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeLedger.GetStateRangeScanIteratorReturns(fakeIterator, nil)
+				fakeIterator.NextReturnsOnCall(0, &queryresult.KV{Key: generateKey("4", "0", "tokenOutput"), Value: jointOutput}, nil)
+				fakeIterator.NextReturnsOnCall(1, results[1], nil)
+				fakeIterator.NextReturnsOnCall(2, nil, nil)
+
+				tokens, err := transactor.ListTokens()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(tokens).To(Equal(&token.UnspentTokens{
+					Tokens: []*token.TokenOutput{
+						{Id: &token.TokenId{TxId: "4", Index: uint32(0)}, Type: "TOK5", Quantity: 500},
+					},
+				}))
+			})
+		})
+
 		When("request list tokens fails", func() {
 			It("returns an error", func() {
 				When("GetStateRangeScanIterator fails", func() {
@@ -367,6 +390,49 @@ var _ = Describe("Transactor", func() {
 			}))
 		})
 
+		Context("when the inputs are jointly owned", func() {
+			var jointOwner *token.TokenOwner
+
+			BeforeEach(func() {
+				raw, err := proto.Marshal(&token.MultiSigOwner{Threshold: 2, Identities: [][]byte{[]byte("Alice"), []byte("Bob")}})
+				Expect(err).NotTo(HaveOccurred())
+				jointOwner = &token.TokenOwner{Type: token.TokenOwner_MULTI_SIG, Raw: raw}
+				inputBytes, err = proto.Marshal(&token.PlainOutput{Owner: jointOwner, Type: "TOK1", Quantity: inputQuantity})
+				Expect(err).NotTo(HaveOccurred())
+				fakeLedger.GetStateReturns(inputBytes, nil)
+				transactor.PublicCredential = []byte("Bob")
+			})
+
+			It("returns the remaining tokens to the joint owners", func() {
+				redeemRequest = &token.RedeemRequest{
+					Credential:       []byte("credential"),
+					TokenIds:         []*token.TokenId{{TxId: "robert", Index: uint32(0)}},
+					QuantityToRedeem: 50,
+				}
+				tt, err := transactor.RequestRedeem(redeemRequest)
+				Expect(err).NotTo(HaveOccurred())
+				outputs := tt.GetPlainAction().GetPlainRedeem().GetOutputs()
+				Expect(outputs).To(HaveLen(2))
+				Expect(proto.Equal(outputs[1], &token.PlainOutput{Owner: jointOwner, Type: "TOK1", Quantity: inputQuantity - 50})).To(BeTrue())
+			})
+
+			Context("when the requestor is not a joint owner", func() {
+				BeforeEach(func() {
+					transactor.PublicCredential = []byte("Charlie")
+				})
+
+				It("returns an error", func() {
+					redeemRequest = &token.RedeemRequest{
+						Credential:       []byte("credential"),
+						TokenIds:         []*token.TokenId{{TxId: "robert", Index: uint32(0)}},
+						QuantityToRedeem: 50,
+					}
+					_, err := transactor.RequestRedeem(redeemRequest)
+					Expect(err).To(MatchError("the requestor does not own inputs"))
+				})
+			})
+		})
+
 		Context("when quantity to redeem is greater than input quantity", func() {
 			BeforeEach(func() {
 				redeemQuantity = inputQuantity + 10
@@ -384,6 +450,178 @@ var _ = Describe("Transactor", func() {
 		})
 	})
 
+	Describe("RequestExchange", func() {
+		var (
+			fakeLedger      *mock.LedgerWriter
+			exchangeRequest *token.ExchangeRequest
+		)
+
+		BeforeEach(func() {
+			aliceInput, err := proto.Marshal(&token.PlainOutput{Owner: &token.TokenOwner{Raw: []byte("Alice")}, Type: "TOK1", Quantity: 100})
+			Expect(err).NotTo(HaveOccurred())
+			aliceLargeInput, err := proto.Marshal(&token.PlainOutput{Owner: &token.TokenOwner{Raw: []byte("Alice")}, Type: "TOK1", Quantity: math.MaxUint64 - 10})
+			Expect(err).NotTo(HaveOccurred())
+			bobInput, err := proto.Marshal(&token.PlainOutput{Owner: &token.TokenOwner{Raw: []byte("Bob")}, Type: "TOK2", Quantity: 20})
+			Expect(err).NotTo(HaveOccurred())
+			fakeLedger = &mock.LedgerWriter{}
+			fakeLedger.GetStateStub = func(namespace, key string) ([]byte, error) {
+				switch key {
+				case generateKey("alice", "0", "tokenOutput"):
+					return aliceInput, nil
+				case generateKey("alice", "1", "tokenOutput"):
+					return aliceLargeInput, nil
+				case generateKey("bob", "0", "tokenOutput"):
+					return bobInput, nil
+				default:
+					return nil, nil
+				}
+			}
+			transactor.Ledger = fakeLedger
+			transactor.TokenOwnerValidator = &TestTokenOwnerValidator{}
+
+			exchangeRequest = &token.ExchangeRequest{
+				Credential: []byte("credential"),
+				Contributions: []*token.ExchangeContribution{
+					{
+						Owner:    []byte("Alice"),
+						TokenIds: []*token.TokenId{{TxId: "alice", Index: 0}},
+						Shares: []*token.ExchangeShare{
+							{Recipient: &token.TokenOwner{Raw: []byte("Bob")}, Type: "TOK1", Quantity: 60},
+							{Recipient: &token.TokenOwner{Raw: []byte("Alice")}, Type: "TOK1", Quantity: 40},
+						},
+					},
+					{
+						Owner:    []byte("Bob"),
+						TokenIds: []*token.TokenId{{TxId: "bob", Index: 0}},
+						Shares: []*token.ExchangeShare{
+							{Recipient: &token.TokenOwner{Raw: []byte("Alice")}, Type: "TOK2", Quantity: 20},
+						},
+					},
+				},
+			}
+		})
+
+		It("creates a token transaction with the inputs and outputs of all the contributions", func() {
+			tt, err := transactor.RequestExchange(exchangeRequest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tt).To(Equal(&token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainExchange{
+							PlainExchange: &token.PlainTransfer{
+								Inputs: []*token.TokenId{
+									{TxId: "alice", Index: 0},
+									{TxId: "bob", Index: 0},
+								},
+								Outputs: []*token.PlainOutput{
+									{Owner: &token.TokenOwner{Raw: []byte("Bob")}, Type: "TOK1", Quantity: 60},
+									{Owner: &token.TokenOwner{Raw: []byte("Alice")}, Type: "TOK1", Quantity: 40},
+									{Owner: &token.TokenOwner{Raw: []byte("Alice")}, Type: "TOK2", Quantity: 20},
+								},
+							},
+						},
+					},
+				},
+			}))
+		})
+
+		Context("when there are no contributions", func() {
+			It("returns an error", func() {
+				_, err := transactor.RequestExchange(&token.ExchangeRequest{})
+				Expect(err).To(MatchError("no contributions in exchange request"))
+			})
+		})
+
+		Context("when a contribution has no token IDs", func() {
+			BeforeEach(func() {
+				exchangeRequest.Contributions[1].TokenIds = nil
+			})
+
+			It("returns an error", func() {
+				_, err := transactor.RequestExchange(exchangeRequest)
+				Expect(err).To(MatchError("no token IDs in contribution 1 of exchange request"))
+			})
+		})
+
+		Context("when the owner of a contribution does not own its inputs", func() {
+			BeforeEach(func() {
+				exchangeRequest.Contributions[1].Owner = []byte("Charlie")
+			})
+
+			It("returns an error", func() {
+				_, err := transactor.RequestExchange(exchangeRequest)
+				Expect(err).To(MatchError("the owner of contribution 1 does not own inputs"))
+			})
+		})
+
+		Context("when an input does not exist", func() {
+			BeforeEach(func() {
+				exchangeRequest.Contributions[1].TokenIds = []*token.TokenId{{TxId: "charlie", Index: 0}}
+			})
+
+			It("returns an error", func() {
+				_, err := transactor.RequestExchange(exchangeRequest)
+				Expect(err).To(MatchError("input '\x00tokenOutput\x00charlie\x000\x00' does not exist"))
+			})
+		})
+
+		Context("when the quantities of a type do not add up", func() {
+			BeforeEach(func() {
+				exchangeRequest.Contributions[1].Shares[0].Quantity = 21
+			})
+
+			It("returns an error", func() {
+				_, err := transactor.RequestExchange(exchangeRequest)
+				Expect(err).To(MatchError("token sum mismatch in inputs and outputs of type 'TOK2' (21 vs 20)"))
+			})
+		})
+
+		Context("when the input quantities of a type overflow", func() {
+			BeforeEach(func() {
+				exchangeRequest.Contributions[0].TokenIds = append(exchangeRequest.Contributions[0].TokenIds, &token.TokenId{TxId: "alice", Index: 1})
+			})
+
+			It("returns an error", func() {
+				_, err := transactor.RequestExchange(exchangeRequest)
+				Expect(err).To(MatchError("input quantity of type 'TOK1' overflows in exchange request"))
+			})
+		})
+
+		Context("when the output quantities of a type overflow", func() {
+			BeforeEach(func() {
+				exchangeRequest.Contributions[0].Shares[1].Quantity = math.MaxUint64 - 10
+			})
+
+			It("returns an error", func() {
+				_, err := transactor.RequestExchange(exchangeRequest)
+				Expect(err).To(MatchError("output quantity of type 'TOK1' overflows in exchange request"))
+			})
+		})
+
+		Context("when an output has a type which is not in the inputs", func() {
+			BeforeEach(func() {
+				exchangeRequest.Contributions[1].Shares = append(exchangeRequest.Contributions[1].Shares,
+					&token.ExchangeShare{Recipient: &token.TokenOwner{Raw: []byte("Alice")}, Type: "TOK3", Quantity: 1})
+			})
+
+			It("returns an error", func() {
+				_, err := transactor.RequestExchange(exchangeRequest)
+				Expect(err).To(MatchError("no inputs of type 'TOK3' in exchange request"))
+			})
+		})
+
+		Context("when a recipient is invalid", func() {
+			BeforeEach(func() {
+				exchangeRequest.Contributions[0].Shares[0].Recipient = nil
+			})
+
+			It("returns an error", func() {
+				_, err := transactor.RequestExchange(exchangeRequest)
+				Expect(err).To(MatchError("invalid recipient in exchange request 'owner is nil'"))
+			})
+		})
+	})
+
 	Describe("RequestExpectation", func() {
 		var (
 			fakeLedger         *mock.LedgerWriter
@@ -8,6 +8,7 @@ package plain
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
 	"strconv"
@@ -17,6 +18,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/core/ledger/customtx"
+	"github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/token"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/hyperledger/fabric/token/identity"
@@ -32,6 +34,8 @@ const (
 	tokenRedeem           = "tokenRedeem"
 	tokenInput            = "tokenInput"
 	tokenDelegatedInput   = "tokenDelegateInput"
+	tokenApproval         = "tokenApproval"
+	tokenSupply           = "tokenSupply"
 	tokenNameSpace        = "_fabtoken"
 )
 
@@ -41,6 +45,21 @@ var verifierLogger = flogging.MustGetLogger("token.tms.plain.verifier")
 type Verifier struct {
 	IssuingValidator    identity.IssuingValidator
 	TokenOwnerValidator identity.TokenOwnerValidator
+	// Deserializer is used to verify the signatures of the joint owners of the inputs
+	Deserializer identity.Deserializer
+	// TokenTypes are the token types defined in the channel config. If they are set, the
+	// supply of each type is tracked, and imports may only issue defined types up to
+	// their maximum supply
+	TokenTypes map[string]*peer.TokenType
+}
+
+// spendAuthorization holds what authorizes a transaction to spend inputs which
+// are not owned by its creator alone
+type spendAuthorization struct {
+	// signers are the serialized identities whose signature on the action has been verified
+	signers map[string]bool
+	// approvalKey is the key that chaincodes owning inputs write to approve the action
+	approvalKey string
 }
 
 // ProcessTx checks that transactions are correct wrt. the most recent ledger state.
@@ -68,7 +87,12 @@ func (v *Verifier) checkProcess(txID string, creator identity.PublicInfo, ttx *t
 		return &customtx.InvalidTxError{Msg: fmt.Sprintf("check process failed for transaction '%s': missing token action", txID)}
 	}
 
-	err := v.checkAction(creator, action, txID, simulator)
+	auth, err := v.checkSignatures(action, ttx.GetSignatures(), txID)
+	if err != nil {
+		return err
+	}
+
+	err = v.checkAction(creator, auth, action, txID, simulator)
 	if err != nil {
 		return err
 	}
@@ -76,14 +100,47 @@ func (v *Verifier) checkProcess(txID string, creator identity.PublicInfo, ttx *t
 	return nil
 }
 
-func (v *Verifier) checkAction(creator identity.PublicInfo, plainAction *token.PlainTokenAction, txID string, simulator ledger.LedgerReader) error {
+// checkSignatures verifies the signatures of the joint owners of the inputs on the action
+func (v *Verifier) checkSignatures(action *token.PlainTokenAction, signatures []*token.TokenSignature, txID string) (*spendAuthorization, error) {
+	actionBytes, err := proto.Marshal(action)
+	if err != nil {
+		return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("error marshaling action of transaction '%s': %s", txID, err)}
+	}
+	approvalKey, err := createApprovalKey(actionBytes)
+	if err != nil {
+		return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating approval key: %s", err)}
+	}
+
+	auth := &spendAuthorization{signers: map[string]bool{}, approvalKey: approvalKey}
+	if len(signatures) != 0 && v.Deserializer == nil {
+		return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("signatures of transaction '%s' cannot be verified", txID)}
+	}
+	for _, signature := range signatures {
+		signer, err := v.Deserializer.DeserializeIdentity(signature.Signer)
+		if err != nil {
+			return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("signer [0x%x] of transaction '%s' cannot be deserialised: %s", signature.Signer, txID, err)}
+		}
+		if err := signer.Validate(); err != nil {
+			return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("signer [0x%x] of transaction '%s' cannot be validated: %s", signature.Signer, txID, err)}
+		}
+		if err := signer.Verify(actionBytes, signature.Signature); err != nil {
+			return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("invalid signature of signer [0x%x] on transaction '%s': %s", signature.Signer, txID, err)}
+		}
+		auth.signers[string(signature.Signer)] = true
+	}
+	return auth, nil
+}
+
+func (v *Verifier) checkAction(creator identity.PublicInfo, auth *spendAuthorization, plainAction *token.PlainTokenAction, txID string, simulator ledger.LedgerReader) error {
 	switch action := plainAction.Data.(type) {
 	case *token.PlainTokenAction_PlainImport:
 		return v.checkImportAction(creator, action.PlainImport, txID, simulator)
 	case *token.PlainTokenAction_PlainTransfer:
-		return v.checkTransferAction(creator, action.PlainTransfer, txID, simulator)
+		return v.checkTransferAction(creator, auth, action.PlainTransfer, txID, simulator)
 	case *token.PlainTokenAction_PlainRedeem:
-		return v.checkRedeemAction(creator, action.PlainRedeem, txID, simulator)
+		return v.checkRedeemAction(creator, auth, action.PlainRedeem, txID, simulator)
+	case *token.PlainTokenAction_PlainExchange:
+		return v.checkExchangeAction(creator, auth, action.PlainExchange, txID, simulator)
 	default:
 		return &customtx.InvalidTxError{Msg: fmt.Sprintf("unknown plain token action: %T", action)}
 	}
@@ -94,7 +151,11 @@ func (v *Verifier) checkImportAction(creator identity.PublicInfo, importAction *
 	if err != nil {
 		return err
 	}
-	return v.checkImportPolicy(creator, txID, importAction)
+	err = v.checkImportPolicy(creator, txID, importAction)
+	if err != nil {
+		return err
+	}
+	return v.checkImportSupply(importAction.GetOutputs(), txID, simulator)
 }
 
 func (v *Verifier) checkImportOutputs(outputs []*token.PlainOutput, txID string, simulator ledger.LedgerReader) error {
@@ -118,12 +179,12 @@ func (v *Verifier) checkImportOutputs(outputs []*token.PlainOutput, txID string,
 	return nil
 }
 
-func (v *Verifier) checkTransferAction(creator identity.PublicInfo, transferAction *token.PlainTransfer, txID string, simulator ledger.LedgerReader) error {
-	return v.checkInputsAndOutputs(creator, transferAction.GetInputs(), transferAction.GetOutputs(), txID, simulator, true)
+func (v *Verifier) checkTransferAction(creator identity.PublicInfo, auth *spendAuthorization, transferAction *token.PlainTransfer, txID string, simulator ledger.LedgerReader) error {
+	return v.checkInputsAndOutputs(creator, auth, transferAction.GetInputs(), transferAction.GetOutputs(), txID, simulator, true)
 }
 
-func (v *Verifier) checkRedeemAction(creator identity.PublicInfo, redeemAction *token.PlainTransfer, txID string, simulator ledger.LedgerReader) error {
-	err := v.checkInputsAndOutputs(creator, redeemAction.GetInputs(), redeemAction.GetOutputs(), txID, simulator, false)
+func (v *Verifier) checkRedeemAction(creator identity.PublicInfo, auth *spendAuthorization, redeemAction *token.PlainTransfer, txID string, simulator ledger.LedgerReader) error {
+	err := v.checkInputsAndOutputs(creator, auth, redeemAction.GetInputs(), redeemAction.GetOutputs(), txID, simulator, false)
 	if err != nil {
 		return err
 	}
@@ -140,8 +201,23 @@ func (v *Verifier) checkRedeemAction(creator identity.PublicInfo, redeemAction *
 		return &customtx.InvalidTxError{Msg: fmt.Sprintf("owner should be nil in a redeem output")}
 	}
 
-	// if output[1] presents, its owner must be same as the creator
-	if len(outputs) == 2 && !bytes.Equal(creator.Public(), outputs[1].Owner.Raw) {
+	if len(outputs) < 2 {
+		return nil
+	}
+
+	// if output[1] presents, its owner must be the joint or chaincode owner of all inputs,
+	// or the creator otherwise
+	inputsOwner, err := v.getInputsOwner(redeemAction.GetInputs(), simulator)
+	if err != nil {
+		return err
+	}
+	if inputsOwner != nil && inputsOwner.Type != token.TokenOwner_MSP_IDENTIFIER {
+		if !proto.Equal(inputsOwner, outputs[1].Owner) {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("wrong owner for remaining tokens, should be the %s owner of the inputs", inputsOwner.Type)}
+		}
+		return nil
+	}
+	if !bytes.Equal(creator.Public(), outputs[1].GetOwner().GetRaw()) {
 		println(hex.EncodeToString(creator.Public()))
 		println(hex.EncodeToString(outputs[1].Owner.Raw))
 		return &customtx.InvalidTxError{Msg: fmt.Sprintf("wrong owner for remaining tokens, should be original owner %s, but got %s", creator.Public(), outputs[1].Owner.Raw)}
@@ -150,9 +226,97 @@ func (v *Verifier) checkRedeemAction(creator identity.PublicInfo, redeemAction *
 	return nil
 }
 
+// getInputsOwner returns the owner of the passed inputs, or nil if they have different owners
+func (v *Verifier) getInputsOwner(tokenIds []*token.TokenId, simulator ledger.LedgerReader) (*token.TokenOwner, error) {
+	var owner *token.TokenOwner
+	for i, id := range tokenIds {
+		inputKey, err := createOutputKey(id.TxId, int(id.Index))
+		if err != nil {
+			return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating output ID for redeem input: %s", err)}
+		}
+		input, err := v.getOutput(inputKey, simulator)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			owner = input.GetOwner()
+		} else if !proto.Equal(owner, input.GetOwner()) {
+			return nil, nil
+		}
+	}
+	return owner, nil
+}
+
+// checkExchangeAction checks that the inputs of the exchange are spent by their owners and that,
+// for each token type, the quantity of the inputs is the quantity of the outputs
+func (v *Verifier) checkExchangeAction(creator identity.PublicInfo, auth *spendAuthorization, exchangeAction *token.PlainTransfer, txID string, simulator ledger.LedgerReader) error {
+	if len(exchangeAction.GetInputs()) == 0 {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("no inputs in exchange transaction: %s", txID)}
+	}
+	if len(exchangeAction.GetOutputs()) == 0 {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("no outputs in exchange transaction: %s", txID)}
+	}
+
+	outputSums := make(map[string]uint64)
+	for i, output := range exchangeAction.GetOutputs() {
+		err := v.checkOutputDoesNotExist(i, output, txID, simulator)
+		if err != nil {
+			return err
+		}
+		err = v.TokenOwnerValidator.Validate(output.GetOwner())
+		if err != nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("invalid owner in output for txID '%s', err '%s'", txID, err)}
+		}
+		sum := outputSums[output.GetType()] + output.GetQuantity()
+		if sum < output.GetQuantity() {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("output quantity of type %s overflows in transaction ID %s", output.GetType(), txID)}
+		}
+		outputSums[output.GetType()] = sum
+	}
+
+	inputSums := make(map[string]uint64)
+	processedIDs := make(map[string]bool)
+	for _, id := range exchangeAction.GetInputs() {
+		input, err := v.checkInput(id, processedIDs, txID, simulator, func(input *token.PlainOutput, inputKey string) error {
+			return v.checkExchangeInputOwner(creator, auth, input, inputKey, simulator)
+		})
+		if err != nil {
+			return err
+		}
+		sum := inputSums[input.GetType()] + input.GetQuantity()
+		if sum < input.GetQuantity() {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("input quantity of type %s overflows in transaction ID %s", input.GetType(), txID)}
+		}
+		inputSums[input.GetType()] = sum
+	}
+
+	for tokenType, inputSum := range inputSums {
+		if outputSums[tokenType] != inputSum {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("token sum mismatch in inputs and outputs of type %s for transaction ID %s (%d vs %d)", tokenType, txID, outputSums[tokenType], inputSum)}
+		}
+	}
+	for tokenType := range outputSums {
+		if _, ok := inputSums[tokenType]; !ok {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("no inputs of output type %s for transaction ID %s", tokenType, txID)}
+		}
+	}
+	return nil
+}
+
+// checkExchangeInputOwner checks that the exchange is authorized to spend the input: in addition
+// to the inputs that a transfer may spend, an exchange spends the inputs owned by the identities
+// which signed it
+func (v *Verifier) checkExchangeInputOwner(creator identity.PublicInfo, auth *spendAuthorization, input *token.PlainOutput, tokenId string, simulator ledger.LedgerReader) error {
+	if input.GetOwner().GetType() == token.TokenOwner_MSP_IDENTIFIER && auth.signers[string(input.Owner.Raw)] {
+		return nil
+	}
+	return v.checkInputOwner(creator, auth, input, tokenId, simulator)
+}
+
 // checkInputsAndOutputs checks that inputs and outputs are valid and have same type and sum of quantity
 func (v *Verifier) checkInputsAndOutputs(
 	creator identity.PublicInfo,
+	auth *spendAuthorization,
 	tokenIds []*token.TokenId,
 	outputs []*token.PlainOutput,
 	txID string,
@@ -163,7 +327,7 @@ func (v *Verifier) checkInputsAndOutputs(
 	if err != nil {
 		return err
 	}
-	inputType, inputSum, err := v.checkInputs(creator, tokenIds, txID, simulator)
+	inputType, inputSum, err := v.checkInputs(creator, auth, tokenIds, txID, simulator)
 	if err != nil {
 		return err
 	}
@@ -223,23 +387,14 @@ func (v *Verifier) checkOutputs(outputs []*token.PlainOutput, txID string, simul
 	return tokenType, tokenSum, nil
 }
 
-func (v *Verifier) checkInputs(creator identity.PublicInfo, tokenIds []*token.TokenId, txID string, simulator ledger.LedgerReader) (string, uint64, error) {
+func (v *Verifier) checkInputs(creator identity.PublicInfo, auth *spendAuthorization, tokenIds []*token.TokenId, txID string, simulator ledger.LedgerReader) (string, uint64, error) {
 	tokenType := ""
 	inputSum := uint64(0)
 	processedIDs := make(map[string]bool)
 	for _, id := range tokenIds {
-		inputKey, err := createOutputKey(id.TxId, int(id.Index))
-		if err != nil {
-			return "", 0, &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating output ID for transfer input: %s", err)}
-		}
-		input, err := v.getOutput(inputKey, simulator)
-		if err != nil {
-			return "", 0, err
-		}
-		if input == nil {
-			return "", 0, &customtx.InvalidTxError{Msg: fmt.Sprintf("input with ID %s for transfer does not exist", inputKey)}
-		}
-		err = v.checkInputOwner(creator, input, inputKey)
+		input, err := v.checkInput(id, processedIDs, txID, simulator, func(input *token.PlainOutput, inputKey string) error {
+			return v.checkInputOwner(creator, auth, input, inputKey, simulator)
+		})
 		if err != nil {
 			return "", 0, err
 		}
@@ -248,29 +403,93 @@ func (v *Verifier) checkInputs(creator identity.PublicInfo, tokenIds []*token.To
 		} else if tokenType != input.GetType() {
 			return "", 0, &customtx.InvalidTxError{Msg: fmt.Sprintf("multiple token types in input for txID: %s (%s, %s)", txID, tokenType, input.GetType())}
 		}
-		if processedIDs[inputKey] {
-			return "", 0, &customtx.InvalidTxError{Msg: fmt.Sprintf("token input '%s' spent more than once in transaction ID '%s'", inputKey, txID)}
-		}
-		processedIDs[inputKey] = true
 		inputSum += input.GetQuantity()
-		spentKey, err := createSpentKey(id.TxId, int(id.Index))
+	}
+	return tokenType, inputSum, nil
+}
+
+// checkInput checks that the input with the passed ID exists, is authorized by checkOwner,
+// is not spent and is not among the processed IDs, to which it is added
+func (v *Verifier) checkInput(
+	id *token.TokenId,
+	processedIDs map[string]bool,
+	txID string,
+	simulator ledger.LedgerReader,
+	checkOwner func(input *token.PlainOutput, inputKey string) error) (*token.PlainOutput, error) {
+
+	inputKey, err := createOutputKey(id.TxId, int(id.Index))
+	if err != nil {
+		return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating output ID for transfer input: %s", err)}
+	}
+	input, err := v.getOutput(inputKey, simulator)
+	if err != nil {
+		return nil, err
+	}
+	if input == nil {
+		return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("input with ID %s for transfer does not exist", inputKey)}
+	}
+	err = checkOwner(input, inputKey)
+	if err != nil {
+		return nil, err
+	}
+	if processedIDs[inputKey] {
+		return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("token input '%s' spent more than once in transaction ID '%s'", inputKey, txID)}
+	}
+	processedIDs[inputKey] = true
+	spentKey, err := createSpentKey(id.TxId, int(id.Index))
+	if err != nil {
+		return nil, err
+	}
+	spent, err := v.isSpent(spentKey, simulator)
+	if err != nil {
+		return nil, err
+	}
+	if spent {
+		return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("input with ID %s for transfer has already been spent", inputKey)}
+	}
+	return input, nil
+}
+
+// checkInputOwner checks that the transaction is authorized to spend the input: an input
+// owned by an identity is spent by that identity, an input owned by multiple identities
+// is spent by at least threshold of them, and an input owned by a chaincode is spent
+// by the transactions that the chaincode approves
+func (v *Verifier) checkInputOwner(creator identity.PublicInfo, auth *spendAuthorization, input *token.PlainOutput, tokenId string, simulator ledger.LedgerReader) error {
+	switch input.GetOwner().GetType() {
+	case token.TokenOwner_MSP_IDENTIFIER:
+		if !bytes.Equal(creator.Public(), input.Owner.Raw) {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("transfer input with ID %s not owned by creator", tokenId)}
+		}
+	case token.TokenOwner_MULTI_SIG:
+		multiSigOwner := &token.MultiSigOwner{}
+		err := proto.Unmarshal(input.Owner.Raw, multiSigOwner)
 		if err != nil {
-			return "", 0, err
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("error unmarshaling multi-signature owner of transfer input with ID %s: %s", tokenId, err)}
+		}
+		signed := uint32(0)
+		for _, owner := range multiSigOwner.Identities {
+			if bytes.Equal(creator.Public(), owner) || auth.signers[string(owner)] {
+				signed++
+			}
+		}
+		if signed < multiSigOwner.Threshold {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("transfer input with ID %s signed by %d owners, %d required", tokenId, signed, multiSigOwner.Threshold)}
 		}
-		spent, err := v.isSpent(spentKey, simulator)
+	case token.TokenOwner_CHAINCODE_ID:
+		chaincodeOwner := &token.ChaincodeOwner{}
+		err := proto.Unmarshal(input.Owner.Raw, chaincodeOwner)
 		if err != nil {
-			return "", 0, err
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("error unmarshaling chaincode owner of transfer input with ID %s: %s", tokenId, err)}
 		}
-		if spent {
-			return "", 0, &customtx.InvalidTxError{Msg: fmt.Sprintf("input with ID %s for transfer has already been spent", inputKey)}
+		approval, err := simulator.GetState(chaincodeOwner.ChaincodeName, auth.approvalKey)
+		if err != nil {
+			return err
 		}
-	}
-	return tokenType, inputSum, nil
-}
-
-func (v *Verifier) checkInputOwner(creator identity.PublicInfo, input *token.PlainOutput, tokenId string) error {
-	if !bytes.Equal(creator.Public(), input.Owner.Raw) {
-		return &customtx.InvalidTxError{Msg: fmt.Sprintf("transfer input with ID %s not owned by creator", tokenId)}
+		if approval == nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("transfer input with ID %s not approved by chaincode %s", tokenId, chaincodeOwner.ChaincodeName)}
+		}
+	default:
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("transfer input with ID %s has unknown owner type %s", tokenId, input.GetOwner().GetType())}
 	}
 	return nil
 }
@@ -285,6 +504,44 @@ func (v *Verifier) checkImportPolicy(creator identity.PublicInfo, txID string, i
 	return nil
 }
 
+// checkImportSupply checks that the outputs of an import do not take the outstanding
+// supply of their types, which is the issued quantity minus the redeemed quantity,
+// above the maximum supply
+func (v *Verifier) checkImportSupply(outputs []*token.PlainOutput, txID string, simulator ledger.LedgerReader) error {
+	if v.TokenTypes == nil {
+		return nil
+	}
+
+	var types []string
+	quantities := map[string]uint64{}
+	for _, output := range outputs {
+		if v.TokenTypes[output.Type] == nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("token type %s is not defined for transaction ID %s", output.Type, txID)}
+		}
+		if _, ok := quantities[output.Type]; !ok {
+			types = append(types, output.Type)
+		}
+		sum := quantities[output.Type] + output.Quantity
+		if sum < output.Quantity {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("quantity of type %s overflows in transaction ID %s", output.Type, txID)}
+		}
+		quantities[output.Type] = sum
+	}
+
+	for _, tokenType := range types {
+		supply, err := getSupply(tokenType, simulator)
+		if err != nil {
+			return err
+		}
+		outstanding := outstandingSupply(supply)
+		maxSupply := v.TokenTypes[tokenType].MaxSupply
+		if quantities[tokenType] > maxSupply || outstanding > maxSupply-quantities[tokenType] {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("import of %d tokens of type %s exceeds the maximum supply %d, with %d tokens outstanding, for transaction ID %s", quantities[tokenType], tokenType, maxSupply, outstanding, txID)}
+		}
+	}
+	return nil
+}
+
 func (v *Verifier) commitProcess(txID string, creator identity.PublicInfo, ttx *token.TokenTransaction, simulator ledger.LedgerWriter) error {
 	verifierLogger.Debugf("committing action with txID '%s'", txID)
 	err := v.commitAction(ttx.GetPlainAction(), txID, simulator)
@@ -306,6 +563,12 @@ func (v *Verifier) commitAction(plainAction *token.PlainTokenAction, txID string
 	case *token.PlainTokenAction_PlainRedeem:
 		// call the same commit method as transfer because PlainRedeem points to the same type of outputs as transfer
 		err = v.commitTransferAction(action.PlainRedeem, txID, simulator)
+		if err == nil {
+			err = v.commitRedeemedSupply(action.PlainRedeem.GetOutputs(), simulator)
+		}
+	case *token.PlainTokenAction_PlainExchange:
+		// an exchange is committed as a transfer, whose inputs and outputs have several types
+		err = v.commitTransferAction(action.PlainExchange, txID, simulator)
 	}
 	return
 }
@@ -322,7 +585,7 @@ func (v *Verifier) commitImportAction(importAction *token.PlainImport, txID stri
 			return err
 		}
 	}
-	return nil
+	return v.commitIssuedSupply(importAction.GetOutputs(), simulator)
 }
 
 // commitTransferAction is called for both transfer and redeem transactions
@@ -348,6 +611,60 @@ func (v *Verifier) commitTransferAction(transferAction *token.PlainTransfer, txI
 	return v.markInputsSpent(txID, transferAction.GetInputs(), simulator)
 }
 
+// commitIssuedSupply adds the quantities of the passed import outputs to the
+// issued quantities of their types, if the supply is tracked
+func (v *Verifier) commitIssuedSupply(outputs []*token.PlainOutput, simulator ledger.LedgerWriter) error {
+	if v.TokenTypes == nil {
+		return nil
+	}
+	for _, output := range outputs {
+		err := v.updateSupply(output.Type, output.Quantity, 0, simulator)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// commitRedeemedSupply adds the quantities of the redeem outputs among the passed
+// outputs to the redeemed quantities of their types, if the supply is tracked.
+// The redeemed quantity of a type never exceeds its issued quantity, since the
+// redemption of tokens issued before the supply was tracked must not make room
+// for new ones
+func (v *Verifier) commitRedeemedSupply(outputs []*token.PlainOutput, simulator ledger.LedgerWriter) error {
+	if v.TokenTypes == nil {
+		return nil
+	}
+	for _, output := range outputs {
+		if output.Owner != nil {
+			continue
+		}
+		err := v.updateSupply(output.Type, 0, output.Quantity, simulator)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *Verifier) updateSupply(tokenType string, issued, redeemed uint64, simulator ledger.LedgerWriter) error {
+	supplyKey, err := createSupplyKey(tokenType)
+	if err != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating supply key: %s", err)}
+	}
+	supply, err := getSupply(tokenType, simulator)
+	if err != nil {
+		return err
+	}
+	supply.Issued += issued
+	if redeemed > outstandingSupply(supply) {
+		redeemed = outstandingSupply(supply)
+	}
+	supply.Redeemed += redeemed
+
+	return simulator.SetState(tokenNameSpace, supplyKey, utils.MarshalOrPanic(supply))
+}
+
 func (v *Verifier) addOutput(outputID string, output *token.PlainOutput, simulator ledger.LedgerWriter) error {
 	outputBytes := utils.MarshalOrPanic(output)
 
@@ -408,6 +725,34 @@ func (v *Verifier) getOutput(outputID string, simulator ledger.LedgerReader) (*t
 	return output, nil
 }
 
+// getSupply returns the supply record of the passed token type, which is empty
+// if no tokens of the type have been issued or redeemed.
+func getSupply(tokenType string, simulator ledger.LedgerReader) (*token.TokenSupply, error) {
+	supplyKey, err := createSupplyKey(tokenType)
+	if err != nil {
+		return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating supply key: %s", err)}
+	}
+	supplyBytes, err := simulator.GetState(tokenNameSpace, supplyKey)
+	if err != nil {
+		return nil, err
+	}
+	supply := &token.TokenSupply{}
+	err = proto.Unmarshal(supplyBytes, supply)
+	if err != nil {
+		return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("unmarshaling error: %s", err)}
+	}
+	supply.Type = tokenType
+	return supply, nil
+}
+
+// outstandingSupply returns the quantity of tokens that have been issued and not redeemed.
+func outstandingSupply(supply *token.TokenSupply) uint64 {
+	if supply.Redeemed > supply.Issued {
+		return 0
+	}
+	return supply.Issued - supply.Redeemed
+}
+
 // isSpent checks whether an output token with identifier outputID has been spent.
 func (v *Verifier) isSpent(spentKey string, simulator ledger.LedgerReader) (bool, error) {
 	verifierLogger.Debugf("checking if input with ID '%s' has been spent", spentKey)
@@ -427,6 +772,11 @@ func createRedeemKey(txID string, index int) (string, error) {
 	return createCompositeKey(tokenRedeem, []string{txID, strconv.Itoa(index)})
 }
 
+// Create a ledger key for the supply record of a token type
+func createSupplyKey(tokenType string) (string, error) {
+	return createCompositeKey(tokenSupply, []string{tokenType})
+}
+
 // Create a ledger key for a spent individual output in a token transaction, as a function of
 // the transaction ID, and the index of the output
 func createSpentKey(txID string, index int) (string, error) {
@@ -439,6 +789,23 @@ func createSpentDelegatedOutputKey(txID string, index int) (string, error) {
 	return createCompositeKey(tokenDelegatedInput, []string{txID, strconv.Itoa(index)})
 }
 
+// ApprovalKey returns the key that a chaincode writes to its state, when invoked,
+// to approve the passed action spending the tokens owned by the chaincode.
+// The key is the composite key of type tokenApproval whose attribute is the
+// hex encoded SHA256 hash of the marshaled action.
+func ApprovalKey(action *token.PlainTokenAction) (string, error) {
+	actionBytes, err := proto.Marshal(action)
+	if err != nil {
+		return "", errors.Wrap(err, "error marshaling token action")
+	}
+	return createApprovalKey(actionBytes)
+}
+
+func createApprovalKey(actionBytes []byte) (string, error) {
+	hash := sha256.Sum256(actionBytes)
+	return createCompositeKey(tokenApproval, []string{hex.EncodeToString(hash[:])})
+}
+
 // createCompositeKey and its related functions and consts copied from core/chaincode/shim/chaincode.go
 func createCompositeKey(objectType string, attributes []string) (string, error) {
 	if err := validateCompositeKeyAttribute(objectType); err != nil {
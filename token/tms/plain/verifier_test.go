@@ -10,12 +10,14 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/hyperledger/fabric/token/identity"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/core/ledger/customtx"
+	"github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/token"
 	mockid "github.com/hyperledger/fabric/token/identity/mock"
 	mockledger "github.com/hyperledger/fabric/token/ledger/mock"
@@ -924,6 +926,584 @@ var _ = Describe("Verifier", func() {
 			})
 		})
 	})
+
+	Describe("Test ProcessTx with joint and chaincode owners with memory ledger", func() {
+		var (
+			fakeDeserializer *mockid.Deserializer
+			fakeIdentity     *mockid.Identity
+			multiSigOwner    *token.TokenOwner
+			chaincodeOwner   *token.TokenOwner
+			transferTxID     string
+		)
+
+		transferOf := func(index uint32, outputs ...*token.PlainOutput) *token.TokenTransaction {
+			return &token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainTransfer{
+							PlainTransfer: &token.PlainTransfer{
+								Inputs:  []*token.TokenId{{TxId: "0", Index: index}},
+								Outputs: outputs,
+							},
+						},
+					},
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			fakeIdentity = &mockid.Identity{}
+			fakeDeserializer = &mockid.Deserializer{}
+			fakeDeserializer.DeserializeIdentityReturns(fakeIdentity, nil)
+			verifier.Deserializer = fakeDeserializer
+
+			multiSigRaw, err := proto.Marshal(&token.MultiSigOwner{
+				Threshold:  2,
+				Identities: [][]byte{[]byte("owner-1"), []byte("owner-2"), []byte("owner-3")},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			multiSigOwner = &token.TokenOwner{Type: token.TokenOwner_MULTI_SIG, Raw: multiSigRaw}
+			chaincodeRaw, err := proto.Marshal(&token.ChaincodeOwner{ChaincodeName: "escrow"})
+			Expect(err).NotTo(HaveOccurred())
+			chaincodeOwner = &token.TokenOwner{Type: token.TokenOwner_CHAINCODE_ID, Raw: chaincodeRaw}
+
+			importTransaction = &token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainImport{
+							PlainImport: &token.PlainImport{
+								Outputs: []*token.PlainOutput{
+									{Owner: multiSigOwner, Type: "TOK1", Quantity: 100},
+									{Owner: chaincodeOwner, Type: "TOK1", Quantity: 200},
+								},
+							},
+						},
+					},
+				},
+			}
+
+			transferTxID = "1"
+			fakePublicInfo.PublicReturns([]byte("owner-1"))
+			memoryLedger = plain.NewMemoryLedger()
+			err = verifier.ProcessTx(importTxID, fakePublicInfo, importTransaction, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Describe("spending a jointly owned input", func() {
+			var transferTransaction *token.TokenTransaction
+
+			BeforeEach(func() {
+				transferTransaction = transferOf(0, &token.PlainOutput{Owner: &token.TokenOwner{Raw: []byte("owner-4")}, Type: "TOK1", Quantity: 100})
+			})
+
+			It("succeeds when the creator and the signers reach the threshold", func() {
+				transferTransaction.Signatures = []*token.TokenSignature{{Signer: []byte("owner-2"), Signature: []byte("signature")}}
+
+				err := verifier.ProcessTx(transferTxID, fakePublicInfo, transferTransaction, memoryLedger)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeDeserializer.DeserializeIdentityArgsForCall(0)).To(Equal([]byte("owner-2")))
+				Expect(fakeIdentity.VerifyCallCount()).To(Equal(1))
+				actionBytes, err := proto.Marshal(transferTransaction.GetPlainAction())
+				Expect(err).NotTo(HaveOccurred())
+				msg, sig := fakeIdentity.VerifyArgsForCall(0)
+				Expect(msg).To(Equal(actionBytes))
+				Expect(sig).To(Equal([]byte("signature")))
+
+				spentMarker, err := memoryLedger.GetState(tokenNamespace, string("\x00")+"tokenInput"+string("\x00")+"0"+string("\x00")+"0"+string("\x00"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(bytes.Equal(spentMarker, plain.TokenInputSpentMarker)).To(BeTrue())
+			})
+
+			It("succeeds when the signers reach the threshold without the creator", func() {
+				fakePublicInfo.PublicReturns([]byte("owner-4"))
+				transferTransaction.Signatures = []*token.TokenSignature{
+					{Signer: []byte("owner-2"), Signature: []byte("signature-2")},
+					{Signer: []byte("owner-3"), Signature: []byte("signature-3")},
+				}
+
+				err := verifier.ProcessTx(transferTxID, fakePublicInfo, transferTransaction, memoryLedger)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			Context("when the threshold is not reached", func() {
+				BeforeEach(func() {
+					transferTransaction.Signatures = []*token.TokenSignature{{Signer: []byte("owner-9"), Signature: []byte("signature")}}
+				})
+
+				It("returns an InvalidTxError", func() {
+					err := verifier.ProcessTx(transferTxID, fakePublicInfo, transferTransaction, memoryLedger)
+					Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "transfer input with ID \x00tokenOutput\x000\x000\x00 signed by 1 owners, 2 required"}))
+				})
+			})
+
+			Context("when a signature is invalid", func() {
+				BeforeEach(func() {
+					transferTransaction.Signatures = []*token.TokenSignature{{Signer: []byte("owner-2"), Signature: []byte("signature")}}
+					fakeIdentity.VerifyReturns(errors.New("bad-signature"))
+				})
+
+				It("returns an InvalidTxError", func() {
+					err := verifier.ProcessTx(transferTxID, fakePublicInfo, transferTransaction, memoryLedger)
+					Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "invalid signature of signer [0x6f776e65722d32] on transaction '1': bad-signature"}))
+				})
+			})
+
+			Context("when a signer cannot be deserialized", func() {
+				BeforeEach(func() {
+					transferTransaction.Signatures = []*token.TokenSignature{{Signer: []byte("owner-2"), Signature: []byte("signature")}}
+					fakeDeserializer.DeserializeIdentityReturns(nil, errors.New("no-way-man"))
+				})
+
+				It("returns an InvalidTxError", func() {
+					err := verifier.ProcessTx(transferTxID, fakePublicInfo, transferTransaction, memoryLedger)
+					Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "signer [0x6f776e65722d32] of transaction '1' cannot be deserialised: no-way-man"}))
+				})
+			})
+
+			Context("when the verifier has no deserializer", func() {
+				BeforeEach(func() {
+					transferTransaction.Signatures = []*token.TokenSignature{{Signer: []byte("owner-2"), Signature: []byte("signature")}}
+					verifier.Deserializer = nil
+				})
+
+				It("returns an InvalidTxError", func() {
+					err := verifier.ProcessTx(transferTxID, fakePublicInfo, transferTransaction, memoryLedger)
+					Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "signatures of transaction '1' cannot be verified"}))
+				})
+			})
+		})
+
+		Describe("spending a chaincode owned input", func() {
+			var transferTransaction *token.TokenTransaction
+
+			BeforeEach(func() {
+				transferTransaction = transferOf(1, &token.PlainOutput{Owner: &token.TokenOwner{Raw: []byte("owner-4")}, Type: "TOK1", Quantity: 200})
+			})
+
+			It("succeeds when the chaincode approved the action", func() {
+				approvalKey, err := plain.ApprovalKey(transferTransaction.GetPlainAction())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(approvalKey).To(HavePrefix("\x00tokenApproval\x00"))
+				err = memoryLedger.SetState("escrow", approvalKey, []byte{1})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = verifier.ProcessTx(transferTxID, fakePublicInfo, transferTransaction, memoryLedger)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			Context("when the chaincode did not approve the action", func() {
+				BeforeEach(func() {
+					approvalKey, err := plain.ApprovalKey(transferOf(1).GetPlainAction())
+					Expect(err).NotTo(HaveOccurred())
+					err = memoryLedger.SetState("escrow", approvalKey, []byte{1})
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("returns an InvalidTxError", func() {
+					err := verifier.ProcessTx(transferTxID, fakePublicInfo, transferTransaction, memoryLedger)
+					Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "transfer input with ID \x00tokenOutput\x000\x001\x00 not approved by chaincode escrow"}))
+				})
+			})
+		})
+
+		Describe("redeeming a jointly owned input", func() {
+			var redeemTransaction *token.TokenTransaction
+
+			BeforeEach(func() {
+				redeemTransaction = &token.TokenTransaction{
+					Action: &token.TokenTransaction_PlainAction{
+						PlainAction: &token.PlainTokenAction{
+							Data: &token.PlainTokenAction_PlainRedeem{
+								PlainRedeem: &token.PlainTransfer{
+									Inputs: []*token.TokenId{{TxId: "0", Index: 0}},
+									Outputs: []*token.PlainOutput{
+										{Type: "TOK1", Quantity: 60},
+										{Owner: multiSigOwner, Type: "TOK1", Quantity: 40},
+									},
+								},
+							},
+						},
+					},
+					Signatures: []*token.TokenSignature{{Signer: []byte("owner-3"), Signature: []byte("signature")}},
+				}
+			})
+
+			It("returns the remaining tokens to the joint owners", func() {
+				err := verifier.ProcessTx("r1", fakePublicInfo, redeemTransaction, memoryLedger)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			Context("when the remaining tokens go to the creator", func() {
+				BeforeEach(func() {
+					redeemTransaction.GetPlainAction().GetPlainRedeem().Outputs[1].Owner = &token.TokenOwner{Raw: []byte("owner-1")}
+				})
+
+				It("returns an InvalidTxError", func() {
+					err := verifier.ProcessTx("r1", fakePublicInfo, redeemTransaction, memoryLedger)
+					Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "wrong owner for remaining tokens, should be the MULTI_SIG owner of the inputs"}))
+				})
+			})
+		})
+	})
+
+	Describe("Test ProcessTx PlainExchange with memory ledger", func() {
+		var (
+			fakeDeserializer    *mockid.Deserializer
+			fakeIdentity        *mockid.Identity
+			exchangeTransaction *token.TokenTransaction
+			exchangeTxID        string
+		)
+
+		BeforeEach(func() {
+			fakeIdentity = &mockid.Identity{}
+			fakeDeserializer = &mockid.Deserializer{}
+			fakeDeserializer.DeserializeIdentityReturns(fakeIdentity, nil)
+			verifier.Deserializer = fakeDeserializer
+
+			fakePublicInfo.PublicReturns([]byte("owner-1"))
+			memoryLedger = plain.NewMemoryLedger()
+			err := verifier.ProcessTx(importTxID, fakePublicInfo, importTransaction, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+
+			exchangeTxID = "1"
+			exchangeTransaction = &token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainExchange{
+							PlainExchange: &token.PlainTransfer{
+								Inputs: []*token.TokenId{{TxId: "0", Index: 0}, {TxId: "0", Index: 1}},
+								Outputs: []*token.PlainOutput{
+									{Owner: &token.TokenOwner{Raw: []byte("owner-2")}, Type: "TOK1", Quantity: 100},
+									{Owner: &token.TokenOwner{Raw: []byte("owner-1")}, Type: "TOK1", Quantity: 11},
+									{Owner: &token.TokenOwner{Raw: []byte("owner-1")}, Type: "TOK2", Quantity: 222},
+								},
+							},
+						},
+					},
+				},
+				Signatures: []*token.TokenSignature{{Signer: []byte("owner-2"), Signature: []byte("signature")}},
+			}
+		})
+
+		It("spends the inputs of all the owners and adds the outputs", func() {
+			err := verifier.ProcessTx(exchangeTxID, fakePublicInfo, exchangeTransaction, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+
+			actionBytes, err := proto.Marshal(exchangeTransaction.GetPlainAction())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeIdentity.VerifyCallCount()).To(Equal(1))
+			msg, sig := fakeIdentity.VerifyArgsForCall(0)
+			Expect(msg).To(Equal(actionBytes))
+			Expect(sig).To(Equal([]byte("signature")))
+
+			for i, expected := range exchangeTransaction.GetPlainAction().GetPlainExchange().Outputs {
+				outputBytes, err := memoryLedger.GetState(tokenNamespace, fmt.Sprintf("\x00tokenOutput\x001\x00%d\x00", i))
+				Expect(err).NotTo(HaveOccurred())
+				output := &token.PlainOutput{}
+				err = proto.Unmarshal(outputBytes, output)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(proto.Equal(output, expected)).To(BeTrue())
+			}
+			for _, index := range []string{"0", "1"} {
+				spentMarker, err := memoryLedger.GetState(tokenNamespace, "\x00tokenInput\x000\x00"+index+"\x00")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(bytes.Equal(spentMarker, plain.TokenInputSpentMarker)).To(BeTrue())
+			}
+		})
+
+		Context("when an owner did not sign the exchange", func() {
+			BeforeEach(func() {
+				exchangeTransaction.Signatures = nil
+			})
+
+			It("returns an InvalidTxError", func() {
+				err := verifier.ProcessTx(exchangeTxID, fakePublicInfo, exchangeTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "transfer input with ID \x00tokenOutput\x000\x001\x00 not owned by creator"}))
+			})
+		})
+
+		Context("when a signature is invalid", func() {
+			BeforeEach(func() {
+				fakeIdentity.VerifyReturns(errors.New("bad-signature"))
+			})
+
+			It("returns an InvalidTxError", func() {
+				err := verifier.ProcessTx(exchangeTxID, fakePublicInfo, exchangeTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "invalid signature of signer [0x6f776e65722d32] on transaction '1': bad-signature"}))
+			})
+		})
+
+		Context("when the quantities of a type do not add up", func() {
+			BeforeEach(func() {
+				exchangeTransaction.GetPlainAction().GetPlainExchange().Outputs[2].Quantity = 221
+			})
+
+			It("returns an InvalidTxError", func() {
+				err := verifier.ProcessTx(exchangeTxID, fakePublicInfo, exchangeTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "token sum mismatch in inputs and outputs of type TOK2 for transaction ID 1 (221 vs 222)"}))
+			})
+		})
+
+		Context("when the output quantities of a type overflow", func() {
+			BeforeEach(func() {
+				exchangeTransaction.GetPlainAction().GetPlainExchange().Outputs[1].Quantity = math.MaxUint64 - 10
+			})
+
+			It("returns an InvalidTxError", func() {
+				err := verifier.ProcessTx(exchangeTxID, fakePublicInfo, exchangeTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "output quantity of type TOK1 overflows in transaction ID 1"}))
+			})
+		})
+
+		Context("when the input quantities of a type overflow", func() {
+			BeforeEach(func() {
+				importTransaction.GetPlainAction().GetPlainImport().Outputs = []*token.PlainOutput{
+					{Owner: &token.TokenOwner{Raw: []byte("owner-1")}, Type: "TOK1", Quantity: math.MaxUint64 - 10},
+				}
+				err := verifier.ProcessTx("2", fakePublicInfo, importTransaction, memoryLedger)
+				Expect(err).NotTo(HaveOccurred())
+
+				exchange := exchangeTransaction.GetPlainAction().GetPlainExchange()
+				exchange.Inputs = append(exchange.Inputs, &token.TokenId{TxId: "2", Index: 0})
+				exchange.Outputs[1].Quantity = 0
+			})
+
+			It("returns an InvalidTxError", func() {
+				err := verifier.ProcessTx(exchangeTxID, fakePublicInfo, exchangeTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "input quantity of type TOK1 overflows in transaction ID 1"}))
+			})
+		})
+
+		Context("when an output has a type which is not in the inputs", func() {
+			BeforeEach(func() {
+				exchange := exchangeTransaction.GetPlainAction().GetPlainExchange()
+				exchange.Outputs = append(exchange.Outputs, &token.PlainOutput{Owner: &token.TokenOwner{Raw: []byte("owner-1")}, Type: "TOK3", Quantity: 5})
+			})
+
+			It("returns an InvalidTxError", func() {
+				err := verifier.ProcessTx(exchangeTxID, fakePublicInfo, exchangeTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "no inputs of output type TOK3 for transaction ID 1"}))
+			})
+		})
+
+		Context("when an input is spent twice", func() {
+			BeforeEach(func() {
+				exchange := exchangeTransaction.GetPlainAction().GetPlainExchange()
+				exchange.Inputs = append(exchange.Inputs, &token.TokenId{TxId: "0", Index: 1})
+			})
+
+			It("returns an InvalidTxError", func() {
+				err := verifier.ProcessTx(exchangeTxID, fakePublicInfo, exchangeTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "token input '\x00tokenOutput\x000\x001\x00' spent more than once in transaction ID '1'"}))
+			})
+		})
+
+		Context("when an input has already been spent", func() {
+			BeforeEach(func() {
+				err := verifier.ProcessTx(exchangeTxID, fakePublicInfo, exchangeTransaction, memoryLedger)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns an InvalidTxError", func() {
+				err := verifier.ProcessTx("2", fakePublicInfo, exchangeTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "input with ID \x00tokenOutput\x000\x000\x00 for transfer has already been spent"}))
+			})
+		})
+
+		Context("when an output has no owner", func() {
+			BeforeEach(func() {
+				exchangeTransaction.GetPlainAction().GetPlainExchange().Outputs[0].Owner = nil
+			})
+
+			It("returns an InvalidTxError", func() {
+				err := verifier.ProcessTx(exchangeTxID, fakePublicInfo, exchangeTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "invalid owner in output for txID '1', err 'owner is nil'"}))
+			})
+		})
+
+		Context("when there are no inputs", func() {
+			BeforeEach(func() {
+				exchangeTransaction.GetPlainAction().GetPlainExchange().Inputs = nil
+			})
+
+			It("returns an InvalidTxError", func() {
+				err := verifier.ProcessTx(exchangeTxID, fakePublicInfo, exchangeTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "no inputs in exchange transaction: 1"}))
+			})
+		})
+	})
+
+	Describe("Test ProcessTx with token types with memory ledger", func() {
+		var (
+			redeemTransaction *token.TokenTransaction
+			supplyKey         func(tokenType string) string
+			getSupply         func(tokenType string) *token.TokenSupply
+		)
+
+		BeforeEach(func() {
+			verifier.TokenTypes = map[string]*peer.TokenType{
+				"TOK1": {MaxSupply: 200, Issuer: "Org1MSP"},
+				"TOK2": {MaxSupply: 300, Issuer: "Org1MSP"},
+			}
+			redeemTransaction = &token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainRedeem{
+							PlainRedeem: &token.PlainTransfer{
+								Inputs: []*token.TokenId{{TxId: "0", Index: 0}},
+								Outputs: []*token.PlainOutput{
+									{Type: "TOK1", Quantity: 100},
+									{Owner: &token.TokenOwner{Raw: []byte("owner-1")}, Type: "TOK1", Quantity: 11},
+								},
+							},
+						},
+					},
+				},
+			}
+			supplyKey = func(tokenType string) string {
+				return strings.Join([]string{"", "tokenSupply", tokenType, ""}, "\x00")
+			}
+			getSupply = func(tokenType string) *token.TokenSupply {
+				supplyBytes, err := memoryLedger.GetState(tokenNamespace, supplyKey(tokenType))
+				Expect(err).NotTo(HaveOccurred())
+				supply := &token.TokenSupply{}
+				err = proto.Unmarshal(supplyBytes, supply)
+				Expect(err).NotTo(HaveOccurred())
+				return supply
+			}
+
+			fakePublicInfo.PublicReturns([]byte("owner-1"))
+			memoryLedger = plain.NewMemoryLedger()
+		})
+
+		It("tracks the issued and redeemed quantities of each type", func() {
+			err := verifier.ProcessTx(importTxID, fakePublicInfo, importTransaction, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Equal(getSupply("TOK1"), &token.TokenSupply{Type: "TOK1", Issued: 111})).To(BeTrue())
+			Expect(proto.Equal(getSupply("TOK2"), &token.TokenSupply{Type: "TOK2", Issued: 222})).To(BeTrue())
+
+			err = verifier.ProcessTx("r1", fakePublicInfo, redeemTransaction, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Equal(getSupply("TOK1"), &token.TokenSupply{Type: "TOK1", Issued: 111, Redeemed: 100})).To(BeTrue())
+		})
+
+		It("allows imports up to the maximum supply of outstanding tokens", func() {
+			err := verifier.ProcessTx(importTxID, fakePublicInfo, importTransaction, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+			err = verifier.ProcessTx("r1", fakePublicInfo, redeemTransaction, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+
+			importTransaction.GetPlainAction().GetPlainImport().Outputs = []*token.PlainOutput{
+				{Owner: &token.TokenOwner{Raw: []byte("owner-1")}, Type: "TOK1", Quantity: 189},
+			}
+			err = verifier.ProcessTx("1", fakePublicInfo, importTransaction, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Equal(getSupply("TOK1"), &token.TokenSupply{Type: "TOK1", Issued: 300, Redeemed: 100})).To(BeTrue())
+		})
+
+		Context("when an import exceeds the maximum supply", func() {
+			BeforeEach(func() {
+				err := verifier.ProcessTx(importTxID, fakePublicInfo, importTransaction, memoryLedger)
+				Expect(err).NotTo(HaveOccurred())
+				importTransaction.GetPlainAction().GetPlainImport().Outputs = []*token.PlainOutput{
+					{Owner: &token.TokenOwner{Raw: []byte("owner-1")}, Type: "TOK1", Quantity: 50},
+					{Owner: &token.TokenOwner{Raw: []byte("owner-2")}, Type: "TOK1", Quantity: 40},
+				}
+			})
+
+			It("returns an InvalidTxError", func() {
+				err := verifier.ProcessTx("1", fakePublicInfo, importTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "import of 90 tokens of type TOK1 exceeds the maximum supply 200, with 111 tokens outstanding, for transaction ID 1"}))
+				Expect(proto.Equal(getSupply("TOK1"), &token.TokenSupply{Type: "TOK1", Issued: 111})).To(BeTrue())
+			})
+		})
+
+		Context("when the import alone exceeds the maximum supply", func() {
+			BeforeEach(func() {
+				importTransaction.GetPlainAction().GetPlainImport().Outputs[0].Quantity = 201
+			})
+
+			It("returns an InvalidTxError", func() {
+				err := verifier.ProcessTx(importTxID, fakePublicInfo, importTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "import of 201 tokens of type TOK1 exceeds the maximum supply 200, with 0 tokens outstanding, for transaction ID 0"}))
+			})
+		})
+
+		Context("when the import quantities of a type overflow", func() {
+			BeforeEach(func() {
+				importTransaction.GetPlainAction().GetPlainImport().Outputs[1].Type = "TOK1"
+				importTransaction.GetPlainAction().GetPlainImport().Outputs[1].Quantity = math.MaxUint64
+			})
+
+			It("returns an InvalidTxError", func() {
+				err := verifier.ProcessTx(importTxID, fakePublicInfo, importTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "quantity of type TOK1 overflows in transaction ID 0"}))
+			})
+		})
+
+		Context("when the token type is not defined", func() {
+			BeforeEach(func() {
+				delete(verifier.TokenTypes, "TOK2")
+			})
+
+			It("returns an InvalidTxError", func() {
+				err := verifier.ProcessTx(importTxID, fakePublicInfo, importTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "token type TOK2 is not defined for transaction ID 0"}))
+			})
+		})
+
+		Context("when the supply record cannot be unmarshaled", func() {
+			BeforeEach(func() {
+				err := memoryLedger.SetState(tokenNamespace, supplyKey("TOK1"), []byte("garbage"))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns an InvalidTxError", func() {
+				err := verifier.ProcessTx(importTxID, fakePublicInfo, importTransaction, memoryLedger)
+				Expect(err).To(BeAssignableToTypeOf(&customtx.InvalidTxError{}))
+				Expect(err.Error()).To(ContainSubstring("unmarshaling error"))
+			})
+		})
+
+		Context("when tokens issued before the supply was tracked are redeemed", func() {
+			BeforeEach(func() {
+				verifier.TokenTypes = nil
+				err := verifier.ProcessTx(importTxID, fakePublicInfo, importTransaction, memoryLedger)
+				Expect(err).NotTo(HaveOccurred())
+				verifier.TokenTypes = map[string]*peer.TokenType{
+					"TOK1": {MaxSupply: 200, Issuer: "Org1MSP"},
+				}
+				err = verifier.ProcessTx("r1", fakePublicInfo, redeemTransaction, memoryLedger)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("does not count the redeemed tokens as outstanding", func() {
+				Expect(proto.Equal(getSupply("TOK1"), &token.TokenSupply{Type: "TOK1"})).To(BeTrue())
+
+				importTransaction.GetPlainAction().GetPlainImport().Outputs = []*token.PlainOutput{
+					{Owner: &token.TokenOwner{Raw: []byte("owner-1")}, Type: "TOK1", Quantity: 200},
+				}
+				err := verifier.ProcessTx("1", fakePublicInfo, importTransaction, memoryLedger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(proto.Equal(getSupply("TOK1"), &token.TokenSupply{Type: "TOK1", Issued: 200})).To(BeTrue())
+			})
+
+			It("does not make room for imports beyond the maximum supply", func() {
+				importTransaction.GetPlainAction().GetPlainImport().Outputs = []*token.PlainOutput{
+					{Owner: &token.TokenOwner{Raw: []byte("owner-1")}, Type: "TOK1", Quantity: 200},
+				}
+				err := verifier.ProcessTx("1", fakePublicInfo, importTransaction, memoryLedger)
+				Expect(err).NotTo(HaveOccurred())
+
+				importTransaction.GetPlainAction().GetPlainImport().Outputs = []*token.PlainOutput{
+					{Owner: &token.TokenOwner{Raw: []byte("owner-1")}, Type: "TOK1", Quantity: 100},
+				}
+				err = verifier.ProcessTx("2", fakePublicInfo, importTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "import of 100 tokens of type TOK1 exceeds the maximum supply 200, with 200 tokens outstanding, for transaction ID 2"}))
+			})
+		})
+	})
 })
 
 type TestTokenOwnerValidator struct {